@@ -3,6 +3,8 @@ package main
 import (
 	"log"
 	"os"
+	"strconv"
+	"time"
 
 	"github.com/joho/godotenv"
 )
@@ -12,6 +14,18 @@ type Config struct {
 	JWT_SECRET   string
 	JWT_ISSUER   string
 	JWT_AUDIENCE string
+
+	// access tokens are short lived, clients renew them with the refresh token
+	ACCESS_TOKEN_TTL  time.Duration
+	REFRESH_TOKEN_TTL time.Duration
+
+	// fake payment gateway behaviour
+	PAYMENT_SUCCESS_RATE   float64
+	PAYMENT_LATENCY        time.Duration
+	PAYMENT_WEBHOOK_SECRET string
+
+	// how long responses are kept for requests sent with an Idempotency-Key
+	IDEMPOTENCY_KEY_TTL time.Duration
 }
 
 func LoadConfig() Config {
@@ -42,5 +56,35 @@ func LoadConfig() Config {
 		config.JWT_AUDIENCE = "jwt_audience"
 	}
 
+	config.ACCESS_TOKEN_TTL = 15 * time.Minute
+	if ttl, err := time.ParseDuration(os.Getenv("ACCESS_TOKEN_TTL")); err == nil {
+		config.ACCESS_TOKEN_TTL = ttl
+	}
+
+	config.REFRESH_TOKEN_TTL = 7 * 24 * time.Hour
+	if ttl, err := time.ParseDuration(os.Getenv("REFRESH_TOKEN_TTL")); err == nil {
+		config.REFRESH_TOKEN_TTL = ttl
+	}
+
+	config.PAYMENT_SUCCESS_RATE = 1
+	if rate, err := strconv.ParseFloat(os.Getenv("PAYMENT_SUCCESS_RATE"), 64); err == nil {
+		config.PAYMENT_SUCCESS_RATE = rate
+	}
+
+	config.PAYMENT_LATENCY = 2 * time.Second
+	if latency, err := time.ParseDuration(os.Getenv("PAYMENT_LATENCY")); err == nil {
+		config.PAYMENT_LATENCY = latency
+	}
+
+	config.PAYMENT_WEBHOOK_SECRET = os.Getenv("PAYMENT_WEBHOOK_SECRET")
+	if config.PAYMENT_WEBHOOK_SECRET == "" {
+		config.PAYMENT_WEBHOOK_SECRET = "webhook_secret"
+	}
+
+	config.IDEMPOTENCY_KEY_TTL = 24 * time.Hour
+	if ttl, err := time.ParseDuration(os.Getenv("IDEMPOTENCY_KEY_TTL")); err == nil {
+		config.IDEMPOTENCY_KEY_TTL = ttl
+	}
+
 	return config
 }
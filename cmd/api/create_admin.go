@@ -0,0 +1,35 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/mohits-git/food-ordering-system/internal/domain"
+	"github.com/mohits-git/food-ordering-system/internal/ports"
+)
+
+// createAdmin bootstraps an admin account, admins cannot register through the
+// API and further admins can be created by an existing one.
+//
+//	go run ./cmd/api create-admin -name Admin -email admin@example.com -password secret
+func createAdmin(ctx context.Context, userService ports.UserService, args []string) {
+	flags := flag.NewFlagSet("create-admin", flag.ExitOnError)
+	name := flags.String("name", "Admin", "name of the admin")
+	email := flags.String("email", "", "email of the admin")
+	password := flags.String("password", os.Getenv("ADMIN_PASSWORD"), "password of the admin, defaults to ADMIN_PASSWORD")
+	flags.Parse(args)
+
+	if *email == "" || *password == "" {
+		flags.Usage()
+		os.Exit(2)
+	}
+
+	id, err := userService.CreateAdmin(ctx, domain.NewUser(0, *name, *email, *password, domain.ADMIN))
+	if err != nil {
+		log.Fatal("Failed to create admin: ", err)
+	}
+	fmt.Printf("Admin created with id %d\n", id)
+}
@@ -5,8 +5,10 @@ import (
 	"database/sql"
 	"log"
 	"net/http"
+	"os"
 
 	"github.com/mohits-git/food-ordering-system/internal/adapters/bcrypt"
+	"github.com/mohits-git/food-ordering-system/internal/adapters/fakegateway"
 	"github.com/mohits-git/food-ordering-system/internal/adapters/http/handlers"
 	"github.com/mohits-git/food-ordering-system/internal/adapters/http/router"
 	"github.com/mohits-git/food-ordering-system/internal/adapters/jwttoken"
@@ -28,23 +30,44 @@ func main() {
 		config.JWT_SECRET,
 		config.JWT_ISSUER,
 		config.JWT_AUDIENCE,
+		config.ACCESS_TOKEN_TTL,
 	)
 	bcryptHasher := bcrypt.NewBcryptPasswordHasher(12)
+	paymentGateway := fakegateway.NewFakeGateway(config.PAYMENT_SUCCESS_RATE, config.PAYMENT_LATENCY, config.PAYMENT_WEBHOOK_SECRET)
 
 	// Initialize repositories
 	userRepo := sqlite.NewUserRepository(db)
 	restaurantRepo := sqlite.NewRestaurantRepository(db)
 	menuItemRepo := sqlite.NewMenuItemRepository(db)
+	menuRepo := sqlite.NewMenuRepository(db)
 	orderRepo := sqlite.NewOrderRepository(db)
 	invoiceRepo := sqlite.NewInvoiceRepository(db)
+	taxRuleRepo := sqlite.NewTaxRuleRepository(db)
+	paymentAttemptRepo := sqlite.NewPaymentAttemptRepository(db)
+	paymentEventRepo := sqlite.NewPaymentEventRepository(db)
+	paymentRepo := sqlite.NewPaymentRepository(db)
+	refundRepo := sqlite.NewRefundRepository(db)
+	idempotencyRepo := sqlite.NewIdempotencyRepository(db)
+	refreshTokenRepo := sqlite.NewRefreshTokenRepository(db)
+	revokedTokenRepo := sqlite.NewRevokedTokenRepository(db)
+	restaurantMemberRepo := sqlite.NewRestaurantMemberRepository(db)
 
 	// Initialize services
-	userService := services.NewUserService(userRepo, bcryptHasher)
-	authService := services.NewAuthenticationService(userRepo, tokenProvider, bcryptHasher)
-	restaurantService := services.NewRestaurantService(restaurantRepo)
-	menuItemService := services.NewMenuItemsService(menuItemRepo, restaurantRepo)
-	orderService := services.NewOrderService(orderRepo, menuItemRepo)
-	invoiceService := services.NewInvoiceService(invoiceRepo, orderRepo, menuItemRepo)
+	authorizer := services.NewAuthorizer(restaurantRepo, restaurantMemberRepo)
+	userService := services.NewUserService(userRepo, refreshTokenRepo, bcryptHasher, authorizer)
+	authService := services.NewAuthenticationService(userRepo, refreshTokenRepo, revokedTokenRepo, tokenProvider, bcryptHasher, config.REFRESH_TOKEN_TTL)
+	restaurantService := services.NewRestaurantService(restaurantRepo, restaurantMemberRepo, userRepo, taxRuleRepo, authorizer)
+	menuItemService := services.NewMenuItemsService(menuItemRepo, menuRepo, authorizer)
+	orderService := services.NewOrderService(orderRepo, restaurantRepo, menuItemRepo, menuRepo, invoiceRepo, authorizer)
+	taxCalculator := services.NewRuleTaxCalculator(taxRuleRepo)
+	invoiceService := services.NewInvoiceService(invoiceRepo, orderRepo, menuItemRepo, taxCalculator, paymentAttemptRepo, paymentRepo, refundRepo, paymentGateway, authorizer)
+	paymentWebhookService := services.NewPaymentWebhookService(invoiceRepo, orderRepo, paymentAttemptRepo, paymentRepo, paymentEventRepo, paymentGateway)
+	idempotencyService := services.NewIdempotencyService(idempotencyRepo, config.IDEMPOTENCY_KEY_TTL)
+
+	if len(os.Args) > 1 && os.Args[1] == "create-admin" {
+		createAdmin(ctx, userService, os.Args[2:])
+		return
+	}
 
 	// Initialize handlers
 	userHandler := handlers.NewUserHandler(userService)
@@ -53,19 +76,25 @@ func main() {
 	menuItemHandler := handlers.NewMenuItemHandler(menuItemService)
 	orderHandler := handlers.NewOrdersHandler(orderService)
 	invoiceHandler := handlers.NewInvoiceHandler(invoiceService)
+	paymentWebhookHandler := handlers.NewPaymentWebhookHandler(paymentWebhookService)
+	adminHandler := handlers.NewAdminHandler(userService, orderService, invoiceService)
 
 	// middlewares
-	authMiddleware := handlers.NewAuthMiddleware(tokenProvider)
+	authMiddleware := handlers.NewAuthMiddleware(tokenProvider, authService)
+	idempotencyMiddleware := handlers.NewIdempotencyMiddleware(idempotencyService)
 
 	// Initialize router
 	mux := router.NewRouter(
 		authMiddleware,
+		idempotencyMiddleware,
 		userHandler,
 		authHandler,
 		restaurantHandler,
 		menuItemHandler,
 		orderHandler,
 		invoiceHandler,
+		paymentWebhookHandler,
+		adminHandler,
 	)
 
 	log.Println("Starting server on :8080")
@@ -5,7 +5,9 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"net/url"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/mohits-git/food-ordering-system/internal/adapters/http/dtos"
@@ -26,10 +28,45 @@ func NewAPIClient(baseUrl string) *APIClient {
 	}
 }
 
+// GetRestaurants fetches every restaurant, page by page.
 func (c *APIClient) GetRestaurants() ([]domain.Restaurant, error) {
-	resp, err := c.client.Get(c.baseUrl + "/api/restaurants")
+	restaurants := []domain.Restaurant{}
+	cursor := 0
+	for {
+		page, nextCursor, err := c.SearchRestaurants("", "", false, "", cursor)
+		if err != nil {
+			return nil, err
+		}
+		restaurants = append(restaurants, page...)
+		if nextCursor == 0 {
+			return restaurants, nil
+		}
+		cursor = nextCursor
+	}
+}
+
+// SearchRestaurants searches the restaurants by name or dish, openNow keeps
+// the ones taking orders.
+func (c *APIClient) SearchRestaurants(query, cuisine string, openNow bool, sort string, cursor int) ([]domain.Restaurant, int, error) {
+	params := url.Values{}
+	if query != "" {
+		params.Set("q", query)
+	}
+	if cuisine != "" {
+		params.Set("cuisine", cuisine)
+	}
+	if openNow {
+		params.Set("open_now", "true")
+	}
+	if sort != "" {
+		params.Set("sort", sort)
+	}
+	if cursor > 0 {
+		params.Set("cursor", strconv.Itoa(cursor))
+	}
+	resp, err := c.client.Get(c.baseUrl + "/api/restaurants?" + params.Encode())
 	if err != nil {
-		return nil, err
+		return nil, 0, err
 	}
 
 	defer resp.Body.Close()
@@ -37,21 +74,21 @@ func (c *APIClient) GetRestaurants() ([]domain.Restaurant, error) {
 	if resp.StatusCode != http.StatusOK {
 		errResp, err := decodeError(resp.Body)
 		if err != nil {
-			return nil, errors.New("unknown error occurred while doing request: " + err.Error())
+			return nil, 0, errors.New("unknown error occurred while doing request: " + err.Error())
 		}
-		return nil, errors.New(errResp.Message)
+		return nil, 0, errors.New(errResp.Message)
 	}
 
 	response, err := decodeResponse[dtos.GetRestaurantsResponse](resp.Body)
 	if err != nil {
-		return nil, fmt.Errorf("error decoding response %w", err)
+		return nil, 0, fmt.Errorf("error decoding response %w", err)
 	}
 
 	restaurants := []domain.Restaurant{}
 	for _, restaurant := range response.Restaurants {
 		restaurants = append(restaurants, dtos.NewRestaurant(restaurant))
 	}
-	return restaurants, nil
+	return restaurants, response.NextCursor, nil
 }
 
 func (c *APIClient) PostRestaurants(name string, token string) (int, error) {
@@ -92,6 +129,109 @@ func (c *APIClient) PostRestaurants(name string, token string) (int, error) {
 	return response.ID, nil
 }
 
+func (c *APIClient) GetRestaurant(restaurantId int) (*domain.Restaurant, error) {
+	restaurantIdStr := strconv.Itoa(restaurantId)
+	resp, err := c.client.Get(c.baseUrl + "/api/restaurants/" + restaurantIdStr)
+	if err != nil {
+		return nil, err
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		errResp, err := decodeError(resp.Body)
+		if err != nil {
+			return nil, errors.New("unknown error occurred while doing request: " + err.Error())
+		}
+		return nil, errors.New(errResp.Message)
+	}
+
+	response, err := decodeResponse[dtos.RestaurantDTO](resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("error decoding response %w", err)
+	}
+
+	restaurant := dtos.NewRestaurant(response)
+	return &restaurant, nil
+}
+
+// PutRestaurant replaces the profile of a restaurant with the one of
+// restaurant.
+func (c *APIClient) PutRestaurant(restaurant domain.Restaurant, token string) error {
+	buf := bytes.NewBuffer(nil)
+	updateReqDto := dtos.UpdateRestaurantRequest{
+		Name:         restaurant.Name,
+		Address:      restaurant.Address,
+		Phone:        restaurant.Phone,
+		Cuisines:     restaurant.Cuisines,
+		OpeningHours: dtos.NewRestaurantDTO(restaurant).OpeningHours,
+		Closures:     restaurant.Closures,
+	}
+	if err := encodeJson(buf, updateReqDto); err != nil {
+		return err
+	}
+
+	restaurantIdStr := strconv.Itoa(restaurant.ID)
+	req, err := http.NewRequest("PUT", c.baseUrl+"/api/restaurants/"+restaurantIdStr, buf)
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+token)
+	resp, err := c.client.Do(req)
+
+	if err != nil {
+		return err
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		errResp, err := decodeError(resp.Body)
+		if err != nil {
+			return errors.New("unknown error occurred while doing request: " + err.Error())
+		}
+		return errors.New(errResp.Message)
+	}
+
+	return nil
+}
+
+func (c *APIClient) PatchRestaurantPause(restaurantId int, paused bool, token string) error {
+	buf := bytes.NewBuffer(nil)
+	pauseReqDto := dtos.PauseOrdersRequest{Paused: &paused}
+	if err := encodeJson(buf, pauseReqDto); err != nil {
+		return err
+	}
+
+	restaurantIdStr := strconv.Itoa(restaurantId)
+	req, err := http.NewRequest("PATCH", c.baseUrl+"/api/restaurants/"+restaurantIdStr+"/pause", buf)
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+token)
+	resp, err := c.client.Do(req)
+
+	if err != nil {
+		return err
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		errResp, err := decodeError(resp.Body)
+		if err != nil {
+			return errors.New("unknown error occurred while doing request: " + err.Error())
+		}
+		return errors.New(errResp.Message)
+	}
+
+	return nil
+}
+
 func (c *APIClient) GetUserById(id int) (*domain.User, error) {
 	idStr := strconv.Itoa(id)
 	req, err := http.NewRequest("GET", c.baseUrl+"/api/users/"+idStr, nil)
@@ -128,23 +268,36 @@ func (c *APIClient) GetUserById(id int) (*domain.User, error) {
 	return &user, nil
 }
 
-func (c *APIClient) PostUser(name, email, password, role string) (int, error) {
+// PostUser registers a new customer or owner, owners have to be approved by
+// an admin before they can log in.
+func (c *APIClient) PostUser(name, email, password, role string) (*dtos.CreateUserResponse, error) {
+	return c.postUser("/api/users", dtos.CreateUserRequest{Name: name, Email: email, Password: password, Role: role}, "")
+}
+
+// PostAdminUser creates a user with any role, for admins.
+func (c *APIClient) PostAdminUser(name, email, password, role string, token string) (*dtos.CreateUserResponse, error) {
+	return c.postUser("/api/admin/users", dtos.CreateUserRequest{Name: name, Email: email, Password: password, Role: role}, token)
+}
+
+func (c *APIClient) postUser(path string, body dtos.CreateUserRequest, token string) (*dtos.CreateUserResponse, error) {
 	buf := bytes.NewBuffer(nil)
-	createReqDto := dtos.CreateUserRequest{Name: name, Email: email, Password: password, Role: role}
-	if err := encodeJson(buf, createReqDto); err != nil {
-		return 0, err
+	if err := encodeJson(buf, body); err != nil {
+		return nil, err
 	}
 
-	req, err := http.NewRequest("POST", c.baseUrl+"/api/users", buf)
+	req, err := http.NewRequest("POST", c.baseUrl+path, buf)
 	if err != nil {
-		return 0, err
+		return nil, err
 	}
 
 	req.Header.Set("Content-Type", "application/json")
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
 	resp, err := c.client.Do(req)
 
 	if err != nil {
-		return 0, err
+		return nil, err
 	}
 
 	defer resp.Body.Close()
@@ -152,36 +305,46 @@ func (c *APIClient) PostUser(name, email, password, role string) (int, error) {
 	if resp.StatusCode != http.StatusCreated {
 		errResp, err := decodeError(resp.Body)
 		if err != nil {
-			return 0, errors.New("unknown error occurred while doing request: " + err.Error())
+			return nil, errors.New("unknown error occurred while doing request: " + err.Error())
 		}
-		return 0, errors.New(errResp.Message)
+		return nil, errors.New(errResp.Message)
 	}
 
 	response, err := decodeResponse[dtos.CreateUserResponse](resp.Body)
 	if err != nil {
-		return 0, err
+		return nil, err
 	}
 
-	return response.UserID, nil
+	return &response, nil
 }
 
-func (c *APIClient) PostLogin(email, password string) (string, error) {
-	buf := bytes.NewBuffer(nil)
+func (c *APIClient) PostLogin(email, password string) (*dtos.LoginResponse, error) {
 	loginReqDto := dtos.LoginRequest{Email: email, Password: password}
-	if err := encodeJson(buf, loginReqDto); err != nil {
-		return "", err
+	return c.postAuthTokens("/api/auth/login", loginReqDto)
+}
+
+// PostRefresh exchanges the refresh token for a new access and refresh token.
+func (c *APIClient) PostRefresh(refreshToken string) (*dtos.LoginResponse, error) {
+	refreshReqDto := dtos.RefreshRequest{RefreshToken: refreshToken}
+	return c.postAuthTokens("/api/auth/refresh", refreshReqDto)
+}
+
+func (c *APIClient) postAuthTokens(path string, body any) (*dtos.LoginResponse, error) {
+	buf := bytes.NewBuffer(nil)
+	if err := encodeJson(buf, body); err != nil {
+		return nil, err
 	}
 
-	req, err := http.NewRequest("POST", c.baseUrl+"/api/auth/login", buf)
+	req, err := http.NewRequest("POST", c.baseUrl+path, buf)
 	if err != nil {
-		return "", err
+		return nil, err
 	}
 
 	req.Header.Set("Content-Type", "application/json")
 	resp, err := c.client.Do(req)
 
 	if err != nil {
-		return "", err
+		return nil, err
 	}
 
 	defer resp.Body.Close()
@@ -189,17 +352,17 @@ func (c *APIClient) PostLogin(email, password string) (string, error) {
 	if resp.StatusCode != http.StatusOK {
 		errResp, err := decodeError(resp.Body)
 		if err != nil {
-			return "", errors.New("unknown error occurred while doing request: " + err.Error())
+			return nil, errors.New("unknown error occurred while doing request: " + err.Error())
 		}
-		return "", errors.New(errResp.Message)
+		return nil, errors.New(errResp.Message)
 	}
 
 	response, err := decodeResponse[dtos.LoginResponse](resp.Body)
 	if err != nil {
-		return "", err
+		return nil, err
 	}
 
-	return response.Token, nil
+	return &response, nil
 }
 
 func (c *APIClient) PostLogout(token string) error {
@@ -257,20 +420,54 @@ func (c *APIClient) GetMenuItems(restaurantId int) ([]domain.MenuItem, error) {
 
 	menuItems := []domain.MenuItem{}
 	for _, item := range response.Items {
-		menuItems = append(menuItems, domain.NewMenuItem(
+		menuItem := domain.NewMenuItem(
 			item.ID,
 			item.Name,
-			item.Price,
+			item.Price.ToDomain(),
 			item.Available,
 			restaurantId,
-		))
+		)
+		menuItem.Category = item.Category
+		menuItem.Description = item.Description
+		menuItem.ImageURL = item.ImageURL
+		menuItem.SortOrder = item.SortOrder
+		if item.Stock != nil {
+			menuItem.TrackStock = true
+			menuItem.Stock = *item.Stock
+		}
+		menuItem.ModifierGroups = toDomainModifierGroups(item.ModifierGroups)
+		menuItems = append(menuItems, menuItem)
 	}
 	return menuItems, nil
 }
 
-func (c *APIClient) PostMenuItem(restaurantId int, name string, price float64, available bool, token string) (int, error) {
+func toDomainModifierGroups(groups []dtos.ModifierGroupDTO) []domain.ModifierGroup {
+	var modifierGroups []domain.ModifierGroup
+	for _, g := range groups {
+		group := domain.ModifierGroup{ID: g.ID, Name: g.Name, MinSelect: g.MinSelect, MaxSelect: g.MaxSelect}
+		for _, o := range g.Options {
+			group.Options = append(group.Options, domain.ModifierOption{ID: o.ID, GroupID: g.ID, Name: o.Name, PriceDelta: o.PriceDelta.ToDomain()})
+		}
+		modifierGroups = append(modifierGroups, group)
+	}
+	return modifierGroups
+}
+
+func newModifierGroupDTOs(groups []domain.ModifierGroup) []dtos.ModifierGroupDTO {
+	var groupDTOs []dtos.ModifierGroupDTO
+	for _, g := range groups {
+		group := dtos.ModifierGroupDTO{ID: g.ID, Name: g.Name, MinSelect: g.MinSelect, MaxSelect: g.MaxSelect}
+		for _, o := range g.Options {
+			group.Options = append(group.Options, dtos.ModifierOptionDTO{ID: o.ID, Name: o.Name, PriceDelta: dtos.NewMoneyDTO(o.PriceDelta)})
+		}
+		groupDTOs = append(groupDTOs, group)
+	}
+	return groupDTOs
+}
+
+func (c *APIClient) PostMenuItem(restaurantId int, name string, price domain.Money, available bool, category string, token string) (int, error) {
 	buf := bytes.NewBuffer(nil)
-	createReqDto := dtos.AddMenuItemRequest{Name: name, Price: price, Available: available}
+	createReqDto := dtos.AddMenuItemRequest{Name: name, Price: dtos.NewMoneyDTO(price), Available: available, Category: category}
 	if err := encodeJson(buf, createReqDto); err != nil {
 		return 0, err
 	}
@@ -307,17 +504,23 @@ func (c *APIClient) PostMenuItem(restaurantId int, name string, price float64, a
 	return response.ID, nil
 }
 
-func (c *APIClient) PatchMenuItemAvailability(menuItemId int, available bool, token string) error {
+func (c *APIClient) PostMenu(menu domain.Menu, token string) (int, error) {
 	buf := bytes.NewBuffer(nil)
-	updateReqDto := dtos.UpdateMenuItemAvailabilityRequest{Available: available}
-	if err := encodeJson(buf, updateReqDto); err != nil {
-		return err
+	createReqDto := dtos.MenuRequest{Name: menu.Name}
+	for _, s := range menu.Schedules {
+		createReqDto.Schedules = append(createReqDto.Schedules, dtos.MenuScheduleDTO{Start: domain.FormatTimeOfDay(s.Start), End: domain.FormatTimeOfDay(s.End)})
+	}
+	for _, c := range menu.Categories {
+		createReqDto.Categories = append(createReqDto.Categories, dtos.MenuCategoryRequest{Name: c.Name, ItemIDs: c.ItemIDs})
+	}
+	if err := encodeJson(buf, createReqDto); err != nil {
+		return 0, err
 	}
 
-	menuItemIdStr := strconv.Itoa(menuItemId)
-	req, err := http.NewRequest("PATCH", c.baseUrl+"/api/items/"+menuItemIdStr, buf)
+	restaurantIdStr := strconv.Itoa(menu.RestaurantID)
+	req, err := http.NewRequest("POST", c.baseUrl+"/api/restaurants/"+restaurantIdStr+"/menus", buf)
 	if err != nil {
-		return err
+		return 0, err
 	}
 
 	req.Header.Set("Content-Type", "application/json")
@@ -325,33 +528,46 @@ func (c *APIClient) PatchMenuItemAvailability(menuItemId int, available bool, to
 	resp, err := c.client.Do(req)
 
 	if err != nil {
-		return err
+		return 0, err
 	}
 
 	defer resp.Body.Close()
 
-	if resp.StatusCode != http.StatusOK {
+	if resp.StatusCode != http.StatusCreated {
 		errResp, err := decodeError(resp.Body)
 		if err != nil {
-			return errors.New("unknown error occurred while doing request: " + err.Error())
+			return 0, errors.New("unknown error occurred while doing request: " + err.Error())
 		}
-		return errors.New(errResp.Message)
+		return 0, errors.New(errResp.Message)
 	}
 
-	return nil
+	response, err := decodeResponse[dtos.AddMenuResponse](resp.Body)
+	if err != nil {
+		return 0, err
+	}
+
+	return response.ID, nil
 }
 
-func (c *APIClient) GetOrderById(orderId int, token string) (*domain.Order, error) {
-	orderIdStr := strconv.Itoa(orderId)
-	req, err := http.NewRequest("GET", c.baseUrl+"/api/orders/"+orderIdStr, nil)
+func (c *APIClient) PatchMenuItemAvailability(menuItemId int, available bool, token string) error {
+	buf := bytes.NewBuffer(nil)
+	updateReqDto := dtos.UpdateMenuItemAvailabilityRequest{Available: available}
+	if err := encodeJson(buf, updateReqDto); err != nil {
+		return err
+	}
+
+	menuItemIdStr := strconv.Itoa(menuItemId)
+	req, err := http.NewRequest("PATCH", c.baseUrl+"/api/items/"+menuItemIdStr, buf)
 	if err != nil {
-		return nil, err
+		return err
 	}
+
+	req.Header.Set("Content-Type", "application/json")
 	req.Header.Set("Authorization", "Bearer "+token)
 	resp, err := c.client.Do(req)
 
 	if err != nil {
-		return nil, err
+		return err
 	}
 
 	defer resp.Body.Close()
@@ -359,54 +575,27 @@ func (c *APIClient) GetOrderById(orderId int, token string) (*domain.Order, erro
 	if resp.StatusCode != http.StatusOK {
 		errResp, err := decodeError(resp.Body)
 		if err != nil {
-			return nil, errors.New("unknown error occurred while doing request: " + err.Error())
+			return errors.New("unknown error occurred while doing request: " + err.Error())
 		}
-		return nil, errors.New(errResp.Message)
-	}
-
-	response, err := decodeResponse[dtos.GetOrderByIdResponse](resp.Body)
-	if err != nil {
-		return nil, err
-	}
-
-	orderItems := []domain.OrderItem{}
-	for _, item := range response.OrderItems {
-		orderItems = append(orderItems, domain.OrderItem{
-			MenuItemID: item.MenuItemID,
-			Quantity:   item.Quantity,
-		})
-	}
-
-	order := domain.Order{
-		ID:           response.ID,
-		CustomerID:   response.CustomerID,
-		RestaurantID: response.RestaurantID,
-		OrderItems:   orderItems,
+		return errors.New(errResp.Message)
 	}
 
-	return &order, nil
+	return nil
 }
 
-func (c *APIClient) PostOrder(restaurantId int, orderItems []domain.OrderItem, token string) (int, error) {
+// PatchMenuItemStock sets the stock of a menu item, a nil stock stops
+// tracking it.
+func (c *APIClient) PatchMenuItemStock(menuItemId int, stock *int, token string) error {
 	buf := bytes.NewBuffer(nil)
-	createReqDto := dtos.CreateOrderRequest{
-		RestaurantID: restaurantId,
-		OrderItems:   []dtos.OrderItemsDTO{},
-	}
-	for _, item := range orderItems {
-		createReqDto.OrderItems = append(createReqDto.OrderItems, dtos.OrderItemsDTO{
-			MenuItemID: item.MenuItemID,
-			Quantity:   item.Quantity,
-		})
-	}
-
-	if err := encodeJson(buf, createReqDto); err != nil {
-		return 0, err
+	updateReqDto := dtos.UpdateMenuItemStockRequest{Stock: stock}
+	if err := encodeJson(buf, updateReqDto); err != nil {
+		return err
 	}
 
-	req, err := http.NewRequest("POST", c.baseUrl+"/api/orders", buf)
+	menuItemIdStr := strconv.Itoa(menuItemId)
+	req, err := http.NewRequest("PATCH", c.baseUrl+"/api/items/"+menuItemIdStr+"/stock", buf)
 	if err != nil {
-		return 0, err
+		return err
 	}
 
 	req.Header.Set("Content-Type", "application/json")
@@ -414,39 +603,41 @@ func (c *APIClient) PostOrder(restaurantId int, orderItems []domain.OrderItem, t
 	resp, err := c.client.Do(req)
 
 	if err != nil {
-		return 0, err
+		return err
 	}
 
 	defer resp.Body.Close()
 
-	if resp.StatusCode != http.StatusCreated {
+	if resp.StatusCode != http.StatusOK {
 		errResp, err := decodeError(resp.Body)
 		if err != nil {
-			return 0, errors.New("unknown error occurred while doing request: " + err.Error())
+			return errors.New("unknown error occurred while doing request: " + err.Error())
 		}
-		return 0, errors.New(errResp.Message)
-	}
-
-	response, err := decodeResponse[dtos.CreateOrderResponse](resp.Body)
-	if err != nil {
-		return 0, err
+		return errors.New(errResp.Message)
 	}
 
-	return response.ID, nil
+	return nil
 }
 
-func (c *APIClient) PostItemToOrder(orderId, menuItemId, quantity int, token string) error {
+// PutMenuItem replaces the details of a menu item with the ones of item.
+func (c *APIClient) PutMenuItem(item domain.MenuItem, token string) error {
 	buf := bytes.NewBuffer(nil)
-	addReqDto := dtos.AddOrderItemRequest{
-		MenuItemID: menuItemId,
-		Quantity:   quantity,
+	updateReqDto := dtos.UpdateMenuItemRequest{
+		Name:           item.Name,
+		Price:          dtos.NewMoneyDTO(item.Price),
+		Available:      item.Available,
+		Category:       item.Category,
+		Description:    item.Description,
+		ImageURL:       item.ImageURL,
+		SortOrder:      item.SortOrder,
+		ModifierGroups: newModifierGroupDTOs(item.ModifierGroups),
 	}
-	if err := encodeJson(buf, addReqDto); err != nil {
+	if err := encodeJson(buf, updateReqDto); err != nil {
 		return err
 	}
 
-	orderIdStr := strconv.Itoa(orderId)
-	req, err := http.NewRequest("POST", c.baseUrl+"/api/orders/"+orderIdStr+"/items", buf)
+	menuItemIdStr := strconv.Itoa(item.ID)
+	req, err := http.NewRequest("PUT", c.baseUrl+"/api/items/"+menuItemIdStr, buf)
 	if err != nil {
 		return err
 	}
@@ -461,7 +652,7 @@ func (c *APIClient) PostItemToOrder(orderId, menuItemId, quantity int, token str
 
 	defer resp.Body.Close()
 
-	if resp.StatusCode != http.StatusCreated {
+	if resp.StatusCode != http.StatusOK {
 		errResp, err := decodeError(resp.Body)
 		if err != nil {
 			return errors.New("unknown error occurred while doing request: " + err.Error())
@@ -472,9 +663,302 @@ func (c *APIClient) PostItemToOrder(orderId, menuItemId, quantity int, token str
 	return nil
 }
 
-func (c *APIClient) PostCreateInvoice(orderId int, token string) (int, error) {
-	orderIdStr := strconv.Itoa(orderId)
-	req, err := http.NewRequest("POST", c.baseUrl+"/api/orders/"+orderIdStr+"/invoices", nil)
+func (c *APIClient) DeleteMenuItem(menuItemId int, token string) error {
+	menuItemIdStr := strconv.Itoa(menuItemId)
+	req, err := http.NewRequest("DELETE", c.baseUrl+"/api/items/"+menuItemIdStr, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "Bearer "+token)
+	resp, err := c.client.Do(req)
+
+	if err != nil {
+		return err
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		errResp, err := decodeError(resp.Body)
+		if err != nil {
+			return errors.New("unknown error occurred while doing request: " + err.Error())
+		}
+		return errors.New(errResp.Message)
+	}
+
+	return nil
+}
+
+func (c *APIClient) GetOrderById(orderId int, token string) (*domain.Order, error) {
+	orderIdStr := strconv.Itoa(orderId)
+	req, err := http.NewRequest("GET", c.baseUrl+"/api/orders/"+orderIdStr, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Authorization", "Bearer "+token)
+	resp, err := c.client.Do(req)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		errResp, err := decodeError(resp.Body)
+		if err != nil {
+			return nil, errors.New("unknown error occurred while doing request: " + err.Error())
+		}
+		return nil, errors.New(errResp.Message)
+	}
+
+	response, err := decodeResponse[dtos.GetOrderByIdResponse](resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	orderItems := []domain.OrderItem{}
+	for _, item := range response.OrderItems {
+		orderItems = append(orderItems, domain.OrderItem{
+			MenuItemID: item.MenuItemID,
+			Quantity:   item.Quantity,
+		})
+	}
+
+	order := domain.Order{
+		ID:           response.ID,
+		CustomerID:   response.CustomerID,
+		RestaurantID: response.RestaurantID,
+		Status:       domain.OrderStatus(response.Status),
+		OrderItems:   orderItems,
+	}
+
+	return &order, nil
+}
+
+func (c *APIClient) GetRestaurantOrders(restaurantId int, statuses []string, token string) ([]domain.Order, error) {
+	restaurantIdStr := strconv.Itoa(restaurantId)
+	reqUrl := c.baseUrl + "/api/restaurants/" + restaurantIdStr + "/orders"
+	if len(statuses) > 0 {
+		reqUrl += "?status=" + strings.Join(statuses, ",")
+	}
+	req, err := http.NewRequest("GET", reqUrl, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Authorization", "Bearer "+token)
+	resp, err := c.client.Do(req)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		errResp, err := decodeError(resp.Body)
+		if err != nil {
+			return nil, errors.New("unknown error occurred while doing request: " + err.Error())
+		}
+		return nil, errors.New(errResp.Message)
+	}
+
+	response, err := decodeResponse[dtos.GetRestaurantOrdersResponse](resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	orders := []domain.Order{}
+	for _, o := range response.Orders {
+		orders = append(orders, toDomainOrder(o))
+	}
+	return orders, nil
+}
+
+func (c *APIClient) GetMyOrders(restaurantId int, statuses []string, cursor int, token string) ([]domain.Order, int, error) {
+	query := url.Values{}
+	if restaurantId > 0 {
+		query.Set("restaurant_id", strconv.Itoa(restaurantId))
+	}
+	if len(statuses) > 0 {
+		query.Set("status", strings.Join(statuses, ","))
+	}
+	if cursor > 0 {
+		query.Set("cursor", strconv.Itoa(cursor))
+	}
+	req, err := http.NewRequest("GET", c.baseUrl+"/api/orders?"+query.Encode(), nil)
+	if err != nil {
+		return nil, 0, err
+	}
+	req.Header.Set("Authorization", "Bearer "+token)
+	resp, err := c.client.Do(req)
+
+	if err != nil {
+		return nil, 0, err
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		errResp, err := decodeError(resp.Body)
+		if err != nil {
+			return nil, 0, errors.New("unknown error occurred while doing request: " + err.Error())
+		}
+		return nil, 0, errors.New(errResp.Message)
+	}
+
+	response, err := decodeResponse[dtos.GetCustomerOrdersResponse](resp.Body)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	orders := []domain.Order{}
+	for _, o := range response.Orders {
+		orders = append(orders, toDomainOrder(o))
+	}
+	return orders, response.NextCursor, nil
+}
+
+func toDomainOrder(o dtos.GetOrderByIdResponse) domain.Order {
+	order := domain.Order{
+		ID:           o.ID,
+		CustomerID:   o.CustomerID,
+		RestaurantID: o.RestaurantID,
+		Status:       domain.OrderStatus(o.Status),
+		OrderItems:   []domain.OrderItem{},
+		CreatedAt:    o.CreatedAt,
+	}
+	for _, item := range o.OrderItems {
+		orderItem := item.ToDomain()
+		orderItem.ID = item.ID
+		for _, option := range item.Options {
+			orderItem.Options = append(orderItem.Options, domain.OrderItemOption{
+				OptionID:   option.OptionID,
+				Name:       option.Name,
+				PriceDelta: option.PriceDelta.ToDomain(),
+			})
+		}
+		order.OrderItems = append(order.OrderItems, orderItem)
+	}
+	return order
+}
+
+func (c *APIClient) PostOrderAction(orderId int, action string, token string) error {
+	orderIdStr := strconv.Itoa(orderId)
+	req, err := http.NewRequest("POST", c.baseUrl+"/api/orders/"+orderIdStr+"/"+action, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "Bearer "+token)
+	resp, err := c.client.Do(req)
+
+	if err != nil {
+		return err
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		errResp, err := decodeError(resp.Body)
+		if err != nil {
+			return errors.New("unknown error occurred while doing request: " + err.Error())
+		}
+		return errors.New(errResp.Message)
+	}
+
+	return nil
+}
+
+func (c *APIClient) PostOrder(restaurantId int, orderItems []domain.OrderItem, token string) (int, error) {
+	buf := bytes.NewBuffer(nil)
+	createReqDto := dtos.CreateOrderRequest{
+		RestaurantID: restaurantId,
+		OrderItems:   []dtos.OrderItemsDTO{},
+	}
+	for _, item := range orderItems {
+		createReqDto.OrderItems = append(createReqDto.OrderItems, dtos.OrderItemsDTO{
+			MenuItemID: item.MenuItemID,
+			Quantity:   item.Quantity,
+			OptionIDs:  item.OptionIDs(),
+		})
+	}
+
+	if err := encodeJson(buf, createReqDto); err != nil {
+		return 0, err
+	}
+
+	req, err := http.NewRequest("POST", c.baseUrl+"/api/orders", buf)
+	if err != nil {
+		return 0, err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+token)
+	resp, err := c.client.Do(req)
+
+	if err != nil {
+		return 0, err
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusCreated {
+		errResp, err := decodeError(resp.Body)
+		if err != nil {
+			return 0, errors.New("unknown error occurred while doing request: " + err.Error())
+		}
+		return 0, errors.New(errResp.Message)
+	}
+
+	response, err := decodeResponse[dtos.CreateOrderResponse](resp.Body)
+	if err != nil {
+		return 0, err
+	}
+
+	return response.ID, nil
+}
+
+func (c *APIClient) PostItemToOrder(orderId, menuItemId, quantity int, optionIds []int, token string) error {
+	buf := bytes.NewBuffer(nil)
+	addReqDto := dtos.AddOrderItemRequest{
+		MenuItemID: menuItemId,
+		Quantity:   quantity,
+		OptionIDs:  optionIds,
+	}
+	if err := encodeJson(buf, addReqDto); err != nil {
+		return err
+	}
+
+	orderIdStr := strconv.Itoa(orderId)
+	req, err := http.NewRequest("POST", c.baseUrl+"/api/orders/"+orderIdStr+"/items", buf)
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+token)
+	resp, err := c.client.Do(req)
+
+	if err != nil {
+		return err
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		errResp, err := decodeError(resp.Body)
+		if err != nil {
+			return errors.New("unknown error occurred while doing request: " + err.Error())
+		}
+		return errors.New(errResp.Message)
+	}
+
+	return nil
+}
+
+func (c *APIClient) PostCreateInvoice(orderId int, token string) (int, error) {
+	orderIdStr := strconv.Itoa(orderId)
+	req, err := http.NewRequest("POST", c.baseUrl+"/api/orders/"+orderIdStr+"/invoices", nil)
 	if err != nil {
 		return 0, err
 	}
@@ -534,16 +1018,361 @@ func (c *APIClient) GetInvoiceById(invoiceId int, token string) (*dtos.InvoiceRe
 	return &response, nil
 }
 
-func (c *APIClient) PostPayInvoice(invoiceId int, amount float64, token string) error {
+func (c *APIClient) PostPayInvoice(invoiceId int, method domain.PaymentMethod, amount domain.Money, token string) (*dtos.PaymentReceiptResponse, error) {
 	invoiceIdStr := strconv.Itoa(invoiceId)
 
 	buf := bytes.NewBuffer(nil)
-	payReqDto := dtos.PaymentRequest{Amount: amount}
+	payReqDto := dtos.PaymentRequest{Amount: dtos.NewMoneyDTO(amount), Method: string(method)}
 	if err := encodeJson(buf, payReqDto); err != nil {
-		return err
+		return nil, err
 	}
 
 	req, err := http.NewRequest("POST", c.baseUrl+"/api/invoices/"+invoiceIdStr+"/pay", buf)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Authorization", "Bearer "+token)
+	resp, err := c.client.Do(req)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer resp.Body.Close()
+
+	// cash is recorded right away, card payments are accepted for processing
+	if resp.StatusCode != http.StatusCreated && resp.StatusCode != http.StatusAccepted {
+		errResp, err := decodeError(resp.Body)
+		if err != nil {
+			return nil, errors.New("unknown error occurred while doing request: " + err.Error())
+		}
+		return nil, errors.New(errResp.Message)
+	}
+
+	response, err := decodeResponse[dtos.PaymentReceiptResponse](resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	return &response, nil
+}
+
+// GetAdminUsers searches users, pending limits the result to owners waiting
+// for approval.
+func (c *APIClient) GetAdminUsers(query, role string, pending bool, cursor int, token string) ([]domain.User, int, error) {
+	params := url.Values{}
+	if pending {
+		params.Set("pending", "true")
+	}
+	if query != "" {
+		params.Set("q", query)
+	}
+	if role != "" {
+		params.Set("role", role)
+	}
+	if cursor > 0 {
+		params.Set("cursor", strconv.Itoa(cursor))
+	}
+	req, err := http.NewRequest("GET", c.baseUrl+"/api/admin/users?"+params.Encode(), nil)
+	if err != nil {
+		return nil, 0, err
+	}
+	req.Header.Set("Authorization", "Bearer "+token)
+	resp, err := c.client.Do(req)
+
+	if err != nil {
+		return nil, 0, err
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		errResp, err := decodeError(resp.Body)
+		if err != nil {
+			return nil, 0, errors.New("unknown error occurred while doing request: " + err.Error())
+		}
+		return nil, 0, errors.New(errResp.Message)
+	}
+
+	response, err := decodeResponse[dtos.GetUsersResponse](resp.Body)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	users := []domain.User{}
+	for _, u := range response.Users {
+		users = append(users, domain.User{
+			ID:              u.UserID,
+			Name:            u.Name,
+			Email:           u.Email,
+			Role:            domain.UserRole(u.Role),
+			Suspended:       u.Suspended,
+			PendingApproval: u.PendingApproval,
+		})
+	}
+	return users, response.NextCursor, nil
+}
+
+func (c *APIClient) PatchUserRole(userId int, role string, token string) error {
+	buf := bytes.NewBuffer(nil)
+	if err := encodeJson(buf, dtos.UpdateUserRoleRequest{Role: role}); err != nil {
+		return err
+	}
+
+	userIdStr := strconv.Itoa(userId)
+	req, err := http.NewRequest("PATCH", c.baseUrl+"/api/admin/users/"+userIdStr+"/role", buf)
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+token)
+	resp, err := c.client.Do(req)
+
+	if err != nil {
+		return err
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		errResp, err := decodeError(resp.Body)
+		if err != nil {
+			return errors.New("unknown error occurred while doing request: " + err.Error())
+		}
+		return errors.New(errResp.Message)
+	}
+
+	return nil
+}
+
+// PostUserAction suspends, reinstates or approves a user, action is
+// "suspend", "reinstate" or "approve".
+func (c *APIClient) PostUserAction(userId int, action string, token string) error {
+	userIdStr := strconv.Itoa(userId)
+	req, err := http.NewRequest("POST", c.baseUrl+"/api/admin/users/"+userIdStr+"/"+action, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "Bearer "+token)
+	resp, err := c.client.Do(req)
+
+	if err != nil {
+		return err
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		errResp, err := decodeError(resp.Body)
+		if err != nil {
+			return errors.New("unknown error occurred while doing request: " + err.Error())
+		}
+		return errors.New(errResp.Message)
+	}
+
+	return nil
+}
+
+func (c *APIClient) GetAdminOrders(customerId, restaurantId int, statuses []string, cursor int, token string) ([]domain.Order, int, error) {
+	query := url.Values{}
+	if customerId > 0 {
+		query.Set("customer_id", strconv.Itoa(customerId))
+	}
+	if restaurantId > 0 {
+		query.Set("restaurant_id", strconv.Itoa(restaurantId))
+	}
+	if len(statuses) > 0 {
+		query.Set("status", strings.Join(statuses, ","))
+	}
+	if cursor > 0 {
+		query.Set("cursor", strconv.Itoa(cursor))
+	}
+	req, err := http.NewRequest("GET", c.baseUrl+"/api/admin/orders?"+query.Encode(), nil)
+	if err != nil {
+		return nil, 0, err
+	}
+	req.Header.Set("Authorization", "Bearer "+token)
+	resp, err := c.client.Do(req)
+
+	if err != nil {
+		return nil, 0, err
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		errResp, err := decodeError(resp.Body)
+		if err != nil {
+			return nil, 0, errors.New("unknown error occurred while doing request: " + err.Error())
+		}
+		return nil, 0, errors.New(errResp.Message)
+	}
+
+	response, err := decodeResponse[dtos.GetCustomerOrdersResponse](resp.Body)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	orders := []domain.Order{}
+	for _, o := range response.Orders {
+		orders = append(orders, toDomainOrder(o))
+	}
+	return orders, response.NextCursor, nil
+}
+
+func (c *APIClient) PostAdminCancelOrder(orderId int, token string) error {
+	orderIdStr := strconv.Itoa(orderId)
+	req, err := http.NewRequest("POST", c.baseUrl+"/api/admin/orders/"+orderIdStr+"/cancel", nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "Bearer "+token)
+	resp, err := c.client.Do(req)
+
+	if err != nil {
+		return err
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		errResp, err := decodeError(resp.Body)
+		if err != nil {
+			return errors.New("unknown error occurred while doing request: " + err.Error())
+		}
+		return errors.New(errResp.Message)
+	}
+
+	return nil
+}
+
+func (c *APIClient) GetAdminInvoices(statuses []string, cursor int, token string) ([]dtos.InvoiceResponse, int, error) {
+	query := url.Values{}
+	if len(statuses) > 0 {
+		query.Set("status", strings.Join(statuses, ","))
+	}
+	if cursor > 0 {
+		query.Set("cursor", strconv.Itoa(cursor))
+	}
+	req, err := http.NewRequest("GET", c.baseUrl+"/api/admin/invoices?"+query.Encode(), nil)
+	if err != nil {
+		return nil, 0, err
+	}
+	req.Header.Set("Authorization", "Bearer "+token)
+	resp, err := c.client.Do(req)
+
+	if err != nil {
+		return nil, 0, err
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		errResp, err := decodeError(resp.Body)
+		if err != nil {
+			return nil, 0, errors.New("unknown error occurred while doing request: " + err.Error())
+		}
+		return nil, 0, errors.New(errResp.Message)
+	}
+
+	response, err := decodeResponse[dtos.GetInvoicesResponse](resp.Body)
+	if err != nil {
+		return nil, 0, err
+	}
+	return response.Invoices, response.NextCursor, nil
+}
+
+func (c *APIClient) GetRestaurantMembers(restaurantId int, token string) ([]domain.RestaurantMember, error) {
+	return c.getMembers("/api/restaurants/"+strconv.Itoa(restaurantId)+"/members", token)
+}
+
+// GetStaffRestaurants lists the restaurants the logged in staff user works at.
+func (c *APIClient) GetStaffRestaurants(token string) ([]domain.RestaurantMember, error) {
+	return c.getMembers("/api/staff/restaurants", token)
+}
+
+func (c *APIClient) getMembers(path string, token string) ([]domain.RestaurantMember, error) {
+	req, err := http.NewRequest("GET", c.baseUrl+path, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Authorization", "Bearer "+token)
+	resp, err := c.client.Do(req)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		errResp, err := decodeError(resp.Body)
+		if err != nil {
+			return nil, errors.New("unknown error occurred while doing request: " + err.Error())
+		}
+		return nil, errors.New(errResp.Message)
+	}
+
+	response, err := decodeResponse[dtos.GetRestaurantMembersResponse](resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	members := []domain.RestaurantMember{}
+	for _, m := range response.Members {
+		members = append(members, domain.RestaurantMember{
+			RestaurantID: m.RestaurantID,
+			UserID:       m.UserID,
+			Role:         domain.StaffRole(m.Role),
+			Name:         m.Name,
+			Email:        m.Email,
+		})
+	}
+	return members, nil
+}
+
+func (c *APIClient) PostRestaurantMember(restaurantId int, email, role string, token string) (*dtos.RestaurantMemberDTO, error) {
+	buf := bytes.NewBuffer(nil)
+	if err := encodeJson(buf, dtos.InviteMemberRequest{Email: email, Role: role}); err != nil {
+		return nil, err
+	}
+
+	restaurantIdStr := strconv.Itoa(restaurantId)
+	req, err := http.NewRequest("POST", c.baseUrl+"/api/restaurants/"+restaurantIdStr+"/members", buf)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+token)
+	resp, err := c.client.Do(req)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusCreated {
+		errResp, err := decodeError(resp.Body)
+		if err != nil {
+			return nil, errors.New("unknown error occurred while doing request: " + err.Error())
+		}
+		return nil, errors.New(errResp.Message)
+	}
+
+	response, err := decodeResponse[dtos.RestaurantMemberDTO](resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	return &response, nil
+}
+
+func (c *APIClient) DeleteRestaurantMember(restaurantId, userId int, token string) error {
+	restaurantIdStr := strconv.Itoa(restaurantId)
+	userIdStr := strconv.Itoa(userId)
+	req, err := http.NewRequest("DELETE", c.baseUrl+"/api/restaurants/"+restaurantIdStr+"/members/"+userIdStr, nil)
 	if err != nil {
 		return err
 	}
@@ -2,14 +2,24 @@ package handlers
 
 import (
 	"bufio"
+	"errors"
 	"fmt"
 	"os"
+	"strconv"
+	"strings"
+	"time"
 
 	apiclient "github.com/mohits-git/food-ordering-system/cmd/cli/api_client"
 	"github.com/mohits-git/food-ordering-system/internal/domain"
 	"github.com/mohits-git/food-ordering-system/internal/utils/authctx"
 )
 
+// the payment is processed in the background, the invoice is polled for the outcome
+const (
+	paymentPollAttempts = 30
+	paymentPollInterval = time.Second
+)
+
 type Handlers struct {
 	apiClient *apiclient.APIClient
 }
@@ -20,7 +30,7 @@ func NewHandlers(apiClient *apiclient.APIClient) *Handlers {
 	}
 }
 
-func (h *Handlers) HandleLogin() (string, authctx.UserClaims) {
+func (h *Handlers) HandleLogin() (string, string, authctx.UserClaims) {
 	var email string
 	var password string
 
@@ -44,15 +54,26 @@ func (h *Handlers) HandleLogin() (string, authctx.UserClaims) {
 		fmt.Println("Password must be at least 6 characters long. Please try again.")
 	}
 
-	token, err := h.apiClient.PostLogin(email, password)
+	tokens, err := h.apiClient.PostLogin(email, password)
 	if err != nil {
 		fmt.Println("Error while login:", err)
-		return "", authctx.UserClaims{}
+		return "", "", authctx.UserClaims{}
 	}
 
-	userClaims := decodeJwt(token)
+	userClaims := decodeJwt(tokens.Token)
 	fmt.Printf("Login successful. User ID: %d, Role: %s\n", userClaims.UserID, userClaims.Role)
-	return token, userClaims
+	return tokens.Token, tokens.RefreshToken, userClaims
+}
+
+// HandleRefreshSession renews the access token before it expires. It returns
+// empty tokens when the session cannot be renewed and the user has to login again.
+func (h *Handlers) HandleRefreshSession(refreshToken string) (string, string, authctx.UserClaims) {
+	tokens, err := h.apiClient.PostRefresh(refreshToken)
+	if err != nil {
+		fmt.Println("Your session has expired, please login again:", err)
+		return "", "", authctx.UserClaims{}
+	}
+	return tokens.Token, tokens.RefreshToken, decodeJwt(tokens.Token)
 }
 
 func (h *Handlers) HandleLogout(token string) {
@@ -66,6 +87,22 @@ func (h *Handlers) HandleLogout(token string) {
 }
 
 func (h *Handlers) handleCreateUser(role string) {
+	name, email, password := readNewUser()
+
+	createResp, err := h.apiClient.PostUser(name, email, password, role)
+	if err != nil {
+		fmt.Println("Error while creating user:", err)
+		return
+	}
+
+	fmt.Printf("User created successfully with ID: %d\n", createResp.UserID)
+	if createResp.PendingApproval {
+		fmt.Println("Your account has to be approved by an admin before you can log in.")
+	}
+}
+
+// readNewUser prompts for the details of a new account.
+func readNewUser() (string, string, string) {
 	var name, email, password string
 
 	reader := bufio.NewReader(os.Stdin)
@@ -94,13 +131,7 @@ func (h *Handlers) handleCreateUser(role string) {
 		fmt.Println("Password must be at least 6 characters long. Please try again.")
 	}
 
-	userID, err := h.apiClient.PostUser(name, email, password, role)
-	if err != nil {
-		fmt.Println("Error while creating user:", err)
-		return
-	}
-
-	fmt.Printf("User created successfully with ID: %d\n", userID)
+	return name, email, password
 }
 
 func (h *Handlers) HandleRegisterCustomer() {
@@ -111,6 +142,13 @@ func (h *Handlers) HandleRegisterRestaurantOwner() {
 	h.handleCreateUser("owner")
 }
 
+// HandleRegisterStaff signs up a staff account, it can only work at a
+// restaurant once the owner adds it.
+func (h *Handlers) HandleRegisterStaff() {
+	h.handleCreateUser("staff")
+	fmt.Println("Ask the restaurant owner to add your email to their staff.")
+}
+
 func (h *Handlers) HandleViewRestaurants(args ...int) {
 	restaurants, err := h.apiClient.GetRestaurants()
 	if err != nil {
@@ -141,8 +179,62 @@ func (h *Handlers) HandleViewRestaurants(args ...int) {
 	}
 
 	fmt.Println("Restaurants:")
+	printRestaurants(restaurants)
+}
+
+// HandleSearchRestaurants searches the restaurants by name or by a dish they
+// serve.
+func (h *Handlers) HandleSearchRestaurants() {
+	reader := bufio.NewReader(os.Stdin)
+	readLine := func(prompt string) string {
+		fmt.Println(prompt)
+		input, _ := reader.ReadString('\n')
+		return strings.TrimSpace(input)
+	}
+
+	query := readLine("Search by restaurant or dish name (empty for all):")
+	cuisine := readLine("Filter by cuisine (empty for all):")
+	openNow := readLine("Only restaurants open now? (yes/no)") == "yes"
+	sort := readLine("Sort by (relevance/name/newest, empty for default):")
+
+	cursor := 0
+	for {
+		restaurants, nextCursor, err := h.apiClient.SearchRestaurants(query, cuisine, openNow, sort, cursor)
+		if err != nil {
+			fmt.Println("Error while searching restaurants:", err)
+			return
+		}
+
+		if len(restaurants) == 0 && cursor == 0 {
+			fmt.Println("No restaurants found.")
+			return
+		}
+		printRestaurants(restaurants)
+
+		if nextCursor == 0 {
+			return
+		}
+		if readLine("\nLoad more restaurants? (yes/no)") != "yes" {
+			return
+		}
+		cursor = nextCursor
+	}
+}
+
+func printRestaurants(restaurants []domain.Restaurant) {
+	now := time.Now()
 	for _, r := range restaurants {
-		fmt.Printf("ID: %d, Name: %s\n", r.ID, r.Name)
+		status := "open"
+		if r.OrdersPaused {
+			status = "paused"
+		} else if !r.IsOpenAt(now) {
+			status = "closed"
+		}
+		fmt.Printf("ID: %d, Name: %s (%s)", r.ID, r.Name, status)
+		if len(r.Cuisines) > 0 {
+			fmt.Printf(", Cuisines: %s", strings.Join(r.Cuisines, ", "))
+		}
+		fmt.Println()
 	}
 }
 
@@ -164,12 +256,75 @@ func (h *Handlers) handleViewMenuItemsByRestaurantId(restaurantId int) []domain.
 		if item.Available {
 			availability = "Available"
 		}
-		fmt.Printf("ID: %d, Name: %s, Price: %.2f, Availability: %s\n", item.ID, item.Name, item.Price, availability)
+		if item.TrackStock {
+			availability = fmt.Sprintf("%s (%d left)", availability, item.Stock)
+		}
+		fmt.Printf("ID: %d, Name: %s, Price: %s, Availability: %s\n", item.ID, item.Name, item.Price, availability)
+		if item.Description != "" {
+			fmt.Printf("    %s\n", item.Description)
+		}
+		for _, group := range item.ModifierGroups {
+			fmt.Printf("    %s (choose %d to %d):\n", group.Name, group.MinSelect, group.MaxSelect)
+			for _, option := range group.Options {
+				fmt.Printf("      Option ID: %d, %s %s\n", option.ID, option.Name, formatPriceDelta(option.PriceDelta))
+			}
+		}
 	}
 
 	return menuItems
 }
 
+func formatPriceDelta(delta domain.Money) string {
+	if delta.IsNegative() {
+		return delta.String()
+	}
+	return "+" + delta.String()
+}
+
+// readOptionIds reads comma separated option ids, empty input chooses none.
+func readOptionIds(prompt string) ([]int, error) {
+	var input string
+	fmt.Println(prompt)
+	fmt.Scanln(&input)
+
+	return parseIds(input, "option")
+}
+
+func parseIds(input string, kind string) ([]int, error) {
+	ids := []int{}
+	if input == "" {
+		return ids, nil
+	}
+	for _, idStr := range strings.Split(input, ",") {
+		id, err := strconv.Atoi(strings.TrimSpace(idStr))
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s id %q", kind, idStr)
+		}
+		ids = append(ids, id)
+	}
+	return ids, nil
+}
+
+// chooseOptions asks for the options of every modifier group of the item.
+func chooseOptions(item domain.MenuItem) ([]domain.OrderItemOption, bool) {
+	optionIds := []int{}
+	for _, group := range item.ModifierGroups {
+		ids, err := readOptionIds(fmt.Sprintf("Choose %s, %d to %d option IDs (comma separated, empty for none):", group.Name, group.MinSelect, group.MaxSelect))
+		if err != nil {
+			fmt.Println(err)
+			return nil, false
+		}
+		optionIds = append(optionIds, ids...)
+	}
+	return item.SelectOptions(optionIds)
+}
+
+func printOrderItems(items []domain.OrderItem) {
+	for _, item := range items {
+		fmt.Printf("  - Item ID: %d, Menu Item ID: %d, %s x %d\n", item.ID, item.MenuItemID, item.DisplayName(), item.Quantity)
+	}
+}
+
 func (h *Handlers) HandleViewRestaurantMenuItems() {
 	var restaurantId int
 	fmt.Println("Enter Restaurant ID:")
@@ -199,9 +354,10 @@ func (h *Handlers) HandleAddRestaurant(token string) {
 func (h *Handlers) HandleAddMenuItemToRestaurant(token string, ownerId int) {
 	var restaurantId int
 	var name string
-	var price float64
+	var priceInput string
 	var availableInput string
 	var available bool
+	var category string
 
 	fmt.Println("--------- Choose Restaurant ----------")
 	h.HandleViewRestaurants(ownerId)
@@ -218,8 +374,13 @@ func (h *Handlers) HandleAddMenuItemToRestaurant(token string, ownerId int) {
 	name, _ = reader.ReadString('\n')
 
 	// enter price
-	fmt.Println("Enter menu item price:")
-	fmt.Scanln(&price)
+	fmt.Println("Enter menu item price (e.g. 12.50):")
+	fmt.Scanln(&priceInput)
+	price, err := domain.ParseMoney(priceInput, domain.DefaultCurrency)
+	if err != nil {
+		fmt.Println("Invalid price:", err)
+		return
+	}
 	fmt.Println("Price: ", price)
 
 	// enter availability
@@ -231,7 +392,11 @@ func (h *Handlers) HandleAddMenuItemToRestaurant(token string, ownerId int) {
 		available = false
 	}
 
-	menuItemID, err := h.apiClient.PostMenuItem(restaurantId, name, price, available, token)
+	// enter tax category
+	fmt.Println("Enter menu item category (e.g. food, alcohol; empty for none):")
+	fmt.Scanln(&category)
+
+	menuItemID, err := h.apiClient.PostMenuItem(restaurantId, name, price, available, category, token)
 	if err != nil {
 		fmt.Println("Error while adding menu item:", err)
 		return
@@ -267,6 +432,242 @@ func (h *Handlers) HandleUpdateMenuItemAvailability(token string) {
 	fmt.Println("Menu item availability updated successfully.")
 }
 
+// HandleSetMenuItemStock sets the quantity left of a menu item, empty input
+// stops tracking its stock.
+func (h *Handlers) HandleSetMenuItemStock(token string) {
+	var menuItemId int
+	var stockInput string
+
+	// enter menu item ID
+	fmt.Println("Enter Menu Item ID:")
+	fmt.Scanln(&menuItemId)
+
+	// enter stock
+	fmt.Println("Enter stock left (empty to stop tracking stock):")
+	fmt.Scanln(&stockInput)
+
+	var stock *int
+	if stockInput != "" {
+		value, err := strconv.Atoi(stockInput)
+		if err != nil {
+			fmt.Println("Invalid stock:", stockInput)
+			return
+		}
+		stock = &value
+	}
+
+	err := h.apiClient.PatchMenuItemStock(menuItemId, stock, token)
+	if err != nil {
+		fmt.Println("Error while updating menu item stock:", err)
+		return
+	}
+
+	fmt.Println("Menu item stock updated successfully.")
+}
+
+// HandleEditMenuItem updates a menu item, empty input keeps the current value.
+func (h *Handlers) HandleEditMenuItem(token string) {
+	var restaurantId int
+	var menuItemId int
+
+	fmt.Println("Enter Restaurant ID:")
+	fmt.Scanln(&restaurantId)
+
+	menuItems := h.handleViewMenuItemsByRestaurantId(restaurantId)
+	if len(menuItems) == 0 {
+		return
+	}
+
+	fmt.Println("\nEnter Menu Item ID:")
+	fmt.Scanln(&menuItemId)
+
+	item, ok := mapMenuItems(menuItems)[menuItemId]
+	if !ok {
+		fmt.Println("Menu item not found in this restaurant.")
+		return
+	}
+
+	reader := bufio.NewReader(os.Stdin)
+	readLine := func(prompt, current string) string {
+		fmt.Printf("%s [%s]:\n", prompt, current)
+		input, _ := reader.ReadString('\n')
+		input = strings.TrimSpace(input)
+		if input == "" {
+			return current
+		}
+		return input
+	}
+
+	item.Name = readLine("Name", item.Name)
+	price, err := domain.ParseMoney(readLine("Price", item.Price.Decimal()), item.Price.Currency)
+	if err != nil {
+		fmt.Println("Invalid price:", err)
+		return
+	}
+	item.Price = price
+	available := "no"
+	if item.Available {
+		available = "yes"
+	}
+	item.Available = readLine("Available (yes/no)", available) == "yes"
+	item.Category = readLine("Category", item.Category)
+	item.Description = readLine("Description", item.Description)
+	item.ImageURL = readLine("Image URL", item.ImageURL)
+	sortOrder, err := strconv.Atoi(readLine("Sort order", strconv.Itoa(item.SortOrder)))
+	if err != nil {
+		fmt.Println("Invalid sort order:", err)
+		return
+	}
+	item.SortOrder = sortOrder
+
+	if err := h.apiClient.PutMenuItem(item, token); err != nil {
+		fmt.Println("Error while updating menu item:", err)
+		return
+	}
+
+	fmt.Println("Menu item updated successfully.")
+}
+
+func (h *Handlers) HandleDeleteMenuItem(token string) {
+	var menuItemId int
+	var confirm string
+
+	fmt.Println("Enter Menu Item ID:")
+	fmt.Scanln(&menuItemId)
+
+	fmt.Println("Remove the item from the menu? (yes/no):")
+	fmt.Scanln(&confirm)
+	if confirm != "yes" {
+		return
+	}
+
+	if err := h.apiClient.DeleteMenuItem(menuItemId, token); err != nil {
+		fmt.Println("Error while deleting menu item:", err)
+		return
+	}
+
+	fmt.Println("Menu item deleted successfully.")
+}
+
+// HandleAddModifierGroup adds a group of options, like sizes or extras, to a
+// menu item.
+func (h *Handlers) HandleAddModifierGroup(token string) {
+	var restaurantId int
+	var menuItemId int
+
+	fmt.Println("Enter Restaurant ID:")
+	fmt.Scanln(&restaurantId)
+
+	menuItems := h.handleViewMenuItemsByRestaurantId(restaurantId)
+	if len(menuItems) == 0 {
+		return
+	}
+
+	fmt.Println("\nEnter Menu Item ID:")
+	fmt.Scanln(&menuItemId)
+
+	item, ok := mapMenuItems(menuItems)[menuItemId]
+	if !ok {
+		fmt.Println("Menu item not found in this restaurant.")
+		return
+	}
+
+	reader := bufio.NewReader(os.Stdin)
+	readLine := func(prompt string) string {
+		fmt.Println(prompt)
+		input, _ := reader.ReadString('\n')
+		return strings.TrimSpace(input)
+	}
+
+	group := domain.ModifierGroup{Name: readLine("Group name (e.g. Size, Extras):")}
+	fmt.Println("Minimum options to choose (0 if optional):")
+	fmt.Scanln(&group.MinSelect)
+	fmt.Println("Maximum options to choose:")
+	fmt.Scanln(&group.MaxSelect)
+
+	for {
+		name := readLine("Option name (empty to finish):")
+		if name == "" {
+			break
+		}
+		delta, err := domain.ParseMoney(readLine("Price change (e.g. 1.50, -0.50, 0):"), item.Price.Currency)
+		if err != nil {
+			fmt.Println("Invalid price:", err)
+			continue
+		}
+		group.Options = append(group.Options, domain.ModifierOption{Name: name, PriceDelta: delta})
+	}
+
+	if !group.Validate() {
+		fmt.Println("Invalid group, it needs a name, a maximum of at least 1 and as many options as the maximum.")
+		return
+	}
+	item.ModifierGroups = append(item.ModifierGroups, group)
+
+	if err := h.apiClient.PutMenuItem(item, token); err != nil {
+		fmt.Println("Error while adding modifier group:", err)
+		return
+	}
+
+	fmt.Println("Modifier group added successfully.")
+}
+
+// HandleCreateMenu adds a named menu, like breakfast or dinner, offered during
+// the given windows of the day.
+func (h *Handlers) HandleCreateMenu(token string) {
+	menu := domain.Menu{}
+	fmt.Println("Enter Restaurant ID:")
+	fmt.Scanln(&menu.RestaurantID)
+
+	if len(h.handleViewMenuItemsByRestaurantId(menu.RestaurantID)) == 0 {
+		return
+	}
+
+	reader := bufio.NewReader(os.Stdin)
+	readLine := func(prompt string) string {
+		fmt.Println(prompt)
+		input, _ := reader.ReadString('\n')
+		return strings.TrimSpace(input)
+	}
+
+	menu.Name = readLine("\nMenu name (e.g. Breakfast, Dinner):")
+	for {
+		startStr := readLine("Served from (HH:MM, empty to finish, a menu without times is served all day):")
+		if startStr == "" {
+			break
+		}
+		endStr := readLine("Served until (HH:MM):")
+		start, okStart := domain.ParseTimeOfDay(startStr)
+		end, okEnd := domain.ParseTimeOfDay(endStr)
+		if !okStart || !okEnd {
+			fmt.Println("Invalid time, use HH:MM like 07:30.")
+			continue
+		}
+		menu.Schedules = append(menu.Schedules, domain.MenuSchedule{Start: start, End: end})
+	}
+
+	for {
+		name := readLine("Category name (e.g. Starters, Mains, empty to finish):")
+		if name == "" {
+			break
+		}
+		itemIds, err := parseIds(readLine("Menu item IDs in this category, in order (comma separated):"), "menu item")
+		if err != nil {
+			fmt.Println(err)
+			continue
+		}
+		menu.Categories = append(menu.Categories, domain.MenuCategory{Name: name, ItemIDs: itemIds})
+	}
+
+	menuId, err := h.apiClient.PostMenu(menu, token)
+	if err != nil {
+		fmt.Println("Error while creating menu:", err)
+		return
+	}
+
+	fmt.Println("Menu created successfully with ID:", menuId)
+}
+
 func (h *Handlers) HandlePlaceOrder(token string) {
 	fmt.Println("--------- Choose Restaurant ----------")
 	h.HandleViewRestaurants()
@@ -286,11 +687,11 @@ func (h *Handlers) HandlePlaceOrder(token string) {
 	}
 
 	invoiceId, toPay := h.HandlePlaceOrderAndGetBill(orderId, token)
-	if toPay == 0 {
+	if toPay.IsZero() {
 		return
 	}
 
-	fmt.Printf("\nPlease Pay %.2f\n", toPay)
+	fmt.Printf("\nPlease Pay %s\n", toPay)
 	fmt.Printf("Confirm (yes/no)")
 	fmt.Scanln(&confirmString)
 	if confirmString != "yes" {
@@ -349,12 +750,19 @@ func (h *Handlers) HandleCreateOrder(token string) int {
 			continue
 		}
 
+		options, ok := chooseOptions(item)
+		if !ok {
+			fmt.Println("Invalid options for this item. Try again...")
+			continue
+		}
+
 		var quantity int
 		fmt.Println("Enter quantity:")
 		fmt.Scanln(&quantity)
 		orderItems = append(orderItems, domain.OrderItem{
 			MenuItemID: menuItemId,
 			Quantity:   quantity,
+			Options:    options,
 		})
 
 		fmt.Printf("Menu item added to order successfully.\n\n")
@@ -379,10 +787,15 @@ func (h *Handlers) HandleAddMenuItemToOrder(token string) {
 	fmt.Scanln(&orderId)
 	fmt.Println("Enter Menu Item ID to add to order:")
 	fmt.Scanln(&menuItemId)
+	optionIds, err := readOptionIds("Enter option IDs (comma separated, empty for none):")
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
 	fmt.Println("Enter quantity:")
 	fmt.Scanln(&quantity)
 
-	err := h.apiClient.PostItemToOrder(orderId, menuItemId, quantity, token)
+	err = h.apiClient.PostItemToOrder(orderId, menuItemId, quantity, optionIds, token)
 	if err != nil {
 		fmt.Println("Error while adding item to order:", err)
 		return
@@ -390,34 +803,92 @@ func (h *Handlers) HandleAddMenuItemToOrder(token string) {
 	fmt.Println("Menu item added to order successfully.")
 }
 
-func (h *Handlers) HandlePlaceOrderAndGetBill(orderId int, token string) (int, float64) {
+func (h *Handlers) HandlePlaceOrderAndGetBill(orderId int, token string) (int, domain.Money) {
 	invoiceId, err := h.apiClient.PostCreateInvoice(orderId, token)
 	if err != nil {
 		fmt.Println("Error while placing order:", err)
-		return 0, 0
+		return 0, domain.Money{}
 	}
 
 	bill, err := h.apiClient.GetInvoiceById(invoiceId, token)
 	if err != nil {
 		fmt.Println("Error while fetching invoice:", err)
-		return 0, 0
+		return 0, domain.Money{}
 	}
 
-	fmt.Printf("Order placed successfully.\n Invoice ID: %d\n Amount: %.2f\n Tax: %.2f\n Total to Pay: %.2f\n Payment Status: %s\n\n", bill.ID, bill.Total, bill.Tax, bill.ToPay, bill.PaymentStatus)
-	return bill.ID, bill.ToPay
+	fmt.Printf("Order placed successfully.\n Invoice ID: %d\n Amount: %s\n Tax: %s\n Total to Pay: %s\n Payment Status: %s\n\n", bill.ID, bill.Total.ToDomain(), bill.Tax.ToDomain(), bill.ToPay.ToDomain(), bill.PaymentStatus)
+	return bill.ID, bill.ToPay.ToDomain()
 }
 
-func (h *Handlers) HandlePayBill(token string, invoiceId int, amount float64) error {
-	err := h.apiClient.PostPayInvoice(invoiceId, amount, token)
-	if err != nil {
-		fmt.Println("Error while paying invoice:", err)
-		return err
+// HandlePayBill takes payments until the invoice is settled, a bill can be
+// split over several cash and card payments.
+func (h *Handlers) HandlePayBill(token string, invoiceId int, due domain.Money) error {
+	for !due.IsZero() {
+		fmt.Printf("\nAmount due: %s\n", due)
+
+		method := string(domain.PaymentCard)
+		fmt.Println("Pay by (card/cash, empty for card):")
+		fmt.Scanln(&method)
+
+		amountInput := ""
+		fmt.Println("Amount to pay (empty for the amount due):")
+		fmt.Scanln(&amountInput)
+		amount := due
+		if amountInput != "" {
+			var err error
+			amount, err = domain.ParseMoney(amountInput, due.Currency)
+			if err != nil {
+				fmt.Println("Invalid amount:", err)
+				continue
+			}
+		}
+
+		receipt, err := h.apiClient.PostPayInvoice(invoiceId, domain.PaymentMethod(method), amount, token)
+		if err != nil {
+			fmt.Println("Error while paying invoice:", err)
+			return err
+		}
+
+		if domain.PaymentStatus(receipt.InvoiceStatus) == domain.Processing {
+			if err := h.waitForPayment(token, invoiceId); err != nil {
+				return err
+			}
+		} else {
+			fmt.Printf("Received %s, change %s\n", receipt.Tendered.ToDomain(), receipt.Change.ToDomain())
+		}
+		due = receipt.AmountDue.ToDomain()
 	}
 
 	fmt.Println("Bill paid successfully.")
 	return nil
 }
 
+// waitForPayment polls the invoice until the card payment went through.
+func (h *Handlers) waitForPayment(token string, invoiceId int) error {
+	fmt.Println("Processing payment...")
+	for range paymentPollAttempts {
+		time.Sleep(paymentPollInterval)
+		invoice, err := h.apiClient.GetInvoiceById(invoiceId, token)
+		if err != nil {
+			fmt.Println("Error while checking payment:", err)
+			return err
+		}
+		switch domain.PaymentStatus(invoice.PaymentStatus) {
+		case domain.Processing:
+			continue
+		case domain.Paid, domain.PartiallyPaid:
+			fmt.Println("Card payment received.")
+			return nil
+		default:
+			fmt.Println("Payment was not successful, invoice status:", invoice.PaymentStatus)
+			return errors.New("payment " + invoice.PaymentStatus)
+		}
+	}
+
+	fmt.Println("Payment is still processing, check the invoice later.")
+	return errors.New("payment still processing")
+}
+
 func (h *Handlers) HandleGetInvoiceById(token string) {
 	var invoiceId int
 
@@ -431,5 +902,622 @@ func (h *Handlers) HandleGetInvoiceById(token string) {
 		return
 	}
 
-	fmt.Printf("Invoice fetched successfully.\nInvoice ID: %d, Amount: %.2f, Tax: %.2f, Total to Pay: %.2f, Payment Status: %s\n", invoice.ID, invoice.Total, invoice.Tax, invoice.ToPay, invoice.PaymentStatus)
+	fmt.Println("Invoice fetched successfully.")
+	fmt.Printf("\n  Invoice #%d (Order #%d)\n", invoice.ID, invoice.OrderID)
+	fmt.Println("  ------------------------------------------------------------------")
+	fmt.Printf("  %-24s %10s %5s %12s %10s\n", "Item", "Price", "Qty", "Amount", "Tax")
+	fmt.Println("  ------------------------------------------------------------------")
+	for _, item := range invoice.Items {
+		fmt.Printf("  %-24s %10s %5d %12s %10s\n", item.Name, item.UnitPrice.ToDomain().Decimal(), item.Quantity, item.Total.ToDomain().Decimal(), item.Tax.ToDomain().Decimal())
+	}
+	fmt.Println("  ------------------------------------------------------------------")
+	fmt.Printf("  %-41s %12s\n", "Subtotal", invoice.Total.ToDomain().Decimal())
+	fmt.Printf("  %-41s %12s\n", "Tax", invoice.Tax.ToDomain().Decimal())
+	fmt.Printf("  %-41s %12s\n", "Total to Pay", invoice.ToPay.ToDomain())
+	fmt.Printf("  %-41s %12s\n", "Payment Status", invoice.PaymentStatus)
+}
+
+func (h *Handlers) HandleViewRestaurantOrders(token string, ownerId int) {
+	var restaurantId int
+	var statusInput string
+
+	fmt.Println("--------- Choose Restaurant ----------")
+	h.HandleViewRestaurants(ownerId)
+	fmt.Printf("\n--------------------------------------\n\n")
+
+	fmt.Println("Enter Restaurant ID:")
+	fmt.Scanln(&restaurantId)
+
+	fmt.Println("Filter by status (comma separated, empty for all):")
+	fmt.Scanln(&statusInput)
+
+	statuses := []string{}
+	if statusInput != "" {
+		statuses = strings.Split(statusInput, ",")
+	}
+
+	orders, err := h.apiClient.GetRestaurantOrders(restaurantId, statuses, token)
+	if err != nil {
+		fmt.Println("Error while fetching restaurant orders:", err)
+		return
+	}
+
+	if len(orders) == 0 {
+		fmt.Println("No orders found.")
+		return
+	}
+
+	fmt.Printf("Orders for Restaurant ID %d:\n", restaurantId)
+	for _, order := range orders {
+		fmt.Printf("Order ID: %d, Customer ID: %d, Status: %s\n", order.ID, order.CustomerID, order.Status)
+		printOrderItems(order.OrderItems)
+	}
+}
+
+func (h *Handlers) HandleUpdateOrderStatus(token string) {
+	var orderId int
+	var action string
+
+	fmt.Println("Enter Order ID:")
+	fmt.Scanln(&orderId)
+
+	for {
+		fmt.Println("Enter action (accept/reject/preparing/ready/delivered/cancel):")
+		fmt.Scanln(&action)
+		if action == "accept" || action == "reject" || action == "preparing" || action == "ready" || action == "delivered" || action == "cancel" {
+			break
+		}
+		fmt.Println("Invalid action. Please try again.")
+	}
+
+	err := h.apiClient.PostOrderAction(orderId, action, token)
+	if err != nil {
+		fmt.Println("Error while updating order status:", err)
+		return
+	}
+
+	fmt.Println("Order status updated successfully.")
+}
+
+func (h *Handlers) HandleCancelOrder(token string) {
+	var orderId int
+
+	fmt.Println("Enter Order ID:")
+	fmt.Scanln(&orderId)
+
+	err := h.apiClient.PostOrderAction(orderId, "cancel", token)
+	if err != nil {
+		fmt.Println("Error while cancelling order:", err)
+		return
+	}
+
+	fmt.Println("Order cancelled successfully. Any paid invoice will be refunded.")
+}
+
+func (h *Handlers) HandleViewMyOrders(token string) {
+	var restaurantId int
+	var statusInput string
+
+	fmt.Println("Filter by Restaurant ID (0 for all):")
+	fmt.Scanln(&restaurantId)
+
+	fmt.Println("Filter by status (comma separated, empty for all):")
+	fmt.Scanln(&statusInput)
+
+	statuses := []string{}
+	if statusInput != "" {
+		statuses = strings.Split(statusInput, ",")
+	}
+
+	cursor := 0
+	for {
+		orders, nextCursor, err := h.apiClient.GetMyOrders(restaurantId, statuses, cursor, token)
+		if err != nil {
+			fmt.Println("Error while fetching orders:", err)
+			return
+		}
+
+		if len(orders) == 0 && cursor == 0 {
+			fmt.Println("No orders found.")
+			return
+		}
+
+		for _, order := range orders {
+			fmt.Printf("Order ID: %d, Restaurant ID: %d, Status: %s, Placed At: %s\n",
+				order.ID, order.RestaurantID, order.Status, order.CreatedAt.Local().Format("2006-01-02 15:04"))
+			printOrderItems(order.OrderItems)
+		}
+
+		if nextCursor == 0 {
+			return
+		}
+
+		loadMore := ""
+		fmt.Println("\nLoad more orders? (yes/no)")
+		fmt.Scanln(&loadMore)
+		if loadMore != "yes" {
+			return
+		}
+		cursor = nextCursor
+	}
+}
+
+func (h *Handlers) HandleViewUsers(token string) {
+	var query string
+	var role string
+
+	fmt.Println("Search by name or email (empty for all):")
+	fmt.Scanln(&query)
+
+	fmt.Println("Filter by role (customer/owner/staff/admin, empty for all):")
+	fmt.Scanln(&role)
+
+	cursor := 0
+	for {
+		users, nextCursor, err := h.apiClient.GetAdminUsers(query, role, false, cursor, token)
+		if err != nil {
+			fmt.Println("Error while fetching users:", err)
+			return
+		}
+
+		if len(users) == 0 && cursor == 0 {
+			fmt.Println("No users found.")
+			return
+		}
+
+		for _, user := range users {
+			suspended := ""
+			if user.Suspended {
+				suspended = " (suspended)"
+			} else if user.PendingApproval {
+				suspended = " (pending approval)"
+			}
+			fmt.Printf("User ID: %d, Name: %s, Email: %s, Role: %s%s\n", user.ID, user.Name, user.Email, user.Role, suspended)
+		}
+
+		if nextCursor == 0 {
+			return
+		}
+
+		loadMore := ""
+		fmt.Println("\nLoad more users? (yes/no)")
+		fmt.Scanln(&loadMore)
+		if loadMore != "yes" {
+			return
+		}
+		cursor = nextCursor
+	}
+}
+
+func (h *Handlers) HandleCreateUser(token string) {
+	var role string
+	for {
+		fmt.Println("Enter role (customer/owner/staff/admin):")
+		fmt.Scanln(&role)
+		if domain.UserRole(role).IsValid() {
+			break
+		}
+		fmt.Println("Invalid role. Please try again.")
+	}
+
+	name, email, password := readNewUser()
+
+	createResp, err := h.apiClient.PostAdminUser(name, email, password, role, token)
+	if err != nil {
+		fmt.Println("Error while creating user:", err)
+		return
+	}
+
+	fmt.Printf("User created successfully with ID: %d\n", createResp.UserID)
+}
+
+func (h *Handlers) HandleApproveOwner(token string) {
+	users, _, err := h.apiClient.GetAdminUsers("", "", true, 0, token)
+	if err != nil {
+		fmt.Println("Error while fetching users:", err)
+		return
+	}
+	if len(users) == 0 {
+		fmt.Println("No owners are waiting for approval.")
+		return
+	}
+	for _, user := range users {
+		fmt.Printf("User ID: %d, Name: %s, Email: %s, Role: %s\n", user.ID, user.Name, user.Email, user.Role)
+	}
+
+	var userId int
+	fmt.Println("\nEnter User ID to approve (0 to go back):")
+	fmt.Scanln(&userId)
+	if userId == 0 {
+		return
+	}
+
+	err = h.apiClient.PostUserAction(userId, "approve", token)
+	if err != nil {
+		fmt.Println("Error while approving user:", err)
+		return
+	}
+
+	fmt.Println("User approved successfully, they can log in now.")
+}
+
+func (h *Handlers) HandleChangeUserRole(token string) {
+	var userId int
+	var role string
+
+	fmt.Println("Enter User ID:")
+	fmt.Scanln(&userId)
+
+	for {
+		fmt.Println("Enter new role (customer/owner/staff/admin):")
+		fmt.Scanln(&role)
+		if domain.UserRole(role).IsValid() {
+			break
+		}
+		fmt.Println("Invalid role. Please try again.")
+	}
+
+	err := h.apiClient.PatchUserRole(userId, role, token)
+	if err != nil {
+		fmt.Println("Error while changing user role:", err)
+		return
+	}
+
+	fmt.Println("User role changed successfully. It applies once their current access token expires.")
+}
+
+func (h *Handlers) HandleSuspendUser(token string) {
+	var userId int
+	var action string
+
+	fmt.Println("Enter User ID:")
+	fmt.Scanln(&userId)
+
+	for {
+		fmt.Println("Enter action (suspend/reinstate):")
+		fmt.Scanln(&action)
+		if action == "suspend" || action == "reinstate" {
+			break
+		}
+		fmt.Println("Invalid action. Please try again.")
+	}
+
+	err := h.apiClient.PostUserAction(userId, action, token)
+	if err != nil {
+		fmt.Println("Error while updating user:", err)
+		return
+	}
+
+	if action == "suspend" {
+		fmt.Println("User suspended successfully.")
+	} else {
+		fmt.Println("User reinstated successfully.")
+	}
+}
+
+func (h *Handlers) HandleViewAllOrders(token string) {
+	var customerId int
+	var restaurantId int
+	var statusInput string
+
+	fmt.Println("Filter by Customer ID (0 for all):")
+	fmt.Scanln(&customerId)
+
+	fmt.Println("Filter by Restaurant ID (0 for all):")
+	fmt.Scanln(&restaurantId)
+
+	fmt.Println("Filter by status (comma separated, empty for all):")
+	fmt.Scanln(&statusInput)
+
+	statuses := []string{}
+	if statusInput != "" {
+		statuses = strings.Split(statusInput, ",")
+	}
+
+	cursor := 0
+	for {
+		orders, nextCursor, err := h.apiClient.GetAdminOrders(customerId, restaurantId, statuses, cursor, token)
+		if err != nil {
+			fmt.Println("Error while fetching orders:", err)
+			return
+		}
+
+		if len(orders) == 0 && cursor == 0 {
+			fmt.Println("No orders found.")
+			return
+		}
+
+		for _, order := range orders {
+			fmt.Printf("Order ID: %d, Customer ID: %d, Restaurant ID: %d, Status: %s, Placed At: %s\n",
+				order.ID, order.CustomerID, order.RestaurantID, order.Status, order.CreatedAt.Local().Format("2006-01-02 15:04"))
+		}
+
+		if nextCursor == 0 {
+			return
+		}
+
+		loadMore := ""
+		fmt.Println("\nLoad more orders? (yes/no)")
+		fmt.Scanln(&loadMore)
+		if loadMore != "yes" {
+			return
+		}
+		cursor = nextCursor
+	}
+}
+
+func (h *Handlers) HandleForceCancelOrder(token string) {
+	var orderId int
+
+	fmt.Println("Enter Order ID:")
+	fmt.Scanln(&orderId)
+
+	err := h.apiClient.PostAdminCancelOrder(orderId, token)
+	if err != nil {
+		fmt.Println("Error while cancelling order:", err)
+		return
+	}
+
+	fmt.Println("Order cancelled successfully. Any paid invoice is marked for refund.")
+}
+
+func (h *Handlers) HandleViewAllInvoices(token string) {
+	var statusInput string
+
+	fmt.Println("Filter by payment status (comma separated, empty for all):")
+	fmt.Scanln(&statusInput)
+
+	statuses := []string{}
+	if statusInput != "" {
+		statuses = strings.Split(statusInput, ",")
+	}
+
+	cursor := 0
+	for {
+		invoices, nextCursor, err := h.apiClient.GetAdminInvoices(statuses, cursor, token)
+		if err != nil {
+			fmt.Println("Error while fetching invoices:", err)
+			return
+		}
+
+		if len(invoices) == 0 && cursor == 0 {
+			fmt.Println("No invoices found.")
+			return
+		}
+
+		for _, invoice := range invoices {
+			fmt.Printf("Invoice ID: %d, Order ID: %d, Total: %s, Tax: %s, Payment Status: %s\n",
+				invoice.ID, invoice.OrderID, invoice.Total.ToDomain(), invoice.Tax.ToDomain(), invoice.PaymentStatus)
+		}
+
+		if nextCursor == 0 {
+			return
+		}
+
+		loadMore := ""
+		fmt.Println("\nLoad more invoices? (yes/no)")
+		fmt.Scanln(&loadMore)
+		if loadMore != "yes" {
+			return
+		}
+		cursor = nextCursor
+	}
+}
+
+func (h *Handlers) HandleViewStaff(token string, ownerId int) {
+	var restaurantId int
+
+	fmt.Println("--------- Choose Restaurant ----------")
+	h.HandleViewRestaurants(ownerId)
+	fmt.Printf("\n--------------------------------------\n\n")
+
+	fmt.Println("Enter Restaurant ID:")
+	fmt.Scanln(&restaurantId)
+
+	members, err := h.apiClient.GetRestaurantMembers(restaurantId, token)
+	if err != nil {
+		fmt.Println("Error while fetching staff:", err)
+		return
+	}
+
+	if len(members) == 0 {
+		fmt.Println("No staff found for this restaurant.")
+		return
+	}
+
+	fmt.Printf("Staff of Restaurant ID %d:\n", restaurantId)
+	for _, member := range members {
+		fmt.Printf("User ID: %d, Name: %s, Email: %s, Role: %s\n", member.UserID, member.Name, member.Email, member.Role)
+	}
+}
+
+func (h *Handlers) HandleAddStaff(token string, ownerId int) {
+	var restaurantId int
+	var email string
+	var role string
+
+	fmt.Println("--------- Choose Restaurant ----------")
+	h.HandleViewRestaurants(ownerId)
+	fmt.Printf("\n--------------------------------------\n\n")
+
+	fmt.Println("Enter Restaurant ID:")
+	fmt.Scanln(&restaurantId)
+
+	fmt.Println("Enter email of the staff account:")
+	fmt.Scanln(&email)
+
+	for {
+		fmt.Println("Enter staff role (manager/cashier/kitchen):")
+		fmt.Scanln(&role)
+		if domain.StaffRole(role).IsValid() {
+			break
+		}
+		fmt.Println("Invalid role. Please try again.")
+	}
+
+	member, err := h.apiClient.PostRestaurantMember(restaurantId, email, role, token)
+	if err != nil {
+		fmt.Println("Error while adding staff:", err)
+		return
+	}
+
+	fmt.Printf("%s (User ID: %d) added as %s.\n", member.Name, member.UserID, member.Role)
+}
+
+func (h *Handlers) HandleRemoveStaff(token string) {
+	var restaurantId int
+	var userId int
+
+	fmt.Println("Enter Restaurant ID:")
+	fmt.Scanln(&restaurantId)
+
+	fmt.Println("Enter User ID of the staff member:")
+	fmt.Scanln(&userId)
+
+	err := h.apiClient.DeleteRestaurantMember(restaurantId, userId, token)
+	if err != nil {
+		fmt.Println("Error while removing staff:", err)
+		return
+	}
+
+	fmt.Println("Staff member removed successfully.")
+}
+
+// HandleEditRestaurantProfile updates the profile of a restaurant, empty
+// input keeps the current value.
+func (h *Handlers) HandleEditRestaurantProfile(token string) {
+	var restaurantId int
+	fmt.Println("Enter Restaurant ID:")
+	fmt.Scanln(&restaurantId)
+
+	restaurant, err := h.apiClient.GetRestaurant(restaurantId)
+	if err != nil {
+		fmt.Println("Error while fetching restaurant:", err)
+		return
+	}
+
+	reader := bufio.NewReader(os.Stdin)
+	readLine := func(prompt, current string) string {
+		fmt.Printf("%s [%s]:\n", prompt, current)
+		input, _ := reader.ReadString('\n')
+		input = strings.TrimSpace(input)
+		if input == "" {
+			return current
+		}
+		return input
+	}
+	splitList := func(input string) []string {
+		var values []string
+		for _, value := range strings.Split(input, ",") {
+			if value = strings.TrimSpace(value); value != "" {
+				values = append(values, value)
+			}
+		}
+		return values
+	}
+
+	restaurant.Name = readLine("Name", restaurant.Name)
+	restaurant.Address = readLine("Address", restaurant.Address)
+	restaurant.Phone = readLine("Phone", restaurant.Phone)
+	restaurant.Cuisines = splitList(readLine("Cuisines (comma separated)", strings.Join(restaurant.Cuisines, ", ")))
+	restaurant.Closures = splitList(readLine("Closed on (YYYY-MM-DD, comma separated)", strings.Join(restaurant.Closures, ", ")))
+
+	if readLine("Replace opening hours (yes/no)", "no") == "yes" {
+		restaurant.OpeningHours = nil
+		for {
+			dayStr := readLine("Day (e.g. monday, empty to finish, a restaurant without hours is open all week)", "")
+			if dayStr == "" {
+				break
+			}
+			day, okDay := domain.ParseWeekday(dayStr)
+			start, okStart := domain.ParseTimeOfDay(readLine("Opens at (HH:MM)", ""))
+			end, okEnd := domain.ParseTimeOfDay(readLine("Closes at (HH:MM)", ""))
+			if !okDay || !okStart || !okEnd {
+				fmt.Println("Invalid day or time, use a day like monday and times like 07:30.")
+				continue
+			}
+			restaurant.OpeningHours = append(restaurant.OpeningHours, domain.OpeningHours{Weekday: day, Start: start, End: end})
+		}
+	}
+
+	if err := h.apiClient.PutRestaurant(*restaurant, token); err != nil {
+		fmt.Println("Error while updating restaurant:", err)
+		return
+	}
+
+	fmt.Println("Restaurant updated successfully.")
+}
+
+// HandlePauseOrders stops or resumes a restaurant taking orders.
+func (h *Handlers) HandlePauseOrders(token string) {
+	var restaurantId int
+	var input string
+
+	fmt.Println("Enter Restaurant ID:")
+	fmt.Scanln(&restaurantId)
+
+	fmt.Println("Pause orders? (yes to pause, no to resume):")
+	fmt.Scanln(&input)
+
+	paused := input == "yes"
+	if err := h.apiClient.PatchRestaurantPause(restaurantId, paused, token); err != nil {
+		fmt.Println("Error while updating restaurant:", err)
+		return
+	}
+
+	if paused {
+		fmt.Println("Orders paused, the restaurant takes no orders until they are resumed.")
+	} else {
+		fmt.Println("Orders resumed.")
+	}
+}
+
+// HandleViewMyRestaurants lists the restaurants the staff user works at and
+// their role in each.
+func (h *Handlers) HandleViewMyRestaurants(token string) {
+	memberships, err := h.apiClient.GetStaffRestaurants(token)
+	if err != nil {
+		fmt.Println("Error while fetching your restaurants:", err)
+		return
+	}
+
+	if len(memberships) == 0 {
+		fmt.Println("You are not part of any restaurant staff yet.")
+		return
+	}
+
+	restaurants, err := h.apiClient.GetRestaurants()
+	if err != nil {
+		fmt.Println("Error while fetching restaurants:", err)
+		return
+	}
+	names := make(map[int]string)
+	for _, r := range restaurants {
+		names[r.ID] = r.Name
+	}
+
+	fmt.Println("Your Restaurants:")
+	for _, m := range memberships {
+		fmt.Printf("ID: %d, Name: %s, Role: %s\n", m.RestaurantID, names[m.RestaurantID], m.Role)
+	}
+}
+
+// HandleTakePayment lets a cashier settle the invoice of a customer's order.
+func (h *Handlers) HandleTakePayment(token string) {
+	var invoiceId int
+
+	fmt.Println("Enter Invoice ID:")
+	fmt.Scanln(&invoiceId)
+
+	invoice, err := h.apiClient.GetInvoiceById(invoiceId, token)
+	if err != nil {
+		fmt.Println("Error while fetching invoice:", err)
+		return
+	}
+
+	if domain.PaymentStatus(invoice.PaymentStatus) == domain.Paid {
+		fmt.Println("Invoice is already paid.")
+		return
+	}
+	h.HandlePayBill(token, invoice.ID, invoice.ToPay.ToDomain())
 }
@@ -28,9 +28,13 @@ func decodeJwt(token string) authctx.UserClaims {
 		return authctx.UserClaims{}
 	}
 
-	return authctx.UserClaims{
+	userClaims := authctx.UserClaims{
 		UserID: int(userIDFloat),
 		Role:   domain.UserRole(role),
 	}
+	if exp, err := claims.GetExpirationTime(); err == nil && exp != nil {
+		userClaims.ExpiresAt = exp.Time
+	}
+	return userClaims
 }
 
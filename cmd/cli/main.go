@@ -5,6 +5,7 @@ import (
 	"os"
 	"os/exec"
 	"runtime"
+	"time"
 
 	apiclient "github.com/mohits-git/food-ordering-system/cmd/cli/api_client"
 	"github.com/mohits-git/food-ordering-system/cmd/cli/handlers"
@@ -12,8 +13,12 @@ import (
 )
 
 var jwtToken string
+var refreshToken string
 var userClaims authctx.UserClaims
 
+// access tokens are renewed when they are this close to expiring
+const tokenRefreshMargin = time.Minute
+
 func main() {
 	apiClient := apiclient.NewAPIClient("http://localhost:8080")
 	handler := handlers.NewHandlers(apiClient)
@@ -31,9 +36,13 @@ func startRepl(handler *handlers.Handlers) {
 			whenCustomerLoggedIn(handler)
 		} else if userClaims.Role == "owner" {
 			whenRestaurantOwnerLoggedIn(handler)
+		} else if userClaims.Role == "staff" {
+			whenStaffLoggedIn(handler)
+		} else if userClaims.Role == "admin" {
+			whenAdminLoggedIn(handler)
 		} else {
 			fmt.Println("Unknown user role. Logging out for safety.")
-			jwtToken = ""
+			jwtToken, refreshToken = "", ""
 			userClaims = authctx.UserClaims{}
 		}
 		fmt.Printf("\nPress Enter to continue...\n")
@@ -56,11 +65,20 @@ func whenUserNotLoggedIn(handlers *handlers.Handlers) {
 		fmt.Println("Exiting...")
 		os.Exit(0)
 	case 1:
-		jwtToken, userClaims = handlers.HandleLogin()
+		jwtToken, refreshToken, userClaims = handlers.HandleLogin()
 	case 2:
 		handlers.HandleRegisterCustomer()
 	case 3:
 		handlers.HandleRegisterRestaurantOwner()
+	case 4:
+		handlers.HandleRegisterStaff()
+	}
+}
+
+// refreshSession renews the access token when it is about to expire.
+func refreshSession(handler *handlers.Handlers) {
+	if time.Until(userClaims.ExpiresAt) < tokenRefreshMargin {
+		jwtToken, refreshToken, userClaims = handler.HandleRefreshSession(refreshToken)
 	}
 }
 
@@ -73,6 +91,7 @@ func printLoggedOutMenu() {
   1. Login
   2. Register as Customer
   3. Register as Restaurant Owner
+  4. Register as Restaurant Staff
  
 `
 	fmt.Println(menu)
@@ -86,6 +105,12 @@ func whenCustomerLoggedIn(handlers *handlers.Handlers) {
 	fmt.Scan(&action)
 	fmt.Println()
 
+	// the menu may have been open for a while
+	refreshSession(handlers)
+	if jwtToken == "" {
+		return
+	}
+
 	clearScreen()
 	switch action {
 	case 0:
@@ -98,8 +123,14 @@ func whenCustomerLoggedIn(handlers *handlers.Handlers) {
 	case 3:
 		handlers.HandlePlaceOrder(jwtToken)
 	case 4:
+		handlers.HandleViewMyOrders(jwtToken)
+	case 5:
+		handlers.HandleCancelOrder(jwtToken)
+	case 6:
+		handlers.HandleSearchRestaurants()
+	case 7:
 		handlers.HandleLogout(jwtToken)
-		jwtToken = ""
+		jwtToken, refreshToken = "", ""
 		userClaims = authctx.UserClaims{}
 	}
 }
@@ -113,7 +144,10 @@ func printCustomerMenu() {
   1. View Restaurants
   2. View Restaurants Menu Items
   3. Place Order
-  4. Logout
+  4. My Orders
+  5. Cancel Order
+  6. Search Restaurants
+  7. Logout
  
 `
 	fmt.Println(menu)
@@ -127,6 +161,12 @@ func whenRestaurantOwnerLoggedIn(handlers *handlers.Handlers) {
 	fmt.Scan(&action)
 	fmt.Println()
 
+	// the menu may have been open for a while
+	refreshSession(handlers)
+	if jwtToken == "" {
+		return
+	}
+
 	clearScreen()
 	switch action {
 	case 0:
@@ -143,8 +183,32 @@ func whenRestaurantOwnerLoggedIn(handlers *handlers.Handlers) {
 	case 5:
 		handlers.HandleUpdateMenuItemAvailability(jwtToken)
 	case 6:
+		handlers.HandleViewRestaurantOrders(jwtToken, userClaims.UserID)
+	case 7:
+		handlers.HandleUpdateOrderStatus(jwtToken)
+	case 8:
+		handlers.HandleViewStaff(jwtToken, userClaims.UserID)
+	case 9:
+		handlers.HandleAddStaff(jwtToken, userClaims.UserID)
+	case 10:
+		handlers.HandleRemoveStaff(jwtToken)
+	case 11:
+		handlers.HandleEditMenuItem(jwtToken)
+	case 12:
+		handlers.HandleDeleteMenuItem(jwtToken)
+	case 13:
+		handlers.HandleAddModifierGroup(jwtToken)
+	case 14:
+		handlers.HandleCreateMenu(jwtToken)
+	case 15:
+		handlers.HandleSetMenuItemStock(jwtToken)
+	case 16:
+		handlers.HandleEditRestaurantProfile(jwtToken)
+	case 17:
+		handlers.HandlePauseOrders(jwtToken)
+	case 18:
 		handlers.HandleLogout(jwtToken)
-		jwtToken = ""
+		jwtToken, refreshToken = "", ""
 		userClaims = authctx.UserClaims{}
 	}
 }
@@ -160,7 +224,163 @@ func printRestaurantOwnerMenu() {
   3. Add Restaurant
   4. Add Menu Item to Restaurant
   5. Update Menu Item Availability
-  6. Logout
+  6. View Restaurant Orders
+  7. Accept / Reject / Mark Ready / Cancel Order
+  8. View Staff
+  9. Add Staff
+  10. Remove Staff
+  11. Edit Menu Item
+  12. Delete Menu Item
+  13. Add Options to Menu Item
+  14. Create Menu
+  15. Set Menu Item Stock
+  16. Edit Restaurant Profile
+  17. Pause / Resume Orders
+  18. Logout
+ 
+`
+	fmt.Println(menu)
+}
+
+// whenStaffLoggedIn offers every staff action, the server only allows the ones
+// of the user's role in each restaurant.
+func whenStaffLoggedIn(handlers *handlers.Handlers) {
+	printStaffMenu()
+
+	action := -1
+	fmt.Println("Choose an action:")
+	fmt.Scan(&action)
+	fmt.Println()
+
+	// the menu may have been open for a while
+	refreshSession(handlers)
+	if jwtToken == "" {
+		return
+	}
+
+	clearScreen()
+	switch action {
+	case 0:
+		fmt.Println("Exiting...")
+		os.Exit(0)
+	case 1:
+		handlers.HandleViewMyRestaurants(jwtToken)
+	case 2:
+		handlers.HandleViewRestaurantMenuItems()
+	case 3:
+		handlers.HandleAddMenuItemToRestaurant(jwtToken, 0)
+	case 4:
+		handlers.HandleUpdateMenuItemAvailability(jwtToken)
+	case 5:
+		handlers.HandleViewRestaurantOrders(jwtToken, 0)
+	case 6:
+		handlers.HandleUpdateOrderStatus(jwtToken)
+	case 7:
+		handlers.HandleTakePayment(jwtToken)
+	case 8:
+		handlers.HandleEditMenuItem(jwtToken)
+	case 9:
+		handlers.HandleDeleteMenuItem(jwtToken)
+	case 10:
+		handlers.HandleAddModifierGroup(jwtToken)
+	case 11:
+		handlers.HandleCreateMenu(jwtToken)
+	case 12:
+		handlers.HandleSetMenuItemStock(jwtToken)
+	case 13:
+		handlers.HandleEditRestaurantProfile(jwtToken)
+	case 14:
+		handlers.HandlePauseOrders(jwtToken)
+	case 15:
+		handlers.HandleLogout(jwtToken)
+		jwtToken, refreshToken = "", ""
+		userClaims = authctx.UserClaims{}
+	}
+}
+
+func printStaffMenu() {
+	menu := `
+  Welcome Restaurant Staff!
+ 
+  Available actions:
+  0. Exit
+  1. My Restaurants
+  2. View Restaurants Menu Items
+  3. Add Menu Item (manager)
+  4. Update Menu Item Availability (manager)
+  5. View Restaurant Orders
+  6. Accept / Reject / Mark Ready Order (kitchen)
+  7. Take Payment (cashier)
+  8. Edit Menu Item (manager)
+  9. Delete Menu Item (manager)
+  10. Add Options to Menu Item (manager)
+  11. Create Menu (manager)
+  12. Set Menu Item Stock (manager)
+  13. Edit Restaurant Profile (manager)
+  14. Pause / Resume Orders (manager, kitchen)
+  15. Logout
+ 
+`
+	fmt.Println(menu)
+}
+
+func whenAdminLoggedIn(handlers *handlers.Handlers) {
+	printAdminMenu()
+
+	action := -1
+	fmt.Println("Choose an action:")
+	fmt.Scan(&action)
+	fmt.Println()
+
+	// the menu may have been open for a while
+	refreshSession(handlers)
+	if jwtToken == "" {
+		return
+	}
+
+	clearScreen()
+	switch action {
+	case 0:
+		fmt.Println("Exiting...")
+		os.Exit(0)
+	case 1:
+		handlers.HandleViewUsers(jwtToken)
+	case 2:
+		handlers.HandleChangeUserRole(jwtToken)
+	case 3:
+		handlers.HandleSuspendUser(jwtToken)
+	case 4:
+		handlers.HandleViewAllOrders(jwtToken)
+	case 5:
+		handlers.HandleForceCancelOrder(jwtToken)
+	case 6:
+		handlers.HandleViewAllInvoices(jwtToken)
+	case 7:
+		handlers.HandleCreateUser(jwtToken)
+	case 8:
+		handlers.HandleApproveOwner(jwtToken)
+	case 9:
+		handlers.HandleLogout(jwtToken)
+		jwtToken, refreshToken = "", ""
+		userClaims = authctx.UserClaims{}
+	}
+}
+
+func printAdminMenu() {
+	menu := `
+  Welcome Admin!
+ 
+  Available actions:
+  0. Exit
+  1. View / Search Users
+  2. Change User Role
+  3. Suspend / Reinstate User
+  4. View All Orders
+  5. Force Cancel Order
+  6. View All Invoices
+  7. Create User
+  8. Approve Owners
+  9. Logout
  
 `
 	fmt.Println(menu)
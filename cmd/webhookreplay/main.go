@@ -0,0 +1,73 @@
+// webhookreplay signs payment provider events the way the fake gateway does
+// and posts them to the webhook endpoint, one event per line of the input.
+//
+//	echo '{"id":"evt_1","type":"payment.captured","transaction_id":"fake_1"}' | go run ./cmd/webhookreplay
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"os"
+
+	"github.com/mohits-git/food-ordering-system/internal/adapters/fakegateway"
+	"github.com/mohits-git/food-ordering-system/internal/adapters/http/handlers"
+)
+
+func main() {
+	url := flag.String("url", "http://localhost:8080/api/payments/webhook", "webhook endpoint")
+	secret := flag.String("secret", os.Getenv("PAYMENT_WEBHOOK_SECRET"), "webhook signing secret, defaults to PAYMENT_WEBHOOK_SECRET")
+	file := flag.String("file", "-", "file with one JSON event per line, - for stdin")
+	flag.Parse()
+
+	if *secret == "" {
+		*secret = "webhook_secret"
+	}
+
+	input := os.Stdin
+	if *file != "-" {
+		f, err := os.Open(*file)
+		if err != nil {
+			log.Fatal("Failed to open events file: ", err)
+		}
+		defer f.Close()
+		input = f
+	}
+
+	scanner := bufio.NewScanner(input)
+	for scanner.Scan() {
+		payload := bytes.TrimSpace(scanner.Bytes())
+		if len(payload) == 0 {
+			continue
+		}
+		if err := replay(*url, *secret, payload); err != nil {
+			log.Fatal("Failed to replay event: ", err)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		log.Fatal("Failed to read events: ", err)
+	}
+}
+
+func replay(url string, secret string, payload []byte) error {
+	req, err := http.NewRequest("POST", url, bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(handlers.WebhookSignatureHeader, fakegateway.Sign(secret, payload))
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	body, _ := io.ReadAll(resp.Body)
+	fmt.Printf("%s -> %d %s\n", payload, resp.StatusCode, bytes.TrimSpace(body))
+	return nil
+}
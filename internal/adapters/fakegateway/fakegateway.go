@@ -0,0 +1,191 @@
+package fakegateway
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"math/rand/v2"
+	"sync"
+	"time"
+
+	"github.com/mohits-git/food-ordering-system/internal/domain"
+	"github.com/mohits-git/food-ordering-system/internal/utils/apperr"
+)
+
+// FakeGateway is an in memory payment provider for local development. The
+// share of authorizations given by successRate goes through and the rest is
+// declined. An authorization stays pending for latency before its outcome
+// shows up in Status. Webhooks are signed with webhookSecret, see Sign.
+type FakeGateway struct {
+	successRate   float64
+	latency       time.Duration
+	webhookSecret string
+
+	mu           sync.Mutex
+	nextId       int
+	transactions map[string]*transaction
+}
+
+type transaction struct {
+	amount    domain.Money
+	refunded  domain.Money
+	status    domain.GatewayStatus
+	outcome   domain.GatewayStatus
+	resolveAt time.Time
+}
+
+// WebhookEvent is the body of a webhook sent by the fake provider.
+type WebhookEvent struct {
+	ID            string `json:"id"`
+	Type          string `json:"type"`
+	TransactionID string `json:"transaction_id"`
+	Message       string `json:"message,omitempty"`
+}
+
+func NewFakeGateway(successRate float64, latency time.Duration, webhookSecret string) *FakeGateway {
+	return &FakeGateway{
+		successRate:   successRate,
+		latency:       latency,
+		webhookSecret: webhookSecret,
+		transactions:  make(map[string]*transaction),
+	}
+}
+
+// Sign returns the hex encoded HMAC-SHA256 of a webhook payload.
+func Sign(secret string, payload []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(payload)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func (g *FakeGateway) Name() string {
+	return "fake"
+}
+
+func (g *FakeGateway) Authorize(ctx context.Context, reference string, amount domain.Money) (domain.GatewayResult, error) {
+	if amount.IsZero() || amount.IsNegative() {
+		return domain.GatewayResult{}, apperr.NewAppError(apperr.ErrInvalid, "amount must be positive", nil)
+	}
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	g.nextId++
+	id := fmt.Sprintf("fake_%d", g.nextId)
+
+	outcome := domain.GatewayAuthorized
+	if rand.Float64() >= g.successRate {
+		outcome = domain.GatewayDeclined
+	}
+	tx := &transaction{
+		amount:    amount,
+		status:    domain.GatewayPending,
+		outcome:   outcome,
+		resolveAt: time.Now().Add(g.latency),
+	}
+	g.transactions[id] = tx
+	return g.result(id, tx), nil
+}
+
+func (g *FakeGateway) Capture(ctx context.Context, transactionId string) (domain.GatewayResult, error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	tx, err := g.transaction(transactionId)
+	if err != nil {
+		return domain.GatewayResult{}, err
+	}
+	if tx.status != domain.GatewayAuthorized {
+		return domain.GatewayResult{}, apperr.NewAppError(apperr.ErrInvalid, "transaction is not authorized", nil)
+	}
+	tx.status = domain.GatewayCaptured
+	return g.result(transactionId, tx), nil
+}
+
+func (g *FakeGateway) Refund(ctx context.Context, transactionId string, amount domain.Money) (domain.GatewayResult, error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	tx, err := g.transaction(transactionId)
+	if err != nil {
+		return domain.GatewayResult{}, err
+	}
+	if tx.status != domain.GatewayCaptured {
+		return domain.GatewayResult{}, apperr.NewAppError(apperr.ErrInvalid, "transaction is not captured", nil)
+	}
+	if amount.IsZero() || amount.IsNegative() || !amount.SameCurrency(tx.amount) {
+		return domain.GatewayResult{}, apperr.NewAppError(apperr.ErrInvalid, "invalid refund amount", nil)
+	}
+	refunded := tx.refunded.Add(amount)
+	if tx.amount.Sub(refunded).IsNegative() {
+		return domain.GatewayResult{}, apperr.NewAppError(apperr.ErrInvalid, "refund exceeds the captured amount", nil)
+	}
+	tx.refunded = refunded
+	if refunded == tx.amount {
+		tx.status = domain.GatewayRefunded
+	}
+	return g.result(transactionId, tx), nil
+}
+
+func (g *FakeGateway) Status(ctx context.Context, transactionId string) (domain.GatewayResult, error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	tx, err := g.transaction(transactionId)
+	if err != nil {
+		return domain.GatewayResult{}, err
+	}
+	return g.result(transactionId, tx), nil
+}
+
+func (g *FakeGateway) ParseWebhook(payload []byte, signature string) (domain.PaymentEvent, error) {
+	expected := Sign(g.webhookSecret, payload)
+	if g.webhookSecret == "" || !hmac.Equal([]byte(expected), []byte(signature)) {
+		return domain.PaymentEvent{}, apperr.NewAppError(apperr.ErrUnauthorized, "invalid webhook signature", nil)
+	}
+
+	var event WebhookEvent
+	if err := json.Unmarshal(payload, &event); err != nil {
+		return domain.PaymentEvent{}, apperr.NewAppError(apperr.ErrInvalid, "invalid webhook payload", err)
+	}
+	if event.ID == "" || event.Type == "" || event.TransactionID == "" {
+		return domain.PaymentEvent{}, apperr.NewAppError(apperr.ErrInvalid, "invalid webhook payload", nil)
+	}
+
+	return domain.PaymentEvent{
+		Provider:      g.Name(),
+		EventID:       event.ID,
+		Type:          domain.PaymentEventType(event.Type),
+		TransactionID: event.TransactionID,
+		Message:       event.Message,
+		Payload:       string(payload),
+	}, nil
+}
+
+// transaction looks up a transaction, the caller must hold the lock.
+func (g *FakeGateway) transaction(id string) (*transaction, error) {
+	tx, ok := g.transactions[id]
+	if !ok {
+		return nil, apperr.NewAppError(apperr.ErrNotFound, "transaction not found", nil)
+	}
+	tx.settle()
+	return tx, nil
+}
+
+func (g *FakeGateway) result(id string, tx *transaction) domain.GatewayResult {
+	tx.settle()
+	result := domain.GatewayResult{TransactionID: id, Status: tx.status}
+	if tx.status == domain.GatewayDeclined {
+		result.Message = "card declined"
+	}
+	return result
+}
+
+func (tx *transaction) settle() {
+	if tx.status == domain.GatewayPending && !time.Now().Before(tx.resolveAt) {
+		tx.status = tx.outcome
+	}
+}
@@ -0,0 +1,105 @@
+package fakegateway
+
+import (
+	"testing"
+	"time"
+
+	"github.com/mohits-git/food-ordering-system/internal/domain"
+	"github.com/mohits-git/food-ordering-system/internal/utils/apperr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_fakegateway_FakeGateway_Authorize_and_Capture(t *testing.T) {
+	gateway := NewFakeGateway(1, 0, "secret")
+	amount := domain.NewMoney(1250, "USD")
+
+	result, err := gateway.Authorize(t.Context(), "invoice-1", amount)
+	require.NoError(t, err)
+	assert.Equal(t, domain.GatewayAuthorized, result.Status)
+	assert.NotEmpty(t, result.TransactionID)
+
+	result, err = gateway.Capture(t.Context(), result.TransactionID)
+	require.NoError(t, err)
+	assert.Equal(t, domain.GatewayCaptured, result.Status)
+}
+
+func Test_fakegateway_FakeGateway_Authorize_declined(t *testing.T) {
+	gateway := NewFakeGateway(0, 0, "secret")
+
+	result, err := gateway.Authorize(t.Context(), "invoice-1", domain.NewMoney(1250, "USD"))
+	require.NoError(t, err)
+	assert.Equal(t, domain.GatewayDeclined, result.Status)
+	assert.NotEmpty(t, result.Message)
+
+	_, err = gateway.Capture(t.Context(), result.TransactionID)
+	assert.True(t, apperr.IsInvalidError(err), "expected declined transaction not to be captured")
+}
+
+func Test_fakegateway_FakeGateway_Authorize_with_latency(t *testing.T) {
+	gateway := NewFakeGateway(1, 20*time.Millisecond, "secret")
+
+	result, err := gateway.Authorize(t.Context(), "invoice-1", domain.NewMoney(1250, "USD"))
+	require.NoError(t, err)
+	assert.Equal(t, domain.GatewayPending, result.Status)
+
+	require.Eventually(t, func() bool {
+		result, err = gateway.Status(t.Context(), result.TransactionID)
+		return err == nil && result.Status == domain.GatewayAuthorized
+	}, time.Second, 5*time.Millisecond)
+}
+
+func Test_fakegateway_FakeGateway_Authorize_invalid_amount(t *testing.T) {
+	gateway := NewFakeGateway(1, 0, "secret")
+
+	_, err := gateway.Authorize(t.Context(), "invoice-1", domain.NewMoney(0, "USD"))
+	assert.True(t, apperr.IsInvalidError(err))
+}
+
+func Test_fakegateway_FakeGateway_Refund(t *testing.T) {
+	gateway := NewFakeGateway(1, 0, "secret")
+	result, err := gateway.Authorize(t.Context(), "invoice-1", domain.NewMoney(1000, "USD"))
+	require.NoError(t, err)
+	_, err = gateway.Capture(t.Context(), result.TransactionID)
+	require.NoError(t, err)
+
+	result, err = gateway.Refund(t.Context(), result.TransactionID, domain.NewMoney(400, "USD"))
+	require.NoError(t, err)
+	assert.Equal(t, domain.GatewayCaptured, result.Status, "expected partial refund to keep the transaction captured")
+
+	_, err = gateway.Refund(t.Context(), result.TransactionID, domain.NewMoney(700, "USD"))
+	assert.True(t, apperr.IsInvalidError(err), "expected refund over the captured amount to fail")
+
+	result, err = gateway.Refund(t.Context(), result.TransactionID, domain.NewMoney(600, "USD"))
+	require.NoError(t, err)
+	assert.Equal(t, domain.GatewayRefunded, result.Status)
+}
+
+func Test_fakegateway_FakeGateway_Status_not_found(t *testing.T) {
+	gateway := NewFakeGateway(1, 0, "secret")
+
+	_, err := gateway.Status(t.Context(), "unknown")
+	assert.True(t, apperr.IsNotFoundError(err))
+}
+
+func Test_fakegateway_FakeGateway_ParseWebhook(t *testing.T) {
+	gateway := NewFakeGateway(1, 0, "secret")
+	payload := []byte(`{"id":"evt_1","type":"payment.captured","transaction_id":"fake_1"}`)
+
+	event, err := gateway.ParseWebhook(payload, Sign("secret", payload))
+	require.NoError(t, err)
+	assert.Equal(t, domain.PaymentEvent{
+		Provider:      "fake",
+		EventID:       "evt_1",
+		Type:          domain.EventPaymentCaptured,
+		TransactionID: "fake_1",
+		Payload:       string(payload),
+	}, event)
+
+	_, err = gateway.ParseWebhook(payload, Sign("other", payload))
+	assert.True(t, apperr.IsUnauthorizedError(err), "expected signature with another secret to be rejected")
+
+	invalid := []byte(`{"id":"evt_2"}`)
+	_, err = gateway.ParseWebhook(invalid, Sign("secret", invalid))
+	assert.True(t, apperr.IsInvalidError(err), "expected payload without type to be rejected")
+}
@@ -1,27 +1,122 @@
 package dtos
 
-import "github.com/mohits-git/food-ordering-system/internal/domain"
+import (
+	"time"
+
+	"github.com/mohits-git/food-ordering-system/internal/domain"
+)
 
 type InvoiceResponse struct {
-	ID            int     `json:"id"`
-	OrderID       int     `json:"order_id"`
-	Total         float64 `json:"total"`
-	Tax           float64 `json:"tax"`
-	ToPay         float64 `json:"to_pay"`
-	PaymentStatus string  `json:"payment_status"`
+	ID            int              `json:"id"`
+	OrderID       int              `json:"order_id"`
+	Total         MoneyDTO         `json:"total"`
+	Tax           MoneyDTO         `json:"tax"`
+	ToPay         MoneyDTO         `json:"to_pay"`
+	PaymentStatus string           `json:"payment_status"`
+	Items         []InvoiceItemDTO `json:"items"`
+}
+
+type GetInvoicesResponse struct {
+	Invoices   []InvoiceResponse `json:"invoices"`
+	NextCursor int               `json:"next_cursor,omitempty"`
+}
+
+type InvoiceItemDTO struct {
+	MenuItemID int      `json:"menu_item_id"`
+	Name       string   `json:"name"`
+	UnitPrice  MoneyDTO `json:"unit_price"`
+	Quantity   int      `json:"quantity"`
+	Total      MoneyDTO `json:"total"`
+	Tax        MoneyDTO `json:"tax"`
 }
 
 func NewInvoiceResponse(invoice domain.Invoice) InvoiceResponse {
+	items := []InvoiceItemDTO{}
+	for _, item := range invoice.Items {
+		items = append(items, InvoiceItemDTO{
+			MenuItemID: item.MenuItemID,
+			Name:       item.Name,
+			UnitPrice:  NewMoneyDTO(item.UnitPrice),
+			Quantity:   item.Quantity,
+			Total:      NewMoneyDTO(item.Total),
+			Tax:        NewMoneyDTO(item.Tax),
+		})
+	}
 	return InvoiceResponse{
 		ID:            invoice.ID,
 		OrderID:       invoice.OrderID,
-		Total:         invoice.Total,
-		Tax:           invoice.Tax,
-		ToPay:         invoice.Total + invoice.Tax,
+		Total:         NewMoneyDTO(invoice.Total),
+		Tax:           NewMoneyDTO(invoice.Tax),
+		ToPay:         NewMoneyDTO(invoice.AmountDue()),
 		PaymentStatus: string(invoice.PaymentStatus),
+		Items:         items,
 	}
 }
 
 type PaymentRequest struct {
-	Amount float64 `json:"amount"`
+	Amount MoneyDTO `json:"amount"`
+	// Method is cash or card, card when left empty
+	Method string `json:"method"`
+}
+
+type PaymentReceiptResponse struct {
+	PaymentID     int        `json:"payment_id,omitempty"`
+	InvoiceID     int        `json:"invoice_id"`
+	PayerID       int        `json:"payer_id"`
+	TakenBy       int        `json:"taken_by"`
+	Method        string     `json:"method"`
+	Amount        MoneyDTO   `json:"amount"`
+	Tendered      MoneyDTO   `json:"tendered"`
+	Change        MoneyDTO   `json:"change"`
+	TransactionID string     `json:"transaction_id,omitempty"`
+	PaidAt        *time.Time `json:"paid_at,omitempty"`
+	AmountDue     MoneyDTO   `json:"amount_due"`
+	InvoiceStatus string     `json:"invoice_status"`
+}
+
+func NewPaymentReceiptResponse(receipt domain.PaymentReceipt) PaymentReceiptResponse {
+	payment := receipt.Payment
+	resp := PaymentReceiptResponse{
+		PaymentID:     payment.ID,
+		InvoiceID:     payment.InvoiceID,
+		PayerID:       payment.PayerID,
+		TakenBy:       payment.TakenBy,
+		Method:        string(payment.Method),
+		Amount:        NewMoneyDTO(payment.Amount),
+		Tendered:      NewMoneyDTO(payment.Tendered),
+		Change:        NewMoneyDTO(payment.Change),
+		TransactionID: payment.TransactionID,
+		AmountDue:     NewMoneyDTO(receipt.AmountDue),
+		InvoiceStatus: string(receipt.InvoiceStatus),
+	}
+	// card payments are not taken yet while the invoice is processing
+	if !payment.PaidAt.IsZero() {
+		resp.PaidAt = &payment.PaidAt
+	}
+	return resp
+}
+
+type RefundRequest struct {
+	Amount MoneyDTO `json:"amount"`
+	Reason string   `json:"reason"`
+}
+
+type RefundResponse struct {
+	ID         int       `json:"id"`
+	InvoiceID  int       `json:"invoice_id"`
+	Amount     MoneyDTO  `json:"amount"`
+	Reason     string    `json:"reason"`
+	RefundedBy int       `json:"refunded_by"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+func NewRefundResponse(refund domain.Refund) RefundResponse {
+	return RefundResponse{
+		ID:         refund.ID,
+		InvoiceID:  refund.InvoiceID,
+		Amount:     NewMoneyDTO(refund.Amount),
+		Reason:     refund.Reason,
+		RefundedBy: refund.RefundedBy,
+		CreatedAt:  refund.CreatedAt,
+	}
 }
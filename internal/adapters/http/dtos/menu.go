@@ -0,0 +1,84 @@
+package dtos
+
+import "github.com/mohits-git/food-ordering-system/internal/domain"
+
+// MenuRequest replaces every detail of the menu on update, categories sent
+// with their id keep it. Categories and their items are shown in the order
+// they are sent.
+type MenuRequest struct {
+	Name       string                `json:"name"`
+	Schedules  []MenuScheduleDTO     `json:"schedules,omitempty"`
+	Categories []MenuCategoryRequest `json:"categories,omitempty"`
+}
+
+// MenuScheduleDTO is a window of the day as "15:04" times, a window ending
+// before it starts runs past midnight.
+type MenuScheduleDTO struct {
+	Start string `json:"start"`
+	End   string `json:"end"`
+}
+
+type MenuCategoryRequest struct {
+	ID      int    `json:"id,omitempty"`
+	Name    string `json:"name"`
+	ItemIDs []int  `json:"item_ids"`
+}
+
+type AddMenuResponse struct {
+	ID int `json:"id"`
+}
+
+type UpdateMenuResponse struct{}
+
+func (r MenuRequest) ToDomain(id int, restaurantId int) domain.Menu {
+	menu := domain.Menu{ID: id, RestaurantID: restaurantId, Name: r.Name}
+	for _, s := range r.Schedules {
+		// times that do not parse fail the validation of the menu
+		start, ok := domain.ParseTimeOfDay(s.Start)
+		if !ok {
+			start = -1
+		}
+		end, ok := domain.ParseTimeOfDay(s.End)
+		if !ok {
+			end = -1
+		}
+		menu.Schedules = append(menu.Schedules, domain.MenuSchedule{Start: start, End: end})
+	}
+	for _, c := range r.Categories {
+		menu.Categories = append(menu.Categories, domain.MenuCategory{ID: c.ID, MenuID: id, Name: c.Name, ItemIDs: c.ItemIDs})
+	}
+	return menu
+}
+
+type MenuResponse struct {
+	ID         int                    `json:"id,omitempty"`
+	Name       string                 `json:"name,omitempty"`
+	Schedules  []MenuScheduleDTO      `json:"schedules,omitempty"`
+	Categories []MenuCategoryResponse `json:"categories"`
+}
+
+type MenuCategoryResponse struct {
+	ID    int                `json:"id,omitempty"`
+	Name  string             `json:"name,omitempty"`
+	Items []MenuItemResponse `json:"items"`
+}
+
+func NewMenuResponse(menu domain.Menu) MenuResponse {
+	response := MenuResponse{ID: menu.ID, Name: menu.Name}
+	for _, s := range menu.Schedules {
+		response.Schedules = append(response.Schedules, MenuScheduleDTO{Start: domain.FormatTimeOfDay(s.Start), End: domain.FormatTimeOfDay(s.End)})
+	}
+	for _, c := range menu.Categories {
+		category := MenuCategoryResponse{ID: c.ID, Name: c.Name}
+		for _, item := range c.Items {
+			category.Items = append(category.Items, NewMenuItemResponse(item))
+		}
+		response.Categories = append(response.Categories, category)
+	}
+	return response
+}
+
+type GetMenusResponse struct {
+	RestaurantID int            `json:"restaurant_id"`
+	Menus        []MenuResponse `json:"menus"`
+}
@@ -3,38 +3,142 @@ package dtos
 import "github.com/mohits-git/food-ordering-system/internal/domain"
 
 type AddMenuItemRequest struct {
-	Name      string  `json:"name"`
-	Price     float64 `json:"price"`
-	Available bool    `json:"available"`
+	Name        string   `json:"name"`
+	Price       MoneyDTO `json:"price"`
+	Available   bool     `json:"available"`
+	Category    string   `json:"category,omitempty"`
+	Description string   `json:"description,omitempty"`
+	ImageURL    string   `json:"image_url,omitempty"`
+	SortOrder   int      `json:"sort_order,omitempty"`
+	// Stock is the quantity to sell, an item added without it does not run
+	// out
+	Stock *int `json:"stock,omitempty"`
+	// ModifierGroups replace the groups of the item on update, groups and
+	// options sent with their id keep it
+	ModifierGroups []ModifierGroupDTO `json:"modifier_groups,omitempty"`
 }
 
+type ModifierGroupDTO struct {
+	ID        int                 `json:"id,omitempty"`
+	Name      string              `json:"name"`
+	MinSelect int                 `json:"min_select"`
+	MaxSelect int                 `json:"max_select"`
+	Options   []ModifierOptionDTO `json:"options"`
+}
+
+// ModifierOptionDTO is one pick of a group, a price delta without a currency
+// is in the currency of the item.
+type ModifierOptionDTO struct {
+	ID         int      `json:"id,omitempty"`
+	Name       string   `json:"name"`
+	PriceDelta MoneyDTO `json:"price_delta"`
+}
+
+// UpdateMenuItemRequest replaces every detail of the item, fields left out
+// are cleared. The stock is not, it is set with UpdateMenuItemStockRequest.
+type UpdateMenuItemRequest = AddMenuItemRequest
+
 type UpdateMenuItemAvailabilityRequest struct {
 	Available bool `json:"available"`
 }
 
+// UpdateMenuItemStockRequest sets the quantity left of the item, a null stock
+// stops tracking it.
+type UpdateMenuItemStockRequest struct {
+	Stock *int `json:"stock"`
+}
+
 type AddMenuItemResponse struct {
 	ID int `json:"id"`
 }
 
 type UpdateMenuItemResponse struct{}
 
+func (r AddMenuItemRequest) ToDomain(id int, restaurantId int) domain.MenuItem {
+	item := domain.NewMenuItem(id, r.Name, r.Price.ToDomain(), r.Available, restaurantId)
+	item.Category = r.Category
+	item.Description = r.Description
+	item.ImageURL = r.ImageURL
+	item.SortOrder = r.SortOrder
+	if r.Stock != nil {
+		item.TrackStock = true
+		item.Stock = *r.Stock
+	}
+	for _, g := range r.ModifierGroups {
+		group := domain.ModifierGroup{ID: g.ID, MenuItemID: id, Name: g.Name, MinSelect: g.MinSelect, MaxSelect: g.MaxSelect}
+		for _, o := range g.Options {
+			delta := o.PriceDelta
+			if delta.Currency == "" {
+				delta.Currency = item.Price.Currency
+			}
+			group.Options = append(group.Options, domain.ModifierOption{ID: o.ID, GroupID: g.ID, Name: o.Name, PriceDelta: delta.ToDomain()})
+		}
+		item.ModifierGroups = append(item.ModifierGroups, group)
+	}
+	return item
+}
+
 type MenuItemResponse struct {
-	ID        int     `json:"id"`
-	Name      string  `json:"name"`
-	Price     float64 `json:"price"`
-	Available bool    `json:"available"`
+	ID             int                `json:"id"`
+	Name           string             `json:"name"`
+	Price          MoneyDTO           `json:"price"`
+	Available      bool               `json:"available"`
+	Category       string             `json:"category,omitempty"`
+	Description    string             `json:"description,omitempty"`
+	ImageURL       string             `json:"image_url,omitempty"`
+	SortOrder      int                `json:"sort_order"`
+	Stock          *int               `json:"stock,omitempty"`
+	ModifierGroups []ModifierGroupDTO `json:"modifier_groups,omitempty"`
 }
 
 func NewMenuItemResponse(item domain.MenuItem) MenuItemResponse {
+	var groups []ModifierGroupDTO
+	for _, g := range item.ModifierGroups {
+		group := ModifierGroupDTO{ID: g.ID, Name: g.Name, MinSelect: g.MinSelect, MaxSelect: g.MaxSelect}
+		for _, o := range g.Options {
+			group.Options = append(group.Options, ModifierOptionDTO{ID: o.ID, Name: o.Name, PriceDelta: NewMoneyDTO(o.PriceDelta)})
+		}
+		groups = append(groups, group)
+	}
+	var stock *int
+	if item.TrackStock {
+		stock = &item.Stock
+	}
 	return MenuItemResponse{
-		ID:        item.ID,
-		Name:      item.Name,
-		Price:     item.Price,
-		Available: item.Available,
+		ID:             item.ID,
+		Name:           item.Name,
+		Price:          NewMoneyDTO(item.Price),
+		Available:      item.Available,
+		Category:       item.Category,
+		Description:    item.Description,
+		ImageURL:       item.ImageURL,
+		SortOrder:      item.SortOrder,
+		Stock:          stock,
+		ModifierGroups: groups,
 	}
 }
 
+// GetMenuItemsResponse has the menus offered right now, Items lists their
+// items once each in the order of the menus.
 type GetMenuItemsResponse struct {
 	RestaurantID int                `json:"restaurant_id"`
+	Menus        []MenuResponse     `json:"menus"`
 	Items        []MenuItemResponse `json:"items"`
 }
+
+func NewGetMenuItemsResponse(restaurantId int, menus []domain.Menu) GetMenuItemsResponse {
+	response := GetMenuItemsResponse{RestaurantID: restaurantId}
+	seen := make(map[int]bool)
+	for _, menu := range menus {
+		response.Menus = append(response.Menus, NewMenuResponse(menu))
+		for _, category := range menu.Categories {
+			for _, item := range category.Items {
+				if !seen[item.ID] {
+					seen[item.ID] = true
+					response.Items = append(response.Items, NewMenuItemResponse(item))
+				}
+			}
+		}
+	}
+	return response
+}
@@ -0,0 +1,21 @@
+package dtos
+
+import "github.com/mohits-git/food-ordering-system/internal/domain"
+
+// MoneyDTO carries an amount in minor units, e.g. {"amount": 1250, "currency": "USD"} is 12.50 USD.
+type MoneyDTO struct {
+	Amount   int64  `json:"amount"`
+	Currency string `json:"currency"`
+}
+
+func NewMoneyDTO(m domain.Money) MoneyDTO {
+	return MoneyDTO{Amount: m.Amount, Currency: m.Currency}
+}
+
+func (m MoneyDTO) ToDomain() domain.Money {
+	currency := m.Currency
+	if currency == "" {
+		currency = domain.DefaultCurrency
+	}
+	return domain.NewMoney(m.Amount, currency)
+}
@@ -1,27 +1,76 @@
 package dtos
 
-import "github.com/mohits-git/food-ordering-system/internal/domain"
+import (
+	"time"
+
+	"github.com/mohits-git/food-ordering-system/internal/domain"
+)
 
 type CreateOrderRequest struct {
 	RestaurantID int             `json:"restaurant_id"`
 	OrderItems   []OrderItemsDTO `json:"order_items"`
 }
 
+// OrderItemsDTO is a line of an order, requests choose the options of the
+// menu item by OptionIDs and responses list them in Options.
 type OrderItemsDTO struct {
-	MenuItemID int `json:"menu_item_id"`
-	Quantity   int `json:"quantity"`
+	ID         int                  `json:"id,omitempty"`
+	MenuItemID int                  `json:"menu_item_id"`
+	Quantity   int                  `json:"quantity"`
+	Name       string               `json:"name,omitempty"`
+	UnitPrice  *MoneyDTO            `json:"unit_price,omitempty"`
+	OptionIDs  []int                `json:"option_ids,omitempty"`
+	Options    []OrderItemOptionDTO `json:"options,omitempty"`
+}
+
+type OrderItemOptionDTO struct {
+	OptionID   int      `json:"option_id"`
+	Name       string   `json:"name"`
+	PriceDelta MoneyDTO `json:"price_delta"`
 }
 
 func (o *OrderItemsDTO) ToDomain() domain.OrderItem {
-	return domain.OrderItem{
+	item := domain.OrderItem{
 		MenuItemID: o.MenuItemID,
 		Quantity:   o.Quantity,
+		Name:       o.Name,
+		Options:    toOrderItemOptions(o.OptionIDs),
+	}
+	if o.UnitPrice != nil {
+		item.UnitPrice = o.UnitPrice.ToDomain()
+	}
+	return item
+}
+
+// toOrderItemOptions turns chosen option ids into options, the service fills
+// in their names and prices from the menu item.
+func toOrderItemOptions(optionIds []int) []domain.OrderItemOption {
+	if len(optionIds) == 0 {
+		return nil
+	}
+	options := make([]domain.OrderItemOption, 0, len(optionIds))
+	for _, id := range optionIds {
+		options = append(options, domain.OrderItemOption{OptionID: id})
 	}
+	return options
 }
 
 type AddOrderItemRequest struct {
-	MenuItemID int `json:"menu_item_id"`
-	Quantity   int `json:"quantity"`
+	MenuItemID int   `json:"menu_item_id"`
+	Quantity   int   `json:"quantity"`
+	OptionIDs  []int `json:"option_ids,omitempty"`
+}
+
+func (r AddOrderItemRequest) ToDomain() domain.OrderItem {
+	return domain.OrderItem{
+		MenuItemID: r.MenuItemID,
+		Quantity:   r.Quantity,
+		Options:    toOrderItemOptions(r.OptionIDs),
+	}
+}
+
+type UpdateOrderItemRequest struct {
+	Quantity int `json:"quantity"`
 }
 
 type CreateOrderResponse struct {
@@ -32,9 +81,67 @@ type AddOrderItemResponse struct {
 	ID int `json:"id"`
 }
 
+type UpdateOrderItemResponse struct {
+	ID         int `json:"id"`
+	MenuItemID int `json:"menu_item_id"`
+}
+
+type UpdateOrderLineResponse struct {
+	ID     int `json:"id"`
+	LineID int `json:"line_id"`
+}
+
 type GetOrderByIdResponse struct {
 	ID           int             `json:"id"`
 	CustomerID   int             `json:"customer_id"`
 	RestaurantID int             `json:"restaurant_id"`
+	Status       string          `json:"status"`
 	OrderItems   []OrderItemsDTO `json:"order_items"`
+	CreatedAt    time.Time       `json:"created_at"`
+}
+
+func NewGetOrderByIdResponse(order domain.Order) GetOrderByIdResponse {
+	orderItemsDTO := []OrderItemsDTO{}
+	for _, item := range order.OrderItems {
+		unitPrice := NewMoneyDTO(item.UnitPrice)
+		var options []OrderItemOptionDTO
+		for _, option := range item.Options {
+			options = append(options, OrderItemOptionDTO{
+				OptionID:   option.OptionID,
+				Name:       option.Name,
+				PriceDelta: NewMoneyDTO(option.PriceDelta),
+			})
+		}
+		orderItemsDTO = append(orderItemsDTO, OrderItemsDTO{
+			ID:         item.ID,
+			MenuItemID: item.MenuItemID,
+			Quantity:   item.Quantity,
+			Name:       item.Name,
+			UnitPrice:  &unitPrice,
+			Options:    options,
+		})
+	}
+	return GetOrderByIdResponse{
+		ID:           order.ID,
+		CustomerID:   order.CustomerID,
+		RestaurantID: order.RestaurantID,
+		Status:       string(order.Status),
+		OrderItems:   orderItemsDTO,
+		CreatedAt:    order.CreatedAt,
+	}
+}
+
+type GetRestaurantOrdersResponse struct {
+	RestaurantID int                    `json:"restaurant_id"`
+	Orders       []GetOrderByIdResponse `json:"orders"`
+}
+
+type GetCustomerOrdersResponse struct {
+	Orders     []GetOrderByIdResponse `json:"orders"`
+	NextCursor int                    `json:"next_cursor,omitempty"`
+}
+
+type UpdateOrderStatusResponse struct {
+	ID     int    `json:"id"`
+	Status string `json:"status"`
 }
@@ -1,6 +1,11 @@
 package dtos
 
-import "github.com/mohits-git/food-ordering-system/internal/domain"
+import (
+	"strings"
+	"time"
+
+	"github.com/mohits-git/food-ordering-system/internal/domain"
+)
 
 type CreateRestaurantRequest struct {
 	Name string `json:"name"`
@@ -11,27 +16,165 @@ type CreateRestaurantResponse struct {
 }
 
 type RestaurantDTO struct {
-	ID      int    `json:"id"`
-	Name    string `json:"name"`
-	OwnerID int    `json:"owner_id"`
+	ID           int               `json:"id"`
+	Name         string            `json:"name"`
+	OwnerID      int               `json:"owner_id"`
+	Address      string            `json:"address,omitempty"`
+	Phone        string            `json:"phone,omitempty"`
+	Cuisines     []string          `json:"cuisines,omitempty"`
+	OpeningHours []OpeningHoursDTO `json:"opening_hours,omitempty"`
+	Closures     []string          `json:"closures,omitempty"`
+	OrdersPaused bool              `json:"orders_paused"`
+	// OpenNow is whether the restaurant takes orders at the time of the response
+	OpenNow bool `json:"open_now"`
+}
+
+// OpeningHoursDTO is a window of a day of the week, like "monday", as "15:04"
+// times. A window ending before it starts runs past midnight.
+type OpeningHoursDTO struct {
+	Day   string `json:"day"`
+	Start string `json:"start"`
+	End   string `json:"end"`
 }
 
 func NewRestaurantDTO(restaurant domain.Restaurant) RestaurantDTO {
-	return RestaurantDTO{
-		ID:      restaurant.ID,
-		Name:    restaurant.Name,
-		OwnerID: restaurant.OwnerID,
+	dto := RestaurantDTO{
+		ID:           restaurant.ID,
+		Name:         restaurant.Name,
+		OwnerID:      restaurant.OwnerID,
+		Address:      restaurant.Address,
+		Phone:        restaurant.Phone,
+		Cuisines:     restaurant.Cuisines,
+		Closures:     restaurant.Closures,
+		OrdersPaused: restaurant.OrdersPaused,
+		OpenNow:      restaurant.AcceptsOrdersAt(time.Now()),
+	}
+	for _, hours := range restaurant.OpeningHours {
+		dto.OpeningHours = append(dto.OpeningHours, OpeningHoursDTO{
+			Day:   strings.ToLower(hours.Weekday.String()),
+			Start: domain.FormatTimeOfDay(hours.Start),
+			End:   domain.FormatTimeOfDay(hours.End),
+		})
 	}
+	return dto
 }
 
 type GetRestaurantsResponse struct {
 	Restaurants []RestaurantDTO `json:"restaurants"`
+	NextCursor  int             `json:"next_cursor,omitempty"`
 }
 
 func NewRestaurant(restaurant RestaurantDTO) domain.Restaurant {
 	return domain.Restaurant{
-		ID:      restaurant.ID,
-		Name:    restaurant.Name,
-		OwnerID: restaurant.OwnerID,
+		ID:           restaurant.ID,
+		Name:         restaurant.Name,
+		OwnerID:      restaurant.OwnerID,
+		Address:      restaurant.Address,
+		Phone:        restaurant.Phone,
+		Cuisines:     restaurant.Cuisines,
+		OpeningHours: toOpeningHours(restaurant.OpeningHours),
+		Closures:     restaurant.Closures,
+		OrdersPaused: restaurant.OrdersPaused,
+	}
+}
+
+// UpdateRestaurantRequest replaces every detail of the profile of the
+// restaurant.
+type UpdateRestaurantRequest struct {
+	Name         string            `json:"name"`
+	Address      string            `json:"address"`
+	Phone        string            `json:"phone"`
+	Cuisines     []string          `json:"cuisines,omitempty"`
+	OpeningHours []OpeningHoursDTO `json:"opening_hours,omitempty"`
+	Closures     []string          `json:"closures,omitempty"`
+}
+
+func (r UpdateRestaurantRequest) ToDomain(id int) domain.Restaurant {
+	return domain.Restaurant{
+		ID:           id,
+		Name:         r.Name,
+		Address:      r.Address,
+		Phone:        r.Phone,
+		Cuisines:     r.Cuisines,
+		OpeningHours: toOpeningHours(r.OpeningHours),
+		Closures:     r.Closures,
+	}
+}
+
+type UpdateRestaurantResponse struct{}
+
+type PauseOrdersRequest struct {
+	Paused *bool `json:"paused"`
+}
+
+func toOpeningHours(hours []OpeningHoursDTO) []domain.OpeningHours {
+	var openingHours []domain.OpeningHours
+	for _, dto := range hours {
+		// days and times that do not parse fail the validation of the restaurant
+		day, ok := domain.ParseWeekday(dto.Day)
+		if !ok {
+			day = -1
+		}
+		start, ok := domain.ParseTimeOfDay(dto.Start)
+		if !ok {
+			start = -1
+		}
+		end, ok := domain.ParseTimeOfDay(dto.End)
+		if !ok {
+			end = -1
+		}
+		openingHours = append(openingHours, domain.OpeningHours{Weekday: day, Start: start, End: end})
+	}
+	return openingHours
+}
+
+type InviteMemberRequest struct {
+	Email string `json:"email"`
+	Role  string `json:"role"`
+}
+
+type RestaurantMemberDTO struct {
+	RestaurantID int    `json:"restaurant_id"`
+	UserID       int    `json:"user_id"`
+	Role         string `json:"role"`
+	Name         string `json:"name,omitempty"`
+	Email        string `json:"email,omitempty"`
+}
+
+func NewRestaurantMemberDTO(member domain.RestaurantMember) RestaurantMemberDTO {
+	return RestaurantMemberDTO{
+		RestaurantID: member.RestaurantID,
+		UserID:       member.UserID,
+		Role:         string(member.Role),
+		Name:         member.Name,
+		Email:        member.Email,
+	}
+}
+
+type GetRestaurantMembersResponse struct {
+	Members []RestaurantMemberDTO `json:"members"`
+}
+
+type TaxRuleRequest struct {
+	Category  string   `json:"category"`
+	Rate      *float64 `json:"rate"`
+	Inclusive bool     `json:"inclusive"`
+}
+
+type TaxRuleDTO struct {
+	ID           int     `json:"id"`
+	RestaurantID int     `json:"restaurant_id"`
+	Category     string  `json:"category"`
+	Rate         float64 `json:"rate"`
+	Inclusive    bool    `json:"inclusive"`
+}
+
+func NewTaxRuleDTO(rule domain.TaxRule) TaxRuleDTO {
+	return TaxRuleDTO{
+		ID:           rule.ID,
+		RestaurantID: rule.RestaurantID,
+		Category:     rule.Category,
+		Rate:         rule.Rate,
+		Inclusive:    rule.Inclusive,
 	}
 }
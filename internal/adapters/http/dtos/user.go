@@ -1,5 +1,7 @@
 package dtos
 
+import "github.com/mohits-git/food-ordering-system/internal/domain"
+
 type CreateUserRequest struct {
 	Name     string `json:"name"`
 	Email    string `json:"email"`
@@ -8,12 +10,35 @@ type CreateUserRequest struct {
 }
 
 type CreateUserResponse struct {
-	UserID int `json:"user_id"`
+	UserID          int  `json:"user_id"`
+	PendingApproval bool `json:"pending_approval,omitempty"`
 }
 
 type GetUserResponse struct {
-	UserID int    `json:"user_id"`
-	Name   string `json:"name"`
-	Email  string `json:"email"`
-	Role   string `json:"role"`
+	UserID          int    `json:"user_id"`
+	Name            string `json:"name"`
+	Email           string `json:"email"`
+	Role            string `json:"role"`
+	Suspended       bool   `json:"suspended"`
+	PendingApproval bool   `json:"pending_approval"`
+}
+
+func NewGetUserResponse(user domain.User) GetUserResponse {
+	return GetUserResponse{
+		UserID:          user.ID,
+		Name:            user.Name,
+		Email:           user.Email,
+		Role:            string(user.Role),
+		Suspended:       user.Suspended,
+		PendingApproval: user.PendingApproval,
+	}
+}
+
+type GetUsersResponse struct {
+	Users      []GetUserResponse `json:"users"`
+	NextCursor int               `json:"next_cursor,omitempty"`
+}
+
+type UpdateUserRoleRequest struct {
+	Role string `json:"role"`
 }
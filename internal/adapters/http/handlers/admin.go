@@ -0,0 +1,233 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/mohits-git/food-ordering-system/internal/adapters/http/dtos"
+	"github.com/mohits-git/food-ordering-system/internal/domain"
+	"github.com/mohits-git/food-ordering-system/internal/ports"
+	"github.com/mohits-git/food-ordering-system/internal/utils/apperr"
+)
+
+// AdminHandler serves the admin views over users, orders and invoices. The
+// services only allow admins through.
+type AdminHandler struct {
+	userService    ports.UserService
+	orderService   ports.OrderService
+	invoiceService ports.InvoiceService
+}
+
+func NewAdminHandler(userService ports.UserService, orderService ports.OrderService, invoiceService ports.InvoiceService) *AdminHandler {
+	return &AdminHandler{
+		userService:    userService,
+		orderService:   orderService,
+		invoiceService: invoiceService,
+	}
+}
+
+func (h *AdminHandler) HandleGetUsers(w http.ResponseWriter, r *http.Request) {
+	filter := domain.UserFilter{
+		Query:           r.URL.Query().Get("q"),
+		Role:            domain.UserRole(r.URL.Query().Get("role")),
+		PendingApproval: r.URL.Query().Get("pending") == "true",
+	}
+	var err error
+	if filter.Cursor, err = getIntFromQuery(r, "cursor"); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid cursor")
+		return
+	}
+	if filter.Limit, err = getIntFromQuery(r, "limit"); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid limit")
+		return
+	}
+
+	users, nextCursor, err := h.userService.ListUsers(r.Context(), filter)
+	if err != nil {
+		writeAdminError(w, err)
+		return
+	}
+
+	resp := dtos.GetUsersResponse{
+		Users:      []dtos.GetUserResponse{},
+		NextCursor: nextCursor,
+	}
+	for _, user := range users {
+		resp.Users = append(resp.Users, dtos.NewGetUserResponse(user))
+	}
+	writeResponse(w, http.StatusOK, "users fetched successfully", resp)
+}
+
+func (h *AdminHandler) HandleCreateUser(w http.ResponseWriter, r *http.Request) {
+	createUserReq, err := decodeRequest[dtos.CreateUserRequest](r)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request payload")
+		return
+	}
+
+	userId, err := h.userService.CreateUser(
+		r.Context(),
+		domain.NewUser(
+			0,
+			createUserReq.Name,
+			createUserReq.Email,
+			createUserReq.Password,
+			domain.UserRole(createUserReq.Role)),
+	)
+	if err != nil {
+		writeAdminError(w, err)
+		return
+	}
+
+	resp := dtos.CreateUserResponse{UserID: userId}
+	writeResponse(w, http.StatusCreated, "user created successfully", resp)
+}
+
+func (h *AdminHandler) HandleApproveUser(w http.ResponseWriter, r *http.Request) {
+	userId := getIdFromPath(r, "id")
+	if userId <= 0 {
+		writeError(w, http.StatusBadRequest, "invalid user id")
+		return
+	}
+
+	if err := h.userService.ApproveUser(r.Context(), userId); err != nil {
+		writeAdminError(w, err)
+		return
+	}
+	writeResponse(w, http.StatusOK, "user approved successfully", struct{}{})
+}
+
+func (h *AdminHandler) HandleUpdateUserRole(w http.ResponseWriter, r *http.Request) {
+	userId := getIdFromPath(r, "id")
+	if userId <= 0 {
+		writeError(w, http.StatusBadRequest, "invalid user id")
+		return
+	}
+
+	req, err := decodeRequest[dtos.UpdateUserRoleRequest](r)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request payload")
+		return
+	}
+
+	if err := h.userService.ChangeUserRole(r.Context(), userId, domain.UserRole(req.Role)); err != nil {
+		writeAdminError(w, err)
+		return
+	}
+	writeResponse(w, http.StatusOK, "user role updated successfully", dtos.UpdateUserRoleRequest{Role: req.Role})
+}
+
+func (h *AdminHandler) HandleSuspendUser(w http.ResponseWriter, r *http.Request) {
+	h.handleSetUserSuspended(w, r, true, "user suspended successfully")
+}
+
+func (h *AdminHandler) HandleReinstateUser(w http.ResponseWriter, r *http.Request) {
+	h.handleSetUserSuspended(w, r, false, "user reinstated successfully")
+}
+
+func (h *AdminHandler) handleSetUserSuspended(w http.ResponseWriter, r *http.Request, suspended bool, msg string) {
+	userId := getIdFromPath(r, "id")
+	if userId <= 0 {
+		writeError(w, http.StatusBadRequest, "invalid user id")
+		return
+	}
+
+	if err := h.userService.SetUserSuspended(r.Context(), userId, suspended); err != nil {
+		writeAdminError(w, err)
+		return
+	}
+	writeResponse(w, http.StatusOK, msg, struct{}{})
+}
+
+func (h *AdminHandler) HandleGetOrders(w http.ResponseWriter, r *http.Request) {
+	filter := domain.OrderFilter{}
+	var err error
+	if filter.CustomerID, err = getIntFromQuery(r, "customer_id"); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid customer id")
+		return
+	}
+	if filter.RestaurantID, err = getIntFromQuery(r, "restaurant_id"); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid restaurant id")
+		return
+	}
+	if filter.Cursor, err = getIntFromQuery(r, "cursor"); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid cursor")
+		return
+	}
+	if filter.Limit, err = getIntFromQuery(r, "limit"); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid limit")
+		return
+	}
+	if filter.From, err = getTimeFromQuery(r, "from", false); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid from date")
+		return
+	}
+	if filter.To, err = getTimeFromQuery(r, "to", true); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid to date")
+		return
+	}
+	for _, status := range getListFromQuery(r, "status") {
+		filter.Statuses = append(filter.Statuses, domain.OrderStatus(status))
+	}
+
+	orders, nextCursor, err := h.orderService.GetAllOrders(r.Context(), filter)
+	if err != nil {
+		writeAdminError(w, err)
+		return
+	}
+
+	resp := dtos.GetCustomerOrdersResponse{
+		Orders:     []dtos.GetOrderByIdResponse{},
+		NextCursor: nextCursor,
+	}
+	for _, order := range orders {
+		resp.Orders = append(resp.Orders, dtos.NewGetOrderByIdResponse(order))
+	}
+	writeResponse(w, http.StatusOK, "orders fetched successfully", resp)
+}
+
+func (h *AdminHandler) HandleGetInvoices(w http.ResponseWriter, r *http.Request) {
+	filter := domain.InvoiceFilter{}
+	var err error
+	if filter.Cursor, err = getIntFromQuery(r, "cursor"); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid cursor")
+		return
+	}
+	if filter.Limit, err = getIntFromQuery(r, "limit"); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid limit")
+		return
+	}
+	for _, status := range getListFromQuery(r, "status") {
+		filter.Statuses = append(filter.Statuses, domain.PaymentStatus(status))
+	}
+
+	invoices, nextCursor, err := h.invoiceService.GetInvoices(r.Context(), filter)
+	if err != nil {
+		writeAdminError(w, err)
+		return
+	}
+
+	resp := dtos.GetInvoicesResponse{
+		Invoices:   []dtos.InvoiceResponse{},
+		NextCursor: nextCursor,
+	}
+	for _, invoice := range invoices {
+		resp.Invoices = append(resp.Invoices, dtos.NewInvoiceResponse(invoice))
+	}
+	writeResponse(w, http.StatusOK, "invoices fetched successfully", resp)
+}
+
+func writeAdminError(w http.ResponseWriter, err error) {
+	if apperr.IsUnauthorizedError(err) {
+		writeError(w, http.StatusUnauthorized, "unauthorized")
+	} else if apperr.IsForbiddenError(err) {
+		writeError(w, http.StatusForbidden, "forbidden")
+	} else if apperr.IsNotFoundError(err) {
+		writeError(w, http.StatusNotFound, "user not found")
+	} else if apperr.IsConflictError(err) {
+		writeError(w, http.StatusConflict, "user already exists")
+	} else if apperr.IsInvalidError(err) {
+		writeError(w, http.StatusBadRequest, err.Error())
+	} else {
+		writeError(w, http.StatusInternalServerError, "internal server error")
+	}
+}
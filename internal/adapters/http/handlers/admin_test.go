@@ -0,0 +1,271 @@
+package handlers
+
+import (
+	"bytes"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/mohits-git/food-ordering-system/internal/adapters/http/dtos"
+	"github.com/mohits-git/food-ordering-system/internal/domain"
+	"github.com/mohits-git/food-ordering-system/internal/utils/apperr"
+	mockservice "github.com/mohits-git/food-ordering-system/tests/mock_service"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_handlers_AdminHandler_NewAdminHandler(t *testing.T) {
+	handler := NewAdminHandler(&mockservice.UserService{}, &mockservice.OrderService{}, &mockservice.InvoiceService{})
+	require.NotNil(t, handler, "expected NewAdminHandler to return a non-nil handler")
+}
+
+func Test_handlers_AdminHandler_HandleGetUsers(t *testing.T) {
+	mockUserService := &mockservice.UserService{}
+	handler := NewAdminHandler(mockUserService, &mockservice.OrderService{}, &mockservice.InvoiceService{})
+
+	mockUserService.On("ListUsers", mock.Anything, domain.UserFilter{Query: "jo", Role: domain.OWNER, Cursor: 10, Limit: 5}).
+		Return([]domain.User{{ID: 8, Name: "John", Email: "john@example.com", Role: domain.OWNER, Suspended: true}}, 8, nil).Once()
+
+	req := httptest.NewRequest("GET", "/api/admin/users?q=jo&role=owner&cursor=10&limit=5", nil)
+	w := httptest.NewRecorder()
+	handler.HandleGetUsers(w, req)
+	res := w.Result()
+
+	require.Equal(t, 200, res.StatusCode, "expected status code 200")
+	defer res.Body.Close()
+	usersResp, err := decodeResponse[dtos.GetUsersResponse](res)
+	require.NoError(t, err, "expected no error while decoding response")
+	require.Len(t, usersResp.Users, 1)
+	require.True(t, usersResp.Users[0].Suspended)
+	require.Equal(t, 8, usersResp.NextCursor)
+	mockUserService.AssertExpectations(t)
+}
+
+func Test_handlers_AdminHandler_HandleGetUsers_Forbidden(t *testing.T) {
+	mockUserService := &mockservice.UserService{}
+	handler := NewAdminHandler(mockUserService, &mockservice.OrderService{}, &mockservice.InvoiceService{})
+
+	mockUserService.On("ListUsers", mock.Anything, domain.UserFilter{}).
+		Return([]domain.User(nil), 0, apperr.NewAppError(apperr.ErrForbidden, "only admins can manage users", nil)).Once()
+
+	req := httptest.NewRequest("GET", "/api/admin/users", nil)
+	w := httptest.NewRecorder()
+	handler.HandleGetUsers(w, req)
+
+	require.Equal(t, 403, w.Result().StatusCode, "expected status code 403")
+}
+
+func Test_handlers_AdminHandler_HandleUpdateUserRole(t *testing.T) {
+	mockUserService := &mockservice.UserService{}
+	handler := NewAdminHandler(mockUserService, &mockservice.OrderService{}, &mockservice.InvoiceService{})
+
+	mockUserService.On("ChangeUserRole", mock.Anything, 3, domain.OWNER).Return(nil).Once()
+
+	buf := bytes.NewBuffer(nil)
+	err := encodeJson(buf, dtos.UpdateUserRoleRequest{Role: "owner"})
+	require.NoError(t, err, "expected no error while encoding request")
+	req := httptest.NewRequest("PATCH", "/api/admin/users/3/role", buf)
+	req.SetPathValue("id", "3")
+	w := httptest.NewRecorder()
+	handler.HandleUpdateUserRole(w, req)
+
+	require.Equal(t, 200, w.Result().StatusCode, "expected status code 200")
+	mockUserService.AssertExpectations(t)
+}
+
+func Test_handlers_AdminHandler_HandleUpdateUserRole_InvalidRole(t *testing.T) {
+	mockUserService := &mockservice.UserService{}
+	handler := NewAdminHandler(mockUserService, &mockservice.OrderService{}, &mockservice.InvoiceService{})
+
+	mockUserService.On("ChangeUserRole", mock.Anything, 3, domain.UserRole("root")).
+		Return(apperr.NewAppError(apperr.ErrInvalid, "invalid input data", nil)).Once()
+
+	buf := bytes.NewBuffer(nil)
+	err := encodeJson(buf, dtos.UpdateUserRoleRequest{Role: "root"})
+	require.NoError(t, err, "expected no error while encoding request")
+	req := httptest.NewRequest("PATCH", "/api/admin/users/3/role", buf)
+	req.SetPathValue("id", "3")
+	w := httptest.NewRecorder()
+	handler.HandleUpdateUserRole(w, req)
+
+	require.Equal(t, 400, w.Result().StatusCode, "expected status code 400")
+}
+
+func Test_handlers_AdminHandler_HandleSuspendUser(t *testing.T) {
+	mockUserService := &mockservice.UserService{}
+	handler := NewAdminHandler(mockUserService, &mockservice.OrderService{}, &mockservice.InvoiceService{})
+
+	mockUserService.On("SetUserSuspended", mock.Anything, 3, true).Return(nil).Once()
+
+	req := httptest.NewRequest("POST", "/api/admin/users/3/suspend", nil)
+	req.SetPathValue("id", "3")
+	w := httptest.NewRecorder()
+	handler.HandleSuspendUser(w, req)
+
+	require.Equal(t, 200, w.Result().StatusCode, "expected status code 200")
+	mockUserService.AssertExpectations(t)
+}
+
+func Test_handlers_AdminHandler_HandleReinstateUser_NotFound(t *testing.T) {
+	mockUserService := &mockservice.UserService{}
+	handler := NewAdminHandler(mockUserService, &mockservice.OrderService{}, &mockservice.InvoiceService{})
+
+	mockUserService.On("SetUserSuspended", mock.Anything, 3, false).
+		Return(apperr.NewAppError(apperr.ErrNotFound, "user not found", nil)).Once()
+
+	req := httptest.NewRequest("POST", "/api/admin/users/3/reinstate", nil)
+	req.SetPathValue("id", "3")
+	w := httptest.NewRecorder()
+	handler.HandleReinstateUser(w, req)
+
+	require.Equal(t, 404, w.Result().StatusCode, "expected status code 404")
+}
+
+func Test_handlers_AdminHandler_HandleGetOrders(t *testing.T) {
+	mockOrderService := &mockservice.OrderService{}
+	handler := NewAdminHandler(&mockservice.UserService{}, mockOrderService, &mockservice.InvoiceService{})
+
+	mockOrderService.On("GetAllOrders", mock.Anything, domain.OrderFilter{
+		CustomerID: 1,
+		Statuses:   []domain.OrderStatus{domain.OrderPlaced},
+		Limit:      5,
+	}).Return([]domain.Order{{ID: 9, CustomerID: 1, RestaurantID: 2, Status: domain.OrderPlaced}}, 0, nil).Once()
+
+	req := httptest.NewRequest("GET", "/api/admin/orders?customer_id=1&status=placed&limit=5", nil)
+	w := httptest.NewRecorder()
+	handler.HandleGetOrders(w, req)
+	res := w.Result()
+
+	require.Equal(t, 200, res.StatusCode, "expected status code 200")
+	defer res.Body.Close()
+	ordersResp, err := decodeResponse[dtos.GetCustomerOrdersResponse](res)
+	require.NoError(t, err, "expected no error while decoding response")
+	require.Len(t, ordersResp.Orders, 1)
+	mockOrderService.AssertExpectations(t)
+}
+
+func Test_handlers_AdminHandler_HandleGetOrders_InvalidQuery(t *testing.T) {
+	mockOrderService := &mockservice.OrderService{}
+	handler := NewAdminHandler(&mockservice.UserService{}, mockOrderService, &mockservice.InvoiceService{})
+
+	req := httptest.NewRequest("GET", "/api/admin/orders?customer_id=abc", nil)
+	w := httptest.NewRecorder()
+	handler.HandleGetOrders(w, req)
+
+	require.Equal(t, 400, w.Result().StatusCode, "expected status code 400")
+	mockOrderService.AssertNotCalled(t, "GetAllOrders", mock.Anything, mock.Anything)
+}
+
+func Test_handlers_AdminHandler_HandleGetInvoices(t *testing.T) {
+	mockInvoiceService := &mockservice.InvoiceService{}
+	handler := NewAdminHandler(&mockservice.UserService{}, &mockservice.OrderService{}, mockInvoiceService)
+
+	mockInvoiceService.On("GetInvoices", mock.Anything, domain.InvoiceFilter{
+		Statuses: []domain.PaymentStatus{domain.RefundPending},
+	}).Return([]domain.Invoice{
+		{ID: 4, OrderID: 2, Total: domain.NewMoney(1000, "USD"), Tax: domain.NewMoney(100, "USD"), PaymentStatus: domain.RefundPending},
+	}, 0, nil).Once()
+
+	req := httptest.NewRequest("GET", "/api/admin/invoices?status="+string(domain.RefundPending), nil)
+	w := httptest.NewRecorder()
+	handler.HandleGetInvoices(w, req)
+	res := w.Result()
+
+	require.Equal(t, 200, res.StatusCode, "expected status code 200")
+	defer res.Body.Close()
+	invoicesResp, err := decodeResponse[dtos.GetInvoicesResponse](res)
+	require.NoError(t, err, "expected no error while decoding response")
+	require.Len(t, invoicesResp.Invoices, 1)
+	require.Equal(t, 4, invoicesResp.Invoices[0].ID)
+	mockInvoiceService.AssertExpectations(t)
+}
+
+func Test_handlers_AdminHandler_HandleGetUsers_Pending(t *testing.T) {
+	mockUserService := &mockservice.UserService{}
+	handler := NewAdminHandler(mockUserService, &mockservice.OrderService{}, &mockservice.InvoiceService{})
+
+	mockUserService.On("ListUsers", mock.Anything, domain.UserFilter{PendingApproval: true}).
+		Return([]domain.User{{ID: 5, Role: domain.OWNER, PendingApproval: true}}, 0, nil).Once()
+
+	req := httptest.NewRequest("GET", "/api/admin/users?pending=true", nil)
+	w := httptest.NewRecorder()
+	handler.HandleGetUsers(w, req)
+	res := w.Result()
+
+	require.Equal(t, 200, res.StatusCode, "expected status code 200")
+	defer res.Body.Close()
+	usersResp, err := decodeResponse[dtos.GetUsersResponse](res)
+	require.NoError(t, err, "expected no error while decoding response")
+	require.Len(t, usersResp.Users, 1)
+	require.True(t, usersResp.Users[0].PendingApproval)
+	mockUserService.AssertExpectations(t)
+}
+
+func Test_handlers_AdminHandler_HandleCreateUser(t *testing.T) {
+	mockUserService := &mockservice.UserService{}
+	handler := NewAdminHandler(mockUserService, &mockservice.OrderService{}, &mockservice.InvoiceService{})
+
+	mockUserService.On("CreateUser", mock.Anything, domain.NewUser(0, "Ada", "ada@example.com", "123456", domain.ADMIN)).
+		Return(7, nil).Once()
+
+	buf := bytes.NewBuffer(nil)
+	err := encodeJson(buf, dtos.CreateUserRequest{Name: "Ada", Email: "ada@example.com", Role: "admin", Password: "123456"})
+	require.NoError(t, err, "expected no error while encoding request")
+	req := httptest.NewRequest("POST", "/api/admin/users", buf)
+	w := httptest.NewRecorder()
+	handler.HandleCreateUser(w, req)
+	res := w.Result()
+
+	require.Equal(t, 201, res.StatusCode, "expected status code 201")
+	defer res.Body.Close()
+	createResp, err := decodeResponse[dtos.CreateUserResponse](res)
+	require.NoError(t, err, "expected no error while decoding response")
+	require.Equal(t, 7, createResp.UserID)
+	mockUserService.AssertExpectations(t)
+}
+
+func Test_handlers_AdminHandler_HandleCreateUser_Conflict(t *testing.T) {
+	mockUserService := &mockservice.UserService{}
+	handler := NewAdminHandler(mockUserService, &mockservice.OrderService{}, &mockservice.InvoiceService{})
+
+	mockUserService.On("CreateUser", mock.Anything, mock.Anything).
+		Return(0, apperr.NewAppError(apperr.ErrConflict, "unique constraint violation", nil)).Once()
+
+	buf := bytes.NewBuffer(nil)
+	err := encodeJson(buf, dtos.CreateUserRequest{Name: "Ada", Email: "ada@example.com", Role: "owner", Password: "123456"})
+	require.NoError(t, err, "expected no error while encoding request")
+	req := httptest.NewRequest("POST", "/api/admin/users", buf)
+	w := httptest.NewRecorder()
+	handler.HandleCreateUser(w, req)
+
+	require.Equal(t, 409, w.Result().StatusCode, "expected status code 409")
+}
+
+func Test_handlers_AdminHandler_HandleApproveUser(t *testing.T) {
+	mockUserService := &mockservice.UserService{}
+	handler := NewAdminHandler(mockUserService, &mockservice.OrderService{}, &mockservice.InvoiceService{})
+
+	mockUserService.On("ApproveUser", mock.Anything, 5).Return(nil).Once()
+
+	req := httptest.NewRequest("POST", "/api/admin/users/5/approve", nil)
+	req.SetPathValue("id", "5")
+	w := httptest.NewRecorder()
+	handler.HandleApproveUser(w, req)
+
+	require.Equal(t, 200, w.Result().StatusCode, "expected status code 200")
+	mockUserService.AssertExpectations(t)
+}
+
+func Test_handlers_AdminHandler_HandleApproveUser_NotPending(t *testing.T) {
+	mockUserService := &mockservice.UserService{}
+	handler := NewAdminHandler(mockUserService, &mockservice.OrderService{}, &mockservice.InvoiceService{})
+
+	mockUserService.On("ApproveUser", mock.Anything, 5).
+		Return(apperr.NewAppError(apperr.ErrInvalid, "user is not pending approval", nil)).Once()
+
+	req := httptest.NewRequest("POST", "/api/admin/users/5/approve", nil)
+	req.SetPathValue("id", "5")
+	w := httptest.NewRecorder()
+	handler.HandleApproveUser(w, req)
+
+	require.Equal(t, 400, w.Result().StatusCode, "expected status code 400")
+}
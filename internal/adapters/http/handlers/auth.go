@@ -24,12 +24,15 @@ func (h *AuthHandler) HandleLogin(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	token, err := h.authService.Login(r.Context(), loginRequest.Email, loginRequest.Password)
+	tokens, err := h.authService.Login(r.Context(), loginRequest.Email, loginRequest.Password)
 	if err != nil {
 		if apperr.IsNotFoundError(err) {
 			writeError(w, http.StatusUnauthorized, "invalid email or password")
 		} else if apperr.IsUnauthorizedError(err) {
 			writeError(w, http.StatusUnauthorized, "invalid email or password")
+		} else if apperr.IsForbiddenError(err) {
+			appErr, _ := err.(*apperr.AppError)
+			writeError(w, http.StatusForbidden, appErr.Message)
 		} else if apperr.IsInvalidError(err) {
 			writeError(w, http.StatusBadRequest, "invalid inputs")
 		} else {
@@ -38,11 +41,36 @@ func (h *AuthHandler) HandleLogin(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	loginResponse := dtos.LoginResponse{Token: token}
-	w.Header().Set("Set-Cookie", "token="+token+"; HttpOnly; Path=/api/; SameSite=Strict")
+	loginResponse := dtos.LoginResponse{Token: tokens.AccessToken, RefreshToken: tokens.RefreshToken}
+	w.Header().Set("Set-Cookie", "token="+tokens.AccessToken+"; HttpOnly; Path=/api/; SameSite=Strict")
 	writeResponse(w, http.StatusOK, "login successful", loginResponse)
 }
 
+func (h *AuthHandler) HandleRefresh(w http.ResponseWriter, r *http.Request) {
+	refreshRequest, err := decodeRequest[dtos.RefreshRequest](r)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request")
+		return
+	}
+
+	tokens, err := h.authService.Refresh(r.Context(), refreshRequest.RefreshToken)
+	if err != nil {
+		if apperr.IsUnauthorizedError(err) {
+			writeError(w, http.StatusUnauthorized, "invalid refresh token")
+		} else if apperr.IsForbiddenError(err) {
+			appErr, _ := err.(*apperr.AppError)
+			writeError(w, http.StatusForbidden, appErr.Message)
+		} else {
+			writeError(w, http.StatusInternalServerError, "internal server error")
+		}
+		return
+	}
+
+	refreshResponse := dtos.LoginResponse{Token: tokens.AccessToken, RefreshToken: tokens.RefreshToken}
+	w.Header().Set("Set-Cookie", "token="+tokens.AccessToken+"; HttpOnly; Path=/api/; SameSite=Strict")
+	writeResponse(w, http.StatusOK, "token refreshed", refreshResponse)
+}
+
 func (h *AuthHandler) HandleLogout(w http.ResponseWriter, r *http.Request) {
 	token, ok := authctx.TokenFromCtx(r.Context())
 	if !ok {
@@ -52,7 +80,11 @@ func (h *AuthHandler) HandleLogout(w http.ResponseWriter, r *http.Request) {
 
 	err := h.authService.Logout(r.Context(), token)
 	if err != nil {
-		writeError(w, http.StatusInternalServerError, "internal server error")
+		if apperr.IsUnauthorizedError(err) {
+			writeError(w, http.StatusUnauthorized, "unauthorized")
+		} else {
+			writeError(w, http.StatusInternalServerError, "internal server error")
+		}
 		return
 	}
 
@@ -6,6 +6,7 @@ import (
 	"testing"
 
 	"github.com/mohits-git/food-ordering-system/internal/adapters/http/dtos"
+	"github.com/mohits-git/food-ordering-system/internal/domain"
 	"github.com/mohits-git/food-ordering-system/internal/utils/apperr"
 	"github.com/mohits-git/food-ordering-system/internal/utils/authctx"
 	mockservice "github.com/mohits-git/food-ordering-system/tests/mock_service"
@@ -34,7 +35,7 @@ func Test_handlers_AuthHandler_HandleLogin(t *testing.T) {
 
 	w := httptest.NewRecorder()
 	mockAuthService.On("Login", mock.Anything, "test@example.com", "12345678").Return(
-		"mocked-jwt-token", nil).Once()
+		domain.AuthTokens{AccessToken: "mocked-jwt-token", RefreshToken: "mocked-refresh-token"}, nil).Once()
 
 	handler.HandleLogin(w, req)
 	res := w.Result()
@@ -47,6 +48,7 @@ func Test_handlers_AuthHandler_HandleLogin(t *testing.T) {
 	loginResponse, err := decodeResponse[dtos.LoginResponse](res)
 	require.NoError(t, err, "expected no error while decoding response")
 	require.Equal(t, "mocked-jwt-token", loginResponse.Token, "expected token to be 'mocked-jwt-token'")
+	require.Equal(t, "mocked-refresh-token", loginResponse.RefreshToken, "expected refresh token to be 'mocked-refresh-token'")
 	ok := mockAuthService.AssertExpectations(t)
 	require.True(t, ok, "expected all expectations to be met for mockAuthService but some were not")
 }
@@ -77,7 +79,7 @@ func Test_handlers_AuthHandler_HandleLogin_Unauthorized(t *testing.T) {
 	require.NotNil(t, handler, "expected NewAuthHandler to return a non-nil handler")
 
 	mockAuthService.On("Login", mock.Anything, "test@example.com", "12345678").Return(
-		"", apperr.NewAppError(apperr.ErrUnauthorized, "invalid credentials", nil)).Once()
+		domain.AuthTokens{}, apperr.NewAppError(apperr.ErrUnauthorized, "invalid credentials", nil)).Once()
 
 	buf := bytes.NewBuffer(nil)
 	err := encodeJson(buf, dtos.LoginRequest{
@@ -100,13 +102,39 @@ func Test_handlers_AuthHandler_HandleLogin_Unauthorized(t *testing.T) {
 	require.Equal(t, 401, errorResponse.Status, "expected error status to be 401")
 }
 
+func Test_handlers_AuthHandler_HandleLogin_Suspended(t *testing.T) {
+	mockAuthService := &mockservice.AuthenticationService{}
+	handler := NewAuthHandler(mockAuthService)
+
+	mockAuthService.On("Login", mock.Anything, "test@example.com", "12345678").Return(
+		domain.AuthTokens{}, apperr.NewAppError(apperr.ErrForbidden, "account suspended", nil)).Once()
+
+	buf := bytes.NewBuffer(nil)
+	err := encodeJson(buf, dtos.LoginRequest{
+		Email:    "test@example.com",
+		Password: "12345678",
+	})
+	require.NoError(t, err, "expected no error while encoding login request")
+	req := httptest.NewRequest("POST", "/api/auth/login", buf)
+	w := httptest.NewRecorder()
+
+	handler.HandleLogin(w, req)
+	res := w.Result()
+
+	require.Equal(t, 403, res.StatusCode, "expected status code 403 for a suspended account")
+	defer res.Body.Close()
+	errorResponse, err := decodeJson[dtos.BaseResponse](res.Body)
+	require.NoError(t, err, "expected no error while decoding response")
+	require.Equal(t, "account suspended", errorResponse.Message)
+}
+
 func Test_handlers_AuthHandler_HandleLogin_NotFound(t *testing.T) {
 	mockAuthService := &mockservice.AuthenticationService{}
 	handler := NewAuthHandler(mockAuthService)
 	require.NotNil(t, handler, "expected NewAuthHandler to return a non-nil handler")
 
 	mockAuthService.On("Login", mock.Anything, "test@example.com", "12345678").Return(
-		"", apperr.NewAppError(apperr.ErrNotFound, "user not found", nil)).Once()
+		domain.AuthTokens{}, apperr.NewAppError(apperr.ErrNotFound, "user not found", nil)).Once()
 
 	buf := bytes.NewBuffer(nil)
 	err := encodeJson(buf, dtos.LoginRequest{
@@ -135,7 +163,7 @@ func Test_handlers_AuthHandler_HandleLogin_BadRequest(t *testing.T) {
 	require.NotNil(t, handler, "expected NewAuthHandler to return a non-nil handler")
 
 	mockAuthService.On("Login", mock.Anything, "test@example.com", "12345678").Return(
-		"", apperr.NewAppError(apperr.ErrInvalid, "bad request", nil)).Once()
+		domain.AuthTokens{}, apperr.NewAppError(apperr.ErrInvalid, "bad request", nil)).Once()
 
 	buf := bytes.NewBuffer(nil)
 	err := encodeJson(buf, dtos.LoginRequest{
@@ -164,7 +192,7 @@ func Test_handlers_AuthHandler_HandleLogin_InternalServerError(t *testing.T) {
 	require.NotNil(t, handler, "expected NewAuthHandler to return a non-nil handler")
 
 	mockAuthService.On("Login", mock.Anything, "test@example.com", "12345678").Return(
-		"", apperr.NewAppError(apperr.ErrInternal, "internal server error", nil)).Once()
+		domain.AuthTokens{}, apperr.NewAppError(apperr.ErrInternal, "internal server error", nil)).Once()
 
 	buf := bytes.NewBuffer(nil)
 	err := encodeJson(buf, dtos.LoginRequest{
@@ -256,3 +284,92 @@ func Test_handlers_AuthHandler_HandleLogout_InternalServerError(t *testing.T) {
 	require.NoError(t, err, "expected no error while decoding response")
 	require.Equal(t, 500, errorResponse.Status, "expected error status to be 500")
 }
+
+func Test_handlers_AuthHandler_HandleLogout_InvalidToken(t *testing.T) {
+	mockAuthService := &mockservice.AuthenticationService{}
+	handler := NewAuthHandler(mockAuthService)
+
+	req := httptest.NewRequest("POST", "/api/auth/logout", nil)
+	req = req.WithContext(authctx.WithToken(req.Context(), "mocked-jwt-token"))
+	w := httptest.NewRecorder()
+
+	mockAuthService.On("Logout", mock.Anything, "mocked-jwt-token").Return(
+		apperr.NewAppError(apperr.ErrUnauthorized, "invalid token", nil)).Once()
+
+	handler.HandleLogout(w, req)
+	res := w.Result()
+	require.Equal(t, 401, res.StatusCode, "expected status code 401 for an invalid token")
+}
+
+func Test_handlers_AuthHandler_HandleRefresh(t *testing.T) {
+	mockAuthService := &mockservice.AuthenticationService{}
+	handler := NewAuthHandler(mockAuthService)
+
+	buf := bytes.NewBuffer(nil)
+	err := encodeJson(buf, dtos.RefreshRequest{RefreshToken: "refresh-1"})
+	require.NoError(t, err, "expected no error while encoding refresh request")
+	req := httptest.NewRequest("POST", "/api/auth/refresh", buf)
+	w := httptest.NewRecorder()
+
+	mockAuthService.On("Refresh", mock.Anything, "refresh-1").Return(
+		domain.AuthTokens{AccessToken: "new-jwt-token", RefreshToken: "refresh-2"}, nil).Once()
+
+	handler.HandleRefresh(w, req)
+	res := w.Result()
+
+	require.Equal(t, 200, res.StatusCode, "expected status code 200")
+	require.Equal(t, "token=new-jwt-token; HttpOnly; Path=/api/; SameSite=Strict", res.Header.Get("Set-Cookie"))
+
+	defer res.Body.Close()
+	refreshResponse, err := decodeResponse[dtos.LoginResponse](res)
+	require.NoError(t, err, "expected no error while decoding response")
+	require.Equal(t, dtos.LoginResponse{Token: "new-jwt-token", RefreshToken: "refresh-2"}, refreshResponse)
+	mockAuthService.AssertExpectations(t)
+}
+
+func Test_handlers_AuthHandler_HandleRefresh_Errors(t *testing.T) {
+	tests := []struct {
+		name       string
+		body       string
+		err        error
+		wantStatus int
+	}{
+		{
+			name:       "Invalid Request",
+			body:       "invalid-json",
+			wantStatus: 400,
+		},
+		{
+			name:       "Invalid Refresh Token",
+			body:       `{"refresh_token":"refresh-1"}`,
+			err:        apperr.NewAppError(apperr.ErrUnauthorized, "refresh token was already used", nil),
+			wantStatus: 401,
+		},
+		{
+			name:       "Internal Server Error",
+			body:       `{"refresh_token":"refresh-1"}`,
+			err:        apperr.NewAppError(apperr.ErrInternal, "internal server error", nil),
+			wantStatus: 500,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockAuthService := &mockservice.AuthenticationService{}
+			handler := NewAuthHandler(mockAuthService)
+			mockAuthService.On("Refresh", mock.Anything, "refresh-1").Return(domain.AuthTokens{}, tt.err)
+
+			req := httptest.NewRequest("POST", "/api/auth/refresh", bytes.NewBufferString(tt.body))
+			w := httptest.NewRecorder()
+
+			handler.HandleRefresh(w, req)
+			res := w.Result()
+			require.Equal(t, tt.wantStatus, res.StatusCode)
+
+			defer res.Body.Close()
+			errorResponse, err := decodeJson[dtos.BaseResponse](res.Body)
+			require.NoError(t, err, "expected no error while decoding response")
+			require.Equal(t, tt.wantStatus, errorResponse.Status)
+		})
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"net/http"
 
 	"github.com/mohits-git/food-ordering-system/internal/adapters/http/dtos"
+	"github.com/mohits-git/food-ordering-system/internal/domain"
 	"github.com/mohits-git/food-ordering-system/internal/ports"
 	"github.com/mohits-git/food-ordering-system/internal/utils/apperr"
 )
@@ -31,10 +32,15 @@ func (h *InvoiceHandler) HandleCreateInvoice(w http.ResponseWriter, r *http.Requ
 		} else if apperr.IsForbiddenError(err) {
 			writeError(w, http.StatusForbidden, "cannot create invoice for this order")
 		} else if apperr.IsConflictError(err) {
-			writeError(w, http.StatusConflict, "invoice already exists for this order")
+			appErr, _ := err.(*apperr.AppError)
+			writeError(w, http.StatusConflict, appErr.Message)
+		} else if apperr.IsInvalidError(err) {
+			appErr, _ := err.(*apperr.AppError)
+			writeError(w, http.StatusBadRequest, appErr.Message)
 		} else {
 			writeError(w, http.StatusInternalServerError, "internal server error")
 		}
+		return
 	}
 	resp := dtos.NewInvoiceResponse(invoice)
 	writeResponse(w, http.StatusCreated, "invoice created successfully", resp)
@@ -77,14 +83,62 @@ func (h *InvoiceHandler) HandleInvoicePayment(w http.ResponseWriter, r *http.Req
 		return
 	}
 
-	err = h.invoiceService.DoInvoicePayment(r.Context(), invoiceId, paymentReq.Amount)
+	method := domain.PaymentMethod(paymentReq.Method)
+	if method == "" {
+		method = domain.PaymentCard
+	}
+
+	receipt, err := h.invoiceService.DoInvoicePayment(r.Context(), invoiceId, method, paymentReq.Amount.ToDomain())
 	if err != nil {
 		if apperr.IsNotFoundError(err) {
 			writeError(w, http.StatusNotFound, "invoice not found")
 		} else if apperr.IsForbiddenError(err) {
 			writeError(w, http.StatusForbidden, "cannot update this invoice")
 		} else if apperr.IsConflictError(err) {
-			writeError(w, http.StatusConflict, "invoice already paid")
+			appErr, _ := err.(*apperr.AppError)
+			writeError(w, http.StatusConflict, appErr.Message)
+		} else if apperr.IsUnauthorizedError(err) {
+			writeError(w, http.StatusUnauthorized, "unauthorized")
+		} else if apperr.IsInvalidError(err) {
+			appErr, _ := err.(*apperr.AppError)
+			writeError(w, http.StatusBadRequest, appErr.Message)
+		} else {
+			writeError(w, http.StatusInternalServerError, "internal server error")
+		}
+		return
+	}
+
+	resp := dtos.NewPaymentReceiptResponse(receipt)
+	if receipt.InvoiceStatus == domain.Processing {
+		writeResponse(w, http.StatusAccepted, "invoice payment is processing", resp)
+		return
+	}
+	writeResponse(w, http.StatusCreated, "payment recorded successfully", resp)
+}
+
+func (h *InvoiceHandler) HandleRefundInvoice(w http.ResponseWriter, r *http.Request) {
+	invoiceId := getIdFromPath(r, "id")
+	if invoiceId <= 0 {
+		writeError(w, http.StatusBadRequest, "invalid invoice id")
+		return
+	}
+
+	refundReq, err := decodeRequest[dtos.RefundRequest](r)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	refund, err := h.invoiceService.RefundInvoice(r.Context(), invoiceId, refundReq.Amount.ToDomain(), refundReq.Reason)
+	if err != nil {
+		if apperr.IsNotFoundError(err) {
+			appErr, _ := err.(*apperr.AppError)
+			writeError(w, http.StatusNotFound, appErr.Message)
+		} else if apperr.IsForbiddenError(err) {
+			writeError(w, http.StatusForbidden, "cannot refund this invoice")
+		} else if apperr.IsConflictError(err) {
+			appErr, _ := err.(*apperr.AppError)
+			writeError(w, http.StatusConflict, appErr.Message)
 		} else if apperr.IsUnauthorizedError(err) {
 			writeError(w, http.StatusUnauthorized, "unauthorized")
 		} else if apperr.IsInvalidError(err) {
@@ -96,5 +150,5 @@ func (h *InvoiceHandler) HandleInvoicePayment(w http.ResponseWriter, r *http.Req
 		return
 	}
 
-	writeResponse(w, http.StatusOK, "invoice payment successful", struct{}{})
+	writeResponse(w, http.StatusCreated, "invoice refunded successfully", dtos.NewRefundResponse(refund))
 }
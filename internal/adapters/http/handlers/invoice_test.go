@@ -2,8 +2,10 @@ package handlers
 
 import (
 	"bytes"
+	"errors"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/mohits-git/food-ordering-system/internal/adapters/http/dtos"
 	"github.com/mohits-git/food-ordering-system/internal/domain"
@@ -27,8 +29,8 @@ func Test_handlers_InvoiceHandler_HandlerCreateInvoice(t *testing.T) {
 	mockInvoiceService.On("GenerateInvoice", mock.Anything, 1).Return(domain.Invoice{
 		ID:            1,
 		OrderID:       1,
-		Total:         100.0,
-		Tax:           10.0,
+		Total:         domain.NewMoney(10000, "USD"),
+		Tax:           domain.NewMoney(1000, "USD"),
 		PaymentStatus: "PAID",
 	}, nil).Once()
 
@@ -137,6 +139,51 @@ func Test_handlers_InvoiceHandler_HandleCreateInvoice_Conflict(t *testing.T) {
 	mockInvoiceService.AssertExpectations(t)
 }
 
+func Test_handlers_InvoiceHandler_HandleCreateInvoice_PaymentTaken(t *testing.T) {
+	mockInvoiceService := &mockservice.InvoiceService{}
+	handler := NewInvoiceHandler(mockInvoiceService)
+
+	mockInvoiceService.On("GenerateInvoice", mock.Anything, 1).Return(
+		domain.Invoice{}, apperr.NewAppError(apperr.ErrConflict, "order has a payment on its invoice and can no longer be changed", nil)).Once()
+
+	req := httptest.NewRequest("POST", "/api/orders/1/invoices", nil)
+	req.SetPathValue("id", "1")
+
+	w := httptest.NewRecorder()
+	handler.HandleCreateInvoice(w, req)
+	res := w.Result()
+	require.Equal(t, 409, res.StatusCode, "expected status code 409")
+
+	defer res.Body.Close()
+	response, err := decodeJson[dtos.BaseResponse](res.Body)
+	require.NoError(t, err, "expected no error while decoding response")
+	require.Equal(t, "order has a payment on its invoice and can no longer be changed", response.Message)
+	require.NotContains(t, w.Body.String(), "invoice created successfully", "expected only the error response")
+	mockInvoiceService.AssertExpectations(t)
+}
+
+func Test_handlers_InvoiceHandler_HandleCreateInvoice_OrderPlaced(t *testing.T) {
+	mockInvoiceService := &mockservice.InvoiceService{}
+	handler := NewInvoiceHandler(mockInvoiceService)
+
+	mockInvoiceService.On("GenerateInvoice", mock.Anything, 1).Return(
+		domain.Invoice{}, apperr.NewAppError(apperr.ErrInvalid, "order has already been placed", nil)).Once()
+
+	req := httptest.NewRequest("POST", "/api/orders/1/invoices", nil)
+	req.SetPathValue("id", "1")
+
+	w := httptest.NewRecorder()
+	handler.HandleCreateInvoice(w, req)
+	res := w.Result()
+	require.Equal(t, 400, res.StatusCode, "expected status code 400")
+
+	defer res.Body.Close()
+	response, err := decodeJson[dtos.BaseResponse](res.Body)
+	require.NoError(t, err, "expected no error while decoding response")
+	require.Equal(t, "order has already been placed", response.Message)
+	mockInvoiceService.AssertExpectations(t)
+}
+
 func Test_handlers_InvoiceHandler_HandleCreateInvoice_Forbidden(t *testing.T) {
 	mockInvoiceService := &mockservice.InvoiceService{}
 	handler := NewInvoiceHandler(mockInvoiceService)
@@ -191,9 +238,12 @@ func Test_handlers_InvoiceHandler_HandleGetInvoice(t *testing.T) {
 	mockInvoiceService.On("GetInvoiceById", mock.Anything, 1).Return(domain.Invoice{
 		ID:            1,
 		OrderID:       1,
-		Total:         100.0,
-		Tax:           10.0,
+		Total:         domain.NewMoney(10000, "USD"),
+		Tax:           domain.NewMoney(1000, "USD"),
 		PaymentStatus: "PAID",
+		Items: []domain.InvoiceItem{
+			{MenuItemID: 1, Name: "Burger", UnitPrice: domain.NewMoney(5000, "USD"), Quantity: 2, Total: domain.NewMoney(10000, "USD"), Tax: domain.NewMoney(1000, "USD")},
+		},
 	}, nil).Once()
 
 	req := httptest.NewRequest("GET", "/api/invoices/1", nil)
@@ -209,6 +259,9 @@ func Test_handlers_InvoiceHandler_HandleGetInvoice(t *testing.T) {
 	invoice, err := decodeResponse[dtos.InvoiceResponse](res)
 	require.NoError(t, err, "expected no error while decoding response")
 	require.Equal(t, 1, invoice.ID, "expected invoice ID to be 1")
+	require.Len(t, invoice.Items, 1, "expected one invoice line item")
+	require.Equal(t, "Burger", invoice.Items[0].Name, "expected line item name to be Burger")
+	require.Equal(t, dtos.MoneyDTO{Amount: 10000, Currency: "USD"}, invoice.Items[0].Total, "expected line item total to be 100.00 USD")
 	mockInvoiceService.AssertExpectations(t)
 }
 
@@ -329,11 +382,11 @@ func Test_handlers_InvoiceHandler_HandleInvoicePayment(t *testing.T) {
 	handler := NewInvoiceHandler(mockInvoiceService)
 	require.NotNil(t, handler, "expected NewInvoiceHandler to return a non-nil handler")
 
-	mockInvoiceService.On("DoInvoicePayment", mock.Anything, 1, 110.1).Return(nil).Once()
+	mockInvoiceService.On("DoInvoicePayment", mock.Anything, 1, domain.PaymentCard, domain.NewMoney(11010, "USD")).Return(domain.PaymentReceipt{InvoiceStatus: domain.Processing}, nil).Once()
 
 	buf := bytes.NewBuffer(nil)
 	err := encodeJson(buf, dtos.PaymentRequest{
-		Amount: 110.1,
+		Amount: dtos.MoneyDTO{Amount: 11010, Currency: "USD"},
 	})
 
 	req := httptest.NewRequest("POST", "/api/invoices/1/pay", buf)
@@ -343,15 +396,75 @@ func Test_handlers_InvoiceHandler_HandleInvoicePayment(t *testing.T) {
 	handler.HandleInvoicePayment(w, req)
 
 	res := w.Result()
-	require.Equal(t, 200, res.StatusCode, "expected status code 200")
+	require.Equal(t, 202, res.StatusCode, "expected status code 202")
 	require.Equal(t, "application/json", res.Header.Get("Content-Type"), "expected content type application/json")
 
 	defer res.Body.Close()
 	response, err := decodeJson[dtos.BaseResponse](res.Body)
 
 	require.NoError(t, err, "expected no error while decoding response")
-	require.Equal(t, 200, response.Status, "expected response status to be 200")
-	require.Equal(t, "invoice payment successful", response.Message, "expected response message to be 'invoice payment successful'")
+	require.Equal(t, 202, response.Status, "expected response status to be 202")
+	require.Equal(t, "invoice payment is processing", response.Message, "expected response message to be 'invoice payment is processing'")
+	mockInvoiceService.AssertExpectations(t)
+}
+
+func Test_handlers_InvoiceHandler_HandleInvoicePayment_Cash(t *testing.T) {
+	mockInvoiceService := &mockservice.InvoiceService{}
+	handler := NewInvoiceHandler(mockInvoiceService)
+
+	paidAt := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+	mockInvoiceService.On("DoInvoicePayment", mock.Anything, 1, domain.PaymentCash, domain.NewMoney(12000, "USD")).Return(domain.PaymentReceipt{
+		Payment: domain.Payment{
+			ID:        9,
+			InvoiceID: 1,
+			PayerID:   1,
+			TakenBy:   5,
+			Method:    domain.PaymentCash,
+			Amount:    domain.NewMoney(11010, "USD"),
+			Tendered:  domain.NewMoney(12000, "USD"),
+			Change:    domain.NewMoney(990, "USD"),
+			PaidAt:    paidAt,
+		},
+		AmountDue:     domain.NewMoney(0, "USD"),
+		InvoiceStatus: domain.Paid,
+	}, nil).Once()
+
+	buf := bytes.NewBuffer(nil)
+	err := encodeJson(buf, dtos.PaymentRequest{
+		Amount: dtos.MoneyDTO{Amount: 12000, Currency: "USD"},
+		Method: "cash",
+	})
+	require.NoError(t, err, "expected no error while encoding request body")
+
+	req := httptest.NewRequest("POST", "/api/invoices/1/pay", buf)
+	req.SetPathValue("id", "1")
+
+	w := httptest.NewRecorder()
+	handler.HandleInvoicePayment(w, req)
+
+	res := w.Result()
+	require.Equal(t, 201, res.StatusCode, "expected status code 201")
+
+	defer res.Body.Close()
+	response, err := decodeJson[struct {
+		Message string                      `json:"message"`
+		Data    dtos.PaymentReceiptResponse `json:"data"`
+	}](res.Body)
+	require.NoError(t, err, "expected no error while decoding response")
+	require.Equal(t, "payment recorded successfully", response.Message)
+	require.Equal(t, dtos.PaymentReceiptResponse{
+		PaymentID:     9,
+		InvoiceID:     1,
+		PayerID:       1,
+		TakenBy:       5,
+		Method:        "cash",
+		Amount:        dtos.MoneyDTO{Amount: 11010, Currency: "USD"},
+		Tendered:      dtos.MoneyDTO{Amount: 12000, Currency: "USD"},
+		Change:        dtos.MoneyDTO{Amount: 990, Currency: "USD"},
+		PaidAt:        &paidAt,
+		AmountDue:     dtos.MoneyDTO{Amount: 0, Currency: "USD"},
+		InvoiceStatus: "paid",
+	}, response.Data)
 	mockInvoiceService.AssertExpectations(t)
 }
 
@@ -362,7 +475,7 @@ func Test_handlers_InvoiceHandler_HandleInvoicePayment_BadRequest(t *testing.T)
 
 	buf := bytes.NewBuffer(nil)
 	err := encodeJson(buf, dtos.PaymentRequest{
-		Amount: 110.1,
+		Amount: dtos.MoneyDTO{Amount: 11010, Currency: "USD"},
 	})
 	require.NoError(t, err, "expected no error while encoding request body")
 
@@ -416,12 +529,11 @@ func Test_handlers_InvoiceHandler_HandleInvoicePayment_ServiceError(t *testing.T
 	handler := NewInvoiceHandler(mockInvoiceService)
 	require.NotNil(t, handler, "expected NewInvoiceHandler to return a non-nil handler")
 
-	mockInvoiceService.On("DoInvoicePayment", mock.Anything, 1, 110.1).Return(
-		apperr.NewAppError(apperr.ErrInternal, "failed to process payment", nil)).Once()
+	mockInvoiceService.On("DoInvoicePayment", mock.Anything, 1, domain.PaymentCard, domain.NewMoney(11010, "USD")).Return(domain.PaymentReceipt{}, apperr.NewAppError(apperr.ErrInternal, "failed to process payment", nil)).Once()
 
 	buf := bytes.NewBuffer(nil)
 	err := encodeJson(buf, dtos.PaymentRequest{
-		Amount: 110.1,
+		Amount: dtos.MoneyDTO{Amount: 11010, Currency: "USD"},
 	})
 	require.NoError(t, err, "expected no error while encoding request body")
 
@@ -446,12 +558,11 @@ func Test_handlers_InvoiceHandler_HandleInvoicePayment_NotFound(t *testing.T) {
 	handler := NewInvoiceHandler(mockInvoiceService)
 	require.NotNil(t, handler, "expected NewInvoiceHandler to return a non-nil handler")
 
-	mockInvoiceService.On("DoInvoicePayment", mock.Anything, 1, 110.1).Return(
-		apperr.NewAppError(apperr.ErrNotFound, "invoice not found", nil)).Once()
+	mockInvoiceService.On("DoInvoicePayment", mock.Anything, 1, domain.PaymentCard, domain.NewMoney(11010, "USD")).Return(domain.PaymentReceipt{}, apperr.NewAppError(apperr.ErrNotFound, "invoice not found", nil)).Once()
 
 	buf := bytes.NewBuffer(nil)
 	err := encodeJson(buf, dtos.PaymentRequest{
-		Amount: 110.1,
+		Amount: dtos.MoneyDTO{Amount: 11010, Currency: "USD"},
 	})
 	require.NoError(t, err, "expected no error while encoding request body")
 
@@ -476,12 +587,11 @@ func Test_handlers_InvoiceHandler_HandleInvoicePayment_Forbidden(t *testing.T) {
 	handler := NewInvoiceHandler(mockInvoiceService)
 	require.NotNil(t, handler, "expected NewInvoiceHandler to return a non-nil handler")
 
-	mockInvoiceService.On("DoInvoicePayment", mock.Anything, 1, 110.1).Return(
-		apperr.NewAppError(apperr.ErrForbidden, "forbidden", nil)).Once()
+	mockInvoiceService.On("DoInvoicePayment", mock.Anything, 1, domain.PaymentCard, domain.NewMoney(11010, "USD")).Return(domain.PaymentReceipt{}, apperr.NewAppError(apperr.ErrForbidden, "forbidden", nil)).Once()
 
 	buf := bytes.NewBuffer(nil)
 	err := encodeJson(buf, dtos.PaymentRequest{
-		Amount: 110.1,
+		Amount: dtos.MoneyDTO{Amount: 11010, Currency: "USD"},
 	})
 	require.NoError(t, err, "expected no error while encoding request body")
 
@@ -506,12 +616,11 @@ func Test_handlers_InvoiceHandler_HandleInvoicePayment_Unauthorized(t *testing.T
 	handler := NewInvoiceHandler(mockInvoiceService)
 	require.NotNil(t, handler, "expected NewInvoiceHandler to return a non-nil handler")
 
-	mockInvoiceService.On("DoInvoicePayment", mock.Anything, 1, 110.1).Return(
-		apperr.NewAppError(apperr.ErrUnauthorized, "unauthorized", nil)).Once()
+	mockInvoiceService.On("DoInvoicePayment", mock.Anything, 1, domain.PaymentCard, domain.NewMoney(11010, "USD")).Return(domain.PaymentReceipt{}, apperr.NewAppError(apperr.ErrUnauthorized, "unauthorized", nil)).Once()
 
 	buf := bytes.NewBuffer(nil)
 	err := encodeJson(buf, dtos.PaymentRequest{
-		Amount: 110.1,
+		Amount: dtos.MoneyDTO{Amount: 11010, Currency: "USD"},
 	})
 	require.NoError(t, err, "expected no error while encoding request body")
 
@@ -530,3 +639,131 @@ func Test_handlers_InvoiceHandler_HandleInvoicePayment_Unauthorized(t *testing.T
 	require.NoError(t, err, "expected error while decoding response")
 	mockInvoiceService.AssertExpectations(t)
 }
+
+func Test_handlers_InvoiceHandler_HandleInvoicePayment_AlreadyProcessing(t *testing.T) {
+	mockInvoiceService := &mockservice.InvoiceService{}
+	handler := NewInvoiceHandler(mockInvoiceService)
+	require.NotNil(t, handler, "expected NewInvoiceHandler to return a non-nil handler")
+
+	mockInvoiceService.On("DoInvoicePayment", mock.Anything, 1, domain.PaymentCard, domain.NewMoney(11010, "USD")).Return(domain.PaymentReceipt{}, apperr.NewAppError(apperr.ErrConflict, "payment is already being processed", errors.New("attempt 7 is pending"))).Once()
+
+	buf := bytes.NewBuffer(nil)
+	err := encodeJson(buf, dtos.PaymentRequest{
+		Amount: dtos.MoneyDTO{Amount: 11010, Currency: "USD"},
+	})
+	require.NoError(t, err, "expected no error while encoding request body")
+
+	req := httptest.NewRequest("POST", "/api/invoices/1/pay", buf)
+	req.SetPathValue("id", "1")
+
+	w := httptest.NewRecorder()
+	handler.HandleInvoicePayment(w, req)
+
+	res := w.Result()
+	require.Equal(t, 409, res.StatusCode, "expected status code 409")
+
+	defer res.Body.Close()
+	response, err := decodeJson[dtos.BaseResponse](res.Body)
+	require.NoError(t, err, "expected no error while decoding response")
+	require.Equal(t, "payment is already being processed", response.Message)
+	mockInvoiceService.AssertExpectations(t)
+}
+
+func Test_handlers_InvoiceHandler_HandleRefundInvoice(t *testing.T) {
+	mockInvoiceService := &mockservice.InvoiceService{}
+	handler := NewInvoiceHandler(mockInvoiceService)
+	require.NotNil(t, handler, "expected NewInvoiceHandler to return a non-nil handler")
+
+	mockInvoiceService.On("RefundInvoice", mock.Anything, 1, domain.NewMoney(500, "USD"), "cold food").
+		Return(domain.Refund{ID: 3, InvoiceID: 1, Amount: domain.NewMoney(500, "USD"), Reason: "cold food", RefundedBy: 2}, nil).Once()
+
+	buf := bytes.NewBuffer(nil)
+	err := encodeJson(buf, dtos.RefundRequest{
+		Amount: dtos.MoneyDTO{Amount: 500, Currency: "USD"},
+		Reason: "cold food",
+	})
+	require.NoError(t, err, "expected no error while encoding request body")
+
+	req := httptest.NewRequest("POST", "/api/invoices/1/refunds", buf)
+	req.SetPathValue("id", "1")
+
+	w := httptest.NewRecorder()
+	handler.HandleRefundInvoice(w, req)
+
+	res := w.Result()
+	require.Equal(t, 201, res.StatusCode, "expected status code 201")
+
+	defer res.Body.Close()
+	response, err := decodeJson[dtos.BaseResponse](res.Body)
+	require.NoError(t, err, "expected no error while decoding response")
+	require.Equal(t, "invoice refunded successfully", response.Message)
+
+	data, ok := response.Data.(map[string]any)
+	require.True(t, ok, "expected response data to be an object")
+	require.Equal(t, float64(3), data["id"])
+	require.Equal(t, "cold food", data["reason"])
+	mockInvoiceService.AssertExpectations(t)
+}
+
+func Test_handlers_InvoiceHandler_HandleRefundInvoice_Errors(t *testing.T) {
+	tests := []struct {
+		name    string
+		err     error
+		status  int
+		message string
+	}{
+		{"not found", apperr.NewAppError(apperr.ErrNotFound, "invoice not found", nil), 404, "invoice not found"},
+		{"transaction not found", apperr.NewAppError(apperr.ErrNotFound, "transaction not found", nil), 404, "transaction not found"},
+		{"forbidden", apperr.NewAppError(apperr.ErrForbidden, "access to the invoice is forbidden", nil), 403, "cannot refund this invoice"},
+		{"conflict", apperr.NewAppError(apperr.ErrConflict, "payment has already been refunded", nil), 409, "payment has already been refunded"},
+		{"unauthorized", apperr.NewAppError(apperr.ErrUnauthorized, "user not authenticated", nil), 401, "unauthorized"},
+		{"exceeds paid", apperr.NewAppError(apperr.ErrInvalid, "refund exceeds the amount paid", nil), 400, "refund exceeds the amount paid"},
+		{"internal", apperr.NewAppError(apperr.ErrInternal, "db down", nil), 500, "internal server error"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockInvoiceService := &mockservice.InvoiceService{}
+			handler := NewInvoiceHandler(mockInvoiceService)
+
+			mockInvoiceService.On("RefundInvoice", mock.Anything, 1, domain.NewMoney(500, "USD"), "cold food").
+				Return(domain.Refund{}, tt.err).Once()
+
+			buf := bytes.NewBuffer(nil)
+			err := encodeJson(buf, dtos.RefundRequest{
+				Amount: dtos.MoneyDTO{Amount: 500, Currency: "USD"},
+				Reason: "cold food",
+			})
+			require.NoError(t, err, "expected no error while encoding request body")
+
+			req := httptest.NewRequest("POST", "/api/invoices/1/refunds", buf)
+			req.SetPathValue("id", "1")
+
+			w := httptest.NewRecorder()
+			handler.HandleRefundInvoice(w, req)
+
+			res := w.Result()
+			require.Equal(t, tt.status, res.StatusCode)
+
+			defer res.Body.Close()
+			response, err := decodeJson[dtos.BaseResponse](res.Body)
+			require.NoError(t, err, "expected no error while decoding response")
+			require.Equal(t, tt.message, response.Message)
+			mockInvoiceService.AssertExpectations(t)
+		})
+	}
+}
+
+func Test_handlers_InvoiceHandler_HandleRefundInvoice_BadRequest(t *testing.T) {
+	mockInvoiceService := &mockservice.InvoiceService{}
+	handler := NewInvoiceHandler(mockInvoiceService)
+
+	req := httptest.NewRequest("POST", "/api/invoices/1/refunds", bytes.NewBufferString("{"))
+	req.SetPathValue("id", "1")
+
+	w := httptest.NewRecorder()
+	handler.HandleRefundInvoice(w, req)
+
+	res := w.Result()
+	require.Equal(t, 400, res.StatusCode, "expected status code 400")
+	mockInvoiceService.AssertNotCalled(t, "RefundInvoice", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
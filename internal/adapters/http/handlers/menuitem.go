@@ -5,7 +5,6 @@ import (
 	"net/http"
 
 	"github.com/mohits-git/food-ordering-system/internal/adapters/http/dtos"
-	"github.com/mohits-git/food-ordering-system/internal/domain"
 	"github.com/mohits-git/food-ordering-system/internal/ports"
 	"github.com/mohits-git/food-ordering-system/internal/utils/apperr"
 )
@@ -29,8 +28,7 @@ func (h *MenuItemHandler) HandleAddMenuItemToRestaurant(w http.ResponseWriter, r
 		writeError(w, http.StatusBadRequest, "invalid request body")
 		return
 	}
-	menuItem := domain.NewMenuItem(0, addRequest.Name, addRequest.Price, addRequest.Available, restaurantId)
-	menuItemId, err := h.menuItemsService.CreateMenuItemForRestaurant(r.Context(), menuItem)
+	menuItemId, err := h.menuItemsService.CreateMenuItemForRestaurant(r.Context(), addRequest.ToDomain(0, restaurantId))
 	if err != nil {
 		log.Println("Error creating menu item:", err)
 		if apperr.IsInvalidError(err) {
@@ -38,7 +36,7 @@ func (h *MenuItemHandler) HandleAddMenuItemToRestaurant(w http.ResponseWriter, r
 		} else if apperr.IsUnauthorizedError(err) {
 			writeError(w, http.StatusUnauthorized, "unauthenticated user")
 		} else if apperr.IsForbiddenError(err) {
-			writeError(w, http.StatusForbidden, "only restaurant owners can add menu items")
+			writeError(w, http.StatusForbidden, "only restaurant owners and managers can add menu items")
 		} else {
 			writeError(w, http.StatusInternalServerError, "internal server error")
 		}
@@ -54,23 +52,13 @@ func (h *MenuItemHandler) HandleGetRestaurantMenuItems(w http.ResponseWriter, r
 		return
 	}
 
-	menuItems, err := h.menuItemsService.GetAllMenuItemsByRestaurantId(r.Context(), restaurantId)
+	menus, err := h.menuItemsService.GetAllMenuItemsByRestaurantId(r.Context(), restaurantId)
 	if err != nil {
 		writeError(w, http.StatusInternalServerError, "internal server error")
 		return
 	}
 
-	// build response
-	var itemsResponse []dtos.MenuItemResponse
-	for _, item := range menuItems {
-		itemsResponse = append(itemsResponse, dtos.NewMenuItemResponse(item))
-	}
-	response := dtos.GetMenuItemsResponse{
-		RestaurantID: restaurantId,
-		Items:        itemsResponse,
-	}
-
-	writeResponse(w, http.StatusOK, "menu items fetched successfully", response)
+	writeResponse(w, http.StatusOK, "menu items fetched successfully", dtos.NewGetMenuItemsResponse(restaurantId, menus))
 }
 
 func (h *MenuItemHandler) HandleUpdateAvailability(w http.ResponseWriter, r *http.Request) {
@@ -93,9 +81,11 @@ func (h *MenuItemHandler) HandleUpdateAvailability(w http.ResponseWriter, r *htt
 		} else if apperr.IsUnauthorizedError(err) {
 			writeError(w, http.StatusUnauthorized, "unauthenticated user")
 		} else if apperr.IsForbiddenError(err) {
-			writeError(w, http.StatusForbidden, "only restaurant owners can update menu items")
+			writeError(w, http.StatusForbidden, "only restaurant owners and managers can update menu items")
 		} else if apperr.IsNotFoundError(err) {
 			writeError(w, http.StatusNotFound, "menu item not found")
+		} else if apperr.IsConflictError(err) {
+			writeError(w, http.StatusConflict, err.Error())
 		} else {
 			writeError(w, http.StatusInternalServerError, "internal server error")
 		}
@@ -104,3 +94,215 @@ func (h *MenuItemHandler) HandleUpdateAvailability(w http.ResponseWriter, r *htt
 
 	writeResponse(w, http.StatusOK, "menu item availability updated successfully", dtos.UpdateMenuItemResponse{})
 }
+
+func (h *MenuItemHandler) HandleUpdateStock(w http.ResponseWriter, r *http.Request) {
+	menuItemId := getIdFromPath(r, "id")
+	if menuItemId <= 0 {
+		writeError(w, http.StatusBadRequest, "invalid menu item id")
+		return
+	}
+
+	updateRequest, err := decodeRequest[dtos.UpdateMenuItemStockRequest](r)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	trackStock, stock := updateRequest.Stock != nil, 0
+	if trackStock {
+		stock = *updateRequest.Stock
+	}
+	err = h.menuItemsService.UpdateStock(r.Context(), menuItemId, trackStock, stock)
+	if err != nil {
+		if apperr.IsInvalidError(err) {
+			writeError(w, http.StatusBadRequest, err.Error())
+		} else if apperr.IsUnauthorizedError(err) {
+			writeError(w, http.StatusUnauthorized, "unauthenticated user")
+		} else if apperr.IsForbiddenError(err) {
+			writeError(w, http.StatusForbidden, "only restaurant owners and managers can update menu items")
+		} else if apperr.IsNotFoundError(err) {
+			writeError(w, http.StatusNotFound, "menu item not found")
+		} else {
+			log.Println("Error updating menu item stock:", err)
+			writeError(w, http.StatusInternalServerError, "internal server error")
+		}
+		return
+	}
+
+	writeResponse(w, http.StatusOK, "menu item stock updated successfully", dtos.UpdateMenuItemResponse{})
+}
+
+func (h *MenuItemHandler) HandleUpdateMenuItem(w http.ResponseWriter, r *http.Request) {
+	menuItemId := getIdFromPath(r, "id")
+	if menuItemId <= 0 {
+		writeError(w, http.StatusBadRequest, "invalid menu item id")
+		return
+	}
+
+	updateRequest, err := decodeRequest[dtos.UpdateMenuItemRequest](r)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	err = h.menuItemsService.UpdateMenuItem(r.Context(), updateRequest.ToDomain(menuItemId, 0))
+	if err != nil {
+		if apperr.IsInvalidError(err) {
+			writeError(w, http.StatusBadRequest, "invalid menu item data")
+		} else if apperr.IsUnauthorizedError(err) {
+			writeError(w, http.StatusUnauthorized, "unauthenticated user")
+		} else if apperr.IsForbiddenError(err) {
+			writeError(w, http.StatusForbidden, "only restaurant owners and managers can update menu items")
+		} else if apperr.IsNotFoundError(err) {
+			writeError(w, http.StatusNotFound, "menu item not found")
+		} else if apperr.IsConflictError(err) {
+			writeError(w, http.StatusConflict, err.Error())
+		} else {
+			log.Println("Error updating menu item:", err)
+			writeError(w, http.StatusInternalServerError, "internal server error")
+		}
+		return
+	}
+
+	writeResponse(w, http.StatusOK, "menu item updated successfully", dtos.UpdateMenuItemResponse{})
+}
+
+func (h *MenuItemHandler) HandleDeleteMenuItem(w http.ResponseWriter, r *http.Request) {
+	menuItemId := getIdFromPath(r, "id")
+	if menuItemId <= 0 {
+		writeError(w, http.StatusBadRequest, "invalid menu item id")
+		return
+	}
+
+	err := h.menuItemsService.DeleteMenuItem(r.Context(), menuItemId)
+	if err != nil {
+		if apperr.IsInvalidError(err) {
+			writeError(w, http.StatusBadRequest, "invalid menu item id")
+		} else if apperr.IsUnauthorizedError(err) {
+			writeError(w, http.StatusUnauthorized, "unauthenticated user")
+		} else if apperr.IsForbiddenError(err) {
+			writeError(w, http.StatusForbidden, "only restaurant owners and managers can delete menu items")
+		} else if apperr.IsNotFoundError(err) {
+			writeError(w, http.StatusNotFound, "menu item not found")
+		} else {
+			log.Println("Error deleting menu item:", err)
+			writeError(w, http.StatusInternalServerError, "internal server error")
+		}
+		return
+	}
+
+	writeResponse(w, http.StatusOK, "menu item deleted successfully", dtos.UpdateMenuItemResponse{})
+}
+
+func (h *MenuItemHandler) HandleGetRestaurantMenus(w http.ResponseWriter, r *http.Request) {
+	restaurantId := getIdFromPath(r, "id")
+	if restaurantId <= 0 {
+		writeError(w, http.StatusBadRequest, "invalid restaurant id")
+		return
+	}
+
+	menus, err := h.menuItemsService.GetMenusByRestaurantId(r.Context(), restaurantId)
+	if err != nil {
+		if apperr.IsInvalidError(err) {
+			writeError(w, http.StatusBadRequest, "invalid restaurant id")
+		} else {
+			log.Println("Error fetching menus:", err)
+			writeError(w, http.StatusInternalServerError, "internal server error")
+		}
+		return
+	}
+
+	response := dtos.GetMenusResponse{RestaurantID: restaurantId}
+	for _, menu := range menus {
+		response.Menus = append(response.Menus, dtos.NewMenuResponse(menu))
+	}
+	writeResponse(w, http.StatusOK, "menus fetched successfully", response)
+}
+
+func (h *MenuItemHandler) HandleAddMenu(w http.ResponseWriter, r *http.Request) {
+	restaurantId := getIdFromPath(r, "id")
+	if restaurantId <= 0 {
+		writeError(w, http.StatusBadRequest, "invalid restaurant id")
+		return
+	}
+	addRequest, err := decodeRequest[dtos.MenuRequest](r)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	menuId, err := h.menuItemsService.CreateMenu(r.Context(), addRequest.ToDomain(0, restaurantId))
+	if err != nil {
+		if apperr.IsInvalidError(err) {
+			writeError(w, http.StatusBadRequest, err.Error())
+		} else if apperr.IsUnauthorizedError(err) {
+			writeError(w, http.StatusUnauthorized, "unauthenticated user")
+		} else if apperr.IsForbiddenError(err) {
+			writeError(w, http.StatusForbidden, "only restaurant owners and managers can manage menus")
+		} else if apperr.IsNotFoundError(err) {
+			writeError(w, http.StatusNotFound, "restaurant not found")
+		} else {
+			log.Println("Error creating menu:", err)
+			writeError(w, http.StatusInternalServerError, "internal server error")
+		}
+		return
+	}
+	writeResponse(w, http.StatusCreated, "menu added successfully", dtos.AddMenuResponse{ID: menuId})
+}
+
+func (h *MenuItemHandler) HandleUpdateMenu(w http.ResponseWriter, r *http.Request) {
+	menuId := getIdFromPath(r, "id")
+	if menuId <= 0 {
+		writeError(w, http.StatusBadRequest, "invalid menu id")
+		return
+	}
+	updateRequest, err := decodeRequest[dtos.MenuRequest](r)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	err = h.menuItemsService.UpdateMenu(r.Context(), updateRequest.ToDomain(menuId, 0))
+	if err != nil {
+		if apperr.IsInvalidError(err) {
+			writeError(w, http.StatusBadRequest, err.Error())
+		} else if apperr.IsUnauthorizedError(err) {
+			writeError(w, http.StatusUnauthorized, "unauthenticated user")
+		} else if apperr.IsForbiddenError(err) {
+			writeError(w, http.StatusForbidden, "only restaurant owners and managers can manage menus")
+		} else if apperr.IsNotFoundError(err) {
+			writeError(w, http.StatusNotFound, "menu not found")
+		} else {
+			log.Println("Error updating menu:", err)
+			writeError(w, http.StatusInternalServerError, "internal server error")
+		}
+		return
+	}
+	writeResponse(w, http.StatusOK, "menu updated successfully", dtos.UpdateMenuResponse{})
+}
+
+func (h *MenuItemHandler) HandleDeleteMenu(w http.ResponseWriter, r *http.Request) {
+	menuId := getIdFromPath(r, "id")
+	if menuId <= 0 {
+		writeError(w, http.StatusBadRequest, "invalid menu id")
+		return
+	}
+
+	err := h.menuItemsService.DeleteMenu(r.Context(), menuId)
+	if err != nil {
+		if apperr.IsInvalidError(err) {
+			writeError(w, http.StatusBadRequest, "invalid menu id")
+		} else if apperr.IsUnauthorizedError(err) {
+			writeError(w, http.StatusUnauthorized, "unauthenticated user")
+		} else if apperr.IsForbiddenError(err) {
+			writeError(w, http.StatusForbidden, "only restaurant owners and managers can manage menus")
+		} else if apperr.IsNotFoundError(err) {
+			writeError(w, http.StatusNotFound, "menu not found")
+		} else {
+			log.Println("Error deleting menu:", err)
+			writeError(w, http.StatusInternalServerError, "internal server error")
+		}
+		return
+	}
+	writeResponse(w, http.StatusOK, "menu deleted successfully", dtos.UpdateMenuResponse{})
+}
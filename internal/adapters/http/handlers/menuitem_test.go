@@ -27,7 +27,7 @@ func Test_handlers_MenuItemHandler_HandleAddMenuItemToRestaurant(t *testing.T) {
 
 	addRequest := dtos.AddMenuItemRequest{
 		Name:      "Test Menu Item",
-		Price:     9.99,
+		Price:     dtos.MoneyDTO{Amount: 999, Currency: "USD"},
 		Available: true,
 	}
 	requestBody, err := json.Marshal(addRequest)
@@ -35,7 +35,7 @@ func Test_handlers_MenuItemHandler_HandleAddMenuItemToRestaurant(t *testing.T) {
 
 	mockservice.On("CreateMenuItemForRestaurant", mock.Anything, mock.MatchedBy(func(menuItem domain.MenuItem) bool {
 		return menuItem.Name == addRequest.Name &&
-			menuItem.Price == addRequest.Price &&
+			menuItem.Price == addRequest.Price.ToDomain() &&
 			menuItem.Available == addRequest.Available &&
 			menuItem.RestaurantID == 1
 	})).Return(1, nil).Once()
@@ -109,7 +109,7 @@ func Test_handlers_MenuItemHandler_HandleAddMenuItemToRestaurant_InvalidRequest(
 	buf := bytes.NewBuffer(nil)
 	err := encodeJson(buf, dtos.AddMenuItemRequest{
 		Name:  "",
-		Price: 10,
+		Price: dtos.MoneyDTO{Amount: 1000, Currency: "USD"},
 	})
 	require.NoError(t, err, "expected no error while encoding request body")
 
@@ -138,7 +138,7 @@ func Test_handlers_MenuItemHandler_HandleAddMenuItemToRestaurant_ServiceError(t
 
 	addRequest := dtos.AddMenuItemRequest{
 		Name:      "Test Menu Item",
-		Price:     9.99,
+		Price:     dtos.MoneyDTO{Amount: 999, Currency: "USD"},
 		Available: true,
 	}
 	requestBody, err := json.Marshal(addRequest)
@@ -146,7 +146,7 @@ func Test_handlers_MenuItemHandler_HandleAddMenuItemToRestaurant_ServiceError(t
 
 	mockservice.On("CreateMenuItemForRestaurant", mock.Anything, mock.MatchedBy(func(menuItem domain.MenuItem) bool {
 		return menuItem.Name == addRequest.Name &&
-			menuItem.Price == addRequest.Price &&
+			menuItem.Price == addRequest.Price.ToDomain() &&
 			menuItem.Available == addRequest.Available &&
 			menuItem.RestaurantID == 1
 	})).Return(
@@ -178,7 +178,7 @@ func Test_handlers_MenuItemHandler_HandleAddMenuItemToRestaurant_Unauthorized(t
 
 	addRequest := dtos.AddMenuItemRequest{
 		Name:      "Test Menu Item",
-		Price:     9.99,
+		Price:     dtos.MoneyDTO{Amount: 999, Currency: "USD"},
 		Available: true,
 	}
 	requestBody, err := json.Marshal(addRequest)
@@ -186,7 +186,7 @@ func Test_handlers_MenuItemHandler_HandleAddMenuItemToRestaurant_Unauthorized(t
 
 	mockservice.On("CreateMenuItemForRestaurant", mock.Anything, mock.MatchedBy(func(menuItem domain.MenuItem) bool {
 		return menuItem.Name == addRequest.Name &&
-			menuItem.Price == addRequest.Price &&
+			menuItem.Price == addRequest.Price.ToDomain() &&
 			menuItem.Available == addRequest.Available &&
 			menuItem.RestaurantID == 1
 	})).Return(
@@ -218,7 +218,7 @@ func Test_handlers_MenuItemHandler_HandleAddMenuItemToRestaurant_Forbidden(t *te
 
 	addRequest := dtos.AddMenuItemRequest{
 		Name:      "Test Menu Item",
-		Price:     9.99,
+		Price:     dtos.MoneyDTO{Amount: 999, Currency: "USD"},
 		Available: true,
 	}
 	requestBody, err := json.Marshal(addRequest)
@@ -226,7 +226,7 @@ func Test_handlers_MenuItemHandler_HandleAddMenuItemToRestaurant_Forbidden(t *te
 
 	mockservice.On("CreateMenuItemForRestaurant", mock.Anything, mock.MatchedBy(func(menuItem domain.MenuItem) bool {
 		return menuItem.Name == addRequest.Name &&
-			menuItem.Price == addRequest.Price &&
+			menuItem.Price == addRequest.Price.ToDomain() &&
 			menuItem.Available == addRequest.Available &&
 			menuItem.RestaurantID == 1
 	})).Return(
@@ -248,7 +248,7 @@ func Test_handlers_MenuItemHandler_HandleAddMenuItemToRestaurant_Forbidden(t *te
 
 	require.NoError(t, err, "expected no error while decoding response")
 	require.Equal(t, 403, errorResponse.Status, "expected error status to be 403")
-	require.Contains(t, errorResponse.Message, "only restaurant owners can add menu items", "expected error message to contain 'only restaurant owners can add menu items'")
+	require.Contains(t, errorResponse.Message, "only restaurant owners and managers can add menu items", "expected error message to contain 'only restaurant owners and managers can add menu items'")
 }
 
 func Test_handlers_MenuItemHandler_HandleGetRestaurantMenuItems(t *testing.T) {
@@ -256,12 +256,20 @@ func Test_handlers_MenuItemHandler_HandleGetRestaurantMenuItems(t *testing.T) {
 	handler := NewMenuItemHandler(mockservice)
 	require.NotNil(t, handler, "expected NewMenuItemHandler to return a non-nil handler")
 
-	menuItems := []domain.MenuItem{
-		domain.NewMenuItem(1, "Item 1", 10.0, true, 1),
-		domain.NewMenuItem(2, "Item 2", 15.0, false, 1),
+	item1 := domain.NewMenuItem(1, "Item 1", domain.NewMoney(1000, "USD"), true, 1)
+	item2 := domain.NewMenuItem(2, "Item 2", domain.NewMoney(1500, "USD"), false, 1)
+	menus := []domain.Menu{
+		{ID: 1, RestaurantID: 1, Name: "Lunch", Schedules: []domain.MenuSchedule{{Start: 660, End: 900}}, Categories: []domain.MenuCategory{
+			{ID: 1, Name: "Mains", ItemIDs: []int{1}, Items: []domain.MenuItem{item1}},
+			{ID: 2, Name: "Desserts", ItemIDs: []int{2}, Items: []domain.MenuItem{item2}},
+		}},
+		// items on several menus are listed once
+		{ID: 2, RestaurantID: 1, Name: "Drinks", Categories: []domain.MenuCategory{
+			{ID: 3, Name: "Specials", ItemIDs: []int{2}, Items: []domain.MenuItem{item2}},
+		}},
 	}
 
-	mockservice.On("GetAllMenuItemsByRestaurantId", mock.Anything, 1).Return(menuItems, nil).Once()
+	mockservice.On("GetAllMenuItemsByRestaurantId", mock.Anything, 1).Return(menus, nil).Once()
 
 	req := httptest.NewRequest("GET", "/api/restaurants/1/items", nil)
 	req.SetPathValue("id", "1")
@@ -282,13 +290,20 @@ func Test_handlers_MenuItemHandler_HandleGetRestaurantMenuItems(t *testing.T) {
 
 	require.Equal(t, 1, getResponse.Items[0].ID)
 	require.Equal(t, "Item 1", getResponse.Items[0].Name)
-	require.Equal(t, 10.0, getResponse.Items[0].Price)
+	require.Equal(t, dtos.MoneyDTO{Amount: 1000, Currency: "USD"}, getResponse.Items[0].Price)
 	require.True(t, getResponse.Items[0].Available)
 
 	require.Equal(t, 2, getResponse.Items[1].ID)
 	require.Equal(t, "Item 2", getResponse.Items[1].Name)
-	require.Equal(t, 15.0, getResponse.Items[1].Price)
+	require.Equal(t, dtos.MoneyDTO{Amount: 1500, Currency: "USD"}, getResponse.Items[1].Price)
 	require.False(t, getResponse.Items[1].Available)
+
+	require.Len(t, getResponse.Menus, 2, "expected 2 menus in response")
+	require.Equal(t, "Lunch", getResponse.Menus[0].Name)
+	require.Equal(t, []dtos.MenuScheduleDTO{{Start: "11:00", End: "15:00"}}, getResponse.Menus[0].Schedules)
+	require.Equal(t, "Desserts", getResponse.Menus[0].Categories[1].Name)
+	require.Equal(t, 2, getResponse.Menus[0].Categories[1].Items[0].ID)
+	require.Empty(t, getResponse.Menus[1].Schedules)
 }
 
 func Test_handlers_MenuItemHandler_HandleGetRestaurantMenuItems_InvalidId(t *testing.T) {
@@ -315,7 +330,7 @@ func Test_handlers_MenuItemHandler_HandleGetRestaurantMenuItems_ServiceError(t *
 	require.NotNil(t, handler, "expected NewMenuItemHandler to return a non-nil handler")
 
 	mockservice.On("GetAllMenuItemsByRestaurantId", mock.Anything, 1).Return(
-		[]domain.MenuItem{}, apperr.NewAppError(apperr.ErrInternal, "failed to fetch menu items", nil)).Once()
+		[]domain.Menu{}, apperr.NewAppError(apperr.ErrInternal, "failed to fetch menu items", nil)).Once()
 
 	req := httptest.NewRequest("GET", "/api/restaurants/1/items", nil)
 	req.SetPathValue("id", "1")
@@ -340,7 +355,7 @@ func Test_handlers_MenuItemHandler_HandleGetRestaurantMenuItems_NoItems(t *testi
 	handler := NewMenuItemHandler(mockservice)
 	require.NotNil(t, handler, "expected NewMenuItemHandler to return a non-nil handler")
 
-	mockservice.On("GetAllMenuItemsByRestaurantId", mock.Anything, 1).Return([]domain.MenuItem{}, nil).Once()
+	mockservice.On("GetAllMenuItemsByRestaurantId", mock.Anything, 1).Return([]domain.Menu{}, nil).Once()
 
 	req := httptest.NewRequest("GET", "/api/restaurants/1/items", nil)
 	req.SetPathValue("id", "1")
@@ -393,6 +408,63 @@ func Test_handlers_MenuItemHandler_HandleUpdateAvailability(t *testing.T) {
 	require.Equal(t, "menu item availability updated successfully", updateResponse.Message, "expected success message")
 }
 
+func Test_handlers_MenuItemHandler_HandleUpdateStock(t *testing.T) {
+	tests := []struct {
+		name           string
+		body           string
+		trackStock     bool
+		stock          int
+		serviceErr     error
+		expectedStatus int
+		expectedMsg    string
+	}{
+		{"set stock", `{"stock": 5}`, true, 5, nil, 200, "menu item stock updated successfully"},
+		{"sold out", `{"stock": 0}`, true, 0, nil, 200, "menu item stock updated successfully"},
+		{"stop tracking", `{"stock": null}`, false, 0, nil, 200, "menu item stock updated successfully"},
+		{"negative stock", `{"stock": -1}`, true, -1, apperr.NewAppError(apperr.ErrInvalid, "stock cannot be negative", nil), 400, "stock cannot be negative"},
+		{"forbidden", `{"stock": 5}`, true, 5, apperr.NewAppError(apperr.ErrForbidden, "forbidden", nil), 403, "only restaurant owners and managers can update menu items"},
+		{"not found", `{"stock": 5}`, true, 5, apperr.NewAppError(apperr.ErrNotFound, "menu item not found", nil), 404, "menu item not found"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockservice := &mockservice.MenuItemService{}
+			handler := NewMenuItemHandler(mockservice)
+			mockservice.On("UpdateStock", mock.Anything, 1, tt.trackStock, tt.stock).Return(tt.serviceErr).Once()
+
+			req := httptest.NewRequest("PATCH", "/api/items/1/stock", bytes.NewReader([]byte(tt.body)))
+			req.SetPathValue("id", "1")
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+			handler.HandleUpdateStock(w, req)
+			res := w.Result()
+			defer res.Body.Close()
+
+			require.Equal(t, tt.expectedStatus, res.StatusCode)
+			response, err := decodeJson[dtos.BaseResponse](res.Body)
+			require.NoError(t, err, "expected no error while decoding response")
+			require.Equal(t, tt.expectedMsg, response.Message)
+			mockservice.AssertExpectations(t)
+		})
+	}
+}
+
+func Test_handlers_MenuItemHandler_HandleUpdateStock_InvalidRequestBody(t *testing.T) {
+	mockservice := &mockservice.MenuItemService{}
+	handler := NewMenuItemHandler(mockservice)
+
+	req := httptest.NewRequest("PATCH", "/api/items/1/stock", bytes.NewReader([]byte(`{"stock": "many"}`)))
+	req.SetPathValue("id", "1")
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	handler.HandleUpdateStock(w, req)
+	res := w.Result()
+	defer res.Body.Close()
+
+	require.Equal(t, 400, res.StatusCode, "expected status code 400")
+	mockservice.AssertNotCalled(t, "UpdateStock", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
 func Test_handlers_MenuItemHandler_HandleUpdateAvailability_InvalidId(t *testing.T) {
 	mockservice := &mockservice.MenuItemService{}
 	handler := NewMenuItemHandler(mockservice)
@@ -534,7 +606,7 @@ func Test_handlers_MenuItemHandler_HandleUpdateAvailability_Forbidden(t *testing
 
 	require.NoError(t, err, "expected no error while decoding response")
 	require.Equal(t, 403, errorResponse.Status, "expected error status to be 403")
-	require.Contains(t, errorResponse.Message, "only restaurant owners can update menu items", "expected error message to contain 'only restaurant owners can update menu items'")
+	require.Contains(t, errorResponse.Message, "only restaurant owners and managers can update menu items", "expected error message to contain 'only restaurant owners and managers can update menu items'")
 }
 
 func Test_handlers_MenuItemHandler_HandleUpdateAvailability_NotFound(t *testing.T) {
@@ -570,3 +642,310 @@ func Test_handlers_MenuItemHandler_HandleUpdateAvailability_NotFound(t *testing.
 	require.Equal(t, 404, errorResponse.Status, "expected error status to be 404")
 	require.Contains(t, errorResponse.Message, "menu item not found", "expected error message to contain 'menu item not found'")
 }
+
+func Test_handlers_MenuItemHandler_HandleUpdateMenuItem(t *testing.T) {
+	mockservice := &mockservice.MenuItemService{}
+	handler := NewMenuItemHandler(mockservice)
+
+	updateRequest := dtos.UpdateMenuItemRequest{
+		Name:        "Pizza",
+		Price:       dtos.MoneyDTO{Amount: 1200, Currency: "USD"},
+		Available:   true,
+		Category:    "food",
+		Description: "Wood fired",
+		ImageURL:    "https://example.com/pizza.png",
+		SortOrder:   2,
+	}
+	requestBody, err := json.Marshal(updateRequest)
+	require.NoError(t, err, "expected no error while marshalling request body")
+
+	mockservice.On("UpdateMenuItem", mock.Anything, domain.MenuItem{
+		ID:          1,
+		Name:        "Pizza",
+		Price:       domain.NewMoney(1200, "USD"),
+		Available:   true,
+		Category:    "food",
+		Description: "Wood fired",
+		ImageURL:    "https://example.com/pizza.png",
+		SortOrder:   2,
+	}).Return(nil).Once()
+
+	req := httptest.NewRequest("PUT", "/api/items/1", bytes.NewReader(requestBody))
+	req.SetPathValue("id", "1")
+	w := httptest.NewRecorder()
+	handler.HandleUpdateMenuItem(w, req)
+	res := w.Result()
+
+	require.Equal(t, 200, res.StatusCode, "expected status code 200")
+	mockservice.AssertExpectations(t)
+}
+
+func Test_handlers_MenuItemHandler_HandleUpdateMenuItem_WithModifierGroups(t *testing.T) {
+	mockservice := &mockservice.MenuItemService{}
+	handler := NewMenuItemHandler(mockservice)
+
+	// the new option has no id yet, and its price delta takes the currency of the item
+	requestBody := `{"name": "Pizza", "price": {"amount": 1000, "currency": "EUR"}, "available": true, "modifier_groups": [
+		{"id": 5, "name": "Size", "min_select": 1, "max_select": 1, "options": [
+			{"id": 10, "name": "Regular", "price_delta": {"amount": 0, "currency": "EUR"}},
+			{"name": "Large", "price_delta": {"amount": 300}}
+		]}
+	]}`
+
+	mockservice.On("UpdateMenuItem", mock.Anything, domain.MenuItem{
+		ID:        1,
+		Name:      "Pizza",
+		Price:     domain.NewMoney(1000, "EUR"),
+		Available: true,
+		ModifierGroups: []domain.ModifierGroup{
+			{ID: 5, MenuItemID: 1, Name: "Size", MinSelect: 1, MaxSelect: 1, Options: []domain.ModifierOption{
+				{ID: 10, GroupID: 5, Name: "Regular", PriceDelta: domain.NewMoney(0, "EUR")},
+				{GroupID: 5, Name: "Large", PriceDelta: domain.NewMoney(300, "EUR")},
+			}},
+		},
+	}).Return(nil).Once()
+
+	req := httptest.NewRequest("PUT", "/api/items/1", bytes.NewReader([]byte(requestBody)))
+	req.SetPathValue("id", "1")
+	w := httptest.NewRecorder()
+	handler.HandleUpdateMenuItem(w, req)
+	res := w.Result()
+
+	require.Equal(t, 200, res.StatusCode, "expected status code 200")
+	mockservice.AssertExpectations(t)
+}
+
+func Test_handlers_MenuItemHandler_HandleUpdateMenuItem_Errors(t *testing.T) {
+	tests := []struct {
+		name   string
+		err    error
+		status int
+	}{
+		{"invalid", apperr.NewAppError(apperr.ErrInvalid, "invalid menu item data", nil), 400},
+		{"unauthenticated", apperr.NewAppError(apperr.ErrUnauthorized, "user not authenticated", nil), 401},
+		{"forbidden", apperr.NewAppError(apperr.ErrForbidden, "not allowed to update menu items", nil), 403},
+		{"not found", apperr.NewAppError(apperr.ErrNotFound, "menu item not found", nil), 404},
+		{"internal", apperr.NewAppError(apperr.ErrInternal, "database error", nil), 500},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockservice := &mockservice.MenuItemService{}
+			handler := NewMenuItemHandler(mockservice)
+
+			mockservice.On("UpdateMenuItem", mock.Anything, mock.Anything).Return(tt.err).Once()
+
+			requestBody, err := json.Marshal(dtos.UpdateMenuItemRequest{Name: "Pizza", Price: dtos.MoneyDTO{Amount: 1200, Currency: "USD"}})
+			require.NoError(t, err, "expected no error while marshalling request body")
+
+			req := httptest.NewRequest("PUT", "/api/items/1", bytes.NewReader(requestBody))
+			req.SetPathValue("id", "1")
+			w := httptest.NewRecorder()
+			handler.HandleUpdateMenuItem(w, req)
+			res := w.Result()
+
+			require.Equal(t, tt.status, res.StatusCode, "expected status code %d", tt.status)
+		})
+	}
+}
+
+func Test_handlers_MenuItemHandler_HandleDeleteMenuItem(t *testing.T) {
+	mockservice := &mockservice.MenuItemService{}
+	handler := NewMenuItemHandler(mockservice)
+
+	mockservice.On("DeleteMenuItem", mock.Anything, 1).Return(nil).Once()
+
+	req := httptest.NewRequest("DELETE", "/api/items/1", nil)
+	req.SetPathValue("id", "1")
+	w := httptest.NewRecorder()
+	handler.HandleDeleteMenuItem(w, req)
+	res := w.Result()
+
+	require.Equal(t, 200, res.StatusCode, "expected status code 200")
+
+	defer res.Body.Close()
+	deleteResponse, err := decodeJson[dtos.BaseResponse](res.Body)
+	require.NoError(t, err, "expected no error while decoding response")
+	require.Equal(t, "menu item deleted successfully", deleteResponse.Message, "expected success message")
+	mockservice.AssertExpectations(t)
+}
+
+func Test_handlers_MenuItemHandler_HandleDeleteMenuItem_NotFound(t *testing.T) {
+	mockservice := &mockservice.MenuItemService{}
+	handler := NewMenuItemHandler(mockservice)
+
+	mockservice.On("DeleteMenuItem", mock.Anything, 1).
+		Return(apperr.NewAppError(apperr.ErrNotFound, "menu item not found", nil)).Once()
+
+	req := httptest.NewRequest("DELETE", "/api/items/1", nil)
+	req.SetPathValue("id", "1")
+	w := httptest.NewRecorder()
+	handler.HandleDeleteMenuItem(w, req)
+	res := w.Result()
+
+	require.Equal(t, 404, res.StatusCode, "expected status code 404")
+	mockservice.AssertExpectations(t)
+}
+
+func Test_handlers_MenuItemHandler_HandleDeleteMenuItem_InvalidId(t *testing.T) {
+	mockservice := &mockservice.MenuItemService{}
+	handler := NewMenuItemHandler(mockservice)
+
+	req := httptest.NewRequest("DELETE", "/api/items/abc", nil)
+	req.SetPathValue("id", "abc")
+	w := httptest.NewRecorder()
+	handler.HandleDeleteMenuItem(w, req)
+	res := w.Result()
+
+	require.Equal(t, 400, res.StatusCode, "expected status code 400")
+	mockservice.AssertNotCalled(t, "DeleteMenuItem", mock.Anything, mock.Anything)
+}
+
+func Test_handlers_MenuItemHandler_HandleGetRestaurantMenus(t *testing.T) {
+	mockservice := &mockservice.MenuItemService{}
+	handler := NewMenuItemHandler(mockservice)
+
+	menus := []domain.Menu{
+		{ID: 1, RestaurantID: 1, Name: "Breakfast", Schedules: []domain.MenuSchedule{{Start: 420, End: 660}}, Categories: []domain.MenuCategory{
+			{ID: 1, Name: "Eggs", ItemIDs: []int{1}, Items: []domain.MenuItem{domain.NewMenuItem(1, "Omelette", domain.NewMoney(800, "USD"), true, 1)}},
+		}},
+		{ID: 2, RestaurantID: 1, Name: "Late night", Schedules: []domain.MenuSchedule{{Start: 1320, End: 120}}},
+	}
+	mockservice.On("GetMenusByRestaurantId", mock.Anything, 1).Return(menus, nil).Once()
+
+	req := httptest.NewRequest("GET", "/api/restaurants/1/menus", nil)
+	req.SetPathValue("id", "1")
+	w := httptest.NewRecorder()
+	handler.HandleGetRestaurantMenus(w, req)
+	res := w.Result()
+
+	require.Equal(t, 200, res.StatusCode, "expected status code 200")
+
+	defer res.Body.Close()
+	getResponse, err := decodeResponse[dtos.GetMenusResponse](res)
+	require.NoError(t, err, "expected no error while decoding response")
+	require.Len(t, getResponse.Menus, 2)
+	require.Equal(t, "Breakfast", getResponse.Menus[0].Name)
+	require.Equal(t, []dtos.MenuScheduleDTO{{Start: "07:00", End: "11:00"}}, getResponse.Menus[0].Schedules)
+	require.Equal(t, "Omelette", getResponse.Menus[0].Categories[0].Items[0].Name)
+	require.Equal(t, []dtos.MenuScheduleDTO{{Start: "22:00", End: "02:00"}}, getResponse.Menus[1].Schedules)
+	mockservice.AssertExpectations(t)
+}
+
+func Test_handlers_MenuItemHandler_HandleAddMenu(t *testing.T) {
+	mockservice := &mockservice.MenuItemService{}
+	handler := NewMenuItemHandler(mockservice)
+
+	requestBody := `{"name":"Lunch","schedules":[{"start":"11:30","end":"15:00"}],"categories":[{"name":"Mains","item_ids":[2,1]},{"name":"Drinks","item_ids":[3]}]}`
+	mockservice.On("CreateMenu", mock.Anything, domain.Menu{
+		RestaurantID: 1,
+		Name:         "Lunch",
+		Schedules:    []domain.MenuSchedule{{Start: 690, End: 900}},
+		Categories: []domain.MenuCategory{
+			{Name: "Mains", ItemIDs: []int{2, 1}},
+			{Name: "Drinks", ItemIDs: []int{3}},
+		},
+	}).Return(4, nil).Once()
+
+	req := httptest.NewRequest("POST", "/api/restaurants/1/menus", bytes.NewReader([]byte(requestBody)))
+	req.SetPathValue("id", "1")
+	w := httptest.NewRecorder()
+	handler.HandleAddMenu(w, req)
+	res := w.Result()
+
+	require.Equal(t, 201, res.StatusCode, "expected status code 201")
+
+	defer res.Body.Close()
+	addResponse, err := decodeResponse[dtos.AddMenuResponse](res)
+	require.NoError(t, err, "expected no error while decoding response")
+	require.Equal(t, 4, addResponse.ID)
+	mockservice.AssertExpectations(t)
+}
+
+func Test_handlers_MenuItemHandler_HandleAddMenu_InvalidSchedule(t *testing.T) {
+	mockservice := &mockservice.MenuItemService{}
+	handler := NewMenuItemHandler(mockservice)
+
+	requestBody := `{"name":"Lunch","schedules":[{"start":"noon","end":"15:00"}]}`
+	mockservice.On("CreateMenu", mock.Anything, domain.Menu{
+		RestaurantID: 1,
+		Name:         "Lunch",
+		Schedules:    []domain.MenuSchedule{{Start: -1, End: 900}},
+	}).Return(0, apperr.NewAppError(apperr.ErrInvalid, "invalid menu data", nil)).Once()
+
+	req := httptest.NewRequest("POST", "/api/restaurants/1/menus", bytes.NewReader([]byte(requestBody)))
+	req.SetPathValue("id", "1")
+	w := httptest.NewRecorder()
+	handler.HandleAddMenu(w, req)
+	res := w.Result()
+
+	require.Equal(t, 400, res.StatusCode, "expected status code 400")
+
+	defer res.Body.Close()
+	errorResponse, err := decodeJson[dtos.BaseResponse](res.Body)
+	require.NoError(t, err, "expected no error while decoding response")
+	require.Contains(t, errorResponse.Message, "invalid menu data")
+	mockservice.AssertExpectations(t)
+}
+
+func Test_handlers_MenuItemHandler_HandleUpdateMenu_Errors(t *testing.T) {
+	tests := []struct {
+		name   string
+		err    error
+		status int
+	}{
+		{"success", nil, 200},
+		{"invalid", apperr.NewAppError(apperr.ErrInvalid, "invalid menu data", nil), 400},
+		{"unauthenticated", apperr.NewAppError(apperr.ErrUnauthorized, "user not authenticated", nil), 401},
+		{"forbidden", apperr.NewAppError(apperr.ErrForbidden, "not allowed to manage menus", nil), 403},
+		{"not found", apperr.NewAppError(apperr.ErrNotFound, "menu not found", nil), 404},
+		{"internal", apperr.NewAppError(apperr.ErrInternal, "database error", nil), 500},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockservice := &mockservice.MenuItemService{}
+			handler := NewMenuItemHandler(mockservice)
+
+			mockservice.On("UpdateMenu", mock.Anything, domain.Menu{ID: 2, Name: "Dinner"}).Return(tt.err).Once()
+
+			req := httptest.NewRequest("PUT", "/api/menus/2", bytes.NewReader([]byte(`{"name":"Dinner"}`)))
+			req.SetPathValue("id", "2")
+			w := httptest.NewRecorder()
+			handler.HandleUpdateMenu(w, req)
+			res := w.Result()
+
+			require.Equal(t, tt.status, res.StatusCode, "expected status code %d", tt.status)
+			mockservice.AssertExpectations(t)
+		})
+	}
+}
+
+func Test_handlers_MenuItemHandler_HandleDeleteMenu(t *testing.T) {
+	mockservice := &mockservice.MenuItemService{}
+	handler := NewMenuItemHandler(mockservice)
+
+	mockservice.On("DeleteMenu", mock.Anything, 2).Return(nil).Once()
+
+	req := httptest.NewRequest("DELETE", "/api/menus/2", nil)
+	req.SetPathValue("id", "2")
+	w := httptest.NewRecorder()
+	handler.HandleDeleteMenu(w, req)
+	res := w.Result()
+
+	require.Equal(t, 200, res.StatusCode, "expected status code 200")
+	mockservice.AssertExpectations(t)
+}
+
+func Test_handlers_MenuItemHandler_HandleDeleteMenu_InvalidId(t *testing.T) {
+	mockservice := &mockservice.MenuItemService{}
+	handler := NewMenuItemHandler(mockservice)
+
+	req := httptest.NewRequest("DELETE", "/api/menus/abc", nil)
+	req.SetPathValue("id", "abc")
+	w := httptest.NewRecorder()
+	handler.HandleDeleteMenu(w, req)
+	res := w.Result()
+
+	require.Equal(t, 400, res.StatusCode, "expected status code 400")
+}
@@ -1,18 +1,26 @@
 package handlers
 
 import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"log"
 	"net/http"
 
 	"github.com/mohits-git/food-ordering-system/internal/ports"
+	"github.com/mohits-git/food-ordering-system/internal/utils/apperr"
 	"github.com/mohits-git/food-ordering-system/internal/utils/authctx"
 )
 
 type AuthMiddleware struct {
 	tokenProvider ports.TokenProvider
+	authService   ports.AuthenticationService
 }
 
-func NewAuthMiddleware(tokenProvider ports.TokenProvider) *AuthMiddleware {
-	return &AuthMiddleware{tokenProvider: tokenProvider}
+func NewAuthMiddleware(tokenProvider ports.TokenProvider, authService ports.AuthenticationService) *AuthMiddleware {
+	return &AuthMiddleware{tokenProvider: tokenProvider, authService: authService}
 }
 
 func (m *AuthMiddleware) Authenticated(next http.HandlerFunc) http.HandlerFunc {
@@ -30,6 +38,9 @@ func (m *AuthMiddleware) Authenticated(next http.HandlerFunc) http.HandlerFunc {
 			writeError(w, http.StatusUnauthorized, "invalid token")
 			return
 		}
+		if !m.checkNotRevoked(w, r, userClaims) {
+			return
+		}
 
 		ctx := authctx.WithUserClaims(r.Context(), &userClaims)
 		next.ServeHTTP(w, r.WithContext(ctx))
@@ -44,13 +55,129 @@ func (m *AuthMiddleware) WithToken(next http.HandlerFunc) http.HandlerFunc {
 			return
 		}
 
-		_, err := m.tokenProvider.ValidateToken(token)
+		userClaims, err := m.tokenProvider.ValidateToken(token)
 		if err != nil {
 			writeError(w, http.StatusUnauthorized, "invalid token")
 			return
 		}
+		if !m.checkNotRevoked(w, r, userClaims) {
+			return
+		}
 
 		ctx := authctx.WithToken(r.Context(), token)
 		next.ServeHTTP(w, r.WithContext(ctx))
 	})
 }
+
+// checkNotRevoked writes the error response and returns false when the token
+// was revoked by a logout.
+func (m *AuthMiddleware) checkNotRevoked(w http.ResponseWriter, r *http.Request, userClaims authctx.UserClaims) bool {
+	revoked, err := m.authService.IsTokenRevoked(r.Context(), userClaims)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "internal server error")
+		return false
+	}
+	if revoked {
+		writeError(w, http.StatusUnauthorized, "token has been revoked")
+		return false
+	}
+	return true
+}
+
+const IdempotencyKeyHeader = "Idempotency-Key"
+
+type IdempotencyMiddleware struct {
+	idempotencyService ports.IdempotencyService
+}
+
+func NewIdempotencyMiddleware(idempotencyService ports.IdempotencyService) *IdempotencyMiddleware {
+	return &IdempotencyMiddleware{idempotencyService: idempotencyService}
+}
+
+// Idempotent replays the stored response when a request is retried with the
+// same Idempotency-Key header. It has to run after Authenticated, keys are
+// kept per user. Requests without the header are passed through.
+func (m *IdempotencyMiddleware) Idempotent(next http.HandlerFunc) http.HandlerFunc {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		key := r.Header.Get(IdempotencyKeyHeader)
+		if key == "" {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, "failed to read request body")
+			return
+		}
+		r.Body = io.NopCloser(bytes.NewReader(body))
+
+		stored, err := m.idempotencyService.StartRequest(r.Context(), key, hashRequest(r, body))
+		if err != nil {
+			if apperr.IsUnauthorizedError(err) {
+				writeError(w, http.StatusUnauthorized, "unauthorized")
+			} else if apperr.IsConflictError(err) {
+				appErr, _ := err.(*apperr.AppError)
+				writeError(w, http.StatusConflict, appErr.Message)
+			} else if apperr.IsInvalidError(err) {
+				appErr, _ := err.(*apperr.AppError)
+				writeError(w, http.StatusBadRequest, appErr.Message)
+			} else {
+				writeError(w, http.StatusInternalServerError, "internal server error")
+			}
+			return
+		}
+
+		if stored.IsCompleted() {
+			w.Header().Set("Content-Type", "application/json")
+			w.Header().Set("Idempotent-Replayed", "true")
+			w.WriteHeader(stored.StatusCode)
+			w.Write(stored.ResponseBody)
+			return
+		}
+
+		recorder := &responseRecorder{ResponseWriter: w, statusCode: http.StatusOK}
+		next.ServeHTTP(recorder, r)
+
+		// the client may have gone away, the response is kept for its retry
+		ctx := context.WithoutCancel(r.Context())
+		if recorder.statusCode >= http.StatusInternalServerError {
+			err = m.idempotencyService.ReleaseRequest(ctx, stored.ID)
+		} else {
+			err = m.idempotencyService.CompleteRequest(ctx, stored.ID, recorder.statusCode, recorder.body.Bytes())
+		}
+		if err != nil {
+			log.Printf("failed to save response for idempotency key %d: %v\n", stored.ID, err)
+		}
+	})
+}
+
+// hashRequest identifies a request by its method, path and body.
+func hashRequest(r *http.Request, body []byte) string {
+	hash := sha256.New()
+	hash.Write([]byte(r.Method + " " + r.URL.Path + "\n"))
+	hash.Write(body)
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
+// responseRecorder copies what a handler writes so it can be stored.
+type responseRecorder struct {
+	http.ResponseWriter
+	statusCode  int
+	wroteHeader bool
+	body        bytes.Buffer
+}
+
+func (rec *responseRecorder) WriteHeader(statusCode int) {
+	if !rec.wroteHeader {
+		rec.statusCode = statusCode
+		rec.wroteHeader = true
+	}
+	rec.ResponseWriter.WriteHeader(statusCode)
+}
+
+func (rec *responseRecorder) Write(b []byte) (int, error) {
+	rec.wroteHeader = true
+	rec.body.Write(b)
+	return rec.ResponseWriter.Write(b)
+}
@@ -1,33 +1,45 @@
 package handlers
 
 import (
+	"errors"
+	"io"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 
+	"github.com/mohits-git/food-ordering-system/internal/adapters/http/dtos"
+	"github.com/mohits-git/food-ordering-system/internal/domain"
+	"github.com/mohits-git/food-ordering-system/internal/utils/apperr"
 	"github.com/mohits-git/food-ordering-system/internal/utils/authctx"
+	mockservice "github.com/mohits-git/food-ordering-system/tests/mock_service"
 	mocktokenprovider "github.com/mohits-git/food-ordering-system/tests/mock_token_provider"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 )
 
 func Test_handlers_NewAuthMiddleware(t *testing.T) {
 	mockTokenProvider := &mocktokenprovider.TokenProvider{}
+	mockAuthService := &mockservice.AuthenticationService{}
 
-	middleware := NewAuthMiddleware(mockTokenProvider)
+	middleware := NewAuthMiddleware(mockTokenProvider, mockAuthService)
 
 	require.NotNil(t, middleware, "NewAuthMiddleware returned nil")
 	require.Equal(t, mockTokenProvider, middleware.tokenProvider, "NewAuthMiddleware did not set the tokenProvider correctly")
+	require.Equal(t, mockAuthService, middleware.authService, "NewAuthMiddleware did not set the authService correctly")
 }
 
 func Test_handlers_Authenticated(t *testing.T) {
 	mockTokenProvider := &mocktokenprovider.TokenProvider{}
-	middleware := NewAuthMiddleware(mockTokenProvider)
+	mockAuthService := &mockservice.AuthenticationService{}
+	middleware := NewAuthMiddleware(mockTokenProvider, mockAuthService)
 
 	mockTokenProvider.On("ValidateToken", "valid-token").Return(authctx.UserClaims{
 		UserID: 1,
 		Role:   "user",
 	}, nil)
+	mockAuthService.On("IsTokenRevoked", mock.Anything, mock.Anything).Return(false, nil)
 
 	handler := middleware.Authenticated(func(w http.ResponseWriter, r *http.Request) {
 		userClaims, ok := authctx.UserClaimsFromCtx(r.Context())
@@ -45,7 +57,8 @@ func Test_handlers_Authenticated(t *testing.T) {
 
 func Test_handlers_Authenticated_MissingToken(t *testing.T) {
 	mockTokenProvider := &mocktokenprovider.TokenProvider{}
-	middleware := NewAuthMiddleware(mockTokenProvider)
+	mockAuthService := &mockservice.AuthenticationService{}
+	middleware := NewAuthMiddleware(mockTokenProvider, mockAuthService)
 
 	handler := middleware.Authenticated(func(w http.ResponseWriter, r *http.Request) {
 		w.WriteHeader(http.StatusOK)
@@ -59,7 +72,8 @@ func Test_handlers_Authenticated_MissingToken(t *testing.T) {
 
 func Test_handlers_Authenticated_InvalidToken(t *testing.T) {
 	mockTokenProvider := &mocktokenprovider.TokenProvider{}
-	middleware := NewAuthMiddleware(mockTokenProvider)
+	mockAuthService := &mockservice.AuthenticationService{}
+	middleware := NewAuthMiddleware(mockTokenProvider, mockAuthService)
 
 	mockTokenProvider.On("ValidateToken", "invalid-token").Return(authctx.UserClaims{}, assert.AnError)
 
@@ -76,12 +90,14 @@ func Test_handlers_Authenticated_InvalidToken(t *testing.T) {
 
 func Test_handlers_WithToken(t *testing.T) {
 	mockTokenProvider := &mocktokenprovider.TokenProvider{}
-	middleware := NewAuthMiddleware(mockTokenProvider)
+	mockAuthService := &mockservice.AuthenticationService{}
+	middleware := NewAuthMiddleware(mockTokenProvider, mockAuthService)
 
 	mockTokenProvider.On("ValidateToken", "valid-token").Return(authctx.UserClaims{
 		UserID: 1,
 		Role:   "user",
 	}, nil)
+	mockAuthService.On("IsTokenRevoked", mock.Anything, mock.Anything).Return(false, nil)
 
 	handler := middleware.WithToken(func(w http.ResponseWriter, r *http.Request) {
 		token, ok := authctx.TokenFromCtx(r.Context())
@@ -99,7 +115,8 @@ func Test_handlers_WithToken(t *testing.T) {
 
 func Test_handlers_WithToken_MissingToken(t *testing.T) {
 	mockTokenProvider := &mocktokenprovider.TokenProvider{}
-	middleware := NewAuthMiddleware(mockTokenProvider)
+	mockAuthService := &mockservice.AuthenticationService{}
+	middleware := NewAuthMiddleware(mockTokenProvider, mockAuthService)
 
 	handler := middleware.WithToken(func(w http.ResponseWriter, r *http.Request) {
 		w.WriteHeader(http.StatusOK)
@@ -113,7 +130,8 @@ func Test_handlers_WithToken_MissingToken(t *testing.T) {
 
 func Test_handlers_WithToken_InvalidToken(t *testing.T) {
 	mockTokenProvider := &mocktokenprovider.TokenProvider{}
-	middleware := NewAuthMiddleware(mockTokenProvider)
+	mockAuthService := &mockservice.AuthenticationService{}
+	middleware := NewAuthMiddleware(mockTokenProvider, mockAuthService)
 
 	mockTokenProvider.On("ValidateToken", "invalid-token").Return(authctx.UserClaims{}, assert.AnError)
 
@@ -127,3 +145,194 @@ func Test_handlers_WithToken_InvalidToken(t *testing.T) {
 	handler.ServeHTTP(w, req)
 	require.Equal(t, http.StatusUnauthorized, w.Result().StatusCode, "Expected status Unauthorized for invalid token")
 }
+
+func Test_handlers_Authenticated_RevokedToken(t *testing.T) {
+	mockTokenProvider := &mocktokenprovider.TokenProvider{}
+	mockAuthService := &mockservice.AuthenticationService{}
+	middleware := NewAuthMiddleware(mockTokenProvider, mockAuthService)
+
+	claims := authctx.UserClaims{UserID: 1, Role: "customer", TokenID: "jti-1"}
+	mockTokenProvider.On("ValidateToken", "revoked-token").Return(claims, nil)
+	mockAuthService.On("IsTokenRevoked", mock.Anything, claims).Return(true, nil)
+
+	for _, wrap := range []func(http.HandlerFunc) http.HandlerFunc{middleware.Authenticated, middleware.WithToken} {
+		handler := wrap(func(w http.ResponseWriter, r *http.Request) {
+			t.Fatal("expected the handler not to run for a revoked token")
+		})
+
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		req.Header.Set("Authorization", "Bearer revoked-token")
+		handler.ServeHTTP(w, req)
+		require.Equal(t, http.StatusUnauthorized, w.Result().StatusCode, "Expected status Unauthorized for a revoked token")
+	}
+}
+
+func Test_handlers_Authenticated_RevocationCheckFails(t *testing.T) {
+	mockTokenProvider := &mocktokenprovider.TokenProvider{}
+	mockAuthService := &mockservice.AuthenticationService{}
+	middleware := NewAuthMiddleware(mockTokenProvider, mockAuthService)
+
+	mockTokenProvider.On("ValidateToken", "valid-token").Return(authctx.UserClaims{UserID: 1, TokenID: "jti-1"}, nil)
+	mockAuthService.On("IsTokenRevoked", mock.Anything, mock.Anything).Return(false, assert.AnError)
+
+	handler := middleware.Authenticated(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set("Authorization", "Bearer valid-token")
+	handler.ServeHTTP(w, req)
+	require.Equal(t, http.StatusInternalServerError, w.Result().StatusCode)
+}
+
+func Test_handlers_Idempotent_WithoutKey(t *testing.T) {
+	mockService := &mockservice.IdempotencyService{}
+	middleware := NewIdempotencyMiddleware(mockService)
+
+	calls := 0
+	handler := middleware.Idempotent(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.WriteHeader(http.StatusCreated)
+	})
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPost, "/api/orders", strings.NewReader(`{"restaurant_id":1}`))
+	handler.ServeHTTP(w, req)
+	require.Equal(t, http.StatusCreated, w.Result().StatusCode)
+	require.Equal(t, 1, calls)
+	mockService.AssertNotCalled(t, "StartRequest", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func Test_handlers_Idempotent_StoresResponse(t *testing.T) {
+	mockService := &mockservice.IdempotencyService{}
+	middleware := NewIdempotencyMiddleware(mockService)
+
+	body := `{"restaurant_id":1}`
+	mockService.On("StartRequest", mock.Anything, "key-1", hashRequest(httptest.NewRequest(http.MethodPost, "/api/orders", nil), []byte(body))).
+		Return(domain.IdempotencyKey{ID: 3, UserID: 1, Key: "key-1"}, nil)
+	mockService.On("CompleteRequest", mock.Anything, 3, http.StatusCreated, []byte(`{"id":7}`)).Return(nil)
+
+	handler := middleware.Idempotent(func(w http.ResponseWriter, r *http.Request) {
+		received, err := io.ReadAll(r.Body)
+		assert.NoError(t, err)
+		assert.Equal(t, body, string(received), "expected the handler to still get the request body")
+		w.WriteHeader(http.StatusCreated)
+		w.Write([]byte(`{"id":7}`))
+	})
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPost, "/api/orders", strings.NewReader(body))
+	req.Header.Set(IdempotencyKeyHeader, "key-1")
+	handler.ServeHTTP(w, req)
+	require.Equal(t, http.StatusCreated, w.Result().StatusCode)
+	require.Equal(t, `{"id":7}`, w.Body.String())
+	mockService.AssertExpectations(t)
+}
+
+func Test_handlers_Idempotent_ReplaysResponse(t *testing.T) {
+	mockService := &mockservice.IdempotencyService{}
+	middleware := NewIdempotencyMiddleware(mockService)
+
+	mockService.On("StartRequest", mock.Anything, "key-1", mock.Anything).
+		Return(domain.IdempotencyKey{ID: 3, UserID: 1, Key: "key-1", StatusCode: http.StatusCreated, ResponseBody: []byte(`{"id":7}`)}, nil)
+
+	handler := middleware.Idempotent(func(w http.ResponseWriter, r *http.Request) {
+		t.Fatal("expected the handler not to run on a replay")
+	})
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPost, "/api/orders", strings.NewReader(`{"restaurant_id":1}`))
+	req.Header.Set(IdempotencyKeyHeader, "key-1")
+	handler.ServeHTTP(w, req)
+	require.Equal(t, http.StatusCreated, w.Result().StatusCode)
+	require.Equal(t, `{"id":7}`, w.Body.String())
+	require.Equal(t, "true", w.Header().Get("Idempotent-Replayed"))
+}
+
+func Test_handlers_Idempotent_ReleasesKeyOnServerError(t *testing.T) {
+	mockService := &mockservice.IdempotencyService{}
+	middleware := NewIdempotencyMiddleware(mockService)
+
+	mockService.On("StartRequest", mock.Anything, "key-1", mock.Anything).
+		Return(domain.IdempotencyKey{ID: 3, UserID: 1, Key: "key-1"}, nil)
+	mockService.On("ReleaseRequest", mock.Anything, 3).Return(nil)
+
+	handler := middleware.Idempotent(func(w http.ResponseWriter, r *http.Request) {
+		writeError(w, http.StatusInternalServerError, "internal server error")
+	})
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPost, "/api/orders", strings.NewReader(`{}`))
+	req.Header.Set(IdempotencyKeyHeader, "key-1")
+	handler.ServeHTTP(w, req)
+	require.Equal(t, http.StatusInternalServerError, w.Result().StatusCode)
+	mockService.AssertExpectations(t)
+	mockService.AssertNotCalled(t, "CompleteRequest", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func Test_handlers_Idempotent_Errors(t *testing.T) {
+	tests := []struct {
+		name       string
+		err        error
+		wantStatus int
+		wantMsg    string
+	}{
+		{
+			name:       "Key Reused With Different Body",
+			err:        apperr.NewAppError(apperr.ErrConflict, "idempotency key was already used for a different request", nil),
+			wantStatus: http.StatusConflict,
+			wantMsg:    "idempotency key was already used for a different request",
+		},
+		{
+			name:       "Invalid Key",
+			err:        apperr.NewAppError(apperr.ErrInvalid, "invalid idempotency key", nil),
+			wantStatus: http.StatusBadRequest,
+			wantMsg:    "invalid idempotency key",
+		},
+		{
+			name:       "Unauthorized",
+			err:        apperr.NewAppError(apperr.ErrUnauthorized, "user not authenticated", nil),
+			wantStatus: http.StatusUnauthorized,
+			wantMsg:    "unauthorized",
+		},
+		{
+			name:       "Internal Error",
+			err:        errors.New("db down"),
+			wantStatus: http.StatusInternalServerError,
+			wantMsg:    "internal server error",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := &mockservice.IdempotencyService{}
+			middleware := NewIdempotencyMiddleware(mockService)
+			mockService.On("StartRequest", mock.Anything, "key-1", mock.Anything).Return(domain.IdempotencyKey{}, tt.err)
+
+			handler := middleware.Idempotent(func(w http.ResponseWriter, r *http.Request) {
+				t.Fatal("expected the handler not to run")
+			})
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodPost, "/api/invoices/1/pay", strings.NewReader(`{}`))
+			req.Header.Set(IdempotencyKeyHeader, "key-1")
+			handler.ServeHTTP(w, req)
+			require.Equal(t, tt.wantStatus, w.Result().StatusCode)
+
+			response, err := decodeJson[dtos.BaseResponse](w.Result().Body)
+			require.NoError(t, err)
+			require.Equal(t, tt.wantMsg, response.Message)
+		})
+	}
+}
+
+func Test_handlers_hashRequest(t *testing.T) {
+	orders := httptest.NewRequest(http.MethodPost, "/api/orders", nil)
+	invoices := httptest.NewRequest(http.MethodPost, "/api/invoices/1/pay", nil)
+
+	require.Equal(t, hashRequest(orders, []byte("a")), hashRequest(orders, []byte("a")))
+	require.NotEqual(t, hashRequest(orders, []byte("a")), hashRequest(orders, []byte("b")))
+	require.NotEqual(t, hashRequest(orders, []byte("a")), hashRequest(invoices, []byte("a")))
+}
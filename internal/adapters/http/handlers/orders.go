@@ -48,6 +48,8 @@ func (h *OrdersHandler) HandleCreateOrder(w http.ResponseWriter, r *http.Request
 			writeError(w, http.StatusForbidden, "forbidden")
 		} else if apperr.IsInvalidError(err) {
 			writeError(w, http.StatusBadRequest, err.Error())
+		} else if apperr.IsConflictError(err) {
+			writeError(w, http.StatusConflict, err.Error())
 		} else {
 			writeError(w, http.StatusInternalServerError, "internal server error")
 		}
@@ -78,20 +80,7 @@ func (h *OrdersHandler) HandleGetOrderById(w http.ResponseWriter, r *http.Reques
 		return
 	}
 
-	// building response
-	orderItemsDTO := []dtos.OrderItemsDTO{}
-	for _, item := range order.OrderItems {
-		orderItemsDTO = append(orderItemsDTO, dtos.OrderItemsDTO{
-			MenuItemID: item.MenuItemID,
-			Quantity:   item.Quantity,
-		})
-	}
-	resp := dtos.GetOrderByIdResponse{
-		ID:           order.ID,
-		CustomerID:   order.CustomerID,
-		RestaurantID: order.RestaurantID,
-		OrderItems:   orderItemsDTO,
-	}
+	resp := dtos.NewGetOrderByIdResponse(order)
 	writeResponse(w, http.StatusOK, "order fetched successfully", resp)
 }
 
@@ -108,12 +97,205 @@ func (h *OrdersHandler) HandleAddOrderItem(w http.ResponseWriter, r *http.Reques
 		return
 	}
 
-	orderItem := domain.OrderItem{
-		MenuItemID: addItemRequest.MenuItemID,
-		Quantity:   addItemRequest.Quantity,
+	err = h.orderService.AddOrderItem(r.Context(), orderID, addItemRequest.ToDomain())
+	if err != nil {
+		if apperr.IsNotFoundError(err) {
+			writeError(w, http.StatusNotFound, "order not found")
+		} else if apperr.IsUnauthorizedError(err) {
+			writeError(w, http.StatusUnauthorized, "unauthorized")
+		} else if apperr.IsForbiddenError(err) {
+			writeError(w, http.StatusForbidden, "forbidden")
+		} else if apperr.IsInvalidError(err) {
+			writeError(w, http.StatusBadRequest, err.Error())
+		} else if apperr.IsConflictError(err) {
+			writeError(w, http.StatusConflict, err.Error())
+		} else {
+			writeError(w, http.StatusInternalServerError, "internal server error")
+		}
+		return
+	}
+
+	writeResponse(w, http.StatusOK, "item added to order successfully", dtos.AddOrderItemResponse{ID: orderID})
+}
+
+func (h *OrdersHandler) HandleUpdateOrderItem(w http.ResponseWriter, r *http.Request) {
+	orderID := getIdFromPath(r, "id")
+	if orderID <= 0 {
+		writeError(w, http.StatusBadRequest, "invalid order id")
+		return
+	}
+	menuItemID := getIdFromPath(r, "menuItemId")
+	if menuItemID <= 0 {
+		writeError(w, http.StatusBadRequest, "invalid menu item id")
+		return
+	}
+
+	updateItemRequest, err := decodeRequest[dtos.UpdateOrderItemRequest](r)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request payload")
+		return
+	}
+
+	err = h.orderService.UpdateOrderItemQuantity(r.Context(), orderID, menuItemID, updateItemRequest.Quantity)
+	if err != nil {
+		h.writeOrderItemError(w, err)
+		return
+	}
+
+	writeResponse(w, http.StatusOK, "order item updated successfully", dtos.UpdateOrderItemResponse{ID: orderID, MenuItemID: menuItemID})
+}
+
+func (h *OrdersHandler) HandleRemoveOrderItem(w http.ResponseWriter, r *http.Request) {
+	orderID := getIdFromPath(r, "id")
+	if orderID <= 0 {
+		writeError(w, http.StatusBadRequest, "invalid order id")
+		return
+	}
+	menuItemID := getIdFromPath(r, "menuItemId")
+	if menuItemID <= 0 {
+		writeError(w, http.StatusBadRequest, "invalid menu item id")
+		return
+	}
+
+	err := h.orderService.RemoveOrderItem(r.Context(), orderID, menuItemID)
+	if err != nil {
+		h.writeOrderItemError(w, err)
+		return
+	}
+
+	writeResponse(w, http.StatusOK, "order item removed successfully", dtos.UpdateOrderItemResponse{ID: orderID, MenuItemID: menuItemID})
+}
+
+func (h *OrdersHandler) HandleUpdateOrderLine(w http.ResponseWriter, r *http.Request) {
+	orderID := getIdFromPath(r, "id")
+	if orderID <= 0 {
+		writeError(w, http.StatusBadRequest, "invalid order id")
+		return
+	}
+	lineID := getIdFromPath(r, "lineId")
+	if lineID <= 0 {
+		writeError(w, http.StatusBadRequest, "invalid order line id")
+		return
+	}
+
+	updateItemRequest, err := decodeRequest[dtos.UpdateOrderItemRequest](r)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request payload")
+		return
+	}
+
+	err = h.orderService.UpdateOrderLineQuantity(r.Context(), orderID, lineID, updateItemRequest.Quantity)
+	if err != nil {
+		h.writeOrderItemError(w, err)
+		return
+	}
+
+	writeResponse(w, http.StatusOK, "order line updated successfully", dtos.UpdateOrderLineResponse{ID: orderID, LineID: lineID})
+}
+
+func (h *OrdersHandler) HandleRemoveOrderLine(w http.ResponseWriter, r *http.Request) {
+	orderID := getIdFromPath(r, "id")
+	if orderID <= 0 {
+		writeError(w, http.StatusBadRequest, "invalid order id")
+		return
+	}
+	lineID := getIdFromPath(r, "lineId")
+	if lineID <= 0 {
+		writeError(w, http.StatusBadRequest, "invalid order line id")
+		return
+	}
+
+	err := h.orderService.RemoveOrderLine(r.Context(), orderID, lineID)
+	if err != nil {
+		h.writeOrderItemError(w, err)
+		return
+	}
+
+	writeResponse(w, http.StatusOK, "order line removed successfully", dtos.UpdateOrderLineResponse{ID: orderID, LineID: lineID})
+}
+
+func (h *OrdersHandler) writeOrderItemError(w http.ResponseWriter, err error) {
+	if apperr.IsNotFoundError(err) {
+		writeError(w, http.StatusNotFound, err.Error())
+	} else if apperr.IsUnauthorizedError(err) {
+		writeError(w, http.StatusUnauthorized, "unauthorized")
+	} else if apperr.IsForbiddenError(err) {
+		writeError(w, http.StatusForbidden, "forbidden")
+	} else if apperr.IsConflictError(err) {
+		writeError(w, http.StatusConflict, err.Error())
+	} else if apperr.IsInvalidError(err) {
+		writeError(w, http.StatusBadRequest, err.Error())
+	} else {
+		writeError(w, http.StatusInternalServerError, "internal server error")
+	}
+}
+
+func (h *OrdersHandler) HandleGetRestaurantOrders(w http.ResponseWriter, r *http.Request) {
+	restaurantId := getIdFromPath(r, "id")
+	if restaurantId <= 0 {
+		writeError(w, http.StatusBadRequest, "invalid restaurant id")
+		return
+	}
+
+	statuses := []domain.OrderStatus{}
+	for _, status := range getListFromQuery(r, "status") {
+		statuses = append(statuses, domain.OrderStatus(status))
 	}
 
-	err = h.orderService.AddOrderItem(r.Context(), orderID, orderItem)
+	orders, err := h.orderService.GetRestaurantOrders(r.Context(), restaurantId, statuses)
+	if err != nil {
+		if apperr.IsNotFoundError(err) {
+			writeError(w, http.StatusNotFound, "restaurant not found")
+		} else if apperr.IsUnauthorizedError(err) {
+			writeError(w, http.StatusUnauthorized, "unauthorized")
+		} else if apperr.IsForbiddenError(err) {
+			writeError(w, http.StatusForbidden, "forbidden")
+		} else if apperr.IsInvalidError(err) {
+			writeError(w, http.StatusBadRequest, err.Error())
+		} else {
+			writeError(w, http.StatusInternalServerError, "internal server error")
+		}
+		return
+	}
+
+	resp := dtos.GetRestaurantOrdersResponse{
+		RestaurantID: restaurantId,
+		Orders:       []dtos.GetOrderByIdResponse{},
+	}
+	for _, order := range orders {
+		resp.Orders = append(resp.Orders, dtos.NewGetOrderByIdResponse(order))
+	}
+	writeResponse(w, http.StatusOK, "restaurant orders fetched successfully", resp)
+}
+
+func (h *OrdersHandler) HandleAcceptOrder(w http.ResponseWriter, r *http.Request) {
+	h.handleTransitionOrder(w, r, domain.OrderAccepted, "order accepted successfully")
+}
+
+func (h *OrdersHandler) HandleRejectOrder(w http.ResponseWriter, r *http.Request) {
+	h.handleTransitionOrder(w, r, domain.OrderCancelled, "order rejected successfully")
+}
+
+func (h *OrdersHandler) HandleMarkOrderReady(w http.ResponseWriter, r *http.Request) {
+	h.handleTransitionOrder(w, r, domain.OrderReady, "order marked as ready successfully")
+}
+
+func (h *OrdersHandler) HandleMarkOrderPreparing(w http.ResponseWriter, r *http.Request) {
+	h.handleTransitionOrder(w, r, domain.OrderPreparing, "order marked as preparing successfully")
+}
+
+func (h *OrdersHandler) HandleMarkOrderDelivered(w http.ResponseWriter, r *http.Request) {
+	h.handleTransitionOrder(w, r, domain.OrderDelivered, "order marked as delivered successfully")
+}
+
+func (h *OrdersHandler) HandleCancelOrder(w http.ResponseWriter, r *http.Request) {
+	orderID := getIdFromPath(r, "id")
+	if orderID <= 0 {
+		writeError(w, http.StatusBadRequest, "invalid order id")
+		return
+	}
+
+	err := h.orderService.CancelOrder(r.Context(), orderID)
 	if err != nil {
 		if apperr.IsNotFoundError(err) {
 			writeError(w, http.StatusNotFound, "order not found")
@@ -129,5 +311,82 @@ func (h *OrdersHandler) HandleAddOrderItem(w http.ResponseWriter, r *http.Reques
 		return
 	}
 
-	writeResponse(w, http.StatusOK, "item added to order successfully", dtos.AddOrderItemResponse{ID: orderID})
+	writeResponse(w, http.StatusOK, "order cancelled successfully", dtos.UpdateOrderStatusResponse{ID: orderID, Status: string(domain.OrderCancelled)})
+}
+
+func (h *OrdersHandler) handleTransitionOrder(w http.ResponseWriter, r *http.Request, status domain.OrderStatus, msg string) {
+	orderID := getIdFromPath(r, "id")
+	if orderID <= 0 {
+		writeError(w, http.StatusBadRequest, "invalid order id")
+		return
+	}
+
+	err := h.orderService.TransitionOrder(r.Context(), orderID, status)
+	if err != nil {
+		if apperr.IsNotFoundError(err) {
+			writeError(w, http.StatusNotFound, "order not found")
+		} else if apperr.IsUnauthorizedError(err) {
+			writeError(w, http.StatusUnauthorized, "unauthorized")
+		} else if apperr.IsForbiddenError(err) {
+			writeError(w, http.StatusForbidden, "forbidden")
+		} else if apperr.IsInvalidError(err) {
+			writeError(w, http.StatusBadRequest, err.Error())
+		} else {
+			writeError(w, http.StatusInternalServerError, "internal server error")
+		}
+		return
+	}
+
+	writeResponse(w, http.StatusOK, msg, dtos.UpdateOrderStatusResponse{ID: orderID, Status: string(status)})
+}
+
+func (h *OrdersHandler) HandleGetCustomerOrders(w http.ResponseWriter, r *http.Request) {
+	filter := domain.OrderFilter{}
+	var err error
+	if filter.RestaurantID, err = getIntFromQuery(r, "restaurant_id"); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid restaurant id")
+		return
+	}
+	if filter.Cursor, err = getIntFromQuery(r, "cursor"); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid cursor")
+		return
+	}
+	if filter.Limit, err = getIntFromQuery(r, "limit"); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid limit")
+		return
+	}
+	if filter.From, err = getTimeFromQuery(r, "from", false); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid from date")
+		return
+	}
+	if filter.To, err = getTimeFromQuery(r, "to", true); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid to date")
+		return
+	}
+	for _, status := range getListFromQuery(r, "status") {
+		filter.Statuses = append(filter.Statuses, domain.OrderStatus(status))
+	}
+
+	orders, nextCursor, err := h.orderService.GetCustomerOrders(r.Context(), filter)
+	if err != nil {
+		if apperr.IsUnauthorizedError(err) {
+			writeError(w, http.StatusUnauthorized, "unauthorized")
+		} else if apperr.IsForbiddenError(err) {
+			writeError(w, http.StatusForbidden, "forbidden")
+		} else if apperr.IsInvalidError(err) {
+			writeError(w, http.StatusBadRequest, err.Error())
+		} else {
+			writeError(w, http.StatusInternalServerError, "internal server error")
+		}
+		return
+	}
+
+	resp := dtos.GetCustomerOrdersResponse{
+		Orders:     []dtos.GetOrderByIdResponse{},
+		NextCursor: nextCursor,
+	}
+	for _, order := range orders {
+		resp.Orders = append(resp.Orders, dtos.NewGetOrderByIdResponse(order))
+	}
+	writeResponse(w, http.StatusOK, "orders fetched successfully", resp)
 }
@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/mohits-git/food-ordering-system/internal/adapters/http/dtos"
 	"github.com/mohits-git/food-ordering-system/internal/domain"
@@ -271,6 +272,36 @@ func Test_handlers_OrdersHandler_HandleCreateOrder_BadRequestFromService(t *test
 	require.Contains(t, errorResponse.Message, "invalid order data", "expected error message to contain 'invalid order data'")
 }
 
+func Test_handlers_OrdersHandler_HandleCreateOrder_OutOfStock(t *testing.T) {
+	mockOrderService := &mockservice.OrderService{}
+	handler := NewOrdersHandler(mockOrderService)
+
+	mockOrderService.On("CreateOrder", mock.Anything, domain.Order{
+		CustomerID:   1,
+		RestaurantID: 1,
+		OrderItems:   []domain.OrderItem{{MenuItemID: 1, Quantity: 5}},
+	}).Return(0, apperr.NewAppError(apperr.ErrConflict, "not enough stock of Cake", nil)).Once()
+	buf := bytes.NewBuffer(nil)
+	err := encodeJson(buf, dtos.CreateOrderRequest{
+		RestaurantID: 1,
+		OrderItems:   []dtos.OrderItemsDTO{{MenuItemID: 1, Quantity: 5}},
+	})
+	require.NoError(t, err, "expected no error while encoding request")
+
+	req := httptest.NewRequest("POST", "/api/orders", buf)
+	req = req.WithContext(authctx.WithUserClaims(req.Context(), &authctx.UserClaims{UserID: 1, Role: "customer"}))
+	w := httptest.NewRecorder()
+	handler.HandleCreateOrder(w, req)
+
+	res := w.Result()
+	require.Equal(t, 409, res.StatusCode, "expected status code 409")
+
+	defer res.Body.Close()
+	errorResponse, err := decodeJson[dtos.BaseResponse](res.Body)
+	require.NoError(t, err, "expected no error while decoding response")
+	require.Equal(t, "not enough stock of Cake", errorResponse.Message)
+}
+
 func Test_handlers_OrdersHandler_HandleCreateOrder_UnauthorizedFromService(t *testing.T) {
 	mockOrderService := &mockservice.OrderService{}
 	handler := NewOrdersHandler(mockOrderService)
@@ -503,6 +534,210 @@ func Test_handlers_OrdersHandler_HandleAddOrderItem(t *testing.T) {
 	require.Equal(t, 1, addItemResp.ID, "expected order ID to be 1 as it's not set in response")
 }
 
+func Test_handlers_OrdersHandler_HandleAddOrderItem_WithOptions(t *testing.T) {
+	mockOrderService := &mockservice.OrderService{}
+	handler := NewOrdersHandler(mockOrderService)
+
+	mockOrderService.On("AddOrderItem", mock.Anything, 1, domain.OrderItem{
+		MenuItemID: 1,
+		Quantity:   1,
+		Options:    []domain.OrderItemOption{{OptionID: 11}, {OptionID: 20}},
+	}).Return(nil).Once()
+	buf := bytes.NewBuffer(nil)
+	err := encodeJson(buf, dtos.AddOrderItemRequest{
+		MenuItemID: 1,
+		Quantity:   1,
+		OptionIDs:  []int{11, 20},
+	})
+	require.NoError(t, err, "expected no error while encoding request")
+	req := httptest.NewRequest("POST", "/api/orders/1/items", buf)
+	req.SetPathValue("id", "1")
+
+	w := httptest.NewRecorder()
+	handler.HandleAddOrderItem(w, req)
+	res := w.Result()
+	require.Equal(t, 200, res.StatusCode, "expected status code 200")
+	mockOrderService.AssertExpectations(t)
+}
+
+func Test_handlers_OrdersHandler_HandleAddOrderItem_InvalidOptions(t *testing.T) {
+	mockOrderService := &mockservice.OrderService{}
+	handler := NewOrdersHandler(mockOrderService)
+
+	mockOrderService.On("AddOrderItem", mock.Anything, 1, mock.Anything).
+		Return(apperr.NewAppError(apperr.ErrInvalid, "invalid options for menu item", nil)).Once()
+	buf := bytes.NewBuffer(nil)
+	err := encodeJson(buf, dtos.AddOrderItemRequest{MenuItemID: 1, Quantity: 1, OptionIDs: []int{99}})
+	require.NoError(t, err, "expected no error while encoding request")
+	req := httptest.NewRequest("POST", "/api/orders/1/items", buf)
+	req.SetPathValue("id", "1")
+
+	w := httptest.NewRecorder()
+	handler.HandleAddOrderItem(w, req)
+	res := w.Result()
+	require.Equal(t, 400, res.StatusCode, "expected status code 400")
+
+	defer res.Body.Close()
+	errorResponse, err := decodeJson[dtos.BaseResponse](res.Body)
+	require.NoError(t, err, "expected no error while decoding response")
+	require.Contains(t, errorResponse.Message, "invalid options for menu item")
+}
+
+func Test_handlers_OrdersHandler_HandleUpdateOrderItem(t *testing.T) {
+	mockOrderService := &mockservice.OrderService{}
+	handler := NewOrdersHandler(mockOrderService)
+
+	mockOrderService.On("UpdateOrderItemQuantity", mock.Anything, 1, 3, 5).Return(nil).Once()
+	buf := bytes.NewBuffer(nil)
+	err := encodeJson(buf, dtos.UpdateOrderItemRequest{Quantity: 5})
+	require.NoError(t, err, "expected no error while encoding request")
+	req := httptest.NewRequest("PATCH", "/api/orders/1/items/3", buf)
+	req.SetPathValue("id", "1")
+	req.SetPathValue("menuItemId", "3")
+
+	w := httptest.NewRecorder()
+	handler.HandleUpdateOrderItem(w, req)
+	res := w.Result()
+	require.Equal(t, 200, res.StatusCode, "expected status code 200")
+
+	defer res.Body.Close()
+	updateItemResp, err := decodeResponse[dtos.UpdateOrderItemResponse](res)
+	require.NoError(t, err, "expected no error while decoding response")
+	require.Equal(t, 1, updateItemResp.ID, "expected order ID to be 1")
+	require.Equal(t, 3, updateItemResp.MenuItemID, "expected menu item ID to be 3")
+	mockOrderService.AssertExpectations(t)
+}
+
+func Test_handlers_OrdersHandler_HandleUpdateOrderItem_InvalidMenuItemId(t *testing.T) {
+	mockOrderService := &mockservice.OrderService{}
+	handler := NewOrdersHandler(mockOrderService)
+
+	buf := bytes.NewBuffer(nil)
+	err := encodeJson(buf, dtos.UpdateOrderItemRequest{Quantity: 5})
+	require.NoError(t, err, "expected no error while encoding request")
+	req := httptest.NewRequest("PATCH", "/api/orders/1/items/abc", buf)
+	req.SetPathValue("id", "1")
+	req.SetPathValue("menuItemId", "abc")
+
+	w := httptest.NewRecorder()
+	handler.HandleUpdateOrderItem(w, req)
+	res := w.Result()
+	require.Equal(t, 400, res.StatusCode, "expected status code 400")
+	mockOrderService.AssertNotCalled(t, "UpdateOrderItemQuantity", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func Test_handlers_OrdersHandler_HandleRemoveOrderItem(t *testing.T) {
+	mockOrderService := &mockservice.OrderService{}
+	handler := NewOrdersHandler(mockOrderService)
+
+	mockOrderService.On("RemoveOrderItem", mock.Anything, 1, 3).Return(nil).Once()
+	req := httptest.NewRequest("DELETE", "/api/orders/1/items/3", nil)
+	req.SetPathValue("id", "1")
+	req.SetPathValue("menuItemId", "3")
+
+	w := httptest.NewRecorder()
+	handler.HandleRemoveOrderItem(w, req)
+	res := w.Result()
+	require.Equal(t, 200, res.StatusCode, "expected status code 200")
+	mockOrderService.AssertExpectations(t)
+}
+
+func Test_handlers_OrdersHandler_HandleRemoveOrderItem_LastItem(t *testing.T) {
+	mockOrderService := &mockservice.OrderService{}
+	handler := NewOrdersHandler(mockOrderService)
+
+	mockOrderService.On("RemoveOrderItem", mock.Anything, 1, 3).
+		Return(apperr.NewAppError(apperr.ErrInvalid, "cannot remove the last item of the order, cancel the order instead", nil)).Once()
+	req := httptest.NewRequest("DELETE", "/api/orders/1/items/3", nil)
+	req.SetPathValue("id", "1")
+	req.SetPathValue("menuItemId", "3")
+
+	w := httptest.NewRecorder()
+	handler.HandleRemoveOrderItem(w, req)
+	res := w.Result()
+	require.Equal(t, 400, res.StatusCode, "expected status code 400")
+	mockOrderService.AssertExpectations(t)
+}
+
+func Test_handlers_OrdersHandler_HandleUpdateOrderItem_SeveralLines(t *testing.T) {
+	mockOrderService := &mockservice.OrderService{}
+	handler := NewOrdersHandler(mockOrderService)
+
+	mockOrderService.On("UpdateOrderItemQuantity", mock.Anything, 1, 3, 5).
+		Return(apperr.NewAppError(apperr.ErrConflict, "menu item is on several lines of the order, change the line by its id", nil)).Once()
+	buf := bytes.NewBuffer(nil)
+	err := encodeJson(buf, dtos.UpdateOrderItemRequest{Quantity: 5})
+	require.NoError(t, err, "expected no error while encoding request")
+	req := httptest.NewRequest("PATCH", "/api/orders/1/items/3", buf)
+	req.SetPathValue("id", "1")
+	req.SetPathValue("menuItemId", "3")
+
+	w := httptest.NewRecorder()
+	handler.HandleUpdateOrderItem(w, req)
+	res := w.Result()
+	require.Equal(t, 409, res.StatusCode, "expected status code 409")
+	mockOrderService.AssertExpectations(t)
+}
+
+func Test_handlers_OrdersHandler_HandleUpdateOrderLine(t *testing.T) {
+	mockOrderService := &mockservice.OrderService{}
+	handler := NewOrdersHandler(mockOrderService)
+
+	mockOrderService.On("UpdateOrderLineQuantity", mock.Anything, 1, 7, 5).Return(nil).Once()
+	buf := bytes.NewBuffer(nil)
+	err := encodeJson(buf, dtos.UpdateOrderItemRequest{Quantity: 5})
+	require.NoError(t, err, "expected no error while encoding request")
+	req := httptest.NewRequest("PATCH", "/api/orders/1/lines/7", buf)
+	req.SetPathValue("id", "1")
+	req.SetPathValue("lineId", "7")
+
+	w := httptest.NewRecorder()
+	handler.HandleUpdateOrderLine(w, req)
+	res := w.Result()
+	require.Equal(t, 200, res.StatusCode, "expected status code 200")
+
+	defer res.Body.Close()
+	updateLineResp, err := decodeResponse[dtos.UpdateOrderLineResponse](res)
+	require.NoError(t, err, "expected no error while decoding response")
+	require.Equal(t, 1, updateLineResp.ID, "expected order ID to be 1")
+	require.Equal(t, 7, updateLineResp.LineID, "expected order line ID to be 7")
+	mockOrderService.AssertExpectations(t)
+}
+
+func Test_handlers_OrdersHandler_HandleUpdateOrderLine_InvalidLineId(t *testing.T) {
+	mockOrderService := &mockservice.OrderService{}
+	handler := NewOrdersHandler(mockOrderService)
+
+	buf := bytes.NewBuffer(nil)
+	err := encodeJson(buf, dtos.UpdateOrderItemRequest{Quantity: 5})
+	require.NoError(t, err, "expected no error while encoding request")
+	req := httptest.NewRequest("PATCH", "/api/orders/1/lines/abc", buf)
+	req.SetPathValue("id", "1")
+	req.SetPathValue("lineId", "abc")
+
+	w := httptest.NewRecorder()
+	handler.HandleUpdateOrderLine(w, req)
+	res := w.Result()
+	require.Equal(t, 400, res.StatusCode, "expected status code 400")
+	mockOrderService.AssertNotCalled(t, "UpdateOrderLineQuantity", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func Test_handlers_OrdersHandler_HandleRemoveOrderLine(t *testing.T) {
+	mockOrderService := &mockservice.OrderService{}
+	handler := NewOrdersHandler(mockOrderService)
+
+	mockOrderService.On("RemoveOrderLine", mock.Anything, 1, 7).Return(nil).Once()
+	req := httptest.NewRequest("DELETE", "/api/orders/1/lines/7", nil)
+	req.SetPathValue("id", "1")
+	req.SetPathValue("lineId", "7")
+
+	w := httptest.NewRecorder()
+	handler.HandleRemoveOrderLine(w, req)
+	res := w.Result()
+	require.Equal(t, 200, res.StatusCode, "expected status code 200")
+	mockOrderService.AssertExpectations(t)
+}
+
 func Test_handlers_OrdersHandler_HandleAddOrderItem_InvalidId(t *testing.T) {
 	mockOrderService := &mockservice.OrderService{}
 	handler := NewOrdersHandler(mockOrderService)
@@ -628,6 +863,34 @@ func Test_handlers_OrdersHandler_HandleAddOrderItem_BadRequestFromService(t *tes
 	require.Contains(t, errorResponse.Message, "invalid order item data", "expected error message to contain 'invalid order item data'")
 }
 
+func Test_handlers_OrdersHandler_HandleAddOrderItem_OutOfStock(t *testing.T) {
+	mockOrderService := &mockservice.OrderService{}
+	handler := NewOrdersHandler(mockOrderService)
+
+	mockOrderService.On("AddOrderItem", mock.Anything, 1, domain.OrderItem{
+		MenuItemID: 1,
+		Quantity:   2,
+	}).Return(apperr.NewAppError(apperr.ErrConflict, "not enough stock of Cake", nil)).Once()
+	buf := bytes.NewBuffer(nil)
+	err := encodeJson(buf, dtos.AddOrderItemRequest{
+		MenuItemID: 1,
+		Quantity:   2,
+	})
+	require.NoError(t, err, "expected no error while encoding request")
+	req := httptest.NewRequest("POST", "/api/orders/1/items", buf)
+	req.SetPathValue("id", "1")
+
+	w := httptest.NewRecorder()
+	handler.HandleAddOrderItem(w, req)
+	res := w.Result()
+	require.Equal(t, 409, res.StatusCode, "expected status code 409")
+
+	defer res.Body.Close()
+	errorResponse, err := decodeJson[dtos.BaseResponse](res.Body)
+	require.NoError(t, err, "expected no error while decoding response")
+	require.Equal(t, "not enough stock of Cake", errorResponse.Message)
+}
+
 func Test_handlers_OrdersHandler_HandleAddOrderItem_UnauthorizedFromService(t *testing.T) {
 	mockOrderService := &mockservice.OrderService{}
 	handler := NewOrdersHandler(mockOrderService)
@@ -723,3 +986,314 @@ func Test_handlers_OrdersHandler_HandleAddOrderItem_NotFoundFromService(t *testi
 	require.Equal(t, 404, errorResponse.Status, "expected error status to be 404")
 	require.Contains(t, errorResponse.Message, "order not found", "expected error message to contain 'order not found'")
 }
+
+func Test_handlers_OrdersHandler_HandleGetRestaurantOrders(t *testing.T) {
+	mockOrderService := &mockservice.OrderService{}
+	handler := NewOrdersHandler(mockOrderService)
+	require.NotNil(t, handler, "expected NewOrdersHandler to return a non-nil handler")
+
+	mockOrderService.On("GetRestaurantOrders", mock.Anything, 1,
+		[]domain.OrderStatus{domain.OrderPlaced, domain.OrderAccepted}).
+		Return([]domain.Order{
+			{ID: 1, CustomerID: 2, RestaurantID: 1, Status: domain.OrderPlaced,
+				OrderItems: []domain.OrderItem{{MenuItemID: 1, Quantity: 2}}},
+			{ID: 2, CustomerID: 3, RestaurantID: 1, Status: domain.OrderAccepted,
+				OrderItems: []domain.OrderItem{{MenuItemID: 2, Quantity: 1}}},
+		}, nil).Once()
+
+	req := httptest.NewRequest("GET", "/api/restaurants/1/orders?status=placed,accepted", nil)
+	req.SetPathValue("id", "1")
+
+	w := httptest.NewRecorder()
+	handler.HandleGetRestaurantOrders(w, req)
+	res := w.Result()
+
+	require.Equal(t, 200, res.StatusCode, "expected status code 200")
+	require.Equal(t, "application/json", res.Header.Get("Content-Type"), "expected content type application/json")
+
+	defer res.Body.Close()
+	ordersResp, err := decodeResponse[dtos.GetRestaurantOrdersResponse](res)
+
+	require.NoError(t, err, "expected no error while decoding response")
+	require.Equal(t, 1, ordersResp.RestaurantID, "expected restaurant ID to be 1")
+	require.Len(t, ordersResp.Orders, 2, "expected 2 orders")
+	require.Equal(t, "placed", ordersResp.Orders[0].Status, "expected first order to be placed")
+	mockOrderService.AssertExpectations(t)
+}
+
+func Test_handlers_OrdersHandler_HandleGetRestaurantOrders_Forbidden(t *testing.T) {
+	mockOrderService := &mockservice.OrderService{}
+	handler := NewOrdersHandler(mockOrderService)
+
+	mockOrderService.On("GetRestaurantOrders", mock.Anything, 1, []domain.OrderStatus{}).
+		Return([]domain.Order{}, apperr.NewAppError(apperr.ErrForbidden, "forbidden", nil)).Once()
+
+	req := httptest.NewRequest("GET", "/api/restaurants/1/orders", nil)
+	req.SetPathValue("id", "1")
+
+	w := httptest.NewRecorder()
+	handler.HandleGetRestaurantOrders(w, req)
+	res := w.Result()
+
+	require.Equal(t, 403, res.StatusCode, "expected status code 403")
+	mockOrderService.AssertExpectations(t)
+}
+
+func Test_handlers_OrdersHandler_HandleGetRestaurantOrders_InvalidId(t *testing.T) {
+	mockOrderService := &mockservice.OrderService{}
+	handler := NewOrdersHandler(mockOrderService)
+
+	req := httptest.NewRequest("GET", "/api/restaurants/abc/orders", nil)
+	req.SetPathValue("id", "abc")
+
+	w := httptest.NewRecorder()
+	handler.HandleGetRestaurantOrders(w, req)
+	res := w.Result()
+
+	require.Equal(t, 400, res.StatusCode, "expected status code 400")
+	mockOrderService.AssertNotCalled(t, "GetRestaurantOrders", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func Test_handlers_OrdersHandler_HandleAcceptOrder(t *testing.T) {
+	mockOrderService := &mockservice.OrderService{}
+	handler := NewOrdersHandler(mockOrderService)
+
+	mockOrderService.On("TransitionOrder", mock.Anything, 1, domain.OrderAccepted).Return(nil).Once()
+
+	req := httptest.NewRequest("POST", "/api/orders/1/accept", nil)
+	req.SetPathValue("id", "1")
+
+	w := httptest.NewRecorder()
+	handler.HandleAcceptOrder(w, req)
+	res := w.Result()
+
+	require.Equal(t, 200, res.StatusCode, "expected status code 200")
+
+	defer res.Body.Close()
+	statusResp, err := decodeResponse[dtos.UpdateOrderStatusResponse](res)
+	require.NoError(t, err, "expected no error while decoding response")
+	require.Equal(t, 1, statusResp.ID, "expected order ID to be 1")
+	require.Equal(t, "accepted", statusResp.Status, "expected status to be accepted")
+	mockOrderService.AssertExpectations(t)
+}
+
+func Test_handlers_OrdersHandler_HandleRejectOrder(t *testing.T) {
+	mockOrderService := &mockservice.OrderService{}
+	handler := NewOrdersHandler(mockOrderService)
+
+	mockOrderService.On("TransitionOrder", mock.Anything, 1, domain.OrderCancelled).Return(nil).Once()
+
+	req := httptest.NewRequest("POST", "/api/orders/1/reject", nil)
+	req.SetPathValue("id", "1")
+
+	w := httptest.NewRecorder()
+	handler.HandleRejectOrder(w, req)
+	res := w.Result()
+
+	require.Equal(t, 200, res.StatusCode, "expected status code 200")
+	mockOrderService.AssertExpectations(t)
+}
+
+func Test_handlers_OrdersHandler_HandleRejectOrder_Forbidden(t *testing.T) {
+	mockOrderService := &mockservice.OrderService{}
+	handler := NewOrdersHandler(mockOrderService)
+
+	// kitchen staff can move orders along but not cancel them
+	mockOrderService.On("TransitionOrder", mock.Anything, 1, domain.OrderCancelled).
+		Return(apperr.NewAppError(apperr.ErrForbidden, "access to the resource is forbidden", nil)).Once()
+
+	req := httptest.NewRequest("POST", "/api/orders/1/reject", nil)
+	req.SetPathValue("id", "1")
+
+	w := httptest.NewRecorder()
+	handler.HandleRejectOrder(w, req)
+	res := w.Result()
+
+	require.Equal(t, 403, res.StatusCode, "expected status code 403")
+	mockOrderService.AssertExpectations(t)
+}
+
+func Test_handlers_OrdersHandler_HandleCancelOrder(t *testing.T) {
+	mockOrderService := &mockservice.OrderService{}
+	handler := NewOrdersHandler(mockOrderService)
+
+	mockOrderService.On("CancelOrder", mock.Anything, 1).Return(nil).Once()
+
+	req := httptest.NewRequest("POST", "/api/orders/1/cancel", nil)
+	req.SetPathValue("id", "1")
+
+	w := httptest.NewRecorder()
+	handler.HandleCancelOrder(w, req)
+	res := w.Result()
+
+	require.Equal(t, 200, res.StatusCode, "expected status code 200")
+
+	defer res.Body.Close()
+	statusResp, err := decodeResponse[dtos.UpdateOrderStatusResponse](res)
+	require.NoError(t, err, "expected no error while decoding response")
+	require.Equal(t, "cancelled", statusResp.Status, "expected status to be cancelled")
+	mockOrderService.AssertExpectations(t)
+}
+
+func Test_handlers_OrdersHandler_HandleCancelOrder_AlreadyAccepted(t *testing.T) {
+	mockOrderService := &mockservice.OrderService{}
+	handler := NewOrdersHandler(mockOrderService)
+
+	mockOrderService.On("CancelOrder", mock.Anything, 1).
+		Return(apperr.NewAppError(apperr.ErrInvalid, "order has already been accepted by the restaurant", nil)).Once()
+
+	req := httptest.NewRequest("POST", "/api/orders/1/cancel", nil)
+	req.SetPathValue("id", "1")
+
+	w := httptest.NewRecorder()
+	handler.HandleCancelOrder(w, req)
+	res := w.Result()
+
+	require.Equal(t, 400, res.StatusCode, "expected status code 400")
+	mockOrderService.AssertExpectations(t)
+}
+
+func Test_handlers_OrdersHandler_HandleMarkOrderPreparing(t *testing.T) {
+	mockOrderService := &mockservice.OrderService{}
+	handler := NewOrdersHandler(mockOrderService)
+
+	mockOrderService.On("TransitionOrder", mock.Anything, 1, domain.OrderPreparing).Return(nil).Once()
+
+	req := httptest.NewRequest("POST", "/api/orders/1/preparing", nil)
+	req.SetPathValue("id", "1")
+
+	w := httptest.NewRecorder()
+	handler.HandleMarkOrderPreparing(w, req)
+	res := w.Result()
+
+	require.Equal(t, 200, res.StatusCode, "expected status code 200")
+
+	defer res.Body.Close()
+	statusResp, err := decodeResponse[dtos.UpdateOrderStatusResponse](res)
+	require.NoError(t, err, "expected no error while decoding response")
+	require.Equal(t, 1, statusResp.ID, "expected order ID to be 1")
+	require.Equal(t, "preparing", statusResp.Status, "expected status to be preparing")
+	mockOrderService.AssertExpectations(t)
+}
+
+func Test_handlers_OrdersHandler_HandleMarkOrderDelivered(t *testing.T) {
+	mockOrderService := &mockservice.OrderService{}
+	handler := NewOrdersHandler(mockOrderService)
+
+	mockOrderService.On("TransitionOrder", mock.Anything, 1, domain.OrderDelivered).Return(nil).Once()
+
+	req := httptest.NewRequest("POST", "/api/orders/1/delivered", nil)
+	req.SetPathValue("id", "1")
+
+	w := httptest.NewRecorder()
+	handler.HandleMarkOrderDelivered(w, req)
+	res := w.Result()
+
+	require.Equal(t, 200, res.StatusCode, "expected status code 200")
+
+	defer res.Body.Close()
+	statusResp, err := decodeResponse[dtos.UpdateOrderStatusResponse](res)
+	require.NoError(t, err, "expected no error while decoding response")
+	require.Equal(t, 1, statusResp.ID, "expected order ID to be 1")
+	require.Equal(t, "delivered", statusResp.Status, "expected status to be delivered")
+	mockOrderService.AssertExpectations(t)
+}
+
+func Test_handlers_OrdersHandler_HandleMarkOrderDelivered_InvalidTransition(t *testing.T) {
+	mockOrderService := &mockservice.OrderService{}
+	handler := NewOrdersHandler(mockOrderService)
+
+	mockOrderService.On("TransitionOrder", mock.Anything, 1, domain.OrderDelivered).
+		Return(apperr.NewAppError(apperr.ErrInvalid, "cannot move order from preparing to delivered", nil)).Once()
+
+	req := httptest.NewRequest("POST", "/api/orders/1/delivered", nil)
+	req.SetPathValue("id", "1")
+
+	w := httptest.NewRecorder()
+	handler.HandleMarkOrderDelivered(w, req)
+	res := w.Result()
+
+	require.Equal(t, 400, res.StatusCode, "expected status code 400")
+	mockOrderService.AssertExpectations(t)
+}
+
+func Test_handlers_OrdersHandler_HandleMarkOrderReady_InvalidTransition(t *testing.T) {
+	mockOrderService := &mockservice.OrderService{}
+	handler := NewOrdersHandler(mockOrderService)
+
+	mockOrderService.On("TransitionOrder", mock.Anything, 1, domain.OrderReady).
+		Return(apperr.NewAppError(apperr.ErrInvalid, "cannot move order from placed to ready", nil)).Once()
+
+	req := httptest.NewRequest("POST", "/api/orders/1/ready", nil)
+	req.SetPathValue("id", "1")
+
+	w := httptest.NewRecorder()
+	handler.HandleMarkOrderReady(w, req)
+	res := w.Result()
+
+	require.Equal(t, 400, res.StatusCode, "expected status code 400")
+	mockOrderService.AssertExpectations(t)
+}
+
+func Test_handlers_OrdersHandler_HandleGetCustomerOrders(t *testing.T) {
+	mockOrderService := &mockservice.OrderService{}
+	handler := NewOrdersHandler(mockOrderService)
+
+	mockOrderService.On("GetCustomerOrders", mock.Anything, domain.OrderFilter{
+		RestaurantID: 2,
+		Statuses:     []domain.OrderStatus{domain.OrderDelivered},
+		From:         time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+		To:           time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC),
+		Cursor:       10,
+		Limit:        5,
+	}).Return([]domain.Order{
+		{ID: 9, CustomerID: 1, RestaurantID: 2, Status: domain.OrderDelivered},
+	}, 9, nil).Once()
+
+	req := httptest.NewRequest("GET",
+		"/api/orders?restaurant_id=2&status=delivered&from=2025-01-01&to=2025-01-31&cursor=10&limit=5", nil)
+
+	w := httptest.NewRecorder()
+	handler.HandleGetCustomerOrders(w, req)
+	res := w.Result()
+
+	require.Equal(t, 200, res.StatusCode, "expected status code 200")
+
+	defer res.Body.Close()
+	ordersResp, err := decodeResponse[dtos.GetCustomerOrdersResponse](res)
+	require.NoError(t, err, "expected no error while decoding response")
+	require.Len(t, ordersResp.Orders, 1, "expected 1 order")
+	require.Equal(t, 9, ordersResp.NextCursor, "expected next cursor to be 9")
+	mockOrderService.AssertExpectations(t)
+}
+
+func Test_handlers_OrdersHandler_HandleGetCustomerOrders_InvalidQuery(t *testing.T) {
+	mockOrderService := &mockservice.OrderService{}
+	handler := NewOrdersHandler(mockOrderService)
+
+	req := httptest.NewRequest("GET", "/api/orders?from=yesterday", nil)
+
+	w := httptest.NewRecorder()
+	handler.HandleGetCustomerOrders(w, req)
+	res := w.Result()
+
+	require.Equal(t, 400, res.StatusCode, "expected status code 400")
+	mockOrderService.AssertNotCalled(t, "GetCustomerOrders", mock.Anything, mock.Anything)
+}
+
+func Test_handlers_OrdersHandler_HandleGetCustomerOrders_Forbidden(t *testing.T) {
+	mockOrderService := &mockservice.OrderService{}
+	handler := NewOrdersHandler(mockOrderService)
+
+	mockOrderService.On("GetCustomerOrders", mock.Anything, domain.OrderFilter{}).
+		Return([]domain.Order{}, 0, apperr.NewAppError(apperr.ErrForbidden, "forbidden", nil)).Once()
+
+	req := httptest.NewRequest("GET", "/api/orders", nil)
+
+	w := httptest.NewRecorder()
+	handler.HandleGetCustomerOrders(w, req)
+	res := w.Result()
+
+	require.Equal(t, 403, res.StatusCode, "expected status code 403")
+	mockOrderService.AssertExpectations(t)
+}
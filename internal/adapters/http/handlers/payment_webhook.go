@@ -0,0 +1,48 @@
+package handlers
+
+import (
+	"io"
+	"net/http"
+
+	"github.com/mohits-git/food-ordering-system/internal/ports"
+	"github.com/mohits-git/food-ordering-system/internal/utils/apperr"
+)
+
+// WebhookSignatureHeader carries the provider's signature of the webhook body.
+const WebhookSignatureHeader = "X-Webhook-Signature"
+
+const maxWebhookBodySize = 1 << 20
+
+type PaymentWebhookHandler struct {
+	paymentWebhookService ports.PaymentWebhookService
+}
+
+func NewPaymentWebhookHandler(paymentWebhookService ports.PaymentWebhookService) *PaymentWebhookHandler {
+	return &PaymentWebhookHandler{paymentWebhookService}
+}
+
+func (h *PaymentWebhookHandler) HandlePaymentWebhook(w http.ResponseWriter, r *http.Request) {
+	// the signature covers the exact bytes, so the body is passed on undecoded
+	payload, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxWebhookBodySize))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	err = h.paymentWebhookService.HandlePaymentWebhook(r.Context(), payload, r.Header.Get(WebhookSignatureHeader))
+	if err != nil {
+		if apperr.IsUnauthorizedError(err) {
+			writeError(w, http.StatusUnauthorized, "invalid signature")
+		} else if apperr.IsInvalidError(err) {
+			appErr, _ := err.(*apperr.AppError)
+			writeError(w, http.StatusBadRequest, appErr.Message)
+		} else if apperr.IsNotFoundError(err) {
+			writeError(w, http.StatusNotFound, "payment not found")
+		} else {
+			writeError(w, http.StatusInternalServerError, "internal server error")
+		}
+		return
+	}
+
+	writeResponse(w, http.StatusOK, "event received", struct{}{})
+}
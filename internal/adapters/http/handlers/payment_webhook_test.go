@@ -0,0 +1,105 @@
+package handlers
+
+import (
+	"bytes"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/mohits-git/food-ordering-system/internal/adapters/fakegateway"
+	"github.com/mohits-git/food-ordering-system/internal/domain"
+	"github.com/mohits-git/food-ordering-system/internal/services"
+	"github.com/mohits-git/food-ordering-system/internal/utils/apperr"
+	mockrepository "github.com/mohits-git/food-ordering-system/tests/mock_repository"
+	mockservice "github.com/mohits-git/food-ordering-system/tests/mock_service"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_handlers_PaymentWebhookHandler_NewPaymentWebhookHandler(t *testing.T) {
+	handler := NewPaymentWebhookHandler(&mockservice.PaymentWebhookService{})
+	require.NotNil(t, handler, "expected NewPaymentWebhookHandler to return a non-nil handler")
+}
+
+func Test_handlers_PaymentWebhookHandler_HandlePaymentWebhook(t *testing.T) {
+	tests := []struct {
+		name       string
+		serviceErr error
+		wantStatus int
+	}{
+		{"event received", nil, 200},
+		{"invalid signature", apperr.NewAppError(apperr.ErrUnauthorized, "invalid webhook signature", nil), 401},
+		{"invalid payload", apperr.NewAppError(apperr.ErrInvalid, "invalid webhook payload", nil), 400},
+		{"unknown transaction", apperr.NewAppError(apperr.ErrNotFound, "payment attempt not found", nil), 404},
+		{"internal error", apperr.NewAppError(apperr.ErrInternal, "database error", nil), 500},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockWebhookService := &mockservice.PaymentWebhookService{}
+			handler := NewPaymentWebhookHandler(mockWebhookService)
+
+			payload := []byte(`{"id":"evt_1","type":"payment.captured","transaction_id":"fake_1"}`)
+			mockWebhookService.On("HandlePaymentWebhook", mock.Anything, payload, "signature").Return(tt.serviceErr).Once()
+
+			req := httptest.NewRequest("POST", "/api/payments/webhook", bytes.NewReader(payload))
+			req.Header.Set(WebhookSignatureHeader, "signature")
+
+			w := httptest.NewRecorder()
+			handler.HandlePaymentWebhook(w, req)
+
+			res := w.Result()
+			require.Equal(t, tt.wantStatus, res.StatusCode)
+			require.Equal(t, "application/json", res.Header.Get("Content-Type"), "expected content type application/json")
+			mockWebhookService.AssertExpectations(t)
+		})
+	}
+}
+
+// Test_handlers_PaymentWebhookHandler_ReplaySignedEvents replays events signed
+// like the fake gateway signs them through the real webhook service.
+func Test_handlers_PaymentWebhookHandler_ReplaySignedEvents(t *testing.T) {
+	invoiceRepo := &mockrepository.InvoiceRepository{}
+	orderRepo := &mockrepository.OrderRepository{}
+	paymentAttemptRepo := &mockrepository.PaymentAttemptRepository{}
+	paymentRepo := &mockrepository.PaymentRepository{}
+	paymentEventRepo := &mockrepository.PaymentEventRepository{}
+	gateway := fakegateway.NewFakeGateway(1, 0, "secret")
+	service := services.NewPaymentWebhookService(invoiceRepo, orderRepo, paymentAttemptRepo, paymentRepo, paymentEventRepo, gateway)
+	handler := NewPaymentWebhookHandler(service)
+
+	paymentEventRepo.On("FindPaymentEvent", mock.Anything, "fake", "evt_1").Return(domain.PaymentEvent{}, nil).Once()
+	paymentEventRepo.On("SavePaymentEvent", mock.Anything, mock.Anything).Return(1, nil).Once()
+	paymentAttemptRepo.On("FindPaymentAttemptByTransactionId", mock.Anything, "fake", "fake_1").
+		Return(domain.PaymentAttempt{ID: 1, InvoiceID: 1, Amount: domain.NewMoney(1100, "USD"), TransactionID: "fake_1", Status: domain.AttemptPending}, nil)
+	paymentAttemptRepo.On("UpdatePaymentAttempt", mock.Anything, mock.Anything).Return(nil)
+	invoiceRepo.On("FindInvoiceById", mock.Anything, 1).
+		Return(domain.Invoice{ID: 1, OrderID: 1, Total: domain.NewMoney(1000, "USD"), Tax: domain.NewMoney(100, "USD"), PaymentStatus: domain.Processing}, nil)
+	orderRepo.On("FindOrderById", mock.Anything, 1).Return(domain.Order{ID: 1, CustomerID: 1, Status: domain.OrderDraft}, nil)
+	paymentRepo.On("SavePayment", mock.Anything, mock.Anything).Return(1, nil).Once()
+	paymentRepo.On("FindPaymentsByInvoiceId", mock.Anything, 1).
+		Return([]domain.Payment{{ID: 1, InvoiceID: 1, Amount: domain.NewMoney(1100, "USD")}}, nil)
+	orderRepo.On("UpdateOrderStatus", mock.Anything, 1, domain.OrderPlaced).Return(nil)
+	invoiceRepo.On("ChangeInvoiceStatus", mock.Anything, 1, domain.Paid).Return(nil).Once()
+	paymentEventRepo.On("UpdatePaymentEventStatus", mock.Anything, 1, domain.EventProcessed, "").Return(nil).Once()
+	// the redelivery finds the processed event
+	paymentEventRepo.On("FindPaymentEvent", mock.Anything, "fake", "evt_1").
+		Return(domain.PaymentEvent{ID: 1, EventID: "evt_1", Status: domain.EventProcessed}, nil).Once()
+
+	payload := []byte(`{"id":"evt_1","type":"payment.captured","transaction_id":"fake_1"}`)
+	for range 2 {
+		req := httptest.NewRequest("POST", "/api/payments/webhook", bytes.NewReader(payload))
+		req.Header.Set(WebhookSignatureHeader, fakegateway.Sign("secret", payload))
+		w := httptest.NewRecorder()
+		handler.HandlePaymentWebhook(w, req)
+		require.Equal(t, 200, w.Result().StatusCode)
+	}
+
+	tampered := bytes.Replace(payload, []byte("captured"), []byte("failed"), 1)
+	req := httptest.NewRequest("POST", "/api/payments/webhook", bytes.NewReader(tampered))
+	req.Header.Set(WebhookSignatureHeader, fakegateway.Sign("secret", payload))
+	w := httptest.NewRecorder()
+	handler.HandlePaymentWebhook(w, req)
+	require.Equal(t, 401, w.Result().StatusCode, "expected tampered payload to be rejected")
+
+	invoiceRepo.AssertExpectations(t)
+	paymentEventRepo.AssertExpectations(t)
+}
@@ -3,8 +3,10 @@ package handlers
 import (
 	"log"
 	"net/http"
+	"time"
 
 	"github.com/mohits-git/food-ordering-system/internal/adapters/http/dtos"
+	"github.com/mohits-git/food-ordering-system/internal/domain"
 	"github.com/mohits-git/food-ordering-system/internal/ports"
 	"github.com/mohits-git/food-ordering-system/internal/utils/apperr"
 )
@@ -20,9 +22,31 @@ func NewRestaurantHandler(restaurantService ports.RestaurantService) *Restaurant
 }
 
 func (h *RestaurantHandler) HandleGetRestaurants(w http.ResponseWriter, r *http.Request) {
-	restaurants, err := h.restaurantService.GetAllRestaurants(r.Context())
+	filter := domain.RestaurantFilter{
+		Query:   r.URL.Query().Get("q"),
+		Cuisine: r.URL.Query().Get("cuisine"),
+		Sort:    domain.RestaurantSort(r.URL.Query().Get("sort")),
+	}
+	if r.URL.Query().Get("open_now") == "true" {
+		filter.OpenAt = time.Now()
+	}
+	var err error
+	if filter.Cursor, err = getIntFromQuery(r, "cursor"); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid cursor")
+		return
+	}
+	if filter.Limit, err = getIntFromQuery(r, "limit"); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid limit")
+		return
+	}
+
+	restaurants, nextCursor, err := h.restaurantService.SearchRestaurants(r.Context(), filter)
 	if err != nil {
-		writeError(w, http.StatusInternalServerError, "failed to fetch restaurants")
+		if apperr.IsInvalidError(err) {
+			writeError(w, http.StatusBadRequest, err.Error())
+		} else {
+			writeError(w, http.StatusInternalServerError, "failed to fetch restaurants")
+		}
 		return
 	}
 
@@ -30,7 +54,7 @@ func (h *RestaurantHandler) HandleGetRestaurants(w http.ResponseWriter, r *http.
 	for _, restaurant := range restaurants {
 		restaurantDTOs = append(restaurantDTOs, dtos.NewRestaurantDTO(restaurant))
 	}
-	resp := dtos.GetRestaurantsResponse{Restaurants: restaurantDTOs}
+	resp := dtos.GetRestaurantsResponse{Restaurants: restaurantDTOs, NextCursor: nextCursor}
 	writeResponse(w, http.StatusOK, "restaurants fetched successfully", resp)
 }
 
@@ -58,3 +82,232 @@ func (h *RestaurantHandler) HandleCreateRestaurant(w http.ResponseWriter, r *htt
 
 	writeResponse(w, http.StatusCreated, "restaurant created successfully", dtos.CreateRestaurantResponse{ID: id})
 }
+
+func (h *RestaurantHandler) HandleGetRestaurant(w http.ResponseWriter, r *http.Request) {
+	restaurantId := getIdFromPath(r, "id")
+	if restaurantId <= 0 {
+		writeError(w, http.StatusBadRequest, "invalid restaurant id")
+		return
+	}
+
+	restaurant, err := h.restaurantService.GetRestaurantById(r.Context(), restaurantId)
+	if err != nil {
+		if apperr.IsNotFoundError(err) {
+			writeError(w, http.StatusNotFound, "restaurant not found")
+		} else if apperr.IsInvalidError(err) {
+			writeError(w, http.StatusBadRequest, err.Error())
+		} else {
+			log.Println("error fetching restaurant:", err)
+			writeError(w, http.StatusInternalServerError, "failed to fetch restaurant")
+		}
+		return
+	}
+	writeResponse(w, http.StatusOK, "restaurant fetched successfully", dtos.NewRestaurantDTO(restaurant))
+}
+
+func (h *RestaurantHandler) HandleUpdateRestaurant(w http.ResponseWriter, r *http.Request) {
+	restaurantId := getIdFromPath(r, "id")
+	if restaurantId <= 0 {
+		writeError(w, http.StatusBadRequest, "invalid restaurant id")
+		return
+	}
+
+	updateReq, err := decodeRequest[dtos.UpdateRestaurantRequest](r)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request payload")
+		return
+	}
+
+	if err := h.restaurantService.UpdateRestaurant(r.Context(), updateReq.ToDomain(restaurantId)); err != nil {
+		writeRestaurantError(w, err)
+		return
+	}
+	writeResponse(w, http.StatusOK, "restaurant updated successfully", dtos.UpdateRestaurantResponse{})
+}
+
+// HandlePauseOrders stops or resumes the restaurant taking orders.
+func (h *RestaurantHandler) HandlePauseOrders(w http.ResponseWriter, r *http.Request) {
+	restaurantId := getIdFromPath(r, "id")
+	if restaurantId <= 0 {
+		writeError(w, http.StatusBadRequest, "invalid restaurant id")
+		return
+	}
+
+	pauseReq, err := decodeRequest[dtos.PauseOrdersRequest](r)
+	if err != nil || pauseReq.Paused == nil {
+		writeError(w, http.StatusBadRequest, "invalid request payload")
+		return
+	}
+
+	if err := h.restaurantService.PauseOrders(r.Context(), restaurantId, *pauseReq.Paused); err != nil {
+		writeRestaurantError(w, err)
+		return
+	}
+	message := "restaurant orders resumed successfully"
+	if *pauseReq.Paused {
+		message = "restaurant orders paused successfully"
+	}
+	writeResponse(w, http.StatusOK, message, dtos.UpdateRestaurantResponse{})
+}
+
+func writeRestaurantError(w http.ResponseWriter, err error) {
+	if apperr.IsUnauthorizedError(err) {
+		writeError(w, http.StatusUnauthorized, "unauthorized, please login")
+	} else if apperr.IsForbiddenError(err) {
+		writeError(w, http.StatusForbidden, "forbidden")
+	} else if apperr.IsNotFoundError(err) {
+		writeError(w, http.StatusNotFound, "restaurant not found")
+	} else if apperr.IsInvalidError(err) {
+		writeError(w, http.StatusBadRequest, err.Error())
+	} else {
+		log.Println("error updating restaurant:", err)
+		writeError(w, http.StatusInternalServerError, "internal server error")
+	}
+}
+
+func (h *RestaurantHandler) HandleGetMembers(w http.ResponseWriter, r *http.Request) {
+	restaurantId := getIdFromPath(r, "id")
+	if restaurantId <= 0 {
+		writeError(w, http.StatusBadRequest, "invalid restaurant id")
+		return
+	}
+
+	members, err := h.restaurantService.GetMembers(r.Context(), restaurantId)
+	if err != nil {
+		writeMemberError(w, err)
+		return
+	}
+	writeResponse(w, http.StatusOK, "members fetched successfully", newGetRestaurantMembersResponse(members))
+}
+
+func (h *RestaurantHandler) HandleInviteMember(w http.ResponseWriter, r *http.Request) {
+	restaurantId := getIdFromPath(r, "id")
+	if restaurantId <= 0 {
+		writeError(w, http.StatusBadRequest, "invalid restaurant id")
+		return
+	}
+
+	inviteReq, err := decodeRequest[dtos.InviteMemberRequest](r)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request payload")
+		return
+	}
+
+	member, err := h.restaurantService.InviteMember(r.Context(), restaurantId, inviteReq.Email, domain.StaffRole(inviteReq.Role))
+	if err != nil {
+		writeMemberError(w, err)
+		return
+	}
+	writeResponse(w, http.StatusCreated, "member added successfully", dtos.NewRestaurantMemberDTO(member))
+}
+
+func (h *RestaurantHandler) HandleRemoveMember(w http.ResponseWriter, r *http.Request) {
+	restaurantId := getIdFromPath(r, "id")
+	userId := getIdFromPath(r, "userId")
+	if restaurantId <= 0 || userId <= 0 {
+		writeError(w, http.StatusBadRequest, "invalid restaurant or user id")
+		return
+	}
+
+	if err := h.restaurantService.RemoveMember(r.Context(), restaurantId, userId); err != nil {
+		writeMemberError(w, err)
+		return
+	}
+	writeResponse(w, http.StatusOK, "member removed successfully", struct{}{})
+}
+
+// HandleGetMemberships lists the restaurants the staff user works at.
+func (h *RestaurantHandler) HandleGetMemberships(w http.ResponseWriter, r *http.Request) {
+	members, err := h.restaurantService.GetMemberships(r.Context())
+	if err != nil {
+		writeMemberError(w, err)
+		return
+	}
+	writeResponse(w, http.StatusOK, "restaurants fetched successfully", newGetRestaurantMembersResponse(members))
+}
+
+func newGetRestaurantMembersResponse(members []domain.RestaurantMember) dtos.GetRestaurantMembersResponse {
+	resp := dtos.GetRestaurantMembersResponse{Members: []dtos.RestaurantMemberDTO{}}
+	for _, member := range members {
+		resp.Members = append(resp.Members, dtos.NewRestaurantMemberDTO(member))
+	}
+	return resp
+}
+
+func writeMemberError(w http.ResponseWriter, err error) {
+	if apperr.IsUnauthorizedError(err) {
+		writeError(w, http.StatusUnauthorized, "unauthorized, please login")
+	} else if apperr.IsForbiddenError(err) {
+		writeError(w, http.StatusForbidden, "forbidden")
+	} else if apperr.IsNotFoundError(err) {
+		writeError(w, http.StatusNotFound, "not found")
+	} else if apperr.IsConflictError(err) {
+		writeError(w, http.StatusConflict, "user is already a member of the restaurant")
+	} else if apperr.IsInvalidError(err) {
+		writeError(w, http.StatusBadRequest, err.Error())
+	} else {
+		log.Println("error managing restaurant members:", err)
+		writeError(w, http.StatusInternalServerError, "internal server error")
+	}
+}
+
+// HandleSetTaxRule sets the tax rate of the restaurant for a category of its
+// menu items, or for all of them without a category.
+func (h *RestaurantHandler) HandleSetTaxRule(w http.ResponseWriter, r *http.Request) {
+	restaurantId := getIdFromPath(r, "id")
+	if restaurantId <= 0 {
+		writeError(w, http.StatusBadRequest, "invalid restaurant id")
+		return
+	}
+
+	ruleReq, err := decodeRequest[dtos.TaxRuleRequest](r)
+	if err != nil || ruleReq.Rate == nil {
+		writeError(w, http.StatusBadRequest, "invalid request payload")
+		return
+	}
+
+	rule, err := h.restaurantService.SetTaxRule(r.Context(), domain.TaxRule{
+		RestaurantID: restaurantId,
+		Category:     ruleReq.Category,
+		Rate:         *ruleReq.Rate,
+		Inclusive:    ruleReq.Inclusive,
+	})
+	if err != nil {
+		writeTaxRuleError(w, err)
+		return
+	}
+	writeResponse(w, http.StatusOK, "tax rule saved successfully", dtos.NewTaxRuleDTO(rule))
+}
+
+// HandleDeleteTaxRule removes the restaurant's tax rule for the category in
+// the query, without one the rule for all of its items.
+func (h *RestaurantHandler) HandleDeleteTaxRule(w http.ResponseWriter, r *http.Request) {
+	restaurantId := getIdFromPath(r, "id")
+	if restaurantId <= 0 {
+		writeError(w, http.StatusBadRequest, "invalid restaurant id")
+		return
+	}
+
+	if err := h.restaurantService.DeleteTaxRule(r.Context(), restaurantId, r.URL.Query().Get("category")); err != nil {
+		writeTaxRuleError(w, err)
+		return
+	}
+	writeResponse(w, http.StatusOK, "tax rule deleted successfully", struct{}{})
+}
+
+func writeTaxRuleError(w http.ResponseWriter, err error) {
+	if apperr.IsUnauthorizedError(err) {
+		writeError(w, http.StatusUnauthorized, "unauthorized, please login")
+	} else if apperr.IsForbiddenError(err) {
+		writeError(w, http.StatusForbidden, "forbidden")
+	} else if apperr.IsNotFoundError(err) {
+		appErr, _ := err.(*apperr.AppError)
+		writeError(w, http.StatusNotFound, appErr.Message)
+	} else if apperr.IsInvalidError(err) {
+		appErr, _ := err.(*apperr.AppError)
+		writeError(w, http.StatusBadRequest, appErr.Message)
+	} else {
+		log.Println("error managing tax rules:", err)
+		writeError(w, http.StatusInternalServerError, "internal server error")
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/mohits-git/food-ordering-system/internal/adapters/http/dtos"
 	"github.com/mohits-git/food-ordering-system/internal/domain"
@@ -24,13 +25,13 @@ func Test_handlers_RestaurantHandler_HandleGetRestaurants(t *testing.T) {
 	handler := NewRestaurantHandler(mockservice)
 	require.NotNil(t, handler, "expected NewRestaurantHandler to return a non-nil handler")
 
-	mockservice.On("GetAllRestaurants", mock.Anything).Return([]domain.Restaurant{
+	mockservice.On("SearchRestaurants", mock.Anything, domain.RestaurantFilter{}).Return([]domain.Restaurant{
 		{
 			ID:      1,
 			Name:    "Test Restaurant",
 			OwnerID: 1,
 		},
-	}, nil).Once()
+	}, 0, nil).Once()
 
 	req := httptest.NewRequest("GET", "/api/restaurants", nil)
 	w := httptest.NewRecorder()
@@ -55,8 +56,8 @@ func Test_handlers_RestaurantHandler_HandleGetRestaurants_Error(t *testing.T) {
 	handler := NewRestaurantHandler(mockservice)
 	require.NotNil(t, handler, "expected NewRestaurantHandler to return a non-nil handler")
 
-	mockservice.On("GetAllRestaurants", mock.Anything).Return(
-		[]domain.Restaurant{}, apperr.NewAppError(apperr.ErrInternal, "failed to fetch restaurants", nil)).Once()
+	mockservice.On("SearchRestaurants", mock.Anything, mock.Anything).Return(
+		[]domain.Restaurant{}, 0, apperr.NewAppError(apperr.ErrInternal, "failed to fetch restaurants", nil)).Once()
 
 	req := httptest.NewRequest("GET", "/api/restaurants", nil)
 	w := httptest.NewRecorder()
@@ -74,6 +75,53 @@ func Test_handlers_RestaurantHandler_HandleGetRestaurants_Error(t *testing.T) {
 	require.Contains(t, errorResponse.Message, "failed to fetch restaurants", "expected error message to contain 'failed to fetch restaurants'")
 }
 
+func Test_handlers_RestaurantHandler_HandleGetRestaurants_search(t *testing.T) {
+	mockservice := &mockservice.RestaurantService{}
+	handler := NewRestaurantHandler(mockservice)
+
+	mockservice.On("SearchRestaurants", mock.Anything, mock.MatchedBy(func(filter domain.RestaurantFilter) bool {
+		return filter.Query == "chicken tikka" && filter.Cuisine == "indian" && !filter.OpenAt.IsZero() &&
+			filter.Sort == domain.RestaurantSortName && filter.Cursor == 20 && filter.Limit == 10
+	})).Return([]domain.Restaurant{{ID: 2, Name: "Curry House", OwnerID: 1}}, 30, nil).Once()
+
+	req := httptest.NewRequest("GET", "/api/restaurants?q=chicken+tikka&cuisine=indian&open_now=true&sort=name&cursor=20&limit=10", nil)
+	w := httptest.NewRecorder()
+	handler.HandleGetRestaurants(w, req)
+	res := w.Result()
+	defer res.Body.Close()
+
+	require.Equal(t, 200, res.StatusCode)
+	restaurants, err := decodeResponse[dtos.GetRestaurantsResponse](res)
+	require.NoError(t, err)
+	require.Len(t, restaurants.Restaurants, 1)
+	require.Equal(t, 30, restaurants.NextCursor)
+	mockservice.AssertExpectations(t)
+}
+
+func Test_handlers_RestaurantHandler_HandleGetRestaurants_when_invalid(t *testing.T) {
+	mockservice := &mockservice.RestaurantService{}
+	handler := NewRestaurantHandler(mockservice)
+
+	req := httptest.NewRequest("GET", "/api/restaurants?cursor=abc", nil)
+	w := httptest.NewRecorder()
+	handler.HandleGetRestaurants(w, req)
+	require.Equal(t, 400, w.Result().StatusCode)
+
+	mockservice.On("SearchRestaurants", mock.Anything, mock.Anything).Return(
+		[]domain.Restaurant{}, 0, apperr.NewAppError(apperr.ErrInvalid, "invalid restaurant sort", nil)).Once()
+
+	req = httptest.NewRequest("GET", "/api/restaurants?sort=rating", nil)
+	w = httptest.NewRecorder()
+	handler.HandleGetRestaurants(w, req)
+	res := w.Result()
+	defer res.Body.Close()
+
+	require.Equal(t, 400, res.StatusCode)
+	errorResponse, err := decodeJson[dtos.BaseResponse](res.Body)
+	require.NoError(t, err)
+	require.Equal(t, "invalid restaurant sort", errorResponse.Message)
+}
+
 func Test_handlers_RestaurantHandler_HandleCreateRestaurant(t *testing.T) {
 	mockservice := &mockservice.RestaurantService{}
 	handler := NewRestaurantHandler(mockservice)
@@ -225,3 +273,369 @@ func Test_handlers_RestaurantHandler_HandleCreateRestaurant_EmptyName(t *testing
 	require.Equal(t, 400, errorResponse.Status, "expected error status to be 400")
 	require.Contains(t, errorResponse.Message, "invalid empty restaurant name", "expected error message to contain 'restaurant name cannot be empty'")
 }
+
+func Test_handlers_RestaurantHandler_HandleGetRestaurant(t *testing.T) {
+	mockservice := &mockservice.RestaurantService{}
+	handler := NewRestaurantHandler(mockservice)
+
+	mockservice.On("GetRestaurantById", mock.Anything, 1).Return(domain.Restaurant{
+		ID: 1, Name: "Test Restaurant", OwnerID: 1, Address: "1 Main St", Cuisines: []string{"indian"},
+		OpeningHours: []domain.OpeningHours{{Weekday: time.Friday, Start: 1080, End: 120}},
+		OrdersPaused: true,
+	}, nil).Once()
+	mockservice.On("GetRestaurantById", mock.Anything, 2).Return(domain.Restaurant{},
+		apperr.NewAppError(apperr.ErrNotFound, "restaurant not found", nil)).Once()
+
+	req := httptest.NewRequest("GET", "/api/restaurants/1", nil)
+	req.SetPathValue("id", "1")
+	w := httptest.NewRecorder()
+	handler.HandleGetRestaurant(w, req)
+	res := w.Result()
+
+	require.Equal(t, 200, res.StatusCode, "expected status code 200")
+
+	defer res.Body.Close()
+	response, err := decodeResponse[dtos.RestaurantDTO](res)
+
+	require.NoError(t, err, "expected no error while decoding response")
+	require.Equal(t, "1 Main St", response.Address)
+	require.Equal(t, []string{"indian"}, response.Cuisines)
+	require.Equal(t, []dtos.OpeningHoursDTO{{Day: "friday", Start: "18:00", End: "02:00"}}, response.OpeningHours)
+	require.True(t, response.OrdersPaused)
+	require.False(t, response.OpenNow, "expected a paused restaurant not to be open")
+
+	req = httptest.NewRequest("GET", "/api/restaurants/2", nil)
+	req.SetPathValue("id", "2")
+	w = httptest.NewRecorder()
+	handler.HandleGetRestaurant(w, req)
+	require.Equal(t, 404, w.Result().StatusCode, "expected status code 404")
+	mockservice.AssertExpectations(t)
+}
+
+func Test_handlers_RestaurantHandler_HandleUpdateRestaurant(t *testing.T) {
+	tests := []struct {
+		name           string
+		serviceErr     error
+		expectedStatus int
+	}{
+		{"updated", nil, 200},
+		{"invalid data", apperr.NewAppError(apperr.ErrInvalid, "invalid restaurant data", nil), 400},
+		{"unauthorized", apperr.NewAppError(apperr.ErrUnauthorized, "user not authenticated", nil), 401},
+		{"forbidden", apperr.NewAppError(apperr.ErrForbidden, "not allowed to update restaurants", nil), 403},
+		{"not found", apperr.NewAppError(apperr.ErrNotFound, "restaurant not found", nil), 404},
+		{"internal error", apperr.NewAppError(apperr.ErrInternal, "database error", nil), 500},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockservice := &mockservice.RestaurantService{}
+			handler := NewRestaurantHandler(mockservice)
+
+			mockservice.On("UpdateRestaurant", mock.Anything, domain.Restaurant{
+				ID: 1, Name: "Renamed", Address: "1 Main St", Phone: "555-0100",
+				Cuisines:     []string{"indian"},
+				OpeningHours: []domain.OpeningHours{{Weekday: time.Monday, Start: 660, End: 1320}, {Weekday: -1, Start: 660, End: -1}},
+				Closures:     []string{"2026-12-25"},
+			}).Return(tt.serviceErr).Once()
+
+			buf := bytes.NewBuffer(nil)
+			err := encodeJson(buf, dtos.UpdateRestaurantRequest{
+				Name: "Renamed", Address: "1 Main St", Phone: "555-0100",
+				Cuisines: []string{"indian"},
+				OpeningHours: []dtos.OpeningHoursDTO{
+					{Day: "monday", Start: "11:00", End: "22:00"},
+					{Day: "someday", Start: "11:00", End: "25:00"},
+				},
+				Closures: []string{"2026-12-25"},
+			})
+			require.NoError(t, err, "expected no error while encoding request body")
+
+			req := httptest.NewRequest("PUT", "/api/restaurants/1", buf)
+			req.SetPathValue("id", "1")
+			w := httptest.NewRecorder()
+			handler.HandleUpdateRestaurant(w, req)
+
+			require.Equal(t, tt.expectedStatus, w.Result().StatusCode)
+			mockservice.AssertExpectations(t)
+		})
+	}
+}
+
+func Test_handlers_RestaurantHandler_HandlePauseOrders(t *testing.T) {
+	mockservice := &mockservice.RestaurantService{}
+	handler := NewRestaurantHandler(mockservice)
+
+	mockservice.On("PauseOrders", mock.Anything, 1, true).Return(nil).Once()
+
+	req := httptest.NewRequest("PATCH", "/api/restaurants/1/pause", bytes.NewBufferString(`{"paused": true}`))
+	req.SetPathValue("id", "1")
+	w := httptest.NewRecorder()
+	handler.HandlePauseOrders(w, req)
+	require.Equal(t, 200, w.Result().StatusCode, "expected status code 200")
+
+	// the switch has to be set either way
+	req = httptest.NewRequest("PATCH", "/api/restaurants/1/pause", bytes.NewBufferString(`{}`))
+	req.SetPathValue("id", "1")
+	w = httptest.NewRecorder()
+	handler.HandlePauseOrders(w, req)
+	require.Equal(t, 400, w.Result().StatusCode, "expected status code 400")
+	mockservice.AssertExpectations(t)
+}
+
+func Test_handlers_RestaurantHandler_HandleGetMembers(t *testing.T) {
+	mockservice := &mockservice.RestaurantService{}
+	handler := NewRestaurantHandler(mockservice)
+
+	mockservice.On("GetMembers", mock.Anything, 1).Return([]domain.RestaurantMember{
+		{RestaurantID: 1, UserID: 5, Role: domain.StaffKitchen, Name: "Cook", Email: "cook@example.com"},
+	}, nil).Once()
+
+	req := httptest.NewRequest("GET", "/api/restaurants/1/members", nil)
+	req.SetPathValue("id", "1")
+	w := httptest.NewRecorder()
+	handler.HandleGetMembers(w, req)
+	res := w.Result()
+
+	require.Equal(t, 200, res.StatusCode, "expected status code 200")
+
+	defer res.Body.Close()
+	response, err := decodeResponse[dtos.GetRestaurantMembersResponse](res)
+
+	require.NoError(t, err, "expected no error while decoding response")
+	require.Len(t, response.Members, 1, "expected one member in response")
+	require.Equal(t, 5, response.Members[0].UserID, "expected member user id to be 5")
+	require.Equal(t, "kitchen", response.Members[0].Role, "expected member role to be kitchen")
+	require.Equal(t, "cook@example.com", response.Members[0].Email, "expected member email to be cook@example.com")
+	mockservice.AssertExpectations(t)
+}
+
+func Test_handlers_RestaurantHandler_HandleGetMembers_Forbidden(t *testing.T) {
+	mockservice := &mockservice.RestaurantService{}
+	handler := NewRestaurantHandler(mockservice)
+
+	mockservice.On("GetMembers", mock.Anything, 1).Return([]domain.RestaurantMember{},
+		apperr.NewAppError(apperr.ErrForbidden, "not allowed to manage restaurant staff of other restaurants", nil)).Once()
+
+	req := httptest.NewRequest("GET", "/api/restaurants/1/members", nil)
+	req.SetPathValue("id", "1")
+	w := httptest.NewRecorder()
+	handler.HandleGetMembers(w, req)
+	res := w.Result()
+
+	require.Equal(t, 403, res.StatusCode, "expected status code 403")
+	mockservice.AssertExpectations(t)
+}
+
+func Test_handlers_RestaurantHandler_HandleInviteMember(t *testing.T) {
+	mockservice := &mockservice.RestaurantService{}
+	handler := NewRestaurantHandler(mockservice)
+
+	mockservice.On("InviteMember", mock.Anything, 1, "cashier@example.com", domain.StaffCashier).Return(
+		domain.RestaurantMember{RestaurantID: 1, UserID: 6, Role: domain.StaffCashier, Name: "Cashier", Email: "cashier@example.com"}, nil).Once()
+
+	buf := bytes.NewBuffer(nil)
+	err := encodeJson(buf, dtos.InviteMemberRequest{Email: "cashier@example.com", Role: "cashier"})
+	require.NoError(t, err, "expected no error while encoding request body")
+
+	req := httptest.NewRequest("POST", "/api/restaurants/1/members", buf)
+	req.SetPathValue("id", "1")
+	w := httptest.NewRecorder()
+	handler.HandleInviteMember(w, req)
+	res := w.Result()
+
+	require.Equal(t, 201, res.StatusCode, "expected status code 201")
+
+	defer res.Body.Close()
+	response, err := decodeResponse[dtos.RestaurantMemberDTO](res)
+
+	require.NoError(t, err, "expected no error while decoding response")
+	require.Equal(t, 6, response.UserID, "expected member user id to be 6")
+	require.Equal(t, "cashier", response.Role, "expected member role to be cashier")
+	mockservice.AssertExpectations(t)
+}
+
+func Test_handlers_RestaurantHandler_HandleInviteMember_Errors(t *testing.T) {
+	tests := []struct {
+		name   string
+		err    error
+		status int
+	}{
+		{"not staff", apperr.NewAppError(apperr.ErrInvalid, "only staff accounts can join a restaurant", nil), 400},
+		{"user not found", apperr.NewAppError(apperr.ErrNotFound, "user not found", nil), 404},
+		{"already member", apperr.NewAppError(apperr.ErrConflict, "already exists", nil), 409},
+		{"not owner", apperr.NewAppError(apperr.ErrForbidden, "not allowed to manage restaurant staff", nil), 403},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockservice := &mockservice.RestaurantService{}
+			handler := NewRestaurantHandler(mockservice)
+
+			mockservice.On("InviteMember", mock.Anything, 1, "staff@example.com", domain.StaffManager).Return(
+				domain.RestaurantMember{}, tt.err).Once()
+
+			buf := bytes.NewBuffer(nil)
+			err := encodeJson(buf, dtos.InviteMemberRequest{Email: "staff@example.com", Role: "manager"})
+			require.NoError(t, err, "expected no error while encoding request body")
+
+			req := httptest.NewRequest("POST", "/api/restaurants/1/members", buf)
+			req.SetPathValue("id", "1")
+			w := httptest.NewRecorder()
+			handler.HandleInviteMember(w, req)
+			res := w.Result()
+
+			require.Equal(t, tt.status, res.StatusCode, "expected status code %d", tt.status)
+			mockservice.AssertExpectations(t)
+		})
+	}
+}
+
+func Test_handlers_RestaurantHandler_HandleRemoveMember(t *testing.T) {
+	mockservice := &mockservice.RestaurantService{}
+	handler := NewRestaurantHandler(mockservice)
+
+	mockservice.On("RemoveMember", mock.Anything, 1, 6).Return(nil).Once()
+
+	req := httptest.NewRequest("DELETE", "/api/restaurants/1/members/6", nil)
+	req.SetPathValue("id", "1")
+	req.SetPathValue("userId", "6")
+	w := httptest.NewRecorder()
+	handler.HandleRemoveMember(w, req)
+	res := w.Result()
+
+	require.Equal(t, 200, res.StatusCode, "expected status code 200")
+	mockservice.AssertExpectations(t)
+}
+
+func Test_handlers_RestaurantHandler_HandleRemoveMember_InvalidId(t *testing.T) {
+	mockservice := &mockservice.RestaurantService{}
+	handler := NewRestaurantHandler(mockservice)
+
+	req := httptest.NewRequest("DELETE", "/api/restaurants/1/members/abc", nil)
+	req.SetPathValue("id", "1")
+	req.SetPathValue("userId", "abc")
+	w := httptest.NewRecorder()
+	handler.HandleRemoveMember(w, req)
+	res := w.Result()
+
+	require.Equal(t, 400, res.StatusCode, "expected status code 400")
+	mockservice.AssertNotCalled(t, "RemoveMember", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func Test_handlers_RestaurantHandler_HandleGetMemberships(t *testing.T) {
+	mockservice := &mockservice.RestaurantService{}
+	handler := NewRestaurantHandler(mockservice)
+
+	mockservice.On("GetMemberships", mock.Anything).Return([]domain.RestaurantMember{
+		{RestaurantID: 1, UserID: 6, Role: domain.StaffCashier},
+		{RestaurantID: 2, UserID: 6, Role: domain.StaffManager},
+	}, nil).Once()
+
+	req := httptest.NewRequest("GET", "/api/staff/restaurants", nil)
+	w := httptest.NewRecorder()
+	handler.HandleGetMemberships(w, req)
+	res := w.Result()
+
+	require.Equal(t, 200, res.StatusCode, "expected status code 200")
+
+	defer res.Body.Close()
+	response, err := decodeResponse[dtos.GetRestaurantMembersResponse](res)
+
+	require.NoError(t, err, "expected no error while decoding response")
+	require.Len(t, response.Members, 2, "expected two memberships in response")
+	require.Equal(t, 2, response.Members[1].RestaurantID, "expected second restaurant id to be 2")
+	mockservice.AssertExpectations(t)
+}
+
+func Test_handlers_RestaurantHandler_HandleSetTaxRule(t *testing.T) {
+	mockservice := &mockservice.RestaurantService{}
+	handler := NewRestaurantHandler(mockservice)
+
+	mockservice.On("SetTaxRule", mock.Anything, domain.TaxRule{RestaurantID: 1, Category: "alcohol", Rate: 0.2, Inclusive: true}).Return(
+		domain.TaxRule{ID: 4, RestaurantID: 1, Category: "alcohol", Rate: 0.2, Inclusive: true}, nil).Once()
+
+	req := httptest.NewRequest("PUT", "/api/restaurants/1/tax-rules", bytes.NewBufferString(`{"category": "alcohol", "rate": 0.2, "inclusive": true}`))
+	req.SetPathValue("id", "1")
+	w := httptest.NewRecorder()
+	handler.HandleSetTaxRule(w, req)
+	res := w.Result()
+
+	require.Equal(t, 200, res.StatusCode, "expected status code 200")
+
+	defer res.Body.Close()
+	response, err := decodeResponse[dtos.TaxRuleDTO](res)
+	require.NoError(t, err, "expected no error while decoding response")
+	require.Equal(t, dtos.TaxRuleDTO{ID: 4, RestaurantID: 1, Category: "alcohol", Rate: 0.2, Inclusive: true}, response)
+	mockservice.AssertExpectations(t)
+}
+
+func Test_handlers_RestaurantHandler_HandleSetTaxRule_Errors(t *testing.T) {
+	tests := []struct {
+		name   string
+		err    error
+		status int
+	}{
+		{"invalid rate", apperr.NewAppError(apperr.ErrInvalid, "invalid tax rule, the rate is a fraction between 0 and 1", nil), 400},
+		{"restaurant not found", apperr.NewAppError(apperr.ErrNotFound, "restaurant not found", nil), 404},
+		{"not owner", apperr.NewAppError(apperr.ErrForbidden, "not allowed to manage tax rules", nil), 403},
+		{"unauthenticated", apperr.NewAppError(apperr.ErrUnauthorized, "user not authenticated", nil), 401},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockservice := &mockservice.RestaurantService{}
+			handler := NewRestaurantHandler(mockservice)
+
+			mockservice.On("SetTaxRule", mock.Anything, domain.TaxRule{RestaurantID: 1, Rate: 5}).Return(domain.TaxRule{}, tt.err).Once()
+
+			req := httptest.NewRequest("PUT", "/api/restaurants/1/tax-rules", bytes.NewBufferString(`{"rate": 5}`))
+			req.SetPathValue("id", "1")
+			w := httptest.NewRecorder()
+			handler.HandleSetTaxRule(w, req)
+
+			require.Equal(t, tt.status, w.Result().StatusCode, "expected status code %d", tt.status)
+			mockservice.AssertExpectations(t)
+		})
+	}
+}
+
+func Test_handlers_RestaurantHandler_HandleSetTaxRule_MissingRate(t *testing.T) {
+	mockservice := &mockservice.RestaurantService{}
+	handler := NewRestaurantHandler(mockservice)
+
+	req := httptest.NewRequest("PUT", "/api/restaurants/1/tax-rules", bytes.NewBufferString(`{"category": "alcohol"}`))
+	req.SetPathValue("id", "1")
+	w := httptest.NewRecorder()
+	handler.HandleSetTaxRule(w, req)
+
+	require.Equal(t, 400, w.Result().StatusCode, "expected status code 400")
+	mockservice.AssertNotCalled(t, "SetTaxRule", mock.Anything, mock.Anything)
+}
+
+func Test_handlers_RestaurantHandler_HandleDeleteTaxRule(t *testing.T) {
+	mockservice := &mockservice.RestaurantService{}
+	handler := NewRestaurantHandler(mockservice)
+
+	mockservice.On("DeleteTaxRule", mock.Anything, 1, "alcohol").Return(nil).Once()
+	mockservice.On("DeleteTaxRule", mock.Anything, 1, "").
+		Return(apperr.NewAppError(apperr.ErrNotFound, "tax rule not found", nil)).Once()
+
+	req := httptest.NewRequest("DELETE", "/api/restaurants/1/tax-rules?category=alcohol", nil)
+	req.SetPathValue("id", "1")
+	w := httptest.NewRecorder()
+	handler.HandleDeleteTaxRule(w, req)
+	require.Equal(t, 200, w.Result().StatusCode, "expected status code 200")
+
+	req = httptest.NewRequest("DELETE", "/api/restaurants/1/tax-rules", nil)
+	req.SetPathValue("id", "1")
+	w = httptest.NewRecorder()
+	handler.HandleDeleteTaxRule(w, req)
+	res := w.Result()
+	require.Equal(t, 404, res.StatusCode, "expected status code 404")
+
+	defer res.Body.Close()
+	response, err := decodeJson[dtos.BaseResponse](res.Body)
+	require.NoError(t, err, "expected no error while decoding response")
+	require.Equal(t, "tax rule not found", response.Message)
+	mockservice.AssertExpectations(t)
+}
@@ -24,18 +24,21 @@ func (h *UserHandler) HandleCreateUser(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	userId, err := h.userService.CreateUser(
+	role := domain.UserRole(createUserReq.Role)
+	userId, err := h.userService.RegisterUser(
 		r.Context(),
 		domain.NewUser(
 			0,
 			createUserReq.Name,
 			createUserReq.Email,
 			createUserReq.Password,
-			domain.UserRole(createUserReq.Role)),
+			role),
 	)
 	if err != nil {
 		if apperr.IsConflictError(err) {
 			writeError(w, http.StatusConflict, "user already exists")
+		} else if apperr.IsForbiddenError(err) {
+			writeError(w, http.StatusForbidden, "admin accounts cannot be registered")
 		} else if apperr.IsInvalidError(err) {
 			writeError(w, http.StatusBadRequest, "invalid user data")
 		} else {
@@ -44,6 +47,12 @@ func (h *UserHandler) HandleCreateUser(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// owners have to be approved by an admin before they can log in
+	if role == domain.OWNER {
+		resp := dtos.CreateUserResponse{UserID: userId, PendingApproval: true}
+		writeResponse(w, http.StatusCreated, "user created successfully, pending approval by an admin", resp)
+		return
+	}
 	resp := dtos.CreateUserResponse{UserID: userId}
 	writeResponse(w, http.StatusCreated, "user created successfully", resp)
 }
@@ -64,11 +73,6 @@ func (h *UserHandler) HandleGetUserById(w http.ResponseWriter, r *http.Request)
 		}
 	}
 
-	resp := dtos.GetUserResponse{
-		UserID: user.ID,
-		Name:   user.Name,
-		Email:  user.Email,
-		Role:   string(user.Role),
-	}
+	resp := dtos.NewGetUserResponse(user)
 	writeResponse(w, http.StatusOK, "user fetched successfully", resp)
 }
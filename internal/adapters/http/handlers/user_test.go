@@ -44,7 +44,7 @@ func Test_handlers_HandleCreateUser_when_valid_input(t *testing.T) {
 	mockUserService := mockservice.UserService{}
 	userHandler := NewUserHandler(&mockUserService)
 
-	mockUserService.On("CreateUser", mock.Anything, mock.MatchedBy(func(u domain.User) bool {
+	mockUserService.On("RegisterUser", mock.Anything, mock.MatchedBy(func(u domain.User) bool {
 		return u.Email == user.Email &&
 			u.Name == user.Name &&
 			u.Role == user.Role &&
@@ -110,7 +110,7 @@ func Test_handlers_HandleCreateUser_when_invalid_input(t *testing.T) {
 	mockUserService := mockservice.UserService{}
 	userHandler := NewUserHandler(&mockUserService)
 
-	mockUserService.On("CreateUser", mock.Anything, mock.MatchedBy(func(u domain.User) bool {
+	mockUserService.On("RegisterUser", mock.Anything, mock.MatchedBy(func(u domain.User) bool {
 		return u.Email == createReq.Email &&
 			u.Name == createReq.Name &&
 			u.Role == domain.UserRole(createReq.Role) &&
@@ -143,7 +143,7 @@ func Test_handlers_HandleCreateUser_when_user_already_exists(t *testing.T) {
 	mockUserService := mockservice.UserService{}
 	userHandler := NewUserHandler(&mockUserService)
 
-	mockUserService.On("CreateUser", mock.Anything, mock.MatchedBy(func(u domain.User) bool {
+	mockUserService.On("RegisterUser", mock.Anything, mock.MatchedBy(func(u domain.User) bool {
 		return u.Email == createReq.Email &&
 			u.Name == createReq.Name &&
 			u.Role == domain.UserRole(createReq.Role) &&
@@ -176,7 +176,7 @@ func Test_handlers_HandleCreateUser_when_internal_server_error(t *testing.T) {
 	mockUserService := mockservice.UserService{}
 	userHandler := NewUserHandler(&mockUserService)
 
-	mockUserService.On("CreateUser", mock.Anything, mock.MatchedBy(func(u domain.User) bool {
+	mockUserService.On("RegisterUser", mock.Anything, mock.MatchedBy(func(u domain.User) bool {
 		return u.Email == createReq.Email &&
 			u.Name == createReq.Name &&
 			u.Role == domain.UserRole(createReq.Role) &&
@@ -192,6 +192,68 @@ func Test_handlers_HandleCreateUser_when_internal_server_error(t *testing.T) {
 	mockUserService.AssertExpectations(t)
 }
 
+func Test_handlers_HandleCreateUser_when_owner(t *testing.T) {
+	createReq := dtos.CreateUserRequest{
+		Name:     "Mohit",
+		Email:    "test@example.com",
+		Role:     "owner",
+		Password: "123456",
+	}
+	buf := bytes.NewBuffer(nil)
+	err := encodeJson(buf, createReq)
+	require.NoError(t, err, "expected no error while encoding create user request to JSON")
+
+	req := httptest.NewRequest("POST", "/api/users", buf)
+	w := httptest.NewRecorder()
+
+	mockUserService := mockservice.UserService{}
+	userHandler := NewUserHandler(&mockUserService)
+
+	mockUserService.On("RegisterUser", mock.Anything, mock.MatchedBy(func(u domain.User) bool {
+		return u.Role == domain.OWNER
+	})).Return(2, nil).Once()
+
+	userHandler.HandleCreateUser(w, req)
+
+	resp := w.Result()
+	require.Equal(t, 201, resp.StatusCode, "expected status code 201 Created")
+	defer resp.Body.Close()
+	body, err := decodeResponse[dtos.CreateUserResponse](resp)
+	require.NoError(t, err, "expected no error while decoding response body")
+	require.Equal(t, 2, body.UserID)
+	require.True(t, body.PendingApproval, "expected owner accounts to be pending approval")
+	mockUserService.AssertExpectations(t)
+}
+
+func Test_handlers_HandleCreateUser_when_admin(t *testing.T) {
+	createReq := dtos.CreateUserRequest{
+		Name:     "Mohit",
+		Email:    "test@example.com",
+		Role:     "admin",
+		Password: "123456",
+	}
+	buf := bytes.NewBuffer(nil)
+	err := encodeJson(buf, createReq)
+	require.NoError(t, err, "expected no error while encoding create user request to JSON")
+
+	req := httptest.NewRequest("POST", "/api/users", buf)
+	w := httptest.NewRecorder()
+
+	mockUserService := mockservice.UserService{}
+	userHandler := NewUserHandler(&mockUserService)
+
+	mockUserService.On("RegisterUser", mock.Anything, mock.Anything).
+		Return(0, apperr.NewAppError(apperr.ErrForbidden, "admin accounts cannot be registered", nil)).Once()
+
+	userHandler.HandleCreateUser(w, req)
+
+	resp := w.Result()
+	defer resp.Body.Close()
+
+	require.Equal(t, 403, resp.StatusCode, "expected status code 403 Forbidden")
+	mockUserService.AssertExpectations(t)
+}
+
 func Test_handlers_HandleGetUserById_user_exists(t *testing.T) {
 	user := domain.User{
 		ID:       1,
@@ -5,6 +5,8 @@ import (
 	"io"
 	"net/http"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/mohits-git/food-ordering-system/internal/adapters/http/dtos"
 	"github.com/mohits-git/food-ordering-system/internal/utils/apperr"
@@ -74,3 +76,52 @@ func getIdFromPath(r *http.Request, key string) int {
 	}
 	return id
 }
+
+// getListFromQuery collects the values of a repeated or comma separated query parameter.
+func getListFromQuery(r *http.Request, key string) []string {
+	values := []string{}
+	for _, param := range r.URL.Query()[key] {
+		for _, value := range strings.Split(param, ",") {
+			value = strings.TrimSpace(value)
+			if value != "" {
+				values = append(values, value)
+			}
+		}
+	}
+	return values
+}
+
+// getIntFromQuery parses an optional non-negative integer query parameter,
+// returning 0 when it is missing.
+func getIntFromQuery(r *http.Request, key string) (int, error) {
+	param := r.URL.Query().Get(key)
+	if param == "" {
+		return 0, nil
+	}
+	value, err := strconv.Atoi(param)
+	if err != nil || value < 0 {
+		return 0, apperr.NewAppError(apperr.ErrInvalid, "invalid "+key, err)
+	}
+	return value, nil
+}
+
+// getTimeFromQuery parses an optional RFC3339 or YYYY-MM-DD query parameter.
+// With endOfDay a plain date is moved to the start of the next day so it can
+// be used as an exclusive upper bound.
+func getTimeFromQuery(r *http.Request, key string, endOfDay bool) (time.Time, error) {
+	param := r.URL.Query().Get(key)
+	if param == "" {
+		return time.Time{}, nil
+	}
+	if date, err := time.Parse(time.DateOnly, param); err == nil {
+		if endOfDay {
+			date = date.AddDate(0, 0, 1)
+		}
+		return date, nil
+	}
+	value, err := time.Parse(time.RFC3339, param)
+	if err != nil {
+		return time.Time{}, apperr.NewAppError(apperr.ErrInvalid, "invalid "+key, err)
+	}
+	return value, nil
+}
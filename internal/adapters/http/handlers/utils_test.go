@@ -7,6 +7,7 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/mohits-git/food-ordering-system/internal/adapters/http/dtos"
 	"github.com/stretchr/testify/require"
@@ -155,3 +156,54 @@ func Test_getIdFromPath_Invalid(t *testing.T) {
 	id = getIdFromPath(req, "id")
 	require.Equal(t, 0, id, "expected id to be 0 for missing id")
 }
+
+func Test_handlers_getListFromQuery(t *testing.T) {
+	req := httptest.NewRequest("GET", "/api/restaurants/1/orders?status=placed,accepted&status=ready&status=", nil)
+	values := getListFromQuery(req, "status")
+	require.Equal(t, []string{"placed", "accepted", "ready"}, values, "expected repeated and comma separated values")
+
+	req = httptest.NewRequest("GET", "/api/restaurants/1/orders", nil)
+	values = getListFromQuery(req, "status")
+	require.Empty(t, values, "expected no values when query param is missing")
+}
+
+func Test_handlers_getIntFromQuery(t *testing.T) {
+	req := httptest.NewRequest("GET", "/api/orders?limit=10&cursor=abc&offset=-1", nil)
+
+	value, err := getIntFromQuery(req, "limit")
+	require.NoError(t, err, "expected no error for a valid integer")
+	require.Equal(t, 10, value, "expected limit to be 10")
+
+	value, err = getIntFromQuery(req, "missing")
+	require.NoError(t, err, "expected no error for a missing param")
+	require.Equal(t, 0, value, "expected missing param to be 0")
+
+	_, err = getIntFromQuery(req, "cursor")
+	require.Error(t, err, "expected error for a non integer param")
+
+	_, err = getIntFromQuery(req, "offset")
+	require.Error(t, err, "expected error for a negative param")
+}
+
+func Test_handlers_getTimeFromQuery(t *testing.T) {
+	req := httptest.NewRequest("GET", "/api/orders?from=2025-01-01&to=2025-01-31&at=2025-01-02T10:00:00Z&bad=tomorrow", nil)
+
+	from, err := getTimeFromQuery(req, "from", false)
+	require.NoError(t, err, "expected no error for a date")
+	require.Equal(t, time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC), from)
+
+	to, err := getTimeFromQuery(req, "to", true)
+	require.NoError(t, err, "expected no error for a date")
+	require.Equal(t, time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC), to, "expected end of day to move to the next day")
+
+	at, err := getTimeFromQuery(req, "at", true)
+	require.NoError(t, err, "expected no error for a RFC3339 time")
+	require.Equal(t, time.Date(2025, 1, 2, 10, 0, 0, 0, time.UTC), at)
+
+	missing, err := getTimeFromQuery(req, "missing", false)
+	require.NoError(t, err, "expected no error for a missing param")
+	require.True(t, missing.IsZero(), "expected zero time for a missing param")
+
+	_, err = getTimeFromQuery(req, "bad", false)
+	require.Error(t, err, "expected error for an invalid time")
+}
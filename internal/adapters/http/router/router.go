@@ -8,12 +8,15 @@ import (
 
 func NewRouter(
 	authMiddleware *handlers.AuthMiddleware,
+	idempotencyMiddleware *handlers.IdempotencyMiddleware,
 	userHandler *handlers.UserHandler,
 	authHandler *handlers.AuthHandler,
 	restaurantHandler *handlers.RestaurantHandler,
 	menuItemHandler *handlers.MenuItemHandler,
 	orderHandler *handlers.OrdersHandler,
 	invoiceHandler *handlers.InvoiceHandler,
+	paymentWebhookHandler *handlers.PaymentWebhookHandler,
+	adminHandler *handlers.AdminHandler,
 ) http.Handler {
 	mux := http.NewServeMux()
 
@@ -28,26 +31,72 @@ func NewRouter(
 
 	// auth routes
 	mux.HandleFunc("POST /api/auth/login", authHandler.HandleLogin)
+	mux.HandleFunc("POST /api/auth/refresh", authHandler.HandleRefresh)
 	mux.HandleFunc("POST /api/auth/logout", authMiddleware.WithToken(authHandler.HandleLogout))
 
 	// restaurants routes
 	mux.HandleFunc("GET /api/restaurants", restaurantHandler.HandleGetRestaurants)
 	mux.HandleFunc("POST /api/restaurants", authMiddleware.Authenticated(restaurantHandler.HandleCreateRestaurant))
+	mux.HandleFunc("GET /api/restaurants/{id}", restaurantHandler.HandleGetRestaurant)
+	mux.HandleFunc("PUT /api/restaurants/{id}", authMiddleware.Authenticated(restaurantHandler.HandleUpdateRestaurant))
+	mux.HandleFunc("PATCH /api/restaurants/{id}/pause", authMiddleware.Authenticated(restaurantHandler.HandlePauseOrders))
+	mux.HandleFunc("GET /api/restaurants/{id}/members", authMiddleware.Authenticated(restaurantHandler.HandleGetMembers))
+	mux.HandleFunc("POST /api/restaurants/{id}/members", authMiddleware.Authenticated(restaurantHandler.HandleInviteMember))
+	mux.HandleFunc("DELETE /api/restaurants/{id}/members/{userId}", authMiddleware.Authenticated(restaurantHandler.HandleRemoveMember))
+	mux.HandleFunc("PUT /api/restaurants/{id}/tax-rules", authMiddleware.Authenticated(restaurantHandler.HandleSetTaxRule))
+	mux.HandleFunc("DELETE /api/restaurants/{id}/tax-rules", authMiddleware.Authenticated(restaurantHandler.HandleDeleteTaxRule))
+	mux.HandleFunc("GET /api/staff/restaurants", authMiddleware.Authenticated(restaurantHandler.HandleGetMemberships))
 
 	// menu items routes
 	mux.HandleFunc("GET /api/restaurants/{id}/items", menuItemHandler.HandleGetRestaurantMenuItems)
 	mux.HandleFunc("POST /api/restaurants/{id}/items", authMiddleware.Authenticated(menuItemHandler.HandleAddMenuItemToRestaurant))
 	mux.HandleFunc("PATCH /api/items/{id}", authMiddleware.Authenticated(menuItemHandler.HandleUpdateAvailability))
+	mux.HandleFunc("PATCH /api/items/{id}/stock", authMiddleware.Authenticated(menuItemHandler.HandleUpdateStock))
+	mux.HandleFunc("PUT /api/items/{id}", authMiddleware.Authenticated(menuItemHandler.HandleUpdateMenuItem))
+	mux.HandleFunc("DELETE /api/items/{id}", authMiddleware.Authenticated(menuItemHandler.HandleDeleteMenuItem))
+
+	// menus routes
+	mux.HandleFunc("GET /api/restaurants/{id}/menus", menuItemHandler.HandleGetRestaurantMenus)
+	mux.HandleFunc("POST /api/restaurants/{id}/menus", authMiddleware.Authenticated(menuItemHandler.HandleAddMenu))
+	mux.HandleFunc("PUT /api/menus/{id}", authMiddleware.Authenticated(menuItemHandler.HandleUpdateMenu))
+	mux.HandleFunc("DELETE /api/menus/{id}", authMiddleware.Authenticated(menuItemHandler.HandleDeleteMenu))
 
 	// orders routes
-	mux.HandleFunc("POST /api/orders", authMiddleware.Authenticated(orderHandler.HandleCreateOrder))
+	mux.HandleFunc("GET /api/orders", authMiddleware.Authenticated(orderHandler.HandleGetCustomerOrders))
+	mux.HandleFunc("POST /api/orders", authMiddleware.Authenticated(idempotencyMiddleware.Idempotent(orderHandler.HandleCreateOrder)))
 	mux.HandleFunc("GET /api/orders/{id}", authMiddleware.Authenticated(orderHandler.HandleGetOrderById))
 	mux.HandleFunc("POST /api/orders/{id}/items", authMiddleware.Authenticated(orderHandler.HandleAddOrderItem))
+	mux.HandleFunc("PATCH /api/orders/{id}/items/{menuItemId}", authMiddleware.Authenticated(orderHandler.HandleUpdateOrderItem))
+	mux.HandleFunc("DELETE /api/orders/{id}/items/{menuItemId}", authMiddleware.Authenticated(orderHandler.HandleRemoveOrderItem))
+	mux.HandleFunc("PATCH /api/orders/{id}/lines/{lineId}", authMiddleware.Authenticated(orderHandler.HandleUpdateOrderLine))
+	mux.HandleFunc("DELETE /api/orders/{id}/lines/{lineId}", authMiddleware.Authenticated(orderHandler.HandleRemoveOrderLine))
+	mux.HandleFunc("POST /api/orders/{id}/accept", authMiddleware.Authenticated(orderHandler.HandleAcceptOrder))
+	mux.HandleFunc("POST /api/orders/{id}/reject", authMiddleware.Authenticated(orderHandler.HandleRejectOrder))
+	mux.HandleFunc("POST /api/orders/{id}/preparing", authMiddleware.Authenticated(orderHandler.HandleMarkOrderPreparing))
+	mux.HandleFunc("POST /api/orders/{id}/ready", authMiddleware.Authenticated(orderHandler.HandleMarkOrderReady))
+	mux.HandleFunc("POST /api/orders/{id}/delivered", authMiddleware.Authenticated(orderHandler.HandleMarkOrderDelivered))
+	mux.HandleFunc("POST /api/orders/{id}/cancel", authMiddleware.Authenticated(orderHandler.HandleCancelOrder))
+	mux.HandleFunc("GET /api/restaurants/{id}/orders", authMiddleware.Authenticated(orderHandler.HandleGetRestaurantOrders))
 
 	// invoice routes
 	mux.HandleFunc("GET /api/invoices/{id}", authMiddleware.Authenticated(invoiceHandler.HandleGetInvoice))
 	mux.HandleFunc("POST /api/orders/{id}/invoices", authMiddleware.Authenticated(invoiceHandler.HandleCreateInvoice))
-	mux.HandleFunc("POST /api/invoices/{id}/pay", authMiddleware.Authenticated(invoiceHandler.HandleInvoicePayment))
+	mux.HandleFunc("POST /api/invoices/{id}/pay", authMiddleware.Authenticated(idempotencyMiddleware.Idempotent(invoiceHandler.HandleInvoicePayment)))
+	mux.HandleFunc("POST /api/invoices/{id}/refunds", authMiddleware.Authenticated(invoiceHandler.HandleRefundInvoice))
+
+	// payment provider callbacks, authenticated by their signature
+	mux.HandleFunc("POST /api/payments/webhook", paymentWebhookHandler.HandlePaymentWebhook)
+
+	// admin routes
+	mux.HandleFunc("GET /api/admin/users", authMiddleware.Authenticated(adminHandler.HandleGetUsers))
+	mux.HandleFunc("POST /api/admin/users", authMiddleware.Authenticated(adminHandler.HandleCreateUser))
+	mux.HandleFunc("POST /api/admin/users/{id}/approve", authMiddleware.Authenticated(adminHandler.HandleApproveUser))
+	mux.HandleFunc("PATCH /api/admin/users/{id}/role", authMiddleware.Authenticated(adminHandler.HandleUpdateUserRole))
+	mux.HandleFunc("POST /api/admin/users/{id}/suspend", authMiddleware.Authenticated(adminHandler.HandleSuspendUser))
+	mux.HandleFunc("POST /api/admin/users/{id}/reinstate", authMiddleware.Authenticated(adminHandler.HandleReinstateUser))
+	mux.HandleFunc("GET /api/admin/orders", authMiddleware.Authenticated(adminHandler.HandleGetOrders))
+	mux.HandleFunc("POST /api/admin/orders/{id}/cancel", authMiddleware.Authenticated(orderHandler.HandleCancelOrder))
+	mux.HandleFunc("GET /api/admin/invoices", authMiddleware.Authenticated(adminHandler.HandleGetInvoices))
 
 	return mux
 }
@@ -11,13 +11,16 @@ import (
 
 func Test_router_NewRouter(t *testing.T) {
 	router := NewRouter(
-		handlers.NewAuthMiddleware(nil),
+		handlers.NewAuthMiddleware(nil, nil),
+		handlers.NewIdempotencyMiddleware(nil),
 		handlers.NewUserHandler(nil),
 		handlers.NewAuthHandler(nil),
 		handlers.NewRestaurantHandler(nil),
 		handlers.NewMenuItemHandler(nil),
 		handlers.NewOrdersHandler(nil),
 		handlers.NewInvoiceHandler(nil),
+		handlers.NewPaymentWebhookHandler(nil),
+		handlers.NewAdminHandler(nil, nil, nil),
 	)
 	require.NotNil(t, router, "expected NewRouter to return a non-nil router")
 
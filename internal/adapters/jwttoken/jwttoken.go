@@ -1,6 +1,8 @@
 package jwttoken
 
 import (
+	"crypto/rand"
+	"encoding/hex"
 	"time"
 
 	jwt "github.com/golang-jwt/jwt/v5"
@@ -13,25 +15,39 @@ type JWTService struct {
 	secretKey string
 	issuer    string
 	audience  string
+	ttl       time.Duration
 }
 
-func NewJWTService(secretKey, issuer, audience string) *JWTService {
+func NewJWTService(secretKey, issuer, audience string, ttl time.Duration) *JWTService {
 	return &JWTService{
 		secretKey: secretKey,
 		issuer:    issuer,
 		audience:  audience,
+		ttl:       ttl,
 	}
 }
 
+// GenerateToken issues an access token valid for the service ttl. Every token
+// gets its own id so it can be revoked before it expires.
 func (s *JWTService) GenerateToken(claims authctx.UserClaims) (string, error) {
+	tokenId := make([]byte, 16)
+	if _, err := rand.Read(tokenId); err != nil {
+		return "", apperr.NewAppError(apperr.ErrInternal, "failed to generate token id", err)
+	}
+
+	now := time.Now()
 	jwtClaims := jwt.MapClaims{
 		"iss":     s.issuer,
 		"aud":     s.audience,
-		"exp":     time.Now().Add(time.Hour * 24).Unix(),
-		"iat":     time.Now().Unix(),
+		"exp":     now.Add(s.ttl).Unix(),
+		"iat":     now.Unix(),
+		"jti":     hex.EncodeToString(tokenId),
 		"user_id": claims.UserID,
 		"role":    claims.Role,
 	}
+	if claims.SessionID != "" {
+		jwtClaims["sid"] = claims.SessionID
+	}
 
 	token := jwt.NewWithClaims(jwt.SigningMethodHS256, jwtClaims)
 	return token.SignedString([]byte(s.secretKey))
@@ -71,8 +87,19 @@ func (s *JWTService) ExtractClaims(claims jwt.MapClaims) (authctx.UserClaims, er
 		return authctx.UserClaims{}, apperr.NewAppError(apperr.ErrUnauthorized, "invalid role in token claims", jwt.ErrTokenInvalidClaims)
 	}
 
+	// tokens issued before revocation have no id or session
+	tokenId, _ := claims["jti"].(string)
+	sessionId, _ := claims["sid"].(string)
+	var expiresAt time.Time
+	if exp, err := claims.GetExpirationTime(); err == nil && exp != nil {
+		expiresAt = exp.Time
+	}
+
 	return authctx.UserClaims{
-		UserID: int(userID),
-		Role:   domain.UserRole(role),
+		UserID:    int(userID),
+		Role:      domain.UserRole(role),
+		TokenID:   tokenId,
+		SessionID: sessionId,
+		ExpiresAt: expiresAt,
 	}, nil
 }
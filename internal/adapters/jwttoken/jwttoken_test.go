@@ -12,7 +12,7 @@ import (
 )
 
 func Test_jwttoken_NewJWTService(t *testing.T) {
-	jwtService := NewJWTService("mysecretkey", "myissuer", "myaudience")
+	jwtService := NewJWTService("mysecretkey", "myissuer", "myaudience", time.Hour)
 	if jwtService.secretKey != "mysecretkey" {
 		t.Errorf("expected secretKey to be 'mysecretkey', got %s", jwtService.secretKey)
 	}
@@ -25,7 +25,7 @@ func Test_jwttoken_NewJWTService(t *testing.T) {
 }
 
 func Test_jwttoken_GenerateToken(t *testing.T) {
-	jwtService := NewJWTService("mysecretkey", "myissuer", "myaudience")
+	jwtService := NewJWTService("mysecretkey", "myissuer", "myaudience", time.Hour)
 	claims := authctx.UserClaims{
 		UserID: 1,
 		Role:   domain.CUSTOMER,
@@ -41,7 +41,7 @@ func Test_jwttoken_GenerateToken(t *testing.T) {
 }
 
 func Test_jwttoken_ValidateToken_when_valid(t *testing.T) {
-	jwtService := NewJWTService("mysecretkey", "myissuer", "myaudience")
+	jwtService := NewJWTService("mysecretkey", "myissuer", "myaudience", time.Hour)
 	claims := authctx.UserClaims{
 		UserID: 1,
 		Role:   domain.CUSTOMER,
@@ -62,11 +62,11 @@ func Test_jwttoken_ValidateToken_when_signed_with_invalid_secret(t *testing.T) {
 		Role:   domain.CUSTOMER,
 	}
 
-	jwtService := NewJWTService("mysecretkey", "myissuer", "myaudience")
+	jwtService := NewJWTService("mysecretkey", "myissuer", "myaudience", time.Hour)
 	token, err := jwtService.GenerateToken(claims)
 	require.NoErrorf(t, err, "expected no error generating token, got %v", err)
 
-	anotherJWTService := NewJWTService("anothersecretkey", "myissuer", "myaudience")
+	anotherJWTService := NewJWTService("anothersecretkey", "myissuer", "myaudience", time.Hour)
 
 	_, err = anotherJWTService.ValidateToken(token)
 	require.Errorf(t, err, "expected error while validating token, got %v", err)
@@ -78,22 +78,22 @@ func Test_jwttoken_ValidateToken_when_signed_with_invalid_iss_or_aud(t *testing.
 		Role:   domain.CUSTOMER,
 	}
 
-	jwtService := NewJWTService("mysecretkey", "myissuer", "myaudience")
+	jwtService := NewJWTService("mysecretkey", "myissuer", "myaudience", time.Hour)
 	token, err := jwtService.GenerateToken(claims)
 	require.NoErrorf(t, err, "expected no error generating token, got %v", err)
 
-	anotherJWTService := NewJWTService("mysecretkey", "anotherissuer", "myaudience")
+	anotherJWTService := NewJWTService("mysecretkey", "anotherissuer", "myaudience", time.Hour)
 
 	_, err = anotherJWTService.ValidateToken(token)
 	require.Errorf(t, err, "expected error while validating token, got %v", err)
 
-	anotherJWTService = NewJWTService("mysecretkey", "myissuer", "anotheraudience")
+	anotherJWTService = NewJWTService("mysecretkey", "myissuer", "anotheraudience", time.Hour)
 	_, err = anotherJWTService.ValidateToken(token)
 	assert.Errorf(t, err, "expected error while validating token, got %v", err)
 }
 
 func Test_jwttoken_ValidateToken_when_invalid_user_claims(t *testing.T) {
-	jwtService := NewJWTService("mysecretkey", "myissuer", "myaudience")
+	jwtService := NewJWTService("mysecretkey", "myissuer", "myaudience", time.Hour)
 	jwtClaims := jwt.MapClaims{
 		"iss":     jwtService.issuer,
 		"aud":     jwtService.audience,
@@ -117,3 +117,36 @@ func Test_jwttoken_ValidateToken_when_invalid_user_claims(t *testing.T) {
 	_, err = jwtService.ValidateToken(tokenString)
 	require.Error(t, err, "expected error while validating token with invalid role claims")
 }
+
+func Test_jwttoken_ValidateToken_returns_token_and_session_id(t *testing.T) {
+	jwtService := NewJWTService("mysecretkey", "myissuer", "myaudience", 15*time.Minute)
+	claims := authctx.UserClaims{
+		UserID:    1,
+		Role:      domain.CUSTOMER,
+		SessionID: "session-1",
+	}
+
+	first, err := jwtService.GenerateToken(claims)
+	require.NoError(t, err)
+	second, err := jwtService.GenerateToken(claims)
+	require.NoError(t, err)
+
+	firstClaims, err := jwtService.ValidateToken(first)
+	require.NoError(t, err)
+	secondClaims, err := jwtService.ValidateToken(second)
+	require.NoError(t, err)
+
+	assert.Equal(t, "session-1", firstClaims.SessionID)
+	assert.NotEmpty(t, firstClaims.TokenID, "expected the token to have an id")
+	assert.NotEqual(t, firstClaims.TokenID, secondClaims.TokenID, "expected every token to get its own id")
+	assert.WithinDuration(t, time.Now().Add(15*time.Minute), firstClaims.ExpiresAt, 5*time.Second)
+}
+
+func Test_jwttoken_ValidateToken_when_expired(t *testing.T) {
+	jwtService := NewJWTService("mysecretkey", "myissuer", "myaudience", -time.Minute)
+	token, err := jwtService.GenerateToken(authctx.NewUserClaims(1, domain.CUSTOMER))
+	require.NoError(t, err)
+
+	_, err = jwtService.ValidateToken(token)
+	require.Error(t, err, "expected error while validating an expired token")
+}
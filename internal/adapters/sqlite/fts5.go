@@ -0,0 +1,7 @@
+//go:build !sqlite_fts5
+
+package sqlite
+
+// The restaurant search is an FTS5 index, which go-sqlite3 only compiles in
+// with the sqlite_fts5 build tag. Build and test with -tags sqlite_fts5.
+var _ = sqliteFTS5BuildTagRequired
@@ -0,0 +1,70 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/mohits-git/food-ordering-system/internal/domain"
+)
+
+type IdempotencyRepository struct {
+	db *sql.DB
+}
+
+func NewIdempotencyRepository(db *sql.DB) *IdempotencyRepository {
+	return &IdempotencyRepository{db: db}
+}
+
+func (r *IdempotencyRepository) SaveIdempotencyKey(ctx context.Context, key domain.IdempotencyKey) (int, error) {
+	query := `INSERT INTO idempotency_keys (user_id, key, request_hash, expires_at) VALUES (?, ?, ?, ?) RETURNING id`
+	var id int
+	err := r.db.QueryRowContext(ctx, query, key.UserID, key.Key, key.RequestHash,
+		key.ExpiresAt.UTC().Format(timestampLayout)).Scan(&id)
+	if err != nil {
+		return 0, HandleSQLiteError(err)
+	}
+	return id, nil
+}
+
+func (r *IdempotencyRepository) FindIdempotencyKey(ctx context.Context, userId int, key string) (domain.IdempotencyKey, error) {
+	query := `SELECT id, user_id, key, request_hash, status_code, response_body, created_at, expires_at FROM idempotency_keys WHERE user_id = ? AND key = ?`
+	var stored domain.IdempotencyKey
+	err := r.db.QueryRowContext(ctx, query, userId, key).Scan(&stored.ID, &stored.UserID, &stored.Key, &stored.RequestHash,
+		&stored.StatusCode, &stored.ResponseBody, &stored.CreatedAt, &stored.ExpiresAt)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return domain.IdempotencyKey{}, nil
+		}
+		return domain.IdempotencyKey{}, HandleSQLiteError(err)
+	}
+	return stored, nil
+}
+
+func (r *IdempotencyRepository) SaveIdempotencyResponse(ctx context.Context, id int, statusCode int, body []byte) error {
+	query := `UPDATE idempotency_keys SET status_code = ?, response_body = ? WHERE id = ?`
+	_, err := r.db.ExecContext(ctx, query, statusCode, body, id)
+	if err != nil {
+		return HandleSQLiteError(err)
+	}
+	return nil
+}
+
+func (r *IdempotencyRepository) DeleteIdempotencyKey(ctx context.Context, id int) error {
+	query := `DELETE FROM idempotency_keys WHERE id = ?`
+	_, err := r.db.ExecContext(ctx, query, id)
+	if err != nil {
+		return HandleSQLiteError(err)
+	}
+	return nil
+}
+
+func (r *IdempotencyRepository) DeleteExpiredIdempotencyKeys(ctx context.Context, now time.Time) error {
+	query := `DELETE FROM idempotency_keys WHERE expires_at <= ?`
+	_, err := r.db.ExecContext(ctx, query, now.UTC().Format(timestampLayout))
+	if err != nil {
+		return HandleSQLiteError(err)
+	}
+	return nil
+}
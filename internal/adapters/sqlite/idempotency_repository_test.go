@@ -0,0 +1,131 @@
+package sqlite
+
+import (
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/mattn/go-sqlite3"
+	"github.com/mohits-git/food-ordering-system/internal/domain"
+	"github.com/mohits-git/food-ordering-system/internal/utils/apperr"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_sqlite_IdempotencyRepository_SaveIdempotencyKey(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err, "Expected no error when creating sqlmock")
+	defer db.Close()
+
+	repo := NewIdempotencyRepository(db)
+	require.NotNil(t, repo, "Expected NewIdempotencyRepository to return a non-nil repository")
+
+	expiresAt := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+	key := domain.NewIdempotencyKey(1, "key-1", "hash", expiresAt)
+	mock.ExpectQuery("INSERT INTO idempotency_keys").
+		WithArgs(1, "key-1", "hash", "2025-01-02 03:04:05").
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+	mock.ExpectQuery("INSERT INTO idempotency_keys").
+		WithArgs(1, "key-1", "hash", "2025-01-02 03:04:05").
+		WillReturnError(sqlite3.Error{Code: sqlite3.ErrConstraint, ExtendedCode: sqlite3.ErrConstraintUnique})
+
+	id, err := repo.SaveIdempotencyKey(t.Context(), key)
+	require.NoError(t, err)
+	require.Equal(t, 1, id)
+
+	_, err = repo.SaveIdempotencyKey(t.Context(), key)
+	require.True(t, apperr.IsConflictError(err), "expected the same key to conflict")
+
+	err = mock.ExpectationsWereMet()
+	require.NoError(t, err, "There were unfulfilled expectations")
+}
+
+func Test_sqlite_IdempotencyRepository_FindIdempotencyKey(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err, "Expected no error when creating sqlmock")
+	defer db.Close()
+
+	repo := NewIdempotencyRepository(db)
+
+	createdAt := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+	expiresAt := createdAt.Add(24 * time.Hour)
+	columns := []string{"id", "user_id", "key", "request_hash", "status_code", "response_body", "created_at", "expires_at"}
+	mock.ExpectQuery("SELECT (.+) FROM idempotency_keys WHERE user_id = \\? AND key = \\?").
+		WithArgs(1, "key-1").
+		WillReturnRows(sqlmock.NewRows(columns).AddRow(3, 1, "key-1", "hash", 201, []byte(`{"status":201}`), createdAt, expiresAt))
+	mock.ExpectQuery("SELECT (.+) FROM idempotency_keys WHERE user_id = \\? AND key = \\?").
+		WithArgs(1, "missing").
+		WillReturnRows(sqlmock.NewRows(columns))
+
+	key, err := repo.FindIdempotencyKey(t.Context(), 1, "key-1")
+	require.NoError(t, err)
+	require.Equal(t, domain.IdempotencyKey{ID: 3, UserID: 1, Key: "key-1", RequestHash: "hash", StatusCode: 201,
+		ResponseBody: []byte(`{"status":201}`), CreatedAt: createdAt, ExpiresAt: expiresAt}, key)
+
+	key, err = repo.FindIdempotencyKey(t.Context(), 1, "missing")
+	require.NoError(t, err)
+	require.Zero(t, key.ID, "expected a zero key when it is not found")
+
+	err = mock.ExpectationsWereMet()
+	require.NoError(t, err, "There were unfulfilled expectations")
+}
+
+func Test_sqlite_IdempotencyRepository_SaveIdempotencyResponse(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err, "Expected no error when creating sqlmock")
+	defer db.Close()
+
+	repo := NewIdempotencyRepository(db)
+
+	mock.ExpectExec("UPDATE idempotency_keys SET status_code = \\?, response_body = \\? WHERE id = \\?").
+		WithArgs(201, []byte("body"), 3).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	err = repo.SaveIdempotencyResponse(t.Context(), 3, 201, []byte("body"))
+	require.NoError(t, err)
+
+	err = mock.ExpectationsWereMet()
+	require.NoError(t, err, "There were unfulfilled expectations")
+}
+
+func Test_sqlite_IdempotencyRepository_DeleteIdempotencyKey(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err, "Expected no error when creating sqlmock")
+	defer db.Close()
+
+	repo := NewIdempotencyRepository(db)
+
+	mock.ExpectExec("DELETE FROM idempotency_keys WHERE id = \\?").
+		WithArgs(3).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	err = repo.DeleteIdempotencyKey(t.Context(), 3)
+	require.NoError(t, err)
+
+	err = mock.ExpectationsWereMet()
+	require.NoError(t, err, "There were unfulfilled expectations")
+}
+
+func Test_sqlite_IdempotencyRepository_DeleteExpiredIdempotencyKeys(t *testing.T) {
+	db := openMemoryDB(t)
+	require.NoError(t, Migrate(db))
+	_, err := db.Exec(`INSERT INTO users (id, name, email, password, role) VALUES (1, 'John', 'john@example.com', 'hash', 'CUSTOMER')`)
+	require.NoError(t, err)
+
+	repo := NewIdempotencyRepository(db)
+	now := time.Now()
+	_, err = repo.SaveIdempotencyKey(t.Context(), domain.NewIdempotencyKey(1, "expired", "hash", now.Add(-time.Minute)))
+	require.NoError(t, err)
+	_, err = repo.SaveIdempotencyKey(t.Context(), domain.NewIdempotencyKey(1, "live", "hash", now.Add(time.Hour)))
+	require.NoError(t, err)
+
+	err = repo.DeleteExpiredIdempotencyKeys(t.Context(), now)
+	require.NoError(t, err)
+
+	expired, err := repo.FindIdempotencyKey(t.Context(), 1, "expired")
+	require.NoError(t, err)
+	require.Zero(t, expired.ID, "expected the expired key to be deleted")
+	live, err := repo.FindIdempotencyKey(t.Context(), 1, "live")
+	require.NoError(t, err)
+	require.NotZero(t, live.ID, "expected the live key to be kept")
+	require.False(t, live.IsCompleted())
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"database/sql"
 	"errors"
+	"strings"
 
 	"github.com/mohits-git/food-ordering-system/internal/domain"
 )
@@ -17,34 +18,85 @@ func NewInvoiceRepository(db *sql.DB) *InvoiceRepository {
 }
 
 func (r *InvoiceRepository) SaveInvoice(cxt context.Context, invoice domain.Invoice) (int, error) {
-	query := `INSERT INTO invoices (order_id, total, tax, payment_status) VALUES (?, ?, ?, ?) RETURNING id`
+	tx, err := r.db.BeginTx(cxt, nil)
+	if err != nil {
+		return 0, HandleSQLiteError(err)
+	}
+
+	query := `INSERT INTO invoices (order_id, total, tax, currency, payment_status) VALUES (?, ?, ?, ?, ?) RETURNING id`
 	var id int
-	total := int(invoice.Total * 100)
-	tax := int(invoice.Tax * 100)
-	err := r.db.QueryRowContext(cxt, query, invoice.OrderID, total, tax, invoice.PaymentStatus).Scan(&id)
+	err = tx.QueryRowContext(cxt, query, invoice.OrderID, invoice.Total.Amount, invoice.Tax.Amount, invoice.Total.Currency, invoice.PaymentStatus).Scan(&id)
 	if err != nil {
+		tx.Rollback()
+		return 0, HandleSQLiteError(err)
+	}
+
+	// save invoice line items
+	itemQuery := `INSERT INTO invoice_items (invoice_id, menuitem_id, name, unit_price, quantity, total, tax) VALUES (?, ?, ?, ?, ?, ?, ?)`
+	for _, item := range invoice.Items {
+		_, err := tx.ExecContext(cxt, itemQuery, id, item.MenuItemID, item.Name,
+			item.UnitPrice.Amount, item.Quantity, item.Total.Amount, item.Tax.Amount)
+		if err != nil {
+			tx.Rollback()
+			return 0, HandleSQLiteError(err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
 		return 0, HandleSQLiteError(err)
 	}
 	return id, nil
 }
 
 func (r *InvoiceRepository) FindInvoiceById(cxt context.Context, id int) (domain.Invoice, error) {
-	query := `SELECT id, order_id, total, tax, payment_status FROM invoices WHERE id = ?`
+	query := `SELECT id, order_id, total, tax, currency, payment_status FROM invoices WHERE id = ?`
 	var invoice domain.Invoice
-	var total int
-	var tax int
-	err := r.db.QueryRowContext(cxt, query, id).Scan(&invoice.ID, &invoice.OrderID, &total, &tax, &invoice.PaymentStatus)
+	var currency string
+	err := r.db.QueryRowContext(cxt, query, id).Scan(&invoice.ID, &invoice.OrderID, &invoice.Total.Amount, &invoice.Tax.Amount, &currency, &invoice.PaymentStatus)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return domain.Invoice{}, nil
 		}
 		return domain.Invoice{}, HandleSQLiteError(err)
 	}
-	invoice.Total = float64(total) / 100
-	invoice.Tax = float64(tax) / 100
+	invoice.Total.Currency = currency
+	invoice.Tax.Currency = currency
+
+	invoice.Items, err = r.findInvoiceItems(cxt, invoice.ID, currency)
+	if err != nil {
+		return domain.Invoice{}, err
+	}
 	return invoice, nil
 }
 
+// findInvoiceItems loads the lines of an invoice, they are in the currency of
+// the invoice.
+func (r *InvoiceRepository) findInvoiceItems(ctx context.Context, invoiceId int, currency string) ([]domain.InvoiceItem, error) {
+	query := `SELECT menuitem_id, name, unit_price, quantity, total, tax FROM invoice_items WHERE invoice_id = ? ORDER BY id`
+	rows, err := r.db.QueryContext(ctx, query, invoiceId)
+	if err != nil {
+		return nil, HandleSQLiteError(err)
+	}
+	defer rows.Close()
+
+	items := []domain.InvoiceItem{}
+	for rows.Next() {
+		var item domain.InvoiceItem
+		var unitPrice, total, tax int64
+		if err := rows.Scan(&item.MenuItemID, &item.Name, &unitPrice, &item.Quantity, &total, &tax); err != nil {
+			return nil, HandleSQLiteError(err)
+		}
+		item.UnitPrice = domain.NewMoney(unitPrice, currency)
+		item.Total = domain.NewMoney(total, currency)
+		item.Tax = domain.NewMoney(tax, currency)
+		items = append(items, item)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, HandleSQLiteError(err)
+	}
+	return items, nil
+}
+
 func (r *InvoiceRepository) ChangeInvoiceStatus(cxt context.Context, invoiceId int, status domain.PaymentStatus) error {
 	query := `UPDATE invoices SET payment_status = ? WHERE id = ?`
 	_, err := r.db.ExecContext(cxt, query, status, invoiceId)
@@ -55,8 +107,40 @@ func (r *InvoiceRepository) ChangeInvoiceStatus(cxt context.Context, invoiceId i
 }
 
 func (r *InvoiceRepository) FindInvoicesByOrderId(ctx context.Context, orderId int) ([]domain.Invoice, error) {
-	query := `SELECT id, order_id, total, tax, payment_status FROM invoices WHERE order_id = ?`
-	rows, err := r.db.QueryContext(ctx, query, orderId)
+	query := `SELECT id, order_id, total, tax, currency, payment_status FROM invoices WHERE order_id = ?`
+	return r.findInvoices(ctx, query, orderId)
+}
+
+// FindInvoices lists the invoices matching the filter, newest first. The
+// invoice lines are not loaded.
+func (r *InvoiceRepository) FindInvoices(ctx context.Context, filter domain.InvoiceFilter) ([]domain.Invoice, error) {
+	conditions := []string{}
+	args := []any{}
+	if len(filter.Statuses) > 0 {
+		conditions = append(conditions, "payment_status IN (?"+strings.Repeat(", ?", len(filter.Statuses)-1)+")")
+		for _, status := range filter.Statuses {
+			args = append(args, status)
+		}
+	}
+	if filter.Cursor > 0 {
+		conditions = append(conditions, "id < ?")
+		args = append(args, filter.Cursor)
+	}
+
+	query := `SELECT id, order_id, total, tax, currency, payment_status FROM invoices`
+	if len(conditions) > 0 {
+		query += " WHERE " + strings.Join(conditions, " AND ")
+	}
+	query += " ORDER BY id DESC"
+	if filter.Limit > 0 {
+		query += " LIMIT ?"
+		args = append(args, filter.Limit)
+	}
+	return r.findInvoices(ctx, query, args...)
+}
+
+func (r *InvoiceRepository) findInvoices(ctx context.Context, query string, args ...any) ([]domain.Invoice, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
 	if err != nil {
 		return nil, HandleSQLiteError(err)
 	}
@@ -65,14 +149,13 @@ func (r *InvoiceRepository) FindInvoicesByOrderId(ctx context.Context, orderId i
 	invoices := []domain.Invoice{}
 	for rows.Next() {
 		var invoice domain.Invoice
-		var total int
-		var tax int
-		err := rows.Scan(&invoice.ID, &invoice.OrderID, &total, &tax, &invoice.PaymentStatus)
+		var currency string
+		err := rows.Scan(&invoice.ID, &invoice.OrderID, &invoice.Total.Amount, &invoice.Tax.Amount, &currency, &invoice.PaymentStatus)
 		if err != nil {
 			return nil, HandleSQLiteError(err)
 		}
-		invoice.Total = float64(total) / 100
-		invoice.Tax = float64(tax) / 100
+		invoice.Total.Currency = currency
+		invoice.Tax.Currency = currency
 		invoices = append(invoices, invoice)
 	}
 	if err = rows.Err(); err != nil {
@@ -41,14 +41,26 @@ func Test_sqlite_InvoiceRepository_SaveInvoice(t *testing.T) {
 			name: "Successful insert",
 			invoice: domain.Invoice{
 				OrderID:       1,
-				Total:         100.00,
-				Tax:           10.00,
+				Total:         domain.NewMoney(10000, "USD"),
+				Tax:           domain.NewMoney(1000, "USD"),
 				PaymentStatus: domain.Unpaid,
+				Items: []domain.InvoiceItem{
+					{MenuItemID: 1, Name: "Burger", UnitPrice: domain.NewMoney(4000, "USD"), Quantity: 2, Total: domain.NewMoney(8000, "USD"), Tax: domain.NewMoney(800, "USD")},
+					{MenuItemID: 2, Name: "Fries", UnitPrice: domain.NewMoney(2000, "USD"), Quantity: 1, Total: domain.NewMoney(2000, "USD"), Tax: domain.NewMoney(200, "USD")},
+				},
 			},
 			mockSetup: func() {
+				mock.ExpectBegin()
 				mock.ExpectQuery("INSERT INTO invoices").
-					WithArgs(1, 10000, 1000, domain.Unpaid).
+					WithArgs(1, 10000, 1000, "USD", domain.Unpaid).
 					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+				mock.ExpectExec("INSERT INTO invoice_items").
+					WithArgs(1, 1, "Burger", 4000, 2, 8000, 800).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectExec("INSERT INTO invoice_items").
+					WithArgs(1, 2, "Fries", 2000, 1, 2000, 200).
+					WillReturnResult(sqlmock.NewResult(2, 1))
+				mock.ExpectCommit()
 			},
 			expectedID:    1,
 			expectedError: false,
@@ -57,14 +69,16 @@ func Test_sqlite_InvoiceRepository_SaveInvoice(t *testing.T) {
 			name: "Database error",
 			invoice: domain.Invoice{
 				OrderID:       1,
-				Total:         100.00,
-				Tax:           10.00,
+				Total:         domain.NewMoney(10000, "USD"),
+				Tax:           domain.NewMoney(1000, "USD"),
 				PaymentStatus: domain.Unpaid,
 			},
 			mockSetup: func() {
+				mock.ExpectBegin()
 				mock.ExpectQuery("INSERT INTO invoices").
-					WithArgs(1, 10000, 1000, domain.Unpaid).
+					WithArgs(1, 10000, 1000, "USD", domain.Unpaid).
 					WillReturnError(sqlite3.Error{Code: sqlite3.ErrConstraint, ExtendedCode: sqlite3.ErrConstraintUnique})
+				mock.ExpectRollback()
 			},
 			expectedID:       0,
 			expectedError:    true,
@@ -116,17 +130,24 @@ func Test_sqlite_InvoiceRepository_FindInvoiceById(t *testing.T) {
 			name:      "Successful fetch",
 			invoiceID: 1,
 			mockSetup: func() {
-				mock.ExpectQuery("SELECT id, order_id, total, tax, payment_status FROM invoices WHERE id = ?").
+				mock.ExpectQuery("SELECT id, order_id, total, tax, currency, payment_status FROM invoices WHERE id = ?").
 					WithArgs(1).
-					WillReturnRows(sqlmock.NewRows([]string{"id", "order_id", "total", "tax", "payment_status"}).
-						AddRow(1, 1, 10000, 1000, domain.Unpaid))
+					WillReturnRows(sqlmock.NewRows([]string{"id", "order_id", "total", "tax", "currency", "payment_status"}).
+						AddRow(1, 1, 10000, 1000, "USD", domain.Unpaid))
+				mock.ExpectQuery("SELECT menuitem_id, name, unit_price, quantity, total, tax FROM invoice_items WHERE invoice_id = ?").
+					WithArgs(1).
+					WillReturnRows(sqlmock.NewRows([]string{"menuitem_id", "name", "unit_price", "quantity", "total", "tax"}).
+						AddRow(1, "Burger", 5000, 2, 10000, 1000))
 			},
 			expectedInvoice: domain.Invoice{
 				ID:            1,
 				OrderID:       1,
-				Total:         100.00,
-				Tax:           10.00,
+				Total:         domain.NewMoney(10000, "USD"),
+				Tax:           domain.NewMoney(1000, "USD"),
 				PaymentStatus: domain.Unpaid,
+				Items: []domain.InvoiceItem{
+					{MenuItemID: 1, Name: "Burger", UnitPrice: domain.NewMoney(5000, "USD"), Quantity: 2, Total: domain.NewMoney(10000, "USD"), Tax: domain.NewMoney(1000, "USD")},
+				},
 			},
 			expectedError: false,
 		},
@@ -134,7 +155,7 @@ func Test_sqlite_InvoiceRepository_FindInvoiceById(t *testing.T) {
 			name:      "Invoice not found",
 			invoiceID: 2,
 			mockSetup: func() {
-				mock.ExpectQuery("SELECT id, order_id, total, tax, payment_status FROM invoices WHERE id = ?").
+				mock.ExpectQuery("SELECT id, order_id, total, tax, currency, payment_status FROM invoices WHERE id = ?").
 					WithArgs(2).
 					WillReturnError(sql.ErrNoRows)
 			},
@@ -145,7 +166,7 @@ func Test_sqlite_InvoiceRepository_FindInvoiceById(t *testing.T) {
 			name:      "Database error",
 			invoiceID: 3,
 			mockSetup: func() {
-				mock.ExpectQuery("SELECT id, order_id, total, tax, payment_status FROM invoices WHERE id = ?").
+				mock.ExpectQuery("SELECT id, order_id, total, tax, currency, payment_status FROM invoices WHERE id = ?").
 					WithArgs(3).
 					WillReturnError(sqlite3.Error{Code: sqlite3.ErrConstraint, ExtendedCode: sqlite3.ErrConstraintUnique})
 			},
@@ -263,25 +284,25 @@ func Test_sqlite_InvoiceRepository_FindInvoicesByOrderId(t *testing.T) {
 			name:    "Successful fetch",
 			orderID: 1,
 			mockSetup: func() {
-				mock.ExpectQuery("SELECT id, order_id, total, tax, payment_status FROM invoices WHERE order_id").
+				mock.ExpectQuery("SELECT id, order_id, total, tax, currency, payment_status FROM invoices WHERE order_id").
 					WithArgs(1).
-					WillReturnRows(sqlmock.NewRows([]string{"id", "order_id", "total", "tax", "payment_status"}).
-						AddRow(1, 1, 10000, 1000, domain.Unpaid).
-						AddRow(2, 1, 20000, 2000, domain.Paid))
+					WillReturnRows(sqlmock.NewRows([]string{"id", "order_id", "total", "tax", "currency", "payment_status"}).
+						AddRow(1, 1, 10000, 1000, "USD", domain.Unpaid).
+						AddRow(2, 1, 20000, 2000, "USD", domain.Paid))
 			},
 			expectedInvoices: []domain.Invoice{
 				{
 					ID:            1,
 					OrderID:       1,
-					Total:         100.00,
-					Tax:           10.00,
+					Total:         domain.NewMoney(10000, "USD"),
+					Tax:           domain.NewMoney(1000, "USD"),
 					PaymentStatus: domain.Unpaid,
 				},
 				{
 					ID:            2,
 					OrderID:       1,
-					Total:         200.00,
-					Tax:           20.00,
+					Total:         domain.NewMoney(20000, "USD"),
+					Tax:           domain.NewMoney(2000, "USD"),
 					PaymentStatus: domain.Paid,
 				},
 			},
@@ -291,9 +312,9 @@ func Test_sqlite_InvoiceRepository_FindInvoicesByOrderId(t *testing.T) {
 			name:    "No invoices found",
 			orderID: 2,
 			mockSetup: func() {
-				mock.ExpectQuery("SELECT id, order_id, total, tax, payment_status FROM invoices WHERE order_id").
+				mock.ExpectQuery("SELECT id, order_id, total, tax, currency, payment_status FROM invoices WHERE order_id").
 					WithArgs(2).
-					WillReturnRows(sqlmock.NewRows([]string{"id", "order_id", "total", "tax", "payment_status"}))
+					WillReturnRows(sqlmock.NewRows([]string{"id", "order_id", "total", "tax", "currency", "payment_status"}))
 			},
 			expectedInvoices: []domain.Invoice{},
 			expectedError:    false,
@@ -302,7 +323,7 @@ func Test_sqlite_InvoiceRepository_FindInvoicesByOrderId(t *testing.T) {
 			name:    "Database error",
 			orderID: 3,
 			mockSetup: func() {
-				mock.ExpectQuery("SELECT id, order_id, total, tax, payment_status FROM invoices WHERE order_id").
+				mock.ExpectQuery("SELECT id, order_id, total, tax, currency, payment_status FROM invoices WHERE order_id").
 					WithArgs(3).
 					WillReturnError(sqlite3.Error{Code: sqlite3.ErrConstraint, ExtendedCode: sqlite3.ErrConstraintUnique})
 			},
@@ -333,3 +354,71 @@ func Test_sqlite_InvoiceRepository_FindInvoicesByOrderId(t *testing.T) {
 		})
 	}
 }
+
+func Test_sqlite_InvoiceRepository_FindInvoices(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err, "Expected no error when creating sqlmock")
+	defer db.Close()
+
+	repo := NewInvoiceRepository(db)
+
+	tests := []struct {
+		name             string
+		filter           domain.InvoiceFilter
+		mockSetup        func()
+		expectedInvoices []domain.Invoice
+		expectedError    bool
+	}{
+		{
+			name:   "No filter",
+			filter: domain.InvoiceFilter{},
+			mockSetup: func() {
+				mock.ExpectQuery(`SELECT id, order_id, total, tax, currency, payment_status FROM invoices ORDER BY id DESC$`).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "order_id", "total", "tax", "currency", "payment_status"}).
+						AddRow(2, 1, 20000, 2000, "USD", domain.Paid))
+			},
+			expectedInvoices: []domain.Invoice{
+				{
+					ID:            2,
+					OrderID:       1,
+					Total:         domain.NewMoney(20000, "USD"),
+					Tax:           domain.NewMoney(2000, "USD"),
+					PaymentStatus: domain.Paid,
+				},
+			},
+		},
+		{
+			name:   "Statuses, cursor and limit",
+			filter: domain.InvoiceFilter{Statuses: []domain.PaymentStatus{domain.Unpaid, domain.Paid}, Cursor: 10, Limit: 5},
+			mockSetup: func() {
+				mock.ExpectQuery(`FROM invoices WHERE payment_status IN \(\?, \?\) AND id < \? ORDER BY id DESC LIMIT \?`).
+					WithArgs(domain.Unpaid, domain.Paid, 10, 5).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "order_id", "total", "tax", "currency", "payment_status"}))
+			},
+			expectedInvoices: []domain.Invoice{},
+		},
+		{
+			name:   "Database error",
+			filter: domain.InvoiceFilter{},
+			mockSetup: func() {
+				mock.ExpectQuery("FROM invoices").
+					WillReturnError(sqlite3.Error{Code: sqlite3.ErrBusy})
+			},
+			expectedError: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockSetup()
+
+			invoices, err := repo.FindInvoices(t.Context(), tt.filter)
+			if tt.expectedError {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, tt.expectedInvoices, invoices)
+			}
+			require.NoError(t, mock.ExpectationsWereMet(), "There were unfulfilled expectations")
+		})
+	}
+}
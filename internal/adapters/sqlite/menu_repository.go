@@ -0,0 +1,265 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/mohits-git/food-ordering-system/internal/domain"
+	"github.com/mohits-git/food-ordering-system/internal/utils/apperr"
+)
+
+type MenuRepository struct {
+	db *sql.DB
+}
+
+func NewMenuRepository(db *sql.DB) *MenuRepository {
+	return &MenuRepository{db: db}
+}
+
+func (r *MenuRepository) SaveMenu(ctx context.Context, menu domain.Menu) (int, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, HandleSQLiteError(err)
+	}
+
+	query := `INSERT INTO menus (restaurant_id, name) VALUES (?, ?) RETURNING id`
+	var id int
+	if err := tx.QueryRowContext(ctx, query, menu.RestaurantID, menu.Name).Scan(&id); err != nil {
+		tx.Rollback()
+		return 0, HandleSQLiteError(err)
+	}
+
+	if err := saveMenuDetails(ctx, tx, id, menu); err != nil {
+		tx.Rollback()
+		return 0, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, HandleSQLiteError(err)
+	}
+	return id, nil
+}
+
+// UpdateMenu replaces the schedules and categories of the menu, categories
+// passed with their id keep it.
+func (r *MenuRepository) UpdateMenu(ctx context.Context, menu domain.Menu) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return HandleSQLiteError(err)
+	}
+
+	query := `UPDATE menus SET name = ? WHERE id = ?`
+	result, err := tx.ExecContext(ctx, query, menu.Name, menu.ID)
+	if err != nil {
+		tx.Rollback()
+		return HandleSQLiteError(err)
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		tx.Rollback()
+		return HandleSQLiteError(err)
+	}
+	if rows == 0 {
+		tx.Rollback()
+		return apperr.NewAppError(apperr.ErrNotFound, "menu not found", nil)
+	}
+
+	if err := deleteMenuDetails(ctx, tx, menu.ID); err != nil {
+		tx.Rollback()
+		return err
+	}
+	if err := saveMenuDetails(ctx, tx, menu.ID, menu); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return HandleSQLiteError(err)
+	}
+	return nil
+}
+
+func (r *MenuRepository) DeleteMenu(ctx context.Context, id int) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return HandleSQLiteError(err)
+	}
+
+	if err := deleteMenuDetails(ctx, tx, id); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	query := `DELETE FROM menus WHERE id = ?`
+	result, err := tx.ExecContext(ctx, query, id)
+	if err != nil {
+		tx.Rollback()
+		return HandleSQLiteError(err)
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		tx.Rollback()
+		return HandleSQLiteError(err)
+	}
+	if rows == 0 {
+		tx.Rollback()
+		return apperr.NewAppError(apperr.ErrNotFound, "menu not found", nil)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return HandleSQLiteError(err)
+	}
+	return nil
+}
+
+// saveMenuDetails inserts the schedules and the categories of a menu, the
+// categories and their items keep the order they are passed in.
+func saveMenuDetails(ctx context.Context, tx *sql.Tx, menuId int, menu domain.Menu) error {
+	scheduleQuery := `INSERT INTO menu_schedules (menu_id, start_minute, end_minute) VALUES (?, ?, ?)`
+	for _, schedule := range menu.Schedules {
+		if _, err := tx.ExecContext(ctx, scheduleQuery, menuId, schedule.Start, schedule.End); err != nil {
+			return HandleSQLiteError(err)
+		}
+	}
+
+	categoryQuery := `INSERT INTO menu_categories (id, menu_id, name, position) VALUES (NULLIF(?, 0), ?, ?, ?) RETURNING id`
+	itemQuery := `INSERT INTO menu_category_items (category_id, menuitem_id, position) VALUES (?, ?, ?)`
+	for position, category := range menu.Categories {
+		var categoryId int
+		err := tx.QueryRowContext(ctx, categoryQuery, category.ID, menuId, category.Name, position).Scan(&categoryId)
+		if err != nil {
+			return HandleSQLiteError(err)
+		}
+		for itemPosition, itemId := range category.ItemIDs {
+			if _, err := tx.ExecContext(ctx, itemQuery, categoryId, itemId, itemPosition); err != nil {
+				return HandleSQLiteError(err)
+			}
+		}
+	}
+	return nil
+}
+
+func deleteMenuDetails(ctx context.Context, tx *sql.Tx, menuId int) error {
+	queries := []string{
+		`DELETE FROM menu_category_items WHERE category_id IN (SELECT id FROM menu_categories WHERE menu_id = ?)`,
+		`DELETE FROM menu_categories WHERE menu_id = ?`,
+		`DELETE FROM menu_schedules WHERE menu_id = ?`,
+	}
+	for _, query := range queries {
+		if _, err := tx.ExecContext(ctx, query, menuId); err != nil {
+			return HandleSQLiteError(err)
+		}
+	}
+	return nil
+}
+
+func (r *MenuRepository) FindMenuById(ctx context.Context, id int) (domain.Menu, error) {
+	query := `SELECT id, restaurant_id, name FROM menus WHERE id = ?`
+	var menu domain.Menu
+	err := r.db.QueryRowContext(ctx, query, id).Scan(&menu.ID, &menu.RestaurantID, &menu.Name)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return domain.Menu{}, apperr.NewAppError(apperr.ErrNotFound, "menu not found", nil)
+		}
+		return domain.Menu{}, HandleSQLiteError(err)
+	}
+
+	menus := []domain.Menu{menu}
+	if err := r.findMenuDetails(ctx, menus, "menu_id = ?", id); err != nil {
+		return domain.Menu{}, err
+	}
+	return menus[0], nil
+}
+
+func (r *MenuRepository) FindMenusByRestaurantId(ctx context.Context, restaurantId int) ([]domain.Menu, error) {
+	query := `SELECT id, restaurant_id, name FROM menus WHERE restaurant_id = ? ORDER BY id`
+	rows, err := r.db.QueryContext(ctx, query, restaurantId)
+	if err != nil {
+		return nil, HandleSQLiteError(err)
+	}
+	defer rows.Close()
+
+	menus := []domain.Menu{}
+	for rows.Next() {
+		var menu domain.Menu
+		if err := rows.Scan(&menu.ID, &menu.RestaurantID, &menu.Name); err != nil {
+			return nil, HandleSQLiteError(err)
+		}
+		menus = append(menus, menu)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, HandleSQLiteError(err)
+	}
+	// release the connection before querying the schedules and categories
+	rows.Close()
+
+	if len(menus) == 0 {
+		return menus, nil
+	}
+	if err := r.findMenuDetails(ctx, menus, "menu_id IN (SELECT id FROM menus WHERE restaurant_id = ?)", restaurantId); err != nil {
+		return nil, err
+	}
+	return menus, nil
+}
+
+// findMenuDetails loads the schedules and the categories of the menus, the
+// condition selects them by their menu_id.
+func (r *MenuRepository) findMenuDetails(ctx context.Context, menus []domain.Menu, condition string, args ...any) error {
+	index := make(map[int]int, len(menus))
+	for i, menu := range menus {
+		index[menu.ID] = i
+	}
+
+	scheduleQuery := `SELECT menu_id, start_minute, end_minute FROM menu_schedules WHERE ` + condition + ` ORDER BY menu_id, start_minute`
+	rows, err := r.db.QueryContext(ctx, scheduleQuery, args...)
+	if err != nil {
+		return HandleSQLiteError(err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var menuId int
+		var schedule domain.MenuSchedule
+		if err := rows.Scan(&menuId, &schedule.Start, &schedule.End); err != nil {
+			return HandleSQLiteError(err)
+		}
+		if i, ok := index[menuId]; ok {
+			menus[i].Schedules = append(menus[i].Schedules, schedule)
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return HandleSQLiteError(err)
+	}
+	rows.Close()
+
+	categoryQuery := `SELECT c.id, c.menu_id, c.name, i.menuitem_id FROM menu_categories c LEFT JOIN menu_category_items i ON i.category_id = c.id WHERE c.` + condition + ` ORDER BY c.menu_id, c.position, c.id, i.position`
+	rows, err = r.db.QueryContext(ctx, categoryQuery, args...)
+	if err != nil {
+		return HandleSQLiteError(err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var category domain.MenuCategory
+		var itemId sql.NullInt64
+		if err := rows.Scan(&category.ID, &category.MenuID, &category.Name, &itemId); err != nil {
+			return HandleSQLiteError(err)
+		}
+		i, ok := index[category.MenuID]
+		if !ok {
+			continue
+		}
+
+		categories := menus[i].Categories
+		if len(categories) == 0 || categories[len(categories)-1].ID != category.ID {
+			categories = append(categories, category)
+		}
+		if itemId.Valid {
+			last := &categories[len(categories)-1]
+			last.ItemIDs = append(last.ItemIDs, int(itemId.Int64))
+		}
+		menus[i].Categories = categories
+	}
+	if err := rows.Err(); err != nil {
+		return HandleSQLiteError(err)
+	}
+	return nil
+}
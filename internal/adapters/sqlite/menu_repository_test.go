@@ -0,0 +1,117 @@
+package sqlite
+
+import (
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/mohits-git/food-ordering-system/internal/domain"
+	"github.com/mohits-git/food-ordering-system/internal/utils/apperr"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_sqlite_MenuRepository_SaveMenu_when_error(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err, "Expected no error when creating sqlmock")
+	defer db.Close()
+
+	repo := NewMenuRepository(db)
+	require.NotNil(t, repo, "Expected NewMenuRepository to return a non-nil repository")
+
+	mock.ExpectBegin()
+	mock.ExpectQuery("INSERT INTO menus").
+		WithArgs(1, "Lunch").
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(3))
+	mock.ExpectExec("INSERT INTO menu_schedules").
+		WithArgs(3, 660, 900).
+		WillReturnError(sqlmock.ErrCancelled)
+	mock.ExpectRollback()
+
+	id, err := repo.SaveMenu(t.Context(), domain.Menu{RestaurantID: 1, Name: "Lunch", Schedules: []domain.MenuSchedule{{Start: 660, End: 900}}})
+	require.Error(t, err)
+	require.Zero(t, id)
+
+	err = mock.ExpectationsWereMet()
+	require.NoError(t, err, "There were unfulfilled expectations")
+}
+
+func Test_sqlite_MenuRepository(t *testing.T) {
+	db := openMemoryDB(t)
+	require.NoError(t, Migrate(db))
+	_, err := db.Exec(`INSERT INTO users (id, name, email, password, role) VALUES (1, 'Owner', 'owner@example.com', 'hash', 'owner');
+		INSERT INTO restaurants (id, name, owner_id) VALUES (1, 'Pizza Place', 1), (2, 'Burger Place', 1);
+		INSERT INTO menuitems (id, name, price, available, restaurant_id) VALUES
+			(1, 'Eggs', 500, TRUE, 1), (2, 'Coffee', 200, TRUE, 1), (3, 'Pizza', 1000, TRUE, 1)`)
+	require.NoError(t, err)
+
+	repo := NewMenuRepository(db)
+	breakfast := domain.Menu{
+		RestaurantID: 1,
+		Name:         "Breakfast",
+		Schedules:    []domain.MenuSchedule{{Start: 420, End: 660}},
+		Categories: []domain.MenuCategory{
+			{Name: "Drinks", ItemIDs: []int{2}},
+			{Name: "Mains", ItemIDs: []int{1}},
+		},
+	}
+	breakfastId, err := repo.SaveMenu(t.Context(), breakfast)
+	require.NoError(t, err)
+	dinnerId, err := repo.SaveMenu(t.Context(), domain.Menu{
+		RestaurantID: 1,
+		Name:         "Dinner",
+		Schedules:    []domain.MenuSchedule{{Start: 1080, End: 1320}, {Start: 720, End: 840}},
+		Categories:   []domain.MenuCategory{{Name: "Pizzas", ItemIDs: []int{3, 2}}, {Name: "Specials"}},
+	})
+	require.NoError(t, err)
+
+	menu, err := repo.FindMenuById(t.Context(), breakfastId)
+	require.NoError(t, err)
+	require.Equal(t, "Breakfast", menu.Name)
+	require.Equal(t, breakfast.Schedules, menu.Schedules)
+	require.Len(t, menu.Categories, 2)
+	require.Equal(t, "Drinks", menu.Categories[0].Name)
+	require.Equal(t, []int{2}, menu.Categories[0].ItemIDs)
+	require.Equal(t, []int{1}, menu.Categories[1].ItemIDs)
+
+	menus, err := repo.FindMenusByRestaurantId(t.Context(), 1)
+	require.NoError(t, err)
+	require.Len(t, menus, 2)
+	require.Equal(t, dinnerId, menus[1].ID)
+	require.Equal(t, []domain.MenuSchedule{{Start: 720, End: 840}, {Start: 1080, End: 1320}}, menus[1].Schedules)
+	require.Equal(t, []int{3, 2}, menus[1].Categories[0].ItemIDs)
+	require.Equal(t, "Specials", menus[1].Categories[1].Name)
+	require.Empty(t, menus[1].Categories[1].ItemIDs)
+
+	menus, err = repo.FindMenusByRestaurantId(t.Context(), 2)
+	require.NoError(t, err)
+	require.Empty(t, menus)
+
+	// reorder the categories and drop the schedule, keeping the id of one
+	menu.Name = "All day breakfast"
+	menu.Schedules = nil
+	menu.Categories = []domain.MenuCategory{
+		{ID: menu.Categories[1].ID, Name: "Mains", ItemIDs: []int{1, 3}},
+		{Name: "Coffee", ItemIDs: []int{2}},
+	}
+	require.NoError(t, repo.UpdateMenu(t.Context(), menu))
+
+	updated, err := repo.FindMenuById(t.Context(), breakfastId)
+	require.NoError(t, err)
+	require.Equal(t, "All day breakfast", updated.Name)
+	require.Empty(t, updated.Schedules)
+	require.Equal(t, menu.Categories[0].ID, updated.Categories[0].ID)
+	require.Equal(t, []int{1, 3}, updated.Categories[0].ItemIDs)
+	require.Equal(t, "Coffee", updated.Categories[1].Name)
+
+	err = repo.UpdateMenu(t.Context(), domain.Menu{ID: 99, RestaurantID: 1, Name: "Missing"})
+	require.True(t, apperr.IsNotFoundError(err), "expected not found error but got %v", err)
+
+	require.NoError(t, repo.DeleteMenu(t.Context(), dinnerId))
+	_, err = repo.FindMenuById(t.Context(), dinnerId)
+	require.True(t, apperr.IsNotFoundError(err), "expected not found error but got %v", err)
+	err = repo.DeleteMenu(t.Context(), dinnerId)
+	require.True(t, apperr.IsNotFoundError(err), "expected not found error but got %v", err)
+
+	var categories int
+	require.NoError(t, db.QueryRow("SELECT COUNT(*) FROM menu_categories WHERE menu_id = ?", dinnerId).Scan(&categories))
+	require.Zero(t, categories)
+}
@@ -17,15 +17,117 @@ func NewMenuItemRepository(db *sql.DB) *MenuItemRepository {
 }
 
 func (m *MenuItemRepository) SaveMenuItem(cxt context.Context, item domain.MenuItem) (int, error) {
-	query := `INSERT INTO menuitems (name, price, available, restaurant_id) VALUES (?, ?, ?, ?) RETURNING id`
+	tx, err := m.db.BeginTx(cxt, nil)
+	if err != nil {
+		return 0, HandleSQLiteError(err)
+	}
+
+	query := `INSERT INTO menuitems (name, price, currency, available, category, description, image_url, sort_order, stock, restaurant_id) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?) RETURNING id`
 	var id int
-	err := m.db.QueryRowContext(cxt, query, item.Name, item.Price, item.Available, item.RestaurantID).Scan(&id)
+	err = tx.QueryRowContext(cxt, query, item.Name, item.Price.Amount, item.Price.Currency, item.Available, item.Category, item.Description, item.ImageURL, item.SortOrder, stockValue(item.TrackStock, item.Stock), item.RestaurantID).Scan(&id)
 	if err != nil {
+		tx.Rollback()
+		return 0, HandleSQLiteError(err)
+	}
+
+	if err := saveModifierGroups(cxt, tx, id, item.ModifierGroups); err != nil {
+		tx.Rollback()
+		return 0, err
+	}
+
+	if err := tx.Commit(); err != nil {
 		return 0, HandleSQLiteError(err)
 	}
 	return id, nil
 }
 
+// UpdateMenuItem also replaces the modifier groups of the item, groups and
+// options passed with their id keep it so the orders that chose them stay
+// valid. The stock is left as it is, orders change it concurrently and it is
+// set through UpdateMenuItemStock.
+func (m *MenuItemRepository) UpdateMenuItem(cxt context.Context, item domain.MenuItem) error {
+	tx, err := m.db.BeginTx(cxt, nil)
+	if err != nil {
+		return HandleSQLiteError(err)
+	}
+
+	query := `UPDATE menuitems SET name = ?, price = ?, currency = ?, available = ?, category = ?, description = ?, image_url = ?, sort_order = ? WHERE id = ? AND deleted = FALSE`
+	result, err := tx.ExecContext(cxt, query, item.Name, item.Price.Amount, item.Price.Currency, item.Available, item.Category, item.Description, item.ImageURL, item.SortOrder, item.ID)
+	if err != nil {
+		tx.Rollback()
+		return HandleSQLiteError(err)
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		tx.Rollback()
+		return HandleSQLiteError(err)
+	}
+	if rows == 0 {
+		tx.Rollback()
+		return apperr.NewAppError(apperr.ErrNotFound, "menu item not found", nil)
+	}
+
+	delOptionsQuery := `DELETE FROM modifier_options WHERE group_id IN (SELECT id FROM modifier_groups WHERE menuitem_id = ?)`
+	if _, err := tx.ExecContext(cxt, delOptionsQuery, item.ID); err != nil {
+		tx.Rollback()
+		return HandleSQLiteError(err)
+	}
+	delGroupsQuery := `DELETE FROM modifier_groups WHERE menuitem_id = ?`
+	if _, err := tx.ExecContext(cxt, delGroupsQuery, item.ID); err != nil {
+		tx.Rollback()
+		return HandleSQLiteError(err)
+	}
+
+	if err := saveModifierGroups(cxt, tx, item.ID, item.ModifierGroups); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return HandleSQLiteError(err)
+	}
+	return nil
+}
+
+// saveModifierGroups inserts the groups of a menu item with their options, a
+// zero id gets a new one.
+func saveModifierGroups(cxt context.Context, tx *sql.Tx, menuItemId int, groups []domain.ModifierGroup) error {
+	groupQuery := `INSERT INTO modifier_groups (id, menuitem_id, name, min_select, max_select) VALUES (NULLIF(?, 0), ?, ?, ?, ?) RETURNING id`
+	optionQuery := `INSERT INTO modifier_options (id, group_id, name, price_delta, currency) VALUES (NULLIF(?, 0), ?, ?, ?, ?)`
+	for _, group := range groups {
+		var groupId int
+		err := tx.QueryRowContext(cxt, groupQuery, group.ID, menuItemId, group.Name, group.MinSelect, group.MaxSelect).Scan(&groupId)
+		if err != nil {
+			return HandleSQLiteError(err)
+		}
+		for _, option := range group.Options {
+			_, err := tx.ExecContext(cxt, optionQuery, option.ID, groupId, option.Name, option.PriceDelta.Amount, option.PriceDelta.Currency)
+			if err != nil {
+				return HandleSQLiteError(err)
+			}
+		}
+	}
+	return nil
+}
+
+// DeleteMenuItem soft deletes the item, it stays in the table for the orders
+// that reference it.
+func (m *MenuItemRepository) DeleteMenuItem(cxt context.Context, id int) error {
+	query := `UPDATE menuitems SET deleted = TRUE, available = FALSE WHERE id = ? AND deleted = FALSE`
+	result, err := m.db.ExecContext(cxt, query, id)
+	if err != nil {
+		return HandleSQLiteError(err)
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return HandleSQLiteError(err)
+	}
+	if rows == 0 {
+		return apperr.NewAppError(apperr.ErrNotFound, "menu item not found", nil)
+	}
+	return nil
+}
+
 func (m *MenuItemRepository) UpdateMenuItemAvailability(cxt context.Context, id int, available bool) error {
 	query := `UPDATE menuitems SET available = ? WHERE id = ?`
 	_, err := m.db.ExecContext(cxt, query, available, id)
@@ -35,8 +137,39 @@ func (m *MenuItemRepository) UpdateMenuItemAvailability(cxt context.Context, id
 	return nil
 }
 
+// UpdateMenuItemStock sets the quantity left of the item, or stops tracking
+// it. A tracked item is available as long as it has stock left.
+func (m *MenuItemRepository) UpdateMenuItemStock(cxt context.Context, id int, trackStock bool, stock int) error {
+	query := `UPDATE menuitems SET stock = ?, available = CASE WHEN ? THEN ? > 0 ELSE available END WHERE id = ? AND deleted = FALSE`
+	result, err := m.db.ExecContext(cxt, query, stockValue(trackStock, stock), trackStock, stock, id)
+	if err != nil {
+		return HandleSQLiteError(err)
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return HandleSQLiteError(err)
+	}
+	if rows == 0 {
+		return apperr.NewAppError(apperr.ErrNotFound, "menu item not found", nil)
+	}
+	return nil
+}
+
+// stockValue is the stock column of an item, NULL when it is not tracked.
+func stockValue(trackStock bool, stock int) any {
+	if !trackStock {
+		return nil
+	}
+	return stock
+}
+
+func scanStock(item *domain.MenuItem, stock sql.NullInt64) {
+	item.TrackStock = stock.Valid
+	item.Stock = int(stock.Int64)
+}
+
 func (m *MenuItemRepository) FindMenuItemsByRestaurantId(cxt context.Context, restaurantId int) ([]domain.MenuItem, error) {
-	query := `SELECT id, name, price, available, restaurant_id FROM menuitems WHERE restaurant_id = ?`
+	query := `SELECT id, name, price, currency, available, category, description, image_url, sort_order, stock, restaurant_id FROM menuitems WHERE restaurant_id = ? AND deleted = FALSE ORDER BY sort_order, id`
 	rows, err := m.db.QueryContext(cxt, query, restaurantId)
 	if err != nil {
 		return nil, HandleSQLiteError(err)
@@ -46,27 +179,84 @@ func (m *MenuItemRepository) FindMenuItemsByRestaurantId(cxt context.Context, re
 	menuItems := []domain.MenuItem{}
 	for rows.Next() {
 		var item domain.MenuItem
-		if err := rows.Scan(&item.ID, &item.Name, &item.Price, &item.Available, &item.RestaurantID); err != nil {
+		var stock sql.NullInt64
+		if err := rows.Scan(&item.ID, &item.Name, &item.Price.Amount, &item.Price.Currency, &item.Available, &item.Category, &item.Description, &item.ImageURL, &item.SortOrder, &stock, &item.RestaurantID); err != nil {
 			return nil, HandleSQLiteError(err)
 		}
+		scanStock(&item, stock)
 		menuItems = append(menuItems, item)
 	}
 
 	if err := rows.Err(); err != nil {
 		return nil, HandleSQLiteError(err)
 	}
+	// release the connection before querying modifier groups
+	rows.Close()
+
+	if len(menuItems) == 0 {
+		return menuItems, nil
+	}
+	groups, err := m.findModifierGroups(cxt, "menuitem_id IN (SELECT id FROM menuitems WHERE restaurant_id = ? AND deleted = FALSE)", restaurantId)
+	if err != nil {
+		return nil, err
+	}
+	for i := range menuItems {
+		menuItems[i].ModifierGroups = groups[menuItems[i].ID]
+	}
 	return menuItems, nil
 }
 
+// FindMenuItemById also finds deleted items, so past orders still resolve.
 func (m *MenuItemRepository) FindMenuItemById(cxt context.Context, id int) (domain.MenuItem, error) {
-	query := `SELECT id, name, price, available, restaurant_id FROM menuitems WHERE id = ?`
+	query := `SELECT id, name, price, currency, available, category, description, image_url, sort_order, stock, deleted, restaurant_id FROM menuitems WHERE id = ?`
 	var item domain.MenuItem
-	err := m.db.QueryRowContext(cxt, query, id).Scan(&item.ID, &item.Name, &item.Price, &item.Available, &item.RestaurantID)
+	var stock sql.NullInt64
+	err := m.db.QueryRowContext(cxt, query, id).Scan(&item.ID, &item.Name, &item.Price.Amount, &item.Price.Currency, &item.Available, &item.Category, &item.Description, &item.ImageURL, &item.SortOrder, &stock, &item.Deleted, &item.RestaurantID)
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return domain.MenuItem{}, apperr.NewAppError(apperr.ErrNotFound, "menu item not found", nil)
 		}
 		return domain.MenuItem{}, HandleSQLiteError(err)
 	}
+	scanStock(&item, stock)
+
+	groups, err := m.findModifierGroups(cxt, "menuitem_id = ?", id)
+	if err != nil {
+		return domain.MenuItem{}, err
+	}
+	item.ModifierGroups = groups[id]
 	return item, nil
 }
+
+// findModifierGroups loads the groups matching the condition with their
+// options, by the id of their menu item.
+func (m *MenuItemRepository) findModifierGroups(cxt context.Context, condition string, args ...any) (map[int][]domain.ModifierGroup, error) {
+	query := `SELECT g.id, g.menuitem_id, g.name, g.min_select, g.max_select, o.id, o.name, o.price_delta, o.currency FROM modifier_groups g JOIN modifier_options o ON o.group_id = g.id WHERE g.` + condition + ` ORDER BY g.id, o.id`
+	rows, err := m.db.QueryContext(cxt, query, args...)
+	if err != nil {
+		return nil, HandleSQLiteError(err)
+	}
+	defer rows.Close()
+
+	groups := make(map[int][]domain.ModifierGroup)
+	for rows.Next() {
+		var group domain.ModifierGroup
+		var option domain.ModifierOption
+		if err := rows.Scan(&group.ID, &group.MenuItemID, &group.Name, &group.MinSelect, &group.MaxSelect, &option.ID, &option.Name, &option.PriceDelta.Amount, &option.PriceDelta.Currency); err != nil {
+			return nil, HandleSQLiteError(err)
+		}
+		option.GroupID = group.ID
+
+		itemGroups := groups[group.MenuItemID]
+		if len(itemGroups) == 0 || itemGroups[len(itemGroups)-1].ID != group.ID {
+			itemGroups = append(itemGroups, group)
+		}
+		last := &itemGroups[len(itemGroups)-1]
+		last.Options = append(last.Options, option)
+		groups[group.MenuItemID] = itemGroups
+	}
+	if err := rows.Err(); err != nil {
+		return nil, HandleSQLiteError(err)
+	}
+	return groups, nil
+}
@@ -40,30 +40,74 @@ func Test_sqlite_MenuItemRepository_SaveMenuItem(t *testing.T) {
 			name: "Successful insert",
 			menuItem: domain.MenuItem{
 				Name:         "Test Item",
-				Price:        9.99,
+				Price:        domain.NewMoney(999, "USD"),
 				Available:    true,
 				RestaurantID: 1,
+				Category:     "food",
+				Description:  "A test item",
+				SortOrder:    2,
+				TrackStock:   true,
+				Stock:        10,
 			},
 			mockSetup: func() {
+				mock.ExpectBegin()
 				mock.ExpectQuery("INSERT INTO menuitems").
-					WithArgs("Test Item", 9.99, true, 1).
+					WithArgs("Test Item", 999, "USD", true, "food", "A test item", "", 2, 10, 1).
 					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+				mock.ExpectCommit()
 			},
 			expectedID:    1,
 			expectedError: false,
 		},
+		{
+			name: "Successful insert with modifier groups",
+			menuItem: domain.MenuItem{
+				Name:         "Pizza",
+				Price:        domain.NewMoney(1000, "USD"),
+				Available:    true,
+				RestaurantID: 1,
+				Category:     "food",
+				ModifierGroups: []domain.ModifierGroup{
+					{Name: "Size", MinSelect: 1, MaxSelect: 1, Options: []domain.ModifierOption{
+						{Name: "Small", PriceDelta: domain.NewMoney(0, "USD")},
+						{Name: "Large", PriceDelta: domain.NewMoney(300, "USD")},
+					}},
+				},
+			},
+			mockSetup: func() {
+				mock.ExpectBegin()
+				mock.ExpectQuery("INSERT INTO menuitems").
+					WithArgs("Pizza", 1000, "USD", true, "food", "", "", 0, nil, 1).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(2))
+				mock.ExpectQuery("INSERT INTO modifier_groups \\(id, menuitem_id, name, min_select, max_select\\) VALUES \\(NULLIF\\(\\?, 0\\), \\?, \\?, \\?, \\?\\) RETURNING id").
+					WithArgs(0, 2, "Size", 1, 1).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(5))
+				mock.ExpectExec("INSERT INTO modifier_options").
+					WithArgs(0, 5, "Small", 0, "USD").
+					WillReturnResult(sqlmock.NewResult(10, 1))
+				mock.ExpectExec("INSERT INTO modifier_options").
+					WithArgs(0, 5, "Large", 300, "USD").
+					WillReturnResult(sqlmock.NewResult(11, 1))
+				mock.ExpectCommit()
+			},
+			expectedID:    2,
+			expectedError: false,
+		},
 		{
 			name: "Database error",
 			menuItem: domain.MenuItem{
 				Name:         "Test Item",
-				Price:        9.99,
+				Price:        domain.NewMoney(999, "USD"),
 				Available:    true,
 				RestaurantID: 1,
+				Category:     "food",
 			},
 			mockSetup: func() {
+				mock.ExpectBegin()
 				mock.ExpectQuery("INSERT INTO menuitems").
-					WithArgs("Test Item", 9.99, true, 1).
+					WithArgs("Test Item", 999, "USD", true, "food", "", "", 0, nil, 1).
 					WillReturnError(sqlmock.ErrCancelled)
+				mock.ExpectRollback()
 			},
 			expectedID:    0,
 			expectedError: true,
@@ -150,6 +194,206 @@ func Test_sqlite_MenuItemRepository_UpdateMenuItemAvailability(t *testing.T) {
 	}
 }
 
+func Test_sqlite_MenuItemRepository_UpdateMenuItemStock(t *testing.T) {
+	db := openMemoryDB(t)
+	require.NoError(t, Migrate(db))
+	_, err := db.Exec(`INSERT INTO users (id, name, email, password, role) VALUES (1, 'Owner', 'owner@example.com', 'hash', 'owner');
+		INSERT INTO restaurants (id, name, owner_id) VALUES (1, 'Pizza Place', 1);
+		INSERT INTO menuitems (id, name, price, available, restaurant_id) VALUES (1, 'Cake', 500, TRUE, 1)`)
+	require.NoError(t, err)
+
+	repo := NewMenuItemRepository(db)
+
+	require.NoError(t, repo.UpdateMenuItemStock(t.Context(), 1, true, 0))
+	item, err := repo.FindMenuItemById(t.Context(), 1)
+	require.NoError(t, err)
+	require.True(t, item.TrackStock)
+	require.Zero(t, item.Stock)
+	require.False(t, item.Available, "expected item without stock to be sold out")
+
+	require.NoError(t, repo.UpdateMenuItemStock(t.Context(), 1, true, 4))
+	item, err = repo.FindMenuItemById(t.Context(), 1)
+	require.NoError(t, err)
+	require.Equal(t, 4, item.Stock)
+	require.True(t, item.Available, "expected restocked item to be available")
+
+	require.NoError(t, repo.UpdateMenuItemAvailability(t.Context(), 1, false))
+	require.NoError(t, repo.UpdateMenuItemStock(t.Context(), 1, false, 0))
+	item, err = repo.FindMenuItemById(t.Context(), 1)
+	require.NoError(t, err)
+	require.False(t, item.TrackStock)
+	require.False(t, item.Available, "expected untracking stock to leave the availability as it is")
+
+	err = repo.UpdateMenuItemStock(t.Context(), 2, true, 1)
+	require.True(t, apperr.IsNotFoundError(err), "expected not found error but got %v", err)
+}
+
+func Test_sqlite_MenuItemRepository_UpdateMenuItem(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err, "Expected no error when creating sqlmock")
+	defer db.Close()
+
+	repo := NewMenuItemRepository(db)
+
+	item := domain.MenuItem{
+		ID:          1,
+		Name:        "Margherita",
+		Price:       domain.NewMoney(1099, "USD"),
+		Available:   true,
+		Category:    "food",
+		Description: "Tomato and mozzarella",
+		ImageURL:    "https://example.com/margherita.png",
+		SortOrder:   3,
+		ModifierGroups: []domain.ModifierGroup{
+			{ID: 5, Name: "Size", MinSelect: 1, MaxSelect: 1, Options: []domain.ModifierOption{
+				{ID: 10, Name: "Small", PriceDelta: domain.NewMoney(0, "USD")},
+				{Name: "Large", PriceDelta: domain.NewMoney(300, "USD")},
+			}},
+		},
+	}
+
+	tests := []struct {
+		name            string
+		mockSetup       func()
+		expectedError   bool
+		expectedErrCode apperr.AppErrorCode
+	}{
+		{
+			name: "Successful update",
+			mockSetup: func() {
+				mock.ExpectBegin()
+				mock.ExpectExec("UPDATE menuitems SET name = \\?, price = \\?, currency = \\?, available = \\?, category = \\?, description = \\?, image_url = \\?, sort_order = \\? WHERE id = \\? AND deleted = FALSE").
+					WithArgs("Margherita", 1099, "USD", true, "food", "Tomato and mozzarella", "https://example.com/margherita.png", 3, 1).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec("DELETE FROM modifier_options WHERE group_id IN \\(SELECT id FROM modifier_groups WHERE menuitem_id = \\?\\)").
+					WithArgs(1).
+					WillReturnResult(sqlmock.NewResult(0, 2))
+				mock.ExpectExec("DELETE FROM modifier_groups WHERE menuitem_id = \\?").
+					WithArgs(1).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectQuery("INSERT INTO modifier_groups").
+					WithArgs(5, 1, "Size", 1, 1).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(5))
+				mock.ExpectExec("INSERT INTO modifier_options").
+					WithArgs(10, 5, "Small", 0, "USD").
+					WillReturnResult(sqlmock.NewResult(10, 1))
+				mock.ExpectExec("INSERT INTO modifier_options").
+					WithArgs(0, 5, "Large", 300, "USD").
+					WillReturnResult(sqlmock.NewResult(12, 1))
+				mock.ExpectCommit()
+			},
+			expectedError: false,
+		},
+		{
+			name: "Menu item not found or deleted",
+			mockSetup: func() {
+				mock.ExpectBegin()
+				mock.ExpectExec("UPDATE menuitems SET name").
+					WithArgs("Margherita", 1099, "USD", true, "food", "Tomato and mozzarella", "https://example.com/margherita.png", 3, 1).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectRollback()
+			},
+			expectedError:   true,
+			expectedErrCode: apperr.ErrNotFound,
+		},
+		{
+			name: "Database error",
+			mockSetup: func() {
+				mock.ExpectBegin()
+				mock.ExpectExec("UPDATE menuitems SET name").
+					WithArgs("Margherita", 1099, "USD", true, "food", "Tomato and mozzarella", "https://example.com/margherita.png", 3, 1).
+					WillReturnError(sqlmock.ErrCancelled)
+				mock.ExpectRollback()
+			},
+			expectedError:   true,
+			expectedErrCode: apperr.ErrInternal,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockSetup()
+
+			err := repo.UpdateMenuItem(t.Context(), item)
+			if tt.expectedError {
+				require.Error(t, err)
+				appErr, ok := err.(*apperr.AppError)
+				require.True(t, ok, "Expected error to be of type AppError")
+				require.Equal(t, tt.expectedErrCode, appErr.Code)
+			} else {
+				require.NoError(t, err)
+			}
+
+			err = mock.ExpectationsWereMet()
+			require.NoError(t, err, "There were unfulfilled expectations")
+		})
+	}
+}
+
+func Test_sqlite_MenuItemRepository_DeleteMenuItem(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err, "Expected no error when creating sqlmock")
+	defer db.Close()
+
+	repo := NewMenuItemRepository(db)
+
+	tests := []struct {
+		name            string
+		mockSetup       func()
+		expectedError   bool
+		expectedErrCode apperr.AppErrorCode
+	}{
+		{
+			name: "Successful delete",
+			mockSetup: func() {
+				mock.ExpectExec("UPDATE menuitems SET deleted = TRUE, available = FALSE WHERE id = \\? AND deleted = FALSE").
+					WithArgs(1).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+			expectedError: false,
+		},
+		{
+			name: "Already deleted",
+			mockSetup: func() {
+				mock.ExpectExec("UPDATE menuitems SET deleted = TRUE").
+					WithArgs(1).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+			},
+			expectedError:   true,
+			expectedErrCode: apperr.ErrNotFound,
+		},
+		{
+			name: "Database error",
+			mockSetup: func() {
+				mock.ExpectExec("UPDATE menuitems SET deleted = TRUE").
+					WithArgs(1).
+					WillReturnError(sqlmock.ErrCancelled)
+			},
+			expectedError:   true,
+			expectedErrCode: apperr.ErrInternal,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockSetup()
+
+			err := repo.DeleteMenuItem(t.Context(), 1)
+			if tt.expectedError {
+				require.Error(t, err)
+				appErr, ok := err.(*apperr.AppError)
+				require.True(t, ok, "Expected error to be of type AppError")
+				require.Equal(t, tt.expectedErrCode, appErr.Code)
+			} else {
+				require.NoError(t, err)
+			}
+
+			err = mock.ExpectationsWereMet()
+			require.NoError(t, err, "There were unfulfilled expectations")
+		})
+	}
+}
+
 func Test_sqlite_MenuItemRepository_FindMenuItemsByRestaurantId(t *testing.T) {
 	db, mock, err := sqlmock.New()
 	require.NoError(t, err, "Expected no error when creating sqlmock")
@@ -170,16 +414,31 @@ func Test_sqlite_MenuItemRepository_FindMenuItemsByRestaurantId(t *testing.T) {
 			name:         "Successful fetch",
 			restaurantID: 1,
 			mockSetup: func() {
-				rows := sqlmock.NewRows([]string{"id", "name", "price", "available", "restaurant_id"}).
-					AddRow(1, "Item 1", 9.99, true, 1).
-					AddRow(2, "Item 2", 19.99, false, 1)
-				mock.ExpectQuery("SELECT id, name, price, available, restaurant_id FROM menuitems WHERE restaurant_id = \\?").
+				rows := sqlmock.NewRows([]string{"id", "name", "price", "currency", "available", "category", "description", "image_url", "sort_order", "stock", "restaurant_id"}).
+					AddRow(1, "Item 1", 999, "USD", true, "food", "Crispy", "https://example.com/1.png", 0, nil, 1).
+					AddRow(2, "Item 2", 1999, "USD", false, "alcohol", "", "", 1, 0, 1)
+				mock.ExpectQuery("SELECT id, name, price, currency, available, category, description, image_url, sort_order, stock, restaurant_id FROM menuitems WHERE restaurant_id = \\? AND deleted = FALSE ORDER BY sort_order, id").
 					WithArgs(1).
 					WillReturnRows(rows)
+				groupRows := sqlmock.NewRows([]string{"id", "menuitem_id", "name", "min_select", "max_select", "id", "name", "price_delta", "currency"}).
+					AddRow(5, 1, "Size", 1, 1, 10, "Small", 0, "USD").
+					AddRow(5, 1, "Size", 1, 1, 11, "Large", 300, "USD").
+					AddRow(6, 1, "Extras", 0, 1, 12, "Cheese", 100, "USD")
+				mock.ExpectQuery("SELECT g.id, g.menuitem_id, g.name, g.min_select, g.max_select, o.id, o.name, o.price_delta, o.currency FROM modifier_groups g JOIN modifier_options o ON o.group_id = g.id WHERE g.menuitem_id IN \\(SELECT id FROM menuitems WHERE restaurant_id = \\? AND deleted = FALSE\\) ORDER BY g.id, o.id").
+					WithArgs(1).
+					WillReturnRows(groupRows)
 			},
 			expectedResults: []domain.MenuItem{
-				{ID: 1, Name: "Item 1", Price: 9.99, Available: true, RestaurantID: 1},
-				{ID: 2, Name: "Item 2", Price: 19.99, Available: false, RestaurantID: 1},
+				{ID: 1, Name: "Item 1", Price: domain.NewMoney(999, "USD"), Available: true, RestaurantID: 1, Category: "food", Description: "Crispy", ImageURL: "https://example.com/1.png", ModifierGroups: []domain.ModifierGroup{
+					{ID: 5, MenuItemID: 1, Name: "Size", MinSelect: 1, MaxSelect: 1, Options: []domain.ModifierOption{
+						{ID: 10, GroupID: 5, Name: "Small", PriceDelta: domain.NewMoney(0, "USD")},
+						{ID: 11, GroupID: 5, Name: "Large", PriceDelta: domain.NewMoney(300, "USD")},
+					}},
+					{ID: 6, MenuItemID: 1, Name: "Extras", MinSelect: 0, MaxSelect: 1, Options: []domain.ModifierOption{
+						{ID: 12, GroupID: 6, Name: "Cheese", PriceDelta: domain.NewMoney(100, "USD")},
+					}},
+				}},
+				{ID: 2, Name: "Item 2", Price: domain.NewMoney(1999, "USD"), Available: false, RestaurantID: 1, Category: "alcohol", SortOrder: 1, TrackStock: true},
 			},
 			expectedError: false,
 		},
@@ -187,8 +446,8 @@ func Test_sqlite_MenuItemRepository_FindMenuItemsByRestaurantId(t *testing.T) {
 			name:         "No items found",
 			restaurantID: 2,
 			mockSetup: func() {
-				rows := sqlmock.NewRows([]string{"id", "name", "price", "available", "restaurant_id"})
-				mock.ExpectQuery("SELECT id, name, price, available, restaurant_id FROM menuitems WHERE restaurant_id = \\?").
+				rows := sqlmock.NewRows([]string{"id", "name", "price", "currency", "available", "category", "description", "image_url", "sort_order", "stock", "restaurant_id"})
+				mock.ExpectQuery("SELECT id, name, price, currency, available, category, description, image_url, sort_order, stock, restaurant_id FROM menuitems WHERE restaurant_id = \\? AND deleted = FALSE ORDER BY sort_order, id").
 					WithArgs(2).
 					WillReturnRows(rows)
 			},
@@ -199,7 +458,7 @@ func Test_sqlite_MenuItemRepository_FindMenuItemsByRestaurantId(t *testing.T) {
 			name:         "Database error",
 			restaurantID: 1,
 			mockSetup: func() {
-				mock.ExpectQuery("SELECT id, name, price, available, restaurant_id FROM menuitems WHERE restaurant_id = \\?").
+				mock.ExpectQuery("SELECT id, name, price, currency, available, category, description, image_url, sort_order, stock, restaurant_id FROM menuitems WHERE restaurant_id = \\? AND deleted = FALSE ORDER BY sort_order, id").
 					WithArgs(1).
 					WillReturnError(sqlmock.ErrCancelled)
 			},
@@ -249,20 +508,63 @@ func Test_sqlite_MenuItemRepository_FindMenuItemById(t *testing.T) {
 			name:       "Successful fetch",
 			menuItemID: 1,
 			mockSetup: func() {
-				row := sqlmock.NewRows([]string{"id", "name", "price", "available", "restaurant_id"}).
-					AddRow(1, "Item 1", 9.99, true, 1)
-				mock.ExpectQuery("SELECT id, name, price, available, restaurant_id FROM menuitems WHERE id = \\?").
+				row := sqlmock.NewRows([]string{"id", "name", "price", "currency", "available", "category", "description", "image_url", "sort_order", "stock", "deleted", "restaurant_id"}).
+					AddRow(1, "Item 1", 999, "USD", true, "food", "Crispy", "", 0, 5, false, 1)
+				mock.ExpectQuery("SELECT id, name, price, currency, available, category, description, image_url, sort_order, stock, deleted, restaurant_id FROM menuitems WHERE id = \\?").
+					WithArgs(1).
+					WillReturnRows(row)
+				mock.ExpectQuery("SELECT g.id, g.menuitem_id, g.name, g.min_select, g.max_select, o.id, o.name, o.price_delta, o.currency FROM modifier_groups g JOIN modifier_options o ON o.group_id = g.id WHERE g.menuitem_id = \\? ORDER BY g.id, o.id").
+					WithArgs(1).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "menuitem_id", "name", "min_select", "max_select", "id", "name", "price_delta", "currency"}))
+			},
+			expectedResult: domain.MenuItem{ID: 1, Name: "Item 1", Price: domain.NewMoney(999, "USD"), Available: true, RestaurantID: 1, Category: "food", Description: "Crispy", TrackStock: true, Stock: 5},
+			expectedError:  false,
+		},
+		{
+			name:       "Deleted item still resolves",
+			menuItemID: 1,
+			mockSetup: func() {
+				row := sqlmock.NewRows([]string{"id", "name", "price", "currency", "available", "category", "description", "image_url", "sort_order", "stock", "deleted", "restaurant_id"}).
+					AddRow(1, "Item 1", 999, "USD", false, "food", "", "", 0, nil, true, 1)
+				mock.ExpectQuery("SELECT id, name, price, currency, available, category, description, image_url, sort_order, stock, deleted, restaurant_id FROM menuitems WHERE id = \\?").
 					WithArgs(1).
 					WillReturnRows(row)
+				mock.ExpectQuery("SELECT g.id, g.menuitem_id, g.name, g.min_select, g.max_select, o.id, o.name, o.price_delta, o.currency FROM modifier_groups g JOIN modifier_options o ON o.group_id = g.id WHERE g.menuitem_id = \\? ORDER BY g.id, o.id").
+					WithArgs(1).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "menuitem_id", "name", "min_select", "max_select", "id", "name", "price_delta", "currency"}))
 			},
-			expectedResult: domain.MenuItem{ID: 1, Name: "Item 1", Price: 9.99, Available: true, RestaurantID: 1},
+			expectedResult: domain.MenuItem{ID: 1, Name: "Item 1", Price: domain.NewMoney(999, "USD"), Available: false, RestaurantID: 1, Category: "food", Deleted: true},
 			expectedError:  false,
 		},
+		{
+			name:       "Item with modifier groups",
+			menuItemID: 1,
+			mockSetup: func() {
+				row := sqlmock.NewRows([]string{"id", "name", "price", "currency", "available", "category", "description", "image_url", "sort_order", "stock", "deleted", "restaurant_id"}).
+					AddRow(1, "Pizza", 1000, "USD", true, "food", "", "", 0, nil, false, 1)
+				mock.ExpectQuery("SELECT id, name, price, currency, available, category, description, image_url, sort_order, stock, deleted, restaurant_id FROM menuitems WHERE id = \\?").
+					WithArgs(1).
+					WillReturnRows(row)
+				groupRows := sqlmock.NewRows([]string{"id", "menuitem_id", "name", "min_select", "max_select", "id", "name", "price_delta", "currency"}).
+					AddRow(5, 1, "Size", 1, 1, 10, "Small", -200, "USD").
+					AddRow(5, 1, "Size", 1, 1, 11, "Large", 300, "USD")
+				mock.ExpectQuery("SELECT g.id, g.menuitem_id, g.name, g.min_select, g.max_select, o.id, o.name, o.price_delta, o.currency FROM modifier_groups g JOIN modifier_options o ON o.group_id = g.id WHERE g.menuitem_id = \\? ORDER BY g.id, o.id").
+					WithArgs(1).
+					WillReturnRows(groupRows)
+			},
+			expectedResult: domain.MenuItem{ID: 1, Name: "Pizza", Price: domain.NewMoney(1000, "USD"), Available: true, RestaurantID: 1, Category: "food", ModifierGroups: []domain.ModifierGroup{
+				{ID: 5, MenuItemID: 1, Name: "Size", MinSelect: 1, MaxSelect: 1, Options: []domain.ModifierOption{
+					{ID: 10, GroupID: 5, Name: "Small", PriceDelta: domain.NewMoney(-200, "USD")},
+					{ID: 11, GroupID: 5, Name: "Large", PriceDelta: domain.NewMoney(300, "USD")},
+				}},
+			}},
+			expectedError: false,
+		},
 		{
 			name:       "Menu item not found",
 			menuItemID: 2,
 			mockSetup: func() {
-				mock.ExpectQuery("SELECT id, name, price, available, restaurant_id FROM menuitems WHERE id = \\?").
+				mock.ExpectQuery("SELECT id, name, price, currency, available, category, description, image_url, sort_order, stock, deleted, restaurant_id FROM menuitems WHERE id = \\?").
 					WithArgs(2).
 					WillReturnError(sql.ErrNoRows)
 			},
@@ -274,7 +576,7 @@ func Test_sqlite_MenuItemRepository_FindMenuItemById(t *testing.T) {
 			name:       "Database error",
 			menuItemID: 3,
 			mockSetup: func() {
-				mock.ExpectQuery("SELECT id, name, price, available, restaurant_id FROM menuitems WHERE id = \\?").
+				mock.ExpectQuery("SELECT id, name, price, currency, available, category, description, image_url, sort_order, stock, deleted, restaurant_id FROM menuitems WHERE id = \\?").
 					WithArgs(3).
 					WillReturnError(sqlmock.ErrCancelled)
 			},
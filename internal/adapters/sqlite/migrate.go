@@ -2,18 +2,87 @@ package sqlite
 
 import (
 	"database/sql"
-	_ "embed"
+	"embed"
+	"fmt"
+	"io/fs"
 	"log"
+	"strconv"
+	"strings"
 )
 
-//go:embed schema.sql
-var schemaSQL string
+//go:embed migrations/*.sql
+var migrationsFS embed.FS
 
+const migrationsDir = "migrations"
+
+// Migrate applies every migration in migrations/ that is not yet recorded in
+// schema_migrations. Files are named <version>_<description>.sql and run in
+// version order, each inside its own transaction.
 func Migrate(db *sql.DB) error {
-	_, err := db.Exec(schemaSQL)
+	_, err := db.Exec(`CREATE TABLE IF NOT EXISTS schema_migrations (
+		version INTEGER PRIMARY KEY,
+		applied_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP
+	)`)
 	if err != nil {
 		log.Println("Failed to execute migrations: ", err)
 		return err
 	}
+
+	entries, err := fs.ReadDir(migrationsFS, migrationsDir)
+	if err != nil {
+		return err
+	}
+
+	for _, entry := range entries {
+		version, err := migrationVersion(entry.Name())
+		if err != nil {
+			return err
+		}
+
+		var applied int
+		err = db.QueryRow("SELECT COUNT(*) FROM schema_migrations WHERE version = ?", version).Scan(&applied)
+		if err != nil {
+			log.Println("Failed to execute migrations: ", err)
+			return err
+		}
+		if applied > 0 {
+			continue
+		}
+
+		if err := applyMigration(db, version, entry.Name()); err != nil {
+			log.Printf("Failed to execute migration %s: %v\n", entry.Name(), err)
+			return err
+		}
+	}
 	return nil
 }
+
+func applyMigration(db *sql.DB, version int, name string) error {
+	script, err := fs.ReadFile(migrationsFS, migrationsDir+"/"+name)
+	if err != nil {
+		return err
+	}
+
+	tx, err := db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.Exec(string(script)); err != nil {
+		return err
+	}
+	if _, err := tx.Exec("INSERT INTO schema_migrations (version) VALUES (?)", version); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+func migrationVersion(name string) (int, error) {
+	prefix, _, _ := strings.Cut(name, "_")
+	version, err := strconv.Atoi(prefix)
+	if err != nil {
+		return 0, fmt.Errorf("invalid migration file name %q", name)
+	}
+	return version, nil
+}
@@ -1,28 +1,125 @@
 package sqlite
 
 import (
+	"database/sql"
+	"io/fs"
 	"testing"
 
 	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/mattn/go-sqlite3"
+	"github.com/mohits-git/food-ordering-system/internal/domain"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
 
+func openMemoryDB(t *testing.T) *sql.DB {
+	db, err := sql.Open("sqlite3", ":memory:")
+	require.NoError(t, err)
+	db.SetMaxOpenConns(1)
+	t.Cleanup(func() { db.Close() })
+	return db
+}
+
 func Test_sqlite_Migrate(t *testing.T) {
-	db, mock, err := sqlmock.New()
-	require.NoErrorf(t, err, "an error '%s' was not expected when opening a stub database connection", err)
-	defer db.Close()
+	db := openMemoryDB(t)
 
-	// the starting query of the schema.sql query
-	mock.ExpectExec("CREATE TABLE IF NOT EXISTS users").
-		WillReturnResult(sqlmock.NewResult(0, 0))
+	err := Migrate(db)
+	require.NoError(t, err)
 
+	// running again must be a no-op
 	err = Migrate(db)
-	assert.NoErrorf(t, err, "unexpected error during migration: %s", err)
+	require.NoError(t, err)
 
-	err = mock.ExpectationsWereMet()
-	assert.NoErrorf(t, err, "there were unfulfilled expectations: %s", err)
+	var versions int
+	err = db.QueryRow("SELECT COUNT(*) FROM schema_migrations").Scan(&versions)
+	require.NoError(t, err)
+
+	entries, err := fs.ReadDir(migrationsFS, migrationsDir)
+	require.NoError(t, err)
+	assert.Equal(t, len(entries), versions)
+}
+
+func Test_sqlite_Migrate_BaselineDatabase(t *testing.T) {
+	db := openMemoryDB(t)
+
+	// a database created before versioned migrations, from the initial schema
+	// alone and without schema_migrations
+	initial, err := fs.ReadFile(migrationsFS, migrationsDir+"/0001_initial_schema.sql")
+	require.NoError(t, err)
+	_, err = db.Exec(string(initial))
+	require.NoError(t, err)
+
+	_, err = db.Exec(`INSERT INTO users (id, name, email, role, password) VALUES (1, 'Owner', 'owner@example.com', 'owner', 'hash'), (2, 'Customer', 'customer@example.com', 'customer', 'hash');
+		INSERT INTO restaurants (id, name, owner_id) VALUES (1, 'Pizza Place', 1);
+		INSERT INTO menuitems (id, name, price, available, restaurant_id) VALUES (1, 'Pizza', 12.99, TRUE, 1), (2, 'Soda', 1.5, TRUE, 1);
+		INSERT INTO orders (id, user_id, restaurant_id) VALUES (1, 2, 1), (2, 2, 1);
+		INSERT INTO orderitems (id, order_id, menuitem_id, quantity) VALUES (1, 1, 1, 3), (2, 1, 2, 1), (3, 2, 2, 2);
+		INSERT INTO invoices (id, order_id, total, tax, payment_status) VALUES (1, 1, 40.47, 4.047, 'paid')`)
+	require.NoError(t, err)
+
+	err = Migrate(db)
+	require.NoError(t, err)
+
+	var price int64
+	var currency, priceType, category string
+	err = db.QueryRow("SELECT price, typeof(price), currency, category FROM menuitems WHERE id = 1").Scan(&price, &priceType, &currency, &category)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1299), price)
+	assert.Equal(t, "integer", priceType)
+	assert.Equal(t, "USD", currency)
+	assert.Equal(t, "", category)
+
+	var status string
+	var createdAt sql.NullString
+	err = db.QueryRow("SELECT status, created_at FROM orders WHERE id = 1").Scan(&status, &createdAt)
+	require.NoError(t, err)
+	assert.Equal(t, "placed", status)
+	assert.True(t, createdAt.Valid)
+	err = db.QueryRow("SELECT status FROM orders WHERE id = 2").Scan(&status)
+	require.NoError(t, err)
+	assert.Equal(t, "draft", status)
+
+	var name string
+	var unitPrice int64
+	err = db.QueryRow("SELECT name, unit_price, currency FROM orderitems WHERE id = 1").Scan(&name, &unitPrice, &currency)
+	require.NoError(t, err)
+	assert.Equal(t, "Pizza", name)
+	assert.Equal(t, int64(1299), unitPrice)
+	assert.Equal(t, "USD", currency)
+
+	var total, tax int64
+	err = db.QueryRow("SELECT total, tax, currency FROM invoices WHERE id = 1").Scan(&total, &tax, &currency)
+	require.NoError(t, err)
+	assert.Equal(t, int64(4047), total)
+	assert.Equal(t, int64(405), tax)
+	assert.Equal(t, "USD", currency)
+
+	var lines int
+	var linesTotal, linesTax int64
+	err = db.QueryRow("SELECT COUNT(*), SUM(total), SUM(tax) FROM invoice_items WHERE invoice_id = 1").Scan(&lines, &linesTotal, &linesTax)
+	require.NoError(t, err)
+	assert.Equal(t, 2, lines)
+	assert.Equal(t, int64(4047), linesTotal)
+	assert.Equal(t, int64(405), linesTax)
+
+	var rate float64
+	err = db.QueryRow("SELECT rate FROM tax_rules WHERE id = 1").Scan(&rate)
+	require.NoError(t, err)
+	assert.Equal(t, 0.10, rate)
+
+	// the migrated rows load through the repositories
+	order, err := NewOrderRepository(db).FindOrderById(t.Context(), 1)
+	require.NoError(t, err)
+	assert.Equal(t, domain.OrderPlaced, order.Status)
+	require.Len(t, order.OrderItems, 2)
+	assert.Equal(t, int64(1299), order.OrderItems[0].UnitPrice.Amount)
+
+	// new orders are stamped with their creation time
+	_, err = db.Exec("INSERT INTO orders (id, user_id, restaurant_id, status) VALUES (3, 2, 1, 'draft')")
+	require.NoError(t, err)
+	err = db.QueryRow("SELECT created_at FROM orders WHERE id = 3").Scan(&createdAt)
+	require.NoError(t, err)
+	assert.True(t, createdAt.Valid)
 }
 
 func Test_sqlite_Migrate_DBError(t *testing.T) {
@@ -30,8 +127,7 @@ func Test_sqlite_Migrate_DBError(t *testing.T) {
 	require.NoErrorf(t, err, "an error '%s' was not expected when opening a stub database connection", err)
 	defer db.Close()
 
-	// the starting query of the schema.sql query
-	mock.ExpectExec("CREATE TABLE IF NOT EXISTS users").
+	mock.ExpectExec("CREATE TABLE IF NOT EXISTS schema_migrations").
 		WillReturnError(sqlite3.ErrInternal)
 
 	err = Migrate(db)
@@ -40,3 +136,68 @@ func Test_sqlite_Migrate_DBError(t *testing.T) {
 	err = mock.ExpectationsWereMet()
 	require.NoErrorf(t, err, "there were unfulfilled expectations: %s", err)
 }
+
+func Test_sqlite_Migrate_BackfillsPayments(t *testing.T) {
+	db := openMemoryDB(t)
+
+	_, err := db.Exec(`CREATE TABLE schema_migrations (version INTEGER PRIMARY KEY, applied_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP)`)
+	require.NoError(t, err)
+	entries, err := fs.ReadDir(migrationsFS, migrationsDir)
+	require.NoError(t, err)
+	for _, entry := range entries {
+		version, err := migrationVersion(entry.Name())
+		require.NoError(t, err)
+		if version >= 11 {
+			break
+		}
+		migration, err := fs.ReadFile(migrationsFS, migrationsDir+"/"+entry.Name())
+		require.NoError(t, err)
+		_, err = db.Exec(string(migration))
+		require.NoError(t, err)
+		_, err = db.Exec("INSERT INTO schema_migrations (version) VALUES (?)", version)
+		require.NoError(t, err)
+	}
+
+	_, err = db.Exec(`INSERT INTO orders (id, user_id, restaurant_id, status) VALUES (1, 7, 1, 'placed'), (2, 8, 1, 'placed');
+		INSERT INTO invoices (id, order_id, total, tax, payment_status) VALUES (1, 1, 1000, 100, 'partially_refunded'), (2, 2, 2000, 200, 'paid');
+		INSERT INTO payment_attempts (invoice_id, amount, currency, provider, transaction_id, status) VALUES (1, 1100, 'USD', 'fake', 'fake_1', 'succeeded');
+		INSERT INTO refunds (invoice_id, amount, currency, reason, transaction_id, refunded_by) VALUES (1, 300, 'USD', 'cold food', 'fake_1', 2)`)
+	require.NoError(t, err)
+
+	err = Migrate(db)
+	require.NoError(t, err)
+
+	rows, err := db.Query("SELECT id, invoice_id, payer_id, method, amount, transaction_id FROM payments ORDER BY invoice_id")
+	require.NoError(t, err)
+	defer rows.Close()
+	type payment struct {
+		id, invoiceId, payerId int
+		method                 string
+		amount                 int64
+		transactionId          string
+	}
+	var payments []payment
+	for rows.Next() {
+		var p payment
+		require.NoError(t, rows.Scan(&p.id, &p.invoiceId, &p.payerId, &p.method, &p.amount, &p.transactionId))
+		payments = append(payments, p)
+	}
+	require.NoError(t, rows.Err())
+	require.Len(t, payments, 2)
+	assert.Equal(t, payment{payments[0].id, 1, 7, "card", 1100, "fake_1"}, payments[0])
+	assert.Equal(t, payment{payments[1].id, 2, 8, "cash", 2200, ""}, payments[1])
+
+	var paymentId int
+	err = db.QueryRow("SELECT payment_id FROM refunds WHERE invoice_id = 1").Scan(&paymentId)
+	require.NoError(t, err)
+	assert.Equal(t, payments[0].id, paymentId)
+
+	// payments from before the taker was recorded were taken by their payer
+	var notTakenByPayer, attemptTakenBy int
+	err = db.QueryRow("SELECT COUNT(*) FROM payments WHERE taken_by != payer_id").Scan(&notTakenByPayer)
+	require.NoError(t, err)
+	assert.Zero(t, notTakenByPayer)
+	err = db.QueryRow("SELECT taken_by FROM payment_attempts WHERE invoice_id = 1").Scan(&attemptTakenBy)
+	require.NoError(t, err)
+	assert.Equal(t, 7, attemptTakenBy)
+}
@@ -0,0 +1,5 @@
+ALTER TABLE orders ADD COLUMN status VARCHAR(20) NOT NULL DEFAULT 'draft';
+
+-- orders that were paid for before statuses existed have been placed
+UPDATE orders SET status = 'placed'
+WHERE id IN (SELECT order_id FROM invoices WHERE payment_status = 'paid');
@@ -0,0 +1,9 @@
+-- sqlite cannot add a column defaulting to CURRENT_TIMESTAMP, existing orders
+-- get the time of the migration and new ones are stamped by the trigger
+ALTER TABLE orders ADD COLUMN created_at DATETIME;
+
+UPDATE orders SET created_at = CURRENT_TIMESTAMP;
+
+CREATE TRIGGER IF NOT EXISTS orders_created_at AFTER INSERT ON orders WHEN new.created_at IS NULL BEGIN
+    UPDATE orders SET created_at = CURRENT_TIMESTAMP WHERE id = new.id;
+END;
@@ -0,0 +1,7 @@
+-- order lines keep the name and price of the item when it was ordered
+ALTER TABLE orderitems ADD COLUMN name VARCHAR(100) NOT NULL DEFAULT '';
+ALTER TABLE orderitems ADD COLUMN unit_price INTEGER NOT NULL DEFAULT 0;
+
+UPDATE orderitems SET
+    name = COALESCE((SELECT m.name FROM menuitems m WHERE m.id = orderitems.menuitem_id), ''),
+    unit_price = COALESCE((SELECT m.price FROM menuitems m WHERE m.id = orderitems.menuitem_id), 0);
@@ -0,0 +1,21 @@
+CREATE TABLE IF NOT EXISTS invoice_items (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    invoice_id INTEGER NOT NULL,
+    menuitem_id INTEGER,
+    name VARCHAR(100) NOT NULL,
+    unit_price INTEGER NOT NULL,
+    quantity INTEGER NOT NULL,
+    total INTEGER NOT NULL,
+    tax INTEGER NOT NULL,
+    FOREIGN KEY (invoice_id) REFERENCES invoices(id),
+    FOREIGN KEY (menuitem_id) REFERENCES menuitems(id)
+);
+
+-- existing invoices get the lines of their order, taxed at the flat 10% they
+-- were issued with
+INSERT INTO invoice_items (invoice_id, menuitem_id, name, unit_price, quantity, total, tax)
+SELECT i.id, oi.menuitem_id, oi.name, oi.unit_price, oi.quantity,
+    oi.unit_price * oi.quantity, oi.unit_price * oi.quantity * 0.10
+FROM invoices i
+JOIN orderitems oi ON oi.order_id = i.order_id
+ORDER BY i.id, oi.id;
@@ -0,0 +1,13 @@
+ALTER TABLE menuitems ADD COLUMN category VARCHAR(50) NOT NULL DEFAULT '';
+
+-- restaurant_id NULL applies to all restaurants, category '' to all items
+CREATE TABLE IF NOT EXISTS tax_rules (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    restaurant_id INTEGER,
+    category VARCHAR(50) NOT NULL DEFAULT '',
+    rate REAL NOT NULL,
+    inclusive BOOLEAN NOT NULL DEFAULT FALSE,
+    FOREIGN KEY (restaurant_id) REFERENCES restaurants(id)
+);
+
+INSERT OR IGNORE INTO tax_rules (id, restaurant_id, category, rate, inclusive) VALUES (1, NULL, '', 0.10, FALSE);
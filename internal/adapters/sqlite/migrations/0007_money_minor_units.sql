@@ -0,0 +1,19 @@
+-- money is stored as integer minor units (cents) next to its currency, the
+-- amounts before were decimal dollars
+
+ALTER TABLE menuitems ADD COLUMN price_minor INTEGER NOT NULL DEFAULT 0;
+UPDATE menuitems SET price_minor = CAST(ROUND(price * 100) AS INTEGER);
+ALTER TABLE menuitems DROP COLUMN price;
+ALTER TABLE menuitems RENAME COLUMN price_minor TO price;
+ALTER TABLE menuitems ADD COLUMN currency VARCHAR(3) NOT NULL DEFAULT 'USD';
+
+UPDATE orderitems SET unit_price = CAST(ROUND(unit_price * 100) AS INTEGER);
+ALTER TABLE orderitems ADD COLUMN currency VARCHAR(3) NOT NULL DEFAULT 'USD';
+
+UPDATE invoices SET total = CAST(ROUND(total * 100) AS INTEGER), tax = CAST(ROUND(tax * 100) AS INTEGER);
+ALTER TABLE invoices ADD COLUMN currency VARCHAR(3) NOT NULL DEFAULT 'USD';
+
+UPDATE invoice_items SET
+    unit_price = CAST(ROUND(unit_price * 100) AS INTEGER),
+    total = CAST(ROUND(total * 100) AS INTEGER),
+    tax = CAST(ROUND(tax * 100) AS INTEGER);
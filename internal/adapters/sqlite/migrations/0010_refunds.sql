@@ -0,0 +1,14 @@
+CREATE TABLE IF NOT EXISTS refunds (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    invoice_id INTEGER NOT NULL,
+    amount INTEGER NOT NULL,
+    currency VARCHAR(3) NOT NULL,
+    reason TEXT NOT NULL,
+    transaction_id VARCHAR(100) NOT NULL,
+    refunded_by INTEGER NOT NULL,
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (invoice_id) REFERENCES invoices(id),
+    FOREIGN KEY (refunded_by) REFERENCES users(id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_refunds_invoice_id ON refunds (invoice_id);
@@ -0,0 +1,41 @@
+-- money received for invoices, one row per payment
+CREATE TABLE IF NOT EXISTS payments (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    invoice_id INTEGER NOT NULL,
+    payer_id INTEGER NOT NULL,
+    method VARCHAR(20) NOT NULL,
+    amount INTEGER NOT NULL,
+    tendered INTEGER NOT NULL,
+    change_given INTEGER NOT NULL DEFAULT 0,
+    currency VARCHAR(3) NOT NULL,
+    transaction_id VARCHAR(100) NOT NULL DEFAULT '',
+    paid_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (invoice_id) REFERENCES invoices(id),
+    FOREIGN KEY (payer_id) REFERENCES users(id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_payments_invoice_id ON payments (invoice_id);
+-- a captured transaction is recorded once, whichever of the payment flow or
+-- the provider webhook gets there first
+CREATE UNIQUE INDEX IF NOT EXISTS idx_payments_transaction_id ON payments (transaction_id) WHERE transaction_id != '';
+
+-- card payments captured before payments were recorded
+INSERT INTO payments (invoice_id, payer_id, method, amount, tendered, change_given, currency, transaction_id, paid_at)
+SELECT a.invoice_id, o.user_id, 'card', a.amount, a.amount, 0, a.currency, a.transaction_id, a.updated_at
+FROM payment_attempts a
+JOIN invoices i ON i.id = a.invoice_id
+JOIN orders o ON o.id = i.order_id
+WHERE a.status = 'succeeded';
+
+-- invoices paid before the payment provider was added were settled in full
+-- without a card, they are recorded as cash
+INSERT INTO payments (invoice_id, payer_id, method, amount, tendered, change_given, currency)
+SELECT i.id, o.user_id, 'cash', i.total + i.tax, i.total + i.tax, 0, i.currency
+FROM invoices i
+JOIN orders o ON o.id = i.order_id
+WHERE i.payment_status IN ('paid', 'refund_pending', 'partially_refunded', 'refunded')
+    AND NOT EXISTS (SELECT 1 FROM payments p WHERE p.invoice_id = i.id);
+
+ALTER TABLE refunds ADD COLUMN payment_id INTEGER NOT NULL DEFAULT 0;
+UPDATE refunds SET payment_id = COALESCE(
+    (SELECT p.id FROM payments p WHERE p.transaction_id = refunds.transaction_id AND p.transaction_id != ''), 0);
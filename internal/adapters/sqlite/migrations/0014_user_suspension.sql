@@ -0,0 +1,2 @@
+-- suspended users cannot login or refresh their session
+ALTER TABLE users ADD COLUMN suspended BOOLEAN NOT NULL DEFAULT FALSE;
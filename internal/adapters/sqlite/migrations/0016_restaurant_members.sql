@@ -0,0 +1,12 @@
+-- staff users attached to a restaurant by its owner, role is manager, cashier or kitchen
+CREATE TABLE IF NOT EXISTS restaurant_members (
+    restaurant_id INTEGER NOT NULL,
+    user_id INTEGER NOT NULL,
+    role VARCHAR(20) NOT NULL,
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (restaurant_id, user_id),
+    FOREIGN KEY (restaurant_id) REFERENCES restaurants(id),
+    FOREIGN KEY (user_id) REFERENCES users(id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_restaurant_members_user_id ON restaurant_members (user_id);
@@ -0,0 +1,5 @@
+ALTER TABLE menuitems ADD COLUMN description TEXT NOT NULL DEFAULT '';
+ALTER TABLE menuitems ADD COLUMN image_url VARCHAR(255) NOT NULL DEFAULT '';
+ALTER TABLE menuitems ADD COLUMN sort_order INTEGER NOT NULL DEFAULT 0;
+-- deleted items are kept for the orders and invoices that reference them
+ALTER TABLE menuitems ADD COLUMN deleted BOOLEAN NOT NULL DEFAULT FALSE;
@@ -0,0 +1,34 @@
+-- choices offered on a menu item, like its size or add-ons
+CREATE TABLE IF NOT EXISTS modifier_groups (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    menuitem_id INTEGER NOT NULL,
+    name VARCHAR(100) NOT NULL,
+    min_select INTEGER NOT NULL DEFAULT 0,
+    max_select INTEGER NOT NULL DEFAULT 1,
+    FOREIGN KEY (menuitem_id) REFERENCES menuitems(id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_modifier_groups_menuitem_id ON modifier_groups (menuitem_id);
+
+CREATE TABLE IF NOT EXISTS modifier_options (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    group_id INTEGER NOT NULL,
+    name VARCHAR(100) NOT NULL,
+    price_delta INTEGER NOT NULL DEFAULT 0,
+    currency VARCHAR(3) NOT NULL DEFAULT 'USD',
+    FOREIGN KEY (group_id) REFERENCES modifier_groups(id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_modifier_options_group_id ON modifier_options (group_id);
+
+-- the options chosen for an order line, name and price are kept as they were
+-- when ordered, so option_id has no foreign key
+CREATE TABLE IF NOT EXISTS orderitem_options (
+    orderitem_id INTEGER NOT NULL,
+    option_id INTEGER NOT NULL,
+    name VARCHAR(100) NOT NULL,
+    price_delta INTEGER NOT NULL DEFAULT 0,
+    currency VARCHAR(3) NOT NULL DEFAULT 'USD',
+    PRIMARY KEY (orderitem_id, option_id),
+    FOREIGN KEY (orderitem_id) REFERENCES orderitems(id)
+);
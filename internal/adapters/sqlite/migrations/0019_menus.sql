@@ -0,0 +1,39 @@
+-- named menus of a restaurant, like breakfast or dinner
+CREATE TABLE IF NOT EXISTS menus (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    restaurant_id INTEGER NOT NULL,
+    name VARCHAR(100) NOT NULL,
+    FOREIGN KEY (restaurant_id) REFERENCES restaurants(id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_menus_restaurant_id ON menus (restaurant_id);
+
+-- windows of the day a menu is offered in, as minutes since midnight, a menu
+-- without schedules is offered all day
+CREATE TABLE IF NOT EXISTS menu_schedules (
+    menu_id INTEGER NOT NULL,
+    start_minute INTEGER NOT NULL,
+    end_minute INTEGER NOT NULL,
+    FOREIGN KEY (menu_id) REFERENCES menus(id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_menu_schedules_menu_id ON menu_schedules (menu_id);
+
+CREATE TABLE IF NOT EXISTS menu_categories (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    menu_id INTEGER NOT NULL,
+    name VARCHAR(100) NOT NULL,
+    position INTEGER NOT NULL DEFAULT 0,
+    FOREIGN KEY (menu_id) REFERENCES menus(id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_menu_categories_menu_id ON menu_categories (menu_id);
+
+CREATE TABLE IF NOT EXISTS menu_category_items (
+    category_id INTEGER NOT NULL,
+    menuitem_id INTEGER NOT NULL,
+    position INTEGER NOT NULL DEFAULT 0,
+    PRIMARY KEY (category_id, menuitem_id),
+    FOREIGN KEY (category_id) REFERENCES menu_categories(id),
+    FOREIGN KEY (menuitem_id) REFERENCES menuitems(id)
+);
@@ -0,0 +1,2 @@
+-- quantity left to sell, NULL when the item does not track its stock
+ALTER TABLE menuitems ADD COLUMN stock INTEGER;
@@ -0,0 +1,31 @@
+ALTER TABLE restaurants ADD COLUMN address VARCHAR(255) NOT NULL DEFAULT '';
+ALTER TABLE restaurants ADD COLUMN phone VARCHAR(30) NOT NULL DEFAULT '';
+-- a paused restaurant takes no orders whatever its opening hours
+ALTER TABLE restaurants ADD COLUMN orders_paused BOOLEAN NOT NULL DEFAULT FALSE;
+
+CREATE TABLE IF NOT EXISTS restaurant_cuisines (
+    restaurant_id INTEGER NOT NULL,
+    cuisine VARCHAR(50) NOT NULL,
+    PRIMARY KEY (restaurant_id, cuisine),
+    FOREIGN KEY (restaurant_id) REFERENCES restaurants(id)
+);
+
+-- windows of the week a restaurant is open in, weekday 0 is sunday and the
+-- minutes are since midnight, a restaurant without hours is open all week
+CREATE TABLE IF NOT EXISTS restaurant_hours (
+    restaurant_id INTEGER NOT NULL,
+    weekday INTEGER NOT NULL,
+    start_minute INTEGER NOT NULL,
+    end_minute INTEGER NOT NULL,
+    FOREIGN KEY (restaurant_id) REFERENCES restaurants(id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_restaurant_hours_restaurant_id ON restaurant_hours (restaurant_id);
+
+-- dates, as YYYY-MM-DD, a restaurant is closed all day on
+CREATE TABLE IF NOT EXISTS restaurant_closures (
+    restaurant_id INTEGER NOT NULL,
+    date VARCHAR(10) NOT NULL,
+    PRIMARY KEY (restaurant_id, date),
+    FOREIGN KEY (restaurant_id) REFERENCES restaurants(id)
+);
@@ -0,0 +1,42 @@
+-- full-text index of the restaurant names and the names of the dishes they
+-- serve, the rowid is the restaurant id
+CREATE VIRTUAL TABLE IF NOT EXISTS restaurant_search USING fts5(name, dishes);
+
+INSERT INTO restaurant_search (rowid, name, dishes)
+SELECT r.id, r.name, COALESCE((SELECT group_concat(m.name, ' ') FROM menuitems m WHERE m.restaurant_id = r.id AND m.deleted = FALSE), '')
+FROM restaurants r;
+
+CREATE TRIGGER IF NOT EXISTS restaurants_search_insert AFTER INSERT ON restaurants BEGIN
+    INSERT INTO restaurant_search (rowid, name, dishes) VALUES (new.id, new.name, '');
+END;
+
+CREATE TRIGGER IF NOT EXISTS restaurants_search_update AFTER UPDATE OF name ON restaurants BEGIN
+    UPDATE restaurant_search SET name = new.name WHERE rowid = new.id;
+END;
+
+CREATE TRIGGER IF NOT EXISTS restaurants_search_delete AFTER DELETE ON restaurants BEGIN
+    DELETE FROM restaurant_search WHERE rowid = old.id;
+END;
+
+-- the dishes of a restaurant are rebuilt whenever one of its items changes,
+-- deleted items are left out
+CREATE TRIGGER IF NOT EXISTS menuitems_search_insert AFTER INSERT ON menuitems BEGIN
+    UPDATE restaurant_search
+    SET dishes = COALESCE((SELECT group_concat(name, ' ') FROM menuitems WHERE restaurant_id = new.restaurant_id AND deleted = FALSE), '')
+    WHERE rowid = new.restaurant_id;
+END;
+
+CREATE TRIGGER IF NOT EXISTS menuitems_search_update AFTER UPDATE OF name, deleted, restaurant_id ON menuitems BEGIN
+    UPDATE restaurant_search
+    SET dishes = COALESCE((SELECT group_concat(name, ' ') FROM menuitems WHERE restaurant_id = old.restaurant_id AND deleted = FALSE), '')
+    WHERE rowid = old.restaurant_id;
+    UPDATE restaurant_search
+    SET dishes = COALESCE((SELECT group_concat(name, ' ') FROM menuitems WHERE restaurant_id = new.restaurant_id AND deleted = FALSE), '')
+    WHERE rowid = new.restaurant_id;
+END;
+
+CREATE TRIGGER IF NOT EXISTS menuitems_search_delete AFTER DELETE ON menuitems BEGIN
+    UPDATE restaurant_search
+    SET dishes = COALESCE((SELECT group_concat(name, ' ') FROM menuitems WHERE restaurant_id = old.restaurant_id AND deleted = FALSE), '')
+    WHERE rowid = old.restaurant_id;
+END;
@@ -0,0 +1,9 @@
+-- the user who took the payment, the customer paying themselves or the
+-- cashier taking it for them
+ALTER TABLE payments ADD COLUMN taken_by INTEGER NOT NULL DEFAULT 0;
+UPDATE payments SET taken_by = payer_id;
+
+-- card payments are recorded from their attempt when the provider answers
+ALTER TABLE payment_attempts ADD COLUMN taken_by INTEGER NOT NULL DEFAULT 0;
+UPDATE payment_attempts SET taken_by = COALESCE(
+    (SELECT o.user_id FROM invoices i JOIN orders o ON o.id = i.order_id WHERE i.id = payment_attempts.invoice_id), 0);
@@ -0,0 +1,7 @@
+-- a restaurant has one tax rule per category, set and removed by its owner.
+-- Of duplicates the first one was used, keep it
+DELETE FROM tax_rules WHERE restaurant_id IS NOT NULL AND id NOT IN (
+    SELECT MIN(id) FROM tax_rules WHERE restaurant_id IS NOT NULL GROUP BY restaurant_id, category
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_tax_rules_restaurant_category ON tax_rules (restaurant_id, category) WHERE restaurant_id IS NOT NULL;
@@ -4,8 +4,10 @@ import (
 	"context"
 	"database/sql"
 	"log"
+	"strings"
 
 	"github.com/mohits-git/food-ordering-system/internal/domain"
+	"github.com/mohits-git/food-ordering-system/internal/utils/apperr"
 )
 
 type OrderRepository struct {
@@ -19,22 +21,18 @@ func NewOrderRepository(db *sql.DB) *OrderRepository {
 func (o *OrderRepository) SaveOrder(ctx context.Context, order domain.Order) (int, error) {
 	tx, err := o.db.BeginTx(ctx, nil)
 
-	query := "INSERT INTO orders (user_id, restaurant_id) VALUES (?, ?) RETURNING id"
+	query := "INSERT INTO orders (user_id, restaurant_id, status) VALUES (?, ?, ?) RETURNING id"
 	var id int
-	err = tx.QueryRowContext(ctx, query, order.CustomerID, order.RestaurantID).Scan(&id)
+	err = tx.QueryRowContext(ctx, query, order.CustomerID, order.RestaurantID, order.Status).Scan(&id)
 	if err != nil {
 		tx.Rollback()
 		return 0, HandleSQLiteError(err)
 	}
 
 	// save order items
-	itemQuery := "INSERT INTO orderitems (order_id, menuitem_id, quantity) VALUES (?, ?, ?)"
-	for _, item := range order.OrderItems {
-		_, err := tx.ExecContext(ctx, itemQuery, id, item.MenuItemID, item.Quantity)
-		if err != nil {
-			tx.Rollback()
-			return 0, HandleSQLiteError(err)
-		}
+	if err := saveOrderItems(ctx, tx, id, order.OrderItems); err != nil {
+		tx.Rollback()
+		return 0, err
 	}
 
 	if err := tx.Commit(); err != nil {
@@ -45,8 +43,8 @@ func (o *OrderRepository) SaveOrder(ctx context.Context, order domain.Order) (in
 
 func (o *OrderRepository) FindOrderById(ctx context.Context, id int) (domain.Order, error) {
 	var order domain.Order
-	query := "SELECT id, user_id, restaurant_id FROM orders WHERE id = ?"
-	err := o.db.QueryRowContext(ctx, query, id).Scan(&order.ID, &order.CustomerID, &order.RestaurantID)
+	query := "SELECT id, user_id, restaurant_id, status, created_at FROM orders WHERE id = ?"
+	err := o.db.QueryRowContext(ctx, query, id).Scan(&order.ID, &order.CustomerID, &order.RestaurantID, &order.Status, &order.CreatedAt)
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return domain.Order{}, nil // or return a custom NotFound error
@@ -55,25 +53,197 @@ func (o *OrderRepository) FindOrderById(ctx context.Context, id int) (domain.Ord
 	}
 
 	// fetch order items
-	itemQuery := "SELECT menuitem_id, quantity FROM orderitems WHERE order_id = ?"
-	rows, err := o.db.QueryContext(ctx, itemQuery, id)
+	order.OrderItems, err = o.findOrderItems(ctx, id)
 	if err != nil {
-		return domain.Order{}, HandleSQLiteError(err)
+		return domain.Order{}, err
+	}
+
+	return order, nil
+}
+
+// saveOrderItems inserts the lines of an order with their options, a line
+// without an id gets a new one.
+func saveOrderItems(ctx context.Context, tx *sql.Tx, orderId int, items []domain.OrderItem) error {
+	itemQuery := "INSERT INTO orderitems (id, order_id, menuitem_id, quantity, name, unit_price, currency) VALUES (NULLIF(?, 0), ?, ?, ?, ?, ?, ?)"
+	optionQuery := "INSERT INTO orderitem_options (orderitem_id, option_id, name, price_delta, currency) VALUES (?, ?, ?, ?, ?)"
+	for _, item := range items {
+		result, err := tx.ExecContext(ctx, itemQuery, item.ID, orderId, item.MenuItemID, item.Quantity, item.Name, item.UnitPrice.Amount, item.UnitPrice.Currency)
+		if err != nil {
+			return HandleSQLiteError(err)
+		}
+		if len(item.Options) == 0 {
+			continue
+		}
+
+		itemId := int64(item.ID)
+		if itemId == 0 {
+			itemId, err = result.LastInsertId()
+			if err != nil {
+				return HandleSQLiteError(err)
+			}
+		}
+		for _, option := range item.Options {
+			_, err := tx.ExecContext(ctx, optionQuery, itemId, option.OptionID, option.Name, option.PriceDelta.Amount, option.PriceDelta.Currency)
+			if err != nil {
+				return HandleSQLiteError(err)
+			}
+		}
+	}
+	return nil
+}
+
+func (o *OrderRepository) findOrderItems(ctx context.Context, orderId int) ([]domain.OrderItem, error) {
+	itemQuery := "SELECT id, menuitem_id, quantity, name, unit_price, currency FROM orderitems WHERE order_id = ? ORDER BY id"
+	rows, err := o.db.QueryContext(ctx, itemQuery, orderId)
+	if err != nil {
+		return nil, HandleSQLiteError(err)
 	}
 	defer rows.Close()
 
+	var items []domain.OrderItem
 	for rows.Next() {
 		var item domain.OrderItem
-		if err := rows.Scan(&item.MenuItemID, &item.Quantity); err != nil {
-			return domain.Order{}, HandleSQLiteError(err)
+		if err := rows.Scan(&item.ID, &item.MenuItemID, &item.Quantity, &item.Name, &item.UnitPrice.Amount, &item.UnitPrice.Currency); err != nil {
+			return nil, HandleSQLiteError(err)
 		}
-		order.OrderItems = append(order.OrderItems, item)
+		items = append(items, item)
 	}
 	if err := rows.Err(); err != nil {
-		return domain.Order{}, HandleSQLiteError(err)
+		return nil, HandleSQLiteError(err)
 	}
+	// release the connection before querying item options
+	rows.Close()
 
-	return order, nil
+	if len(items) == 0 {
+		return items, nil
+	}
+	options, err := o.findOrderItemOptions(ctx, orderId)
+	if err != nil {
+		return nil, err
+	}
+	for i := range items {
+		items[i].Options = options[items[i].ID]
+	}
+	return items, nil
+}
+
+// findOrderItemOptions loads the chosen options of the lines of an order, by
+// line id.
+func (o *OrderRepository) findOrderItemOptions(ctx context.Context, orderId int) (map[int][]domain.OrderItemOption, error) {
+	query := "SELECT orderitem_id, option_id, name, price_delta, currency FROM orderitem_options WHERE orderitem_id IN (SELECT id FROM orderitems WHERE order_id = ?) ORDER BY orderitem_id, option_id"
+	rows, err := o.db.QueryContext(ctx, query, orderId)
+	if err != nil {
+		return nil, HandleSQLiteError(err)
+	}
+	defer rows.Close()
+
+	options := make(map[int][]domain.OrderItemOption)
+	for rows.Next() {
+		var itemId int
+		var option domain.OrderItemOption
+		if err := rows.Scan(&itemId, &option.OptionID, &option.Name, &option.PriceDelta.Amount, &option.PriceDelta.Currency); err != nil {
+			return nil, HandleSQLiteError(err)
+		}
+		options[itemId] = append(options[itemId], option)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, HandleSQLiteError(err)
+	}
+	return options, nil
+}
+
+func (o *OrderRepository) FindOrdersByRestaurantId(ctx context.Context, restaurantId int, statuses []domain.OrderStatus) ([]domain.Order, error) {
+	query := "SELECT id, user_id, restaurant_id, status, created_at FROM orders WHERE restaurant_id = ?"
+	args := []any{restaurantId}
+	if len(statuses) > 0 {
+		query += " AND status IN (?" + strings.Repeat(", ?", len(statuses)-1) + ")"
+		for _, status := range statuses {
+			args = append(args, status)
+		}
+	}
+	query += " ORDER BY id"
+
+	return o.findOrders(ctx, query, args...)
+}
+
+func (o *OrderRepository) FindOrdersByCustomerId(ctx context.Context, customerId int, filter domain.OrderFilter) ([]domain.Order, error) {
+	filter.CustomerID = customerId
+	return o.FindOrders(ctx, filter)
+}
+
+// FindOrders lists the orders matching the filter, newest first.
+func (o *OrderRepository) FindOrders(ctx context.Context, filter domain.OrderFilter) ([]domain.Order, error) {
+	conditions := []string{}
+	args := []any{}
+	if filter.CustomerID > 0 {
+		conditions = append(conditions, "user_id = ?")
+		args = append(args, filter.CustomerID)
+	}
+	if filter.RestaurantID > 0 {
+		conditions = append(conditions, "restaurant_id = ?")
+		args = append(args, filter.RestaurantID)
+	}
+	if len(filter.Statuses) > 0 {
+		conditions = append(conditions, "status IN (?"+strings.Repeat(", ?", len(filter.Statuses)-1)+")")
+		for _, status := range filter.Statuses {
+			args = append(args, status)
+		}
+	}
+	if !filter.From.IsZero() {
+		conditions = append(conditions, "created_at >= ?")
+		args = append(args, filter.From.UTC().Format(timestampLayout))
+	}
+	if !filter.To.IsZero() {
+		conditions = append(conditions, "created_at < ?")
+		args = append(args, filter.To.UTC().Format(timestampLayout))
+	}
+	if filter.Cursor > 0 {
+		conditions = append(conditions, "id < ?")
+		args = append(args, filter.Cursor)
+	}
+
+	query := "SELECT id, user_id, restaurant_id, status, created_at FROM orders"
+	if len(conditions) > 0 {
+		query += " WHERE " + strings.Join(conditions, " AND ")
+	}
+	query += " ORDER BY id DESC"
+	if filter.Limit > 0 {
+		query += " LIMIT ?"
+		args = append(args, filter.Limit)
+	}
+
+	return o.findOrders(ctx, query, args...)
+}
+
+// findOrders runs a query selecting order rows and loads the items of each order.
+func (o *OrderRepository) findOrders(ctx context.Context, query string, args ...any) ([]domain.Order, error) {
+	rows, err := o.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, HandleSQLiteError(err)
+	}
+	defer rows.Close()
+
+	orders := []domain.Order{}
+	for rows.Next() {
+		var order domain.Order
+		if err := rows.Scan(&order.ID, &order.CustomerID, &order.RestaurantID, &order.Status, &order.CreatedAt); err != nil {
+			return nil, HandleSQLiteError(err)
+		}
+		orders = append(orders, order)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, HandleSQLiteError(err)
+	}
+	// release the connection before querying order items
+	rows.Close()
+
+	for i := range orders {
+		orders[i].OrderItems, err = o.findOrderItems(ctx, orders[i].ID)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return orders, nil
 }
 
 func (o *OrderRepository) UpdateOrder(ctx context.Context, order domain.Order) error {
@@ -93,7 +263,14 @@ func (o *OrderRepository) UpdateOrder(ctx context.Context, order domain.Order) e
 		return HandleSQLiteError(err)
 	}
 
-	// For simplicity, delete existing items and re-insert
+	// For simplicity, delete existing items and re-insert, they keep their ids
+	delOptionsQuery := "DELETE FROM orderitem_options WHERE orderitem_id IN (SELECT id FROM orderitems WHERE order_id = ?)"
+	_, err = tx.ExecContext(ctx, delOptionsQuery, order.ID)
+	if err != nil {
+		tx.Rollback()
+		return HandleSQLiteError(err)
+	}
+
 	delQuery := "DELETE FROM orderitems WHERE order_id = ?"
 	_, err = tx.ExecContext(ctx, delQuery, order.ID)
 	if err != nil {
@@ -101,13 +278,51 @@ func (o *OrderRepository) UpdateOrder(ctx context.Context, order domain.Order) e
 		return HandleSQLiteError(err)
 	}
 
-	itemQuery := "INSERT INTO orderitems (order_id, menuitem_id, quantity) VALUES (?, ?, ?)"
-	for _, item := range order.OrderItems {
-		_, err := tx.ExecContext(ctx, itemQuery, order.ID, item.MenuItemID, item.Quantity)
-		if err != nil {
-			tx.Rollback()
-			return HandleSQLiteError(err)
+	if err := saveOrderItems(ctx, tx, order.ID, order.OrderItems); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return HandleSQLiteError(err)
+	}
+	return nil
+}
+
+// UpdateOrderStatus also moves the stock of the ordered items, it is taken
+// when the order is placed and put back when the order is cancelled. Placing
+// the order fails with a conflict when an item has not enough stock left.
+func (o *OrderRepository) UpdateOrderStatus(ctx context.Context, id int, status domain.OrderStatus) error {
+	tx, err := o.db.BeginTx(ctx, nil)
+	if err != nil {
+		return HandleSQLiteError(err)
+	}
+
+	var current domain.OrderStatus
+	err = tx.QueryRowContext(ctx, "SELECT status FROM orders WHERE id = ?", id).Scan(&current)
+	if err != nil {
+		tx.Rollback()
+		if err == sql.ErrNoRows {
+			return apperr.NewAppError(apperr.ErrNotFound, "order not found", nil)
 		}
+		return HandleSQLiteError(err)
+	}
+
+	query := "UPDATE orders SET status = ? WHERE id = ?"
+	_, err = tx.ExecContext(ctx, query, status, id)
+	if err != nil {
+		tx.Rollback()
+		return HandleSQLiteError(err)
+	}
+
+	if !current.HoldsStock() && status.HoldsStock() {
+		err = takeStock(ctx, tx, id)
+	} else if current.HoldsStock() && !status.HoldsStock() {
+		err = restoreStock(ctx, tx, id)
+	}
+	if err != nil {
+		tx.Rollback()
+		return err
 	}
 
 	if err := tx.Commit(); err != nil {
@@ -115,3 +330,75 @@ func (o *OrderRepository) UpdateOrder(ctx context.Context, order domain.Order) e
 	}
 	return nil
 }
+
+// stockLine is the quantity of a menu item on an order, across its lines.
+type stockLine struct {
+	menuItemID int
+	name       string
+	quantity   int
+}
+
+func findStockLines(ctx context.Context, tx *sql.Tx, orderId int) ([]stockLine, error) {
+	query := "SELECT menuitem_id, MAX(name), SUM(quantity) FROM orderitems WHERE order_id = ? GROUP BY menuitem_id ORDER BY menuitem_id"
+	rows, err := tx.QueryContext(ctx, query, orderId)
+	if err != nil {
+		return nil, HandleSQLiteError(err)
+	}
+	defer rows.Close()
+
+	var lines []stockLine
+	for rows.Next() {
+		var line stockLine
+		if err := rows.Scan(&line.menuItemID, &line.name, &line.quantity); err != nil {
+			return nil, HandleSQLiteError(err)
+		}
+		lines = append(lines, line)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, HandleSQLiteError(err)
+	}
+	return lines, nil
+}
+
+// takeStock decrements the stock of the tracked items of the order, an item
+// running out is no longer available. The check and the decrement are one
+// statement, so concurrent orders cannot both take the last of an item.
+func takeStock(ctx context.Context, tx *sql.Tx, orderId int) error {
+	lines, err := findStockLines(ctx, tx, orderId)
+	if err != nil {
+		return err
+	}
+
+	query := "UPDATE menuitems SET stock = stock - ?, available = CASE WHEN stock - ? <= 0 THEN FALSE ELSE available END WHERE id = ? AND (stock IS NULL OR stock >= ?)"
+	for _, line := range lines {
+		result, err := tx.ExecContext(ctx, query, line.quantity, line.quantity, line.menuItemID, line.quantity)
+		if err != nil {
+			return HandleSQLiteError(err)
+		}
+		rows, err := result.RowsAffected()
+		if err != nil {
+			return HandleSQLiteError(err)
+		}
+		if rows == 0 {
+			return apperr.NewAppError(apperr.ErrConflict, "not enough stock of "+line.name, nil)
+		}
+	}
+	return nil
+}
+
+// restoreStock puts the items of a cancelled order back in stock, an item
+// that had sold out is available again unless it was deleted.
+func restoreStock(ctx context.Context, tx *sql.Tx, orderId int) error {
+	lines, err := findStockLines(ctx, tx, orderId)
+	if err != nil {
+		return err
+	}
+
+	query := "UPDATE menuitems SET stock = stock + ?, available = CASE WHEN stock <= 0 AND deleted = FALSE THEN TRUE ELSE available END WHERE id = ? AND stock IS NOT NULL"
+	for _, line := range lines {
+		if _, err := tx.ExecContext(ctx, query, line.quantity, line.menuItemID); err != nil {
+			return HandleSQLiteError(err)
+		}
+	}
+	return nil
+}
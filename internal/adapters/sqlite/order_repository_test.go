@@ -4,9 +4,11 @@ import (
 	"context"
 	"database/sql"
 	"testing"
+	"time"
 
 	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/mohits-git/food-ordering-system/internal/domain"
+	"github.com/mohits-git/food-ordering-system/internal/utils/apperr"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -22,19 +24,20 @@ func Test_sqlite_OrderRepository_SaveOrder(t *testing.T) {
 	order := domain.Order{
 		CustomerID:   1,
 		RestaurantID: 2,
+		Status:       domain.OrderDraft,
 		OrderItems: []domain.OrderItem{
-			{MenuItemID: 1, Quantity: 2},
-			{MenuItemID: 2, Quantity: 1},
+			{MenuItemID: 1, Quantity: 2, Name: "Burger", UnitPrice: domain.NewMoney(499, "USD")},
+			{MenuItemID: 2, Quantity: 1, Name: "Fries", UnitPrice: domain.NewMoney(250, "USD")},
 		},
 	}
 
 	mock.ExpectBegin()
 	mock.ExpectQuery("INSERT INTO orders").
-		WithArgs(order.CustomerID, order.RestaurantID).
+		WithArgs(order.CustomerID, order.RestaurantID, order.Status).
 		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
 	for _, item := range order.OrderItems {
 		mock.ExpectExec("INSERT INTO orderitems").
-			WithArgs(1, item.MenuItemID, item.Quantity).
+			WithArgs(item.ID, 1, item.MenuItemID, item.Quantity, item.Name, item.UnitPrice.Amount, item.UnitPrice.Currency).
 			WillReturnResult(sqlmock.NewResult(1, 1))
 	}
 	mock.ExpectCommit()
@@ -47,6 +50,52 @@ func Test_sqlite_OrderRepository_SaveOrder(t *testing.T) {
 	assert.NoErrorf(t, err, "there were unfulfilled expectations: %s", err)
 }
 
+func Test_sqlite_OrderRepository_SaveOrder_with_options(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoErrorf(t, err, "an error '%s' was not expected when opening a stub database connection", err)
+	defer db.Close()
+
+	repo := NewOrderRepository(db)
+
+	order := domain.Order{
+		CustomerID:   1,
+		RestaurantID: 2,
+		Status:       domain.OrderDraft,
+		OrderItems: []domain.OrderItem{
+			{MenuItemID: 1, Quantity: 1, Name: "Pizza", UnitPrice: domain.NewMoney(1400, "USD"), Options: []domain.OrderItemOption{
+				{OptionID: 11, Name: "Large", PriceDelta: domain.NewMoney(300, "USD")},
+				{OptionID: 20, Name: "Extra cheese", PriceDelta: domain.NewMoney(100, "USD")},
+			}},
+			{MenuItemID: 1, Quantity: 2, Name: "Pizza", UnitPrice: domain.NewMoney(1000, "USD")},
+		},
+	}
+
+	mock.ExpectBegin()
+	mock.ExpectQuery("INSERT INTO orders").
+		WithArgs(order.CustomerID, order.RestaurantID, order.Status).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+	mock.ExpectExec(`INSERT INTO orderitems \(id, order_id, menuitem_id, quantity, name, unit_price, currency\) VALUES \(NULLIF\(\?, 0\), \?, \?, \?, \?, \?, \?\)`).
+		WithArgs(0, 1, 1, 1, "Pizza", 1400, "USD").
+		WillReturnResult(sqlmock.NewResult(5, 1))
+	mock.ExpectExec("INSERT INTO orderitem_options").
+		WithArgs(5, 11, "Large", 300, "USD").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("INSERT INTO orderitem_options").
+		WithArgs(5, 20, "Extra cheese", 100, "USD").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("INSERT INTO orderitems").
+		WithArgs(0, 1, 1, 2, "Pizza", 1000, "USD").
+		WillReturnResult(sqlmock.NewResult(6, 1))
+	mock.ExpectCommit()
+
+	id, err := repo.SaveOrder(context.Background(), order)
+	assert.NoErrorf(t, err, "unexpected error: %s", err)
+	assert.Equal(t, 1, id, "expected order ID to be 1, got %d", id)
+
+	err = mock.ExpectationsWereMet()
+	assert.NoErrorf(t, err, "there were unfulfilled expectations: %s", err)
+}
+
 func Test_sqlite_OrderRepository_SaveOrder_InternalFailure(t *testing.T) {
 	db, mock, err := sqlmock.New()
 	require.NoErrorf(t, err, "an error '%s' was not expected when opening a stub database connection", err)
@@ -58,15 +107,16 @@ func Test_sqlite_OrderRepository_SaveOrder_InternalFailure(t *testing.T) {
 	order := domain.Order{
 		CustomerID:   1,
 		RestaurantID: 2,
+		Status:       domain.OrderDraft,
 		OrderItems: []domain.OrderItem{
-			{MenuItemID: 1, Quantity: 2},
-			{MenuItemID: 2, Quantity: 1},
+			{MenuItemID: 1, Quantity: 2, Name: "Burger", UnitPrice: domain.NewMoney(499, "USD")},
+			{MenuItemID: 2, Quantity: 1, Name: "Fries", UnitPrice: domain.NewMoney(250, "USD")},
 		},
 	}
 
 	mock.ExpectBegin()
 	mock.ExpectQuery("INSERT INTO orders").
-		WithArgs(order.CustomerID, order.RestaurantID).
+		WithArgs(order.CustomerID, order.RestaurantID, order.Status).
 		WillReturnError(assert.AnError)
 	mock.ExpectRollback()
 
@@ -87,22 +137,23 @@ func Test_sqlite_OrderRepository_SaveOrder_FailureWhenSavingOrderItems(t *testin
 	order := domain.Order{
 		CustomerID:   1,
 		RestaurantID: 2,
+		Status:       domain.OrderDraft,
 		OrderItems: []domain.OrderItem{
-			{MenuItemID: 1, Quantity: 2},
-			{MenuItemID: 2, Quantity: 1},
+			{MenuItemID: 1, Quantity: 2, Name: "Burger", UnitPrice: domain.NewMoney(499, "USD")},
+			{MenuItemID: 2, Quantity: 1, Name: "Fries", UnitPrice: domain.NewMoney(250, "USD")},
 		},
 	}
 
 	mock.ExpectBegin()
 	mock.ExpectQuery("INSERT INTO orders").
-		WithArgs(order.CustomerID, order.RestaurantID).
+		WithArgs(order.CustomerID, order.RestaurantID, order.Status).
 		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
 	mock.ExpectExec("INSERT INTO orderitems").
-		WithArgs(1, order.OrderItems[0].MenuItemID, order.OrderItems[0].Quantity).
+		WithArgs(order.OrderItems[0].ID, 1, order.OrderItems[0].MenuItemID, order.OrderItems[0].Quantity, order.OrderItems[0].Name, order.OrderItems[0].UnitPrice.Amount, order.OrderItems[0].UnitPrice.Currency).
 		WillReturnResult(sqlmock.NewResult(1, 1))
 		// fail on second insert
 	mock.ExpectExec("INSERT INTO orderitems").
-		WithArgs(1, order.OrderItems[1].MenuItemID, order.OrderItems[1].Quantity).
+		WithArgs(order.OrderItems[1].ID, 1, order.OrderItems[1].MenuItemID, order.OrderItems[1].Quantity, order.OrderItems[1].Name, order.OrderItems[1].UnitPrice.Amount, order.OrderItems[1].UnitPrice.Currency).
 		WillReturnError(assert.AnError)
 
 	// Mock the transaction rollback
@@ -124,22 +175,23 @@ func Test_sqlite_OrderRepository_SaveOrder_FailToCommit(t *testing.T) {
 	order := domain.Order{
 		CustomerID:   1,
 		RestaurantID: 2,
+		Status:       domain.OrderDraft,
 		OrderItems: []domain.OrderItem{
-			{MenuItemID: 1, Quantity: 2},
-			{MenuItemID: 2, Quantity: 1},
+			{MenuItemID: 1, Quantity: 2, Name: "Burger", UnitPrice: domain.NewMoney(499, "USD")},
+			{MenuItemID: 2, Quantity: 1, Name: "Fries", UnitPrice: domain.NewMoney(250, "USD")},
 		},
 	}
 
 	mock.ExpectBegin()
 	mock.ExpectQuery("INSERT INTO orders").
-		WithArgs(order.CustomerID, order.RestaurantID).
+		WithArgs(order.CustomerID, order.RestaurantID, order.Status).
 		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
 	mock.ExpectExec("INSERT INTO orderitems").
-		WithArgs(1, order.OrderItems[0].MenuItemID, order.OrderItems[0].Quantity).
+		WithArgs(order.OrderItems[0].ID, 1, order.OrderItems[0].MenuItemID, order.OrderItems[0].Quantity, order.OrderItems[0].Name, order.OrderItems[0].UnitPrice.Amount, order.OrderItems[0].UnitPrice.Currency).
 		WillReturnResult(sqlmock.NewResult(1, 1))
 		// fail on second insert
 	mock.ExpectExec("INSERT INTO orderitems").
-		WithArgs(1, order.OrderItems[1].MenuItemID, order.OrderItems[1].Quantity).
+		WithArgs(order.OrderItems[1].ID, 1, order.OrderItems[1].MenuItemID, order.OrderItems[1].Quantity, order.OrderItems[1].Name, order.OrderItems[1].UnitPrice.Amount, order.OrderItems[1].UnitPrice.Currency).
 		WillReturnResult(sqlmock.NewResult(1, 1))
 
 	// Mock the transaction rollback
@@ -163,20 +215,29 @@ func Test_sqlite_OrderRepository_FindOrderById(t *testing.T) {
 	ctx := context.Background()
 	orderID := 1
 
-	mock.ExpectQuery("SELECT id, user_id, restaurant_id FROM orders WHERE id = ?").
+	mock.ExpectQuery("SELECT id, user_id, restaurant_id, status, created_at FROM orders WHERE id = ?").
+		WithArgs(orderID).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "restaurant_id", "status", "created_at"}).
+			AddRow(1, 1, 2, "placed", time.Now()))
+	mock.ExpectQuery(`SELECT id, menuitem_id, quantity, name, unit_price, currency FROM orderitems WHERE order_id = \? ORDER BY id`).
 		WithArgs(orderID).
-		WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "restaurant_id"}).
-			AddRow(1, 1, 2))
-	mock.ExpectQuery("SELECT menuitem_id, quantity FROM orderitems WHERE order_id = ?").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "menuitem_id", "quantity", "name", "unit_price", "currency"}).
+			AddRow(11, 1, 2, "Burger", 499, "USD").
+			AddRow(12, 2, 1, "Fries", 250, "USD"))
+	mock.ExpectQuery(`SELECT orderitem_id, option_id, name, price_delta, currency FROM orderitem_options WHERE orderitem_id IN \(SELECT id FROM orderitems WHERE order_id = \?\) ORDER BY orderitem_id, option_id`).
 		WithArgs(orderID).
-		WillReturnRows(sqlmock.NewRows([]string{"menuitem_id", "quantity"}).
-			AddRow(1, 2).
-			AddRow(2, 1))
+		WillReturnRows(sqlmock.NewRows([]string{"orderitem_id", "option_id", "name", "price_delta", "currency"}).
+			AddRow(11, 7, "Extra cheese", 100, "USD"))
 
 	order, err := repo.FindOrderById(ctx, orderID)
 	require.NoError(t, err, "unexpected error while fetching order")
 	assert.Equal(t, orderID, order.ID, "expected order ID to match")
+	assert.Equal(t, domain.OrderPlaced, order.Status, "expected order status to match")
 	assert.Equal(t, 2, len(order.OrderItems), "expected two order items")
+	assert.Equal(t, domain.NewMoney(499, "USD"), order.OrderItems[0].UnitPrice, "expected unit price to be read with its currency")
+	assert.Equal(t, 11, order.OrderItems[0].ID, "expected the order line id to be read")
+	assert.Equal(t, []domain.OrderItemOption{{OptionID: 7, Name: "Extra cheese", PriceDelta: domain.NewMoney(100, "USD")}}, order.OrderItems[0].Options, "expected the options of the line")
+	assert.Empty(t, order.OrderItems[1].Options, "expected no options on the second line")
 
 	err = mock.ExpectationsWereMet()
 	assert.NoErrorf(t, err, "there were unfulfilled expectations: %s", err)
@@ -192,7 +253,7 @@ func Test_sqlite_OrderRepository_FindOrderById_Empty(t *testing.T) {
 	ctx := context.Background()
 	orderID := 1
 
-	mock.ExpectQuery("SELECT id, user_id, restaurant_id FROM orders WHERE id").
+	mock.ExpectQuery("SELECT id, user_id, restaurant_id, status, created_at FROM orders WHERE id").
 		WithArgs(orderID).
 		WillReturnError(sql.ErrNoRows)
 
@@ -215,7 +276,7 @@ func Test_sqlite_OrderRepository_FindOrderById_Failure(t *testing.T) {
 	ctx := context.Background()
 	orderID := 1
 
-	mock.ExpectQuery("SELECT id, user_id, restaurant_id FROM orders WHERE id").
+	mock.ExpectQuery("SELECT id, user_id, restaurant_id, status, created_at FROM orders WHERE id").
 		WithArgs(orderID).
 		WillReturnError(assert.AnError)
 
@@ -241,8 +302,8 @@ func Test_sqlite_OrderRepository_UpdateOrder(t *testing.T) {
 		CustomerID:   1,
 		RestaurantID: 2,
 		OrderItems: []domain.OrderItem{
-			{MenuItemID: 1, Quantity: 3},
-			{MenuItemID: 2, Quantity: 2},
+			{ID: 11, MenuItemID: 1, Quantity: 3, Name: "Burger", UnitPrice: domain.NewMoney(499, "USD")},
+			{ID: 12, MenuItemID: 2, Quantity: 2, Name: "Fries", UnitPrice: domain.NewMoney(250, "USD")},
 		},
 	}
 
@@ -255,7 +316,10 @@ func Test_sqlite_OrderRepository_UpdateOrder(t *testing.T) {
 		WillReturnResult(sqlmock.NewResult(1, 1)).
 		WillReturnError(nil)
 
-	// Mock the delete from orderitems table
+	// Mock the delete of the options and the items
+	mock.ExpectExec(`DELETE FROM orderitem_options WHERE orderitem_id IN \(SELECT id FROM orderitems WHERE order_id = \?\)`).
+		WithArgs(order.ID).
+		WillReturnResult(sqlmock.NewResult(0, 1))
 	mock.ExpectExec("DELETE FROM orderitems").
 		WithArgs(order.ID).
 		WillReturnResult(sqlmock.NewResult(1, 2)).
@@ -264,7 +328,7 @@ func Test_sqlite_OrderRepository_UpdateOrder(t *testing.T) {
 	// Mock the insert into orderitems table
 	for _, item := range order.OrderItems {
 		mock.ExpectExec("INSERT INTO orderitems").
-			WithArgs(order.ID, item.MenuItemID, item.Quantity).
+			WithArgs(item.ID, order.ID, item.MenuItemID, item.Quantity, item.Name, item.UnitPrice.Amount, item.UnitPrice.Currency).
 			WillReturnResult(sqlmock.NewResult(1, 1)).
 			WillReturnError(nil)
 	}
@@ -291,8 +355,8 @@ func Test_sqlite_OrderRepository_UpdateOrder_Failure(t *testing.T) {
 		CustomerID:   1,
 		RestaurantID: 2,
 		OrderItems: []domain.OrderItem{
-			{MenuItemID: 1, Quantity: 3},
-			{MenuItemID: 2, Quantity: 2},
+			{MenuItemID: 1, Quantity: 3, Name: "Burger", UnitPrice: domain.NewMoney(499, "USD")},
+			{MenuItemID: 2, Quantity: 2, Name: "Fries", UnitPrice: domain.NewMoney(250, "USD")},
 		},
 	}
 
@@ -308,3 +372,310 @@ func Test_sqlite_OrderRepository_UpdateOrder_Failure(t *testing.T) {
 	err = mock.ExpectationsWereMet()
 	assert.NoErrorf(t, err, "there were unfulfilled expectations: %s", err)
 }
+
+func Test_sqlite_OrderRepository_UpdateOrderStatus(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoErrorf(t, err, "an error '%s' was not expected when opening a stub database connection", err)
+	defer db.Close()
+
+	repo := NewOrderRepository(db)
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(`SELECT status FROM orders WHERE id = \?`).
+		WithArgs(1).
+		WillReturnRows(sqlmock.NewRows([]string{"status"}).AddRow(domain.OrderPlaced))
+	mock.ExpectExec("UPDATE orders SET status").
+		WithArgs(domain.OrderAccepted, 1).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	err = repo.UpdateOrderStatus(context.Background(), 1, domain.OrderAccepted)
+	assert.NoErrorf(t, err, "unexpected error: %s", err)
+
+	err = mock.ExpectationsWereMet()
+	assert.NoErrorf(t, err, "there were unfulfilled expectations: %s", err)
+}
+
+func Test_sqlite_OrderRepository_UpdateOrderStatus_when_placed(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoErrorf(t, err, "an error '%s' was not expected when opening a stub database connection", err)
+	defer db.Close()
+
+	repo := NewOrderRepository(db)
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(`SELECT status FROM orders WHERE id = \?`).
+		WithArgs(1).
+		WillReturnRows(sqlmock.NewRows([]string{"status"}).AddRow(domain.OrderDraft))
+	mock.ExpectExec("UPDATE orders SET status").
+		WithArgs(domain.OrderPlaced, 1).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectQuery(`SELECT menuitem_id, MAX\(name\), SUM\(quantity\) FROM orderitems WHERE order_id = \? GROUP BY menuitem_id`).
+		WithArgs(1).
+		WillReturnRows(sqlmock.NewRows([]string{"menuitem_id", "name", "quantity"}).AddRow(1, "Burger", 2).AddRow(2, "Cake", 1))
+	mock.ExpectExec(`UPDATE menuitems SET stock = stock - \?`).
+		WithArgs(2, 2, 1, 2).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(`UPDATE menuitems SET stock = stock - \?`).
+		WithArgs(1, 1, 2, 1).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectRollback()
+
+	err = repo.UpdateOrderStatus(context.Background(), 1, domain.OrderPlaced)
+	assert.Truef(t, apperr.IsConflictError(err), "expected conflict error but got %v", err)
+	assert.EqualError(t, err, "not enough stock of Cake")
+
+	err = mock.ExpectationsWereMet()
+	assert.NoErrorf(t, err, "there were unfulfilled expectations: %s", err)
+}
+
+func Test_sqlite_OrderRepository_UpdateOrderStatus_Failure(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoErrorf(t, err, "an error '%s' was not expected when opening a stub database connection", err)
+	defer db.Close()
+
+	repo := NewOrderRepository(db)
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(`SELECT status FROM orders WHERE id = \?`).
+		WithArgs(1).
+		WillReturnRows(sqlmock.NewRows([]string{"status"}).AddRow(domain.OrderPlaced))
+	mock.ExpectExec("UPDATE orders SET status").
+		WithArgs(domain.OrderAccepted, 1).
+		WillReturnError(assert.AnError)
+	mock.ExpectRollback()
+
+	err = repo.UpdateOrderStatus(context.Background(), 1, domain.OrderAccepted)
+	assert.Errorf(t, err, "expected an error but got none")
+
+	err = mock.ExpectationsWereMet()
+	assert.NoErrorf(t, err, "there were unfulfilled expectations: %s", err)
+}
+
+func Test_sqlite_OrderRepository_UpdateOrderStatus_stock(t *testing.T) {
+	db := openMemoryDB(t)
+	require.NoError(t, Migrate(db))
+	_, err := db.Exec(`INSERT INTO users (id, name, email, password, role) VALUES (1, 'Owner', 'owner@example.com', 'hash', 'owner'), (2, 'Customer', 'customer@example.com', 'hash', 'customer');
+		INSERT INTO restaurants (id, name, owner_id) VALUES (1, 'Pizza Place', 1);
+		INSERT INTO menuitems (id, name, price, available, stock, restaurant_id) VALUES
+			(1, 'Cake', 500, TRUE, 3, 1), (2, 'Coffee', 200, TRUE, NULL, 1)`)
+	require.NoError(t, err)
+
+	repo := NewOrderRepository(db)
+	newOrder := func(cakes int) int {
+		id, err := repo.SaveOrder(t.Context(), domain.Order{CustomerID: 2, RestaurantID: 1, Status: domain.OrderDraft, OrderItems: []domain.OrderItem{
+			{MenuItemID: 1, Quantity: cakes, Name: "Cake", UnitPrice: domain.NewMoney(500, "USD")},
+			{MenuItemID: 2, Quantity: 1, Name: "Coffee", UnitPrice: domain.NewMoney(200, "USD")},
+		}})
+		require.NoError(t, err)
+		return id
+	}
+	stockOf := func(id int) (sql.NullInt64, bool) {
+		var stock sql.NullInt64
+		var available bool
+		require.NoError(t, db.QueryRow("SELECT stock, available FROM menuitems WHERE id = ?", id).Scan(&stock, &available))
+		return stock, available
+	}
+
+	first, second := newOrder(2), newOrder(2)
+	require.NoError(t, repo.UpdateOrderStatus(t.Context(), first, domain.OrderPlaced))
+	stock, available := stockOf(1)
+	require.Equal(t, int64(1), stock.Int64)
+	require.True(t, available)
+	coffee, _ := stockOf(2)
+	require.False(t, coffee.Valid, "expected untracked stock to stay untracked")
+
+	// the second order would oversell the cake
+	err = repo.UpdateOrderStatus(t.Context(), second, domain.OrderPlaced)
+	require.Truef(t, apperr.IsConflictError(err), "expected conflict error but got %v", err)
+	order, err := repo.FindOrderById(t.Context(), second)
+	require.NoError(t, err)
+	require.Equal(t, domain.OrderDraft, order.Status)
+	stock, _ = stockOf(1)
+	require.Equal(t, int64(1), stock.Int64)
+
+	third := newOrder(1)
+	require.NoError(t, repo.UpdateOrderStatus(t.Context(), third, domain.OrderPlaced))
+	stock, available = stockOf(1)
+	require.Equal(t, int64(0), stock.Int64)
+	require.False(t, available, "expected item to sell out at zero stock")
+
+	// moving on does not take the stock again, cancelling puts it back
+	require.NoError(t, repo.UpdateOrderStatus(t.Context(), first, domain.OrderAccepted))
+	require.NoError(t, repo.UpdateOrderStatus(t.Context(), first, domain.OrderCancelled))
+	stock, available = stockOf(1)
+	require.Equal(t, int64(2), stock.Int64)
+	require.True(t, available, "expected item to be back on sale")
+
+	// cancelling a draft has nothing to put back
+	require.NoError(t, repo.UpdateOrderStatus(t.Context(), second, domain.OrderCancelled))
+	stock, _ = stockOf(1)
+	require.Equal(t, int64(2), stock.Int64)
+
+	err = repo.UpdateOrderStatus(t.Context(), 99, domain.OrderPlaced)
+	require.Truef(t, apperr.IsNotFoundError(err), "expected not found error but got %v", err)
+}
+
+func Test_sqlite_OrderRepository_FindOrdersByRestaurantId(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoErrorf(t, err, "an error '%s' was not expected when opening a stub database connection", err)
+	defer db.Close()
+
+	repo := NewOrderRepository(db)
+
+	statuses := []domain.OrderStatus{domain.OrderPlaced, domain.OrderAccepted}
+	mock.ExpectQuery(`SELECT id, user_id, restaurant_id, status, created_at FROM orders WHERE restaurant_id = \? AND status IN \(\?, \?\)`).
+		WithArgs(2, domain.OrderPlaced, domain.OrderAccepted).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "restaurant_id", "status", "created_at"}).
+			AddRow(1, 1, 2, "placed", time.Now()).
+			AddRow(3, 4, 2, "accepted", time.Now()))
+	mock.ExpectQuery(`SELECT id, menuitem_id, quantity, name, unit_price, currency FROM orderitems WHERE order_id = \? ORDER BY id`).
+		WithArgs(1).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "menuitem_id", "quantity", "name", "unit_price", "currency"}).AddRow(11, 1, 2, "Burger", 499, "USD"))
+	mock.ExpectQuery(`SELECT orderitem_id, option_id, name, price_delta, currency FROM orderitem_options WHERE orderitem_id IN \(SELECT id FROM orderitems WHERE order_id = \?\) ORDER BY orderitem_id, option_id`).
+		WithArgs(1).
+		WillReturnRows(sqlmock.NewRows([]string{"orderitem_id", "option_id", "name", "price_delta", "currency"}))
+	mock.ExpectQuery(`SELECT id, menuitem_id, quantity, name, unit_price, currency FROM orderitems WHERE order_id = \? ORDER BY id`).
+		WithArgs(3).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "menuitem_id", "quantity", "name", "unit_price", "currency"}).AddRow(31, 5, 1, "Pizza", 1200, "USD"))
+	mock.ExpectQuery(`SELECT orderitem_id, option_id, name, price_delta, currency FROM orderitem_options WHERE orderitem_id IN \(SELECT id FROM orderitems WHERE order_id = \?\) ORDER BY orderitem_id, option_id`).
+		WithArgs(3).
+		WillReturnRows(sqlmock.NewRows([]string{"orderitem_id", "option_id", "name", "price_delta", "currency"}))
+
+	orders, err := repo.FindOrdersByRestaurantId(context.Background(), 2, statuses)
+	require.NoError(t, err, "unexpected error while fetching orders")
+	require.Len(t, orders, 2, "expected two orders")
+	assert.Equal(t, domain.OrderAccepted, orders[1].Status, "expected second order to be accepted")
+	assert.Equal(t, []domain.OrderItem{{ID: 31, MenuItemID: 5, Quantity: 1, Name: "Pizza", UnitPrice: domain.NewMoney(1200, "USD")}}, orders[1].OrderItems)
+
+	err = mock.ExpectationsWereMet()
+	assert.NoErrorf(t, err, "there were unfulfilled expectations: %s", err)
+}
+
+func Test_sqlite_OrderRepository_FindOrdersByRestaurantId_Failure(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoErrorf(t, err, "an error '%s' was not expected when opening a stub database connection", err)
+	defer db.Close()
+
+	repo := NewOrderRepository(db)
+
+	mock.ExpectQuery("SELECT id, user_id, restaurant_id, status, created_at FROM orders WHERE restaurant_id").
+		WithArgs(2).
+		WillReturnError(assert.AnError)
+
+	orders, err := repo.FindOrdersByRestaurantId(context.Background(), 2, nil)
+	assert.Errorf(t, err, "expected an error but got none")
+	assert.Nil(t, orders, "expected no orders on failure")
+
+	err = mock.ExpectationsWereMet()
+	assert.NoErrorf(t, err, "there were unfulfilled expectations: %s", err)
+}
+
+func Test_sqlite_OrderRepository_FindOrdersByCustomerId(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoErrorf(t, err, "an error '%s' was not expected when opening a stub database connection", err)
+	defer db.Close()
+
+	repo := NewOrderRepository(db)
+
+	createdAt := time.Date(2025, 1, 10, 12, 0, 0, 0, time.UTC)
+	filter := domain.OrderFilter{
+		RestaurantID: 2,
+		Statuses:     []domain.OrderStatus{domain.OrderDelivered},
+		From:         time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+		To:           time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC),
+		Cursor:       10,
+		Limit:        21,
+	}
+	mock.ExpectQuery(`SELECT id, user_id, restaurant_id, status, created_at FROM orders WHERE user_id = \? `+
+		`AND restaurant_id = \? AND status IN \(\?\) AND created_at >= \? AND created_at < \? AND id < \? `+
+		`ORDER BY id DESC LIMIT \?`).
+		WithArgs(1, 2, domain.OrderDelivered, "2025-01-01 00:00:00", "2025-02-01 00:00:00", 10, 21).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "restaurant_id", "status", "created_at"}).
+			AddRow(9, 1, 2, "delivered", createdAt))
+	mock.ExpectQuery(`SELECT id, menuitem_id, quantity, name, unit_price, currency FROM orderitems WHERE order_id = \? ORDER BY id`).
+		WithArgs(9).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "menuitem_id", "quantity", "name", "unit_price", "currency"}).AddRow(11, 1, 2, "Burger", 499, "USD"))
+	mock.ExpectQuery(`SELECT orderitem_id, option_id, name, price_delta, currency FROM orderitem_options WHERE orderitem_id IN \(SELECT id FROM orderitems WHERE order_id = \?\) ORDER BY orderitem_id, option_id`).
+		WithArgs(9).
+		WillReturnRows(sqlmock.NewRows([]string{"orderitem_id", "option_id", "name", "price_delta", "currency"}))
+
+	orders, err := repo.FindOrdersByCustomerId(context.Background(), 1, filter)
+	require.NoError(t, err, "unexpected error while fetching orders")
+	require.Len(t, orders, 1, "expected one order")
+	assert.Equal(t, 9, orders[0].ID, "expected order ID to match")
+	assert.Equal(t, createdAt, orders[0].CreatedAt, "expected created at to match")
+
+	err = mock.ExpectationsWereMet()
+	assert.NoErrorf(t, err, "there were unfulfilled expectations: %s", err)
+}
+
+func Test_sqlite_OrderRepository_FindOrdersByCustomerId_NoFilters(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoErrorf(t, err, "an error '%s' was not expected when opening a stub database connection", err)
+	defer db.Close()
+
+	repo := NewOrderRepository(db)
+
+	mock.ExpectQuery(`SELECT id, user_id, restaurant_id, status, created_at FROM orders WHERE user_id = \? ORDER BY id DESC$`).
+		WithArgs(1).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "restaurant_id", "status", "created_at"}))
+
+	orders, err := repo.FindOrdersByCustomerId(context.Background(), 1, domain.OrderFilter{})
+	require.NoError(t, err, "unexpected error while fetching orders")
+	assert.Empty(t, orders, "expected no orders")
+
+	err = mock.ExpectationsWereMet()
+	assert.NoErrorf(t, err, "there were unfulfilled expectations: %s", err)
+}
+
+func Test_sqlite_OrderRepository_FindOrders(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoErrorf(t, err, "an error '%s' was not expected when opening a stub database connection", err)
+	defer db.Close()
+
+	repo := NewOrderRepository(db)
+
+	createdAt := time.Date(2025, 1, 10, 12, 0, 0, 0, time.UTC)
+	mock.ExpectQuery(`SELECT id, user_id, restaurant_id, status, created_at FROM orders WHERE restaurant_id = \? `+
+		`AND status IN \(\?, \?\) ORDER BY id DESC LIMIT \?`).
+		WithArgs(2, domain.OrderPlaced, domain.OrderAccepted, 21).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "restaurant_id", "status", "created_at"}).
+			AddRow(9, 1, 2, "placed", createdAt))
+	mock.ExpectQuery(`SELECT id, menuitem_id, quantity, name, unit_price, currency FROM orderitems WHERE order_id = \? ORDER BY id`).
+		WithArgs(9).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "menuitem_id", "quantity", "name", "unit_price", "currency"}).AddRow(11, 1, 2, "Burger", 499, "USD"))
+	mock.ExpectQuery(`SELECT orderitem_id, option_id, name, price_delta, currency FROM orderitem_options WHERE orderitem_id IN \(SELECT id FROM orderitems WHERE order_id = \?\) ORDER BY orderitem_id, option_id`).
+		WithArgs(9).
+		WillReturnRows(sqlmock.NewRows([]string{"orderitem_id", "option_id", "name", "price_delta", "currency"}))
+
+	orders, err := repo.FindOrders(context.Background(), domain.OrderFilter{
+		RestaurantID: 2,
+		Statuses:     []domain.OrderStatus{domain.OrderPlaced, domain.OrderAccepted},
+		Limit:        21,
+	})
+	require.NoError(t, err, "unexpected error while fetching orders")
+	require.Len(t, orders, 1, "expected one order")
+	assert.Equal(t, 1, orders[0].CustomerID, "expected customer ID to match")
+
+	err = mock.ExpectationsWereMet()
+	assert.NoErrorf(t, err, "there were unfulfilled expectations: %s", err)
+}
+
+func Test_sqlite_OrderRepository_FindOrders_NoFilters(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoErrorf(t, err, "an error '%s' was not expected when opening a stub database connection", err)
+	defer db.Close()
+
+	repo := NewOrderRepository(db)
+
+	mock.ExpectQuery(`SELECT id, user_id, restaurant_id, status, created_at FROM orders ORDER BY id DESC$`).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "restaurant_id", "status", "created_at"}))
+
+	orders, err := repo.FindOrders(context.Background(), domain.OrderFilter{})
+	require.NoError(t, err, "unexpected error while fetching orders")
+	assert.Empty(t, orders, "expected no orders")
+
+	err = mock.ExpectationsWereMet()
+	assert.NoErrorf(t, err, "there were unfulfilled expectations: %s", err)
+}
@@ -0,0 +1,75 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/mohits-git/food-ordering-system/internal/domain"
+)
+
+type PaymentAttemptRepository struct {
+	db *sql.DB
+}
+
+func NewPaymentAttemptRepository(db *sql.DB) *PaymentAttemptRepository {
+	return &PaymentAttemptRepository{db: db}
+}
+
+func (r *PaymentAttemptRepository) SavePaymentAttempt(ctx context.Context, attempt domain.PaymentAttempt) (int, error) {
+	query := `INSERT INTO payment_attempts (invoice_id, amount, currency, provider, taken_by, transaction_id, status, failure_reason) VALUES (?, ?, ?, ?, ?, ?, ?, ?) RETURNING id`
+	var id int
+	err := r.db.QueryRowContext(ctx, query, attempt.InvoiceID, attempt.Amount.Amount, attempt.Amount.Currency,
+		attempt.Provider, attempt.TakenBy, attempt.TransactionID, attempt.Status, attempt.FailureReason).Scan(&id)
+	if err != nil {
+		return 0, HandleSQLiteError(err)
+	}
+	return id, nil
+}
+
+func (r *PaymentAttemptRepository) UpdatePaymentAttempt(ctx context.Context, attempt domain.PaymentAttempt) error {
+	query := `UPDATE payment_attempts SET transaction_id = ?, status = ?, failure_reason = ?, updated_at = CURRENT_TIMESTAMP WHERE id = ?`
+	_, err := r.db.ExecContext(ctx, query, attempt.TransactionID, attempt.Status, attempt.FailureReason, attempt.ID)
+	if err != nil {
+		return HandleSQLiteError(err)
+	}
+	return nil
+}
+
+func (r *PaymentAttemptRepository) FindPaymentAttemptsByInvoiceId(ctx context.Context, invoiceId int) ([]domain.PaymentAttempt, error) {
+	query := `SELECT id, invoice_id, amount, currency, provider, taken_by, transaction_id, status, failure_reason, created_at FROM payment_attempts WHERE invoice_id = ? ORDER BY id`
+	rows, err := r.db.QueryContext(ctx, query, invoiceId)
+	if err != nil {
+		return nil, HandleSQLiteError(err)
+	}
+	defer rows.Close()
+
+	attempts := []domain.PaymentAttempt{}
+	for rows.Next() {
+		var attempt domain.PaymentAttempt
+		err := rows.Scan(&attempt.ID, &attempt.InvoiceID, &attempt.Amount.Amount, &attempt.Amount.Currency,
+			&attempt.Provider, &attempt.TakenBy, &attempt.TransactionID, &attempt.Status, &attempt.FailureReason, &attempt.CreatedAt)
+		if err != nil {
+			return nil, HandleSQLiteError(err)
+		}
+		attempts = append(attempts, attempt)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, HandleSQLiteError(err)
+	}
+	return attempts, nil
+}
+
+func (r *PaymentAttemptRepository) FindPaymentAttemptByTransactionId(ctx context.Context, provider string, transactionId string) (domain.PaymentAttempt, error) {
+	query := `SELECT id, invoice_id, amount, currency, provider, taken_by, transaction_id, status, failure_reason, created_at FROM payment_attempts WHERE provider = ? AND transaction_id = ?`
+	var attempt domain.PaymentAttempt
+	err := r.db.QueryRowContext(ctx, query, provider, transactionId).Scan(&attempt.ID, &attempt.InvoiceID, &attempt.Amount.Amount, &attempt.Amount.Currency,
+		&attempt.Provider, &attempt.TakenBy, &attempt.TransactionID, &attempt.Status, &attempt.FailureReason, &attempt.CreatedAt)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return domain.PaymentAttempt{}, nil
+		}
+		return domain.PaymentAttempt{}, HandleSQLiteError(err)
+	}
+	return attempt, nil
+}
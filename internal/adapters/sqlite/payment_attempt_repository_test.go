@@ -0,0 +1,107 @@
+package sqlite
+
+import (
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/mohits-git/food-ordering-system/internal/domain"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_sqlite_PaymentAttemptRepository_SavePaymentAttempt(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err, "Expected no error when creating sqlmock")
+	defer db.Close()
+
+	repo := NewPaymentAttemptRepository(db)
+	require.NotNil(t, repo, "Expected NewPaymentAttemptRepository to return a non-nil repository")
+
+	attempt := domain.NewPaymentAttempt(1, domain.NewMoney(1100, "USD"), "fake")
+	attempt.TakenBy = 5
+	mock.ExpectQuery("INSERT INTO payment_attempts").
+		WithArgs(1, 1100, "USD", "fake", 5, "", domain.AttemptPending, "").
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(3))
+
+	id, err := repo.SavePaymentAttempt(t.Context(), attempt)
+	require.NoError(t, err)
+	require.Equal(t, 3, id)
+
+	err = mock.ExpectationsWereMet()
+	require.NoError(t, err, "There were unfulfilled expectations")
+}
+
+func Test_sqlite_PaymentAttemptRepository_UpdatePaymentAttempt(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err, "Expected no error when creating sqlmock")
+	defer db.Close()
+
+	repo := NewPaymentAttemptRepository(db)
+
+	attempt := domain.NewPaymentAttempt(1, domain.NewMoney(1100, "USD"), "fake")
+	attempt.ID = 3
+	attempt.Fail("fake_1", "card declined")
+	mock.ExpectExec("UPDATE payment_attempts SET transaction_id = \\?, status = \\?, failure_reason = \\?").
+		WithArgs("fake_1", domain.AttemptFailed, "card declined", 3).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	err = repo.UpdatePaymentAttempt(t.Context(), attempt)
+	require.NoError(t, err)
+
+	err = mock.ExpectationsWereMet()
+	require.NoError(t, err, "There were unfulfilled expectations")
+}
+
+func Test_sqlite_PaymentAttemptRepository_FindPaymentAttemptsByInvoiceId(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err, "Expected no error when creating sqlmock")
+	defer db.Close()
+
+	repo := NewPaymentAttemptRepository(db)
+
+	createdAt := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+	mock.ExpectQuery("SELECT (.+) FROM payment_attempts WHERE invoice_id = \\?").
+		WithArgs(1).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "invoice_id", "amount", "currency", "provider", "taken_by", "transaction_id", "status", "failure_reason", "created_at"}).
+			AddRow(1, 1, 1100, "USD", "fake", 5, "fake_1", "failed", "card declined", createdAt).
+			AddRow(2, 1, 1100, "USD", "fake", 5, "fake_2", "succeeded", "", createdAt))
+
+	attempts, err := repo.FindPaymentAttemptsByInvoiceId(t.Context(), 1)
+	require.NoError(t, err)
+	require.Equal(t, []domain.PaymentAttempt{
+		{ID: 1, InvoiceID: 1, Amount: domain.NewMoney(1100, "USD"), Provider: "fake", TakenBy: 5, TransactionID: "fake_1", Status: domain.AttemptFailed, FailureReason: "card declined", CreatedAt: createdAt},
+		{ID: 2, InvoiceID: 1, Amount: domain.NewMoney(1100, "USD"), Provider: "fake", TakenBy: 5, TransactionID: "fake_2", Status: domain.AttemptSucceeded, CreatedAt: createdAt},
+	}, attempts)
+
+	err = mock.ExpectationsWereMet()
+	require.NoError(t, err, "There were unfulfilled expectations")
+}
+
+func Test_sqlite_PaymentAttemptRepository_FindPaymentAttemptByTransactionId(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err, "Expected no error when creating sqlmock")
+	defer db.Close()
+
+	repo := NewPaymentAttemptRepository(db)
+
+	createdAt := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+	columns := []string{"id", "invoice_id", "amount", "currency", "provider", "taken_by", "transaction_id", "status", "failure_reason", "created_at"}
+	mock.ExpectQuery("SELECT (.+) FROM payment_attempts WHERE provider = \\? AND transaction_id = \\?").
+		WithArgs("fake", "fake_1").
+		WillReturnRows(sqlmock.NewRows(columns).
+			AddRow(1, 2, 1100, "USD", "fake", 2, "fake_1", "pending", "", createdAt))
+	mock.ExpectQuery("SELECT (.+) FROM payment_attempts WHERE provider = \\? AND transaction_id = \\?").
+		WithArgs("fake", "unknown").
+		WillReturnRows(sqlmock.NewRows(columns))
+
+	attempt, err := repo.FindPaymentAttemptByTransactionId(t.Context(), "fake", "fake_1")
+	require.NoError(t, err)
+	require.Equal(t, domain.PaymentAttempt{ID: 1, InvoiceID: 2, Amount: domain.NewMoney(1100, "USD"), Provider: "fake", TakenBy: 2, TransactionID: "fake_1", Status: domain.AttemptPending, CreatedAt: createdAt}, attempt)
+
+	attempt, err = repo.FindPaymentAttemptByTransactionId(t.Context(), "fake", "unknown")
+	require.NoError(t, err)
+	require.Zero(t, attempt.ID, "expected no attempt for an unknown transaction")
+
+	err = mock.ExpectationsWereMet()
+	require.NoError(t, err, "There were unfulfilled expectations")
+}
@@ -0,0 +1,53 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/mohits-git/food-ordering-system/internal/domain"
+)
+
+type PaymentEventRepository struct {
+	db *sql.DB
+}
+
+func NewPaymentEventRepository(db *sql.DB) *PaymentEventRepository {
+	return &PaymentEventRepository{db: db}
+}
+
+// SavePaymentEvent stores an event once per provider event id, saving it
+// again gives a conflict error.
+func (r *PaymentEventRepository) SavePaymentEvent(ctx context.Context, event domain.PaymentEvent) (int, error) {
+	query := `INSERT INTO payment_events (provider, event_id, type, transaction_id, payload, status, error) VALUES (?, ?, ?, ?, ?, ?, ?) RETURNING id`
+	var id int
+	err := r.db.QueryRowContext(ctx, query, event.Provider, event.EventID, event.Type, event.TransactionID,
+		event.Payload, event.Status, event.Error).Scan(&id)
+	if err != nil {
+		return 0, HandleSQLiteError(err)
+	}
+	return id, nil
+}
+
+func (r *PaymentEventRepository) FindPaymentEvent(ctx context.Context, provider string, eventId string) (domain.PaymentEvent, error) {
+	query := `SELECT id, provider, event_id, type, transaction_id, payload, status, error, received_at FROM payment_events WHERE provider = ? AND event_id = ?`
+	var event domain.PaymentEvent
+	err := r.db.QueryRowContext(ctx, query, provider, eventId).Scan(&event.ID, &event.Provider, &event.EventID, &event.Type,
+		&event.TransactionID, &event.Payload, &event.Status, &event.Error, &event.ReceivedAt)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return domain.PaymentEvent{}, nil
+		}
+		return domain.PaymentEvent{}, HandleSQLiteError(err)
+	}
+	return event, nil
+}
+
+func (r *PaymentEventRepository) UpdatePaymentEventStatus(ctx context.Context, id int, status domain.PaymentEventStatus, errMsg string) error {
+	query := `UPDATE payment_events SET status = ?, error = ? WHERE id = ?`
+	_, err := r.db.ExecContext(ctx, query, status, errMsg, id)
+	if err != nil {
+		return HandleSQLiteError(err)
+	}
+	return nil
+}
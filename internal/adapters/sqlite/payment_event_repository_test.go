@@ -0,0 +1,95 @@
+package sqlite
+
+import (
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/mattn/go-sqlite3"
+	"github.com/mohits-git/food-ordering-system/internal/domain"
+	"github.com/mohits-git/food-ordering-system/internal/utils/apperr"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_sqlite_PaymentEventRepository_SavePaymentEvent(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err, "Expected no error when creating sqlmock")
+	defer db.Close()
+
+	repo := NewPaymentEventRepository(db)
+	require.NotNil(t, repo, "Expected NewPaymentEventRepository to return a non-nil repository")
+
+	event := domain.PaymentEvent{Provider: "fake", EventID: "evt_1", Type: domain.EventPaymentCaptured, TransactionID: "fake_1", Payload: "{}", Status: domain.EventReceived}
+	mock.ExpectQuery("INSERT INTO payment_events").
+		WithArgs("fake", "evt_1", domain.EventPaymentCaptured, "fake_1", "{}", domain.EventReceived, "").
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+	mock.ExpectQuery("INSERT INTO payment_events").
+		WithArgs("fake", "evt_1", domain.EventPaymentCaptured, "fake_1", "{}", domain.EventReceived, "").
+		WillReturnError(sqlite3.Error{Code: sqlite3.ErrConstraint, ExtendedCode: sqlite3.ErrConstraintUnique})
+
+	id, err := repo.SavePaymentEvent(t.Context(), event)
+	require.NoError(t, err)
+	require.Equal(t, 1, id)
+
+	_, err = repo.SavePaymentEvent(t.Context(), event)
+	require.True(t, apperr.IsConflictError(err), "expected the same event id to conflict")
+
+	err = mock.ExpectationsWereMet()
+	require.NoError(t, err, "There were unfulfilled expectations")
+}
+
+func Test_sqlite_PaymentEventRepository_FindPaymentEvent(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err, "Expected no error when creating sqlmock")
+	defer db.Close()
+
+	repo := NewPaymentEventRepository(db)
+
+	receivedAt := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+	columns := []string{"id", "provider", "event_id", "type", "transaction_id", "payload", "status", "error", "received_at"}
+	mock.ExpectQuery("SELECT (.+) FROM payment_events WHERE provider = \\? AND event_id = \\?").
+		WithArgs("fake", "evt_1").
+		WillReturnRows(sqlmock.NewRows(columns).
+			AddRow(1, "fake", "evt_1", "payment.captured", "fake_1", "{}", "processed", "", receivedAt))
+	mock.ExpectQuery("SELECT (.+) FROM payment_events WHERE provider = \\? AND event_id = \\?").
+		WithArgs("fake", "evt_2").
+		WillReturnRows(sqlmock.NewRows(columns))
+
+	event, err := repo.FindPaymentEvent(t.Context(), "fake", "evt_1")
+	require.NoError(t, err)
+	require.Equal(t, domain.PaymentEvent{
+		ID:            1,
+		Provider:      "fake",
+		EventID:       "evt_1",
+		Type:          domain.EventPaymentCaptured,
+		TransactionID: "fake_1",
+		Payload:       "{}",
+		Status:        domain.EventProcessed,
+		ReceivedAt:    receivedAt,
+	}, event)
+
+	event, err = repo.FindPaymentEvent(t.Context(), "fake", "evt_2")
+	require.NoError(t, err)
+	require.Zero(t, event.ID, "expected no event for an unknown id")
+
+	err = mock.ExpectationsWereMet()
+	require.NoError(t, err, "There were unfulfilled expectations")
+}
+
+func Test_sqlite_PaymentEventRepository_UpdatePaymentEventStatus(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err, "Expected no error when creating sqlmock")
+	defer db.Close()
+
+	repo := NewPaymentEventRepository(db)
+
+	mock.ExpectExec("UPDATE payment_events SET status = \\?, error = \\? WHERE id = \\?").
+		WithArgs(domain.EventFailed, "payment attempt not found", 1).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	err = repo.UpdatePaymentEventStatus(t.Context(), 1, domain.EventFailed, "payment attempt not found")
+	require.NoError(t, err)
+
+	err = mock.ExpectationsWereMet()
+	require.NoError(t, err, "There were unfulfilled expectations")
+}
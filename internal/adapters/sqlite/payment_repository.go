@@ -0,0 +1,57 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/mohits-git/food-ordering-system/internal/domain"
+)
+
+type PaymentRepository struct {
+	db *sql.DB
+}
+
+func NewPaymentRepository(db *sql.DB) *PaymentRepository {
+	return &PaymentRepository{db: db}
+}
+
+// SavePayment stores a payment, saving a second payment with the same
+// transaction id gives a conflict error.
+func (r *PaymentRepository) SavePayment(ctx context.Context, payment domain.Payment) (int, error) {
+	query := `INSERT INTO payments (invoice_id, payer_id, taken_by, method, amount, tendered, change_given, currency, transaction_id) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?) RETURNING id`
+	var id int
+	err := r.db.QueryRowContext(ctx, query, payment.InvoiceID, payment.PayerID, payment.TakenBy, payment.Method, payment.Amount.Amount,
+		payment.Tendered.Amount, payment.Change.Amount, payment.Amount.Currency, payment.TransactionID).Scan(&id)
+	if err != nil {
+		return 0, HandleSQLiteError(err)
+	}
+	return id, nil
+}
+
+func (r *PaymentRepository) FindPaymentsByInvoiceId(ctx context.Context, invoiceId int) ([]domain.Payment, error) {
+	query := `SELECT id, invoice_id, payer_id, taken_by, method, amount, tendered, change_given, currency, transaction_id, paid_at FROM payments WHERE invoice_id = ? ORDER BY id`
+	rows, err := r.db.QueryContext(ctx, query, invoiceId)
+	if err != nil {
+		return nil, HandleSQLiteError(err)
+	}
+	defer rows.Close()
+
+	payments := []domain.Payment{}
+	for rows.Next() {
+		var payment domain.Payment
+		var currency string
+		err := rows.Scan(&payment.ID, &payment.InvoiceID, &payment.PayerID, &payment.TakenBy, &payment.Method, &payment.Amount.Amount,
+			&payment.Tendered.Amount, &payment.Change.Amount, &currency, &payment.TransactionID, &payment.PaidAt)
+		if err != nil {
+			return nil, HandleSQLiteError(err)
+		}
+		payment.Amount.Currency = currency
+		payment.Tendered.Currency = currency
+		payment.Change.Currency = currency
+		payments = append(payments, payment)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, HandleSQLiteError(err)
+	}
+	return payments, nil
+}
@@ -0,0 +1,74 @@
+package sqlite
+
+import (
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/mattn/go-sqlite3"
+	"github.com/mohits-git/food-ordering-system/internal/domain"
+	"github.com/mohits-git/food-ordering-system/internal/utils/apperr"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_sqlite_PaymentRepository_SavePayment(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err, "Expected no error when creating sqlmock")
+	defer db.Close()
+
+	repo := NewPaymentRepository(db)
+	require.NotNil(t, repo, "Expected NewPaymentRepository to return a non-nil repository")
+
+	// a cashier takes the payment of the customer
+	payment := domain.NewPayment(1, 2, domain.PaymentCash, domain.NewMoney(5000, "USD"), domain.NewMoney(4400, "USD"))
+	payment.TakenBy = 5
+	mock.ExpectQuery("INSERT INTO payments").
+		WithArgs(1, 2, 5, domain.PaymentCash, int64(4400), int64(5000), int64(600), "USD", "").
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(9))
+
+	id, err := repo.SavePayment(t.Context(), payment)
+	require.NoError(t, err)
+	require.Equal(t, 9, id)
+
+	card := domain.Payment{InvoiceID: 1, PayerID: 2, TakenBy: 2, Method: domain.PaymentCard, Amount: domain.NewMoney(4400, "USD"), Tendered: domain.NewMoney(4400, "USD"), TransactionID: "fake_1"}
+	mock.ExpectQuery("INSERT INTO payments").
+		WithArgs(1, 2, 2, domain.PaymentCard, int64(4400), int64(4400), int64(0), "USD", "fake_1").
+		WillReturnError(sqlite3.Error{Code: sqlite3.ErrConstraint, ExtendedCode: sqlite3.ErrConstraintUnique})
+
+	_, err = repo.SavePayment(t.Context(), card)
+	require.True(t, apperr.IsConflictError(err), "expected a recorded transaction to conflict")
+
+	err = mock.ExpectationsWereMet()
+	require.NoError(t, err, "There were unfulfilled expectations")
+}
+
+func Test_sqlite_PaymentRepository_FindPaymentsByInvoiceId(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err, "Expected no error when creating sqlmock")
+	defer db.Close()
+
+	repo := NewPaymentRepository(db)
+
+	paidAt := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+	mock.ExpectQuery("SELECT (.+) FROM payments WHERE invoice_id = \\?").
+		WithArgs(1).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "invoice_id", "payer_id", "taken_by", "method", "amount", "tendered", "change_given", "currency", "transaction_id", "paid_at"}).
+			AddRow(9, 1, 2, 5, "cash", 4400, 5000, 600, "USD", "", paidAt))
+
+	payments, err := repo.FindPaymentsByInvoiceId(t.Context(), 1)
+	require.NoError(t, err)
+	require.Equal(t, []domain.Payment{{
+		ID:        9,
+		InvoiceID: 1,
+		PayerID:   2,
+		TakenBy:   5,
+		Method:    domain.PaymentCash,
+		Amount:    domain.NewMoney(4400, "USD"),
+		Tendered:  domain.NewMoney(5000, "USD"),
+		Change:    domain.NewMoney(600, "USD"),
+		PaidAt:    paidAt,
+	}}, payments)
+
+	err = mock.ExpectationsWereMet()
+	require.NoError(t, err, "There were unfulfilled expectations")
+}
@@ -0,0 +1,83 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/mohits-git/food-ordering-system/internal/domain"
+)
+
+type RefreshTokenRepository struct {
+	db *sql.DB
+}
+
+func NewRefreshTokenRepository(db *sql.DB) *RefreshTokenRepository {
+	return &RefreshTokenRepository{db: db}
+}
+
+func (r *RefreshTokenRepository) SaveRefreshToken(ctx context.Context, token domain.RefreshToken) (int, error) {
+	query := `INSERT INTO refresh_tokens (user_id, session_id, token_hash, expires_at) VALUES (?, ?, ?, ?) RETURNING id`
+	var id int
+	err := r.db.QueryRowContext(ctx, query, token.UserID, token.SessionID, token.TokenHash,
+		token.ExpiresAt.UTC().Format(timestampLayout)).Scan(&id)
+	if err != nil {
+		return 0, HandleSQLiteError(err)
+	}
+	return id, nil
+}
+
+func (r *RefreshTokenRepository) FindRefreshTokenByHash(ctx context.Context, tokenHash string) (domain.RefreshToken, error) {
+	query := `SELECT id, user_id, session_id, token_hash, expires_at, revoked, created_at FROM refresh_tokens WHERE token_hash = ?`
+	var token domain.RefreshToken
+	err := r.db.QueryRowContext(ctx, query, tokenHash).Scan(&token.ID, &token.UserID, &token.SessionID, &token.TokenHash,
+		&token.ExpiresAt, &token.Revoked, &token.CreatedAt)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return domain.RefreshToken{}, nil
+		}
+		return domain.RefreshToken{}, HandleSQLiteError(err)
+	}
+	return token, nil
+}
+
+func (r *RefreshTokenRepository) RevokeRefreshToken(ctx context.Context, id int) (bool, error) {
+	query := `UPDATE refresh_tokens SET revoked = TRUE WHERE id = ? AND revoked = FALSE`
+	result, err := r.db.ExecContext(ctx, query, id)
+	if err != nil {
+		return false, HandleSQLiteError(err)
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return false, HandleSQLiteError(err)
+	}
+	return rows > 0, nil
+}
+
+func (r *RefreshTokenRepository) RevokeSession(ctx context.Context, sessionId string) error {
+	query := `UPDATE refresh_tokens SET revoked = TRUE WHERE session_id = ?`
+	_, err := r.db.ExecContext(ctx, query, sessionId)
+	if err != nil {
+		return HandleSQLiteError(err)
+	}
+	return nil
+}
+
+func (r *RefreshTokenRepository) RevokeUserSessions(ctx context.Context, userId int) error {
+	query := `UPDATE refresh_tokens SET revoked = TRUE WHERE user_id = ?`
+	_, err := r.db.ExecContext(ctx, query, userId)
+	if err != nil {
+		return HandleSQLiteError(err)
+	}
+	return nil
+}
+
+func (r *RefreshTokenRepository) DeleteExpiredRefreshTokens(ctx context.Context, now time.Time) error {
+	query := `DELETE FROM refresh_tokens WHERE expires_at <= ?`
+	_, err := r.db.ExecContext(ctx, query, now.UTC().Format(timestampLayout))
+	if err != nil {
+		return HandleSQLiteError(err)
+	}
+	return nil
+}
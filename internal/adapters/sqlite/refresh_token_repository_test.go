@@ -0,0 +1,128 @@
+package sqlite
+
+import (
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/mohits-git/food-ordering-system/internal/domain"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_sqlite_RefreshTokenRepository_SaveRefreshToken(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err, "Expected no error when creating sqlmock")
+	defer db.Close()
+
+	repo := NewRefreshTokenRepository(db)
+	require.NotNil(t, repo, "Expected NewRefreshTokenRepository to return a non-nil repository")
+
+	expiresAt := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+	mock.ExpectQuery("INSERT INTO refresh_tokens").
+		WithArgs(1, "session-1", "hash", "2025-01-02 03:04:05").
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(4))
+
+	id, err := repo.SaveRefreshToken(t.Context(), domain.NewRefreshToken(1, "session-1", "hash", expiresAt))
+	require.NoError(t, err)
+	require.Equal(t, 4, id)
+
+	err = mock.ExpectationsWereMet()
+	require.NoError(t, err, "There were unfulfilled expectations")
+}
+
+func Test_sqlite_RefreshTokenRepository_FindRefreshTokenByHash(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err, "Expected no error when creating sqlmock")
+	defer db.Close()
+
+	repo := NewRefreshTokenRepository(db)
+
+	createdAt := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+	expiresAt := createdAt.Add(time.Hour)
+	columns := []string{"id", "user_id", "session_id", "token_hash", "expires_at", "revoked", "created_at"}
+	mock.ExpectQuery("SELECT (.+) FROM refresh_tokens WHERE token_hash = \\?").
+		WithArgs("hash").
+		WillReturnRows(sqlmock.NewRows(columns).AddRow(4, 1, "session-1", "hash", expiresAt, true, createdAt))
+	mock.ExpectQuery("SELECT (.+) FROM refresh_tokens WHERE token_hash = \\?").
+		WithArgs("missing").
+		WillReturnRows(sqlmock.NewRows(columns))
+
+	token, err := repo.FindRefreshTokenByHash(t.Context(), "hash")
+	require.NoError(t, err)
+	require.Equal(t, domain.RefreshToken{ID: 4, UserID: 1, SessionID: "session-1", TokenHash: "hash", ExpiresAt: expiresAt, Revoked: true, CreatedAt: createdAt}, token)
+
+	token, err = repo.FindRefreshTokenByHash(t.Context(), "missing")
+	require.NoError(t, err)
+	require.Zero(t, token.ID, "expected a zero token when it is not found")
+
+	err = mock.ExpectationsWereMet()
+	require.NoError(t, err, "There were unfulfilled expectations")
+}
+
+func Test_sqlite_RefreshTokenRepository_RevokeRefreshToken(t *testing.T) {
+	db := openMemoryDB(t)
+	require.NoError(t, Migrate(db))
+	_, err := db.Exec(`INSERT INTO users (id, name, email, password, role) VALUES (1, 'John', 'john@example.com', 'hash', 'customer')`)
+	require.NoError(t, err)
+
+	repo := NewRefreshTokenRepository(db)
+	first, err := repo.SaveRefreshToken(t.Context(), domain.NewRefreshToken(1, "session-1", "hash-1", time.Now().Add(time.Hour)))
+	require.NoError(t, err)
+	_, err = repo.SaveRefreshToken(t.Context(), domain.NewRefreshToken(1, "session-1", "hash-2", time.Now().Add(time.Hour)))
+	require.NoError(t, err)
+	_, err = repo.SaveRefreshToken(t.Context(), domain.NewRefreshToken(1, "session-2", "hash-3", time.Now().Add(time.Hour)))
+	require.NoError(t, err)
+
+	revoked, err := repo.RevokeRefreshToken(t.Context(), first)
+	require.NoError(t, err)
+	require.True(t, revoked)
+	revoked, err = repo.RevokeRefreshToken(t.Context(), first)
+	require.NoError(t, err)
+	require.False(t, revoked, "expected a token to be revoked only once")
+
+	err = repo.RevokeSession(t.Context(), "session-1")
+	require.NoError(t, err)
+	second, err := repo.FindRefreshTokenByHash(t.Context(), "hash-2")
+	require.NoError(t, err)
+	require.True(t, second.Revoked, "expected every token of the session to be revoked")
+	other, err := repo.FindRefreshTokenByHash(t.Context(), "hash-3")
+	require.NoError(t, err)
+	require.False(t, other.Revoked, "expected other sessions to be kept")
+}
+
+func Test_sqlite_RefreshTokenRepository_DeleteExpiredRefreshTokens(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err, "Expected no error when creating sqlmock")
+	defer db.Close()
+
+	repo := NewRefreshTokenRepository(db)
+
+	now := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+	mock.ExpectExec("DELETE FROM refresh_tokens WHERE expires_at <= \\?").
+		WithArgs("2025-01-02 03:04:05").
+		WillReturnResult(sqlmock.NewResult(0, 2))
+
+	err = repo.DeleteExpiredRefreshTokens(t.Context(), now)
+	require.NoError(t, err)
+
+	err = mock.ExpectationsWereMet()
+	require.NoError(t, err, "There were unfulfilled expectations")
+}
+
+func Test_sqlite_RefreshTokenRepository_RevokeUserSessions(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err, "Expected no error when creating sqlmock")
+	defer db.Close()
+
+	repo := NewRefreshTokenRepository(db)
+
+	mock.ExpectExec("UPDATE refresh_tokens SET revoked = TRUE WHERE user_id = ?").
+		WithArgs(1).
+		WillReturnResult(sqlmock.NewResult(0, 3))
+
+	err = repo.RevokeUserSessions(t.Context(), 1)
+	require.NoError(t, err)
+
+	err = mock.ExpectationsWereMet()
+	require.NoError(t, err, "There were unfulfilled expectations")
+}
@@ -0,0 +1,51 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/mohits-git/food-ordering-system/internal/domain"
+)
+
+type RefundRepository struct {
+	db *sql.DB
+}
+
+func NewRefundRepository(db *sql.DB) *RefundRepository {
+	return &RefundRepository{db: db}
+}
+
+func (r *RefundRepository) SaveRefund(ctx context.Context, refund domain.Refund) (int, error) {
+	query := `INSERT INTO refunds (invoice_id, payment_id, amount, currency, reason, transaction_id, refunded_by) VALUES (?, ?, ?, ?, ?, ?, ?) RETURNING id`
+	var id int
+	err := r.db.QueryRowContext(ctx, query, refund.InvoiceID, refund.PaymentID, refund.Amount.Amount, refund.Amount.Currency,
+		refund.Reason, refund.TransactionID, refund.RefundedBy).Scan(&id)
+	if err != nil {
+		return 0, HandleSQLiteError(err)
+	}
+	return id, nil
+}
+
+func (r *RefundRepository) FindRefundsByInvoiceId(ctx context.Context, invoiceId int) ([]domain.Refund, error) {
+	query := `SELECT id, invoice_id, payment_id, amount, currency, reason, transaction_id, refunded_by, created_at FROM refunds WHERE invoice_id = ? ORDER BY id`
+	rows, err := r.db.QueryContext(ctx, query, invoiceId)
+	if err != nil {
+		return nil, HandleSQLiteError(err)
+	}
+	defer rows.Close()
+
+	refunds := []domain.Refund{}
+	for rows.Next() {
+		var refund domain.Refund
+		err := rows.Scan(&refund.ID, &refund.InvoiceID, &refund.PaymentID, &refund.Amount.Amount, &refund.Amount.Currency,
+			&refund.Reason, &refund.TransactionID, &refund.RefundedBy, &refund.CreatedAt)
+		if err != nil {
+			return nil, HandleSQLiteError(err)
+		}
+		refunds = append(refunds, refund)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, HandleSQLiteError(err)
+	}
+	return refunds, nil
+}
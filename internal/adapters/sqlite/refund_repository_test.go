@@ -0,0 +1,56 @@
+package sqlite
+
+import (
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/mohits-git/food-ordering-system/internal/domain"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_sqlite_RefundRepository_SaveRefund(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err, "Expected no error when creating sqlmock")
+	defer db.Close()
+
+	repo := NewRefundRepository(db)
+	require.NotNil(t, repo, "Expected NewRefundRepository to return a non-nil repository")
+
+	refund := domain.NewRefund(1, domain.NewMoney(500, "USD"), "cold food", 2)
+	refund.PaymentID = 4
+	refund.TransactionID = "fake_1"
+	mock.ExpectQuery("INSERT INTO refunds").
+		WithArgs(1, 4, int64(500), "USD", "cold food", "fake_1", 2).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(3))
+
+	id, err := repo.SaveRefund(t.Context(), refund)
+	require.NoError(t, err)
+	require.Equal(t, 3, id)
+
+	err = mock.ExpectationsWereMet()
+	require.NoError(t, err, "There were unfulfilled expectations")
+}
+
+func Test_sqlite_RefundRepository_FindRefundsByInvoiceId(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err, "Expected no error when creating sqlmock")
+	defer db.Close()
+
+	repo := NewRefundRepository(db)
+
+	createdAt := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+	mock.ExpectQuery("SELECT (.+) FROM refunds WHERE invoice_id = \\?").
+		WithArgs(1).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "invoice_id", "payment_id", "amount", "currency", "reason", "transaction_id", "refunded_by", "created_at"}).
+			AddRow(3, 1, 4, 500, "USD", "cold food", "fake_1", 2, createdAt))
+
+	refunds, err := repo.FindRefundsByInvoiceId(t.Context(), 1)
+	require.NoError(t, err)
+	require.Equal(t, []domain.Refund{
+		{ID: 3, InvoiceID: 1, PaymentID: 4, Amount: domain.NewMoney(500, "USD"), Reason: "cold food", TransactionID: "fake_1", RefundedBy: 2, CreatedAt: createdAt},
+	}, refunds)
+
+	err = mock.ExpectationsWereMet()
+	require.NoError(t, err, "There were unfulfilled expectations")
+}
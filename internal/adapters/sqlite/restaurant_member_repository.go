@@ -0,0 +1,98 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/mohits-git/food-ordering-system/internal/domain"
+)
+
+type RestaurantMemberRepository struct {
+	db *sql.DB
+}
+
+func NewRestaurantMemberRepository(db *sql.DB) *RestaurantMemberRepository {
+	return &RestaurantMemberRepository{db: db}
+}
+
+func (r *RestaurantMemberRepository) SaveRestaurantMember(ctx context.Context, member domain.RestaurantMember) error {
+	query := `INSERT INTO restaurant_members (restaurant_id, user_id, role) VALUES (?, ?, ?)`
+	_, err := r.db.ExecContext(ctx, query, member.RestaurantID, member.UserID, string(member.Role))
+	if err != nil {
+		return HandleSQLiteError(err)
+	}
+	return nil
+}
+
+func (r *RestaurantMemberRepository) FindRestaurantMember(ctx context.Context, restaurantId int, userId int) (domain.RestaurantMember, error) {
+	query := `SELECT restaurant_id, user_id, role FROM restaurant_members WHERE restaurant_id = ? AND user_id = ?`
+	var member domain.RestaurantMember
+	err := r.db.QueryRowContext(ctx, query, restaurantId, userId).Scan(&member.RestaurantID, &member.UserID, &member.Role)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return domain.RestaurantMember{}, nil
+		}
+		return domain.RestaurantMember{}, HandleSQLiteError(err)
+	}
+	return member, nil
+}
+
+func (r *RestaurantMemberRepository) FindRestaurantMembers(ctx context.Context, restaurantId int) ([]domain.RestaurantMember, error) {
+	query := `SELECT m.restaurant_id, m.user_id, m.role, u.name, u.email
+		FROM restaurant_members m JOIN users u ON u.id = m.user_id
+		WHERE m.restaurant_id = ? ORDER BY m.created_at, m.user_id`
+	rows, err := r.db.QueryContext(ctx, query, restaurantId)
+	if err != nil {
+		return nil, HandleSQLiteError(err)
+	}
+	defer rows.Close()
+
+	members := []domain.RestaurantMember{}
+	for rows.Next() {
+		var member domain.RestaurantMember
+		if err := rows.Scan(&member.RestaurantID, &member.UserID, &member.Role, &member.Name, &member.Email); err != nil {
+			return nil, HandleSQLiteError(err)
+		}
+		members = append(members, member)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, HandleSQLiteError(err)
+	}
+	return members, nil
+}
+
+func (r *RestaurantMemberRepository) FindMembershipsByUserId(ctx context.Context, userId int) ([]domain.RestaurantMember, error) {
+	query := `SELECT restaurant_id, user_id, role FROM restaurant_members WHERE user_id = ? ORDER BY restaurant_id`
+	rows, err := r.db.QueryContext(ctx, query, userId)
+	if err != nil {
+		return nil, HandleSQLiteError(err)
+	}
+	defer rows.Close()
+
+	members := []domain.RestaurantMember{}
+	for rows.Next() {
+		var member domain.RestaurantMember
+		if err := rows.Scan(&member.RestaurantID, &member.UserID, &member.Role); err != nil {
+			return nil, HandleSQLiteError(err)
+		}
+		members = append(members, member)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, HandleSQLiteError(err)
+	}
+	return members, nil
+}
+
+func (r *RestaurantMemberRepository) DeleteRestaurantMember(ctx context.Context, restaurantId int, userId int) (bool, error) {
+	query := `DELETE FROM restaurant_members WHERE restaurant_id = ? AND user_id = ?`
+	result, err := r.db.ExecContext(ctx, query, restaurantId, userId)
+	if err != nil {
+		return false, HandleSQLiteError(err)
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return false, HandleSQLiteError(err)
+	}
+	return rows > 0, nil
+}
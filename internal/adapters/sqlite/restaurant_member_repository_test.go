@@ -0,0 +1,81 @@
+package sqlite
+
+import (
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/mohits-git/food-ordering-system/internal/domain"
+	"github.com/mohits-git/food-ordering-system/internal/utils/apperr"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_sqlite_RestaurantMemberRepository_SaveRestaurantMember(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err, "Expected no error when creating sqlmock")
+	defer db.Close()
+
+	repo := NewRestaurantMemberRepository(db)
+	require.NotNil(t, repo, "Expected NewRestaurantMemberRepository to return a non-nil repository")
+
+	mock.ExpectExec("INSERT INTO restaurant_members").
+		WithArgs(1, 2, "kitchen").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	err = repo.SaveRestaurantMember(t.Context(), domain.NewRestaurantMember(1, 2, domain.StaffKitchen))
+	require.NoError(t, err)
+
+	err = mock.ExpectationsWereMet()
+	require.NoError(t, err, "There were unfulfilled expectations")
+}
+
+func Test_sqlite_RestaurantMemberRepository(t *testing.T) {
+	db := openMemoryDB(t)
+	require.NoError(t, Migrate(db))
+	_, err := db.Exec(`INSERT INTO users (id, name, email, password, role) VALUES
+			(1, 'Owner', 'owner@example.com', 'hash', 'owner'),
+			(2, 'Cook', 'cook@example.com', 'hash', 'staff'),
+			(3, 'Cashier', 'cashier@example.com', 'hash', 'staff');
+		INSERT INTO restaurants (id, name, owner_id) VALUES (1, 'Pizza Place', 1), (2, 'Burger Place', 1)`)
+	require.NoError(t, err)
+
+	repo := NewRestaurantMemberRepository(db)
+	require.NoError(t, repo.SaveRestaurantMember(t.Context(), domain.NewRestaurantMember(1, 2, domain.StaffKitchen)))
+	require.NoError(t, repo.SaveRestaurantMember(t.Context(), domain.NewRestaurantMember(1, 3, domain.StaffCashier)))
+	require.NoError(t, repo.SaveRestaurantMember(t.Context(), domain.NewRestaurantMember(2, 2, domain.StaffManager)))
+
+	err = repo.SaveRestaurantMember(t.Context(), domain.NewRestaurantMember(1, 2, domain.StaffManager))
+	require.True(t, apperr.IsConflictError(err), "expected a conflict when the user is already a member")
+
+	member, err := repo.FindRestaurantMember(t.Context(), 1, 2)
+	require.NoError(t, err)
+	require.Equal(t, domain.NewRestaurantMember(1, 2, domain.StaffKitchen), member)
+
+	member, err = repo.FindRestaurantMember(t.Context(), 2, 3)
+	require.NoError(t, err)
+	require.Zero(t, member.UserID, "expected a zero member when the user is not a member")
+
+	members, err := repo.FindRestaurantMembers(t.Context(), 1)
+	require.NoError(t, err)
+	require.Equal(t, []domain.RestaurantMember{
+		{RestaurantID: 1, UserID: 2, Role: domain.StaffKitchen, Name: "Cook", Email: "cook@example.com"},
+		{RestaurantID: 1, UserID: 3, Role: domain.StaffCashier, Name: "Cashier", Email: "cashier@example.com"},
+	}, members)
+
+	memberships, err := repo.FindMembershipsByUserId(t.Context(), 2)
+	require.NoError(t, err)
+	require.Equal(t, []domain.RestaurantMember{
+		domain.NewRestaurantMember(1, 2, domain.StaffKitchen),
+		domain.NewRestaurantMember(2, 2, domain.StaffManager),
+	}, memberships)
+
+	deleted, err := repo.DeleteRestaurantMember(t.Context(), 1, 2)
+	require.NoError(t, err)
+	require.True(t, deleted)
+	deleted, err = repo.DeleteRestaurantMember(t.Context(), 1, 2)
+	require.NoError(t, err)
+	require.False(t, deleted)
+
+	members, err = repo.FindRestaurantMembers(t.Context(), 1)
+	require.NoError(t, err)
+	require.Len(t, members, 1)
+}
@@ -3,8 +3,12 @@ package sqlite
 import (
 	"context"
 	"database/sql"
+	"strings"
+	"time"
+	"unicode"
 
 	"github.com/mohits-git/food-ordering-system/internal/domain"
+	"github.com/mohits-git/food-ordering-system/internal/utils/apperr"
 )
 
 type RestaurantRepository struct {
@@ -27,37 +31,303 @@ func (r *RestaurantRepository) SaveRestaurant(ctx context.Context, restaurant do
 	return id, nil
 }
 
-func (r *RestaurantRepository) FindAllRestaurants(ctx context.Context) ([]domain.Restaurant, error) {
-	query := `SELECT id, name, owner_id FROM restaurants`
-	rows, err := r.db.QueryContext(ctx, query)
+// searchScoreQuery ranks the restaurants matching a search with bm25, lower
+// scores first. Matches in the restaurant name weigh more than in its dishes.
+const searchScoreQuery = `SELECT rowid AS id, bm25(restaurant_search, 10.0, 1.0) AS score FROM restaurant_search WHERE restaurant_search MATCH ?`
+
+// FindRestaurants lists the restaurants matching the filter. The cursor is the
+// id of the last restaurant of the previous page, the page goes on after its
+// sort key and id.
+func (r *RestaurantRepository) FindRestaurants(ctx context.Context, filter domain.RestaurantFilter) ([]domain.Restaurant, error) {
+	query := `SELECT r.id, r.name, r.owner_id, r.address, r.phone, r.orders_paused FROM restaurants r`
+	conditions := []string{}
+	args := []any{}
+	terms := searchTerms(filter.Query)
+	if len(terms) > 0 {
+		query += ` JOIN (` + searchScoreQuery + `) s ON s.id = r.id`
+		args = append(args, matchExpression(terms))
+	}
+	if filter.Cuisine != "" {
+		conditions = append(conditions, "EXISTS (SELECT 1 FROM restaurant_cuisines c WHERE c.restaurant_id = r.id AND c.cuisine = ?)")
+		args = append(args, filter.Cuisine)
+	}
+	if !filter.OpenAt.IsZero() {
+		condition, openArgs := openAtCondition(filter.OpenAt)
+		conditions = append(conditions, condition)
+		args = append(args, openArgs...)
+	}
+
+	relevance := filter.Sort == domain.RestaurantSortRelevance && len(terms) > 0
+	if filter.Cursor > 0 {
+		switch {
+		case filter.Sort == domain.RestaurantSortNewest:
+			conditions = append(conditions, "r.id < ?")
+			args = append(args, filter.Cursor)
+		case relevance:
+			conditions = append(conditions, "(s.score, r.id) > (SELECT score, id FROM ("+searchScoreQuery+") WHERE id = ?)")
+			args = append(args, matchExpression(terms), filter.Cursor)
+		default:
+			conditions = append(conditions, "(r.name COLLATE NOCASE, r.id) > (SELECT name, id FROM restaurants WHERE id = ?)")
+			args = append(args, filter.Cursor)
+		}
+	}
+
+	if len(conditions) > 0 {
+		query += " WHERE " + strings.Join(conditions, " AND ")
+	}
+	switch {
+	case filter.Sort == domain.RestaurantSortNewest:
+		query += " ORDER BY r.id DESC"
+	case relevance:
+		query += " ORDER BY s.score, r.id"
+	default:
+		query += " ORDER BY r.name COLLATE NOCASE, r.id"
+	}
+	if filter.Limit > 0 {
+		query += " LIMIT ?"
+		args = append(args, filter.Limit)
+	}
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
 	if err != nil {
 		return nil, HandleSQLiteError(err)
 	}
 	defer rows.Close()
 
 	var restaurants []domain.Restaurant
+	var ids []any
 	for rows.Next() {
 		var restaurant domain.Restaurant
-		if err := rows.Scan(&restaurant.ID, &restaurant.Name, &restaurant.OwnerID); err != nil {
+		if err := rows.Scan(&restaurant.ID, &restaurant.Name, &restaurant.OwnerID,
+			&restaurant.Address, &restaurant.Phone, &restaurant.OrdersPaused); err != nil {
 			return nil, HandleSQLiteError(err)
 		}
 		restaurants = append(restaurants, restaurant)
+		ids = append(ids, restaurant.ID)
 	}
 	if err := rows.Err(); err != nil {
 		return nil, HandleSQLiteError(err)
 	}
+	// release the connection before querying the profiles
+	rows.Close()
+
+	if len(restaurants) == 0 {
+		return restaurants, nil
+	}
+	condition := "restaurant_id IN (?" + strings.Repeat(", ?", len(ids)-1) + ")"
+	if err := r.findRestaurantDetails(ctx, restaurants, condition, ids...); err != nil {
+		return nil, err
+	}
 	return restaurants, nil
 }
 
 func (r *RestaurantRepository) FindRestaurantById(ctx context.Context, id int) (domain.Restaurant, error) {
-	query := `SELECT id, name, owner_id FROM restaurants WHERE id = ?`
+	query := `SELECT id, name, owner_id, address, phone, orders_paused FROM restaurants WHERE id = ?`
 	var restaurant domain.Restaurant
-	err := r.db.QueryRowContext(ctx, query, id).Scan(&restaurant.ID, &restaurant.Name, &restaurant.OwnerID)
+	err := r.db.QueryRowContext(ctx, query, id).Scan(&restaurant.ID, &restaurant.Name, &restaurant.OwnerID,
+		&restaurant.Address, &restaurant.Phone, &restaurant.OrdersPaused)
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return domain.Restaurant{}, nil
 		}
 		return domain.Restaurant{}, HandleSQLiteError(err)
 	}
-	return restaurant, nil
+
+	restaurants := []domain.Restaurant{restaurant}
+	if err := r.findRestaurantDetails(ctx, restaurants, "restaurant_id = ?", id); err != nil {
+		return domain.Restaurant{}, err
+	}
+	return restaurants[0], nil
+}
+
+// UpdateRestaurant replaces the profile of the restaurant, its owner and
+// whether its orders are paused are left as they are.
+func (r *RestaurantRepository) UpdateRestaurant(ctx context.Context, restaurant domain.Restaurant) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return HandleSQLiteError(err)
+	}
+
+	query := `UPDATE restaurants SET name = ?, address = ?, phone = ? WHERE id = ?`
+	result, err := tx.ExecContext(ctx, query, restaurant.Name, restaurant.Address, restaurant.Phone, restaurant.ID)
+	if err != nil {
+		tx.Rollback()
+		return HandleSQLiteError(err)
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		tx.Rollback()
+		return HandleSQLiteError(err)
+	}
+	if rows == 0 {
+		tx.Rollback()
+		return apperr.NewAppError(apperr.ErrNotFound, "restaurant not found", nil)
+	}
+
+	if err := saveRestaurantDetails(ctx, tx, restaurant); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return HandleSQLiteError(err)
+	}
+	return nil
+}
+
+func (r *RestaurantRepository) UpdateOrdersPaused(ctx context.Context, id int, paused bool) error {
+	query := `UPDATE restaurants SET orders_paused = ? WHERE id = ?`
+	result, err := r.db.ExecContext(ctx, query, paused, id)
+	if err != nil {
+		return HandleSQLiteError(err)
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return HandleSQLiteError(err)
+	}
+	if rows == 0 {
+		return apperr.NewAppError(apperr.ErrNotFound, "restaurant not found", nil)
+	}
+	return nil
+}
+
+// saveRestaurantDetails replaces the cuisines, opening hours and closures of
+// the restaurant.
+func saveRestaurantDetails(ctx context.Context, tx *sql.Tx, restaurant domain.Restaurant) error {
+	deleteQueries := []string{
+		`DELETE FROM restaurant_cuisines WHERE restaurant_id = ?`,
+		`DELETE FROM restaurant_hours WHERE restaurant_id = ?`,
+		`DELETE FROM restaurant_closures WHERE restaurant_id = ?`,
+	}
+	for _, query := range deleteQueries {
+		if _, err := tx.ExecContext(ctx, query, restaurant.ID); err != nil {
+			return HandleSQLiteError(err)
+		}
+	}
+
+	cuisineQuery := `INSERT INTO restaurant_cuisines (restaurant_id, cuisine) VALUES (?, ?)`
+	for _, cuisine := range restaurant.Cuisines {
+		if _, err := tx.ExecContext(ctx, cuisineQuery, restaurant.ID, cuisine); err != nil {
+			return HandleSQLiteError(err)
+		}
+	}
+	hoursQuery := `INSERT INTO restaurant_hours (restaurant_id, weekday, start_minute, end_minute) VALUES (?, ?, ?, ?)`
+	for _, hours := range restaurant.OpeningHours {
+		if _, err := tx.ExecContext(ctx, hoursQuery, restaurant.ID, int(hours.Weekday), hours.Start, hours.End); err != nil {
+			return HandleSQLiteError(err)
+		}
+	}
+	closureQuery := `INSERT INTO restaurant_closures (restaurant_id, date) VALUES (?, ?)`
+	for _, date := range restaurant.Closures {
+		if _, err := tx.ExecContext(ctx, closureQuery, restaurant.ID, date); err != nil {
+			return HandleSQLiteError(err)
+		}
+	}
+	return nil
+}
+
+// findRestaurantDetails loads the cuisines, opening hours and closures of the
+// restaurants, the condition selects them by their restaurant_id.
+func (r *RestaurantRepository) findRestaurantDetails(ctx context.Context, restaurants []domain.Restaurant, condition string, args ...any) error {
+	index := make(map[int]int, len(restaurants))
+	for i, restaurant := range restaurants {
+		index[restaurant.ID] = i
+	}
+
+	cuisineQuery := `SELECT restaurant_id, cuisine FROM restaurant_cuisines WHERE ` + condition + ` ORDER BY restaurant_id, cuisine`
+	err := r.queryDetails(ctx, cuisineQuery, args, func(rows *sql.Rows) error {
+		var restaurantId int
+		var cuisine string
+		if err := rows.Scan(&restaurantId, &cuisine); err != nil {
+			return err
+		}
+		if i, ok := index[restaurantId]; ok {
+			restaurants[i].Cuisines = append(restaurants[i].Cuisines, cuisine)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	hoursQuery := `SELECT restaurant_id, weekday, start_minute, end_minute FROM restaurant_hours WHERE ` + condition + ` ORDER BY restaurant_id, weekday, start_minute`
+	err = r.queryDetails(ctx, hoursQuery, args, func(rows *sql.Rows) error {
+		var restaurantId, weekday int
+		var hours domain.OpeningHours
+		if err := rows.Scan(&restaurantId, &weekday, &hours.Start, &hours.End); err != nil {
+			return err
+		}
+		hours.Weekday = time.Weekday(weekday)
+		if i, ok := index[restaurantId]; ok {
+			restaurants[i].OpeningHours = append(restaurants[i].OpeningHours, hours)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	closureQuery := `SELECT restaurant_id, date FROM restaurant_closures WHERE ` + condition + ` ORDER BY restaurant_id, date`
+	return r.queryDetails(ctx, closureQuery, args, func(rows *sql.Rows) error {
+		var restaurantId int
+		var date string
+		if err := rows.Scan(&restaurantId, &date); err != nil {
+			return err
+		}
+		if i, ok := index[restaurantId]; ok {
+			restaurants[i].Closures = append(restaurants[i].Closures, date)
+		}
+		return nil
+	})
+}
+
+// openAtCondition keeps the restaurants taking orders at t, following
+// domain.Restaurant.AcceptsOrdersAt: not paused, not closed on the day and
+// either without hours or inside a window of the day or one running over from
+// the day before.
+func openAtCondition(t time.Time) (string, []any) {
+	weekday := int(t.Weekday())
+	previous := (weekday + 6) % 7
+	minute := t.Hour()*60 + t.Minute()
+	condition := `r.orders_paused = FALSE
+		AND NOT EXISTS (SELECT 1 FROM restaurant_closures c WHERE c.restaurant_id = r.id AND c.date = ?)
+		AND (NOT EXISTS (SELECT 1 FROM restaurant_hours h WHERE h.restaurant_id = r.id)
+			OR EXISTS (SELECT 1 FROM restaurant_hours h WHERE h.restaurant_id = r.id AND (
+				(h.weekday = ? AND h.start_minute <= ? AND (h.end_minute > ? OR h.end_minute < h.start_minute))
+				OR (h.weekday = ? AND h.end_minute < h.start_minute AND h.end_minute > ?))))`
+	return condition, []any{t.Format(domain.DateLayout), weekday, minute, minute, previous, minute}
+}
+
+// searchTerms splits a search query into lower case words, punctuation is
+// dropped so nothing in the query is taken as full-text query syntax.
+func searchTerms(query string) []string {
+	return strings.FieldsFunc(strings.ToLower(query), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
+
+// matchExpression matches every term as a word prefix.
+func matchExpression(terms []string) string {
+	expression := make([]string, len(terms))
+	for i, term := range terms {
+		expression[i] = term + "*"
+	}
+	return strings.Join(expression, " ")
+}
+
+func (r *RestaurantRepository) queryDetails(ctx context.Context, query string, args []any, scan func(*sql.Rows) error) error {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return HandleSQLiteError(err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		if err := scan(rows); err != nil {
+			return HandleSQLiteError(err)
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return HandleSQLiteError(err)
+	}
+	return nil
 }
@@ -3,10 +3,12 @@ package sqlite
 import (
 	"database/sql"
 	"testing"
+	"time"
 
 	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/mattn/go-sqlite3"
 	"github.com/mohits-git/food-ordering-system/internal/domain"
+	"github.com/mohits-git/food-ordering-system/internal/utils/apperr"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -91,7 +93,7 @@ func Test_sqlite_RestaurantRepository_SaveRestaurant(t *testing.T) {
 	}
 }
 
-func Test_sqlite_RestaurantRepository_FindAllRestaurants(t *testing.T) {
+func Test_sqlite_RestaurantRepository_FindRestaurants(t *testing.T) {
 	db, mock, err := sqlmock.New()
 	require.NoError(t, err, "Expected no error when creating sqlmock")
 	defer db.Close()
@@ -102,29 +104,50 @@ func Test_sqlite_RestaurantRepository_FindAllRestaurants(t *testing.T) {
 	// Define test cases
 	tests := []struct {
 		name             string
+		filter           domain.RestaurantFilter
 		mockSetup        func()
 		expectedResults  []domain.Restaurant
 		expectedError    bool
 		expectedErrorMsg string
 	}{
 		{
-			name: "Successful fetch",
+			name:   "Successful fetch",
+			filter: domain.RestaurantFilter{Sort: domain.RestaurantSortName, Limit: 10},
 			mockSetup: func() {
-				rows := sqlmock.NewRows([]string{"id", "name", "owner_id"}).
-					AddRow(1, "Restaurant 1", 1).
-					AddRow(2, "Restaurant 2", 2)
-				mock.ExpectQuery("SELECT id, name, owner_id FROM restaurants").WillReturnRows(rows)
+				rows := sqlmock.NewRows([]string{"id", "name", "owner_id", "address", "phone", "orders_paused"}).
+					AddRow(1, "Restaurant 1", 1, "1 Main St", "555-0100", false).
+					AddRow(2, "Restaurant 2", 2, "", "", true)
+				mock.ExpectQuery("SELECT r.id, r.name, r.owner_id, r.address, r.phone, r.orders_paused FROM restaurants r ORDER BY r.name").
+					WithArgs(10).WillReturnRows(rows)
+				mock.ExpectQuery("SELECT restaurant_id, cuisine FROM restaurant_cuisines").
+					WillReturnRows(sqlmock.NewRows([]string{"restaurant_id", "cuisine"}).AddRow(1, "indian").AddRow(1, "vegan"))
+				mock.ExpectQuery("SELECT restaurant_id, weekday, start_minute, end_minute FROM restaurant_hours").
+					WillReturnRows(sqlmock.NewRows([]string{"restaurant_id", "weekday", "start_minute", "end_minute"}).AddRow(2, 5, 1080, 120))
+				mock.ExpectQuery("SELECT restaurant_id, date FROM restaurant_closures").
+					WillReturnRows(sqlmock.NewRows([]string{"restaurant_id", "date"}).AddRow(2, "2026-12-25"))
 			},
 			expectedResults: []domain.Restaurant{
-				{ID: 1, Name: "Restaurant 1", OwnerID: 1},
-				{ID: 2, Name: "Restaurant 2", OwnerID: 2},
+				{ID: 1, Name: "Restaurant 1", OwnerID: 1, Address: "1 Main St", Phone: "555-0100", Cuisines: []string{"indian", "vegan"}},
+				{ID: 2, Name: "Restaurant 2", OwnerID: 2, OrdersPaused: true,
+					OpeningHours: []domain.OpeningHours{{Weekday: time.Friday, Start: 1080, End: 120}}, Closures: []string{"2026-12-25"}},
 			},
 			expectedError: false,
 		},
+		{
+			name:   "Search",
+			filter: domain.RestaurantFilter{Query: "Chicken, tikka!", Cuisine: "indian", Sort: domain.RestaurantSortRelevance, Cursor: 20, Limit: 10},
+			mockSetup: func() {
+				rows := sqlmock.NewRows([]string{"id", "name", "owner_id", "address", "phone", "orders_paused"})
+				mock.ExpectQuery(`SELECT r.id, r.name, r.owner_id, r.address, r.phone, r.orders_paused FROM restaurants r JOIN \(SELECT rowid AS id, bm25\(restaurant_search, 10.0, 1.0\) AS score FROM restaurant_search WHERE restaurant_search MATCH \?\) s ON s.id = r.id WHERE EXISTS .* ORDER BY s.score, r.id`).
+					WithArgs("chicken* tikka*", "indian", "chicken* tikka*", 20, 10).WillReturnRows(rows)
+			},
+			expectedResults: nil,
+			expectedError:   false,
+		},
 		{
 			name: "Database error",
 			mockSetup: func() {
-				mock.ExpectQuery("SELECT id, name, owner_id FROM restaurants").
+				mock.ExpectQuery("SELECT r.id, r.name, r.owner_id, r.address, r.phone, r.orders_paused FROM restaurants r").
 					WillReturnError(sql.ErrConnDone)
 			},
 			expectedResults:  nil,
@@ -139,7 +162,7 @@ func Test_sqlite_RestaurantRepository_FindAllRestaurants(t *testing.T) {
 				tt.mockSetup()
 			}
 
-			results, err := repo.FindAllRestaurants(t.Context())
+			results, err := repo.FindRestaurants(t.Context(), tt.filter)
 			if tt.expectedError {
 				require.Error(t, err)
 				assert.Contains(t, err.Error(), tt.expectedErrorMsg)
@@ -176,11 +199,20 @@ func Test_sqlite_RestaurantRepository_FindRestaurantById(t *testing.T) {
 			name:         "Successful fetch",
 			restaurantID: 1,
 			mockSetup: func() {
-				row := sqlmock.NewRows([]string{"id", "name", "owner_id"}).
-					AddRow(1, "Restaurant 1", 1)
-				mock.ExpectQuery("SELECT id, name, owner_id FROM restaurants WHERE id = ?").
+				row := sqlmock.NewRows([]string{"id", "name", "owner_id", "address", "phone", "orders_paused"}).
+					AddRow(1, "Restaurant 1", 1, "", "", false)
+				mock.ExpectQuery("SELECT id, name, owner_id, address, phone, orders_paused FROM restaurants WHERE id = ?").
 					WithArgs(1).
 					WillReturnRows(row)
+				mock.ExpectQuery("SELECT restaurant_id, cuisine FROM restaurant_cuisines WHERE restaurant_id = ?").
+					WithArgs(1).
+					WillReturnRows(sqlmock.NewRows([]string{"restaurant_id", "cuisine"}))
+				mock.ExpectQuery("SELECT restaurant_id, weekday, start_minute, end_minute FROM restaurant_hours WHERE restaurant_id = ?").
+					WithArgs(1).
+					WillReturnRows(sqlmock.NewRows([]string{"restaurant_id", "weekday", "start_minute", "end_minute"}))
+				mock.ExpectQuery("SELECT restaurant_id, date FROM restaurant_closures WHERE restaurant_id = ?").
+					WithArgs(1).
+					WillReturnRows(sqlmock.NewRows([]string{"restaurant_id", "date"}))
 			},
 			expectedResult: domain.Restaurant{ID: 1, Name: "Restaurant 1", OwnerID: 1},
 			expectedError:  false,
@@ -189,7 +221,7 @@ func Test_sqlite_RestaurantRepository_FindRestaurantById(t *testing.T) {
 			name:         "Restaurant not found",
 			restaurantID: 2,
 			mockSetup: func() {
-				mock.ExpectQuery("SELECT id, name, owner_id FROM restaurants WHERE id = ?").
+				mock.ExpectQuery("SELECT id, name, owner_id, address, phone, orders_paused FROM restaurants WHERE id = ?").
 					WithArgs(2).
 					WillReturnError(sql.ErrNoRows)
 			},
@@ -200,7 +232,7 @@ func Test_sqlite_RestaurantRepository_FindRestaurantById(t *testing.T) {
 			name:         "Database error",
 			restaurantID: 3,
 			mockSetup: func() {
-				mock.ExpectQuery("SELECT id, name, owner_id FROM restaurants WHERE id = ?").
+				mock.ExpectQuery("SELECT id, name, owner_id, address, phone, orders_paused FROM restaurants WHERE id = ?").
 					WithArgs(3).
 					WillReturnError(sql.ErrConnDone)
 			},
@@ -230,3 +262,143 @@ func Test_sqlite_RestaurantRepository_FindRestaurantById(t *testing.T) {
 		})
 	}
 }
+
+func Test_sqlite_RestaurantRepository_UpdateRestaurant(t *testing.T) {
+	db := openMemoryDB(t)
+	require.NoError(t, Migrate(db))
+	_, err := db.Exec(`INSERT INTO users (id, name, email, password, role) VALUES (1, 'Owner', 'owner@example.com', 'hash', 'owner');
+		INSERT INTO restaurants (id, name, owner_id) VALUES (1, 'Pizza Place', 1)`)
+	require.NoError(t, err)
+
+	repo := NewRestaurantRepository(db)
+
+	restaurant := domain.Restaurant{
+		ID: 1, Name: "Pizza Palace", OwnerID: 1, Address: "1 Main St", Phone: "555-0100",
+		Cuisines:     []string{"italian", "pizza"},
+		OpeningHours: []domain.OpeningHours{{Weekday: time.Monday, Start: 660, End: 1320}, {Weekday: time.Friday, Start: 1080, End: 120}},
+		Closures:     []string{"2026-12-25"},
+	}
+	require.NoError(t, repo.UpdateRestaurant(t.Context(), restaurant))
+	require.NoError(t, repo.UpdateOrdersPaused(t.Context(), 1, true))
+
+	found, err := repo.FindRestaurantById(t.Context(), 1)
+	require.NoError(t, err)
+	restaurant.OrdersPaused = true
+	require.Equal(t, restaurant, found)
+
+	// the profile is replaced, not merged
+	restaurant.Cuisines = []string{"pizza"}
+	restaurant.OpeningHours = nil
+	restaurant.Closures = nil
+	require.NoError(t, repo.UpdateRestaurant(t.Context(), restaurant))
+
+	all, err := repo.FindRestaurants(t.Context(), domain.RestaurantFilter{})
+	require.NoError(t, err)
+	require.Equal(t, []domain.Restaurant{restaurant}, all)
+
+	err = repo.UpdateRestaurant(t.Context(), domain.Restaurant{ID: 2, Name: "Missing", OwnerID: 1})
+	require.True(t, apperr.IsNotFoundError(err), "expected not found error but got %v", err)
+	err = repo.UpdateOrdersPaused(t.Context(), 2, true)
+	require.True(t, apperr.IsNotFoundError(err), "expected not found error but got %v", err)
+}
+
+func Test_sqlite_RestaurantRepository_FindRestaurants_search(t *testing.T) {
+	db := openMemoryDB(t)
+	require.NoError(t, Migrate(db))
+	_, err := db.Exec(`INSERT INTO users (id, name, email, password, role) VALUES (1, 'Owner', 'owner@example.com', 'hash', 'owner');
+		INSERT INTO restaurants (id, name, owner_id) VALUES (1, 'Pizza Palace', 1), (2, 'Curry House', 1), (3, 'Burger Barn', 1);
+		INSERT INTO menuitems (id, name, price, restaurant_id) VALUES
+			(1, 'Margherita', 900, 1), (2, 'Pepperoni Pizza', 1100, 1),
+			(3, 'Chicken Tikka Masala', 1200, 2), (4, 'Garlic Naan', 300, 2),
+			(5, 'Chicken Burger', 800, 3), (6, 'Veggie Pizza Burger', 900, 3);
+		INSERT INTO restaurant_cuisines (restaurant_id, cuisine) VALUES (1, 'italian'), (2, 'indian'), (3, 'american')`)
+	require.NoError(t, err)
+
+	repo := NewRestaurantRepository(db)
+	ids := func(filter domain.RestaurantFilter) []int {
+		restaurants, err := repo.FindRestaurants(t.Context(), filter)
+		require.NoError(t, err)
+		var ids []int
+		for _, restaurant := range restaurants {
+			ids = append(ids, restaurant.ID)
+		}
+		return ids
+	}
+
+	// dishes find the restaurants serving them, the ones named after the query first
+	assert.Equal(t, []int{1, 3}, ids(domain.RestaurantFilter{Query: "pizza", Sort: domain.RestaurantSortRelevance}))
+	assert.Equal(t, []int{3, 2}, ids(domain.RestaurantFilter{Query: "chick", Sort: domain.RestaurantSortName}))
+	assert.Equal(t, []int{2}, ids(domain.RestaurantFilter{Query: "Tikka masala"}))
+	assert.Equal(t, []int{2}, ids(domain.RestaurantFilter{Query: "chicken", Cuisine: "indian"}))
+	assert.Empty(t, ids(domain.RestaurantFilter{Query: "sushi"}))
+	// nothing in the query is taken as search syntax
+	assert.Equal(t, []int{3, 2, 1}, ids(domain.RestaurantFilter{Query: `"*:-`, Sort: domain.RestaurantSortNewest}))
+
+	assert.Equal(t, []int{3, 2}, ids(domain.RestaurantFilter{Sort: domain.RestaurantSortName, Limit: 2}))
+	assert.Equal(t, []int{1}, ids(domain.RestaurantFilter{Sort: domain.RestaurantSortName, Cursor: 2, Limit: 2}))
+	assert.Equal(t, []int{1}, ids(domain.RestaurantFilter{Query: "pizza", Sort: domain.RestaurantSortRelevance, Limit: 1}))
+	assert.Equal(t, []int{3}, ids(domain.RestaurantFilter{Query: "pizza", Sort: domain.RestaurantSortRelevance, Cursor: 1, Limit: 1}))
+	assert.Equal(t, []int{2, 1}, ids(domain.RestaurantFilter{Sort: domain.RestaurantSortNewest, Cursor: 3}))
+
+	// a restaurant added before the cursor does not shift the next page
+	_, err = db.Exec(`INSERT INTO restaurants (id, name, owner_id) VALUES (5, 'Aardvark Diner', 1)`)
+	require.NoError(t, err)
+	assert.Equal(t, []int{1}, ids(domain.RestaurantFilter{Sort: domain.RestaurantSortName, Cursor: 2, Limit: 2}))
+	_, err = db.Exec(`DELETE FROM restaurants WHERE id = 5`)
+	require.NoError(t, err)
+
+	// the index follows the restaurants and their menus
+	_, err = db.Exec(`UPDATE menuitems SET deleted = TRUE WHERE id = 6;
+		UPDATE menuitems SET name = 'Paneer Tikka' WHERE id = 3;
+		UPDATE restaurants SET name = 'Sushi Pizza Bar' WHERE id = 2;
+		INSERT INTO restaurants (id, name, owner_id) VALUES (4, 'Taco Stand', 1);
+		INSERT INTO menuitems (id, name, price, restaurant_id) VALUES (7, 'Fish Taco', 500, 4)`)
+	require.NoError(t, err)
+	assert.Equal(t, []int{1, 2}, ids(domain.RestaurantFilter{Query: "pizza"}))
+	assert.Equal(t, []int{3}, ids(domain.RestaurantFilter{Query: "chicken"}))
+	assert.Equal(t, []int{4}, ids(domain.RestaurantFilter{Query: "fish"}))
+	_, err = db.Exec(`DELETE FROM menuitems WHERE id = 7`)
+	require.NoError(t, err)
+	assert.Empty(t, ids(domain.RestaurantFilter{Query: "fish"}))
+}
+
+func Test_sqlite_RestaurantRepository_FindRestaurants_open_at(t *testing.T) {
+	db := openMemoryDB(t)
+	require.NoError(t, Migrate(db))
+	_, err := db.Exec(`INSERT INTO users (id, name, email, password, role) VALUES (1, 'Owner', 'owner@example.com', 'hash', 'owner')`)
+	require.NoError(t, err)
+
+	repo := NewRestaurantRepository(db)
+	restaurants := []domain.Restaurant{
+		{Name: "Always Open"},
+		{Name: "Paused", OrdersPaused: true},
+		{Name: "Lunch", OpeningHours: []domain.OpeningHours{{Weekday: time.Monday, Start: 660, End: 900}, {Weekday: time.Tuesday, Start: 660, End: 900}}},
+		{Name: "Late Night", OpeningHours: []domain.OpeningHours{{Weekday: time.Friday, Start: 1080, End: 120}}, Closures: []string{"2026-10-17"}},
+		{Name: "Until Midnight", OpeningHours: []domain.OpeningHours{{Weekday: time.Monday, Start: 1200, End: 1440}}, Closures: []string{"2026-10-13"}},
+	}
+	for i := range restaurants {
+		restaurants[i].OwnerID = 1
+		restaurants[i].ID, err = repo.SaveRestaurant(t.Context(), restaurants[i])
+		require.NoError(t, err)
+		require.NoError(t, repo.UpdateRestaurant(t.Context(), restaurants[i]))
+		require.NoError(t, repo.UpdateOrdersPaused(t.Context(), restaurants[i].ID, restaurants[i].OrdersPaused))
+	}
+
+	// every day of a week, at every quarter of an hour, matches the domain rules
+	start := time.Date(2026, 10, 12, 0, 0, 0, 0, time.UTC)
+	for at := start; at.Before(start.AddDate(0, 0, 7)); at = at.Add(15 * time.Minute) {
+		var expected []int
+		for _, restaurant := range restaurants {
+			if restaurant.AcceptsOrdersAt(at) {
+				expected = append(expected, restaurant.ID)
+			}
+		}
+		found, err := repo.FindRestaurants(t.Context(), domain.RestaurantFilter{OpenAt: at, Sort: domain.RestaurantSortNewest})
+		require.NoError(t, err)
+		var ids []int
+		for i := len(found) - 1; i >= 0; i-- {
+			ids = append(ids, found[i].ID)
+		}
+		require.Equal(t, expected, ids, "open restaurants at %s", at.Format(time.RFC1123))
+	}
+}
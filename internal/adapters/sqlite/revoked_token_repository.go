@@ -0,0 +1,44 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"time"
+)
+
+type RevokedTokenRepository struct {
+	db *sql.DB
+}
+
+func NewRevokedTokenRepository(db *sql.DB) *RevokedTokenRepository {
+	return &RevokedTokenRepository{db: db}
+}
+
+// RevokeToken adds the token to the revocation list, revoking it again is a no-op.
+func (r *RevokedTokenRepository) RevokeToken(ctx context.Context, tokenId string, expiresAt time.Time) error {
+	query := `INSERT INTO revoked_tokens (token_id, expires_at) VALUES (?, ?) ON CONFLICT (token_id) DO NOTHING`
+	_, err := r.db.ExecContext(ctx, query, tokenId, expiresAt.UTC().Format(timestampLayout))
+	if err != nil {
+		return HandleSQLiteError(err)
+	}
+	return nil
+}
+
+func (r *RevokedTokenRepository) IsTokenRevoked(ctx context.Context, tokenId string) (bool, error) {
+	query := `SELECT EXISTS (SELECT 1 FROM revoked_tokens WHERE token_id = ?)`
+	var revoked bool
+	err := r.db.QueryRowContext(ctx, query, tokenId).Scan(&revoked)
+	if err != nil {
+		return false, HandleSQLiteError(err)
+	}
+	return revoked, nil
+}
+
+func (r *RevokedTokenRepository) DeleteExpiredRevokedTokens(ctx context.Context, now time.Time) error {
+	query := `DELETE FROM revoked_tokens WHERE expires_at <= ?`
+	_, err := r.db.ExecContext(ctx, query, now.UTC().Format(timestampLayout))
+	if err != nil {
+		return HandleSQLiteError(err)
+	}
+	return nil
+}
@@ -0,0 +1,55 @@
+package sqlite
+
+import (
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_sqlite_RevokedTokenRepository_RevokeToken(t *testing.T) {
+	db := openMemoryDB(t)
+	require.NoError(t, Migrate(db))
+
+	repo := NewRevokedTokenRepository(db)
+	require.NotNil(t, repo, "Expected NewRevokedTokenRepository to return a non-nil repository")
+
+	revoked, err := repo.IsTokenRevoked(t.Context(), "jti-1")
+	require.NoError(t, err)
+	require.False(t, revoked)
+
+	expiresAt := time.Now().Add(15 * time.Minute)
+	require.NoError(t, repo.RevokeToken(t.Context(), "jti-1", expiresAt))
+	require.NoError(t, repo.RevokeToken(t.Context(), "jti-1", expiresAt), "expected revoking twice to be a no-op")
+
+	revoked, err = repo.IsTokenRevoked(t.Context(), "jti-1")
+	require.NoError(t, err)
+	require.True(t, revoked)
+
+	require.NoError(t, repo.DeleteExpiredRevokedTokens(t.Context(), time.Now()))
+	revoked, err = repo.IsTokenRevoked(t.Context(), "jti-1")
+	require.NoError(t, err)
+	require.True(t, revoked, "expected the token to stay revoked until it expires")
+
+	require.NoError(t, repo.DeleteExpiredRevokedTokens(t.Context(), expiresAt.Add(time.Second)))
+	revoked, err = repo.IsTokenRevoked(t.Context(), "jti-1")
+	require.NoError(t, err)
+	require.False(t, revoked)
+}
+
+func Test_sqlite_RevokedTokenRepository_IsTokenRevoked_Error(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err, "Expected no error when creating sqlmock")
+	defer db.Close()
+
+	repo := NewRevokedTokenRepository(db)
+
+	mock.ExpectQuery("SELECT EXISTS").WithArgs("jti-1").WillReturnError(sqlmock.ErrCancelled)
+
+	_, err = repo.IsTokenRevoked(t.Context(), "jti-1")
+	require.Error(t, err)
+
+	err = mock.ExpectationsWereMet()
+	require.NoError(t, err, "There were unfulfilled expectations")
+}
@@ -12,6 +12,9 @@ import (
 var db *sql.DB
 var once sync.Once
 
+// timestampLayout matches the format sqlite uses for CURRENT_TIMESTAMP.
+const timestampLayout = "2006-01-02 15:04:05"
+
 func Connect(ctx context.Context, dsn string) (*sql.DB, error) {
 	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
 	defer cancel()
@@ -0,0 +1,69 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/mohits-git/food-ordering-system/internal/domain"
+)
+
+type TaxRuleRepository struct {
+	db *sql.DB
+}
+
+func NewTaxRuleRepository(db *sql.DB) *TaxRuleRepository {
+	return &TaxRuleRepository{db: db}
+}
+
+// FindTaxRulesByRestaurantId returns the rules of the restaurant along with the
+// rules which apply to all restaurants.
+func (r *TaxRuleRepository) FindTaxRulesByRestaurantId(ctx context.Context, restaurantId int) ([]domain.TaxRule, error) {
+	query := `SELECT id, restaurant_id, category, rate, inclusive FROM tax_rules WHERE restaurant_id = ? OR restaurant_id IS NULL`
+	rows, err := r.db.QueryContext(ctx, query, restaurantId)
+	if err != nil {
+		return nil, HandleSQLiteError(err)
+	}
+	defer rows.Close()
+
+	rules := []domain.TaxRule{}
+	for rows.Next() {
+		var rule domain.TaxRule
+		var ruleRestaurantId sql.NullInt64
+		if err := rows.Scan(&rule.ID, &ruleRestaurantId, &rule.Category, &rule.Rate, &rule.Inclusive); err != nil {
+			return nil, HandleSQLiteError(err)
+		}
+		rule.RestaurantID = int(ruleRestaurantId.Int64)
+		rules = append(rules, rule)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, HandleSQLiteError(err)
+	}
+	return rules, nil
+}
+
+// SaveTaxRule sets the rule of a restaurant for its category, replacing the
+// rate of an existing one.
+func (r *TaxRuleRepository) SaveTaxRule(ctx context.Context, rule domain.TaxRule) (int, error) {
+	query := `INSERT INTO tax_rules (restaurant_id, category, rate, inclusive) VALUES (?, ?, ?, ?)
+		ON CONFLICT (restaurant_id, category) WHERE restaurant_id IS NOT NULL
+		DO UPDATE SET rate = excluded.rate, inclusive = excluded.inclusive RETURNING id`
+	var id int
+	err := r.db.QueryRowContext(ctx, query, rule.RestaurantID, rule.Category, rule.Rate, rule.Inclusive).Scan(&id)
+	if err != nil {
+		return 0, HandleSQLiteError(err)
+	}
+	return id, nil
+}
+
+func (r *TaxRuleRepository) DeleteTaxRule(ctx context.Context, restaurantId int, category string) (bool, error) {
+	query := `DELETE FROM tax_rules WHERE restaurant_id = ? AND category = ?`
+	result, err := r.db.ExecContext(ctx, query, restaurantId, category)
+	if err != nil {
+		return false, HandleSQLiteError(err)
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return false, HandleSQLiteError(err)
+	}
+	return rows > 0, nil
+}
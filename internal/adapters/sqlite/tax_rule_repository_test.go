@@ -0,0 +1,132 @@
+package sqlite
+
+import (
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/mohits-git/food-ordering-system/internal/domain"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_sqlite_TaxRuleRepository_FindTaxRulesByRestaurantId(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err, "Expected no error when creating sqlmock")
+	defer db.Close()
+
+	repo := NewTaxRuleRepository(db)
+	require.NotNil(t, repo, "Expected NewTaxRuleRepository to return a non-nil repository")
+
+	mock.ExpectQuery("SELECT id, restaurant_id, category, rate, inclusive FROM tax_rules WHERE restaurant_id = \\? OR restaurant_id IS NULL").
+		WithArgs(5).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "restaurant_id", "category", "rate", "inclusive"}).
+			AddRow(1, nil, "", 0.10, false).
+			AddRow(2, 5, "alcohol", 0.20, true))
+
+	rules, err := repo.FindTaxRulesByRestaurantId(t.Context(), 5)
+	require.NoError(t, err)
+	require.Equal(t, []domain.TaxRule{
+		{ID: 1, RestaurantID: 0, Category: "", Rate: 0.10, Inclusive: false},
+		{ID: 2, RestaurantID: 5, Category: "alcohol", Rate: 0.20, Inclusive: true},
+	}, rules)
+
+	err = mock.ExpectationsWereMet()
+	require.NoError(t, err, "There were unfulfilled expectations")
+}
+
+func Test_sqlite_TaxRuleRepository_FindTaxRulesByRestaurantId_DatabaseError(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err, "Expected no error when creating sqlmock")
+	defer db.Close()
+
+	repo := NewTaxRuleRepository(db)
+
+	mock.ExpectQuery("SELECT id, restaurant_id, category, rate, inclusive FROM tax_rules").
+		WithArgs(5).
+		WillReturnError(sqlmock.ErrCancelled)
+
+	rules, err := repo.FindTaxRulesByRestaurantId(t.Context(), 5)
+	require.Error(t, err)
+	require.Nil(t, rules)
+
+	err = mock.ExpectationsWereMet()
+	require.NoError(t, err, "There were unfulfilled expectations")
+}
+
+func Test_sqlite_TaxRuleRepository_SaveTaxRule(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err, "Expected no error when creating sqlmock")
+	defer db.Close()
+
+	repo := NewTaxRuleRepository(db)
+
+	mock.ExpectQuery("INSERT INTO tax_rules \\(restaurant_id, category, rate, inclusive\\) VALUES \\(\\?, \\?, \\?, \\?\\)").
+		WithArgs(5, "alcohol", 0.20, true).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(3))
+
+	id, err := repo.SaveTaxRule(t.Context(), domain.TaxRule{RestaurantID: 5, Category: "alcohol", Rate: 0.20, Inclusive: true})
+	require.NoError(t, err)
+	require.Equal(t, 3, id)
+
+	err = mock.ExpectationsWereMet()
+	require.NoError(t, err, "There were unfulfilled expectations")
+}
+
+func Test_sqlite_TaxRuleRepository_DeleteTaxRule(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err, "Expected no error when creating sqlmock")
+	defer db.Close()
+
+	repo := NewTaxRuleRepository(db)
+
+	mock.ExpectExec("DELETE FROM tax_rules WHERE restaurant_id = \\? AND category = \\?").
+		WithArgs(5, "alcohol").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("DELETE FROM tax_rules WHERE restaurant_id = \\? AND category = \\?").
+		WithArgs(5, "food").
+		WillReturnResult(sqlmock.NewResult(0, 0))
+
+	deleted, err := repo.DeleteTaxRule(t.Context(), 5, "alcohol")
+	require.NoError(t, err)
+	require.True(t, deleted)
+
+	deleted, err = repo.DeleteTaxRule(t.Context(), 5, "food")
+	require.NoError(t, err)
+	require.False(t, deleted)
+
+	err = mock.ExpectationsWereMet()
+	require.NoError(t, err, "There were unfulfilled expectations")
+}
+
+func Test_sqlite_TaxRuleRepository_SaveTaxRule_ReplacesCategoryRule(t *testing.T) {
+	db := openMemoryDB(t)
+	require.NoError(t, Migrate(db))
+	_, err := db.Exec(`INSERT INTO users (id, name, email, password, role) VALUES (1, 'Owner', 'owner@example.com', 'hash', 'owner');
+		INSERT INTO restaurants (id, name, owner_id) VALUES (1, 'Pizza Place', 1)`)
+	require.NoError(t, err)
+
+	repo := NewTaxRuleRepository(db)
+
+	id, err := repo.SaveTaxRule(t.Context(), domain.TaxRule{RestaurantID: 1, Category: "alcohol", Rate: 0.20})
+	require.NoError(t, err)
+	replacedId, err := repo.SaveTaxRule(t.Context(), domain.TaxRule{RestaurantID: 1, Category: "alcohol", Rate: 0.25, Inclusive: true})
+	require.NoError(t, err)
+	require.Equal(t, id, replacedId, "expected the category's rule to be updated in place")
+
+	rules, err := repo.FindTaxRulesByRestaurantId(t.Context(), 1)
+	require.NoError(t, err)
+	require.Contains(t, rules, domain.TaxRule{ID: id, RestaurantID: 1, Category: "alcohol", Rate: 0.25, Inclusive: true})
+	count := 0
+	for _, rule := range rules {
+		if rule.RestaurantID == 1 {
+			count++
+		}
+	}
+	require.Equal(t, 1, count, "expected one rule per category")
+
+	deleted, err := repo.DeleteTaxRule(t.Context(), 1, "alcohol")
+	require.NoError(t, err)
+	require.True(t, deleted)
+	deleted, err = repo.DeleteTaxRule(t.Context(), 1, "alcohol")
+	require.NoError(t, err)
+	require.False(t, deleted)
+}
@@ -3,6 +3,7 @@ package sqlite
 import (
 	"context"
 	"database/sql"
+	"strings"
 
 	"github.com/mohits-git/food-ordering-system/internal/domain"
 	"github.com/mohits-git/food-ordering-system/internal/ports"
@@ -18,8 +19,8 @@ func NewUserRepository(db *sql.DB) ports.UserRepository {
 
 func (r *UserRepository) FindUserById(ctx context.Context, id int) (domain.User, error) {
 	var user domain.User
-	query := "SELECT id, name, email, role, password FROM users WHERE id = ?"
-	err := r.db.QueryRowContext(ctx, query, id).Scan(&user.ID, &user.Name, &user.Email, &user.Role, &user.Password)
+	query := "SELECT id, name, email, role, password, suspended, pending_approval FROM users WHERE id = ?"
+	err := r.db.QueryRowContext(ctx, query, id).Scan(&user.ID, &user.Name, &user.Email, &user.Role, &user.Password, &user.Suspended, &user.PendingApproval)
 	if err != nil {
 		err = HandleSQLiteError(err)
 		return domain.User{}, err
@@ -29,8 +30,8 @@ func (r *UserRepository) FindUserById(ctx context.Context, id int) (domain.User,
 
 func (r *UserRepository) FindUserByEmail(ctx context.Context, email string) (domain.User, error) {
 	var user domain.User
-	query := "SELECT id, name, email, role, password FROM users WHERE email = ?"
-	err := r.db.QueryRowContext(ctx, query, email).Scan(&user.ID, &user.Name, &user.Email, &user.Role, &user.Password)
+	query := "SELECT id, name, email, role, password, suspended, pending_approval FROM users WHERE email = ?"
+	err := r.db.QueryRowContext(ctx, query, email).Scan(&user.ID, &user.Name, &user.Email, &user.Role, &user.Password, &user.Suspended, &user.PendingApproval)
 	if err != nil {
 		return domain.User{}, HandleSQLiteError(err)
 	}
@@ -38,8 +39,8 @@ func (r *UserRepository) FindUserByEmail(ctx context.Context, email string) (dom
 }
 
 func (r *UserRepository) SaveUser(ctx context.Context, user domain.User) (int, error) {
-	query := "INSERT INTO users (name, email, role, password) VALUES (?, ?, ?, ?)"
-	res, err := r.db.ExecContext(ctx, query, user.Name, user.Email, user.Role, user.Password)
+	query := "INSERT INTO users (name, email, role, password, pending_approval) VALUES (?, ?, ?, ?, ?)"
+	res, err := r.db.ExecContext(ctx, query, user.Name, user.Email, user.Role, user.Password, user.PendingApproval)
 	if err != nil {
 		return 0, HandleSQLiteError(err)
 	}
@@ -49,3 +50,81 @@ func (r *UserRepository) SaveUser(ctx context.Context, user domain.User) (int, e
 	}
 	return int(id), nil
 }
+
+// FindUsers lists users matching the filter, newest first.
+func (r *UserRepository) FindUsers(ctx context.Context, filter domain.UserFilter) ([]domain.User, error) {
+	conditions := []string{}
+	args := []any{}
+	if filter.Query != "" {
+		pattern := "%" + strings.ToLower(filter.Query) + "%"
+		conditions = append(conditions, "(LOWER(name) LIKE ? OR LOWER(email) LIKE ?)")
+		args = append(args, pattern, pattern)
+	}
+	if filter.Role != "" {
+		conditions = append(conditions, "role = ?")
+		args = append(args, filter.Role)
+	}
+	if filter.PendingApproval {
+		conditions = append(conditions, "pending_approval = TRUE")
+	}
+	if filter.Cursor > 0 {
+		conditions = append(conditions, "id < ?")
+		args = append(args, filter.Cursor)
+	}
+
+	query := "SELECT id, name, email, role, password, suspended, pending_approval FROM users"
+	if len(conditions) > 0 {
+		query += " WHERE " + strings.Join(conditions, " AND ")
+	}
+	query += " ORDER BY id DESC"
+	if filter.Limit > 0 {
+		query += " LIMIT ?"
+		args = append(args, filter.Limit)
+	}
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, HandleSQLiteError(err)
+	}
+	defer rows.Close()
+
+	users := []domain.User{}
+	for rows.Next() {
+		var user domain.User
+		if err := rows.Scan(&user.ID, &user.Name, &user.Email, &user.Role, &user.Password, &user.Suspended, &user.PendingApproval); err != nil {
+			return nil, HandleSQLiteError(err)
+		}
+		users = append(users, user)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, HandleSQLiteError(err)
+	}
+	return users, nil
+}
+
+func (r *UserRepository) UpdateUserRole(ctx context.Context, id int, role domain.UserRole) error {
+	query := "UPDATE users SET role = ? WHERE id = ?"
+	_, err := r.db.ExecContext(ctx, query, role, id)
+	if err != nil {
+		return HandleSQLiteError(err)
+	}
+	return nil
+}
+
+func (r *UserRepository) UpdateUserSuspended(ctx context.Context, id int, suspended bool) error {
+	query := "UPDATE users SET suspended = ? WHERE id = ?"
+	_, err := r.db.ExecContext(ctx, query, suspended, id)
+	if err != nil {
+		return HandleSQLiteError(err)
+	}
+	return nil
+}
+
+func (r *UserRepository) ApproveUser(ctx context.Context, id int) error {
+	query := "UPDATE users SET pending_approval = FALSE WHERE id = ?"
+	_, err := r.db.ExecContext(ctx, query, id)
+	if err != nil {
+		return HandleSQLiteError(err)
+	}
+	return nil
+}
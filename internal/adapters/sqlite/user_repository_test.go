@@ -44,9 +44,9 @@ func Test_sqlite_UserRepository_FindUserById(t *testing.T) {
 			name:   "User found",
 			userId: 1,
 			mockSetup: func() {
-				rows := sqlmock.NewRows([]string{"id", "name", "email", "role", "password"}).
-					AddRow(1, "John Doe", "test@example.com", "customer", "hashedpassword")
-				mock.ExpectQuery("SELECT id, name, email, role, password FROM users WHERE id").
+				rows := sqlmock.NewRows([]string{"id", "name", "email", "role", "password", "suspended", "pending_approval"}).
+					AddRow(1, "John Doe", "test@example.com", "customer", "hashedpassword", false, false)
+				mock.ExpectQuery("SELECT id, name, email, role, password, suspended, pending_approval FROM users WHERE id").
 					WithArgs(int64(1)).
 					WillReturnRows(rows)
 
@@ -64,8 +64,8 @@ func Test_sqlite_UserRepository_FindUserById(t *testing.T) {
 			name:   "User not found",
 			userId: 2,
 			mockSetup: func() {
-				rows := sqlmock.NewRows([]string{"id", "name", "email", "role", "password"})
-				mock.ExpectQuery("SELECT id, name, email, role, password FROM users WHERE id").
+				rows := sqlmock.NewRows([]string{"id", "name", "email", "role", "password", "suspended", "pending_approval"})
+				mock.ExpectQuery("SELECT id, name, email, role, password, suspended, pending_approval FROM users WHERE id").
 					WithArgs(int64(2)).
 					WillReturnRows(rows)
 			},
@@ -76,7 +76,7 @@ func Test_sqlite_UserRepository_FindUserById(t *testing.T) {
 			name:   "Database error",
 			userId: 3,
 			mockSetup: func() {
-				mock.ExpectQuery("SELECT id, name, email, role, password FROM users WHERE id").
+				mock.ExpectQuery("SELECT id, name, email, role, password, suspended, pending_approval FROM users WHERE id").
 					WithArgs(int64(3)).
 					WillReturnError(sql.ErrConnDone)
 			},
@@ -128,9 +128,9 @@ func Test_sqlite_UserRepository_FindUserByEmail(t *testing.T) {
 			name:  "User found",
 			email: "test@example.com",
 			mockSetup: func() {
-				rows := sqlmock.NewRows([]string{"id", "name", "email", "role", "password"}).
-					AddRow(1, "John Doe", "test@example.com", "customer", "hashedpassword")
-				mock.ExpectQuery("SELECT id, name, email, role, password FROM users WHERE email").
+				rows := sqlmock.NewRows([]string{"id", "name", "email", "role", "password", "suspended", "pending_approval"}).
+					AddRow(1, "John Doe", "test@example.com", "customer", "hashedpassword", false, false)
+				mock.ExpectQuery("SELECT id, name, email, role, password, suspended, pending_approval FROM users WHERE email").
 					WithArgs("test@example.com").
 					WillReturnRows(rows)
 			},
@@ -147,8 +147,8 @@ func Test_sqlite_UserRepository_FindUserByEmail(t *testing.T) {
 			name:  "User not found",
 			email: "test@example.com",
 			mockSetup: func() {
-				rows := sqlmock.NewRows([]string{"id", "name", "email", "role", "password"})
-				mock.ExpectQuery("SELECT id, name, email, role, password FROM users WHERE email").
+				rows := sqlmock.NewRows([]string{"id", "name", "email", "role", "password", "suspended", "pending_approval"})
+				mock.ExpectQuery("SELECT id, name, email, role, password, suspended, pending_approval FROM users WHERE email").
 					WithArgs("test@example.com").
 					WillReturnRows(rows)
 			},
@@ -159,7 +159,7 @@ func Test_sqlite_UserRepository_FindUserByEmail(t *testing.T) {
 			name:  "Database error",
 			email: "test@example.com",
 			mockSetup: func() {
-				mock.ExpectQuery("SELECT id, name, email, role, password FROM users WHERE email").
+				mock.ExpectQuery("SELECT id, name, email, role, password, suspended, pending_approval FROM users WHERE email").
 					WithArgs("test@example.com").
 					WillReturnError(sql.ErrConnDone)
 			},
@@ -216,8 +216,8 @@ func Test_sqlite_UserRepository_SaveUser(t *testing.T) {
 				Password: "hashedpassword",
 			},
 			mockSetup: func() {
-				mock.ExpectExec("INSERT INTO users \\(name, email, role, password\\) VALUES \\(\\?, \\?, \\?, \\?\\)").
-					WithArgs("John Doe", "test@example.com", "customer", "hashedpassword").
+				mock.ExpectExec("INSERT INTO users \\(name, email, role, password, pending_approval\\) VALUES \\(\\?, \\?, \\?, \\?, \\?\\)").
+					WithArgs("John Doe", "test@example.com", "customer", "hashedpassword", false).
 					WillReturnResult(sqlmock.NewResult(1, 1))
 			},
 			expectedUserId:    1,
@@ -232,8 +232,8 @@ func Test_sqlite_UserRepository_SaveUser(t *testing.T) {
 				Password: "hashedpassword",
 			},
 			mockSetup: func() {
-				mock.ExpectExec("INSERT INTO users \\(name, email, role, password\\) VALUES \\(\\?, \\?, \\?, \\?\\)").
-					WithArgs("John Doe", "test@example.com", "customer", "hashedpassword").
+				mock.ExpectExec("INSERT INTO users \\(name, email, role, password, pending_approval\\) VALUES \\(\\?, \\?, \\?, \\?, \\?\\)").
+					WithArgs("John Doe", "test@example.com", "customer", "hashedpassword", false).
 					WillReturnError(sqlite3.Error{Code: sqlite3.ErrConstraint, ExtendedCode: sqlite3.ErrConstraintUnique})
 			},
 			expectedUserId:    0,
@@ -248,8 +248,8 @@ func Test_sqlite_UserRepository_SaveUser(t *testing.T) {
 				Password: "hashedpassword",
 			},
 			mockSetup: func() {
-				mock.ExpectExec("INSERT INTO users \\(name, email, role, password\\) VALUES \\(\\?, \\?, \\?, \\?\\)").
-					WithArgs("John Doe", "test@example.com", "customer", "hashedpassword").
+				mock.ExpectExec("INSERT INTO users \\(name, email, role, password, pending_approval\\) VALUES \\(\\?, \\?, \\?, \\?, \\?\\)").
+					WithArgs("John Doe", "test@example.com", "customer", "hashedpassword", false).
 					WillReturnError(sql.ErrConnDone)
 			},
 			expectedUserId:    0,
@@ -279,3 +279,125 @@ func Test_sqlite_UserRepository_SaveUser(t *testing.T) {
 		})
 	}
 }
+
+func Test_sqlite_UserRepository_FindUsers(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err, "Expected no error when creating sqlmock")
+	defer db.Close()
+
+	repo := NewUserRepository(db)
+
+	tests := []struct {
+		name          string
+		filter        domain.UserFilter
+		mockSetup     func()
+		expectedUsers []domain.User
+		expectedError bool
+	}{
+		{
+			name:   "All filters",
+			filter: domain.UserFilter{Query: "John", Role: domain.OWNER, Cursor: 10, Limit: 21},
+			mockSetup: func() {
+				mock.ExpectQuery(`SELECT id, name, email, role, password, suspended, pending_approval FROM users `+
+					`WHERE \(LOWER\(name\) LIKE \? OR LOWER\(email\) LIKE \?\) AND role = \? AND id < \? ORDER BY id DESC LIMIT \?`).
+					WithArgs("%john%", "%john%", domain.OWNER, 10, 21).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "name", "email", "role", "password", "suspended", "pending_approval"}).
+						AddRow(4, "John Doe", "john@example.com", "owner", "hashedpassword", true, false))
+			},
+			expectedUsers: []domain.User{
+				{ID: 4, Name: "John Doe", Email: "john@example.com", Role: domain.OWNER, Password: "hashedpassword", Suspended: true},
+			},
+		},
+		{
+			name:   "Pending approval",
+			filter: domain.UserFilter{PendingApproval: true},
+			mockSetup: func() {
+				mock.ExpectQuery(`FROM users WHERE pending_approval = TRUE ORDER BY id DESC$`).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "name", "email", "role", "password", "suspended", "pending_approval"}).
+						AddRow(5, "Jane Doe", "jane@example.com", "owner", "hashedpassword", false, true))
+			},
+			expectedUsers: []domain.User{
+				{ID: 5, Name: "Jane Doe", Email: "jane@example.com", Role: domain.OWNER, Password: "hashedpassword", PendingApproval: true},
+			},
+		},
+		{
+			name:   "No filters",
+			filter: domain.UserFilter{},
+			mockSetup: func() {
+				mock.ExpectQuery(`SELECT id, name, email, role, password, suspended, pending_approval FROM users ORDER BY id DESC$`).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "name", "email", "role", "password", "suspended", "pending_approval"}))
+			},
+			expectedUsers: []domain.User{},
+		},
+		{
+			name:   "Database error",
+			filter: domain.UserFilter{},
+			mockSetup: func() {
+				mock.ExpectQuery("FROM users").WillReturnError(sql.ErrConnDone)
+			},
+			expectedError: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockSetup()
+
+			users, err := repo.FindUsers(t.Context(), tt.filter)
+			if tt.expectedError {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, tt.expectedUsers, users)
+			}
+			require.NoError(t, mock.ExpectationsWereMet(), "There were unfulfilled expectations")
+		})
+	}
+}
+
+func Test_sqlite_UserRepository_UpdateUserRole(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err, "Expected no error when creating sqlmock")
+	defer db.Close()
+
+	repo := NewUserRepository(db)
+
+	mock.ExpectExec("UPDATE users SET role = \\? WHERE id = \\?").
+		WithArgs(domain.OWNER, 1).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	err = repo.UpdateUserRole(t.Context(), 1, domain.OWNER)
+	require.NoError(t, err)
+	require.NoError(t, mock.ExpectationsWereMet(), "There were unfulfilled expectations")
+}
+
+func Test_sqlite_UserRepository_UpdateUserSuspended(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err, "Expected no error when creating sqlmock")
+	defer db.Close()
+
+	repo := NewUserRepository(db)
+
+	mock.ExpectExec("UPDATE users SET suspended = \\? WHERE id = \\?").
+		WithArgs(true, 1).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	err = repo.UpdateUserSuspended(t.Context(), 1, true)
+	require.NoError(t, err)
+	require.NoError(t, mock.ExpectationsWereMet(), "There were unfulfilled expectations")
+}
+
+func Test_sqlite_UserRepository_ApproveUser(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err, "Expected no error when creating sqlmock")
+	defer db.Close()
+
+	repo := NewUserRepository(db)
+
+	mock.ExpectExec("UPDATE users SET pending_approval = FALSE WHERE id = \\?").
+		WithArgs(1).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	err = repo.ApproveUser(t.Context(), 1)
+	require.NoError(t, err)
+	require.NoError(t, mock.ExpectationsWereMet(), "There were unfulfilled expectations")
+}
@@ -0,0 +1,40 @@
+package domain
+
+import "time"
+
+// MaxIdempotencyKeyLength bounds the client supplied Idempotency-Key header.
+const MaxIdempotencyKeyLength = 255
+
+// IdempotencyKey remembers a request sent with an Idempotency-Key header and
+// the response given to it, so a retry gets the same response instead of
+// running the request again. StatusCode is 0 while the request is running.
+type IdempotencyKey struct {
+	ID           int
+	UserID       int
+	Key          string
+	RequestHash  string
+	StatusCode   int
+	ResponseBody []byte
+	CreatedAt    time.Time
+	ExpiresAt    time.Time
+}
+
+func NewIdempotencyKey(userId int, key string, requestHash string, expiresAt time.Time) IdempotencyKey {
+	return IdempotencyKey{
+		UserID:      userId,
+		Key:         key,
+		RequestHash: requestHash,
+		ExpiresAt:   expiresAt,
+	}
+}
+
+func (k *IdempotencyKey) Validate() bool {
+	if k.UserID <= 0 || k.RequestHash == "" {
+		return false
+	}
+	return k.Key != "" && len(k.Key) <= MaxIdempotencyKeyLength
+}
+
+func (k *IdempotencyKey) IsCompleted() bool {
+	return k.StatusCode != 0
+}
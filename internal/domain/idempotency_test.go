@@ -0,0 +1,58 @@
+package domain
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_domain_IdempotencyKey_Validate(t *testing.T) {
+	expiresAt := time.Now().Add(time.Hour)
+	tests := []struct {
+		name string
+		key  IdempotencyKey
+		want bool
+	}{
+		{
+			name: "Valid Key",
+			key:  NewIdempotencyKey(1, "key-1", "hash", expiresAt),
+			want: true,
+		},
+		{
+			name: "Invalid Key - Empty Key",
+			key:  NewIdempotencyKey(1, "", "hash", expiresAt),
+			want: false,
+		},
+		{
+			name: "Invalid Key - Too Long",
+			key:  NewIdempotencyKey(1, strings.Repeat("k", MaxIdempotencyKeyLength+1), "hash", expiresAt),
+			want: false,
+		},
+		{
+			name: "Invalid Key - Missing Request Hash",
+			key:  NewIdempotencyKey(1, "key-1", "", expiresAt),
+			want: false,
+		},
+		{
+			name: "Invalid Key - Invalid User ID",
+			key:  NewIdempotencyKey(0, "key-1", "hash", expiresAt),
+			want: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.key.Validate())
+		})
+	}
+}
+
+func Test_domain_IdempotencyKey_IsCompleted(t *testing.T) {
+	key := NewIdempotencyKey(1, "key-1", "hash", time.Now())
+	assert.False(t, key.IsCompleted())
+
+	key.StatusCode = 201
+	assert.True(t, key.IsCompleted())
+}
@@ -8,11 +8,31 @@ const (
 	Cancelled  PaymentStatus = "cancelled"
 	Processing PaymentStatus = "processing"
 	Failed     PaymentStatus = "failed"
+	// PartiallyPaid invoices have payments not yet covering the amount due
+	PartiallyPaid PaymentStatus = "partially_paid"
+	// RefundPending marks a paid invoice whose order got cancelled
+	RefundPending     PaymentStatus = "refund_pending"
+	PartiallyRefunded PaymentStatus = "partially_refunded"
+	Refunded          PaymentStatus = "refunded"
 )
 
 func (p PaymentStatus) Validate() bool {
 	switch p {
-	case Paid, Unpaid, Cancelled, Processing, Failed:
+	case Paid, Unpaid, Cancelled, Processing, Failed, PartiallyPaid, RefundPending, PartiallyRefunded, Refunded:
+		return true
+	}
+	return false
+}
+
+// IsOpen reports whether the invoice takes payments.
+func (p PaymentStatus) IsOpen() bool {
+	return p == Unpaid || p == Failed || p == PartiallyPaid
+}
+
+// IsRefundable reports whether money taken for the invoice can be given back.
+func (p PaymentStatus) IsRefundable() bool {
+	switch p {
+	case Paid, RefundPending, PartiallyRefunded:
 		return true
 	}
 	return false
@@ -21,12 +41,33 @@ func (p PaymentStatus) Validate() bool {
 type Invoice struct {
 	ID            int
 	OrderID       int
-	Total         float64
-	Tax           float64
+	Total         Money
+	Tax           Money
 	PaymentStatus PaymentStatus
+	Items         []InvoiceItem
+}
+
+// InvoiceFilter narrows down a list of invoices. Zero values are ignored,
+// Cursor is the id of the last invoice of the previous page.
+type InvoiceFilter struct {
+	Statuses []PaymentStatus
+	Cursor   int
+	Limit    int
 }
 
-func NewInvoice(id int, orderID int, total, tax float64, paymentStatus PaymentStatus) Invoice {
+// InvoiceItem is a line of the bill, Total is the line amount before tax and
+// Tax is the tax charged on that line. With tax inclusive pricing Total is
+// less than UnitPrice x Quantity.
+type InvoiceItem struct {
+	MenuItemID int
+	Name       string
+	UnitPrice  Money
+	Quantity   int
+	Total      Money
+	Tax        Money
+}
+
+func NewInvoice(id int, orderID int, total, tax Money, paymentStatus PaymentStatus) Invoice {
 	return Invoice{
 		ID:            id,
 		OrderID:       orderID,
@@ -37,8 +78,13 @@ func NewInvoice(id int, orderID int, total, tax float64, paymentStatus PaymentSt
 }
 
 func (i *Invoice) Validate() bool {
-	if i.OrderID <= 0 || i.Total < 0 || i.Tax < 0 {
+	if i.OrderID <= 0 || i.Total.IsNegative() || i.Tax.IsNegative() || !i.Total.SameCurrency(i.Tax) {
 		return false
 	}
 	return i.PaymentStatus.Validate()
 }
+
+// AmountDue is the amount the customer has to pay, the total with tax.
+func (i *Invoice) AmountDue() Money {
+	return i.Total.Add(i.Tax)
+}
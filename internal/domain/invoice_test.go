@@ -10,8 +10,8 @@ func Test_domain_NewInvoice(t *testing.T) {
 	type args struct {
 		id            int
 		orderID       int
-		total         float64
-		tax           float64
+		total         Money
+		tax           Money
 		paymentStatus PaymentStatus
 	}
 	tests := []struct {
@@ -24,15 +24,15 @@ func Test_domain_NewInvoice(t *testing.T) {
 			args: args{
 				id:            1,
 				orderID:       100,
-				total:         250.75,
-				tax:           20.25,
+				total:         NewMoney(25075, "USD"),
+				tax:           NewMoney(2025, "USD"),
 				paymentStatus: Paid,
 			},
 			want: Invoice{
 				ID:            1,
 				OrderID:       100,
-				Total:         250.75,
-				Tax:           20.25,
+				Total:         NewMoney(25075, "USD"),
+				Tax:           NewMoney(2025, "USD"),
 				PaymentStatus: Paid,
 			},
 		},
@@ -56,8 +56,8 @@ func Test_domain_Invoice_Validate(t *testing.T) {
 			inv: Invoice{
 				ID:            1,
 				OrderID:       100,
-				Total:         250.75,
-				Tax:           20.25,
+				Total:         NewMoney(25075, "USD"),
+				Tax:           NewMoney(2025, "USD"),
 				PaymentStatus: Paid,
 			},
 			want: true,
@@ -67,8 +67,8 @@ func Test_domain_Invoice_Validate(t *testing.T) {
 			inv: Invoice{
 				ID:            2,
 				OrderID:       0,
-				Total:         150.00,
-				Tax:           15.00,
+				Total:         NewMoney(15000, "USD"),
+				Tax:           NewMoney(1500, "USD"),
 				PaymentStatus: Unpaid,
 			},
 			want: false,
@@ -78,8 +78,8 @@ func Test_domain_Invoice_Validate(t *testing.T) {
 			inv: Invoice{
 				ID:            3,
 				OrderID:       101,
-				Total:         -50.00,
-				Tax:           5.00,
+				Total:         NewMoney(-5000, "USD"),
+				Tax:           NewMoney(500, "USD"),
 				PaymentStatus: Cancelled,
 			},
 			want: false,
@@ -89,19 +89,30 @@ func Test_domain_Invoice_Validate(t *testing.T) {
 			inv: Invoice{
 				ID:            4,
 				OrderID:       102,
-				Total:         200.00,
-				Tax:           -10.00,
+				Total:         NewMoney(20000, "USD"),
+				Tax:           NewMoney(-1000, "USD"),
 				PaymentStatus: Processing,
 			},
 			want: false,
 		},
+		{
+			name: "Mixed Currencies",
+			inv: Invoice{
+				ID:            6,
+				OrderID:       104,
+				Total:         NewMoney(30000, "USD"),
+				Tax:           NewMoney(3000, "EUR"),
+				PaymentStatus: Unpaid,
+			},
+			want: false,
+		},
 		{
 			name: "Invalid Payment Status",
 			inv: Invoice{
 				ID:            5,
 				OrderID:       103,
-				Total:         300.00,
-				Tax:           30.00,
+				Total:         NewMoney(30000, "USD"),
+				Tax:           NewMoney(3000, "USD"),
 				PaymentStatus: "unknown",
 			},
 			want: false,
@@ -146,6 +157,16 @@ func Test_domain_PaymentStatus_Validate(t *testing.T) {
 			ps:   Failed,
 			want: true,
 		},
+		{
+			name: "Valid Status - Partially Refunded",
+			ps:   PartiallyRefunded,
+			want: true,
+		},
+		{
+			name: "Valid Status - Refunded",
+			ps:   Refunded,
+			want: true,
+		},
 		{
 			name: "Invalid Status",
 			ps:   "unknown",
@@ -159,3 +180,14 @@ func Test_domain_PaymentStatus_Validate(t *testing.T) {
 		})
 	}
 }
+
+func Test_domain_PaymentStatus_IsRefundable(t *testing.T) {
+	refundable := []PaymentStatus{Paid, RefundPending, PartiallyRefunded}
+	for _, ps := range refundable {
+		assert.True(t, ps.IsRefundable(), "expected %s to be refundable", ps)
+	}
+	notRefundable := []PaymentStatus{Unpaid, Processing, Failed, Cancelled, Refunded}
+	for _, ps := range notRefundable {
+		assert.False(t, ps.IsRefundable(), "expected %s not to be refundable", ps)
+	}
+}
@@ -0,0 +1,149 @@
+package domain
+
+import (
+	"fmt"
+	"slices"
+	"time"
+)
+
+// Menu is a named part of a restaurant's offer, like breakfast or dinner,
+// with its items grouped in ordered categories. A menu is offered during its
+// schedules, a menu without schedules is offered all day.
+type Menu struct {
+	ID           int
+	RestaurantID int
+	Name         string
+	Schedules    []MenuSchedule
+	Categories   []MenuCategory
+}
+
+// MenuSchedule is a window of the day, as minutes since midnight, End is not
+// part of it. A window ending before it starts runs past midnight.
+type MenuSchedule struct {
+	Start int
+	End   int
+}
+
+// MenuCategory lists the items of a menu in the order they are shown, Items
+// are resolved from ItemIDs when the menu is read.
+type MenuCategory struct {
+	ID      int
+	MenuID  int
+	Name    string
+	ItemIDs []int
+	Items   []MenuItem
+}
+
+const minutesPerDay = 24 * 60
+
+func (m *Menu) Validate() bool {
+	if m.Name == "" || m.RestaurantID <= 0 {
+		return false
+	}
+	for _, schedule := range m.Schedules {
+		if !schedule.Validate() {
+			return false
+		}
+	}
+	for _, category := range m.Categories {
+		if category.Name == "" {
+			return false
+		}
+		for i, id := range category.ItemIDs {
+			if id <= 0 || slices.Contains(category.ItemIDs[:i], id) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+func (s MenuSchedule) Validate() bool {
+	return s.Start >= 0 && s.Start < minutesPerDay && s.End >= 0 && s.End <= minutesPerDay && s.Start != s.End
+}
+
+// Contains reports if the time of day of t falls in the window.
+func (s MenuSchedule) Contains(t time.Time) bool {
+	minute := t.Hour()*60 + t.Minute()
+	if s.Start < s.End {
+		return minute >= s.Start && minute < s.End
+	}
+	return minute >= s.Start || minute < s.End
+}
+
+func (m *Menu) IsActiveAt(t time.Time) bool {
+	if len(m.Schedules) == 0 {
+		return true
+	}
+	for _, schedule := range m.Schedules {
+		if schedule.Contains(t) {
+			return true
+		}
+	}
+	return false
+}
+
+func (m *Menu) HasItem(menuItemId int) bool {
+	for _, category := range m.Categories {
+		if slices.Contains(category.ItemIDs, menuItemId) {
+			return true
+		}
+	}
+	return false
+}
+
+// ResolveItems fills the items of the categories, ids missing from items, like
+// deleted items, are left out.
+func (m *Menu) ResolveItems(items map[int]MenuItem) {
+	for i := range m.Categories {
+		category := &m.Categories[i]
+		category.Items = nil
+		for _, id := range category.ItemIDs {
+			if item, ok := items[id]; ok {
+				category.Items = append(category.Items, item)
+			}
+		}
+	}
+}
+
+// ActiveMenus returns the menus offered at t.
+func ActiveMenus(menus []Menu, t time.Time) []Menu {
+	var active []Menu
+	for _, menu := range menus {
+		if menu.IsActiveAt(t) {
+			active = append(active, menu)
+		}
+	}
+	return active
+}
+
+// IsOnActiveMenu reports if the item can be ordered at t, every item can be
+// when the restaurant has no menus set up.
+func IsOnActiveMenu(menus []Menu, menuItemId int, t time.Time) bool {
+	if len(menus) == 0 {
+		return true
+	}
+	for _, menu := range ActiveMenus(menus, t) {
+		if menu.HasItem(menuItemId) {
+			return true
+		}
+	}
+	return false
+}
+
+// ParseTimeOfDay parses a "15:04" time into minutes since midnight, "24:00"
+// is accepted as the end of the day.
+func ParseTimeOfDay(value string) (int, bool) {
+	var hours, minutes int
+	if n, err := fmt.Sscanf(value, "%d:%d", &hours, &minutes); err != nil || n != 2 || len(value) != 5 {
+		return 0, false
+	}
+	if hours < 0 || minutes < 0 || minutes > 59 || hours*60+minutes > minutesPerDay {
+		return 0, false
+	}
+	return hours*60 + minutes, true
+}
+
+func FormatTimeOfDay(minutes int) string {
+	return fmt.Sprintf("%02d:%02d", minutes/60, minutes%60)
+}
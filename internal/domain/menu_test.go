@@ -0,0 +1,121 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func at(hour, minute int) time.Time {
+	return time.Date(2026, time.March, 2, hour, minute, 0, 0, time.Local)
+}
+
+func Test_domain_Menu_Validate(t *testing.T) {
+	categories := []MenuCategory{{Name: "Mains", ItemIDs: []int{1, 2}}}
+	tests := []struct {
+		name string
+		menu Menu
+		want bool
+	}{
+		{"valid menu", Menu{Name: "Lunch", RestaurantID: 1, Schedules: []MenuSchedule{{Start: 660, End: 900}}, Categories: categories}, true},
+		{"without schedules", Menu{Name: "All day", RestaurantID: 1, Categories: categories}, true},
+		{"schedule past midnight", Menu{Name: "Late", RestaurantID: 1, Schedules: []MenuSchedule{{Start: 1320, End: 120}}}, true},
+		{"schedule to end of day", Menu{Name: "Dinner", RestaurantID: 1, Schedules: []MenuSchedule{{Start: 1080, End: 1440}}}, true},
+		{"empty name", Menu{Name: "", RestaurantID: 1}, false},
+		{"no restaurant", Menu{Name: "Lunch"}, false},
+		{"empty schedule", Menu{Name: "Lunch", RestaurantID: 1, Schedules: []MenuSchedule{{Start: 600, End: 600}}}, false},
+		{"schedule out of the day", Menu{Name: "Lunch", RestaurantID: 1, Schedules: []MenuSchedule{{Start: 1440, End: 60}}}, false},
+		{"category without name", Menu{Name: "Lunch", RestaurantID: 1, Categories: []MenuCategory{{ItemIDs: []int{1}}}}, false},
+		{"invalid item id", Menu{Name: "Lunch", RestaurantID: 1, Categories: []MenuCategory{{Name: "Mains", ItemIDs: []int{0}}}}, false},
+		{"item twice in a category", Menu{Name: "Lunch", RestaurantID: 1, Categories: []MenuCategory{{Name: "Mains", ItemIDs: []int{1, 1}}}}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.menu.Validate())
+		})
+	}
+}
+
+func Test_domain_Menu_IsActiveAt(t *testing.T) {
+	breakfast := Menu{Name: "Breakfast", Schedules: []MenuSchedule{{Start: 420, End: 660}}}
+	late := Menu{Name: "Late", Schedules: []MenuSchedule{{Start: 1320, End: 120}}}
+	split := Menu{Name: "Lunch", Schedules: []MenuSchedule{{Start: 660, End: 840}, {Start: 1080, End: 1320}}}
+	allDay := Menu{Name: "Drinks"}
+
+	tests := []struct {
+		name string
+		menu Menu
+		at   time.Time
+		want bool
+	}{
+		{"at start", breakfast, at(7, 0), true},
+		{"inside", breakfast, at(9, 30), true},
+		{"at end", breakfast, at(11, 0), false},
+		{"before start", breakfast, at(6, 59), false},
+		{"past midnight before", late, at(23, 30), true},
+		{"past midnight after", late, at(1, 0), true},
+		{"past midnight outside", late, at(12, 0), false},
+		{"second window", split, at(19, 0), true},
+		{"between windows", split, at(15, 0), false},
+		{"no schedules", allDay, at(4, 0), true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.menu.IsActiveAt(tt.at))
+		})
+	}
+}
+
+func Test_domain_IsOnActiveMenu(t *testing.T) {
+	menus := []Menu{
+		{ID: 1, Name: "Breakfast", Schedules: []MenuSchedule{{Start: 420, End: 660}}, Categories: []MenuCategory{{Name: "Eggs", ItemIDs: []int{1}}}},
+		{ID: 2, Name: "Dinner", Schedules: []MenuSchedule{{Start: 1080, End: 1320}}, Categories: []MenuCategory{{Name: "Mains", ItemIDs: []int{2}}}},
+	}
+
+	assert.True(t, IsOnActiveMenu(menus, 1, at(8, 0)))
+	assert.False(t, IsOnActiveMenu(menus, 2, at(8, 0)))
+	assert.False(t, IsOnActiveMenu(menus, 3, at(8, 0)))
+	assert.True(t, IsOnActiveMenu(menus, 2, at(19, 0)))
+	assert.True(t, IsOnActiveMenu(nil, 3, at(8, 0)))
+}
+
+func Test_domain_Menu_ResolveItems(t *testing.T) {
+	menu := Menu{Categories: []MenuCategory{
+		{Name: "Mains", ItemIDs: []int{2, 1}},
+		{Name: "Desserts", ItemIDs: []int{3}},
+	}}
+	items := map[int]MenuItem{1: {ID: 1, Name: "Pasta"}, 2: {ID: 2, Name: "Pizza"}}
+
+	menu.ResolveItems(items)
+	assert.Equal(t, []MenuItem{{ID: 2, Name: "Pizza"}, {ID: 1, Name: "Pasta"}}, menu.Categories[0].Items)
+	assert.Empty(t, menu.Categories[1].Items)
+}
+
+func Test_domain_ParseTimeOfDay(t *testing.T) {
+	tests := []struct {
+		value  string
+		want   int
+		wantOk bool
+	}{
+		{"00:00", 0, true},
+		{"07:30", 450, true},
+		{"23:59", 1439, true},
+		{"24:00", 1440, true},
+		{"24:01", 0, false},
+		{"7:30", 0, false},
+		{"07:60", 0, false},
+		{"noon", 0, false},
+		{"", 0, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			got, ok := ParseTimeOfDay(tt.value)
+			assert.Equal(t, tt.wantOk, ok)
+			assert.Equal(t, tt.want, got)
+			if ok {
+				assert.Equal(t, tt.value, FormatTimeOfDay(got))
+			}
+		})
+	}
+}
@@ -3,12 +3,26 @@ package domain
 type MenuItem struct {
 	ID           int
 	Name         string
-	Price        float64
+	Price        Money
 	Available    bool
 	RestaurantID int
+	// Category is used to pick the tax rule of the item, e.g. food or alcohol
+	Category    string
+	Description string
+	ImageURL    string
+	// SortOrder positions the item in the restaurant's menu, lowest first
+	SortOrder int
+	// Deleted items are hidden from the menu and cannot be ordered, they still
+	// resolve by id for past orders
+	Deleted        bool
+	ModifierGroups []ModifierGroup
+	// TrackStock items run out, Stock is the quantity left to sell and the
+	// item is no longer available once it reaches zero
+	TrackStock bool
+	Stock      int
 }
 
-func NewMenuItem(id int, name string, price float64, available bool, restaurantId int) MenuItem {
+func NewMenuItem(id int, name string, price Money, available bool, restaurantId int) MenuItem {
 	return MenuItem{
 		ID:           id,
 		Name:         name,
@@ -19,12 +33,28 @@ func NewMenuItem(id int, name string, price float64, available bool, restaurantI
 }
 
 func (m *MenuItem) Validate() bool {
-	if m.Name == "" || m.Price < 0 || m.RestaurantID <= 0 {
+	if m.Name == "" || m.Price.IsNegative() || m.Price.Currency == "" || m.RestaurantID <= 0 || m.Stock < 0 {
 		return false
 	}
+	for _, group := range m.ModifierGroups {
+		if !group.Validate() {
+			return false
+		}
+		for _, option := range group.Options {
+			if !option.PriceDelta.SameCurrency(m.Price) {
+				return false
+			}
+		}
+	}
 	return true
 }
 
 func (m *MenuItem) IsAvailable() bool {
-	return m.Available
+	return m.Available && !m.Deleted
+}
+
+// HasStockFor reports if quantity more of the item can be sold, items not
+// tracking stock never run out.
+func (m *MenuItem) HasStockFor(quantity int) bool {
+	return !m.TrackStock || m.Stock >= quantity
 }
@@ -10,7 +10,7 @@ func Test_domain_NewMenuItem(t *testing.T) {
 	type args struct {
 		id           int
 		name         string
-		price        float64
+		price        Money
 		available    bool
 		restaurantId int
 	}
@@ -24,14 +24,14 @@ func Test_domain_NewMenuItem(t *testing.T) {
 			args: args{
 				id:           1,
 				name:         "Pizza",
-				price:        9.99,
+				price:        NewMoney(999, "USD"),
 				available:    true,
 				restaurantId: 1,
 			},
 			want: MenuItem{
 				ID:           1,
 				Name:         "Pizza",
-				Price:        9.99,
+				Price:        NewMoney(999, "USD"),
 				Available:    true,
 				RestaurantID: 1,
 			},
@@ -56,7 +56,7 @@ func Test_domain_MenuItem_Validate(t *testing.T) {
 			m: MenuItem{
 				ID:           1,
 				Name:         "Burger",
-				Price:        5.99,
+				Price:        NewMoney(599, "USD"),
 				Available:    true,
 				RestaurantID: 1,
 			},
@@ -67,7 +67,7 @@ func Test_domain_MenuItem_Validate(t *testing.T) {
 			m: MenuItem{
 				ID:           2,
 				Name:         "",
-				Price:        5.99,
+				Price:        NewMoney(599, "USD"),
 				Available:    true,
 				RestaurantID: 1,
 			},
@@ -78,7 +78,7 @@ func Test_domain_MenuItem_Validate(t *testing.T) {
 			m: MenuItem{
 				ID:           3,
 				Name:         "Salad",
-				Price:        -1.00,
+				Price:        NewMoney(-100, "USD"),
 				Available:    true,
 				RestaurantID: 1,
 			},
@@ -89,12 +89,25 @@ func Test_domain_MenuItem_Validate(t *testing.T) {
 			m: MenuItem{
 				ID:           4,
 				Name:         "Pasta",
-				Price:        7.99,
+				Price:        NewMoney(799, "USD"),
 				Available:    true,
 				RestaurantID: 0,
 			},
 			want: false,
 		},
+		{
+			name: "invalid menu item with negative stock",
+			m: MenuItem{
+				ID:           5,
+				Name:         "Soup",
+				Price:        NewMoney(499, "USD"),
+				Available:    true,
+				RestaurantID: 1,
+				TrackStock:   true,
+				Stock:        -1,
+			},
+			want: false,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -115,7 +128,7 @@ func Test_domain_MenuItem_IsAvailable(t *testing.T) {
 			m: MenuItem{
 				ID:           1,
 				Name:         "Sushi",
-				Price:        12.99,
+				Price:        NewMoney(1299, "USD"),
 				Available:    true,
 				RestaurantID: 1,
 			},
@@ -126,12 +139,24 @@ func Test_domain_MenuItem_IsAvailable(t *testing.T) {
 			m: MenuItem{
 				ID:           2,
 				Name:         "Steak",
-				Price:        19.99,
+				Price:        NewMoney(1999, "USD"),
 				Available:    false,
 				RestaurantID: 1,
 			},
 			want: false,
 		},
+		{
+			name: "menu item is deleted",
+			m: MenuItem{
+				ID:           3,
+				Name:         "Ramen",
+				Price:        NewMoney(1499, "USD"),
+				Available:    true,
+				RestaurantID: 1,
+				Deleted:      true,
+			},
+			want: false,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -140,3 +165,15 @@ func Test_domain_MenuItem_IsAvailable(t *testing.T) {
 		})
 	}
 }
+
+func Test_domain_MenuItem_HasStockFor(t *testing.T) {
+	untracked := MenuItem{ID: 1, Name: "Water"}
+	assert.True(t, untracked.HasStockFor(100), "items not tracking stock never run out")
+
+	tracked := MenuItem{ID: 2, Name: "Cake", TrackStock: true, Stock: 3}
+	assert.True(t, tracked.HasStockFor(3))
+	assert.False(t, tracked.HasStockFor(4))
+
+	tracked.Stock = 0
+	assert.False(t, tracked.HasStockFor(1))
+}
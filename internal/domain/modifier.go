@@ -0,0 +1,90 @@
+package domain
+
+import "slices"
+
+// ModifierGroup is a choice offered on a menu item, like its size, add-ons or
+// ingredients to leave out. MinSelect and MaxSelect bound how many of its
+// options are picked, a group with MinSelect 0 is optional.
+type ModifierGroup struct {
+	ID         int
+	MenuItemID int
+	Name       string
+	MinSelect  int
+	MaxSelect  int
+	Options    []ModifierOption
+}
+
+// ModifierOption is one pick of a group, PriceDelta is added to the price of
+// the item and can be negative, e.g. for a smaller size.
+type ModifierOption struct {
+	ID         int
+	GroupID    int
+	Name       string
+	PriceDelta Money
+}
+
+func (g *ModifierGroup) Validate() bool {
+	if g.Name == "" || g.MinSelect < 0 || g.MaxSelect < 1 || g.MinSelect > g.MaxSelect {
+		return false
+	}
+	if len(g.Options) < g.MaxSelect {
+		return false
+	}
+	for _, option := range g.Options {
+		if option.Name == "" {
+			return false
+		}
+	}
+	return true
+}
+
+// OrderItemOption keeps the name and price delta of a chosen option at the
+// time it was ordered.
+type OrderItemOption struct {
+	OptionID   int
+	Name       string
+	PriceDelta Money
+}
+
+// SelectOptions checks the chosen options against the modifier groups of the
+// item, every option has to belong to the item and every group has to have
+// between its MinSelect and MaxSelect options chosen.
+func (m *MenuItem) SelectOptions(optionIds []int) ([]OrderItemOption, bool) {
+	var selected []OrderItemOption
+	found := 0
+	for _, group := range m.ModifierGroups {
+		count := 0
+		for _, option := range group.Options {
+			if slices.Contains(optionIds, option.ID) {
+				count++
+				selected = append(selected, OrderItemOption{
+					OptionID:   option.ID,
+					Name:       option.Name,
+					PriceDelta: option.PriceDelta,
+				})
+			}
+		}
+		if count < group.MinSelect || count > group.MaxSelect {
+			return nil, false
+		}
+		found += count
+	}
+
+	// options of other items, or the same option twice
+	if found != len(optionIds) {
+		return nil, false
+	}
+	if m.PriceWith(selected).IsNegative() {
+		return nil, false
+	}
+	return selected, true
+}
+
+// PriceWith is the unit price of the item with the chosen options.
+func (m *MenuItem) PriceWith(options []OrderItemOption) Money {
+	price := m.Price
+	for _, option := range options {
+		price = price.Add(option.PriceDelta)
+	}
+	return price
+}
@@ -0,0 +1,108 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_domain_ModifierGroup_Validate(t *testing.T) {
+	options := []ModifierOption{
+		{ID: 1, Name: "Cheese", PriceDelta: NewMoney(100, "USD")},
+		{ID: 2, Name: "Olives", PriceDelta: NewMoney(50, "USD")},
+	}
+	tests := []struct {
+		name  string
+		group ModifierGroup
+		want  bool
+	}{
+		{"valid optional group", ModifierGroup{Name: "Add-ons", MinSelect: 0, MaxSelect: 2, Options: options}, true},
+		{"valid required group", ModifierGroup{Name: "Size", MinSelect: 1, MaxSelect: 1, Options: options}, true},
+		{"empty name", ModifierGroup{Name: "", MinSelect: 0, MaxSelect: 1, Options: options}, false},
+		{"min above max", ModifierGroup{Name: "Add-ons", MinSelect: 2, MaxSelect: 1, Options: options}, false},
+		{"max of zero", ModifierGroup{Name: "Add-ons", MinSelect: 0, MaxSelect: 0, Options: options}, false},
+		{"max above option count", ModifierGroup{Name: "Add-ons", MinSelect: 0, MaxSelect: 3, Options: options}, false},
+		{"option without name", ModifierGroup{Name: "Add-ons", MinSelect: 0, MaxSelect: 1, Options: []ModifierOption{{Name: ""}}}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.group.Validate())
+		})
+	}
+}
+
+func Test_domain_MenuItem_SelectOptions(t *testing.T) {
+	pizza := MenuItem{
+		ID:    1,
+		Name:  "Pizza",
+		Price: NewMoney(1000, "USD"),
+		ModifierGroups: []ModifierGroup{
+			{ID: 1, Name: "Size", MinSelect: 1, MaxSelect: 1, Options: []ModifierOption{
+				{ID: 10, Name: "Small", PriceDelta: NewMoney(-200, "USD")},
+				{ID: 11, Name: "Large", PriceDelta: NewMoney(300, "USD")},
+			}},
+			{ID: 2, Name: "Extras", MinSelect: 0, MaxSelect: 2, Options: []ModifierOption{
+				{ID: 20, Name: "Extra cheese", PriceDelta: NewMoney(150, "USD")},
+				{ID: 21, Name: "No onions", PriceDelta: NewMoney(0, "USD")},
+				{ID: 22, Name: "Olives", PriceDelta: NewMoney(100, "USD")},
+			}},
+		},
+	}
+
+	tests := []struct {
+		name      string
+		optionIds []int
+		wantOk    bool
+		wantPrice Money
+	}{
+		{"required option only", []int{11}, true, NewMoney(1300, "USD")},
+		{"negative delta", []int{10}, true, NewMoney(800, "USD")},
+		{"with extras", []int{11, 20, 21}, true, NewMoney(1450, "USD")},
+		{"missing required group", []int{20}, false, Money{}},
+		{"two of a single choice group", []int{10, 11}, false, Money{}},
+		{"too many extras", []int{11, 20, 21, 22}, false, Money{}},
+		{"option of another item", []int{11, 99}, false, Money{}},
+		{"same option twice", []int{11, 20, 20}, false, Money{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			options, ok := pizza.SelectOptions(tt.optionIds)
+			assert.Equal(t, tt.wantOk, ok)
+			if ok {
+				assert.Len(t, options, len(tt.optionIds))
+				assert.Equal(t, tt.wantPrice, pizza.PriceWith(options))
+			}
+		})
+	}
+
+	// items without modifier groups take no options
+	plain := MenuItem{ID: 2, Name: "Cola", Price: NewMoney(200, "USD")}
+	options, ok := plain.SelectOptions(nil)
+	assert.True(t, ok)
+	assert.Empty(t, options)
+	_, ok = plain.SelectOptions([]int{11})
+	assert.False(t, ok)
+
+	// a delta can not take the price below zero
+	cheap := MenuItem{ID: 3, Name: "Water", Price: NewMoney(100, "USD"), ModifierGroups: []ModifierGroup{
+		{ID: 3, Name: "Size", MinSelect: 0, MaxSelect: 1, Options: []ModifierOption{
+			{ID: 30, Name: "Tiny", PriceDelta: NewMoney(-200, "USD")},
+		}},
+	}}
+	_, ok = cheap.SelectOptions([]int{30})
+	assert.False(t, ok)
+}
+
+func Test_domain_MenuItem_Validate_with_modifier_groups(t *testing.T) {
+	item := MenuItem{Name: "Pizza", Price: NewMoney(1000, "USD"), RestaurantID: 1, ModifierGroups: []ModifierGroup{
+		{Name: "Size", MinSelect: 1, MaxSelect: 1, Options: []ModifierOption{{Name: "Large", PriceDelta: NewMoney(300, "USD")}}},
+	}}
+	assert.True(t, item.Validate())
+
+	item.ModifierGroups[0].Options[0].PriceDelta = NewMoney(300, "EUR")
+	assert.False(t, item.Validate(), "option deltas have to be in the currency of the item")
+
+	item.ModifierGroups[0].Options[0].PriceDelta = NewMoney(300, "USD")
+	item.ModifierGroups[0].MaxSelect = 0
+	assert.False(t, item.Validate(), "groups have to be valid")
+}
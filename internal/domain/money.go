@@ -0,0 +1,135 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// DefaultCurrency is used when an amount is given without a currency.
+const DefaultCurrency = "USD"
+
+// Money is an amount in the minor unit of its currency, e.g. cents. All
+// supported currencies have two decimal places.
+type Money struct {
+	Amount   int64
+	Currency string
+}
+
+var ErrCurrencyMismatch = errors.New("currency mismatch")
+
+func NewMoney(amount int64, currency string) Money {
+	return Money{Amount: amount, Currency: currency}
+}
+
+// ParseMoney parses a decimal amount like "12.5" or "12.50" without going
+// through float64.
+func ParseMoney(value string, currency string) (Money, error) {
+	value = strings.TrimSpace(value)
+	negative := strings.HasPrefix(value, "-")
+	value = strings.TrimPrefix(value, "-")
+
+	major, minor, hasMinor := strings.Cut(value, ".")
+	if !isDigits(major) || (hasMinor && (!isDigits(minor) || len(minor) > 2)) {
+		return Money{}, fmt.Errorf("invalid amount %q", value)
+	}
+	minor += strings.Repeat("0", 2-len(minor))
+
+	majorUnits, err := strconv.ParseInt(major, 10, 64)
+	if err != nil {
+		return Money{}, fmt.Errorf("invalid amount %q", value)
+	}
+	minorUnits, _ := strconv.ParseInt(minor, 10, 64)
+
+	amount := majorUnits*100 + minorUnits
+	if negative {
+		amount = -amount
+	}
+	return NewMoney(amount, currency), nil
+}
+
+func (m Money) IsZero() bool {
+	return m.Amount == 0
+}
+
+func (m Money) IsNegative() bool {
+	return m.Amount < 0
+}
+
+func (m Money) SameCurrency(other Money) bool {
+	return m.Currency == other.Currency
+}
+
+// Add returns the sum of both amounts. A zero Money without currency takes
+// the currency of the other amount, so sums can start from Money{}. Adding
+// different currencies is a programming error and panics.
+func (m Money) Add(other Money) Money {
+	return NewMoney(m.Amount+other.Amount, m.mustMatch(other))
+}
+
+func (m Money) Sub(other Money) Money {
+	return NewMoney(m.Amount-other.Amount, m.mustMatch(other))
+}
+
+func (m Money) Mul(quantity int) Money {
+	return NewMoney(m.Amount*int64(quantity), m.Currency)
+}
+
+// MulRate multiplies by a rate, rounding half away from zero to the minor unit.
+func (m Money) MulRate(rate float64) Money {
+	return NewMoney(int64(math.Round(float64(m.Amount)*rate)), m.Currency)
+}
+
+// DivRate divides by a rate, rounding half away from zero to the minor unit.
+func (m Money) DivRate(rate float64) Money {
+	return NewMoney(int64(math.Round(float64(m.Amount)/rate)), m.Currency)
+}
+
+// LessThan compares two amounts of the same currency.
+func (m Money) LessThan(other Money) (bool, error) {
+	if !m.SameCurrency(other) {
+		return false, ErrCurrencyMismatch
+	}
+	return m.Amount < other.Amount, nil
+}
+
+// Decimal formats the amount in major units, e.g. "12.50".
+func (m Money) Decimal() string {
+	sign := ""
+	amount := m.Amount
+	if amount < 0 {
+		sign = "-"
+		amount = -amount
+	}
+	return fmt.Sprintf("%s%d.%02d", sign, amount/100, amount%100)
+}
+
+func (m Money) String() string {
+	return m.Decimal() + " " + m.Currency
+}
+
+func (m Money) mustMatch(other Money) string {
+	switch {
+	case m.Currency == other.Currency:
+		return m.Currency
+	case m.Currency == "" && m.Amount == 0:
+		return other.Currency
+	case other.Currency == "" && other.Amount == 0:
+		return m.Currency
+	}
+	panic(fmt.Sprintf("%s: %s and %s", ErrCurrencyMismatch, m.Currency, other.Currency))
+}
+
+func isDigits(s string) bool {
+	if s == "" {
+		return false
+	}
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,72 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_domain_ParseMoney(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    int64
+		wantErr bool
+	}{
+		{"12", 1200, false},
+		{"12.5", 1250, false},
+		{"12.50", 1250, false},
+		{"0.07", 7, false},
+		{" 3.10 ", 310, false},
+		{"-1.25", -125, false},
+		{"12.345", 0, true},
+		{"12.", 0, true},
+		{".5", 0, true},
+		{"1.+5", 0, true},
+		{"abc", 0, true},
+		{"", 0, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := ParseMoney(tt.input, "USD")
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, NewMoney(tt.want, "USD"), got)
+		})
+	}
+}
+
+func Test_domain_Money_Arithmetic(t *testing.T) {
+	price := NewMoney(1999, "USD")
+
+	// 3 x 19.99 must be exactly 59.97, which float64 cannot represent
+	assert.Equal(t, NewMoney(5997, "USD"), price.Mul(3))
+	assert.Equal(t, NewMoney(2998, "USD"), Money{}.Add(price).Add(NewMoney(999, "USD")))
+	assert.Equal(t, NewMoney(1000, "USD"), price.Sub(NewMoney(999, "USD")))
+	assert.Equal(t, NewMoney(200, "USD"), price.MulRate(0.10))
+	assert.Equal(t, NewMoney(1666, "USD"), price.DivRate(1.20))
+
+	assert.Panics(t, func() { price.Add(NewMoney(100, "EUR")) })
+}
+
+func Test_domain_Money_LessThan(t *testing.T) {
+	less, err := NewMoney(100, "USD").LessThan(NewMoney(101, "USD"))
+	require.NoError(t, err)
+	assert.True(t, less)
+
+	less, err = NewMoney(101, "USD").LessThan(NewMoney(101, "USD"))
+	require.NoError(t, err)
+	assert.False(t, less)
+
+	_, err = NewMoney(100, "USD").LessThan(NewMoney(100, "EUR"))
+	assert.ErrorIs(t, err, ErrCurrencyMismatch)
+}
+
+func Test_domain_Money_String(t *testing.T) {
+	assert.Equal(t, "12.50 USD", NewMoney(1250, "USD").String())
+	assert.Equal(t, "0.05", NewMoney(5, "USD").Decimal())
+	assert.Equal(t, "-1.05", NewMoney(-105, "USD").Decimal())
+}
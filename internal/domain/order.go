@@ -1,40 +1,178 @@
 package domain
 
+import (
+	"slices"
+	"strings"
+	"time"
+)
+
+type OrderStatus string
+
+const (
+	OrderDraft     OrderStatus = "draft"
+	OrderPlaced    OrderStatus = "placed"
+	OrderAccepted  OrderStatus = "accepted"
+	OrderPreparing OrderStatus = "preparing"
+	OrderReady     OrderStatus = "ready"
+	OrderDelivered OrderStatus = "delivered"
+	OrderCancelled OrderStatus = "cancelled"
+)
+
+// orderTransitions lists the statuses an order can move to from each status.
+var orderTransitions = map[OrderStatus][]OrderStatus{
+	OrderDraft:     {OrderPlaced, OrderCancelled},
+	OrderPlaced:    {OrderAccepted, OrderCancelled},
+	OrderAccepted:  {OrderPreparing, OrderReady, OrderCancelled},
+	OrderPreparing: {OrderReady, OrderCancelled},
+	OrderReady:     {OrderDelivered, OrderCancelled},
+	OrderDelivered: {},
+	OrderCancelled: {},
+}
+
+func (s OrderStatus) IsValid() bool {
+	_, ok := orderTransitions[s]
+	return ok
+}
+
+// HoldsStock reports if the items of an order in this status are taken out
+// of the stock, from the order being placed until it is cancelled.
+func (s OrderStatus) HoldsStock() bool {
+	return s != OrderDraft && s != OrderCancelled && s.IsValid()
+}
+
+func (s OrderStatus) CanTransitionTo(next OrderStatus) bool {
+	for _, allowed := range orderTransitions[s] {
+		if allowed == next {
+			return true
+		}
+	}
+	return false
+}
+
 type Order struct {
 	ID           int
 	CustomerID   int
 	RestaurantID int
+	Status       OrderStatus
 	OrderItems   []OrderItem
+	CreatedAt    time.Time
+}
+
+// OrderFilter narrows down a list of orders. Zero values are ignored, Cursor
+// is the id of the last order of the previous page.
+type OrderFilter struct {
+	CustomerID   int
+	RestaurantID int
+	Statuses     []OrderStatus
+	From         time.Time
+	To           time.Time
+	Cursor       int
+	Limit        int
 }
 
+// OrderItem is a line of the order. It keeps the name and unit price of the
+// menu item at the time it was added, so the order is billed the same even if
+// the menu changes later. The unit price includes the price of the options.
 type OrderItem struct {
+	ID         int
 	MenuItemID int
 	Quantity   int
+	Name       string
+	UnitPrice  Money
+	Options    []OrderItemOption
 }
 
 func (oi *OrderItem) Validate() bool {
 	return oi.MenuItemID > 0 && oi.Quantity > 0
 }
 
+func (oi *OrderItem) OptionIDs() []int {
+	ids := make([]int, 0, len(oi.Options))
+	for _, option := range oi.Options {
+		ids = append(ids, option.OptionID)
+	}
+	return ids
+}
+
+// SameLine reports whether other is the same menu item with the same options,
+// so it is added to this line instead of a new one.
+func (oi *OrderItem) SameLine(other OrderItem) bool {
+	if oi.MenuItemID != other.MenuItemID || len(oi.Options) != len(other.Options) {
+		return false
+	}
+	otherIds := other.OptionIDs()
+	for _, option := range oi.Options {
+		if !slices.Contains(otherIds, option.OptionID) {
+			return false
+		}
+	}
+	return true
+}
+
+// DisplayName is the name of the item followed by its options, e.g.
+// "Pizza (Large, Extra cheese)".
+func (oi *OrderItem) DisplayName() string {
+	if len(oi.Options) == 0 {
+		return oi.Name
+	}
+	names := make([]string, 0, len(oi.Options))
+	for _, option := range oi.Options {
+		names = append(names, option.Name)
+	}
+	return oi.Name + " (" + strings.Join(names, ", ") + ")"
+}
+
+func (oi *OrderItem) Total() Money {
+	return oi.UnitPrice.Mul(oi.Quantity)
+}
+
 func NewOrder(id int, customerID int, restaurantID int) Order {
 	return Order{
 		ID:           id,
 		CustomerID:   customerID,
 		RestaurantID: restaurantID,
+		Status:       OrderDraft,
 		OrderItems:   []OrderItem{},
 	}
 }
 
-func (o *Order) Validate(menuItems map[int]bool) bool {
+// Validate checks the order against the menu items of its restaurant, every
+// item has to be available and its options valid for the menu item.
+func (o *Order) Validate(menuItems map[int]MenuItem) bool {
 	if o.CustomerID <= 0 || o.RestaurantID <= 0 || len(o.OrderItems) == 0 {
 		return false
 	}
 	for _, item := range o.OrderItems {
-		if available, exists := menuItems[item.MenuItemID]; !exists ||
-			!available ||
-			!item.Validate() {
+		menuItem, exists := menuItems[item.MenuItemID]
+		if !exists || !menuItem.IsAvailable() || !item.Validate() {
+			return false
+		}
+		if _, ok := menuItem.SelectOptions(item.OptionIDs()); !ok {
 			return false
 		}
 	}
 	return true
 }
+
+// Quantities sums the quantity ordered of each menu item across the lines.
+func (o *Order) Quantities() map[int]int {
+	quantities := make(map[int]int, len(o.OrderItems))
+	for _, item := range o.OrderItems {
+		quantities[item.MenuItemID] += item.Quantity
+	}
+	return quantities
+}
+
+func (o *Order) IsDraft() bool {
+	return o.Status == OrderDraft
+}
+
+// TransitionTo moves the order to the next status, reporting false when the
+// transition is not allowed from the current status.
+func (o *Order) TransitionTo(next OrderStatus) bool {
+	if !o.Status.CanTransitionTo(next) {
+		return false
+	}
+	o.Status = next
+	return true
+}
@@ -164,6 +164,42 @@ func Test_domain_Order_Validate(t *testing.T) {
 			},
 			want: false,
 		},
+		{
+			name: "valid order with options",
+			fields: fields{
+				ID:           9,
+				CustomerID:   1,
+				RestaurantID: 1,
+				Items: []OrderItem{
+					{MenuItemID: 4, Quantity: 1, Options: []OrderItemOption{{OptionID: 11}}},
+				},
+			},
+			want: true,
+		},
+		{
+			name: "invalid order with missing required option",
+			fields: fields{
+				ID:           10,
+				CustomerID:   1,
+				RestaurantID: 1,
+				Items: []OrderItem{
+					{MenuItemID: 4, Quantity: 1},
+				},
+			},
+			want: false,
+		},
+		{
+			name: "invalid order with option of another item",
+			fields: fields{
+				ID:           11,
+				CustomerID:   1,
+				RestaurantID: 1,
+				Items: []OrderItem{
+					{MenuItemID: 1, Quantity: 1, Options: []OrderItemOption{{OptionID: 11}}},
+				},
+			},
+			want: false,
+		},
 		{
 			name: "invalid order with unavailable MenuItemID",
 			fields: fields{
@@ -177,10 +213,16 @@ func Test_domain_Order_Validate(t *testing.T) {
 			want: false,
 		},
 	}
-	menuItems := map[int]bool{
-		1: true,
-		2: true,
-		3: false,
+	menuItems := map[int]MenuItem{
+		1: {ID: 1, Available: true},
+		2: {ID: 2, Available: true},
+		3: {ID: 3, Available: false},
+		4: {ID: 4, Available: true, Price: NewMoney(1000, "USD"), ModifierGroups: []ModifierGroup{
+			{ID: 1, Name: "Size", MinSelect: 1, MaxSelect: 1, Options: []ModifierOption{
+				{ID: 10, Name: "Small", PriceDelta: NewMoney(0, "USD")},
+				{ID: 11, Name: "Large", PriceDelta: NewMoney(300, "USD")},
+			}},
+		}},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -195,3 +237,121 @@ func Test_domain_Order_Validate(t *testing.T) {
 		})
 	}
 }
+
+func Test_domain_NewOrder(t *testing.T) {
+	got := NewOrder(1, 2, 3)
+	want := Order{
+		ID:           1,
+		CustomerID:   2,
+		RestaurantID: 3,
+		Status:       OrderDraft,
+		OrderItems:   []OrderItem{},
+	}
+	assert.Equal(t, want, got, "NewOrder() = %v, want %v", got, want)
+}
+
+func Test_domain_OrderStatus_IsValid(t *testing.T) {
+	tests := []struct {
+		name   string
+		status OrderStatus
+		want   bool
+	}{
+		{name: "draft", status: OrderDraft, want: true},
+		{name: "placed", status: OrderPlaced, want: true},
+		{name: "accepted", status: OrderAccepted, want: true},
+		{name: "preparing", status: OrderPreparing, want: true},
+		{name: "ready", status: OrderReady, want: true},
+		{name: "delivered", status: OrderDelivered, want: true},
+		{name: "cancelled", status: OrderCancelled, want: true},
+		{name: "unknown", status: "unknown", want: false},
+		{name: "empty", status: "", want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.status.IsValid()
+			assert.Equal(t, tt.want, got, "OrderStatus.IsValid() = %v, want %v", got, tt.want)
+		})
+	}
+}
+
+func Test_domain_OrderStatus_CanTransitionTo(t *testing.T) {
+	tests := []struct {
+		name string
+		from OrderStatus
+		to   OrderStatus
+		want bool
+	}{
+		{name: "draft to placed", from: OrderDraft, to: OrderPlaced, want: true},
+		{name: "draft to cancelled", from: OrderDraft, to: OrderCancelled, want: true},
+		{name: "draft to accepted", from: OrderDraft, to: OrderAccepted, want: false},
+		{name: "placed to accepted", from: OrderPlaced, to: OrderAccepted, want: true},
+		{name: "placed to draft", from: OrderPlaced, to: OrderDraft, want: false},
+		{name: "accepted to preparing", from: OrderAccepted, to: OrderPreparing, want: true},
+		{name: "accepted to ready", from: OrderAccepted, to: OrderReady, want: true},
+		{name: "preparing to ready", from: OrderPreparing, to: OrderReady, want: true},
+		{name: "ready to delivered", from: OrderReady, to: OrderDelivered, want: true},
+		{name: "ready to cancelled", from: OrderReady, to: OrderCancelled, want: true},
+		{name: "delivered to cancelled", from: OrderDelivered, to: OrderCancelled, want: false},
+		{name: "cancelled to placed", from: OrderCancelled, to: OrderPlaced, want: false},
+		{name: "unknown to placed", from: "unknown", to: OrderPlaced, want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.from.CanTransitionTo(tt.to)
+			assert.Equal(t, tt.want, got, "OrderStatus.CanTransitionTo() = %v, want %v", got, tt.want)
+		})
+	}
+}
+
+func Test_domain_OrderStatus_HoldsStock(t *testing.T) {
+	for _, status := range []OrderStatus{OrderPlaced, OrderAccepted, OrderPreparing, OrderReady, OrderDelivered} {
+		assert.True(t, status.HoldsStock(), "expected %s order to hold stock", status)
+	}
+	for _, status := range []OrderStatus{OrderDraft, OrderCancelled, "unknown"} {
+		assert.False(t, status.HoldsStock(), "expected %s order to not hold stock", status)
+	}
+}
+
+func Test_domain_Order_Quantities(t *testing.T) {
+	order := Order{OrderItems: []OrderItem{
+		{MenuItemID: 1, Quantity: 2},
+		{MenuItemID: 2, Quantity: 1},
+		{MenuItemID: 1, Quantity: 3, Options: []OrderItemOption{{OptionID: 1}}},
+	}}
+	assert.Equal(t, map[int]int{1: 5, 2: 1}, order.Quantities())
+}
+
+func Test_domain_Order_TransitionTo(t *testing.T) {
+	order := NewOrder(1, 1, 1)
+
+	ok := order.TransitionTo(OrderPlaced)
+	assert.True(t, ok, "expected draft order to be placed")
+	assert.Equal(t, OrderPlaced, order.Status)
+	assert.False(t, order.IsDraft(), "expected placed order to not be a draft")
+
+	ok = order.TransitionTo(OrderDraft)
+	assert.False(t, ok, "expected placed order to not go back to draft")
+	assert.Equal(t, OrderPlaced, order.Status)
+}
+
+func Test_domain_OrderItem_Total(t *testing.T) {
+	item := OrderItem{MenuItemID: 1, Quantity: 3, Name: "Burger", UnitPrice: NewMoney(450, "USD")}
+	assert.Equal(t, NewMoney(1350, "USD"), item.Total())
+}
+
+func Test_domain_OrderItem_SameLine(t *testing.T) {
+	item := OrderItem{MenuItemID: 1, Options: []OrderItemOption{{OptionID: 1}, {OptionID: 2}}}
+
+	assert.True(t, item.SameLine(OrderItem{MenuItemID: 1, Options: []OrderItemOption{{OptionID: 2}, {OptionID: 1}}}), "options in another order are the same line")
+	assert.False(t, item.SameLine(OrderItem{MenuItemID: 1, Options: []OrderItemOption{{OptionID: 1}}}), "fewer options are another line")
+	assert.False(t, item.SameLine(OrderItem{MenuItemID: 1, Options: []OrderItemOption{{OptionID: 1}, {OptionID: 3}}}), "other options are another line")
+	assert.False(t, item.SameLine(OrderItem{MenuItemID: 2, Options: []OrderItemOption{{OptionID: 1}, {OptionID: 2}}}), "another menu item is another line")
+}
+
+func Test_domain_OrderItem_DisplayName(t *testing.T) {
+	item := OrderItem{Name: "Pizza"}
+	assert.Equal(t, "Pizza", item.DisplayName())
+
+	item.Options = []OrderItemOption{{Name: "Large"}, {Name: "No onions"}}
+	assert.Equal(t, "Pizza (Large, No onions)", item.DisplayName())
+}
@@ -0,0 +1,152 @@
+package domain
+
+import "time"
+
+// GatewayStatus is the state of a transaction at the payment provider.
+type GatewayStatus string
+
+const (
+	GatewayPending    GatewayStatus = "pending"
+	GatewayAuthorized GatewayStatus = "authorized"
+	GatewayCaptured   GatewayStatus = "captured"
+	GatewayDeclined   GatewayStatus = "declined"
+	GatewayRefunded   GatewayStatus = "refunded"
+)
+
+// IsFinal is true once the provider will not change the transaction on its
+// own anymore.
+func (s GatewayStatus) IsFinal() bool {
+	return s != GatewayPending
+}
+
+// GatewayResult is what the provider reports about a transaction.
+type GatewayResult struct {
+	TransactionID string
+	Status        GatewayStatus
+	Message       string
+}
+
+type PaymentMethod string
+
+const (
+	PaymentCash PaymentMethod = "cash"
+	// PaymentCard is charged through the payment provider
+	PaymentCard PaymentMethod = "card"
+)
+
+func (m PaymentMethod) Validate() bool {
+	return m == PaymentCash || m == PaymentCard
+}
+
+// Payment is money received for an invoice. Amount is what went towards the
+// invoice, anything tendered above it was given back as change.
+type Payment struct {
+	ID        int
+	InvoiceID int
+	PayerID   int
+	// TakenBy is the user who took the payment, the payer or a cashier
+	TakenBy       int
+	Method        PaymentMethod
+	Amount        Money
+	Tendered      Money
+	Change        Money
+	TransactionID string
+	PaidAt        time.Time
+}
+
+// NewPayment applies the tendered money to the amount due on the invoice.
+func NewPayment(invoiceId int, payerId int, method PaymentMethod, tendered Money, due Money) Payment {
+	amount := tendered
+	if due.Sub(tendered).IsNegative() {
+		amount = due
+	}
+	return Payment{
+		InvoiceID: invoiceId,
+		PayerID:   payerId,
+		Method:    method,
+		Amount:    amount,
+		Tendered:  tendered,
+		Change:    tendered.Sub(amount),
+	}
+}
+
+// PaymentReceipt is a payment with what is left to pay on its invoice.
+type PaymentReceipt struct {
+	Payment       Payment
+	AmountDue     Money
+	InvoiceStatus PaymentStatus
+}
+
+type PaymentAttemptStatus string
+
+const (
+	AttemptPending   PaymentAttemptStatus = "pending"
+	AttemptSucceeded PaymentAttemptStatus = "succeeded"
+	AttemptFailed    PaymentAttemptStatus = "failed"
+)
+
+// PaymentAttempt records one try to charge an invoice through a payment
+// provider, whether it went through or not.
+type PaymentAttempt struct {
+	ID            int
+	InvoiceID     int
+	Amount        Money
+	Provider      string
+	TakenBy       int
+	TransactionID string
+	Status        PaymentAttemptStatus
+	FailureReason string
+	CreatedAt     time.Time
+}
+
+func NewPaymentAttempt(invoiceId int, amount Money, provider string) PaymentAttempt {
+	return PaymentAttempt{
+		InvoiceID: invoiceId,
+		Amount:    amount,
+		Provider:  provider,
+		Status:    AttemptPending,
+	}
+}
+
+func (a *PaymentAttempt) Succeed(transactionId string) {
+	a.TransactionID = transactionId
+	a.Status = AttemptSucceeded
+	a.FailureReason = ""
+}
+
+func (a *PaymentAttempt) Fail(transactionId string, reason string) {
+	a.TransactionID = transactionId
+	a.Status = AttemptFailed
+	a.FailureReason = reason
+}
+
+type PaymentEventType string
+
+const (
+	EventPaymentCaptured PaymentEventType = "payment.captured"
+	EventPaymentFailed   PaymentEventType = "payment.failed"
+)
+
+type PaymentEventStatus string
+
+const (
+	EventReceived  PaymentEventStatus = "received"
+	EventProcessed PaymentEventStatus = "processed"
+	EventFailed    PaymentEventStatus = "failed"
+)
+
+// PaymentEvent is a notification the payment provider sent us about one of
+// its transactions. EventID is the provider's id and Payload the raw body,
+// kept for auditing.
+type PaymentEvent struct {
+	ID            int
+	Provider      string
+	EventID       string
+	Type          PaymentEventType
+	TransactionID string
+	Message       string
+	Payload       string
+	Status        PaymentEventStatus
+	Error         string
+	ReceivedAt    time.Time
+}
@@ -0,0 +1,26 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_domain_NewPayment(t *testing.T) {
+	due := NewMoney(4400, "USD")
+
+	payment := NewPayment(1, 2, PaymentCash, NewMoney(5000, "USD"), due)
+	assert.Equal(t, NewMoney(4400, "USD"), payment.Amount)
+	assert.Equal(t, NewMoney(5000, "USD"), payment.Tendered)
+	assert.Equal(t, NewMoney(600, "USD"), payment.Change)
+
+	payment = NewPayment(1, 2, PaymentCash, NewMoney(1000, "USD"), due)
+	assert.Equal(t, NewMoney(1000, "USD"), payment.Amount)
+	assert.True(t, payment.Change.IsZero())
+}
+
+func Test_domain_PaymentMethod_Validate(t *testing.T) {
+	assert.True(t, PaymentCash.Validate())
+	assert.True(t, PaymentCard.Validate())
+	assert.False(t, PaymentMethod("cheque").Validate())
+}
@@ -0,0 +1,104 @@
+package domain
+
+// Action is something a user does to a kind of resource, the value reads as
+// part of the error message when it is not allowed.
+type Action string
+
+const (
+	ActionCreateRestaurant     Action = "create restaurants"
+	ActionUpdateRestaurant     Action = "update restaurants"
+	ActionPauseOrders          Action = "pause restaurant orders"
+	ActionManageStaff          Action = "manage restaurant staff"
+	ActionManageTaxRules       Action = "manage tax rules"
+	ActionViewMemberships      Action = "view restaurant memberships"
+	ActionCreateMenuItem       Action = "add menu items"
+	ActionUpdateMenuItem       Action = "update menu items"
+	ActionDeleteMenuItem       Action = "delete menu items"
+	ActionManageMenus          Action = "manage menus"
+	ActionCreateOrder          Action = "create orders"
+	ActionViewOrder            Action = "view orders"
+	ActionViewOrderHistory     Action = "view order history"
+	ActionViewRestaurantOrders Action = "view restaurant orders"
+	ActionViewAllOrders        Action = "view all orders"
+	ActionModifyOrder          Action = "modify orders"
+	ActionTransitionOrder      Action = "update order status"
+	ActionCancelOrder          Action = "cancel orders"
+	ActionGenerateInvoice      Action = "generate invoices"
+	ActionViewInvoice          Action = "view invoices"
+	ActionViewAllInvoices      Action = "view all invoices"
+	ActionPayInvoice           Action = "pay invoices"
+	ActionRefundInvoice        Action = "refund invoices"
+	ActionManageUsers          Action = "manage users"
+)
+
+// Scope is which resources a role may perform an action on.
+type Scope int
+
+const (
+	// ScopeAny allows the action on every resource
+	ScopeAny Scope = iota + 1
+	// ScopeOwn allows the action on resources where the user is the customer
+	ScopeOwn
+	// ScopeRestaurant allows the action on resources of the user's restaurants
+	ScopeRestaurant
+	// ScopeStaff allows the action on resources of the restaurants the user is
+	// a member of, when their staff role is listed in StaffPolicies
+	ScopeStaff
+)
+
+// Resource identifies who a resource belongs to, for the ownership checks of
+// a policy.
+type Resource struct {
+	CustomerID   int
+	RestaurantID int
+}
+
+// Policy maps the roles allowed to perform an action to the resources they
+// may perform it on.
+type Policy map[UserRole]Scope
+
+// Policies lists who may do what, a role without an entry for an action is
+// not allowed to perform it.
+var Policies = map[Action]Policy{
+	ActionCreateRestaurant:     {OWNER: ScopeAny},
+	ActionUpdateRestaurant:     {OWNER: ScopeRestaurant, STAFF: ScopeStaff},
+	ActionPauseOrders:          {OWNER: ScopeRestaurant, STAFF: ScopeStaff},
+	ActionManageStaff:          {OWNER: ScopeRestaurant},
+	ActionManageTaxRules:       {OWNER: ScopeRestaurant, ADMIN: ScopeAny},
+	ActionViewMemberships:      {STAFF: ScopeAny},
+	ActionCreateMenuItem:       {OWNER: ScopeRestaurant, STAFF: ScopeStaff},
+	ActionUpdateMenuItem:       {OWNER: ScopeRestaurant, STAFF: ScopeStaff},
+	ActionDeleteMenuItem:       {OWNER: ScopeRestaurant, STAFF: ScopeStaff},
+	ActionManageMenus:          {OWNER: ScopeRestaurant, STAFF: ScopeStaff},
+	ActionCreateOrder:          {CUSTOMER: ScopeOwn},
+	ActionViewOrder:            {CUSTOMER: ScopeOwn, OWNER: ScopeRestaurant, STAFF: ScopeStaff, ADMIN: ScopeAny},
+	ActionViewOrderHistory:     {CUSTOMER: ScopeOwn},
+	ActionViewRestaurantOrders: {OWNER: ScopeRestaurant, STAFF: ScopeStaff},
+	ActionViewAllOrders:        {ADMIN: ScopeAny},
+	ActionModifyOrder:          {CUSTOMER: ScopeOwn},
+	ActionTransitionOrder:      {OWNER: ScopeRestaurant, STAFF: ScopeStaff},
+	ActionCancelOrder:          {CUSTOMER: ScopeOwn, OWNER: ScopeRestaurant, ADMIN: ScopeAny},
+	ActionGenerateInvoice:      {CUSTOMER: ScopeOwn},
+	ActionViewInvoice:          {CUSTOMER: ScopeOwn, OWNER: ScopeRestaurant, STAFF: ScopeStaff, ADMIN: ScopeAny},
+	ActionViewAllInvoices:      {ADMIN: ScopeAny},
+	ActionPayInvoice:           {CUSTOMER: ScopeOwn, STAFF: ScopeStaff},
+	ActionRefundInvoice:        {OWNER: ScopeRestaurant, ADMIN: ScopeAny},
+	ActionManageUsers:          {ADMIN: ScopeAny},
+}
+
+// StaffPolicies lists the staff roles allowed to perform an action in the
+// restaurants they are members of, for the actions where STAFF has
+// ScopeStaff.
+var StaffPolicies = map[Action][]StaffRole{
+	ActionUpdateRestaurant:     {StaffManager},
+	ActionPauseOrders:          {StaffManager, StaffKitchen},
+	ActionCreateMenuItem:       {StaffManager},
+	ActionUpdateMenuItem:       {StaffManager},
+	ActionDeleteMenuItem:       {StaffManager},
+	ActionManageMenus:          {StaffManager},
+	ActionViewOrder:            {StaffManager, StaffCashier, StaffKitchen},
+	ActionViewRestaurantOrders: {StaffManager, StaffCashier, StaffKitchen},
+	ActionTransitionOrder:      {StaffKitchen},
+	ActionViewInvoice:          {StaffCashier},
+	ActionPayInvoice:           {StaffCashier},
+}
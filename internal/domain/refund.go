@@ -0,0 +1,41 @@
+package domain
+
+import "time"
+
+// Refund is money given back on a paid invoice, against the payment it was
+// taken with.
+type Refund struct {
+	ID            int
+	InvoiceID     int
+	PaymentID     int
+	Amount        Money
+	Reason        string
+	TransactionID string
+	RefundedBy    int
+	CreatedAt     time.Time
+}
+
+func NewRefund(invoiceId int, amount Money, reason string, refundedBy int) Refund {
+	return Refund{
+		InvoiceID:  invoiceId,
+		Amount:     amount,
+		Reason:     reason,
+		RefundedBy: refundedBy,
+	}
+}
+
+func (r *Refund) Validate() bool {
+	if r.InvoiceID <= 0 || r.Reason == "" || r.RefundedBy <= 0 {
+		return false
+	}
+	return !r.Amount.IsZero() && !r.Amount.IsNegative()
+}
+
+// RefundStatus is the status of an invoice after refunding the given total of
+// the paid amount.
+func RefundStatus(paid, refunded Money) PaymentStatus {
+	if paid.Sub(refunded).IsZero() {
+		return Refunded
+	}
+	return PartiallyRefunded
+}
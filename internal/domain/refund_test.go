@@ -0,0 +1,53 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_domain_Refund_Validate(t *testing.T) {
+	tests := []struct {
+		name   string
+		refund Refund
+		want   bool
+	}{
+		{
+			name:   "Valid Refund",
+			refund: NewRefund(1, NewMoney(500, "USD"), "cold food", 2),
+			want:   true,
+		},
+		{
+			name:   "Invalid Refund - Zero Amount",
+			refund: NewRefund(1, NewMoney(0, "USD"), "cold food", 2),
+			want:   false,
+		},
+		{
+			name:   "Invalid Refund - Negative Amount",
+			refund: NewRefund(1, NewMoney(-500, "USD"), "cold food", 2),
+			want:   false,
+		},
+		{
+			name:   "Invalid Refund - Missing Reason",
+			refund: NewRefund(1, NewMoney(500, "USD"), "", 2),
+			want:   false,
+		},
+		{
+			name:   "Invalid Refund - Invalid Invoice ID",
+			refund: NewRefund(0, NewMoney(500, "USD"), "cold food", 2),
+			want:   false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.refund.Validate()
+			assert.Equal(t, tt.want, got, "Refund.Validate() = %v, want %v", got, tt.want)
+		})
+	}
+}
+
+func Test_domain_RefundStatus(t *testing.T) {
+	paid := NewMoney(4400, "USD")
+	assert.Equal(t, PartiallyRefunded, RefundStatus(paid, NewMoney(1000, "USD")))
+	assert.Equal(t, Refunded, RefundStatus(paid, NewMoney(4400, "USD")))
+}
@@ -1,11 +1,38 @@
 package domain
 
+import (
+	"slices"
+	"strings"
+	"time"
+)
+
+// Restaurant is a restaurant with its profile. A restaurant takes orders in
+// its opening hours, a restaurant without opening hours is open all week, and
+// not on the dates of its closures or while its orders are paused.
 type Restaurant struct {
-	ID      int
-	Name    string
-	OwnerID int
+	ID           int
+	Name         string
+	OwnerID      int
+	Address      string
+	Phone        string
+	Cuisines     []string
+	OpeningHours []OpeningHours
+	// Closures are the dates, as "2006-01-02", the restaurant is closed all day
+	Closures     []string
+	OrdersPaused bool
+}
+
+// OpeningHours is a window of a day of the week, as minutes since midnight,
+// End is not part of it. A window ending before it starts runs past midnight
+// into the next day.
+type OpeningHours struct {
+	Weekday time.Weekday
+	Start   int
+	End     int
 }
 
+const DateLayout = "2006-01-02"
+
 func NewRestaurant(id int, name string, ownerID int) Restaurant {
 	return Restaurant{
 		ID:      id,
@@ -18,5 +45,156 @@ func (r *Restaurant) Validate() bool {
 	if r.Name == "" || r.OwnerID <= 0 {
 		return false
 	}
+	for i, cuisine := range r.Cuisines {
+		if cuisine == "" || slices.Contains(r.Cuisines[:i], cuisine) {
+			return false
+		}
+	}
+	for _, hours := range r.OpeningHours {
+		if !hours.Validate() {
+			return false
+		}
+	}
+	for i, date := range r.Closures {
+		if _, err := time.Parse(DateLayout, date); err != nil || slices.Contains(r.Closures[:i], date) {
+			return false
+		}
+	}
 	return true
 }
+
+func (h OpeningHours) Validate() bool {
+	if h.Weekday < time.Sunday || h.Weekday > time.Saturday {
+		return false
+	}
+	return MenuSchedule{Start: h.Start, End: h.End}.Validate()
+}
+
+// Contains reports if t falls in the window, on its weekday or past midnight
+// on the next day.
+func (h OpeningHours) Contains(t time.Time) bool {
+	minute := t.Hour()*60 + t.Minute()
+	if h.Start < h.End {
+		return t.Weekday() == h.Weekday && minute >= h.Start && minute < h.End
+	}
+	if t.Weekday() == h.Weekday {
+		return minute >= h.Start
+	}
+	return t.Weekday() == (h.Weekday+1)%7 && minute < h.End
+}
+
+func (r *Restaurant) IsClosedOn(t time.Time) bool {
+	return slices.Contains(r.Closures, t.Format(DateLayout))
+}
+
+// IsOpenAt reports if t falls in the opening hours of the restaurant and not
+// on one of its closures.
+func (r *Restaurant) IsOpenAt(t time.Time) bool {
+	if r.IsClosedOn(t) {
+		return false
+	}
+	if len(r.OpeningHours) == 0 {
+		return true
+	}
+	for _, hours := range r.OpeningHours {
+		if hours.Contains(t) {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *Restaurant) AcceptsOrdersAt(t time.Time) bool {
+	return !r.OrdersPaused && r.IsOpenAt(t)
+}
+
+// NormalizeCuisines lower cases and trims the cuisine tags, so they are
+// matched whatever the case they were entered in.
+func NormalizeCuisines(cuisines []string) []string {
+	normalized := make([]string, 0, len(cuisines))
+	for _, cuisine := range cuisines {
+		normalized = append(normalized, strings.ToLower(strings.TrimSpace(cuisine)))
+	}
+	return normalized
+}
+
+// ParseWeekday parses the english name of a day of the week, in any case.
+func ParseWeekday(value string) (time.Weekday, bool) {
+	for day := time.Sunday; day <= time.Saturday; day++ {
+		if strings.EqualFold(value, day.String()) {
+			return day, true
+		}
+	}
+	return 0, false
+}
+
+// RestaurantFilter narrows down and orders a list of restaurants. Query is
+// searched in the names of the restaurants and of the dishes they serve,
+// Cuisine keeps the restaurants tagged with it and OpenAt, when set, the ones
+// taking orders at that time. Cursor is the id of the last restaurant of the
+// previous page.
+type RestaurantFilter struct {
+	Query   string
+	Cuisine string
+	OpenAt  time.Time
+	Sort    RestaurantSort
+	Cursor  int
+	Limit   int
+}
+
+type RestaurantSort string
+
+const (
+	// RestaurantSortRelevance ranks the restaurants by how well they match
+	// the query, their name weighing more than their dishes
+	RestaurantSortRelevance RestaurantSort = "relevance"
+	RestaurantSortName      RestaurantSort = "name"
+	RestaurantSortNewest    RestaurantSort = "newest"
+)
+
+func (s RestaurantSort) IsValid() bool {
+	switch s {
+	case RestaurantSortRelevance, RestaurantSortName, RestaurantSortNewest:
+		return true
+	}
+	return false
+}
+
+// StaffRole is what a staff member does in a restaurant.
+type StaffRole string
+
+const (
+	StaffManager StaffRole = "manager"
+	StaffCashier StaffRole = "cashier"
+	StaffKitchen StaffRole = "kitchen"
+)
+
+func (r StaffRole) IsValid() bool {
+	switch r {
+	case StaffManager, StaffCashier, StaffKitchen:
+		return true
+	}
+	return false
+}
+
+// RestaurantMember attaches a staff user to a restaurant. Name and Email are
+// filled in when listing the members of a restaurant.
+type RestaurantMember struct {
+	RestaurantID int
+	UserID       int
+	Role         StaffRole
+	Name         string
+	Email        string
+}
+
+func NewRestaurantMember(restaurantId int, userId int, role StaffRole) RestaurantMember {
+	return RestaurantMember{
+		RestaurantID: restaurantId,
+		UserID:       userId,
+		Role:         role,
+	}
+}
+
+func (m *RestaurantMember) Validate() bool {
+	return m.RestaurantID > 0 && m.UserID > 0 && m.Role.IsValid()
+}
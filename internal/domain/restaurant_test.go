@@ -2,6 +2,7 @@ package domain
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -81,6 +82,38 @@ func Test_domain_Restaurant_Validate(t *testing.T) {
 			},
 			want: false,
 		},
+		{
+			name: "valid restaurant with profile",
+			r: Restaurant{
+				ID:           5,
+				Name:         "Profile Restaurant",
+				OwnerID:      1,
+				Cuisines:     []string{"indian", "vegan"},
+				OpeningHours: []OpeningHours{{Weekday: time.Friday, Start: 1080, End: 120}},
+				Closures:     []string{"2026-12-25"},
+			},
+			want: true,
+		},
+		{
+			name: "invalid restaurant with duplicate cuisine",
+			r:    Restaurant{ID: 6, Name: "Restaurant", OwnerID: 1, Cuisines: []string{"thai", "thai"}},
+			want: false,
+		},
+		{
+			name: "invalid restaurant with empty window",
+			r:    Restaurant{ID: 7, Name: "Restaurant", OwnerID: 1, OpeningHours: []OpeningHours{{Weekday: time.Monday, Start: 600, End: 600}}},
+			want: false,
+		},
+		{
+			name: "invalid restaurant with unknown weekday",
+			r:    Restaurant{ID: 8, Name: "Restaurant", OwnerID: 1, OpeningHours: []OpeningHours{{Weekday: 7, Start: 600, End: 900}}},
+			want: false,
+		},
+		{
+			name: "invalid restaurant with malformed closure",
+			r:    Restaurant{ID: 9, Name: "Restaurant", OwnerID: 1, Closures: []string{"25/12/2026"}},
+			want: false,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -89,3 +122,119 @@ func Test_domain_Restaurant_Validate(t *testing.T) {
 		})
 	}
 }
+
+func Test_domain_RestaurantMember_Validate(t *testing.T) {
+	tests := []struct {
+		name string
+		m    RestaurantMember
+		want bool
+	}{
+		{
+			name: "valid member",
+			m:    NewRestaurantMember(1, 2, StaffKitchen),
+			want: true,
+		},
+		{
+			name: "invalid member without restaurant",
+			m:    NewRestaurantMember(0, 2, StaffCashier),
+			want: false,
+		},
+		{
+			name: "invalid member without user",
+			m:    NewRestaurantMember(1, 0, StaffManager),
+			want: false,
+		},
+		{
+			name: "invalid member with unknown role",
+			m:    NewRestaurantMember(1, 2, StaffRole("waiter")),
+			want: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.m.Validate())
+		})
+	}
+}
+
+func Test_domain_OpeningHours_Contains(t *testing.T) {
+	// 2026-10-16 is a Friday
+	at := func(day int, hour int, minute int) time.Time {
+		return time.Date(2026, 10, day, hour, minute, 0, 0, time.UTC)
+	}
+	lunch := OpeningHours{Weekday: time.Friday, Start: 660, End: 900}
+	late := OpeningHours{Weekday: time.Friday, Start: 1080, End: 120}
+
+	assert.True(t, lunch.Contains(at(16, 11, 0)))
+	assert.False(t, lunch.Contains(at(16, 15, 0)))
+	assert.False(t, lunch.Contains(at(17, 12, 0)), "lunch is only on fridays")
+	assert.True(t, late.Contains(at(16, 23, 30)))
+	assert.True(t, late.Contains(at(17, 1, 59)), "runs past midnight into saturday")
+	assert.False(t, late.Contains(at(17, 2, 0)))
+	assert.False(t, late.Contains(at(16, 1, 0)), "does not run into friday morning")
+}
+
+func Test_domain_Restaurant_AcceptsOrdersAt(t *testing.T) {
+	friday := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name string
+		r    Restaurant
+		want bool
+	}{
+		{
+			name: "open all week without opening hours",
+			r:    Restaurant{ID: 1},
+			want: true,
+		},
+		{
+			name: "open in its opening hours",
+			r:    Restaurant{ID: 1, OpeningHours: []OpeningHours{{Weekday: time.Monday, Start: 660, End: 900}, {Weekday: time.Friday, Start: 660, End: 900}}},
+			want: true,
+		},
+		{
+			name: "closed outside its opening hours",
+			r:    Restaurant{ID: 1, OpeningHours: []OpeningHours{{Weekday: time.Friday, Start: 1080, End: 1380}}},
+			want: false,
+		},
+		{
+			name: "closed on a closure",
+			r:    Restaurant{ID: 1, Closures: []string{"2026-10-16"}},
+			want: false,
+		},
+		{
+			name: "paused",
+			r:    Restaurant{ID: 1, OrdersPaused: true},
+			want: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.r.AcceptsOrdersAt(friday))
+		})
+	}
+}
+
+func Test_domain_ParseWeekday(t *testing.T) {
+	day, ok := ParseWeekday("Monday")
+	assert.True(t, ok)
+	assert.Equal(t, time.Monday, day)
+
+	day, ok = ParseWeekday("sunday")
+	assert.True(t, ok)
+	assert.Equal(t, time.Sunday, day)
+
+	_, ok = ParseWeekday("mon")
+	assert.False(t, ok)
+}
+
+func Test_domain_NormalizeCuisines(t *testing.T) {
+	assert.Equal(t, []string{"indian", "street food"}, NormalizeCuisines([]string{" Indian", "Street Food "}))
+}
+
+func Test_domain_RestaurantSort_IsValid(t *testing.T) {
+	assert.True(t, RestaurantSortRelevance.IsValid())
+	assert.True(t, RestaurantSortName.IsValid())
+	assert.True(t, RestaurantSortNewest.IsValid())
+	assert.False(t, RestaurantSort("rating").IsValid())
+	assert.False(t, RestaurantSort("").IsValid())
+}
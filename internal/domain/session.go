@@ -0,0 +1,36 @@
+package domain
+
+import "time"
+
+// AuthTokens are handed out on login and refresh: a short lived access token
+// and the refresh token used to get the next pair.
+type AuthTokens struct {
+	AccessToken  string
+	RefreshToken string
+}
+
+// RefreshToken is stored by the hash of the token, never the token itself.
+// Every refresh revokes the used token and issues a new one in the same
+// session, so a revoked token coming back means it was stolen.
+type RefreshToken struct {
+	ID        int
+	UserID    int
+	SessionID string
+	TokenHash string
+	ExpiresAt time.Time
+	Revoked   bool
+	CreatedAt time.Time
+}
+
+func NewRefreshToken(userId int, sessionId string, tokenHash string, expiresAt time.Time) RefreshToken {
+	return RefreshToken{
+		UserID:    userId,
+		SessionID: sessionId,
+		TokenHash: tokenHash,
+		ExpiresAt: expiresAt,
+	}
+}
+
+func (t *RefreshToken) IsExpired(now time.Time) bool {
+	return !now.Before(t.ExpiresAt)
+}
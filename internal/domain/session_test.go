@@ -0,0 +1,17 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_domain_RefreshToken_IsExpired(t *testing.T) {
+	now := time.Now()
+	token := NewRefreshToken(1, "session", "hash", now.Add(time.Hour))
+
+	assert.False(t, token.IsExpired(now))
+	assert.True(t, token.IsExpired(now.Add(time.Hour)))
+	assert.True(t, token.IsExpired(now.Add(2*time.Hour)))
+}
@@ -0,0 +1,64 @@
+package domain
+
+// TaxRule is a tax rate charged on menu items. A rule without a restaurant
+// applies to every restaurant and a rule without a category applies to every
+// item, when several rules match the most specific one is used.
+type TaxRule struct {
+	ID           int
+	RestaurantID int
+	Category     string
+	Rate         float64
+	// Inclusive rules treat the menu price as already including the tax
+	Inclusive bool
+}
+
+// DefaultTaxRule is used when no rule is configured for a restaurant.
+var DefaultTaxRule = TaxRule{Rate: 0.10}
+
+// Validate checks the rate is a fraction, 0.10 is a 10% tax.
+func (r *TaxRule) Validate() bool {
+	return r.RestaurantID >= 0 && r.Rate >= 0 && r.Rate <= 1
+}
+
+func (r *TaxRule) Matches(restaurantId int, category string) bool {
+	return (r.RestaurantID == 0 || r.RestaurantID == restaurantId) &&
+		(r.Category == "" || r.Category == category)
+}
+
+func (r *TaxRule) specificity() int {
+	specificity := 0
+	if r.RestaurantID != 0 {
+		specificity += 2
+	}
+	if r.Category != "" {
+		specificity++
+	}
+	return specificity
+}
+
+// Apply splits an amount into its pre-tax part and the tax on it, so that
+// net + tax is always the amount charged.
+func (r *TaxRule) Apply(amount Money) (net Money, tax Money) {
+	if r.Inclusive {
+		net = amount.DivRate(1 + r.Rate)
+		return net, amount.Sub(net)
+	}
+	return amount, amount.MulRate(r.Rate)
+}
+
+// SelectTaxRule returns the most specific rule matching the restaurant and
+// category, falling back to DefaultTaxRule.
+func SelectTaxRule(rules []TaxRule, restaurantId int, category string) TaxRule {
+	selected := DefaultTaxRule
+	found := false
+	for _, rule := range rules {
+		if !rule.Matches(restaurantId, category) {
+			continue
+		}
+		if !found || rule.specificity() > selected.specificity() {
+			selected = rule
+			found = true
+		}
+	}
+	return selected
+}
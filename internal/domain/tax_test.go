@@ -0,0 +1,71 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_domain_TaxRule_Validate(t *testing.T) {
+	assert.True(t, (&TaxRule{RestaurantID: 1, Category: "alcohol", Rate: 0.20}).Validate())
+	assert.True(t, (&TaxRule{Rate: 0}).Validate())
+	assert.False(t, (&TaxRule{RestaurantID: 1, Rate: -0.10}).Validate())
+	// a percentage instead of a fraction
+	assert.False(t, (&TaxRule{RestaurantID: 1, Rate: 10}).Validate())
+	assert.False(t, (&TaxRule{RestaurantID: -1, Rate: 0.10}).Validate())
+}
+
+func Test_domain_TaxRule_Apply(t *testing.T) {
+	tests := []struct {
+		name    string
+		rule    TaxRule
+		amount  int64
+		wantNet int64
+		wantTax int64
+	}{
+		{"exclusive", TaxRule{Rate: 0.10}, 20000, 20000, 2000},
+		{"exclusive rounds to cents", TaxRule{Rate: 0.10}, 345, 345, 35},
+		{"inclusive", TaxRule{Rate: 0.20, Inclusive: true}, 1200, 1000, 200},
+		{"inclusive rounds to cents", TaxRule{Rate: 0.05, Inclusive: true}, 1000, 952, 48},
+		{"zero rate", TaxRule{Rate: 0}, 1000, 1000, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			net, tax := tt.rule.Apply(NewMoney(tt.amount, "USD"))
+			assert.Equal(t, NewMoney(tt.wantNet, "USD"), net)
+			assert.Equal(t, NewMoney(tt.wantTax, "USD"), tax)
+		})
+	}
+}
+
+func Test_domain_SelectTaxRule(t *testing.T) {
+	rules := []TaxRule{
+		{ID: 1, Rate: 0.10},
+		{ID: 2, Category: "alcohol", Rate: 0.20},
+		{ID: 3, RestaurantID: 5, Rate: 0.08},
+		{ID: 4, RestaurantID: 5, Category: "alcohol", Rate: 0.25},
+		{ID: 5, RestaurantID: 6, Rate: 0.05},
+	}
+
+	tests := []struct {
+		name         string
+		rules        []TaxRule
+		restaurantId int
+		category     string
+		wantID       int
+	}{
+		{"default rule", rules, 1, "food", 1},
+		{"default category rule", rules, 1, "alcohol", 2},
+		{"restaurant rule", rules, 5, "food", 3},
+		{"restaurant category rule", rules, 5, "alcohol", 4},
+		{"restaurant rule beats default category rule", rules, 6, "alcohol", 5},
+		{"no rules falls back to default", nil, 1, "food", 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := SelectTaxRule(tt.rules, tt.restaurantId, tt.category)
+			assert.Equal(t, tt.wantID, got.ID)
+		})
+	}
+	assert.Equal(t, 0.10, SelectTaxRule(nil, 1, "").Rate, "expected default rate to be 10%")
+}
@@ -8,22 +8,40 @@ const (
 	CUSTOMER UserRole = "customer"
 	OWNER    UserRole = "owner"
 	ADMIN    UserRole = "admin"
+	// STAFF works in the restaurants they are members of, with the
+	// permissions of their staff role there.
+	STAFF UserRole = "staff"
 )
 
 func (r UserRole) IsValid() bool {
 	switch r {
-	case CUSTOMER, OWNER, ADMIN:
+	case CUSTOMER, OWNER, ADMIN, STAFF:
 		return true
 	}
 	return false
 }
 
 type User struct {
-	ID       int
-	Name     string
-	Email    string
-	Role     UserRole
-	Password string
+	ID        int
+	Name      string
+	Email     string
+	Role      UserRole
+	Password  string
+	Suspended bool
+	// PendingApproval is set on self-registered owners until an admin
+	// approves them, they cannot log in before that.
+	PendingApproval bool
+}
+
+// UserFilter narrows down a list of users. Query matches part of the name or
+// email, PendingApproval keeps only the users waiting for approval and Cursor
+// is the id of the last user of the previous page.
+type UserFilter struct {
+	Query           string
+	Role            UserRole
+	PendingApproval bool
+	Cursor          int
+	Limit           int
 }
 
 func NewUser(id int, name, email, password string, role UserRole) User {
@@ -36,6 +36,13 @@ func Test_domain_UserRole_IsValid(t *testing.T) {
 			},
 			want: true,
 		},
+		{
+			name: "valid role STAFF",
+			fields: fields{
+				r: STAFF,
+			},
+			want: true,
+		},
 		{
 			name: "invalid role GUEST",
 			fields: fields{
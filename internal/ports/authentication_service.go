@@ -1,8 +1,15 @@
 package ports
 
-import "context"
+import (
+	"context"
+
+	"github.com/mohits-git/food-ordering-system/internal/domain"
+	"github.com/mohits-git/food-ordering-system/internal/utils/authctx"
+)
 
 type AuthenticationService interface {
-	Login(ctx context.Context, email, password string) (token string, err error)
+	Login(ctx context.Context, email, password string) (domain.AuthTokens, error)
+	Refresh(ctx context.Context, refreshToken string) (domain.AuthTokens, error)
 	Logout(ctx context.Context, token string) error
+	IsTokenRevoked(ctx context.Context, claims authctx.UserClaims) (bool, error)
 }
@@ -0,0 +1,17 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/mohits-git/food-ordering-system/internal/domain"
+	"github.com/mohits-git/food-ordering-system/internal/utils/authctx"
+)
+
+type Authorizer interface {
+	// Authorize checks the user of ctx has a policy for the action, before
+	// the resource is loaded.
+	Authorize(ctx context.Context, action domain.Action) (*authctx.UserClaims, error)
+	// AuthorizeResource also checks the user may perform the action on the
+	// resource.
+	AuthorizeResource(ctx context.Context, action domain.Action, resource domain.Resource) (*authctx.UserClaims, error)
+}
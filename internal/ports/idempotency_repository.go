@@ -0,0 +1,17 @@
+package ports
+
+import (
+	"context"
+	"time"
+
+	"github.com/mohits-git/food-ordering-system/internal/domain"
+)
+
+type IdempotencyRepository interface {
+	// SaveIdempotencyKey gives a conflict error when the user already has the key.
+	SaveIdempotencyKey(ctx context.Context, key domain.IdempotencyKey) (int, error)
+	FindIdempotencyKey(ctx context.Context, userId int, key string) (domain.IdempotencyKey, error)
+	SaveIdempotencyResponse(ctx context.Context, id int, statusCode int, body []byte) error
+	DeleteIdempotencyKey(ctx context.Context, id int) error
+	DeleteExpiredIdempotencyKeys(ctx context.Context, now time.Time) error
+}
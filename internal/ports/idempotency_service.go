@@ -0,0 +1,13 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/mohits-git/food-ordering-system/internal/domain"
+)
+
+type IdempotencyService interface {
+	StartRequest(ctx context.Context, key string, requestHash string) (domain.IdempotencyKey, error)
+	CompleteRequest(ctx context.Context, id int, statusCode int, body []byte) error
+	ReleaseRequest(ctx context.Context, id int) error
+}
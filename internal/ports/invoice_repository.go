@@ -10,5 +10,6 @@ type InvoiceRepository interface {
 	SaveInvoice(cxt context.Context, invoice domain.Invoice) (int, error)
 	FindInvoiceById(cxt context.Context, id int) (domain.Invoice, error)
 	FindInvoicesByOrderId(ctx context.Context, orderId int) ([]domain.Invoice, error)
+	FindInvoices(ctx context.Context, filter domain.InvoiceFilter) ([]domain.Invoice, error)
 	ChangeInvoiceStatus(cxt context.Context, invoiceId int, status domain.PaymentStatus) error
 }
@@ -9,5 +9,7 @@ import (
 type InvoiceService interface {
 	GenerateInvoice(cxt context.Context, orderId int) (domain.Invoice, error)
 	GetInvoiceById(cxt context.Context, id int) (domain.Invoice, error)
-	DoInvoicePayment(cxt context.Context, invoiceId int, payment float64) error
+	GetInvoices(ctx context.Context, filter domain.InvoiceFilter) (invoices []domain.Invoice, nextCursor int, err error)
+	DoInvoicePayment(cxt context.Context, invoiceId int, method domain.PaymentMethod, tendered domain.Money) (domain.PaymentReceipt, error)
+	RefundInvoice(ctx context.Context, invoiceId int, amount domain.Money, reason string) (domain.Refund, error)
 }
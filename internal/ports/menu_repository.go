@@ -0,0 +1,15 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/mohits-git/food-ordering-system/internal/domain"
+)
+
+type MenuRepository interface {
+	SaveMenu(ctx context.Context, menu domain.Menu) (int, error)
+	UpdateMenu(ctx context.Context, menu domain.Menu) error
+	DeleteMenu(ctx context.Context, id int) error
+	FindMenuById(ctx context.Context, id int) (domain.Menu, error)
+	FindMenusByRestaurantId(ctx context.Context, restaurantId int) ([]domain.Menu, error)
+}
@@ -7,7 +7,10 @@ import (
 
 type MenuItemRepository interface {
   SaveMenuItem(cxt context.Context, item domain.MenuItem) (int, error)
+  UpdateMenuItem(cxt context.Context, item domain.MenuItem) error
+  DeleteMenuItem(cxt context.Context, id int) error
   UpdateMenuItemAvailability(cxt context.Context, id int, available bool) error
+  UpdateMenuItemStock(cxt context.Context, id int, trackStock bool, stock int) error
   FindMenuItemsByRestaurantId(cxt context.Context, restaurantId int) ([]domain.MenuItem, error)
   FindMenuItemById(cxt context.Context, id int) (domain.MenuItem, error)
 }
@@ -8,6 +8,13 @@ import (
 
 type MenuItemService interface {
   CreateMenuItemForRestaurant(ctx context.Context, item domain.MenuItem) (int, error)
-  GetAllMenuItemsByRestaurantId(ctx context.Context, restaurantId int) ([]domain.MenuItem, error)
+  GetAllMenuItemsByRestaurantId(ctx context.Context, restaurantId int) ([]domain.Menu, error)
   UpdateAvailability(ctx context.Context, id int, available bool) error
+  UpdateStock(ctx context.Context, id int, trackStock bool, stock int) error
+  UpdateMenuItem(ctx context.Context, item domain.MenuItem) error
+  DeleteMenuItem(ctx context.Context, id int) error
+  GetMenusByRestaurantId(ctx context.Context, restaurantId int) ([]domain.Menu, error)
+  CreateMenu(ctx context.Context, menu domain.Menu) (int, error)
+  UpdateMenu(ctx context.Context, menu domain.Menu) error
+  DeleteMenu(ctx context.Context, id int) error
 }
@@ -9,5 +9,9 @@ import (
 type OrderRepository interface {
   SaveOrder(ctx context.Context, order domain.Order) (int, error)
   FindOrderById(ctx context.Context, id int) (domain.Order, error)
+  FindOrdersByCustomerId(ctx context.Context, customerId int, filter domain.OrderFilter) ([]domain.Order, error)
+  FindOrders(ctx context.Context, filter domain.OrderFilter) ([]domain.Order, error)
+  FindOrdersByRestaurantId(ctx context.Context, restaurantId int, statuses []domain.OrderStatus) ([]domain.Order, error)
   UpdateOrder(ctx context.Context, order domain.Order) error
+  UpdateOrderStatus(ctx context.Context, id int, status domain.OrderStatus) error
 }
@@ -9,5 +9,14 @@ import (
 type OrderService interface {
 	CreateOrder(ctx context.Context, order domain.Order) (int, error)
 	GetOrderById(ctx context.Context, id int) (domain.Order, error)
+	GetCustomerOrders(ctx context.Context, filter domain.OrderFilter) (orders []domain.Order, nextCursor int, err error)
+	GetAllOrders(ctx context.Context, filter domain.OrderFilter) (orders []domain.Order, nextCursor int, err error)
+	GetRestaurantOrders(ctx context.Context, restaurantId int, statuses []domain.OrderStatus) ([]domain.Order, error)
 	AddOrderItem(ctx context.Context, orderId int, item domain.OrderItem) error
+	UpdateOrderItemQuantity(ctx context.Context, orderId int, menuItemId int, quantity int) error
+	UpdateOrderLineQuantity(ctx context.Context, orderId int, lineId int, quantity int) error
+	RemoveOrderItem(ctx context.Context, orderId int, menuItemId int) error
+	RemoveOrderLine(ctx context.Context, orderId int, lineId int) error
+	TransitionOrder(ctx context.Context, orderId int, status domain.OrderStatus) error
+	CancelOrder(ctx context.Context, orderId int) error
 }
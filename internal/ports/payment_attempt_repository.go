@@ -0,0 +1,14 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/mohits-git/food-ordering-system/internal/domain"
+)
+
+type PaymentAttemptRepository interface {
+	SavePaymentAttempt(ctx context.Context, attempt domain.PaymentAttempt) (int, error)
+	UpdatePaymentAttempt(ctx context.Context, attempt domain.PaymentAttempt) error
+	FindPaymentAttemptsByInvoiceId(ctx context.Context, invoiceId int) ([]domain.PaymentAttempt, error)
+	FindPaymentAttemptByTransactionId(ctx context.Context, provider string, transactionId string) (domain.PaymentAttempt, error)
+}
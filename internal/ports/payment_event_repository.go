@@ -0,0 +1,13 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/mohits-git/food-ordering-system/internal/domain"
+)
+
+type PaymentEventRepository interface {
+	SavePaymentEvent(ctx context.Context, event domain.PaymentEvent) (int, error)
+	FindPaymentEvent(ctx context.Context, provider string, eventId string) (domain.PaymentEvent, error)
+	UpdatePaymentEventStatus(ctx context.Context, id int, status domain.PaymentEventStatus, errMsg string) error
+}
@@ -0,0 +1,19 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/mohits-git/food-ordering-system/internal/domain"
+)
+
+// PaymentGateway is a payment provider. Authorize may return a pending result,
+// the final outcome is then read with Status or arrives as a webhook event.
+// ParseWebhook verifies the signature of a webhook payload and decodes it.
+type PaymentGateway interface {
+	Name() string
+	Authorize(ctx context.Context, reference string, amount domain.Money) (domain.GatewayResult, error)
+	Capture(ctx context.Context, transactionId string) (domain.GatewayResult, error)
+	Refund(ctx context.Context, transactionId string, amount domain.Money) (domain.GatewayResult, error)
+	Status(ctx context.Context, transactionId string) (domain.GatewayResult, error)
+	ParseWebhook(payload []byte, signature string) (domain.PaymentEvent, error)
+}
@@ -0,0 +1,12 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/mohits-git/food-ordering-system/internal/domain"
+)
+
+type PaymentRepository interface {
+	SavePayment(ctx context.Context, payment domain.Payment) (int, error)
+	FindPaymentsByInvoiceId(ctx context.Context, invoiceId int) ([]domain.Payment, error)
+}
@@ -0,0 +1,7 @@
+package ports
+
+import "context"
+
+type PaymentWebhookService interface {
+	HandlePaymentWebhook(ctx context.Context, payload []byte, signature string) error
+}
@@ -0,0 +1,18 @@
+package ports
+
+import (
+	"context"
+	"time"
+
+	"github.com/mohits-git/food-ordering-system/internal/domain"
+)
+
+type RefreshTokenRepository interface {
+	SaveRefreshToken(ctx context.Context, token domain.RefreshToken) (int, error)
+	FindRefreshTokenByHash(ctx context.Context, tokenHash string) (domain.RefreshToken, error)
+	// RevokeRefreshToken reports false when the token was already revoked.
+	RevokeRefreshToken(ctx context.Context, id int) (bool, error)
+	RevokeSession(ctx context.Context, sessionId string) error
+	RevokeUserSessions(ctx context.Context, userId int) error
+	DeleteExpiredRefreshTokens(ctx context.Context, now time.Time) error
+}
@@ -0,0 +1,12 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/mohits-git/food-ordering-system/internal/domain"
+)
+
+type RefundRepository interface {
+	SaveRefund(ctx context.Context, refund domain.Refund) (int, error)
+	FindRefundsByInvoiceId(ctx context.Context, invoiceId int) ([]domain.Refund, error)
+}
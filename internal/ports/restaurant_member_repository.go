@@ -0,0 +1,15 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/mohits-git/food-ordering-system/internal/domain"
+)
+
+type RestaurantMemberRepository interface {
+	SaveRestaurantMember(ctx context.Context, member domain.RestaurantMember) error
+	FindRestaurantMember(ctx context.Context, restaurantId int, userId int) (domain.RestaurantMember, error)
+	FindRestaurantMembers(ctx context.Context, restaurantId int) ([]domain.RestaurantMember, error)
+	FindMembershipsByUserId(ctx context.Context, userId int) ([]domain.RestaurantMember, error)
+	DeleteRestaurantMember(ctx context.Context, restaurantId int, userId int) (bool, error)
+}
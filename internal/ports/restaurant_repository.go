@@ -8,6 +8,8 @@ import (
 
 type RestaurantRepository interface {
   SaveRestaurant(cxt context.Context, restaurant domain.Restaurant) (int, error)
-  FindAllRestaurants(cxt context.Context) ([]domain.Restaurant, error)
+  FindRestaurants(cxt context.Context, filter domain.RestaurantFilter) ([]domain.Restaurant, error)
 	FindRestaurantById(cxt context.Context, id int) (domain.Restaurant, error)
+  UpdateRestaurant(cxt context.Context, restaurant domain.Restaurant) error
+  UpdateOrdersPaused(cxt context.Context, id int, paused bool) error
 }
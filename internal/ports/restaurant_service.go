@@ -8,5 +8,14 @@ import (
 
 type RestaurantService interface {
   CreateRestaurant(ctx context.Context, restaurantName string) (int, error)
-  GetAllRestaurants(ctx context.Context) ([]domain.Restaurant, error)
+  SearchRestaurants(ctx context.Context, filter domain.RestaurantFilter) ([]domain.Restaurant, int, error)
+  GetRestaurantById(ctx context.Context, id int) (domain.Restaurant, error)
+  UpdateRestaurant(ctx context.Context, restaurant domain.Restaurant) error
+  PauseOrders(ctx context.Context, restaurantId int, paused bool) error
+  InviteMember(ctx context.Context, restaurantId int, email string, role domain.StaffRole) (domain.RestaurantMember, error)
+  RemoveMember(ctx context.Context, restaurantId int, userId int) error
+  GetMembers(ctx context.Context, restaurantId int) ([]domain.RestaurantMember, error)
+  GetMemberships(ctx context.Context) ([]domain.RestaurantMember, error)
+  SetTaxRule(ctx context.Context, rule domain.TaxRule) (domain.TaxRule, error)
+  DeleteTaxRule(ctx context.Context, restaurantId int, category string) error
 }
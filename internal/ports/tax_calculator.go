@@ -0,0 +1,11 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/mohits-git/food-ordering-system/internal/domain"
+)
+
+type TaxCalculator interface {
+	CalculateTax(ctx context.Context, restaurantId int, category string, amount domain.Money) (net domain.Money, tax domain.Money, err error)
+}
@@ -0,0 +1,13 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/mohits-git/food-ordering-system/internal/domain"
+)
+
+type TaxRuleRepository interface {
+	FindTaxRulesByRestaurantId(ctx context.Context, restaurantId int) ([]domain.TaxRule, error)
+	SaveTaxRule(ctx context.Context, rule domain.TaxRule) (int, error)
+	DeleteTaxRule(ctx context.Context, restaurantId int, category string) (bool, error)
+}
@@ -10,4 +10,8 @@ type UserRepository interface {
 	FindUserById(ctx context.Context, id int) (domain.User, error)
 	FindUserByEmail(ctx context.Context, email string) (domain.User, error)
 	SaveUser(ctx context.Context, user domain.User) (int, error)
+	FindUsers(ctx context.Context, filter domain.UserFilter) ([]domain.User, error)
+	UpdateUserRole(ctx context.Context, id int, role domain.UserRole) error
+	UpdateUserSuspended(ctx context.Context, id int, suspended bool) error
+	ApproveUser(ctx context.Context, id int) error
 }
@@ -8,5 +8,11 @@ import (
 
 type UserService interface {
 	GetUserById(ctx context.Context, id int) (domain.User, error)
+	RegisterUser(ctx context.Context, user domain.User) (int, error)
 	CreateUser(ctx context.Context, user domain.User) (int, error)
+	CreateAdmin(ctx context.Context, user domain.User) (int, error)
+	ApproveUser(ctx context.Context, id int) error
+	ListUsers(ctx context.Context, filter domain.UserFilter) (users []domain.User, nextCursor int, err error)
+	ChangeUserRole(ctx context.Context, id int, role domain.UserRole) error
+	SetUserSuspended(ctx context.Context, id int, suspended bool) error
 }
@@ -2,56 +2,185 @@ package services
 
 import (
 	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"time"
 
+	"github.com/mohits-git/food-ordering-system/internal/domain"
 	"github.com/mohits-git/food-ordering-system/internal/ports"
 	"github.com/mohits-git/food-ordering-system/internal/utils/apperr"
 	"github.com/mohits-git/food-ordering-system/internal/utils/authctx"
 )
 
 type AuthenticationService struct {
-	userRepo       ports.UserRepository
-	tokenProvider  ports.TokenProvider
-	passwordHasher ports.PasswordHasher
+	userRepo         ports.UserRepository
+	refreshTokenRepo ports.RefreshTokenRepository
+	revokedTokenRepo ports.RevokedTokenRepository
+	tokenProvider    ports.TokenProvider
+	passwordHasher   ports.PasswordHasher
+	refreshTokenTTL  time.Duration
 }
 
 func NewAuthenticationService(
 	userRepo ports.UserRepository,
+	refreshTokenRepo ports.RefreshTokenRepository,
+	revokedTokenRepo ports.RevokedTokenRepository,
 	tokenProvider ports.TokenProvider,
 	passwordHasher ports.PasswordHasher,
+	refreshTokenTTL time.Duration,
 ) *AuthenticationService {
 	return &AuthenticationService{
-		userRepo:       userRepo,
-		tokenProvider:  tokenProvider,
-		passwordHasher: passwordHasher,
+		userRepo:         userRepo,
+		refreshTokenRepo: refreshTokenRepo,
+		revokedTokenRepo: revokedTokenRepo,
+		tokenProvider:    tokenProvider,
+		passwordHasher:   passwordHasher,
+		refreshTokenTTL:  refreshTokenTTL,
 	}
 }
 
-func (s *AuthenticationService) Login(ctx context.Context, email, password string) (token string, err error) {
+// Login starts a new session for the user.
+func (s *AuthenticationService) Login(ctx context.Context, email, password string) (domain.AuthTokens, error) {
 	user, err := s.userRepo.FindUserByEmail(ctx, email)
 	if err != nil {
-		return "", err
+		return domain.AuthTokens{}, err
 	}
 
 	match, err := s.passwordHasher.ComparePassword(user.Password, password)
 	if err != nil {
-		return "", err
+		return domain.AuthTokens{}, err
 	}
 	if !match {
-		return "", apperr.NewAppError(apperr.ErrUnauthorized, "invalid email or password", nil)
+		return domain.AuthTokens{}, apperr.NewAppError(apperr.ErrUnauthorized, "invalid email or password", nil)
+	}
+	if user.Suspended {
+		return domain.AuthTokens{}, apperr.NewAppError(apperr.ErrForbidden, "account suspended", nil)
+	}
+	if user.PendingApproval {
+		return domain.AuthTokens{}, apperr.NewAppError(apperr.ErrForbidden, "account pending approval", nil)
 	}
 
-	claims := authctx.NewUserClaims(user.ID, user.Role)
-	token, err = s.tokenProvider.GenerateToken(claims)
+	if err := s.refreshTokenRepo.DeleteExpiredRefreshTokens(ctx, time.Now()); err != nil {
+		return domain.AuthTokens{}, err
+	}
+
+	sessionId, err := randomToken()
+	if err != nil {
+		return domain.AuthTokens{}, err
+	}
+	return s.issueTokens(ctx, user, sessionId)
+}
+
+// Refresh exchanges a refresh token for a new pair in the same session. The
+// used token is revoked; if it comes back the whole session is revoked, as
+// either the client or somebody who stole the token already used it.
+func (s *AuthenticationService) Refresh(ctx context.Context, refreshToken string) (domain.AuthTokens, error) {
+	if refreshToken == "" {
+		return domain.AuthTokens{}, apperr.NewAppError(apperr.ErrUnauthorized, "invalid refresh token", nil)
+	}
+
+	stored, err := s.refreshTokenRepo.FindRefreshTokenByHash(ctx, hashToken(refreshToken))
 	if err != nil {
-		return "", err
+		return domain.AuthTokens{}, err
+	}
+	if stored.ID == 0 {
+		return domain.AuthTokens{}, apperr.NewAppError(apperr.ErrUnauthorized, "invalid refresh token", nil)
+	}
+	if stored.IsExpired(time.Now()) {
+		return domain.AuthTokens{}, apperr.NewAppError(apperr.ErrUnauthorized, "refresh token expired", nil)
+	}
+
+	rotated := false
+	if !stored.Revoked {
+		rotated, err = s.refreshTokenRepo.RevokeRefreshToken(ctx, stored.ID)
+		if err != nil {
+			return domain.AuthTokens{}, err
+		}
+	}
+	if !rotated {
+		if err := s.refreshTokenRepo.RevokeSession(ctx, stored.SessionID); err != nil {
+			return domain.AuthTokens{}, err
+		}
+		return domain.AuthTokens{}, apperr.NewAppError(apperr.ErrUnauthorized, "refresh token was already used", nil)
 	}
 
-	return token, nil
+	user, err := s.userRepo.FindUserById(ctx, stored.UserID)
+	if err != nil {
+		if apperr.IsNotFoundError(err) {
+			return domain.AuthTokens{}, apperr.NewAppError(apperr.ErrUnauthorized, "invalid refresh token", err)
+		}
+		return domain.AuthTokens{}, err
+	}
+	if user.Suspended {
+		return domain.AuthTokens{}, apperr.NewAppError(apperr.ErrForbidden, "account suspended", nil)
+	}
+	if user.PendingApproval {
+		return domain.AuthTokens{}, apperr.NewAppError(apperr.ErrForbidden, "account pending approval", nil)
+	}
+	return s.issueTokens(ctx, user, stored.SessionID)
 }
 
+// Logout revokes the access token and every refresh token of its session.
 func (s *AuthenticationService) Logout(ctx context.Context, token string) error {
-	// no op logout for stateless JWT
-	// if we add blacklisting, we can implement it here
-	// or refresh token mechanism
+	claims, err := s.tokenProvider.ValidateToken(token)
+	if err != nil {
+		return err
+	}
+
+	if claims.TokenID != "" {
+		if err := s.revokedTokenRepo.DeleteExpiredRevokedTokens(ctx, time.Now()); err != nil {
+			return err
+		}
+		if err := s.revokedTokenRepo.RevokeToken(ctx, claims.TokenID, claims.ExpiresAt); err != nil {
+			return err
+		}
+	}
+	if claims.SessionID != "" {
+		if err := s.refreshTokenRepo.RevokeSession(ctx, claims.SessionID); err != nil {
+			return err
+		}
+	}
 	return nil
 }
+
+func (s *AuthenticationService) IsTokenRevoked(ctx context.Context, claims authctx.UserClaims) (bool, error) {
+	if claims.TokenID == "" {
+		return false, nil
+	}
+	return s.revokedTokenRepo.IsTokenRevoked(ctx, claims.TokenID)
+}
+
+func (s *AuthenticationService) issueTokens(ctx context.Context, user domain.User, sessionId string) (domain.AuthTokens, error) {
+	refreshToken, err := randomToken()
+	if err != nil {
+		return domain.AuthTokens{}, err
+	}
+	stored := domain.NewRefreshToken(user.ID, sessionId, hashToken(refreshToken), time.Now().Add(s.refreshTokenTTL))
+	if _, err := s.refreshTokenRepo.SaveRefreshToken(ctx, stored); err != nil {
+		return domain.AuthTokens{}, err
+	}
+
+	claims := authctx.NewUserClaims(user.ID, user.Role)
+	claims.SessionID = sessionId
+	accessToken, err := s.tokenProvider.GenerateToken(claims)
+	if err != nil {
+		return domain.AuthTokens{}, err
+	}
+
+	return domain.AuthTokens{AccessToken: accessToken, RefreshToken: refreshToken}, nil
+}
+
+func randomToken() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", apperr.NewAppError(apperr.ErrInternal, "failed to generate token", err)
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+func hashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
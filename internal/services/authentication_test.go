@@ -2,6 +2,7 @@ package services
 
 import (
 	"testing"
+	"time"
 
 	"github.com/mohits-git/food-ordering-system/internal/domain"
 	"github.com/mohits-git/food-ordering-system/internal/utils/apperr"
@@ -15,19 +16,24 @@ import (
 
 func Test_services_AuthenticationService_NewAuthenticationService(t *testing.T) {
 	mockUserRepo := mockrepository.UserRepository{}
+	mockRefreshTokenRepo := mockrepository.RefreshTokenRepository{}
+	mockRevokedTokenRepo := mockrepository.RevokedTokenRepository{}
 	mockTokenProvider := mocktokenprovider.TokenProvider{}
 	mockPasswordHasher := mockpasswordhasher.PasswordHasher{}
 
-	service := NewAuthenticationService(&mockUserRepo, &mockTokenProvider, &mockPasswordHasher)
+	service := NewAuthenticationService(&mockUserRepo, &mockRefreshTokenRepo, &mockRevokedTokenRepo, &mockTokenProvider, &mockPasswordHasher, time.Hour)
 	require.NotNil(t, service)
+	require.Equal(t, time.Hour, service.refreshTokenTTL)
 }
 
 func Test_services_AuthenticationService_Login(t *testing.T) {
 	mockUserRepo := mockrepository.UserRepository{}
+	mockRefreshTokenRepo := mockrepository.RefreshTokenRepository{}
+	mockRevokedTokenRepo := mockrepository.RevokedTokenRepository{}
 	mockTokenProvider := mocktokenprovider.TokenProvider{}
 	mockPasswordHasher := mockpasswordhasher.PasswordHasher{}
 
-	service := NewAuthenticationService(&mockUserRepo, &mockTokenProvider, &mockPasswordHasher)
+	service := NewAuthenticationService(&mockUserRepo, &mockRefreshTokenRepo, &mockRevokedTokenRepo, &mockTokenProvider, &mockPasswordHasher, time.Hour)
 
 	email := "test@example.com"
 	password := "password123"
@@ -36,30 +42,43 @@ func Test_services_AuthenticationService_Login(t *testing.T) {
 	userRole := "customer"
 	expectedToken := "valid.jwt.token"
 	user := domain.User{ID: userID, Email: email, Password: hashedPassword, Role: domain.UserRole(userRole)}
-	userClaims := authctx.NewUserClaims(userID, domain.UserRole(userRole))
 	// Mocking the user repository to return a user
 	mockUserRepo.On("FindUserByEmail", mock.Anything, email).
 		Return(user, nil)
 	// Mocking the password hasher to return a successful match
 	mockPasswordHasher.On("ComparePassword", hashedPassword, password).
 		Return(true, nil)
+	mockRefreshTokenRepo.On("DeleteExpiredRefreshTokens", mock.Anything, mock.Anything).Return(nil)
+	var savedToken domain.RefreshToken
+	mockRefreshTokenRepo.On("SaveRefreshToken", mock.Anything, mock.MatchedBy(func(token domain.RefreshToken) bool {
+		savedToken = token
+		return token.UserID == userID && token.SessionID != "" && token.TokenHash != ""
+	})).Return(1, nil)
 	// Mocking the token provider to return a valid token
-	mockTokenProvider.On("GenerateToken", userClaims).
-		Return(expectedToken, nil)
-	token, err := service.Login(t.Context(), email, password)
+	mockTokenProvider.On("GenerateToken", mock.MatchedBy(func(claims authctx.UserClaims) bool {
+		return claims.UserID == userID && claims.Role == domain.UserRole(userRole) && claims.SessionID != ""
+	})).Return(expectedToken, nil)
+
+	tokens, err := service.Login(t.Context(), email, password)
 	require.NoError(t, err)
-	require.Equal(t, expectedToken, token)
+	require.Equal(t, expectedToken, tokens.AccessToken)
+	require.NotEmpty(t, tokens.RefreshToken)
+	require.Equal(t, hashToken(tokens.RefreshToken), savedToken.TokenHash, "expected only the hash of the refresh token to be stored")
+	require.WithinDuration(t, time.Now().Add(time.Hour), savedToken.ExpiresAt, 5*time.Second)
 	mockUserRepo.AssertExpectations(t)
 	mockPasswordHasher.AssertExpectations(t)
 	mockTokenProvider.AssertExpectations(t)
+	mockRefreshTokenRepo.AssertExpectations(t)
 }
 
 func Test_services_AuthenticationService_Login_when_user_not_found(t *testing.T) {
 	mockUserRepo := mockrepository.UserRepository{}
+	mockRefreshTokenRepo := mockrepository.RefreshTokenRepository{}
+	mockRevokedTokenRepo := mockrepository.RevokedTokenRepository{}
 	mockTokenProvider := mocktokenprovider.TokenProvider{}
 	mockPasswordHasher := mockpasswordhasher.PasswordHasher{}
 
-	service := NewAuthenticationService(&mockUserRepo, &mockTokenProvider, &mockPasswordHasher)
+	service := NewAuthenticationService(&mockUserRepo, &mockRefreshTokenRepo, &mockRevokedTokenRepo, &mockTokenProvider, &mockPasswordHasher, time.Hour)
 
 	email := "test@example.com"
 	password := "password123"
@@ -77,10 +96,12 @@ func Test_services_AuthenticationService_Login_when_user_not_found(t *testing.T)
 
 func Test_services_AuthenticationService_Login_when_password_mismatch(t *testing.T) {
 	mockUserRepo := mockrepository.UserRepository{}
+	mockRefreshTokenRepo := mockrepository.RefreshTokenRepository{}
+	mockRevokedTokenRepo := mockrepository.RevokedTokenRepository{}
 	mockTokenProvider := mocktokenprovider.TokenProvider{}
 	mockPasswordHasher := mockpasswordhasher.PasswordHasher{}
 
-	service := NewAuthenticationService(&mockUserRepo, &mockTokenProvider, &mockPasswordHasher)
+	service := NewAuthenticationService(&mockUserRepo, &mockRefreshTokenRepo, &mockRevokedTokenRepo, &mockTokenProvider, &mockPasswordHasher, time.Hour)
 
 	email := "test@example.com"
 	password := "password123"
@@ -105,13 +126,244 @@ func Test_services_AuthenticationService_Login_when_password_mismatch(t *testing
 	mockTokenProvider.AssertNotCalled(t, "GenerateToken", mock.Anything)
 }
 
+func Test_services_AuthenticationService_Login_when_suspended(t *testing.T) {
+	mockUserRepo := mockrepository.UserRepository{}
+	mockRefreshTokenRepo := mockrepository.RefreshTokenRepository{}
+	mockRevokedTokenRepo := mockrepository.RevokedTokenRepository{}
+	mockTokenProvider := mocktokenprovider.TokenProvider{}
+	mockPasswordHasher := mockpasswordhasher.PasswordHasher{}
+
+	service := NewAuthenticationService(&mockUserRepo, &mockRefreshTokenRepo, &mockRevokedTokenRepo, &mockTokenProvider, &mockPasswordHasher, time.Hour)
+
+	user := domain.User{ID: 1, Email: "test@example.com", Password: "hashed", Role: domain.CUSTOMER, Suspended: true}
+	mockUserRepo.On("FindUserByEmail", mock.Anything, user.Email).Return(user, nil)
+	mockPasswordHasher.On("ComparePassword", user.Password, "password123").Return(true, nil)
+
+	tokens, err := service.Login(t.Context(), user.Email, "password123")
+	require.True(t, apperr.IsForbiddenError(err))
+	require.Empty(t, tokens.AccessToken)
+	mockTokenProvider.AssertNotCalled(t, "GenerateToken", mock.Anything)
+	mockRefreshTokenRepo.AssertNotCalled(t, "SaveRefreshToken", mock.Anything, mock.Anything)
+}
+
+func Test_services_AuthenticationService_Login_when_pending_approval(t *testing.T) {
+	mockUserRepo := mockrepository.UserRepository{}
+	mockRefreshTokenRepo := mockrepository.RefreshTokenRepository{}
+	mockRevokedTokenRepo := mockrepository.RevokedTokenRepository{}
+	mockTokenProvider := mocktokenprovider.TokenProvider{}
+	mockPasswordHasher := mockpasswordhasher.PasswordHasher{}
+
+	service := NewAuthenticationService(&mockUserRepo, &mockRefreshTokenRepo, &mockRevokedTokenRepo, &mockTokenProvider, &mockPasswordHasher, time.Hour)
+
+	user := domain.User{ID: 2, Email: "owner@example.com", Password: "hashed", Role: domain.OWNER, PendingApproval: true}
+	mockUserRepo.On("FindUserByEmail", mock.Anything, user.Email).Return(user, nil)
+	mockPasswordHasher.On("ComparePassword", user.Password, "password123").Return(true, nil)
+
+	_, err := service.Login(t.Context(), user.Email, "password123")
+	require.True(t, apperr.IsForbiddenError(err))
+	mockTokenProvider.AssertNotCalled(t, "GenerateToken", mock.Anything)
+}
+
+func Test_services_AuthenticationService_Refresh(t *testing.T) {
+	mockUserRepo := mockrepository.UserRepository{}
+	mockRefreshTokenRepo := mockrepository.RefreshTokenRepository{}
+	mockRevokedTokenRepo := mockrepository.RevokedTokenRepository{}
+	mockTokenProvider := mocktokenprovider.TokenProvider{}
+	mockPasswordHasher := mockpasswordhasher.PasswordHasher{}
+
+	service := NewAuthenticationService(&mockUserRepo, &mockRefreshTokenRepo, &mockRevokedTokenRepo, &mockTokenProvider, &mockPasswordHasher, time.Hour)
+
+	stored := domain.RefreshToken{ID: 4, UserID: 1, SessionID: "session-1", TokenHash: hashToken("refresh-1"), ExpiresAt: time.Now().Add(time.Hour)}
+	mockRefreshTokenRepo.On("FindRefreshTokenByHash", mock.Anything, hashToken("refresh-1")).Return(stored, nil)
+	mockRefreshTokenRepo.On("RevokeRefreshToken", mock.Anything, 4).Return(true, nil)
+	mockUserRepo.On("FindUserById", mock.Anything, 1).Return(domain.User{ID: 1, Role: domain.CUSTOMER}, nil)
+	mockRefreshTokenRepo.On("SaveRefreshToken", mock.Anything, mock.MatchedBy(func(token domain.RefreshToken) bool {
+		return token.UserID == 1 && token.SessionID == "session-1" && token.TokenHash != stored.TokenHash
+	})).Return(5, nil)
+	mockTokenProvider.On("GenerateToken", authctx.UserClaims{UserID: 1, Role: domain.CUSTOMER, SessionID: "session-1"}).
+		Return("new.jwt.token", nil)
+
+	tokens, err := service.Refresh(t.Context(), "refresh-1")
+	require.NoError(t, err)
+	require.Equal(t, "new.jwt.token", tokens.AccessToken)
+	require.NotEqual(t, "refresh-1", tokens.RefreshToken, "expected the refresh token to be rotated")
+	mockRefreshTokenRepo.AssertExpectations(t)
+	mockTokenProvider.AssertExpectations(t)
+}
+
+func Test_services_AuthenticationService_Refresh_when_token_reused(t *testing.T) {
+	mockUserRepo := mockrepository.UserRepository{}
+	mockRefreshTokenRepo := mockrepository.RefreshTokenRepository{}
+	mockRevokedTokenRepo := mockrepository.RevokedTokenRepository{}
+	mockTokenProvider := mocktokenprovider.TokenProvider{}
+	mockPasswordHasher := mockpasswordhasher.PasswordHasher{}
+
+	service := NewAuthenticationService(&mockUserRepo, &mockRefreshTokenRepo, &mockRevokedTokenRepo, &mockTokenProvider, &mockPasswordHasher, time.Hour)
+
+	stored := domain.RefreshToken{ID: 4, UserID: 1, SessionID: "session-1", ExpiresAt: time.Now().Add(time.Hour), Revoked: true}
+	mockRefreshTokenRepo.On("FindRefreshTokenByHash", mock.Anything, hashToken("refresh-1")).Return(stored, nil)
+	mockRefreshTokenRepo.On("RevokeSession", mock.Anything, "session-1").Return(nil)
+
+	_, err := service.Refresh(t.Context(), "refresh-1")
+	require.True(t, apperr.IsUnauthorizedError(err))
+	mockRefreshTokenRepo.AssertExpectations(t)
+	mockTokenProvider.AssertNotCalled(t, "GenerateToken", mock.Anything)
+}
+
+func Test_services_AuthenticationService_Refresh_when_rotated_concurrently(t *testing.T) {
+	mockUserRepo := mockrepository.UserRepository{}
+	mockRefreshTokenRepo := mockrepository.RefreshTokenRepository{}
+	mockRevokedTokenRepo := mockrepository.RevokedTokenRepository{}
+	mockTokenProvider := mocktokenprovider.TokenProvider{}
+	mockPasswordHasher := mockpasswordhasher.PasswordHasher{}
+
+	service := NewAuthenticationService(&mockUserRepo, &mockRefreshTokenRepo, &mockRevokedTokenRepo, &mockTokenProvider, &mockPasswordHasher, time.Hour)
+
+	stored := domain.RefreshToken{ID: 4, UserID: 1, SessionID: "session-1", ExpiresAt: time.Now().Add(time.Hour)}
+	mockRefreshTokenRepo.On("FindRefreshTokenByHash", mock.Anything, hashToken("refresh-1")).Return(stored, nil)
+	mockRefreshTokenRepo.On("RevokeRefreshToken", mock.Anything, 4).Return(false, nil)
+	mockRefreshTokenRepo.On("RevokeSession", mock.Anything, "session-1").Return(nil)
+
+	_, err := service.Refresh(t.Context(), "refresh-1")
+	require.True(t, apperr.IsUnauthorizedError(err))
+	mockRefreshTokenRepo.AssertExpectations(t)
+}
+
+func Test_services_AuthenticationService_Refresh_when_suspended(t *testing.T) {
+	mockUserRepo := mockrepository.UserRepository{}
+	mockRefreshTokenRepo := mockrepository.RefreshTokenRepository{}
+	mockRevokedTokenRepo := mockrepository.RevokedTokenRepository{}
+	mockTokenProvider := mocktokenprovider.TokenProvider{}
+	mockPasswordHasher := mockpasswordhasher.PasswordHasher{}
+
+	service := NewAuthenticationService(&mockUserRepo, &mockRefreshTokenRepo, &mockRevokedTokenRepo, &mockTokenProvider, &mockPasswordHasher, time.Hour)
+
+	stored := domain.RefreshToken{ID: 4, UserID: 1, SessionID: "session-1", ExpiresAt: time.Now().Add(time.Hour)}
+	mockRefreshTokenRepo.On("FindRefreshTokenByHash", mock.Anything, hashToken("refresh-1")).Return(stored, nil)
+	mockRefreshTokenRepo.On("RevokeRefreshToken", mock.Anything, 4).Return(true, nil)
+	mockUserRepo.On("FindUserById", mock.Anything, 1).Return(domain.User{ID: 1, Role: domain.CUSTOMER, Suspended: true}, nil)
+
+	_, err := service.Refresh(t.Context(), "refresh-1")
+	require.True(t, apperr.IsForbiddenError(err))
+	mockTokenProvider.AssertNotCalled(t, "GenerateToken", mock.Anything)
+}
+
+func Test_services_AuthenticationService_Refresh_when_pending_approval(t *testing.T) {
+	mockUserRepo := mockrepository.UserRepository{}
+	mockRefreshTokenRepo := mockrepository.RefreshTokenRepository{}
+	mockRevokedTokenRepo := mockrepository.RevokedTokenRepository{}
+	mockTokenProvider := mocktokenprovider.TokenProvider{}
+	mockPasswordHasher := mockpasswordhasher.PasswordHasher{}
+
+	service := NewAuthenticationService(&mockUserRepo, &mockRefreshTokenRepo, &mockRevokedTokenRepo, &mockTokenProvider, &mockPasswordHasher, time.Hour)
+
+	stored := domain.RefreshToken{ID: 4, UserID: 2, SessionID: "session-1", ExpiresAt: time.Now().Add(time.Hour)}
+	mockRefreshTokenRepo.On("FindRefreshTokenByHash", mock.Anything, hashToken("refresh-1")).Return(stored, nil)
+	mockRefreshTokenRepo.On("RevokeRefreshToken", mock.Anything, 4).Return(true, nil)
+	mockUserRepo.On("FindUserById", mock.Anything, 2).Return(domain.User{ID: 2, Role: domain.OWNER, PendingApproval: true}, nil)
+
+	_, err := service.Refresh(t.Context(), "refresh-1")
+	require.True(t, apperr.IsForbiddenError(err))
+	mockTokenProvider.AssertNotCalled(t, "GenerateToken", mock.Anything)
+	mockRefreshTokenRepo.AssertNotCalled(t, "SaveRefreshToken", mock.Anything, mock.Anything)
+}
+
+func Test_services_AuthenticationService_Refresh_Errors(t *testing.T) {
+	tests := []struct {
+		name   string
+		token  string
+		stored domain.RefreshToken
+	}{
+		{
+			name:  "Empty Token",
+			token: "",
+		},
+		{
+			name:  "Unknown Token",
+			token: "refresh-1",
+		},
+		{
+			name:   "Expired Token",
+			token:  "refresh-1",
+			stored: domain.RefreshToken{ID: 4, UserID: 1, SessionID: "session-1", ExpiresAt: time.Now().Add(-time.Minute)},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockUserRepo := mockrepository.UserRepository{}
+			mockRefreshTokenRepo := mockrepository.RefreshTokenRepository{}
+			mockRevokedTokenRepo := mockrepository.RevokedTokenRepository{}
+			mockTokenProvider := mocktokenprovider.TokenProvider{}
+			mockPasswordHasher := mockpasswordhasher.PasswordHasher{}
+
+			service := NewAuthenticationService(&mockUserRepo, &mockRefreshTokenRepo, &mockRevokedTokenRepo, &mockTokenProvider, &mockPasswordHasher, time.Hour)
+			mockRefreshTokenRepo.On("FindRefreshTokenByHash", mock.Anything, hashToken(tt.token)).Return(tt.stored, nil)
+
+			_, err := service.Refresh(t.Context(), tt.token)
+			require.True(t, apperr.IsUnauthorizedError(err))
+			mockRefreshTokenRepo.AssertNotCalled(t, "SaveRefreshToken", mock.Anything, mock.Anything)
+		})
+	}
+}
+
 func Test_services_AuthenticationService_Logout(t *testing.T) {
 	mockUserRepo := mockrepository.UserRepository{}
+	mockRefreshTokenRepo := mockrepository.RefreshTokenRepository{}
+	mockRevokedTokenRepo := mockrepository.RevokedTokenRepository{}
+	mockTokenProvider := mocktokenprovider.TokenProvider{}
+	mockPasswordHasher := mockpasswordhasher.PasswordHasher{}
+
+	service := NewAuthenticationService(&mockUserRepo, &mockRefreshTokenRepo, &mockRevokedTokenRepo, &mockTokenProvider, &mockPasswordHasher, time.Hour)
+
+	expiresAt := time.Now().Add(15 * time.Minute)
+	mockTokenProvider.On("ValidateToken", "some.jwt.token").
+		Return(authctx.UserClaims{UserID: 1, Role: domain.CUSTOMER, TokenID: "jti-1", SessionID: "session-1", ExpiresAt: expiresAt}, nil)
+	mockRevokedTokenRepo.On("DeleteExpiredRevokedTokens", mock.Anything, mock.Anything).Return(nil)
+	mockRevokedTokenRepo.On("RevokeToken", mock.Anything, "jti-1", expiresAt).Return(nil)
+	mockRefreshTokenRepo.On("RevokeSession", mock.Anything, "session-1").Return(nil)
+
+	err := service.Logout(t.Context(), "some.jwt.token")
+	require.NoError(t, err)
+	mockRevokedTokenRepo.AssertExpectations(t)
+	mockRefreshTokenRepo.AssertExpectations(t)
+}
+
+func Test_services_AuthenticationService_Logout_when_token_invalid(t *testing.T) {
+	mockUserRepo := mockrepository.UserRepository{}
+	mockRefreshTokenRepo := mockrepository.RefreshTokenRepository{}
+	mockRevokedTokenRepo := mockrepository.RevokedTokenRepository{}
 	mockTokenProvider := mocktokenprovider.TokenProvider{}
 	mockPasswordHasher := mockpasswordhasher.PasswordHasher{}
 
-	service := NewAuthenticationService(&mockUserRepo, &mockTokenProvider, &mockPasswordHasher)
+	service := NewAuthenticationService(&mockUserRepo, &mockRefreshTokenRepo, &mockRevokedTokenRepo, &mockTokenProvider, &mockPasswordHasher, time.Hour)
+
+	mockTokenProvider.On("ValidateToken", "some.jwt.token").
+		Return(authctx.UserClaims{}, apperr.NewAppError(apperr.ErrUnauthorized, "invalid token", nil))
 
 	err := service.Logout(t.Context(), "some.jwt.token")
+	require.True(t, apperr.IsUnauthorizedError(err))
+	mockRevokedTokenRepo.AssertNotCalled(t, "RevokeToken", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func Test_services_AuthenticationService_IsTokenRevoked(t *testing.T) {
+	mockUserRepo := mockrepository.UserRepository{}
+	mockRefreshTokenRepo := mockrepository.RefreshTokenRepository{}
+	mockRevokedTokenRepo := mockrepository.RevokedTokenRepository{}
+	mockTokenProvider := mocktokenprovider.TokenProvider{}
+	mockPasswordHasher := mockpasswordhasher.PasswordHasher{}
+
+	service := NewAuthenticationService(&mockUserRepo, &mockRefreshTokenRepo, &mockRevokedTokenRepo, &mockTokenProvider, &mockPasswordHasher, time.Hour)
+
+	mockRevokedTokenRepo.On("IsTokenRevoked", mock.Anything, "jti-1").Return(true, nil)
+
+	revoked, err := service.IsTokenRevoked(t.Context(), authctx.UserClaims{UserID: 1, TokenID: "jti-1"})
+	require.NoError(t, err)
+	require.True(t, revoked)
+
+	// tokens without an id were issued before revocation and cannot be revoked
+	revoked, err = service.IsTokenRevoked(t.Context(), authctx.UserClaims{UserID: 1})
 	require.NoError(t, err)
+	require.False(t, revoked)
+	mockRevokedTokenRepo.AssertNumberOfCalls(t, "IsTokenRevoked", 1)
 }
@@ -0,0 +1,82 @@
+package services
+
+import (
+	"context"
+	"slices"
+
+	"github.com/mohits-git/food-ordering-system/internal/domain"
+	"github.com/mohits-git/food-ordering-system/internal/ports"
+	"github.com/mohits-git/food-ordering-system/internal/utils/apperr"
+	"github.com/mohits-git/food-ordering-system/internal/utils/authctx"
+)
+
+// Authorizer checks the user of a request against the policies of the
+// domain.
+type Authorizer struct {
+	restaurantRepo ports.RestaurantRepository
+	memberRepo     ports.RestaurantMemberRepository
+	policies       map[domain.Action]domain.Policy
+	staffPolicies  map[domain.Action][]domain.StaffRole
+}
+
+func NewAuthorizer(restaurantRepo ports.RestaurantRepository, memberRepo ports.RestaurantMemberRepository) *Authorizer {
+	return &Authorizer{
+		restaurantRepo: restaurantRepo,
+		memberRepo:     memberRepo,
+		policies:       domain.Policies,
+		staffPolicies:  domain.StaffPolicies,
+	}
+}
+
+func (a *Authorizer) Authorize(ctx context.Context, action domain.Action) (*authctx.UserClaims, error) {
+	user, ok := authctx.UserClaimsFromCtx(ctx)
+	if !ok {
+		return nil, apperr.NewAppError(apperr.ErrUnauthorized, "user not authenticated", nil)
+	}
+	if _, ok := a.policies[action][user.Role]; !ok {
+		return nil, apperr.NewAppError(apperr.ErrForbidden, "not allowed to "+string(action), nil)
+	}
+	return user, nil
+}
+
+func (a *Authorizer) AuthorizeResource(ctx context.Context, action domain.Action, resource domain.Resource) (*authctx.UserClaims, error) {
+	user, err := a.Authorize(ctx, action)
+	if err != nil {
+		return nil, err
+	}
+
+	switch a.policies[action][user.Role] {
+	case domain.ScopeAny:
+		return user, nil
+	case domain.ScopeOwn:
+		if resource.CustomerID == user.UserID {
+			return user, nil
+		}
+		return nil, apperr.NewAppError(apperr.ErrForbidden, "not allowed to "+string(action)+" of other users", nil)
+	case domain.ScopeRestaurant:
+		restaurant, err := a.restaurantRepo.FindRestaurantById(ctx, resource.RestaurantID)
+		if err != nil {
+			return nil, err
+		}
+		if restaurant.ID == 0 {
+			return nil, apperr.NewAppError(apperr.ErrNotFound, "restaurant not found", nil)
+		}
+		if restaurant.OwnerID == user.UserID {
+			return user, nil
+		}
+		return nil, apperr.NewAppError(apperr.ErrForbidden, "not allowed to "+string(action)+" of other restaurants", nil)
+	case domain.ScopeStaff:
+		member, err := a.memberRepo.FindRestaurantMember(ctx, resource.RestaurantID, user.UserID)
+		if err != nil {
+			return nil, err
+		}
+		if member.UserID == 0 {
+			return nil, apperr.NewAppError(apperr.ErrForbidden, "not allowed to "+string(action)+" of other restaurants", nil)
+		}
+		if slices.Contains(a.staffPolicies[action], member.Role) {
+			return user, nil
+		}
+		return nil, apperr.NewAppError(apperr.ErrForbidden, "not allowed to "+string(action)+" as "+string(member.Role), nil)
+	}
+	return nil, apperr.NewAppError(apperr.ErrForbidden, "not allowed to "+string(action), nil)
+}
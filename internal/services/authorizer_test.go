@@ -0,0 +1,149 @@
+package services
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/mohits-git/food-ordering-system/internal/domain"
+	"github.com/mohits-git/food-ordering-system/internal/utils/apperr"
+	"github.com/mohits-git/food-ordering-system/internal/utils/authctx"
+	mockrepository "github.com/mohits-git/food-ordering-system/tests/mock_repository"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_services_Authorizer_AuthorizeResource(t *testing.T) {
+	// the user is 1, who is the customer of own and owns or works at its
+	// restaurant, other belongs to user 2
+	own := domain.Resource{CustomerID: 1, RestaurantID: 10}
+	other := domain.Resource{CustomerID: 2, RestaurantID: 20}
+
+	type subject struct {
+		role      domain.UserRole
+		staffRole domain.StaffRole
+	}
+	customer := subject{role: domain.CUSTOMER}
+	owner := subject{role: domain.OWNER}
+	admin := subject{role: domain.ADMIN}
+	manager := subject{role: domain.STAFF, staffRole: domain.StaffManager}
+	cashier := subject{role: domain.STAFF, staffRole: domain.StaffCashier}
+	kitchen := subject{role: domain.STAFF, staffRole: domain.StaffKitchen}
+	subjects := []subject{customer, owner, admin, manager, cashier, kitchen}
+
+	tests := []struct {
+		action domain.Action
+		// own lists who may perform the action on their own resources and
+		// other who may on the resources of others
+		own   []subject
+		other []subject
+	}{
+		{domain.ActionCreateRestaurant, []subject{owner}, []subject{owner}},
+		{domain.ActionUpdateRestaurant, []subject{owner, manager}, nil},
+		{domain.ActionPauseOrders, []subject{owner, manager, kitchen}, nil},
+		{domain.ActionManageStaff, []subject{owner}, nil},
+		{domain.ActionManageTaxRules, []subject{owner, admin}, []subject{admin}},
+		{domain.ActionViewMemberships, []subject{manager, cashier, kitchen}, []subject{manager, cashier, kitchen}},
+		{domain.ActionCreateMenuItem, []subject{owner, manager}, nil},
+		{domain.ActionUpdateMenuItem, []subject{owner, manager}, nil},
+		{domain.ActionDeleteMenuItem, []subject{owner, manager}, nil},
+		{domain.ActionManageMenus, []subject{owner, manager}, nil},
+		{domain.ActionCreateOrder, []subject{customer}, nil},
+		{domain.ActionViewOrder, []subject{customer, owner, admin, manager, cashier, kitchen}, []subject{admin}},
+		{domain.ActionViewOrderHistory, []subject{customer}, nil},
+		{domain.ActionViewRestaurantOrders, []subject{owner, manager, cashier, kitchen}, nil},
+		{domain.ActionViewAllOrders, []subject{admin}, []subject{admin}},
+		{domain.ActionModifyOrder, []subject{customer}, nil},
+		{domain.ActionTransitionOrder, []subject{owner, kitchen}, nil},
+		{domain.ActionCancelOrder, []subject{customer, owner, admin}, []subject{admin}},
+		{domain.ActionGenerateInvoice, []subject{customer}, nil},
+		{domain.ActionViewInvoice, []subject{customer, owner, admin, cashier}, []subject{admin}},
+		{domain.ActionViewAllInvoices, []subject{admin}, []subject{admin}},
+		{domain.ActionPayInvoice, []subject{customer, cashier}, nil},
+		{domain.ActionRefundInvoice, []subject{owner, admin}, []subject{admin}},
+		{domain.ActionManageUsers, []subject{admin}, []subject{admin}},
+	}
+
+	// every action with a policy has to be covered
+	covered := make(map[domain.Action]bool)
+	for _, tt := range tests {
+		covered[tt.action] = true
+	}
+	for action := range domain.Policies {
+		require.True(t, covered[action], "no test for %q", action)
+	}
+
+	for _, tt := range tests {
+		for _, sub := range subjects {
+			name := string(sub.role)
+			if sub.staffRole != "" {
+				name = string(sub.staffRole)
+			}
+			t.Run(name+" "+string(tt.action), func(t *testing.T) {
+				mockRestaurantRepo := mockrepository.RestaurantRepository{}
+				mockRestaurantRepo.On("FindRestaurantById", mock.Anything, 10).
+					Return(domain.Restaurant{ID: 10, Name: "Own", OwnerID: 1}, nil).Maybe()
+				mockRestaurantRepo.On("FindRestaurantById", mock.Anything, 20).
+					Return(domain.Restaurant{ID: 20, Name: "Other", OwnerID: 2}, nil).Maybe()
+				mockMemberRepo := mockrepository.RestaurantMemberRepository{}
+				mockMemberRepo.On("FindRestaurantMember", mock.Anything, 10, 1).
+					Return(domain.NewRestaurantMember(10, 1, sub.staffRole), nil).Maybe()
+				mockMemberRepo.On("FindRestaurantMember", mock.Anything, 20, 1).
+					Return(domain.RestaurantMember{}, nil).Maybe()
+				authorizer := NewAuthorizer(&mockRestaurantRepo, &mockMemberRepo)
+
+				ctx := authctx.WithUserClaims(t.Context(), &authctx.UserClaims{UserID: 1, Role: sub.role})
+				allowedOwn := slices.Contains(tt.own, sub)
+				allowedOther := slices.Contains(tt.other, sub)
+
+				_, err := authorizer.AuthorizeResource(ctx, tt.action, own)
+				if allowedOwn {
+					require.NoError(t, err)
+				} else {
+					require.True(t, apperr.IsForbiddenError(err), "expected forbidden error but got %v", err)
+				}
+
+				_, err = authorizer.AuthorizeResource(ctx, tt.action, other)
+				if allowedOther {
+					require.NoError(t, err)
+				} else {
+					require.True(t, apperr.IsForbiddenError(err), "expected forbidden error but got %v", err)
+				}
+
+				// the role alone is enough to get past the first check
+				if _, ok := domain.Policies[tt.action][sub.role]; ok {
+					user, err := authorizer.Authorize(ctx, tt.action)
+					require.NoError(t, err)
+					require.Equal(t, 1, user.UserID)
+				} else {
+					require.False(t, allowedOwn || allowedOther, "%s is allowed without a policy", name)
+					_, err := authorizer.Authorize(ctx, tt.action)
+					require.True(t, apperr.IsForbiddenError(err), "expected forbidden error but got %v", err)
+				}
+			})
+		}
+	}
+}
+
+func Test_services_Authorizer_Authorize_when_unauthenticated(t *testing.T) {
+	authorizer := NewAuthorizer(&mockrepository.RestaurantRepository{}, &mockrepository.RestaurantMemberRepository{})
+
+	for action := range domain.Policies {
+		_, err := authorizer.Authorize(t.Context(), action)
+		require.True(t, apperr.IsUnauthorizedError(err), "expected unauthorized error for %q but got %v", action, err)
+
+		_, err = authorizer.AuthorizeResource(t.Context(), action, domain.Resource{CustomerID: 1, RestaurantID: 1})
+		require.True(t, apperr.IsUnauthorizedError(err), "expected unauthorized error for %q but got %v", action, err)
+	}
+}
+
+func Test_services_Authorizer_AuthorizeResource_when_restaurant_not_found(t *testing.T) {
+	mockRestaurantRepo := mockrepository.RestaurantRepository{}
+	mockRestaurantRepo.On("FindRestaurantById", mock.Anything, 30).
+		Return(domain.Restaurant{}, nil)
+	authorizer := NewAuthorizer(&mockRestaurantRepo, &mockrepository.RestaurantMemberRepository{})
+
+	ctx := authctx.WithUserClaims(t.Context(), &authctx.UserClaims{UserID: 1, Role: domain.OWNER})
+	_, err := authorizer.AuthorizeResource(ctx, domain.ActionCreateMenuItem, domain.Resource{RestaurantID: 30})
+	require.True(t, apperr.IsNotFoundError(err), "expected not found error but got %v", err)
+	mockRestaurantRepo.AssertExpectations(t)
+}
@@ -0,0 +1,78 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"github.com/mohits-git/food-ordering-system/internal/domain"
+	"github.com/mohits-git/food-ordering-system/internal/ports"
+	"github.com/mohits-git/food-ordering-system/internal/utils/apperr"
+	"github.com/mohits-git/food-ordering-system/internal/utils/authctx"
+)
+
+type IdempotencyService struct {
+	idempotencyRepo ports.IdempotencyRepository
+	ttl             time.Duration
+}
+
+func NewIdempotencyService(idempotencyRepo ports.IdempotencyRepository, ttl time.Duration) *IdempotencyService {
+	return &IdempotencyService{
+		idempotencyRepo: idempotencyRepo,
+		ttl:             ttl,
+	}
+}
+
+// StartRequest claims the key for the current user. The stored response is
+// returned when the same request already completed; a new, not completed key
+// means the request should run and its response be saved with
+// CompleteRequest. Keys are scoped to the user and forgotten after the ttl.
+func (s *IdempotencyService) StartRequest(ctx context.Context, key string, requestHash string) (domain.IdempotencyKey, error) {
+	user, ok := authctx.UserClaimsFromCtx(ctx)
+	if !ok {
+		return domain.IdempotencyKey{}, apperr.NewAppError(apperr.ErrUnauthorized, "user not authenticated", nil)
+	}
+
+	now := time.Now()
+	record := domain.NewIdempotencyKey(user.UserID, key, requestHash, now.Add(s.ttl))
+	if !record.Validate() {
+		return domain.IdempotencyKey{}, apperr.NewAppError(apperr.ErrInvalid, "invalid idempotency key", nil)
+	}
+
+	if err := s.idempotencyRepo.DeleteExpiredIdempotencyKeys(ctx, now); err != nil {
+		return domain.IdempotencyKey{}, err
+	}
+
+	stored, err := s.idempotencyRepo.FindIdempotencyKey(ctx, user.UserID, key)
+	if err != nil {
+		return domain.IdempotencyKey{}, err
+	}
+	if stored.ID != 0 {
+		if stored.RequestHash != requestHash {
+			return domain.IdempotencyKey{}, apperr.NewAppError(apperr.ErrConflict, "idempotency key was already used for a different request", nil)
+		}
+		if !stored.IsCompleted() {
+			return domain.IdempotencyKey{}, apperr.NewAppError(apperr.ErrConflict, "a request with this idempotency key is still being processed", nil)
+		}
+		return stored, nil
+	}
+
+	record.ID, err = s.idempotencyRepo.SaveIdempotencyKey(ctx, record)
+	if apperr.IsConflictError(err) {
+		// the same key sent twice at the same time
+		return domain.IdempotencyKey{}, apperr.NewAppError(apperr.ErrConflict, "a request with this idempotency key is still being processed", err)
+	}
+	if err != nil {
+		return domain.IdempotencyKey{}, err
+	}
+	return record, nil
+}
+
+func (s *IdempotencyService) CompleteRequest(ctx context.Context, id int, statusCode int, body []byte) error {
+	return s.idempotencyRepo.SaveIdempotencyResponse(ctx, id, statusCode, body)
+}
+
+// ReleaseRequest forgets a key whose request did not complete, so it can be
+// retried with the same key.
+func (s *IdempotencyService) ReleaseRequest(ctx context.Context, id int) error {
+	return s.idempotencyRepo.DeleteIdempotencyKey(ctx, id)
+}
@@ -0,0 +1,123 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"github.com/mohits-git/food-ordering-system/internal/domain"
+	"github.com/mohits-git/food-ordering-system/internal/utils/apperr"
+	"github.com/mohits-git/food-ordering-system/internal/utils/authctx"
+	mockrepository "github.com/mohits-git/food-ordering-system/tests/mock_repository"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_services_NewIdempotencyService(t *testing.T) {
+	idempotencyRepo := &mockrepository.IdempotencyRepository{}
+	service := NewIdempotencyService(idempotencyRepo, time.Hour)
+	require.NotNil(t, service)
+	require.Equal(t, time.Hour, service.ttl)
+}
+
+func Test_services_IdempotencyService_StartRequest(t *testing.T) {
+	ctx := authctx.WithUserClaims(t.Context(), &authctx.UserClaims{UserID: 1, Role: domain.CUSTOMER})
+
+	t.Run("New Key", func(t *testing.T) {
+		idempotencyRepo := &mockrepository.IdempotencyRepository{}
+		service := NewIdempotencyService(idempotencyRepo, time.Hour)
+		idempotencyRepo.On("DeleteExpiredIdempotencyKeys", mock.Anything, mock.Anything).Return(nil)
+		idempotencyRepo.On("FindIdempotencyKey", mock.Anything, 1, "key-1").Return(domain.IdempotencyKey{}, nil)
+		idempotencyRepo.On("SaveIdempotencyKey", mock.Anything, mock.MatchedBy(func(k domain.IdempotencyKey) bool {
+			return k.UserID == 1 && k.Key == "key-1" && k.RequestHash == "hash" && k.ExpiresAt.After(time.Now().Add(59*time.Minute))
+		})).Return(3, nil)
+
+		key, err := service.StartRequest(ctx, "key-1", "hash")
+		require.NoError(t, err)
+		require.Equal(t, 3, key.ID)
+		require.False(t, key.IsCompleted(), "expected a new key to run the request")
+		idempotencyRepo.AssertExpectations(t)
+	})
+
+	t.Run("Completed Key", func(t *testing.T) {
+		idempotencyRepo := &mockrepository.IdempotencyRepository{}
+		service := NewIdempotencyService(idempotencyRepo, time.Hour)
+		stored := domain.IdempotencyKey{ID: 3, UserID: 1, Key: "key-1", RequestHash: "hash", StatusCode: 201, ResponseBody: []byte("body")}
+		idempotencyRepo.On("DeleteExpiredIdempotencyKeys", mock.Anything, mock.Anything).Return(nil)
+		idempotencyRepo.On("FindIdempotencyKey", mock.Anything, 1, "key-1").Return(stored, nil)
+
+		key, err := service.StartRequest(ctx, "key-1", "hash")
+		require.NoError(t, err)
+		require.Equal(t, stored, key)
+		idempotencyRepo.AssertNotCalled(t, "SaveIdempotencyKey", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Different Request", func(t *testing.T) {
+		idempotencyRepo := &mockrepository.IdempotencyRepository{}
+		service := NewIdempotencyService(idempotencyRepo, time.Hour)
+		idempotencyRepo.On("DeleteExpiredIdempotencyKeys", mock.Anything, mock.Anything).Return(nil)
+		idempotencyRepo.On("FindIdempotencyKey", mock.Anything, 1, "key-1").
+			Return(domain.IdempotencyKey{ID: 3, UserID: 1, Key: "key-1", RequestHash: "other", StatusCode: 201}, nil)
+
+		_, err := service.StartRequest(ctx, "key-1", "hash")
+		require.True(t, apperr.IsConflictError(err), "expected a conflict when the key is reused with another body")
+	})
+
+	t.Run("In Progress", func(t *testing.T) {
+		idempotencyRepo := &mockrepository.IdempotencyRepository{}
+		service := NewIdempotencyService(idempotencyRepo, time.Hour)
+		idempotencyRepo.On("DeleteExpiredIdempotencyKeys", mock.Anything, mock.Anything).Return(nil)
+		idempotencyRepo.On("FindIdempotencyKey", mock.Anything, 1, "key-1").
+			Return(domain.IdempotencyKey{ID: 3, UserID: 1, Key: "key-1", RequestHash: "hash"}, nil)
+
+		_, err := service.StartRequest(ctx, "key-1", "hash")
+		require.True(t, apperr.IsConflictError(err), "expected a conflict while the first request is running")
+	})
+
+	t.Run("Concurrent Save", func(t *testing.T) {
+		idempotencyRepo := &mockrepository.IdempotencyRepository{}
+		service := NewIdempotencyService(idempotencyRepo, time.Hour)
+		idempotencyRepo.On("DeleteExpiredIdempotencyKeys", mock.Anything, mock.Anything).Return(nil)
+		idempotencyRepo.On("FindIdempotencyKey", mock.Anything, 1, "key-1").Return(domain.IdempotencyKey{}, nil)
+		idempotencyRepo.On("SaveIdempotencyKey", mock.Anything, mock.Anything).
+			Return(0, apperr.NewAppError(apperr.ErrConflict, "conflict", nil))
+
+		_, err := service.StartRequest(ctx, "key-1", "hash")
+		require.True(t, apperr.IsConflictError(err))
+	})
+
+	t.Run("Invalid Key", func(t *testing.T) {
+		idempotencyRepo := &mockrepository.IdempotencyRepository{}
+		service := NewIdempotencyService(idempotencyRepo, time.Hour)
+
+		_, err := service.StartRequest(ctx, "", "hash")
+		require.True(t, apperr.IsInvalidError(err))
+	})
+
+	t.Run("Unauthenticated", func(t *testing.T) {
+		idempotencyRepo := &mockrepository.IdempotencyRepository{}
+		service := NewIdempotencyService(idempotencyRepo, time.Hour)
+
+		_, err := service.StartRequest(t.Context(), "key-1", "hash")
+		require.True(t, apperr.IsUnauthorizedError(err))
+	})
+}
+
+func Test_services_IdempotencyService_CompleteRequest(t *testing.T) {
+	idempotencyRepo := &mockrepository.IdempotencyRepository{}
+	service := NewIdempotencyService(idempotencyRepo, time.Hour)
+	idempotencyRepo.On("SaveIdempotencyResponse", mock.Anything, 3, 201, []byte("body")).Return(nil)
+
+	err := service.CompleteRequest(t.Context(), 3, 201, []byte("body"))
+	require.NoError(t, err)
+	idempotencyRepo.AssertExpectations(t)
+}
+
+func Test_services_IdempotencyService_ReleaseRequest(t *testing.T) {
+	idempotencyRepo := &mockrepository.IdempotencyRepository{}
+	service := NewIdempotencyService(idempotencyRepo, time.Hour)
+	idempotencyRepo.On("DeleteIdempotencyKey", mock.Anything, 3).Return(nil)
+
+	err := service.ReleaseRequest(t.Context(), 3)
+	require.NoError(t, err)
+	idempotencyRepo.AssertExpectations(t)
+}
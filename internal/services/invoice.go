@@ -2,28 +2,63 @@ package services
 
 import (
 	"context"
+	"log"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
 
 	"github.com/mohits-git/food-ordering-system/internal/domain"
 	"github.com/mohits-git/food-ordering-system/internal/ports"
 	"github.com/mohits-git/food-ordering-system/internal/utils/apperr"
-	"github.com/mohits-git/food-ordering-system/internal/utils/authctx"
+)
+
+const (
+	// paymentTimeout bounds how long a payment may wait on the provider
+	paymentTimeout = 2 * time.Minute
+	// paymentPollInterval is how often a pending authorization is checked
+	paymentPollInterval = 500 * time.Millisecond
 )
 
 type InvoiceService struct {
-	invoiceRepo  ports.InvoiceRepository
-	orderRepo    ports.OrderRepository
-	menuItemRepo ports.MenuItemRepository
+	invoiceRepo        ports.InvoiceRepository
+	orderRepo          ports.OrderRepository
+	menuItemRepo       ports.MenuItemRepository
+	taxCalculator      ports.TaxCalculator
+	paymentAttemptRepo ports.PaymentAttemptRepository
+	paymentRepo        ports.PaymentRepository
+	refundRepo         ports.RefundRepository
+	paymentGateway     ports.PaymentGateway
+	authorizer         ports.Authorizer
+	// runAsync runs the payment processing in the background
+	runAsync func(func())
+	// ledgerMu keeps concurrent payments and refunds of an invoice from both
+	// passing the checks against what was paid
+	ledgerMu sync.Mutex
 }
 
 func NewInvoiceService(
 	invoiceRepo ports.InvoiceRepository,
 	orderRepo ports.OrderRepository,
 	menuItemRepo ports.MenuItemRepository,
+	taxCalculator ports.TaxCalculator,
+	paymentAttemptRepo ports.PaymentAttemptRepository,
+	paymentRepo ports.PaymentRepository,
+	refundRepo ports.RefundRepository,
+	paymentGateway ports.PaymentGateway,
+	authorizer ports.Authorizer,
 ) *InvoiceService {
 	return &InvoiceService{
-		invoiceRepo:  invoiceRepo,
-		orderRepo:    orderRepo,
-		menuItemRepo: menuItemRepo,
+		invoiceRepo:        invoiceRepo,
+		orderRepo:          orderRepo,
+		menuItemRepo:       menuItemRepo,
+		taxCalculator:      taxCalculator,
+		paymentAttemptRepo: paymentAttemptRepo,
+		paymentRepo:        paymentRepo,
+		refundRepo:         refundRepo,
+		paymentGateway:     paymentGateway,
+		authorizer:         authorizer,
+		runAsync:           func(f func()) { go f() },
 	}
 }
 
@@ -42,29 +77,51 @@ func (s *InvoiceService) getRestaurantItemsMap(ctx context.Context, restaurantId
 	return restaurantItemMap, nil
 }
 
-func (s *InvoiceService) getItemsAvailabilityMap(restaurantItemsMap map[int]domain.MenuItem) map[int]bool {
-	availabilityMap := make(map[int]bool)
-	for id, item := range restaurantItemsMap {
-		availabilityMap[id] = item.Available
+// cancelInvoices cancels the unpaid and failed invoices of an order, invoices with
+// payments are moved to refund pending as the order will not be fulfilled against them.
+func cancelInvoices(ctx context.Context, invoiceRepo ports.InvoiceRepository, orderId int) error {
+	allInvoices, err := invoiceRepo.FindInvoicesByOrderId(ctx, orderId)
+	if err != nil {
+		return err
 	}
-	return availabilityMap
-}
-
-func (s *InvoiceService) calculateTax(amount float64) float64 {
-	return amount * 0.10 // 10% tax
+	for _, inv := range allInvoices {
+		var status domain.PaymentStatus
+		switch inv.PaymentStatus {
+		case domain.Unpaid, domain.Failed:
+			status = domain.Cancelled
+		case domain.Paid, domain.PartiallyPaid:
+			status = domain.RefundPending
+		default:
+			continue
+		}
+		if err := invoiceRepo.ChangeInvoiceStatus(ctx, inv.ID, status); err != nil {
+			return err
+		}
+	}
+	return nil
 }
 
-func (s *InvoiceService) cancelInvoices(ctx context.Context, orderId int) error {
-	allInvoices, err := s.invoiceRepo.FindInvoicesByOrderId(ctx, orderId)
+// voidDraftInvoices cancels the open invoices of a draft order before its
+// lines change, they no longer bill what is ordered. An invoice with money
+// taken or being taken ties the order to the lines it billed, so the order
+// cannot change then.
+func voidDraftInvoices(ctx context.Context, invoiceRepo ports.InvoiceRepository, orderId int) error {
+	invoices, err := invoiceRepo.FindInvoicesByOrderId(ctx, orderId)
 	if err != nil {
 		return err
 	}
-	for _, inv := range allInvoices {
-		if inv.PaymentStatus == domain.Unpaid {
-			err := s.invoiceRepo.ChangeInvoiceStatus(ctx, inv.ID, domain.Cancelled)
-			if err != nil {
-				return err
-			}
+	for _, inv := range invoices {
+		switch inv.PaymentStatus {
+		case domain.Processing, domain.PartiallyPaid, domain.Paid:
+			return apperr.NewAppError(apperr.ErrConflict, "order has a payment on its invoice and can no longer be changed", nil)
+		}
+	}
+	for _, inv := range invoices {
+		if inv.PaymentStatus != domain.Unpaid && inv.PaymentStatus != domain.Failed {
+			continue
+		}
+		if err := invoiceRepo.ChangeInvoiceStatus(ctx, inv.ID, domain.Cancelled); err != nil {
+			return err
 		}
 	}
 	return nil
@@ -86,48 +143,79 @@ func (s *InvoiceService) getOrderById(cxt context.Context, orderId int) (domain.
 	return order, nil
 }
 
-func (s *InvoiceService) getTotalPrice(order domain.Order, menuItems map[int]domain.MenuItem) float64 {
-	total := 0.0
+// getInvoiceItems bills the order from the prices snapshotted on its items, so
+// regenerating an invoice always gives the same amount. Tax is calculated per
+// line using the category of the menu item.
+func (s *InvoiceService) getInvoiceItems(ctx context.Context, order domain.Order, menuItems map[int]domain.MenuItem) ([]domain.InvoiceItem, error) {
+	items := make([]domain.InvoiceItem, 0, len(order.OrderItems))
 	for _, item := range order.OrderItems {
-		if menuItem, exists := menuItems[item.MenuItemID]; exists && menuItem.IsAvailable() {
-			total += menuItem.Price * float64(item.Quantity)
+		category := menuItems[item.MenuItemID].Category
+		net, tax, err := s.taxCalculator.CalculateTax(ctx, order.RestaurantID, category, item.Total())
+		if err != nil {
+			return nil, err
 		}
-	}
-	return total
+		items = append(items, domain.InvoiceItem{
+			MenuItemID: item.MenuItemID,
+			Name:       item.DisplayName(),
+			UnitPrice:  item.UnitPrice,
+			Quantity:   item.Quantity,
+			Total:      net,
+			Tax:        tax,
+		})
+	}
+	return items, nil
 }
 
 func (s *InvoiceService) GenerateInvoice(ctx context.Context, orderId int) (domain.Invoice, error) {
-	order, err := s.getOrderById(ctx, orderId)
+	if _, err := s.authorizer.Authorize(ctx, domain.ActionGenerateInvoice); err != nil {
+		return domain.Invoice{}, err
+	}
 
-	user, ok := authctx.UserClaimsFromCtx(ctx)
-	if !ok {
-		return domain.Invoice{}, apperr.NewAppError(apperr.ErrUnauthorized, "user not authenticated", nil)
+	order, err := s.getOrderById(ctx, orderId)
+	if err != nil {
+		return domain.Invoice{}, err
 	}
-	if user.Role != domain.CUSTOMER || user.UserID != order.CustomerID {
-		return domain.Invoice{}, apperr.NewAppError(apperr.ErrForbidden, "only customers can generate invoices", nil)
+	if _, err := s.authorizer.AuthorizeResource(ctx, domain.ActionGenerateInvoice, orderResource(order)); err != nil {
+		return domain.Invoice{}, err
 	}
 
 	restaurantItemsMap, err := s.getRestaurantItemsMap(ctx, order.RestaurantID)
 	if err != nil {
 		return domain.Invoice{}, err
 	}
-	restaurantItemsAvailableMap := s.getItemsAvailabilityMap(restaurantItemsMap)
 
-	if !order.Validate(restaurantItemsAvailableMap) {
+	if !order.Validate(restaurantItemsMap) {
 		return domain.Invoice{}, apperr.NewAppError(apperr.ErrInvalid, "invalid order data, or item not available", nil)
 	}
+	if !order.IsDraft() {
+		return domain.Invoice{}, apperr.NewAppError(apperr.ErrInvalid, "order has already been placed", nil)
+	}
 
-	// update other invoices for this order to be cancelled
-	s.cancelInvoices(ctx, orderId)
+	// the new invoice replaces the open ones, an order with money taken or being
+	// taken on an invoice keeps it
+	if err := voidDraftInvoices(ctx, s.invoiceRepo, orderId); err != nil {
+		return domain.Invoice{}, err
+	}
 
 	// Create an invoice based on the order details
-	total := s.getTotalPrice(order, restaurantItemsMap)
-	tax := s.calculateTax(total)
+	items, err := s.getInvoiceItems(ctx, order, restaurantItemsMap)
+	if err != nil {
+		return domain.Invoice{}, err
+	}
+	var total, tax domain.Money
+	for _, item := range items {
+		if total.Currency != "" && !total.SameCurrency(item.Total) {
+			return domain.Invoice{}, apperr.NewAppError(apperr.ErrInvalid, "order items have different currencies", nil)
+		}
+		total = total.Add(item.Total)
+		tax = tax.Add(item.Tax)
+	}
 	invoice := domain.Invoice{
 		OrderID:       order.ID,
 		Total:         total,
 		Tax:           tax,
 		PaymentStatus: domain.Unpaid,
+		Items:         items,
 	}
 
 	id, err := s.invoiceRepo.SaveInvoice(ctx, invoice)
@@ -148,12 +236,8 @@ func (s *InvoiceService) GetInvoiceById(cxt context.Context, id int) (domain.Inv
 		return domain.Invoice{}, apperr.NewAppError(apperr.ErrInvalid, "invalid invoice id", nil)
 	}
 
-	user, ok := authctx.UserClaimsFromCtx(cxt)
-	if !ok {
-		return domain.Invoice{}, apperr.NewAppError(apperr.ErrUnauthorized, "user not authenticated", nil)
-	}
-	if user.Role != domain.CUSTOMER {
-		return domain.Invoice{}, apperr.NewAppError(apperr.ErrForbidden, "only customers can access invoices", nil)
+	if _, err := s.authorizer.Authorize(cxt, domain.ActionViewInvoice); err != nil {
+		return domain.Invoice{}, err
 	}
 
 	invoice, err := s.invoiceRepo.FindInvoiceById(cxt, id)
@@ -163,58 +247,452 @@ func (s *InvoiceService) GetInvoiceById(cxt context.Context, id int) (domain.Inv
 	if invoice.ID == 0 {
 		return domain.Invoice{}, apperr.NewAppError(apperr.ErrNotFound, "invoice not found", nil)
 	}
-
-	order, err := s.orderRepo.FindOrderById(cxt, invoice.OrderID)
-	if err != nil {
+	if _, err := s.authorizeInvoice(cxt, domain.ActionViewInvoice, invoice); err != nil {
 		return domain.Invoice{}, err
 	}
-	if order.CustomerID != user.UserID {
-		return domain.Invoice{}, apperr.NewAppError(apperr.ErrForbidden, "access to the invoice is forbidden", nil)
-	}
 
 	return invoice, nil
 }
 
-func (s *InvoiceService) DoInvoicePayment(cxt context.Context, invoiceId int, payment float64) error {
+const (
+	defaultInvoicesPageSize = 20
+	maxInvoicesPageSize     = 100
+)
+
+// GetInvoices lists the invoices of all orders, for admins.
+func (s *InvoiceService) GetInvoices(ctx context.Context, filter domain.InvoiceFilter) ([]domain.Invoice, int, error) {
+	if filter.Cursor < 0 || filter.Limit < 0 {
+		return nil, 0, apperr.NewAppError(apperr.ErrInvalid, "invalid invoice filter", nil)
+	}
+	for _, status := range filter.Statuses {
+		if !status.Validate() {
+			return nil, 0, apperr.NewAppError(apperr.ErrInvalid, "invalid payment status filter", nil)
+		}
+	}
+
+	if _, err := s.authorizer.Authorize(ctx, domain.ActionViewAllInvoices); err != nil {
+		return nil, 0, err
+	}
+
+	pageSize := filter.Limit
+	if pageSize == 0 {
+		pageSize = defaultInvoicesPageSize
+	}
+	pageSize = min(pageSize, maxInvoicesPageSize)
+
+	// fetch one extra invoice to know if there is a next page
+	filter.Limit = pageSize + 1
+	invoices, err := s.invoiceRepo.FindInvoices(ctx, filter)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	nextCursor := 0
+	if len(invoices) > pageSize {
+		invoices = invoices[:pageSize]
+		nextCursor = invoices[pageSize-1].ID
+	}
+	return invoices, nextCursor, nil
+}
+
+// DoInvoicePayment takes a payment towards the invoice. Cash is recorded
+// straight away with change for anything tendered above the amount due, card
+// payments are charged through the payment provider in the background and the
+// receipt comes back with the invoice processing.
+func (s *InvoiceService) DoInvoicePayment(cxt context.Context, invoiceId int, method domain.PaymentMethod, tendered domain.Money) (domain.PaymentReceipt, error) {
 	if invoiceId <= 0 {
-		return apperr.NewAppError(apperr.ErrInvalid, "invalid invoice id", nil)
+		return domain.PaymentReceipt{}, apperr.NewAppError(apperr.ErrInvalid, "invalid invoice id", nil)
 	}
-	user, ok := authctx.UserClaimsFromCtx(cxt)
-	if !ok {
-		return apperr.NewAppError(apperr.ErrUnauthorized, "user not authenticated", nil)
+	user, err := s.authorizer.Authorize(cxt, domain.ActionPayInvoice)
+	if err != nil {
+		return domain.PaymentReceipt{}, err
+	}
+	if !method.Validate() {
+		return domain.PaymentReceipt{}, apperr.NewAppError(apperr.ErrInvalid, "invalid payment method", nil)
 	}
-	if user.Role != domain.CUSTOMER {
-		return apperr.NewAppError(apperr.ErrForbidden, "only customers can update invoice status", nil)
+	if tendered.IsZero() || tendered.IsNegative() {
+		return domain.PaymentReceipt{}, apperr.NewAppError(apperr.ErrInvalid, "invalid payment amount", nil)
 	}
 
+	s.ledgerMu.Lock()
+	defer s.ledgerMu.Unlock()
+
 	invoice, err := s.invoiceRepo.FindInvoiceById(cxt, invoiceId)
 	if err != nil {
-		return err
+		return domain.PaymentReceipt{}, err
 	}
 	if invoice.ID == 0 {
-		return apperr.NewAppError(apperr.ErrNotFound, "invoice not found", nil)
+		return domain.PaymentReceipt{}, apperr.NewAppError(apperr.ErrNotFound, "invoice not found", nil)
 	}
 
-	order, err := s.orderRepo.FindOrderById(cxt, invoice.OrderID)
+	order, err := s.authorizeInvoice(cxt, domain.ActionPayInvoice, invoice)
 	if err != nil {
-		return err
+		return domain.PaymentReceipt{}, err
+	}
+
+	if invoice.PaymentStatus == domain.Processing {
+		return domain.PaymentReceipt{}, apperr.NewAppError(apperr.ErrConflict, "payment is already being processed", nil)
+	}
+	if !invoice.PaymentStatus.IsOpen() {
+		return domain.PaymentReceipt{}, apperr.NewAppError(apperr.ErrInvalid, "invoice is not open for payment", nil)
+	}
+	if !tendered.SameCurrency(invoice.AmountDue()) {
+		return domain.PaymentReceipt{}, apperr.NewAppError(apperr.ErrInvalid, "payment currency does not match the invoice", nil)
 	}
-	if order.CustomerID != user.UserID {
-		return apperr.NewAppError(apperr.ErrForbidden, "access to the invoice is forbidden", nil)
+	if err := s.checkLatestInvoice(cxt, invoice); err != nil {
+		return domain.PaymentReceipt{}, err
 	}
 
-	if invoice.PaymentStatus == domain.Paid {
-		return apperr.NewAppError(apperr.ErrInvalid, "invalid request", nil)
+	// settling the invoice places the order
+	if !order.Status.CanTransitionTo(domain.OrderPlaced) {
+		return domain.PaymentReceipt{}, apperr.NewAppError(apperr.ErrInvalid, "order is no longer open for payment", nil)
+	}
+	if err := s.checkOrderStock(cxt, order); err != nil {
+		return domain.PaymentReceipt{}, err
+	}
+
+	due, err := amountLeftToPay(cxt, s.paymentRepo, invoice)
+	if err != nil {
+		return domain.PaymentReceipt{}, err
+	}
+	// the customer pays, either themselves or through a cashier taking it
+	payment := domain.NewPayment(invoice.ID, order.CustomerID, method, tendered, due)
+	payment.TakenBy = user.UserID
+
+	if method == domain.PaymentCash {
+		return recordPayment(cxt, s.invoiceRepo, s.orderRepo, s.paymentRepo, invoice, payment)
 	}
 
-	if invoice.Tax+invoice.Total > payment {
-		return apperr.NewAppError(apperr.ErrInvalid, "insufficient payment amount", nil)
+	if !payment.Change.IsZero() {
+		return domain.PaymentReceipt{}, apperr.NewAppError(apperr.ErrInvalid, "card payment exceeds the amount due", nil)
+	}
+
+	attempt := domain.NewPaymentAttempt(invoice.ID, payment.Amount, s.paymentGateway.Name())
+	attempt.TakenBy = payment.TakenBy
+	attempt.ID, err = s.paymentAttemptRepo.SavePaymentAttempt(cxt, attempt)
+	if err != nil {
+		return domain.PaymentReceipt{}, err
+	}
+
+	err = s.invoiceRepo.ChangeInvoiceStatus(cxt, invoiceId, domain.Processing)
+	if err != nil {
+		return domain.PaymentReceipt{}, err
 	}
 
-	err = s.invoiceRepo.ChangeInvoiceStatus(cxt, invoiceId, domain.Paid)
+	// the provider answers after the request is done
+	paymentCtx := context.WithoutCancel(cxt)
+	s.runAsync(func() {
+		s.processPayment(paymentCtx, attempt, invoice, payment)
+	})
+
+	// the amount due once the card payment goes through
+	return domain.PaymentReceipt{Payment: payment, AmountDue: due.Sub(payment.Amount), InvoiceStatus: domain.Processing}, nil
+}
+
+// checkLatestInvoice rejects paying an invoice that a newer one of the order
+// replaced, only the latest invoice bills the current lines of the order.
+func (s *InvoiceService) checkLatestInvoice(ctx context.Context, invoice domain.Invoice) error {
+	invoices, err := s.invoiceRepo.FindInvoicesByOrderId(ctx, invoice.OrderID)
 	if err != nil {
 		return err
 	}
+	for _, inv := range invoices {
+		if inv.ID > invoice.ID {
+			return apperr.NewAppError(apperr.ErrInvalid, "invoice has been replaced by a newer one", nil)
+		}
+	}
+	return nil
+}
+
+// checkOrderStock rejects paying for an order that can no longer be placed,
+// as one of its items ran out since it was ordered.
+func (s *InvoiceService) checkOrderStock(ctx context.Context, order domain.Order) error {
+	quantities := order.Quantities()
+	for _, item := range order.OrderItems {
+		quantity, ok := quantities[item.MenuItemID]
+		if !ok {
+			continue
+		}
+		delete(quantities, item.MenuItemID)
 
+		menuItem, err := s.menuItemRepo.FindMenuItemById(ctx, item.MenuItemID)
+		if err != nil {
+			return err
+		}
+		if err := checkStock(menuItem, quantity); err != nil {
+			return err
+		}
+	}
 	return nil
 }
+
+// processPayment charges the attempt through the gateway and records the
+// payment on the invoice when it went through.
+func (s *InvoiceService) processPayment(ctx context.Context, attempt domain.PaymentAttempt, invoice domain.Invoice, payment domain.Payment) {
+	ctx, cancel := context.WithTimeout(ctx, paymentTimeout)
+	defer cancel()
+
+	result, err := s.chargeInvoice(ctx, attempt)
+	if err != nil {
+		attempt.Fail(result.TransactionID, err.Error())
+	} else if result.Status != domain.GatewayCaptured {
+		reason := result.Message
+		if reason == "" {
+			reason = "payment " + string(result.Status)
+		}
+		attempt.Fail(result.TransactionID, reason)
+	} else {
+		attempt.Succeed(result.TransactionID)
+	}
+
+	if err := s.paymentAttemptRepo.UpdatePaymentAttempt(ctx, attempt); err != nil {
+		log.Printf("failed to record payment attempt %d: %v\n", attempt.ID, err)
+	}
+
+	if attempt.Status != domain.AttemptSucceeded {
+		status, err := failedPaymentStatus(ctx, s.paymentRepo, invoice.ID)
+		if err != nil {
+			log.Printf("failed to read payments of invoice %d: %v\n", invoice.ID, err)
+		}
+		if err := s.invoiceRepo.ChangeInvoiceStatus(ctx, invoice.ID, status); err != nil {
+			log.Printf("failed to update invoice %d to %s: %v\n", invoice.ID, status, err)
+		}
+		return
+	}
+
+	payment.TransactionID = attempt.TransactionID
+	if _, err := recordPayment(ctx, s.invoiceRepo, s.orderRepo, s.paymentRepo, invoice, payment); err != nil {
+		log.Printf("failed to record payment %s of invoice %d: %v\n", attempt.TransactionID, invoice.ID, err)
+	}
+}
+
+// chargeInvoice authorizes the amount, waits while the authorization is
+// pending and captures it. The result keeps the transaction id on errors.
+func (s *InvoiceService) chargeInvoice(ctx context.Context, attempt domain.PaymentAttempt) (domain.GatewayResult, error) {
+	reference := "invoice-" + strconv.Itoa(attempt.InvoiceID)
+	result, err := s.paymentGateway.Authorize(ctx, reference, attempt.Amount)
+	if err != nil {
+		return result, err
+	}
+
+	for !result.Status.IsFinal() {
+		select {
+		case <-ctx.Done():
+			return result, ctx.Err()
+		case <-time.After(paymentPollInterval):
+		}
+		next, err := s.paymentGateway.Status(ctx, result.TransactionID)
+		if err != nil {
+			return result, err
+		}
+		result = next
+	}
+
+	if result.Status != domain.GatewayAuthorized {
+		return result, nil
+	}
+	captured, err := s.paymentGateway.Capture(ctx, result.TransactionID)
+	if err != nil {
+		return result, err
+	}
+	return captured, nil
+}
+
+// placePaidOrder places the order of a paid invoice. If the order got
+// cancelled while the payment was processing, or an item sold out in the
+// meantime, the money has to go back.
+func placePaidOrder(ctx context.Context, orderRepo ports.OrderRepository, orderId int) (domain.PaymentStatus, error) {
+	order, err := orderRepo.FindOrderById(ctx, orderId)
+	if err != nil {
+		return domain.Paid, err
+	}
+	if !order.TransitionTo(domain.OrderPlaced) {
+		return domain.RefundPending, nil
+	}
+	err = orderRepo.UpdateOrderStatus(ctx, order.ID, order.Status)
+	if apperr.IsConflictError(err) {
+		if cancelErr := orderRepo.UpdateOrderStatus(ctx, order.ID, domain.OrderCancelled); cancelErr != nil {
+			return domain.RefundPending, cancelErr
+		}
+		return domain.RefundPending, err
+	}
+	return domain.Paid, err
+}
+
+// amountLeftToPay is the amount due on the invoice less what was paid so far.
+func amountLeftToPay(ctx context.Context, paymentRepo ports.PaymentRepository, invoice domain.Invoice) (domain.Money, error) {
+	payments, err := paymentRepo.FindPaymentsByInvoiceId(ctx, invoice.ID)
+	if err != nil {
+		return domain.Money{}, err
+	}
+	due := invoice.AmountDue()
+	for _, p := range payments {
+		due = due.Sub(p.Amount)
+	}
+	return due, nil
+}
+
+// recordPayment stores a received payment and moves the invoice on, once the
+// payments cover the amount due the invoice is paid and the order placed. A
+// provider transaction already recorded is left as it is.
+func recordPayment(
+	ctx context.Context,
+	invoiceRepo ports.InvoiceRepository,
+	orderRepo ports.OrderRepository,
+	paymentRepo ports.PaymentRepository,
+	invoice domain.Invoice,
+	payment domain.Payment,
+) (domain.PaymentReceipt, error) {
+	id, err := paymentRepo.SavePayment(ctx, payment)
+	if apperr.IsConflictError(err) && payment.TransactionID != "" {
+		return domain.PaymentReceipt{Payment: payment, InvoiceStatus: invoice.PaymentStatus}, nil
+	}
+	if err != nil {
+		return domain.PaymentReceipt{}, err
+	}
+	payment.ID = id
+	payment.PaidAt = time.Now()
+
+	due, err := amountLeftToPay(ctx, paymentRepo, invoice)
+	if err != nil {
+		return domain.PaymentReceipt{}, err
+	}
+
+	status := domain.PartiallyPaid
+	if !due.IsNegative() && !due.IsZero() {
+		err = invoiceRepo.ChangeInvoiceStatus(ctx, invoice.ID, status)
+		return domain.PaymentReceipt{Payment: payment, AmountDue: due, InvoiceStatus: status}, err
+	}
+
+	status, err = placePaidOrder(ctx, orderRepo, invoice.OrderID)
+	if err != nil {
+		log.Printf("failed to place order %d after payment: %v\n", invoice.OrderID, err)
+	}
+	if err := invoiceRepo.ChangeInvoiceStatus(ctx, invoice.ID, status); err != nil {
+		return domain.PaymentReceipt{}, err
+	}
+	return domain.PaymentReceipt{Payment: payment, AmountDue: domain.NewMoney(0, due.Currency), InvoiceStatus: status}, nil
+}
+
+// failedPaymentStatus is the status an invoice goes back to when a payment
+// on it did not go through.
+func failedPaymentStatus(ctx context.Context, paymentRepo ports.PaymentRepository, invoiceId int) (domain.PaymentStatus, error) {
+	payments, err := paymentRepo.FindPaymentsByInvoiceId(ctx, invoiceId)
+	if err != nil {
+		return domain.Failed, err
+	}
+	if len(payments) > 0 {
+		return domain.PartiallyPaid, nil
+	}
+	return domain.Failed, nil
+}
+
+// RefundInvoice gives back part or all of the amount paid for an invoice
+// through the payment provider. Restaurant owners can refund the invoices of
+// their restaurants and admins any invoice.
+func (s *InvoiceService) RefundInvoice(ctx context.Context, invoiceId int, amount domain.Money, reason string) (domain.Refund, error) {
+	if invoiceId <= 0 {
+		return domain.Refund{}, apperr.NewAppError(apperr.ErrInvalid, "invalid invoice id", nil)
+	}
+	user, err := s.authorizer.Authorize(ctx, domain.ActionRefundInvoice)
+	if err != nil {
+		return domain.Refund{}, err
+	}
+
+	refund := domain.NewRefund(invoiceId, amount, strings.TrimSpace(reason), user.UserID)
+	if !refund.Validate() {
+		return domain.Refund{}, apperr.NewAppError(apperr.ErrInvalid, "refund needs a positive amount and a reason", nil)
+	}
+
+	invoice, err := s.invoiceRepo.FindInvoiceById(ctx, invoiceId)
+	if err != nil {
+		return domain.Refund{}, err
+	}
+	if invoice.ID == 0 {
+		return domain.Refund{}, apperr.NewAppError(apperr.ErrNotFound, "invoice not found", nil)
+	}
+
+	if _, err := s.authorizeInvoice(ctx, domain.ActionRefundInvoice, invoice); err != nil {
+		return domain.Refund{}, err
+	}
+
+	if !invoice.PaymentStatus.IsRefundable() {
+		return domain.Refund{}, apperr.NewAppError(apperr.ErrInvalid, "invoice has no payment to refund", nil)
+	}
+	if !amount.SameCurrency(invoice.AmountDue()) {
+		return domain.Refund{}, apperr.NewAppError(apperr.ErrInvalid, "refund currency does not match the invoice", nil)
+	}
+
+	s.ledgerMu.Lock()
+	defer s.ledgerMu.Unlock()
+
+	payments, err := s.paymentRepo.FindPaymentsByInvoiceId(ctx, invoiceId)
+	if err != nil {
+		return domain.Refund{}, err
+	}
+	refunds, err := s.refundRepo.FindRefundsByInvoiceId(ctx, invoiceId)
+	if err != nil {
+		return domain.Refund{}, err
+	}
+
+	paid, refunded, payment := refundablePayment(payments, refunds, amount)
+	if paid.Sub(refunded).Sub(amount).IsNegative() {
+		return domain.Refund{}, apperr.NewAppError(apperr.ErrInvalid, "refund exceeds the amount paid", nil)
+	}
+	if payment.ID == 0 {
+		return domain.Refund{}, apperr.NewAppError(apperr.ErrInvalid, "refund exceeds the amount left on any single payment", nil)
+	}
+
+	// cash is handed back at the counter
+	if payment.Method == domain.PaymentCard {
+		if _, err := s.paymentGateway.Refund(ctx, payment.TransactionID, amount); err != nil {
+			return domain.Refund{}, err
+		}
+	}
+
+	refund.PaymentID = payment.ID
+	refund.TransactionID = payment.TransactionID
+	refund.ID, err = s.refundRepo.SaveRefund(ctx, refund)
+	if err != nil {
+		log.Printf("refunded %s on payment %d but failed to record it: %v\n", amount, payment.ID, err)
+		return domain.Refund{}, err
+	}
+
+	status := domain.RefundStatus(paid, refunded.Add(amount))
+	if err := s.invoiceRepo.ChangeInvoiceStatus(ctx, invoiceId, status); err != nil {
+		return domain.Refund{}, err
+	}
+
+	return refund, nil
+}
+
+// authorizeInvoice checks the action against the order the invoice is for
+// and returns the order.
+func (s *InvoiceService) authorizeInvoice(ctx context.Context, action domain.Action, invoice domain.Invoice) (domain.Order, error) {
+	order, err := s.orderRepo.FindOrderById(ctx, invoice.OrderID)
+	if err != nil {
+		return domain.Order{}, err
+	}
+	if _, err := s.authorizer.AuthorizeResource(ctx, action, orderResource(order)); err != nil {
+		return domain.Order{}, err
+	}
+	return order, nil
+}
+
+// refundablePayment sums what was paid and refunded on an invoice and picks
+// the first payment with enough left on it to refund the amount.
+func refundablePayment(payments []domain.Payment, refunds []domain.Refund, amount domain.Money) (paid, refunded domain.Money, payment domain.Payment) {
+	refundedByPayment := make(map[int]domain.Money)
+	for _, r := range refunds {
+		refunded = refunded.Add(r.Amount)
+		refundedByPayment[r.PaymentID] = refundedByPayment[r.PaymentID].Add(r.Amount)
+	}
+	for _, p := range payments {
+		paid = paid.Add(p.Amount)
+		left := p.Amount.Sub(refundedByPayment[p.ID])
+		if payment.ID == 0 && !left.Sub(amount).IsNegative() {
+			payment = p
+		}
+	}
+	return paid, refunded, payment
+}
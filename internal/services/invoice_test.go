@@ -6,7 +6,9 @@ import (
 	"github.com/mohits-git/food-ordering-system/internal/domain"
 	"github.com/mohits-git/food-ordering-system/internal/utils/apperr"
 	"github.com/mohits-git/food-ordering-system/internal/utils/authctx"
+	mockpaymentgateway "github.com/mohits-git/food-ordering-system/tests/mock_payment_gateway"
 	mockrepository "github.com/mohits-git/food-ordering-system/tests/mock_repository"
+	mocktaxcalculator "github.com/mohits-git/food-ordering-system/tests/mock_tax_calculator"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 )
@@ -15,7 +17,13 @@ func Test_services_InvoiceService_NewInvoiceService(t *testing.T) {
 	mockInvoiceRepo := mockrepository.InvoiceRepository{}
 	mockOrderRepo := mockrepository.OrderRepository{}
 	mockMenuItemRepo := mockrepository.MenuItemRepository{}
-	service := NewInvoiceService(&mockInvoiceRepo, &mockOrderRepo, &mockMenuItemRepo)
+	mockRestaurantRepo := mockrepository.RestaurantRepository{}
+	mockTaxCalculator := mocktaxcalculator.TaxCalculator{}
+	mockPaymentAttemptRepo := mockrepository.PaymentAttemptRepository{}
+	mockPaymentRepo := mockrepository.PaymentRepository{}
+	mockRefundRepo := mockrepository.RefundRepository{}
+	mockPaymentGateway := mockpaymentgateway.PaymentGateway{}
+	service := NewInvoiceService(&mockInvoiceRepo, &mockOrderRepo, &mockMenuItemRepo, &mockTaxCalculator, &mockPaymentAttemptRepo, &mockPaymentRepo, &mockRefundRepo, &mockPaymentGateway, NewAuthorizer(&mockRestaurantRepo, &mockrepository.RestaurantMemberRepository{}))
 	require.NotNil(t, service)
 }
 
@@ -23,7 +31,13 @@ func Test_services_InvoiceService_GenerateInvoice(t *testing.T) {
 	mockInvoiceRepo := mockrepository.InvoiceRepository{}
 	mockOrderRepo := mockrepository.OrderRepository{}
 	mockMenuItemRepo := mockrepository.MenuItemRepository{}
-	service := NewInvoiceService(&mockInvoiceRepo, &mockOrderRepo, &mockMenuItemRepo)
+	mockRestaurantRepo := mockrepository.RestaurantRepository{}
+	mockTaxCalculator := mocktaxcalculator.TaxCalculator{}
+	mockPaymentAttemptRepo := mockrepository.PaymentAttemptRepository{}
+	mockPaymentRepo := mockrepository.PaymentRepository{}
+	mockRefundRepo := mockrepository.RefundRepository{}
+	mockPaymentGateway := mockpaymentgateway.PaymentGateway{}
+	service := NewInvoiceService(&mockInvoiceRepo, &mockOrderRepo, &mockMenuItemRepo, &mockTaxCalculator, &mockPaymentAttemptRepo, &mockPaymentRepo, &mockRefundRepo, &mockPaymentGateway, NewAuthorizer(&mockRestaurantRepo, &mockrepository.RestaurantMemberRepository{}))
 
 	userCtx := authctx.WithUserClaims(t.Context(), &authctx.UserClaims{
 		UserID: 1,
@@ -34,34 +48,45 @@ func Test_services_InvoiceService_GenerateInvoice(t *testing.T) {
 		ID:           1,
 		CustomerID:   1,
 		RestaurantID: 1,
+		Status:       domain.OrderDraft,
 		OrderItems: []domain.OrderItem{
-			{MenuItemID: 1, Quantity: 2},
-			{MenuItemID: 2, Quantity: 1},
+			{MenuItemID: 1, Quantity: 2, Name: "Item 1", UnitPrice: domain.NewMoney(10000, "USD")},
+			{MenuItemID: 2, Quantity: 1, Name: "Item 2", UnitPrice: domain.NewMoney(20000, "USD"), Options: []domain.OrderItemOption{
+				{OptionID: 5, Name: "Spicy", PriceDelta: domain.NewMoney(0, "USD")},
+			}},
 		},
 	}
 
 	mockOrderRepo.On("FindOrderById", mock.Anything, order.ID).
 		Return(order, nil)
+	// menu prices changed since the items were added, the order is billed at the snapshot
 	mockMenuItemRepo.On("FindMenuItemsByRestaurantId", mock.Anything, order.RestaurantID).
 		Return([]domain.MenuItem{
-			{ID: 1, Name: "Item 1", Price: 100.0, Available: true},
-			{ID: 2, Name: "Item 2", Price: 200.0, Available: true},
+			{ID: 1, Name: "Item 1", Price: domain.NewMoney(15000, "USD"), Available: true},
+			{ID: 2, Name: "Item 2", Price: domain.NewMoney(25000, "USD"), Available: true, ModifierGroups: []domain.ModifierGroup{
+				{ID: 1, Name: "Extras", MinSelect: 0, MaxSelect: 1, Options: []domain.ModifierOption{
+					{ID: 5, Name: "Spicy", PriceDelta: domain.NewMoney(50, "USD")},
+				}},
+			}},
 		}, nil)
 	mockInvoiceRepo.On("FindInvoicesByOrderId", mock.Anything, order.ID).
 		Return([]domain.Invoice{}, nil)
+	mockTaxCalculator.On("CalculateTax", mock.Anything, order.RestaurantID, "", domain.NewMoney(20000, "USD")).
+		Return(domain.NewMoney(20000, "USD"), domain.NewMoney(2000, "USD"), nil)
 	mockInvoiceRepo.On("SaveInvoice", mock.Anything, mock.MatchedBy(func(inv domain.Invoice) bool {
-		return inv.OrderID == order.ID && inv.Total == 400.0 && inv.Tax == 40.0 && inv.Total+inv.Tax == 440.0
+		return inv.OrderID == order.ID && inv.Total == domain.NewMoney(40000, "USD") && inv.Tax == domain.NewMoney(4000, "USD") && inv.AmountDue() == domain.NewMoney(44000, "USD") &&
+			len(inv.Items) == 2 && inv.Items[0].Total == domain.NewMoney(20000, "USD") && inv.Items[0].Tax == domain.NewMoney(2000, "USD") && inv.Items[1].Name == "Item 2 (Spicy)"
 	})).
 		Return(1, nil)
 	mockInvoiceRepo.On("FindInvoiceById", mock.Anything, 1).
-		Return(domain.Invoice{ID: 1, OrderID: order.ID, Total: 400.0, Tax: 40.0, PaymentStatus: domain.Unpaid}, nil)
+		Return(domain.Invoice{ID: 1, OrderID: order.ID, Total: domain.NewMoney(40000, "USD"), Tax: domain.NewMoney(4000, "USD"), PaymentStatus: domain.Unpaid}, nil)
 
 	invoice, err := service.GenerateInvoice(userCtx, order.ID)
 	require.NoError(t, err)
 	require.Equal(t, 1, invoice.ID)
 	require.Equal(t, order.ID, invoice.OrderID)
-	require.Equal(t, 400.0, invoice.Total)
-	require.Equal(t, 40.0, invoice.Tax)
+	require.Equal(t, domain.NewMoney(40000, "USD"), invoice.Total)
+	require.Equal(t, domain.NewMoney(4000, "USD"), invoice.Tax)
 	require.Equal(t, domain.Unpaid, invoice.PaymentStatus)
 
 	mockOrderRepo.AssertExpectations(t)
@@ -69,34 +94,87 @@ func Test_services_InvoiceService_GenerateInvoice(t *testing.T) {
 	mockInvoiceRepo.AssertExpectations(t)
 }
 
+func Test_services_InvoiceService_GenerateInvoice_WhenPaymentTaken(t *testing.T) {
+	mockInvoiceRepo := mockrepository.InvoiceRepository{}
+	mockOrderRepo := mockrepository.OrderRepository{}
+	mockMenuItemRepo := mockrepository.MenuItemRepository{}
+	mockRestaurantRepo := mockrepository.RestaurantRepository{}
+	mockTaxCalculator := mocktaxcalculator.TaxCalculator{}
+	mockPaymentAttemptRepo := mockrepository.PaymentAttemptRepository{}
+	mockPaymentRepo := mockrepository.PaymentRepository{}
+	mockRefundRepo := mockrepository.RefundRepository{}
+	mockPaymentGateway := mockpaymentgateway.PaymentGateway{}
+	service := NewInvoiceService(&mockInvoiceRepo, &mockOrderRepo, &mockMenuItemRepo, &mockTaxCalculator, &mockPaymentAttemptRepo, &mockPaymentRepo, &mockRefundRepo, &mockPaymentGateway, NewAuthorizer(&mockRestaurantRepo, &mockrepository.RestaurantMemberRepository{}))
+
+	userCtx := authctx.WithUserClaims(t.Context(), &authctx.UserClaims{
+		UserID: 1,
+		Role:   domain.CUSTOMER,
+	})
+
+	order := domain.Order{
+		ID:           1,
+		CustomerID:   1,
+		RestaurantID: 1,
+		Status:       domain.OrderDraft,
+		OrderItems: []domain.OrderItem{
+			{MenuItemID: 1, Quantity: 2, Name: "Item 1", UnitPrice: domain.NewMoney(10000, "USD")},
+		},
+	}
+
+	mockOrderRepo.On("FindOrderById", mock.Anything, order.ID).
+		Return(order, nil)
+	mockMenuItemRepo.On("FindMenuItemsByRestaurantId", mock.Anything, order.RestaurantID).
+		Return([]domain.MenuItem{{ID: 1, Name: "Item 1", Price: domain.NewMoney(10000, "USD"), Available: true}}, nil)
+
+	for _, status := range []domain.PaymentStatus{domain.PartiallyPaid, domain.Processing} {
+		mockInvoiceRepo.On("FindInvoicesByOrderId", mock.Anything, order.ID).
+			Return([]domain.Invoice{{ID: 3, OrderID: order.ID, PaymentStatus: status}}, nil).Once()
+
+		_, err := service.GenerateInvoice(userCtx, order.ID)
+		require.True(t, apperr.IsConflictError(err), "expected conflict error for %s but got %v", status, err)
+	}
+
+	mockInvoiceRepo.AssertNotCalled(t, "ChangeInvoiceStatus", mock.Anything, mock.Anything, mock.Anything)
+	mockInvoiceRepo.AssertNotCalled(t, "SaveInvoice", mock.Anything, mock.Anything)
+}
+
 func Test_services_InvoiceService_GenerateInvoice_Unauthenticated(t *testing.T) {
 	mockInvoiceRepo := mockrepository.InvoiceRepository{}
 	mockOrderRepo := mockrepository.OrderRepository{}
 	mockMenuItemRepo := mockrepository.MenuItemRepository{}
-	service := NewInvoiceService(&mockInvoiceRepo, &mockOrderRepo, &mockMenuItemRepo)
+	mockRestaurantRepo := mockrepository.RestaurantRepository{}
+	mockTaxCalculator := mocktaxcalculator.TaxCalculator{}
+	mockPaymentAttemptRepo := mockrepository.PaymentAttemptRepository{}
+	mockPaymentRepo := mockrepository.PaymentRepository{}
+	mockRefundRepo := mockrepository.RefundRepository{}
+	mockPaymentGateway := mockpaymentgateway.PaymentGateway{}
+	service := NewInvoiceService(&mockInvoiceRepo, &mockOrderRepo, &mockMenuItemRepo, &mockTaxCalculator, &mockPaymentAttemptRepo, &mockPaymentRepo, &mockRefundRepo, &mockPaymentGateway, NewAuthorizer(&mockRestaurantRepo, &mockrepository.RestaurantMemberRepository{}))
 
 	orderId := 1
 
-	mockOrderRepo.On("FindOrderById", mock.Anything, orderId).
-		Return(domain.Order{}, nil)
-
 	_, err := service.GenerateInvoice(t.Context(), orderId)
 	appErr, ok := err.(*apperr.AppError)
 	require.Error(t, err)
 	require.True(t, ok)
 	require.Equal(t, apperr.ErrUnauthorized, appErr.Code)
 
+	mockOrderRepo.AssertNotCalled(t, "FindOrderById", mock.Anything, orderId)
 	mockMenuItemRepo.AssertNotCalled(t, "FindMenuItemsByRestaurantId", mock.Anything, mock.Anything)
 	mockInvoiceRepo.AssertNotCalled(t, "FindInvoicesByOrderId", mock.Anything, orderId)
 	mockInvoiceRepo.AssertNotCalled(t, "SaveInvoice", mock.Anything, mock.Anything)
-	mockOrderRepo.AssertExpectations(t)
 }
 
 func Test_services_InvoiceService_GenerateInvoice_Forbidden(t *testing.T) {
 	mockInvoiceRepo := mockrepository.InvoiceRepository{}
 	mockOrderRepo := mockrepository.OrderRepository{}
 	mockMenuItemRepo := mockrepository.MenuItemRepository{}
-	service := NewInvoiceService(&mockInvoiceRepo, &mockOrderRepo, &mockMenuItemRepo)
+	mockRestaurantRepo := mockrepository.RestaurantRepository{}
+	mockTaxCalculator := mocktaxcalculator.TaxCalculator{}
+	mockPaymentAttemptRepo := mockrepository.PaymentAttemptRepository{}
+	mockPaymentRepo := mockrepository.PaymentRepository{}
+	mockRefundRepo := mockrepository.RefundRepository{}
+	mockPaymentGateway := mockpaymentgateway.PaymentGateway{}
+	service := NewInvoiceService(&mockInvoiceRepo, &mockOrderRepo, &mockMenuItemRepo, &mockTaxCalculator, &mockPaymentAttemptRepo, &mockPaymentRepo, &mockRefundRepo, &mockPaymentGateway, NewAuthorizer(&mockRestaurantRepo, &mockrepository.RestaurantMemberRepository{}))
 
 	userCtx := authctx.WithUserClaims(t.Context(), &authctx.UserClaims{
 		UserID: 2,
@@ -133,7 +211,13 @@ func Test_services_InvoiceService_GetInvoiceById(t *testing.T) {
 	mockInvoiceRepo := mockrepository.InvoiceRepository{}
 	mockOrderRepo := mockrepository.OrderRepository{}
 	mockMenuItemRepo := mockrepository.MenuItemRepository{}
-	service := NewInvoiceService(&mockInvoiceRepo, &mockOrderRepo, &mockMenuItemRepo)
+	mockRestaurantRepo := mockrepository.RestaurantRepository{}
+	mockTaxCalculator := mocktaxcalculator.TaxCalculator{}
+	mockPaymentAttemptRepo := mockrepository.PaymentAttemptRepository{}
+	mockPaymentRepo := mockrepository.PaymentRepository{}
+	mockRefundRepo := mockrepository.RefundRepository{}
+	mockPaymentGateway := mockpaymentgateway.PaymentGateway{}
+	service := NewInvoiceService(&mockInvoiceRepo, &mockOrderRepo, &mockMenuItemRepo, &mockTaxCalculator, &mockPaymentAttemptRepo, &mockPaymentRepo, &mockRefundRepo, &mockPaymentGateway, NewAuthorizer(&mockRestaurantRepo, &mockrepository.RestaurantMemberRepository{}))
 
 	userCtx := authctx.WithUserClaims(t.Context(), &authctx.UserClaims{
 		UserID: 1,
@@ -143,8 +227,8 @@ func Test_services_InvoiceService_GetInvoiceById(t *testing.T) {
 	invoice := domain.Invoice{
 		ID:            1,
 		OrderID:       1,
-		Total:         400.0,
-		Tax:           40.0,
+		Total:         domain.NewMoney(40000, "USD"),
+		Tax:           domain.NewMoney(4000, "USD"),
 		PaymentStatus: domain.Unpaid,
 	}
 
@@ -165,7 +249,13 @@ func Test_services_InvoiceService_GetInvoiceById_Unauthenticated(t *testing.T) {
 	mockInvoiceRepo := mockrepository.InvoiceRepository{}
 	mockOrderRepo := mockrepository.OrderRepository{}
 	mockMenuItemRepo := mockrepository.MenuItemRepository{}
-	service := NewInvoiceService(&mockInvoiceRepo, &mockOrderRepo, &mockMenuItemRepo)
+	mockRestaurantRepo := mockrepository.RestaurantRepository{}
+	mockTaxCalculator := mocktaxcalculator.TaxCalculator{}
+	mockPaymentAttemptRepo := mockrepository.PaymentAttemptRepository{}
+	mockPaymentRepo := mockrepository.PaymentRepository{}
+	mockRefundRepo := mockrepository.RefundRepository{}
+	mockPaymentGateway := mockpaymentgateway.PaymentGateway{}
+	service := NewInvoiceService(&mockInvoiceRepo, &mockOrderRepo, &mockMenuItemRepo, &mockTaxCalculator, &mockPaymentAttemptRepo, &mockPaymentRepo, &mockRefundRepo, &mockPaymentGateway, NewAuthorizer(&mockRestaurantRepo, &mockrepository.RestaurantMemberRepository{}))
 
 	invoiceId := 1
 
@@ -183,7 +273,13 @@ func Test_services_InvoiceService_GetInvoiceById_Forbidden(t *testing.T) {
 	mockInvoiceRepo := mockrepository.InvoiceRepository{}
 	mockOrderRepo := mockrepository.OrderRepository{}
 	mockMenuItemRepo := mockrepository.MenuItemRepository{}
-	service := NewInvoiceService(&mockInvoiceRepo, &mockOrderRepo, &mockMenuItemRepo)
+	mockRestaurantRepo := mockrepository.RestaurantRepository{}
+	mockTaxCalculator := mocktaxcalculator.TaxCalculator{}
+	mockPaymentAttemptRepo := mockrepository.PaymentAttemptRepository{}
+	mockPaymentRepo := mockrepository.PaymentRepository{}
+	mockRefundRepo := mockrepository.RefundRepository{}
+	mockPaymentGateway := mockpaymentgateway.PaymentGateway{}
+	service := NewInvoiceService(&mockInvoiceRepo, &mockOrderRepo, &mockMenuItemRepo, &mockTaxCalculator, &mockPaymentAttemptRepo, &mockPaymentRepo, &mockRefundRepo, &mockPaymentGateway, NewAuthorizer(&mockRestaurantRepo, &mockrepository.RestaurantMemberRepository{}))
 
 	userCtx := authctx.WithUserClaims(t.Context(), &authctx.UserClaims{
 		UserID: 2,
@@ -193,8 +289,8 @@ func Test_services_InvoiceService_GetInvoiceById_Forbidden(t *testing.T) {
 	invoice := domain.Invoice{
 		ID:            1,
 		OrderID:       1,
-		Total:         400.0,
-		Tax:           40.0,
+		Total:         domain.NewMoney(40000, "USD"),
+		Tax:           domain.NewMoney(4000, "USD"),
 		PaymentStatus: domain.Unpaid,
 	}
 
@@ -218,45 +314,361 @@ func Test_services_InvoiceService_DoInvoicePayment(t *testing.T) {
 	mockInvoiceRepo := mockrepository.InvoiceRepository{}
 	mockOrderRepo := mockrepository.OrderRepository{}
 	mockMenuItemRepo := mockrepository.MenuItemRepository{}
-	service := NewInvoiceService(&mockInvoiceRepo, &mockOrderRepo, &mockMenuItemRepo)
+	mockRestaurantRepo := mockrepository.RestaurantRepository{}
+	mockTaxCalculator := mocktaxcalculator.TaxCalculator{}
+	mockPaymentAttemptRepo := mockrepository.PaymentAttemptRepository{}
+	mockPaymentRepo := mockrepository.PaymentRepository{}
+	mockRefundRepo := mockrepository.RefundRepository{}
+	mockPaymentGateway := mockpaymentgateway.PaymentGateway{}
+
+	service := NewInvoiceService(&mockInvoiceRepo, &mockOrderRepo, &mockMenuItemRepo, &mockTaxCalculator, &mockPaymentAttemptRepo, &mockPaymentRepo, &mockRefundRepo, &mockPaymentGateway, NewAuthorizer(&mockRestaurantRepo, &mockrepository.RestaurantMemberRepository{}))
+	service.runAsync = func(f func()) { f() }
+
+	invoice := domain.Invoice{
+		ID:            1,
+		OrderID:       1,
+		Total:         domain.NewMoney(40000, "USD"),
+		Tax:           domain.NewMoney(4000, "USD"),
+		PaymentStatus: domain.Unpaid,
+	}
+	mockInvoiceRepo.On("FindInvoiceById", mock.Anything, invoice.ID).
+		Return(invoice, nil)
+	mockInvoiceRepo.On("FindInvoicesByOrderId", mock.Anything, invoice.OrderID).
+		Return([]domain.Invoice{invoice}, nil)
+	mockOrderRepo.On("FindOrderById", mock.Anything, invoice.OrderID).
+		Return(domain.Order{ID: invoice.OrderID, CustomerID: 1, Status: domain.OrderDraft}, nil).Once()
+	mockPaymentRepo.On("FindPaymentsByInvoiceId", mock.Anything, invoice.ID).
+		Return([]domain.Payment{}, nil).Once()
+	mockPaymentGateway.On("Name").Return("fake")
+	attempt := domain.NewPaymentAttempt(invoice.ID, invoice.AmountDue(), "fake")
+	attempt.TakenBy = 1
+	mockPaymentAttemptRepo.On("SavePaymentAttempt", mock.Anything, attempt).
+		Return(7, nil)
+	mockInvoiceRepo.On("ChangeInvoiceStatus", mock.Anything, invoice.ID, domain.Processing).
+		Return(nil).Once()
 
 	userCtx := authctx.WithUserClaims(t.Context(), &authctx.UserClaims{
 		UserID: 1,
 		Role:   domain.CUSTOMER,
 	})
 
+	mockPaymentGateway.On("Authorize", mock.Anything, "invoice-1", invoice.AmountDue()).
+		Return(domain.GatewayResult{TransactionID: "tx_1", Status: domain.GatewayAuthorized}, nil)
+	mockPaymentGateway.On("Capture", mock.Anything, "tx_1").
+		Return(domain.GatewayResult{TransactionID: "tx_1", Status: domain.GatewayCaptured}, nil)
+	mockPaymentAttemptRepo.On("UpdatePaymentAttempt", mock.Anything, mock.MatchedBy(func(a domain.PaymentAttempt) bool {
+		return a.ID == 7 && a.Status == domain.AttemptSucceeded && a.TransactionID == "tx_1"
+	})).Return(nil)
+	mockOrderRepo.On("FindOrderById", mock.Anything, invoice.OrderID).
+		Return(domain.Order{ID: invoice.OrderID, CustomerID: 1, Status: domain.OrderDraft}, nil).Once()
+	mockOrderRepo.On("UpdateOrderStatus", mock.Anything, invoice.OrderID, domain.OrderPlaced).
+		Return(nil)
+	payment := domain.Payment{InvoiceID: invoice.ID, PayerID: 1, TakenBy: 1, Method: domain.PaymentCard, Amount: invoice.AmountDue(), Tendered: invoice.AmountDue(), Change: domain.NewMoney(0, "USD"), TransactionID: "tx_1"}
+	mockPaymentRepo.On("SavePayment", mock.Anything, payment).
+		Return(9, nil)
+	mockPaymentRepo.On("FindPaymentsByInvoiceId", mock.Anything, invoice.ID).
+		Return([]domain.Payment{payment}, nil)
+	mockInvoiceRepo.On("ChangeInvoiceStatus", mock.Anything, invoice.ID, domain.Paid).
+		Return(nil)
+
+	receipt, err := service.DoInvoicePayment(userCtx, invoice.ID, domain.PaymentCard, domain.NewMoney(44000, "USD"))
+	require.NoError(t, err)
+	require.Equal(t, domain.Processing, receipt.InvoiceStatus)
+	require.Equal(t, domain.NewMoney(0, "USD"), receipt.AmountDue)
+	require.Equal(t, invoice.AmountDue(), receipt.Payment.Amount)
+
+	mockInvoiceRepo.AssertExpectations(t)
+	mockOrderRepo.AssertExpectations(t)
+	mockPaymentAttemptRepo.AssertExpectations(t)
+	mockPaymentRepo.AssertExpectations(t)
+	mockPaymentGateway.AssertExpectations(t)
+}
+
+func Test_services_InvoiceService_DoInvoicePayment_Declined(t *testing.T) {
+	mockInvoiceRepo := mockrepository.InvoiceRepository{}
+	mockOrderRepo := mockrepository.OrderRepository{}
+	mockMenuItemRepo := mockrepository.MenuItemRepository{}
+	mockRestaurantRepo := mockrepository.RestaurantRepository{}
+	mockTaxCalculator := mocktaxcalculator.TaxCalculator{}
+	mockPaymentAttemptRepo := mockrepository.PaymentAttemptRepository{}
+	mockPaymentRepo := mockrepository.PaymentRepository{}
+	mockRefundRepo := mockrepository.RefundRepository{}
+	mockPaymentGateway := mockpaymentgateway.PaymentGateway{}
+
+	service := NewInvoiceService(&mockInvoiceRepo, &mockOrderRepo, &mockMenuItemRepo, &mockTaxCalculator, &mockPaymentAttemptRepo, &mockPaymentRepo, &mockRefundRepo, &mockPaymentGateway, NewAuthorizer(&mockRestaurantRepo, &mockrepository.RestaurantMemberRepository{}))
+	service.runAsync = func(f func()) { f() }
+
 	invoice := domain.Invoice{
 		ID:            1,
 		OrderID:       1,
-		Total:         400.0,
-		Tax:           40.0,
+		Total:         domain.NewMoney(40000, "USD"),
+		Tax:           domain.NewMoney(4000, "USD"),
 		PaymentStatus: domain.Unpaid,
 	}
+	mockInvoiceRepo.On("FindInvoiceById", mock.Anything, invoice.ID).
+		Return(invoice, nil)
+	mockInvoiceRepo.On("FindInvoicesByOrderId", mock.Anything, invoice.OrderID).
+		Return([]domain.Invoice{invoice}, nil)
+	mockOrderRepo.On("FindOrderById", mock.Anything, invoice.OrderID).
+		Return(domain.Order{ID: invoice.OrderID, CustomerID: 1, Status: domain.OrderDraft}, nil).Once()
+	mockPaymentRepo.On("FindPaymentsByInvoiceId", mock.Anything, invoice.ID).
+		Return([]domain.Payment{}, nil).Once()
+	mockPaymentGateway.On("Name").Return("fake")
+	attempt := domain.NewPaymentAttempt(invoice.ID, invoice.AmountDue(), "fake")
+	attempt.TakenBy = 1
+	mockPaymentAttemptRepo.On("SavePaymentAttempt", mock.Anything, attempt).
+		Return(7, nil)
+	mockInvoiceRepo.On("ChangeInvoiceStatus", mock.Anything, invoice.ID, domain.Processing).
+		Return(nil).Once()
+
+	userCtx := authctx.WithUserClaims(t.Context(), &authctx.UserClaims{
+		UserID: 1,
+		Role:   domain.CUSTOMER,
+	})
 
+	mockPaymentGateway.On("Authorize", mock.Anything, "invoice-1", invoice.AmountDue()).
+		Return(domain.GatewayResult{TransactionID: "tx_1", Status: domain.GatewayPending}, nil)
+	mockPaymentGateway.On("Status", mock.Anything, "tx_1").
+		Return(domain.GatewayResult{TransactionID: "tx_1", Status: domain.GatewayDeclined, Message: "card declined"}, nil)
+	mockPaymentAttemptRepo.On("UpdatePaymentAttempt", mock.Anything, mock.MatchedBy(func(a domain.PaymentAttempt) bool {
+		return a.ID == 7 && a.Status == domain.AttemptFailed && a.FailureReason == "card declined"
+	})).Return(nil)
+	mockPaymentRepo.On("FindPaymentsByInvoiceId", mock.Anything, invoice.ID).
+		Return([]domain.Payment{}, nil)
+	mockInvoiceRepo.On("ChangeInvoiceStatus", mock.Anything, invoice.ID, domain.Failed).
+		Return(nil)
+
+	_, err := service.DoInvoicePayment(userCtx, invoice.ID, domain.PaymentCard, domain.NewMoney(44000, "USD"))
+	require.NoError(t, err)
+
+	mockInvoiceRepo.AssertExpectations(t)
+	mockPaymentAttemptRepo.AssertExpectations(t)
+	mockPaymentGateway.AssertExpectations(t)
+	mockPaymentRepo.AssertNotCalled(t, "SavePayment", mock.Anything, mock.Anything)
+	mockPaymentGateway.AssertNotCalled(t, "Capture", mock.Anything, mock.Anything)
+	mockOrderRepo.AssertNotCalled(t, "UpdateOrderStatus", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func Test_services_InvoiceService_DoInvoicePayment_OrderCancelledWhileProcessing(t *testing.T) {
+	mockInvoiceRepo := mockrepository.InvoiceRepository{}
+	mockOrderRepo := mockrepository.OrderRepository{}
+	mockMenuItemRepo := mockrepository.MenuItemRepository{}
+	mockRestaurantRepo := mockrepository.RestaurantRepository{}
+	mockTaxCalculator := mocktaxcalculator.TaxCalculator{}
+	mockPaymentAttemptRepo := mockrepository.PaymentAttemptRepository{}
+	mockPaymentRepo := mockrepository.PaymentRepository{}
+	mockRefundRepo := mockrepository.RefundRepository{}
+	mockPaymentGateway := mockpaymentgateway.PaymentGateway{}
+
+	service := NewInvoiceService(&mockInvoiceRepo, &mockOrderRepo, &mockMenuItemRepo, &mockTaxCalculator, &mockPaymentAttemptRepo, &mockPaymentRepo, &mockRefundRepo, &mockPaymentGateway, NewAuthorizer(&mockRestaurantRepo, &mockrepository.RestaurantMemberRepository{}))
+	service.runAsync = func(f func()) { f() }
+
+	invoice := domain.Invoice{
+		ID:            1,
+		OrderID:       1,
+		Total:         domain.NewMoney(40000, "USD"),
+		Tax:           domain.NewMoney(4000, "USD"),
+		PaymentStatus: domain.Unpaid,
+	}
 	mockInvoiceRepo.On("FindInvoiceById", mock.Anything, invoice.ID).
 		Return(invoice, nil)
+	mockInvoiceRepo.On("FindInvoicesByOrderId", mock.Anything, invoice.OrderID).
+		Return([]domain.Invoice{invoice}, nil)
 	mockOrderRepo.On("FindOrderById", mock.Anything, invoice.OrderID).
-		Return(domain.Order{ID: invoice.OrderID, CustomerID: 1}, nil)
-	mockInvoiceRepo.On("ChangeInvoiceStatus", mock.Anything, invoice.ID, domain.Paid).
+		Return(domain.Order{ID: invoice.OrderID, CustomerID: 1, Status: domain.OrderDraft}, nil).Once()
+	mockPaymentRepo.On("FindPaymentsByInvoiceId", mock.Anything, invoice.ID).
+		Return([]domain.Payment{}, nil).Once()
+	mockPaymentGateway.On("Name").Return("fake")
+	attempt := domain.NewPaymentAttempt(invoice.ID, invoice.AmountDue(), "fake")
+	attempt.TakenBy = 1
+	mockPaymentAttemptRepo.On("SavePaymentAttempt", mock.Anything, attempt).
+		Return(7, nil)
+	mockInvoiceRepo.On("ChangeInvoiceStatus", mock.Anything, invoice.ID, domain.Processing).
+		Return(nil).Once()
+
+	userCtx := authctx.WithUserClaims(t.Context(), &authctx.UserClaims{
+		UserID: 1,
+		Role:   domain.CUSTOMER,
+	})
+
+	mockPaymentGateway.On("Authorize", mock.Anything, "invoice-1", invoice.AmountDue()).
+		Return(domain.GatewayResult{TransactionID: "tx_1", Status: domain.GatewayAuthorized}, nil)
+	mockPaymentGateway.On("Capture", mock.Anything, "tx_1").
+		Return(domain.GatewayResult{TransactionID: "tx_1", Status: domain.GatewayCaptured}, nil)
+	mockPaymentAttemptRepo.On("UpdatePaymentAttempt", mock.Anything, mock.Anything).
+		Return(nil)
+	mockOrderRepo.On("FindOrderById", mock.Anything, invoice.OrderID).
+		Return(domain.Order{ID: invoice.OrderID, CustomerID: 1, Status: domain.OrderCancelled}, nil).Once()
+	mockPaymentRepo.On("SavePayment", mock.Anything, mock.Anything).
+		Return(9, nil)
+	mockPaymentRepo.On("FindPaymentsByInvoiceId", mock.Anything, invoice.ID).
+		Return([]domain.Payment{{ID: 9, Amount: invoice.AmountDue()}}, nil)
+	mockInvoiceRepo.On("ChangeInvoiceStatus", mock.Anything, invoice.ID, domain.RefundPending).
+		Return(nil)
+
+	_, err := service.DoInvoicePayment(userCtx, invoice.ID, domain.PaymentCard, domain.NewMoney(44000, "USD"))
+	require.NoError(t, err)
+
+	mockInvoiceRepo.AssertExpectations(t)
+	mockOrderRepo.AssertExpectations(t)
+	mockOrderRepo.AssertNotCalled(t, "UpdateOrderStatus", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func Test_services_InvoiceService_DoInvoicePayment_SoldOutWhileProcessing(t *testing.T) {
+	mockInvoiceRepo := mockrepository.InvoiceRepository{}
+	mockOrderRepo := mockrepository.OrderRepository{}
+	mockMenuItemRepo := mockrepository.MenuItemRepository{}
+	mockRestaurantRepo := mockrepository.RestaurantRepository{}
+	mockTaxCalculator := mocktaxcalculator.TaxCalculator{}
+	mockPaymentAttemptRepo := mockrepository.PaymentAttemptRepository{}
+	mockPaymentRepo := mockrepository.PaymentRepository{}
+	mockRefundRepo := mockrepository.RefundRepository{}
+	mockPaymentGateway := mockpaymentgateway.PaymentGateway{}
+
+	service := NewInvoiceService(&mockInvoiceRepo, &mockOrderRepo, &mockMenuItemRepo, &mockTaxCalculator, &mockPaymentAttemptRepo, &mockPaymentRepo, &mockRefundRepo, &mockPaymentGateway, NewAuthorizer(&mockRestaurantRepo, &mockrepository.RestaurantMemberRepository{}))
+	service.runAsync = func(f func()) { f() }
+
+	invoice := domain.Invoice{
+		ID:            1,
+		OrderID:       1,
+		Total:         domain.NewMoney(40000, "USD"),
+		Tax:           domain.NewMoney(4000, "USD"),
+		PaymentStatus: domain.Unpaid,
+	}
+	mockInvoiceRepo.On("FindInvoiceById", mock.Anything, invoice.ID).
+		Return(invoice, nil)
+	mockInvoiceRepo.On("FindInvoicesByOrderId", mock.Anything, invoice.OrderID).
+		Return([]domain.Invoice{invoice}, nil)
+	mockOrderRepo.On("FindOrderById", mock.Anything, invoice.OrderID).
+		Return(domain.Order{ID: invoice.OrderID, CustomerID: 1, Status: domain.OrderDraft}, nil).Once()
+	mockPaymentRepo.On("FindPaymentsByInvoiceId", mock.Anything, invoice.ID).
+		Return([]domain.Payment{}, nil).Once()
+	mockPaymentGateway.On("Name").Return("fake")
+	attempt := domain.NewPaymentAttempt(invoice.ID, invoice.AmountDue(), "fake")
+	attempt.TakenBy = 1
+	mockPaymentAttemptRepo.On("SavePaymentAttempt", mock.Anything, attempt).
+		Return(7, nil)
+	mockInvoiceRepo.On("ChangeInvoiceStatus", mock.Anything, invoice.ID, domain.Processing).
+		Return(nil).Once()
+
+	userCtx := authctx.WithUserClaims(t.Context(), &authctx.UserClaims{
+		UserID: 1,
+		Role:   domain.CUSTOMER,
+	})
+
+	mockPaymentGateway.On("Authorize", mock.Anything, "invoice-1", invoice.AmountDue()).
+		Return(domain.GatewayResult{TransactionID: "tx_1", Status: domain.GatewayAuthorized}, nil)
+	mockPaymentGateway.On("Capture", mock.Anything, "tx_1").
+		Return(domain.GatewayResult{TransactionID: "tx_1", Status: domain.GatewayCaptured}, nil)
+	mockPaymentAttemptRepo.On("UpdatePaymentAttempt", mock.Anything, mock.Anything).
+		Return(nil)
+	mockOrderRepo.On("FindOrderById", mock.Anything, invoice.OrderID).
+		Return(domain.Order{ID: invoice.OrderID, CustomerID: 1, Status: domain.OrderDraft}, nil).Once()
+	// another order took the last of an item first
+	mockOrderRepo.On("UpdateOrderStatus", mock.Anything, invoice.OrderID, domain.OrderPlaced).
+		Return(apperr.NewAppError(apperr.ErrConflict, "not enough stock of Cake", nil))
+	mockOrderRepo.On("UpdateOrderStatus", mock.Anything, invoice.OrderID, domain.OrderCancelled).
+		Return(nil)
+	mockPaymentRepo.On("SavePayment", mock.Anything, mock.Anything).
+		Return(9, nil)
+	mockPaymentRepo.On("FindPaymentsByInvoiceId", mock.Anything, invoice.ID).
+		Return([]domain.Payment{{ID: 9, Amount: invoice.AmountDue()}}, nil)
+	mockInvoiceRepo.On("ChangeInvoiceStatus", mock.Anything, invoice.ID, domain.RefundPending).
 		Return(nil)
 
-	err := service.DoInvoicePayment(userCtx, invoice.ID, 440.0)
+	_, err := service.DoInvoicePayment(userCtx, invoice.ID, domain.PaymentCard, domain.NewMoney(44000, "USD"))
 	require.NoError(t, err)
 
 	mockInvoiceRepo.AssertExpectations(t)
 	mockOrderRepo.AssertExpectations(t)
+	mockInvoiceRepo.AssertNotCalled(t, "ChangeInvoiceStatus", mock.Anything, invoice.ID, domain.Paid)
+}
+
+func Test_services_InvoiceService_DoInvoicePayment_OutOfStock(t *testing.T) {
+	mockInvoiceRepo := mockrepository.InvoiceRepository{}
+	mockOrderRepo := mockrepository.OrderRepository{}
+	mockMenuItemRepo := mockrepository.MenuItemRepository{}
+	mockPaymentRepo := mockrepository.PaymentRepository{}
+	service := NewInvoiceService(&mockInvoiceRepo, &mockOrderRepo, &mockMenuItemRepo, &mocktaxcalculator.TaxCalculator{}, &mockrepository.PaymentAttemptRepository{}, &mockPaymentRepo, &mockrepository.RefundRepository{}, &mockpaymentgateway.PaymentGateway{}, NewAuthorizer(&mockrepository.RestaurantRepository{}, &mockrepository.RestaurantMemberRepository{}))
+
+	userCtx := authctx.WithUserClaims(t.Context(), &authctx.UserClaims{
+		UserID: 1,
+		Role:   domain.CUSTOMER,
+	})
+
+	mockInvoiceRepo.On("FindInvoiceById", mock.Anything, 1).
+		Return(domain.Invoice{ID: 1, OrderID: 1, Total: domain.NewMoney(1500, "USD"), Tax: domain.NewMoney(0, "USD"), PaymentStatus: domain.Unpaid}, nil)
+	mockInvoiceRepo.On("FindInvoicesByOrderId", mock.Anything, 1).
+		Return([]domain.Invoice{{ID: 1, OrderID: 1}}, nil)
+	mockOrderRepo.On("FindOrderById", mock.Anything, 1).
+		Return(domain.Order{ID: 1, CustomerID: 1, RestaurantID: 1, Status: domain.OrderDraft, OrderItems: []domain.OrderItem{
+			{ID: 1, MenuItemID: 1, Quantity: 1, Name: "Cake", UnitPrice: domain.NewMoney(500, "USD")},
+			{ID: 2, MenuItemID: 1, Quantity: 2, Name: "Cake", UnitPrice: domain.NewMoney(500, "USD")},
+		}}, nil)
+	mockMenuItemRepo.On("FindMenuItemById", mock.Anything, 1).
+		Return(domain.MenuItem{ID: 1, Name: "Cake", Price: domain.NewMoney(500, "USD"), Available: true, RestaurantID: 1, TrackStock: true, Stock: 2}, nil).Once()
+
+	_, err := service.DoInvoicePayment(userCtx, 1, domain.PaymentCash, domain.NewMoney(1500, "USD"))
+	require.True(t, apperr.IsConflictError(err), "expected conflict error but got %v", err)
+	require.EqualError(t, err, "not enough stock of Cake")
+
+	mockMenuItemRepo.AssertExpectations(t)
+	mockPaymentRepo.AssertNotCalled(t, "SavePayment", mock.Anything, mock.Anything)
+}
+
+func Test_services_InvoiceService_DoInvoicePayment_AlreadyProcessing(t *testing.T) {
+	mockInvoiceRepo := mockrepository.InvoiceRepository{}
+	mockOrderRepo := mockrepository.OrderRepository{}
+	mockMenuItemRepo := mockrepository.MenuItemRepository{}
+	mockRestaurantRepo := mockrepository.RestaurantRepository{}
+	mockTaxCalculator := mocktaxcalculator.TaxCalculator{}
+	mockPaymentAttemptRepo := mockrepository.PaymentAttemptRepository{}
+	mockPaymentRepo := mockrepository.PaymentRepository{}
+	mockRefundRepo := mockrepository.RefundRepository{}
+	mockPaymentGateway := mockpaymentgateway.PaymentGateway{}
+	service := NewInvoiceService(&mockInvoiceRepo, &mockOrderRepo, &mockMenuItemRepo, &mockTaxCalculator, &mockPaymentAttemptRepo, &mockPaymentRepo, &mockRefundRepo, &mockPaymentGateway, NewAuthorizer(&mockRestaurantRepo, &mockrepository.RestaurantMemberRepository{}))
+
+	userCtx := authctx.WithUserClaims(t.Context(), &authctx.UserClaims{
+		UserID: 1,
+		Role:   domain.CUSTOMER,
+	})
+
+	invoice := domain.Invoice{
+		ID:            1,
+		OrderID:       1,
+		Total:         domain.NewMoney(40000, "USD"),
+		Tax:           domain.NewMoney(4000, "USD"),
+		PaymentStatus: domain.Processing,
+	}
+
+	mockInvoiceRepo.On("FindInvoiceById", mock.Anything, invoice.ID).
+		Return(invoice, nil)
+	mockOrderRepo.On("FindOrderById", mock.Anything, invoice.OrderID).
+		Return(domain.Order{ID: invoice.OrderID, CustomerID: 1, Status: domain.OrderDraft}, nil)
+
+	_, err := service.DoInvoicePayment(userCtx, invoice.ID, domain.PaymentCard, domain.NewMoney(44000, "USD"))
+	require.True(t, apperr.IsConflictError(err))
+
+	mockPaymentAttemptRepo.AssertNotCalled(t, "SavePaymentAttempt", mock.Anything, mock.Anything)
+	mockPaymentGateway.AssertNotCalled(t, "Authorize", mock.Anything, mock.Anything, mock.Anything)
 }
 
 func Test_services_InvoiceService_DoInvoicePayment_Unauthenticated(t *testing.T) {
 	mockInvoiceRepo := mockrepository.InvoiceRepository{}
 	mockOrderRepo := mockrepository.OrderRepository{}
 	mockMenuItemRepo := mockrepository.MenuItemRepository{}
-	service := NewInvoiceService(&mockInvoiceRepo, &mockOrderRepo, &mockMenuItemRepo)
+	mockRestaurantRepo := mockrepository.RestaurantRepository{}
+	mockTaxCalculator := mocktaxcalculator.TaxCalculator{}
+	mockPaymentAttemptRepo := mockrepository.PaymentAttemptRepository{}
+	mockPaymentRepo := mockrepository.PaymentRepository{}
+	mockRefundRepo := mockrepository.RefundRepository{}
+	mockPaymentGateway := mockpaymentgateway.PaymentGateway{}
+	service := NewInvoiceService(&mockInvoiceRepo, &mockOrderRepo, &mockMenuItemRepo, &mockTaxCalculator, &mockPaymentAttemptRepo, &mockPaymentRepo, &mockRefundRepo, &mockPaymentGateway, NewAuthorizer(&mockRestaurantRepo, &mockrepository.RestaurantMemberRepository{}))
 
 	invoiceId := 1
-	payment := 440.0
+	payment := domain.NewMoney(44000, "USD")
 
-	err := service.DoInvoicePayment(t.Context(), invoiceId, payment)
+	_, err := service.DoInvoicePayment(t.Context(), invoiceId, domain.PaymentCard, payment)
 	appErr, ok := err.(*apperr.AppError)
 	require.Error(t, err)
 	require.True(t, ok)
@@ -270,7 +682,13 @@ func Test_services_InvoiceService_DoInvoicePayment_Forbidden(t *testing.T) {
 	mockInvoiceRepo := mockrepository.InvoiceRepository{}
 	mockOrderRepo := mockrepository.OrderRepository{}
 	mockMenuItemRepo := mockrepository.MenuItemRepository{}
-	service := NewInvoiceService(&mockInvoiceRepo, &mockOrderRepo, &mockMenuItemRepo)
+	mockRestaurantRepo := mockrepository.RestaurantRepository{}
+	mockTaxCalculator := mocktaxcalculator.TaxCalculator{}
+	mockPaymentAttemptRepo := mockrepository.PaymentAttemptRepository{}
+	mockPaymentRepo := mockrepository.PaymentRepository{}
+	mockRefundRepo := mockrepository.RefundRepository{}
+	mockPaymentGateway := mockpaymentgateway.PaymentGateway{}
+	service := NewInvoiceService(&mockInvoiceRepo, &mockOrderRepo, &mockMenuItemRepo, &mockTaxCalculator, &mockPaymentAttemptRepo, &mockPaymentRepo, &mockRefundRepo, &mockPaymentGateway, NewAuthorizer(&mockRestaurantRepo, &mockrepository.RestaurantMemberRepository{}))
 
 	userCtx := authctx.WithUserClaims(t.Context(), &authctx.UserClaims{
 		UserID: 2,
@@ -280,8 +698,8 @@ func Test_services_InvoiceService_DoInvoicePayment_Forbidden(t *testing.T) {
 	invoice := domain.Invoice{
 		ID:            1,
 		OrderID:       1,
-		Total:         400.0,
-		Tax:           40.0,
+		Total:         domain.NewMoney(40000, "USD"),
+		Tax:           domain.NewMoney(4000, "USD"),
 		PaymentStatus: domain.Unpaid,
 	}
 
@@ -290,7 +708,7 @@ func Test_services_InvoiceService_DoInvoicePayment_Forbidden(t *testing.T) {
 	mockOrderRepo.On("FindOrderById", mock.Anything, invoice.OrderID).
 		Return(domain.Order{ID: invoice.OrderID, CustomerID: 1}, nil)
 
-	err := service.DoInvoicePayment(userCtx, invoice.ID, 440.0)
+	_, err := service.DoInvoicePayment(userCtx, invoice.ID, domain.PaymentCard, domain.NewMoney(44000, "USD"))
 	appErr, ok := err.(*apperr.AppError)
 	require.Error(t, err)
 	require.True(t, ok)
@@ -300,11 +718,17 @@ func Test_services_InvoiceService_DoInvoicePayment_Forbidden(t *testing.T) {
 	mockOrderRepo.AssertExpectations(t)
 }
 
-func Test_services_InvoiceService_DoInvoicePayment_InsufficientPayment(t *testing.T) {
+func Test_services_InvoiceService_DoInvoicePayment_CardOverAmountDue(t *testing.T) {
 	mockInvoiceRepo := mockrepository.InvoiceRepository{}
 	mockOrderRepo := mockrepository.OrderRepository{}
 	mockMenuItemRepo := mockrepository.MenuItemRepository{}
-	service := NewInvoiceService(&mockInvoiceRepo, &mockOrderRepo, &mockMenuItemRepo)
+	mockRestaurantRepo := mockrepository.RestaurantRepository{}
+	mockTaxCalculator := mocktaxcalculator.TaxCalculator{}
+	mockPaymentAttemptRepo := mockrepository.PaymentAttemptRepository{}
+	mockPaymentRepo := mockrepository.PaymentRepository{}
+	mockRefundRepo := mockrepository.RefundRepository{}
+	mockPaymentGateway := mockpaymentgateway.PaymentGateway{}
+	service := NewInvoiceService(&mockInvoiceRepo, &mockOrderRepo, &mockMenuItemRepo, &mockTaxCalculator, &mockPaymentAttemptRepo, &mockPaymentRepo, &mockRefundRepo, &mockPaymentGateway, NewAuthorizer(&mockRestaurantRepo, &mockrepository.RestaurantMemberRepository{}))
 
 	userCtx := authctx.WithUserClaims(t.Context(), &authctx.UserClaims{
 		UserID: 1,
@@ -314,32 +738,43 @@ func Test_services_InvoiceService_DoInvoicePayment_InsufficientPayment(t *testin
 	invoice := domain.Invoice{
 		ID:            1,
 		OrderID:       1,
-		Total:         400.0,
-		Tax:           40.0,
+		Total:         domain.NewMoney(40000, "USD"),
+		Tax:           domain.NewMoney(4000, "USD"),
 		PaymentStatus: domain.Unpaid,
 	}
 
 	mockInvoiceRepo.On("FindInvoiceById", mock.Anything, invoice.ID).
 		Return(invoice, nil)
+	mockInvoiceRepo.On("FindInvoicesByOrderId", mock.Anything, invoice.OrderID).
+		Return([]domain.Invoice{invoice}, nil)
 	mockOrderRepo.On("FindOrderById", mock.Anything, invoice.OrderID).
-		Return(domain.Order{ID: invoice.OrderID, CustomerID: 1}, nil)
+		Return(domain.Order{ID: invoice.OrderID, CustomerID: 1, Status: domain.OrderDraft}, nil)
+	mockPaymentRepo.On("FindPaymentsByInvoiceId", mock.Anything, invoice.ID).
+		Return([]domain.Payment{}, nil)
 
-	err := service.DoInvoicePayment(userCtx, invoice.ID, 300.0)
+	_, err := service.DoInvoicePayment(userCtx, invoice.ID, domain.PaymentCard, domain.NewMoney(50000, "USD"))
 	appErr, ok := err.(*apperr.AppError)
 	require.Error(t, err)
 	require.True(t, ok)
 	require.Equal(t, apperr.ErrInvalid, appErr.Code)
-	require.Equal(t, "insufficient payment amount", appErr.Message)
+	require.Equal(t, "card payment exceeds the amount due", appErr.Message)
 
 	mockInvoiceRepo.AssertExpectations(t)
 	mockOrderRepo.AssertExpectations(t)
+	mockPaymentAttemptRepo.AssertNotCalled(t, "SavePaymentAttempt", mock.Anything, mock.Anything)
 }
 
 func Test_services_InvoiceService_DoInvoicePayment_AlreadyPaid(t *testing.T) {
 	mockInvoiceRepo := mockrepository.InvoiceRepository{}
 	mockOrderRepo := mockrepository.OrderRepository{}
 	mockMenuItemRepo := mockrepository.MenuItemRepository{}
-	service := NewInvoiceService(&mockInvoiceRepo, &mockOrderRepo, &mockMenuItemRepo)
+	mockRestaurantRepo := mockrepository.RestaurantRepository{}
+	mockTaxCalculator := mocktaxcalculator.TaxCalculator{}
+	mockPaymentAttemptRepo := mockrepository.PaymentAttemptRepository{}
+	mockPaymentRepo := mockrepository.PaymentRepository{}
+	mockRefundRepo := mockrepository.RefundRepository{}
+	mockPaymentGateway := mockpaymentgateway.PaymentGateway{}
+	service := NewInvoiceService(&mockInvoiceRepo, &mockOrderRepo, &mockMenuItemRepo, &mockTaxCalculator, &mockPaymentAttemptRepo, &mockPaymentRepo, &mockRefundRepo, &mockPaymentGateway, NewAuthorizer(&mockRestaurantRepo, &mockrepository.RestaurantMemberRepository{}))
 
 	userCtx := authctx.WithUserClaims(t.Context(), &authctx.UserClaims{
 		UserID: 1,
@@ -349,8 +784,8 @@ func Test_services_InvoiceService_DoInvoicePayment_AlreadyPaid(t *testing.T) {
 	invoice := domain.Invoice{
 		ID:            1,
 		OrderID:       1,
-		Total:         400.0,
-		Tax:           40.0,
+		Total:         domain.NewMoney(40000, "USD"),
+		Tax:           domain.NewMoney(4000, "USD"),
 		PaymentStatus: domain.Paid,
 	}
 
@@ -359,7 +794,7 @@ func Test_services_InvoiceService_DoInvoicePayment_AlreadyPaid(t *testing.T) {
 	mockOrderRepo.On("FindOrderById", mock.Anything, invoice.OrderID).
 		Return(domain.Order{ID: invoice.OrderID, CustomerID: 1}, nil)
 
-	err := service.DoInvoicePayment(userCtx, invoice.ID, 440.0)
+	_, err := service.DoInvoicePayment(userCtx, invoice.ID, domain.PaymentCard, domain.NewMoney(44000, "USD"))
 	appErr, ok := err.(*apperr.AppError)
 	require.Error(t, err)
 	require.True(t, ok)
@@ -373,14 +808,20 @@ func Test_services_InvoiceService_DoInvoicePayment_InvalidInvoiceId(t *testing.T
 	mockInvoiceRepo := mockrepository.InvoiceRepository{}
 	mockOrderRepo := mockrepository.OrderRepository{}
 	mockMenuItemRepo := mockrepository.MenuItemRepository{}
-	service := NewInvoiceService(&mockInvoiceRepo, &mockOrderRepo, &mockMenuItemRepo)
+	mockRestaurantRepo := mockrepository.RestaurantRepository{}
+	mockTaxCalculator := mocktaxcalculator.TaxCalculator{}
+	mockPaymentAttemptRepo := mockrepository.PaymentAttemptRepository{}
+	mockPaymentRepo := mockrepository.PaymentRepository{}
+	mockRefundRepo := mockrepository.RefundRepository{}
+	mockPaymentGateway := mockpaymentgateway.PaymentGateway{}
+	service := NewInvoiceService(&mockInvoiceRepo, &mockOrderRepo, &mockMenuItemRepo, &mockTaxCalculator, &mockPaymentAttemptRepo, &mockPaymentRepo, &mockRefundRepo, &mockPaymentGateway, NewAuthorizer(&mockRestaurantRepo, &mockrepository.RestaurantMemberRepository{}))
 
 	userCtx := authctx.WithUserClaims(t.Context(), &authctx.UserClaims{
 		UserID: 1,
 		Role:   domain.CUSTOMER,
 	})
 
-	err := service.DoInvoicePayment(userCtx, 0, 440.0)
+	_, err := service.DoInvoicePayment(userCtx, 0, domain.PaymentCard, domain.NewMoney(44000, "USD"))
 	appErr, ok := err.(*apperr.AppError)
 	require.Error(t, err)
 	require.True(t, ok)
@@ -390,3 +831,718 @@ func Test_services_InvoiceService_DoInvoicePayment_InvalidInvoiceId(t *testing.T
 	mockInvoiceRepo.AssertNotCalled(t, "FindInvoiceById", mock.Anything, mock.Anything)
 	mockOrderRepo.AssertNotCalled(t, "FindOrderById", mock.Anything, mock.Anything)
 }
+
+func Test_services_InvoiceService_DoInvoicePayment_OrderNotDraft(t *testing.T) {
+	mockInvoiceRepo := mockrepository.InvoiceRepository{}
+	mockOrderRepo := mockrepository.OrderRepository{}
+	mockMenuItemRepo := mockrepository.MenuItemRepository{}
+	mockRestaurantRepo := mockrepository.RestaurantRepository{}
+	mockTaxCalculator := mocktaxcalculator.TaxCalculator{}
+	mockPaymentAttemptRepo := mockrepository.PaymentAttemptRepository{}
+	mockPaymentRepo := mockrepository.PaymentRepository{}
+	mockRefundRepo := mockrepository.RefundRepository{}
+	mockPaymentGateway := mockpaymentgateway.PaymentGateway{}
+	service := NewInvoiceService(&mockInvoiceRepo, &mockOrderRepo, &mockMenuItemRepo, &mockTaxCalculator, &mockPaymentAttemptRepo, &mockPaymentRepo, &mockRefundRepo, &mockPaymentGateway, NewAuthorizer(&mockRestaurantRepo, &mockrepository.RestaurantMemberRepository{}))
+
+	userCtx := authctx.WithUserClaims(t.Context(), &authctx.UserClaims{
+		UserID: 1,
+		Role:   domain.CUSTOMER,
+	})
+
+	invoice := domain.Invoice{
+		ID:            1,
+		OrderID:       1,
+		Total:         domain.NewMoney(40000, "USD"),
+		Tax:           domain.NewMoney(4000, "USD"),
+		PaymentStatus: domain.Unpaid,
+	}
+
+	mockInvoiceRepo.On("FindInvoiceById", mock.Anything, invoice.ID).
+		Return(invoice, nil)
+	mockInvoiceRepo.On("FindInvoicesByOrderId", mock.Anything, invoice.OrderID).
+		Return([]domain.Invoice{invoice}, nil)
+	mockOrderRepo.On("FindOrderById", mock.Anything, invoice.OrderID).
+		Return(domain.Order{ID: invoice.OrderID, CustomerID: 1, Status: domain.OrderCancelled}, nil)
+
+	_, err := service.DoInvoicePayment(userCtx, invoice.ID, domain.PaymentCard, domain.NewMoney(44000, "USD"))
+	appErr, ok := err.(*apperr.AppError)
+	require.Error(t, err)
+	require.True(t, ok)
+	require.Equal(t, apperr.ErrInvalid, appErr.Code)
+
+	mockInvoiceRepo.AssertExpectations(t)
+	mockOrderRepo.AssertExpectations(t)
+	mockInvoiceRepo.AssertNotCalled(t, "ChangeInvoiceStatus", mock.Anything, mock.Anything, mock.Anything)
+	mockOrderRepo.AssertNotCalled(t, "UpdateOrderStatus", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func Test_services_InvoiceService_DoInvoicePayment_InvoiceReplaced(t *testing.T) {
+	mockInvoiceRepo := mockrepository.InvoiceRepository{}
+	mockOrderRepo := mockrepository.OrderRepository{}
+	mockMenuItemRepo := mockrepository.MenuItemRepository{}
+	mockRestaurantRepo := mockrepository.RestaurantRepository{}
+	mockTaxCalculator := mocktaxcalculator.TaxCalculator{}
+	mockPaymentAttemptRepo := mockrepository.PaymentAttemptRepository{}
+	mockPaymentRepo := mockrepository.PaymentRepository{}
+	mockRefundRepo := mockrepository.RefundRepository{}
+	mockPaymentGateway := mockpaymentgateway.PaymentGateway{}
+	service := NewInvoiceService(&mockInvoiceRepo, &mockOrderRepo, &mockMenuItemRepo, &mockTaxCalculator, &mockPaymentAttemptRepo, &mockPaymentRepo, &mockRefundRepo, &mockPaymentGateway, NewAuthorizer(&mockRestaurantRepo, &mockrepository.RestaurantMemberRepository{}))
+
+	userCtx := authctx.WithUserClaims(t.Context(), &authctx.UserClaims{
+		UserID: 1,
+		Role:   domain.CUSTOMER,
+	})
+
+	invoice := domain.Invoice{
+		ID:            1,
+		OrderID:       1,
+		Total:         domain.NewMoney(40000, "USD"),
+		Tax:           domain.NewMoney(4000, "USD"),
+		PaymentStatus: domain.Unpaid,
+	}
+
+	mockInvoiceRepo.On("FindInvoiceById", mock.Anything, invoice.ID).
+		Return(invoice, nil)
+	mockInvoiceRepo.On("FindInvoicesByOrderId", mock.Anything, invoice.OrderID).
+		Return([]domain.Invoice{invoice, {ID: 2, OrderID: invoice.OrderID, PaymentStatus: domain.Unpaid}}, nil)
+	mockOrderRepo.On("FindOrderById", mock.Anything, invoice.OrderID).
+		Return(domain.Order{ID: invoice.OrderID, CustomerID: 1, Status: domain.OrderDraft}, nil)
+
+	_, err := service.DoInvoicePayment(userCtx, invoice.ID, domain.PaymentCard, domain.NewMoney(44000, "USD"))
+	appErr, ok := err.(*apperr.AppError)
+	require.Error(t, err)
+	require.True(t, ok)
+	require.Equal(t, apperr.ErrInvalid, appErr.Code)
+
+	mockInvoiceRepo.AssertExpectations(t)
+	mockOrderRepo.AssertExpectations(t)
+	mockInvoiceRepo.AssertNotCalled(t, "ChangeInvoiceStatus", mock.Anything, mock.Anything, mock.Anything)
+	mockOrderRepo.AssertNotCalled(t, "UpdateOrderStatus", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func Test_services_InvoiceService_getInvoiceItems(t *testing.T) {
+	mockInvoiceRepo := mockrepository.InvoiceRepository{}
+	mockOrderRepo := mockrepository.OrderRepository{}
+	mockMenuItemRepo := mockrepository.MenuItemRepository{}
+	mockRestaurantRepo := mockrepository.RestaurantRepository{}
+	mockTaxCalculator := mocktaxcalculator.TaxCalculator{}
+	mockPaymentAttemptRepo := mockrepository.PaymentAttemptRepository{}
+	mockPaymentRepo := mockrepository.PaymentRepository{}
+	mockRefundRepo := mockrepository.RefundRepository{}
+	mockPaymentGateway := mockpaymentgateway.PaymentGateway{}
+	service := NewInvoiceService(&mockInvoiceRepo, &mockOrderRepo, &mockMenuItemRepo, &mockTaxCalculator, &mockPaymentAttemptRepo, &mockPaymentRepo, &mockRefundRepo, &mockPaymentGateway, NewAuthorizer(&mockRestaurantRepo, &mockrepository.RestaurantMemberRepository{}))
+
+	order := domain.Order{
+		ID:           1,
+		RestaurantID: 2,
+		OrderItems: []domain.OrderItem{
+			{MenuItemID: 1, Quantity: 2, Name: "Beer", UnitPrice: domain.NewMoney(250, "USD")},
+		},
+	}
+	menuItems := map[int]domain.MenuItem{
+		1: {ID: 1, Name: "Beer", Price: domain.NewMoney(300, "USD"), Available: true, RestaurantID: 2, Category: "alcohol"},
+	}
+
+	mockTaxCalculator.On("CalculateTax", mock.Anything, 2, "alcohol", domain.NewMoney(500, "USD")).
+		Return(domain.NewMoney(417, "USD"), domain.NewMoney(83, "USD"), nil)
+
+	items, err := service.getInvoiceItems(t.Context(), order, menuItems)
+	require.NoError(t, err)
+	require.Len(t, items, 1)
+	require.Equal(t, domain.InvoiceItem{MenuItemID: 1, Name: "Beer", UnitPrice: domain.NewMoney(250, "USD"), Quantity: 2, Total: domain.NewMoney(417, "USD"), Tax: domain.NewMoney(83, "USD")}, items[0])
+	mockTaxCalculator.AssertExpectations(t)
+}
+
+func Test_services_InvoiceService_RefundInvoice(t *testing.T) {
+	mockInvoiceRepo := mockrepository.InvoiceRepository{}
+	mockOrderRepo := mockrepository.OrderRepository{}
+	mockMenuItemRepo := mockrepository.MenuItemRepository{}
+	mockRestaurantRepo := mockrepository.RestaurantRepository{}
+	mockTaxCalculator := mocktaxcalculator.TaxCalculator{}
+	mockPaymentAttemptRepo := mockrepository.PaymentAttemptRepository{}
+	mockPaymentRepo := mockrepository.PaymentRepository{}
+	mockRefundRepo := mockrepository.RefundRepository{}
+	mockPaymentGateway := mockpaymentgateway.PaymentGateway{}
+
+	service := NewInvoiceService(&mockInvoiceRepo, &mockOrderRepo, &mockMenuItemRepo, &mockTaxCalculator, &mockPaymentAttemptRepo, &mockPaymentRepo, &mockRefundRepo, &mockPaymentGateway, NewAuthorizer(&mockRestaurantRepo, &mockrepository.RestaurantMemberRepository{}))
+
+	mockInvoiceRepo.On("FindInvoiceById", mock.Anything, 1).
+		Return(domain.Invoice{ID: 1, OrderID: 1, Total: domain.NewMoney(40000, "USD"), Tax: domain.NewMoney(4000, "USD"), PaymentStatus: domain.Paid}, nil)
+	mockOrderRepo.On("FindOrderById", mock.Anything, 1).
+		Return(domain.Order{ID: 1, CustomerID: 1, RestaurantID: 1, Status: domain.OrderPlaced}, nil)
+	mockRestaurantRepo.On("FindRestaurantById", mock.Anything, 1).
+		Return(domain.Restaurant{ID: 1, OwnerID: 2}, nil)
+	mockPaymentRepo.On("FindPaymentsByInvoiceId", mock.Anything, 1).
+		Return([]domain.Payment{
+			{ID: 7, InvoiceID: 1, Method: domain.PaymentCard, Amount: domain.NewMoney(44000, "USD"), TransactionID: "tx_1"},
+		}, nil).Maybe()
+
+	ownerCtx := authctx.WithUserClaims(t.Context(), &authctx.UserClaims{
+		UserID: 2,
+		Role:   domain.OWNER,
+	})
+
+	amount := domain.NewMoney(1000, "USD")
+	mockRefundRepo.On("FindRefundsByInvoiceId", mock.Anything, 1).
+		Return([]domain.Refund{}, nil)
+	mockPaymentGateway.On("Refund", mock.Anything, "tx_1", amount).
+		Return(domain.GatewayResult{TransactionID: "tx_1", Status: domain.GatewayCaptured}, nil)
+	mockRefundRepo.On("SaveRefund", mock.Anything, domain.Refund{InvoiceID: 1, PaymentID: 7, Amount: amount, Reason: "cold food", TransactionID: "tx_1", RefundedBy: 2}).
+		Return(3, nil)
+	mockInvoiceRepo.On("ChangeInvoiceStatus", mock.Anything, 1, domain.PartiallyRefunded).
+		Return(nil)
+
+	refund, err := service.RefundInvoice(ownerCtx, 1, amount, "  cold food ")
+	require.NoError(t, err)
+	require.Equal(t, 3, refund.ID)
+	require.Equal(t, "cold food", refund.Reason)
+
+	mockInvoiceRepo.AssertExpectations(t)
+	mockRefundRepo.AssertExpectations(t)
+	mockPaymentGateway.AssertExpectations(t)
+}
+
+func Test_services_InvoiceService_RefundInvoice_RemainingByAdmin(t *testing.T) {
+	mockInvoiceRepo := mockrepository.InvoiceRepository{}
+	mockOrderRepo := mockrepository.OrderRepository{}
+	mockMenuItemRepo := mockrepository.MenuItemRepository{}
+	mockRestaurantRepo := mockrepository.RestaurantRepository{}
+	mockTaxCalculator := mocktaxcalculator.TaxCalculator{}
+	mockPaymentAttemptRepo := mockrepository.PaymentAttemptRepository{}
+	mockPaymentRepo := mockrepository.PaymentRepository{}
+	mockRefundRepo := mockrepository.RefundRepository{}
+	mockPaymentGateway := mockpaymentgateway.PaymentGateway{}
+
+	service := NewInvoiceService(&mockInvoiceRepo, &mockOrderRepo, &mockMenuItemRepo, &mockTaxCalculator, &mockPaymentAttemptRepo, &mockPaymentRepo, &mockRefundRepo, &mockPaymentGateway, NewAuthorizer(&mockRestaurantRepo, &mockrepository.RestaurantMemberRepository{}))
+
+	mockInvoiceRepo.On("FindInvoiceById", mock.Anything, 1).
+		Return(domain.Invoice{ID: 1, OrderID: 1, Total: domain.NewMoney(40000, "USD"), Tax: domain.NewMoney(4000, "USD"), PaymentStatus: domain.PartiallyRefunded}, nil)
+	mockOrderRepo.On("FindOrderById", mock.Anything, 1).
+		Return(domain.Order{ID: 1, CustomerID: 1, RestaurantID: 1, Status: domain.OrderPlaced}, nil)
+	mockRestaurantRepo.On("FindRestaurantById", mock.Anything, 1).
+		Return(domain.Restaurant{ID: 1, OwnerID: 2}, nil)
+	mockPaymentRepo.On("FindPaymentsByInvoiceId", mock.Anything, 1).
+		Return([]domain.Payment{
+			{ID: 7, InvoiceID: 1, Method: domain.PaymentCard, Amount: domain.NewMoney(44000, "USD"), TransactionID: "tx_1"},
+		}, nil).Maybe()
+
+	adminCtx := authctx.WithUserClaims(t.Context(), &authctx.UserClaims{
+		UserID: 9,
+		Role:   domain.ADMIN,
+	})
+
+	amount := domain.NewMoney(43000, "USD")
+	mockRefundRepo.On("FindRefundsByInvoiceId", mock.Anything, 1).
+		Return([]domain.Refund{{ID: 3, InvoiceID: 1, PaymentID: 7, Amount: domain.NewMoney(1000, "USD"), TransactionID: "tx_1"}}, nil)
+	mockPaymentGateway.On("Refund", mock.Anything, "tx_1", amount).
+		Return(domain.GatewayResult{TransactionID: "tx_1", Status: domain.GatewayRefunded}, nil)
+	mockRefundRepo.On("SaveRefund", mock.Anything, mock.Anything).
+		Return(4, nil)
+	mockInvoiceRepo.On("ChangeInvoiceStatus", mock.Anything, 1, domain.Refunded).
+		Return(nil)
+
+	_, err := service.RefundInvoice(adminCtx, 1, amount, "order never arrived")
+	require.NoError(t, err)
+
+	mockInvoiceRepo.AssertExpectations(t)
+	mockRefundRepo.AssertExpectations(t)
+	mockPaymentGateway.AssertExpectations(t)
+}
+
+func Test_services_InvoiceService_RefundInvoice_ExceedsPaid(t *testing.T) {
+	mockInvoiceRepo := mockrepository.InvoiceRepository{}
+	mockOrderRepo := mockrepository.OrderRepository{}
+	mockMenuItemRepo := mockrepository.MenuItemRepository{}
+	mockRestaurantRepo := mockrepository.RestaurantRepository{}
+	mockTaxCalculator := mocktaxcalculator.TaxCalculator{}
+	mockPaymentAttemptRepo := mockrepository.PaymentAttemptRepository{}
+	mockPaymentRepo := mockrepository.PaymentRepository{}
+	mockRefundRepo := mockrepository.RefundRepository{}
+	mockPaymentGateway := mockpaymentgateway.PaymentGateway{}
+
+	service := NewInvoiceService(&mockInvoiceRepo, &mockOrderRepo, &mockMenuItemRepo, &mockTaxCalculator, &mockPaymentAttemptRepo, &mockPaymentRepo, &mockRefundRepo, &mockPaymentGateway, NewAuthorizer(&mockRestaurantRepo, &mockrepository.RestaurantMemberRepository{}))
+
+	mockInvoiceRepo.On("FindInvoiceById", mock.Anything, 1).
+		Return(domain.Invoice{ID: 1, OrderID: 1, Total: domain.NewMoney(40000, "USD"), Tax: domain.NewMoney(4000, "USD"), PaymentStatus: domain.PartiallyRefunded}, nil)
+	mockOrderRepo.On("FindOrderById", mock.Anything, 1).
+		Return(domain.Order{ID: 1, CustomerID: 1, RestaurantID: 1, Status: domain.OrderPlaced}, nil)
+	mockRestaurantRepo.On("FindRestaurantById", mock.Anything, 1).
+		Return(domain.Restaurant{ID: 1, OwnerID: 2}, nil)
+	mockPaymentRepo.On("FindPaymentsByInvoiceId", mock.Anything, 1).
+		Return([]domain.Payment{
+			{ID: 7, InvoiceID: 1, Method: domain.PaymentCard, Amount: domain.NewMoney(44000, "USD"), TransactionID: "tx_1"},
+		}, nil).Maybe()
+
+	ownerCtx := authctx.WithUserClaims(t.Context(), &authctx.UserClaims{
+		UserID: 2,
+		Role:   domain.OWNER,
+	})
+
+	mockRefundRepo.On("FindRefundsByInvoiceId", mock.Anything, 1).
+		Return([]domain.Refund{{ID: 3, InvoiceID: 1, PaymentID: 7, Amount: domain.NewMoney(1000, "USD"), TransactionID: "tx_1"}}, nil)
+
+	_, err := service.RefundInvoice(ownerCtx, 1, domain.NewMoney(43001, "USD"), "cold food")
+	require.Error(t, err)
+	require.True(t, apperr.IsInvalidError(err), "expected invalid error for a refund over the amount paid")
+
+	mockPaymentGateway.AssertNotCalled(t, "Refund", mock.Anything, mock.Anything, mock.Anything)
+	mockRefundRepo.AssertNotCalled(t, "SaveRefund", mock.Anything, mock.Anything)
+	mockInvoiceRepo.AssertNotCalled(t, "ChangeInvoiceStatus", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func Test_services_InvoiceService_RefundInvoice_GatewayError(t *testing.T) {
+	mockInvoiceRepo := mockrepository.InvoiceRepository{}
+	mockOrderRepo := mockrepository.OrderRepository{}
+	mockMenuItemRepo := mockrepository.MenuItemRepository{}
+	mockRestaurantRepo := mockrepository.RestaurantRepository{}
+	mockTaxCalculator := mocktaxcalculator.TaxCalculator{}
+	mockPaymentAttemptRepo := mockrepository.PaymentAttemptRepository{}
+	mockPaymentRepo := mockrepository.PaymentRepository{}
+	mockRefundRepo := mockrepository.RefundRepository{}
+	mockPaymentGateway := mockpaymentgateway.PaymentGateway{}
+
+	service := NewInvoiceService(&mockInvoiceRepo, &mockOrderRepo, &mockMenuItemRepo, &mockTaxCalculator, &mockPaymentAttemptRepo, &mockPaymentRepo, &mockRefundRepo, &mockPaymentGateway, NewAuthorizer(&mockRestaurantRepo, &mockrepository.RestaurantMemberRepository{}))
+
+	mockInvoiceRepo.On("FindInvoiceById", mock.Anything, 1).
+		Return(domain.Invoice{ID: 1, OrderID: 1, Total: domain.NewMoney(40000, "USD"), Tax: domain.NewMoney(4000, "USD"), PaymentStatus: domain.Paid}, nil)
+	mockOrderRepo.On("FindOrderById", mock.Anything, 1).
+		Return(domain.Order{ID: 1, CustomerID: 1, RestaurantID: 1, Status: domain.OrderPlaced}, nil)
+	mockRestaurantRepo.On("FindRestaurantById", mock.Anything, 1).
+		Return(domain.Restaurant{ID: 1, OwnerID: 2}, nil)
+	mockPaymentRepo.On("FindPaymentsByInvoiceId", mock.Anything, 1).
+		Return([]domain.Payment{
+			{ID: 7, InvoiceID: 1, Method: domain.PaymentCard, Amount: domain.NewMoney(44000, "USD"), TransactionID: "tx_1"},
+		}, nil).Maybe()
+
+	ownerCtx := authctx.WithUserClaims(t.Context(), &authctx.UserClaims{
+		UserID: 2,
+		Role:   domain.OWNER,
+	})
+
+	amount := domain.NewMoney(1000, "USD")
+	mockRefundRepo.On("FindRefundsByInvoiceId", mock.Anything, 1).
+		Return([]domain.Refund{}, nil)
+	mockPaymentGateway.On("Refund", mock.Anything, "tx_1", amount).
+		Return(domain.GatewayResult{}, apperr.NewAppError(apperr.ErrInternal, "provider unavailable", nil))
+
+	_, err := service.RefundInvoice(ownerCtx, 1, amount, "cold food")
+	require.Error(t, err)
+
+	mockRefundRepo.AssertNotCalled(t, "SaveRefund", mock.Anything, mock.Anything)
+	mockInvoiceRepo.AssertNotCalled(t, "ChangeInvoiceStatus", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func Test_services_InvoiceService_RefundInvoice_Rejected(t *testing.T) {
+	tests := []struct {
+		name    string
+		claims  *authctx.UserClaims
+		status  domain.PaymentStatus
+		amount  domain.Money
+		reason  string
+		checkFn func(error) bool
+	}{
+		{"customer", &authctx.UserClaims{UserID: 1, Role: domain.CUSTOMER}, domain.Paid, domain.NewMoney(1000, "USD"), "cold food", apperr.IsForbiddenError},
+		{"other owner", &authctx.UserClaims{UserID: 5, Role: domain.OWNER}, domain.Paid, domain.NewMoney(1000, "USD"), "cold food", apperr.IsForbiddenError},
+		{"missing reason", &authctx.UserClaims{UserID: 2, Role: domain.OWNER}, domain.Paid, domain.NewMoney(1000, "USD"), " ", apperr.IsInvalidError},
+		{"zero amount", &authctx.UserClaims{UserID: 2, Role: domain.OWNER}, domain.Paid, domain.NewMoney(0, "USD"), "cold food", apperr.IsInvalidError},
+		{"unpaid invoice", &authctx.UserClaims{UserID: 2, Role: domain.OWNER}, domain.Unpaid, domain.NewMoney(1000, "USD"), "cold food", apperr.IsInvalidError},
+		{"fully refunded invoice", &authctx.UserClaims{UserID: 2, Role: domain.OWNER}, domain.Refunded, domain.NewMoney(1000, "USD"), "cold food", apperr.IsInvalidError},
+		{"other currency", &authctx.UserClaims{UserID: 2, Role: domain.OWNER}, domain.Paid, domain.NewMoney(1000, "EUR"), "cold food", apperr.IsInvalidError},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockInvoiceRepo := mockrepository.InvoiceRepository{}
+			mockOrderRepo := mockrepository.OrderRepository{}
+			mockMenuItemRepo := mockrepository.MenuItemRepository{}
+			mockRestaurantRepo := mockrepository.RestaurantRepository{}
+			mockTaxCalculator := mocktaxcalculator.TaxCalculator{}
+			mockPaymentAttemptRepo := mockrepository.PaymentAttemptRepository{}
+			mockPaymentRepo := mockrepository.PaymentRepository{}
+			mockRefundRepo := mockrepository.RefundRepository{}
+			mockPaymentGateway := mockpaymentgateway.PaymentGateway{}
+
+			service := NewInvoiceService(&mockInvoiceRepo, &mockOrderRepo, &mockMenuItemRepo, &mockTaxCalculator, &mockPaymentAttemptRepo, &mockPaymentRepo, &mockRefundRepo, &mockPaymentGateway, NewAuthorizer(&mockRestaurantRepo, &mockrepository.RestaurantMemberRepository{}))
+
+			mockInvoiceRepo.On("FindInvoiceById", mock.Anything, 1).
+				Return(domain.Invoice{ID: 1, OrderID: 1, Total: domain.NewMoney(40000, "USD"), Tax: domain.NewMoney(4000, "USD"), PaymentStatus: tt.status}, nil)
+			mockOrderRepo.On("FindOrderById", mock.Anything, 1).
+				Return(domain.Order{ID: 1, CustomerID: 1, RestaurantID: 1, Status: domain.OrderPlaced}, nil)
+			mockRestaurantRepo.On("FindRestaurantById", mock.Anything, 1).
+				Return(domain.Restaurant{ID: 1, OwnerID: 2}, nil)
+			mockPaymentRepo.On("FindPaymentsByInvoiceId", mock.Anything, 1).
+				Return([]domain.Payment{
+					{ID: 7, InvoiceID: 1, Method: domain.PaymentCard, Amount: domain.NewMoney(44000, "USD"), TransactionID: "tx_1"},
+				}, nil).Maybe()
+
+			ctx := authctx.WithUserClaims(t.Context(), tt.claims)
+
+			_, err := service.RefundInvoice(ctx, 1, tt.amount, tt.reason)
+			require.Error(t, err)
+			require.True(t, tt.checkFn(err), "unexpected error %v", err)
+
+			mockPaymentGateway.AssertNotCalled(t, "Refund", mock.Anything, mock.Anything, mock.Anything)
+			mockRefundRepo.AssertNotCalled(t, "SaveRefund", mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func Test_services_InvoiceService_RefundInvoice_Unauthenticated(t *testing.T) {
+	mockInvoiceRepo := mockrepository.InvoiceRepository{}
+	mockOrderRepo := mockrepository.OrderRepository{}
+	mockMenuItemRepo := mockrepository.MenuItemRepository{}
+	mockRestaurantRepo := mockrepository.RestaurantRepository{}
+	mockTaxCalculator := mocktaxcalculator.TaxCalculator{}
+	mockPaymentAttemptRepo := mockrepository.PaymentAttemptRepository{}
+	mockPaymentRepo := mockrepository.PaymentRepository{}
+	mockRefundRepo := mockrepository.RefundRepository{}
+	mockPaymentGateway := mockpaymentgateway.PaymentGateway{}
+
+	service := NewInvoiceService(&mockInvoiceRepo, &mockOrderRepo, &mockMenuItemRepo, &mockTaxCalculator, &mockPaymentAttemptRepo, &mockPaymentRepo, &mockRefundRepo, &mockPaymentGateway, NewAuthorizer(&mockRestaurantRepo, &mockrepository.RestaurantMemberRepository{}))
+
+	mockInvoiceRepo.On("FindInvoiceById", mock.Anything, 1).
+		Return(domain.Invoice{ID: 1, OrderID: 1, Total: domain.NewMoney(40000, "USD"), Tax: domain.NewMoney(4000, "USD"), PaymentStatus: domain.Paid}, nil)
+	mockOrderRepo.On("FindOrderById", mock.Anything, 1).
+		Return(domain.Order{ID: 1, CustomerID: 1, RestaurantID: 1, Status: domain.OrderPlaced}, nil)
+	mockRestaurantRepo.On("FindRestaurantById", mock.Anything, 1).
+		Return(domain.Restaurant{ID: 1, OwnerID: 2}, nil)
+	mockPaymentRepo.On("FindPaymentsByInvoiceId", mock.Anything, 1).
+		Return([]domain.Payment{
+			{ID: 7, InvoiceID: 1, Method: domain.PaymentCard, Amount: domain.NewMoney(44000, "USD"), TransactionID: "tx_1"},
+		}, nil).Maybe()
+
+	_, err := service.RefundInvoice(t.Context(), 1, domain.NewMoney(1000, "USD"), "cold food")
+	require.Error(t, err)
+	require.True(t, apperr.IsUnauthorizedError(err), "expected unauthorized error")
+}
+
+func Test_services_InvoiceService_DoInvoicePayment_PartialCash(t *testing.T) {
+	mockInvoiceRepo := mockrepository.InvoiceRepository{}
+	mockOrderRepo := mockrepository.OrderRepository{}
+	mockMenuItemRepo := mockrepository.MenuItemRepository{}
+	mockRestaurantRepo := mockrepository.RestaurantRepository{}
+	mockTaxCalculator := mocktaxcalculator.TaxCalculator{}
+	mockPaymentAttemptRepo := mockrepository.PaymentAttemptRepository{}
+	mockPaymentRepo := mockrepository.PaymentRepository{}
+	mockRefundRepo := mockrepository.RefundRepository{}
+	mockPaymentGateway := mockpaymentgateway.PaymentGateway{}
+
+	service := NewInvoiceService(&mockInvoiceRepo, &mockOrderRepo, &mockMenuItemRepo, &mockTaxCalculator, &mockPaymentAttemptRepo, &mockPaymentRepo, &mockRefundRepo, &mockPaymentGateway, NewAuthorizer(&mockRestaurantRepo, &mockrepository.RestaurantMemberRepository{}))
+
+	mockInvoiceRepo.On("FindInvoiceById", mock.Anything, 1).
+		Return(domain.Invoice{ID: 1, OrderID: 1, Total: domain.NewMoney(40000, "USD"), Tax: domain.NewMoney(4000, "USD"), PaymentStatus: domain.Unpaid}, nil)
+	mockInvoiceRepo.On("FindInvoicesByOrderId", mock.Anything, 1).
+		Return([]domain.Invoice{{ID: 1, OrderID: 1}}, nil)
+	mockOrderRepo.On("FindOrderById", mock.Anything, 1).
+		Return(domain.Order{ID: 1, CustomerID: 1, Status: domain.OrderDraft}, nil)
+	mockPaymentRepo.On("FindPaymentsByInvoiceId", mock.Anything, 1).
+		Return([]domain.Payment{}, nil).Once()
+
+	userCtx := authctx.WithUserClaims(t.Context(), &authctx.UserClaims{
+		UserID: 1,
+		Role:   domain.CUSTOMER,
+	})
+
+	payment := domain.Payment{InvoiceID: 1, PayerID: 1, TakenBy: 1, Method: domain.PaymentCash, Amount: domain.NewMoney(30000, "USD"), Tendered: domain.NewMoney(30000, "USD"), Change: domain.NewMoney(0, "USD")}
+	mockPaymentRepo.On("SavePayment", mock.Anything, payment).
+		Return(9, nil)
+	mockPaymentRepo.On("FindPaymentsByInvoiceId", mock.Anything, 1).
+		Return([]domain.Payment{payment}, nil)
+	mockInvoiceRepo.On("ChangeInvoiceStatus", mock.Anything, 1, domain.PartiallyPaid).
+		Return(nil)
+
+	receipt, err := service.DoInvoicePayment(userCtx, 1, domain.PaymentCash, domain.NewMoney(30000, "USD"))
+	require.NoError(t, err)
+	require.Equal(t, 9, receipt.Payment.ID)
+	require.Equal(t, domain.NewMoney(14000, "USD"), receipt.AmountDue)
+	require.Equal(t, domain.PartiallyPaid, receipt.InvoiceStatus)
+
+	mockPaymentRepo.AssertExpectations(t)
+	mockInvoiceRepo.AssertExpectations(t)
+	mockOrderRepo.AssertNotCalled(t, "UpdateOrderStatus", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func Test_services_InvoiceService_DoInvoicePayment_CashWithChange(t *testing.T) {
+	earlier := domain.Payment{ID: 8, InvoiceID: 1, PayerID: 1, Method: domain.PaymentCard, Amount: domain.NewMoney(30000, "USD"), TransactionID: "tx_1"}
+
+	mockInvoiceRepo := mockrepository.InvoiceRepository{}
+	mockOrderRepo := mockrepository.OrderRepository{}
+	mockMenuItemRepo := mockrepository.MenuItemRepository{}
+	mockRestaurantRepo := mockrepository.RestaurantRepository{}
+	mockTaxCalculator := mocktaxcalculator.TaxCalculator{}
+	mockPaymentAttemptRepo := mockrepository.PaymentAttemptRepository{}
+	mockPaymentRepo := mockrepository.PaymentRepository{}
+	mockRefundRepo := mockrepository.RefundRepository{}
+	mockPaymentGateway := mockpaymentgateway.PaymentGateway{}
+
+	service := NewInvoiceService(&mockInvoiceRepo, &mockOrderRepo, &mockMenuItemRepo, &mockTaxCalculator, &mockPaymentAttemptRepo, &mockPaymentRepo, &mockRefundRepo, &mockPaymentGateway, NewAuthorizer(&mockRestaurantRepo, &mockrepository.RestaurantMemberRepository{}))
+
+	mockInvoiceRepo.On("FindInvoiceById", mock.Anything, 1).
+		Return(domain.Invoice{ID: 1, OrderID: 1, Total: domain.NewMoney(40000, "USD"), Tax: domain.NewMoney(4000, "USD"), PaymentStatus: domain.PartiallyPaid}, nil)
+	mockInvoiceRepo.On("FindInvoicesByOrderId", mock.Anything, 1).
+		Return([]domain.Invoice{{ID: 1, OrderID: 1}}, nil)
+	mockOrderRepo.On("FindOrderById", mock.Anything, 1).
+		Return(domain.Order{ID: 1, CustomerID: 1, Status: domain.OrderDraft}, nil)
+	mockPaymentRepo.On("FindPaymentsByInvoiceId", mock.Anything, 1).
+		Return([]domain.Payment{earlier}, nil).Once()
+
+	userCtx := authctx.WithUserClaims(t.Context(), &authctx.UserClaims{
+		UserID: 1,
+		Role:   domain.CUSTOMER,
+	})
+
+	payment := domain.Payment{InvoiceID: 1, PayerID: 1, TakenBy: 1, Method: domain.PaymentCash, Amount: domain.NewMoney(14000, "USD"), Tendered: domain.NewMoney(20000, "USD"), Change: domain.NewMoney(6000, "USD")}
+	mockPaymentRepo.On("SavePayment", mock.Anything, payment).
+		Return(9, nil)
+	mockPaymentRepo.On("FindPaymentsByInvoiceId", mock.Anything, 1).
+		Return([]domain.Payment{earlier, payment}, nil)
+	mockOrderRepo.On("UpdateOrderStatus", mock.Anything, 1, domain.OrderPlaced).
+		Return(nil)
+	mockInvoiceRepo.On("ChangeInvoiceStatus", mock.Anything, 1, domain.Paid).
+		Return(nil)
+
+	receipt, err := service.DoInvoicePayment(userCtx, 1, domain.PaymentCash, domain.NewMoney(20000, "USD"))
+	require.NoError(t, err)
+	require.Equal(t, domain.NewMoney(6000, "USD"), receipt.Payment.Change)
+	require.Equal(t, domain.NewMoney(0, "USD"), receipt.AmountDue)
+	require.Equal(t, domain.Paid, receipt.InvoiceStatus)
+
+	mockPaymentRepo.AssertExpectations(t)
+	mockInvoiceRepo.AssertExpectations(t)
+	mockOrderRepo.AssertExpectations(t)
+}
+
+func Test_services_InvoiceService_DoInvoicePayment_Cashier(t *testing.T) {
+	mockInvoiceRepo := mockrepository.InvoiceRepository{}
+	mockOrderRepo := mockrepository.OrderRepository{}
+	mockMenuItemRepo := mockrepository.MenuItemRepository{}
+	mockRestaurantRepo := mockrepository.RestaurantRepository{}
+	mockMemberRepo := mockrepository.RestaurantMemberRepository{}
+	mockTaxCalculator := mocktaxcalculator.TaxCalculator{}
+	mockPaymentAttemptRepo := mockrepository.PaymentAttemptRepository{}
+	mockPaymentRepo := mockrepository.PaymentRepository{}
+	mockRefundRepo := mockrepository.RefundRepository{}
+	mockPaymentGateway := mockpaymentgateway.PaymentGateway{}
+	service := NewInvoiceService(&mockInvoiceRepo, &mockOrderRepo, &mockMenuItemRepo, &mockTaxCalculator, &mockPaymentAttemptRepo, &mockPaymentRepo, &mockRefundRepo, &mockPaymentGateway, NewAuthorizer(&mockRestaurantRepo, &mockMemberRepo))
+
+	cashierCtx := authctx.WithUserClaims(t.Context(), &authctx.UserClaims{
+		UserID: 9,
+		Role:   domain.STAFF,
+	})
+
+	mockInvoiceRepo.On("FindInvoiceById", mock.Anything, 1).
+		Return(domain.Invoice{ID: 1, OrderID: 1, Total: domain.NewMoney(40000, "USD"), Tax: domain.NewMoney(4000, "USD"), PaymentStatus: domain.Unpaid}, nil)
+	mockInvoiceRepo.On("FindInvoicesByOrderId", mock.Anything, 1).
+		Return([]domain.Invoice{{ID: 1, OrderID: 1}}, nil)
+	mockOrderRepo.On("FindOrderById", mock.Anything, 1).
+		Return(domain.Order{ID: 1, CustomerID: 1, RestaurantID: 3, Status: domain.OrderDraft}, nil)
+	mockMemberRepo.On("FindRestaurantMember", mock.Anything, 3, 9).
+		Return(domain.NewRestaurantMember(3, 9, domain.StaffCashier), nil)
+	mockPaymentRepo.On("FindPaymentsByInvoiceId", mock.Anything, 1).
+		Return([]domain.Payment{}, nil).Once()
+
+	// the payment is recorded for the customer of the order, taken by the cashier
+	payment := domain.Payment{InvoiceID: 1, PayerID: 1, TakenBy: 9, Method: domain.PaymentCash, Amount: domain.NewMoney(44000, "USD"), Tendered: domain.NewMoney(44000, "USD"), Change: domain.NewMoney(0, "USD")}
+	mockPaymentRepo.On("SavePayment", mock.Anything, payment).
+		Return(9, nil)
+	mockPaymentRepo.On("FindPaymentsByInvoiceId", mock.Anything, 1).
+		Return([]domain.Payment{payment}, nil)
+	mockOrderRepo.On("UpdateOrderStatus", mock.Anything, 1, domain.OrderPlaced).
+		Return(nil)
+	mockInvoiceRepo.On("ChangeInvoiceStatus", mock.Anything, 1, domain.Paid).
+		Return(nil)
+
+	receipt, err := service.DoInvoicePayment(cashierCtx, 1, domain.PaymentCash, domain.NewMoney(44000, "USD"))
+	require.NoError(t, err)
+	require.Equal(t, domain.Paid, receipt.InvoiceStatus)
+
+	mockMemberRepo.AssertExpectations(t)
+	mockPaymentRepo.AssertExpectations(t)
+	mockInvoiceRepo.AssertExpectations(t)
+	mockOrderRepo.AssertExpectations(t)
+}
+
+func Test_services_InvoiceService_DoInvoicePayment_InvalidTender(t *testing.T) {
+	mockInvoiceRepo := mockrepository.InvoiceRepository{}
+	mockOrderRepo := mockrepository.OrderRepository{}
+	mockMenuItemRepo := mockrepository.MenuItemRepository{}
+	mockRestaurantRepo := mockrepository.RestaurantRepository{}
+	mockTaxCalculator := mocktaxcalculator.TaxCalculator{}
+	mockPaymentAttemptRepo := mockrepository.PaymentAttemptRepository{}
+	mockPaymentRepo := mockrepository.PaymentRepository{}
+	mockRefundRepo := mockrepository.RefundRepository{}
+	mockPaymentGateway := mockpaymentgateway.PaymentGateway{}
+
+	service := NewInvoiceService(&mockInvoiceRepo, &mockOrderRepo, &mockMenuItemRepo, &mockTaxCalculator, &mockPaymentAttemptRepo, &mockPaymentRepo, &mockRefundRepo, &mockPaymentGateway, NewAuthorizer(&mockRestaurantRepo, &mockrepository.RestaurantMemberRepository{}))
+
+	mockInvoiceRepo.On("FindInvoiceById", mock.Anything, 1).
+		Return(domain.Invoice{ID: 1, OrderID: 1, Total: domain.NewMoney(40000, "USD"), Tax: domain.NewMoney(4000, "USD"), PaymentStatus: domain.Unpaid}, nil)
+	mockInvoiceRepo.On("FindInvoicesByOrderId", mock.Anything, 1).
+		Return([]domain.Invoice{{ID: 1, OrderID: 1}}, nil)
+	mockOrderRepo.On("FindOrderById", mock.Anything, 1).
+		Return(domain.Order{ID: 1, CustomerID: 1, Status: domain.OrderDraft}, nil)
+	mockPaymentRepo.On("FindPaymentsByInvoiceId", mock.Anything, 1).
+		Return([]domain.Payment{}, nil).Once()
+
+	userCtx := authctx.WithUserClaims(t.Context(), &authctx.UserClaims{
+		UserID: 1,
+		Role:   domain.CUSTOMER,
+	})
+
+	_, err := service.DoInvoicePayment(userCtx, 1, domain.PaymentCash, domain.NewMoney(0, "USD"))
+	require.True(t, apperr.IsInvalidError(err), "expected invalid error for a zero payment")
+
+	_, err = service.DoInvoicePayment(userCtx, 1, "cheque", domain.NewMoney(1000, "USD"))
+	require.True(t, apperr.IsInvalidError(err), "expected invalid error for an unknown method")
+
+	_, err = service.DoInvoicePayment(userCtx, 1, domain.PaymentCash, domain.NewMoney(1000, "EUR"))
+	require.True(t, apperr.IsInvalidError(err), "expected invalid error for another currency")
+
+	mockPaymentRepo.AssertNotCalled(t, "SavePayment", mock.Anything, mock.Anything)
+}
+
+func Test_services_InvoiceService_RefundInvoice_Cash(t *testing.T) {
+	mockInvoiceRepo := mockrepository.InvoiceRepository{}
+	mockOrderRepo := mockrepository.OrderRepository{}
+	mockMenuItemRepo := mockrepository.MenuItemRepository{}
+	mockRestaurantRepo := mockrepository.RestaurantRepository{}
+	mockTaxCalculator := mocktaxcalculator.TaxCalculator{}
+	mockPaymentAttemptRepo := mockrepository.PaymentAttemptRepository{}
+	mockPaymentRepo := mockrepository.PaymentRepository{}
+	mockRefundRepo := mockrepository.RefundRepository{}
+	mockPaymentGateway := mockpaymentgateway.PaymentGateway{}
+	service := NewInvoiceService(&mockInvoiceRepo, &mockOrderRepo, &mockMenuItemRepo, &mockTaxCalculator, &mockPaymentAttemptRepo, &mockPaymentRepo, &mockRefundRepo, &mockPaymentGateway, NewAuthorizer(&mockRestaurantRepo, &mockrepository.RestaurantMemberRepository{}))
+
+	adminCtx := authctx.WithUserClaims(t.Context(), &authctx.UserClaims{
+		UserID: 9,
+		Role:   domain.ADMIN,
+	})
+
+	mockInvoiceRepo.On("FindInvoiceById", mock.Anything, 1).
+		Return(domain.Invoice{ID: 1, OrderID: 1, Total: domain.NewMoney(40000, "USD"), Tax: domain.NewMoney(4000, "USD"), PaymentStatus: domain.Paid}, nil)
+	mockOrderRepo.On("FindOrderById", mock.Anything, 1).
+		Return(domain.Order{ID: 1, CustomerID: 1, RestaurantID: 1}, nil)
+	// split tender, the card payment has already been refunded in full
+	mockPaymentRepo.On("FindPaymentsByInvoiceId", mock.Anything, 1).
+		Return([]domain.Payment{
+			{ID: 7, InvoiceID: 1, Method: domain.PaymentCard, Amount: domain.NewMoney(30000, "USD"), TransactionID: "tx_1"},
+			{ID: 8, InvoiceID: 1, Method: domain.PaymentCash, Amount: domain.NewMoney(14000, "USD")},
+		}, nil)
+	mockRefundRepo.On("FindRefundsByInvoiceId", mock.Anything, 1).
+		Return([]domain.Refund{{ID: 3, PaymentID: 7, Amount: domain.NewMoney(30000, "USD"), TransactionID: "tx_1"}}, nil)
+	mockRefundRepo.On("SaveRefund", mock.Anything, domain.Refund{InvoiceID: 1, PaymentID: 8, Amount: domain.NewMoney(14000, "USD"), Reason: "order never arrived", RefundedBy: 9}).
+		Return(4, nil)
+	mockInvoiceRepo.On("ChangeInvoiceStatus", mock.Anything, 1, domain.Refunded).
+		Return(nil)
+
+	_, err := service.RefundInvoice(adminCtx, 1, domain.NewMoney(14000, "USD"), "order never arrived")
+	require.NoError(t, err)
+
+	mockRefundRepo.AssertExpectations(t)
+	mockInvoiceRepo.AssertExpectations(t)
+	mockPaymentGateway.AssertNotCalled(t, "Refund", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func Test_services_InvoiceService_GetInvoiceById_Admin(t *testing.T) {
+	mockInvoiceRepo := mockrepository.InvoiceRepository{}
+	mockOrderRepo := mockrepository.OrderRepository{}
+	mockMenuItemRepo := mockrepository.MenuItemRepository{}
+	mockRestaurantRepo := mockrepository.RestaurantRepository{}
+	mockTaxCalculator := mocktaxcalculator.TaxCalculator{}
+	mockPaymentAttemptRepo := mockrepository.PaymentAttemptRepository{}
+	mockPaymentRepo := mockrepository.PaymentRepository{}
+	mockRefundRepo := mockrepository.RefundRepository{}
+	mockPaymentGateway := mockpaymentgateway.PaymentGateway{}
+	service := NewInvoiceService(&mockInvoiceRepo, &mockOrderRepo, &mockMenuItemRepo, &mockTaxCalculator, &mockPaymentAttemptRepo, &mockPaymentRepo, &mockRefundRepo, &mockPaymentGateway, NewAuthorizer(&mockRestaurantRepo, &mockrepository.RestaurantMemberRepository{}))
+
+	adminCtx := authctx.WithUserClaims(t.Context(), &authctx.UserClaims{
+		UserID: 9,
+		Role:   domain.ADMIN,
+	})
+
+	invoice := domain.Invoice{ID: 1, OrderID: 1, PaymentStatus: domain.Paid}
+	mockInvoiceRepo.On("FindInvoiceById", mock.Anything, invoice.ID).Return(invoice, nil)
+	mockOrderRepo.On("FindOrderById", mock.Anything, invoice.OrderID).Return(domain.Order{ID: 1, CustomerID: 1}, nil)
+
+	result, err := service.GetInvoiceById(adminCtx, invoice.ID)
+	require.NoError(t, err)
+	require.Equal(t, invoice, result)
+	mockRestaurantRepo.AssertNotCalled(t, "FindRestaurantById", mock.Anything, mock.Anything)
+}
+
+func Test_services_InvoiceService_GetInvoiceById_RestaurantOwner(t *testing.T) {
+	mockInvoiceRepo := mockrepository.InvoiceRepository{}
+	mockOrderRepo := mockrepository.OrderRepository{}
+	mockMenuItemRepo := mockrepository.MenuItemRepository{}
+	mockRestaurantRepo := mockrepository.RestaurantRepository{}
+	mockTaxCalculator := mocktaxcalculator.TaxCalculator{}
+	mockPaymentAttemptRepo := mockrepository.PaymentAttemptRepository{}
+	mockPaymentRepo := mockrepository.PaymentRepository{}
+	mockRefundRepo := mockrepository.RefundRepository{}
+	mockPaymentGateway := mockpaymentgateway.PaymentGateway{}
+	service := NewInvoiceService(&mockInvoiceRepo, &mockOrderRepo, &mockMenuItemRepo, &mockTaxCalculator, &mockPaymentAttemptRepo, &mockPaymentRepo, &mockRefundRepo, &mockPaymentGateway, NewAuthorizer(&mockRestaurantRepo, &mockrepository.RestaurantMemberRepository{}))
+
+	invoice := domain.Invoice{ID: 1, OrderID: 1, PaymentStatus: domain.Paid}
+	mockInvoiceRepo.On("FindInvoiceById", mock.Anything, invoice.ID).Return(invoice, nil)
+	mockOrderRepo.On("FindOrderById", mock.Anything, invoice.OrderID).Return(domain.Order{ID: 1, CustomerID: 1, RestaurantID: 2}, nil)
+	mockRestaurantRepo.On("FindRestaurantById", mock.Anything, 2).Return(domain.Restaurant{ID: 2, OwnerID: 5}, nil)
+
+	ownerCtx := authctx.WithUserClaims(t.Context(), &authctx.UserClaims{UserID: 5, Role: domain.OWNER})
+	result, err := service.GetInvoiceById(ownerCtx, invoice.ID)
+	require.NoError(t, err)
+	require.Equal(t, invoice, result)
+
+	otherOwnerCtx := authctx.WithUserClaims(t.Context(), &authctx.UserClaims{UserID: 6, Role: domain.OWNER})
+	_, err = service.GetInvoiceById(otherOwnerCtx, invoice.ID)
+	require.True(t, apperr.IsForbiddenError(err), "expected forbidden error but got %v", err)
+}
+
+func Test_services_InvoiceService_GetInvoices(t *testing.T) {
+	mockInvoiceRepo := mockrepository.InvoiceRepository{}
+	mockOrderRepo := mockrepository.OrderRepository{}
+	mockMenuItemRepo := mockrepository.MenuItemRepository{}
+	mockRestaurantRepo := mockrepository.RestaurantRepository{}
+	mockTaxCalculator := mocktaxcalculator.TaxCalculator{}
+	mockPaymentAttemptRepo := mockrepository.PaymentAttemptRepository{}
+	mockPaymentRepo := mockrepository.PaymentRepository{}
+	mockRefundRepo := mockrepository.RefundRepository{}
+	mockPaymentGateway := mockpaymentgateway.PaymentGateway{}
+	service := NewInvoiceService(&mockInvoiceRepo, &mockOrderRepo, &mockMenuItemRepo, &mockTaxCalculator, &mockPaymentAttemptRepo, &mockPaymentRepo, &mockRefundRepo, &mockPaymentGateway, NewAuthorizer(&mockRestaurantRepo, &mockrepository.RestaurantMemberRepository{}))
+
+	adminCtx := authctx.WithUserClaims(t.Context(), &authctx.UserClaims{
+		UserID: 9,
+		Role:   domain.ADMIN,
+	})
+
+	filter := domain.InvoiceFilter{Statuses: []domain.PaymentStatus{domain.RefundPending}}
+	mockInvoiceRepo.On("FindInvoices", mock.Anything, domain.InvoiceFilter{Statuses: filter.Statuses, Limit: defaultInvoicesPageSize + 1}).
+		Return([]domain.Invoice{{ID: 4, OrderID: 2, PaymentStatus: domain.RefundPending}}, nil)
+
+	invoices, nextCursor, err := service.GetInvoices(adminCtx, filter)
+	require.NoError(t, err)
+	require.Len(t, invoices, 1)
+	require.Equal(t, 0, nextCursor)
+	mockInvoiceRepo.AssertExpectations(t)
+}
+
+func Test_services_InvoiceService_GetInvoices_Forbidden(t *testing.T) {
+	mockInvoiceRepo := mockrepository.InvoiceRepository{}
+	mockOrderRepo := mockrepository.OrderRepository{}
+	mockMenuItemRepo := mockrepository.MenuItemRepository{}
+	mockRestaurantRepo := mockrepository.RestaurantRepository{}
+	mockTaxCalculator := mocktaxcalculator.TaxCalculator{}
+	mockPaymentAttemptRepo := mockrepository.PaymentAttemptRepository{}
+	mockPaymentRepo := mockrepository.PaymentRepository{}
+	mockRefundRepo := mockrepository.RefundRepository{}
+	mockPaymentGateway := mockpaymentgateway.PaymentGateway{}
+	service := NewInvoiceService(&mockInvoiceRepo, &mockOrderRepo, &mockMenuItemRepo, &mockTaxCalculator, &mockPaymentAttemptRepo, &mockPaymentRepo, &mockRefundRepo, &mockPaymentGateway, NewAuthorizer(&mockRestaurantRepo, &mockrepository.RestaurantMemberRepository{}))
+
+	userCtx := authctx.WithUserClaims(t.Context(), &authctx.UserClaims{
+		UserID: 1,
+		Role:   domain.CUSTOMER,
+	})
+
+	invoices, _, err := service.GetInvoices(userCtx, domain.InvoiceFilter{})
+	require.True(t, apperr.IsForbiddenError(err))
+	require.Nil(t, invoices)
+	mockInvoiceRepo.AssertNotCalled(t, "FindInvoices", mock.Anything, mock.Anything)
+}
@@ -2,20 +2,23 @@ package services
 
 import (
 	"context"
+	"time"
 
 	"github.com/mohits-git/food-ordering-system/internal/domain"
 	"github.com/mohits-git/food-ordering-system/internal/ports"
 	"github.com/mohits-git/food-ordering-system/internal/utils/apperr"
-	"github.com/mohits-git/food-ordering-system/internal/utils/authctx"
 )
 
 type MenuItemService struct {
-	menuItemRepo   ports.MenuItemRepository
-	restaurantRepo ports.RestaurantRepository
+	menuItemRepo ports.MenuItemRepository
+	menuRepo     ports.MenuRepository
+	authorizer   ports.Authorizer
+	// now is the time the schedules of the menus are checked against
+	now func() time.Time
 }
 
-func NewMenuItemsService(menuItemsRepo ports.MenuItemRepository, restaurantRepo ports.RestaurantRepository) *MenuItemService {
-	return &MenuItemService{menuItemsRepo, restaurantRepo}
+func NewMenuItemsService(menuItemsRepo ports.MenuItemRepository, menuRepo ports.MenuRepository, authorizer ports.Authorizer) *MenuItemService {
+	return &MenuItemService{menuItemsRepo, menuRepo, authorizer, time.Now}
 }
 
 func (m *MenuItemService) CreateMenuItemForRestaurant(ctx context.Context, item domain.MenuItem) (int, error) {
@@ -23,31 +26,37 @@ func (m *MenuItemService) CreateMenuItemForRestaurant(ctx context.Context, item
 		return 0, apperr.NewAppError(apperr.ErrInvalid, "invalid menu item data", nil)
 	}
 
-	user, ok := authctx.UserClaimsFromCtx(ctx)
-	if !ok {
-		return 0, apperr.NewAppError(apperr.ErrUnauthorized, "unauthenticated user", nil)
-	}
-	if user.Role != domain.OWNER {
-		return 0, apperr.NewAppError(apperr.ErrForbidden, "only restaurant owners can add menu items", nil)
-	}
-
-	restaurant, err := m.restaurantRepo.FindRestaurantById(ctx, item.RestaurantID)
-	if err != nil {
+	if _, err := m.authorizer.AuthorizeResource(ctx, domain.ActionCreateMenuItem, domain.Resource{RestaurantID: item.RestaurantID}); err != nil {
 		return 0, err
 	}
-	if restaurant.OwnerID != user.UserID {
-		return 0, apperr.NewAppError(apperr.ErrForbidden, "only restaurant owners can add menu items", nil)
-	}
 
+	if !item.HasStockFor(1) {
+		item.Available = false
+	}
 	return m.menuItemRepo.SaveMenuItem(ctx, item)
 }
 
-func (m *MenuItemService) GetAllMenuItemsByRestaurantId(ctx context.Context, restaurantId int) ([]domain.MenuItem, error) {
+// GetAllMenuItemsByRestaurantId returns the menus offered right now with their
+// items. A restaurant without menus offers all of its items, as one menu
+// without a name or categories.
+func (m *MenuItemService) GetAllMenuItemsByRestaurantId(ctx context.Context, restaurantId int) ([]domain.Menu, error) {
 	items, err := m.menuItemRepo.FindMenuItemsByRestaurantId(ctx, restaurantId)
 	if err != nil {
 		return nil, err
 	}
-	return items, nil
+	menus, err := m.menuRepo.FindMenusByRestaurantId(ctx, restaurantId)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(menus) == 0 {
+		category := domain.MenuCategory{Items: items}
+		for _, item := range items {
+			category.ItemIDs = append(category.ItemIDs, item.ID)
+		}
+		return []domain.Menu{{RestaurantID: restaurantId, Categories: []domain.MenuCategory{category}}}, nil
+	}
+	return withMenuItems(domain.ActiveMenus(menus, m.now()), items), nil
 }
 
 func (m *MenuItemService) UpdateAvailability(ctx context.Context, id int, available bool) error {
@@ -55,25 +64,219 @@ func (m *MenuItemService) UpdateAvailability(ctx context.Context, id int, availa
 		return apperr.NewAppError(apperr.ErrInvalid, "invalid menu item id", nil)
 	}
 
-	user, ok := authctx.UserClaimsFromCtx(ctx)
-	if !ok {
-		return apperr.NewAppError(apperr.ErrUnauthorized, "unauthenticated user", nil)
+	item, err := m.authorizeMenuItem(ctx, domain.ActionUpdateMenuItem, id)
+	if err != nil {
+		return err
+	}
+	if available {
+		if err := checkNotSoldOut(item); err != nil {
+			return err
+		}
+	}
+
+	return m.menuItemRepo.UpdateMenuItemAvailability(ctx, id, available)
+}
+
+// UpdateStock sets the quantity left to sell of the item, an item tracking
+// its stock sells out at zero and is back on sale when restocked.
+func (m *MenuItemService) UpdateStock(ctx context.Context, id int, trackStock bool, stock int) error {
+	if id <= 0 {
+		return apperr.NewAppError(apperr.ErrInvalid, "invalid menu item id", nil)
+	}
+	if stock < 0 {
+		return apperr.NewAppError(apperr.ErrInvalid, "stock cannot be negative", nil)
+	}
+
+	if _, err := m.authorizeMenuItem(ctx, domain.ActionUpdateMenuItem, id); err != nil {
+		return err
+	}
+
+	return m.menuItemRepo.UpdateMenuItemStock(ctx, id, trackStock, stock)
+}
+
+// UpdateMenuItem replaces the details of an item, it stays in the restaurant
+// it was created in.
+func (m *MenuItemService) UpdateMenuItem(ctx context.Context, item domain.MenuItem) error {
+	if item.ID <= 0 {
+		return apperr.NewAppError(apperr.ErrInvalid, "invalid menu item id", nil)
+	}
+
+	existing, err := m.authorizeMenuItem(ctx, domain.ActionUpdateMenuItem, item.ID)
+	if err != nil {
+		return err
+	}
+
+	item.RestaurantID = existing.RestaurantID
+	if !item.Validate() {
+		return apperr.NewAppError(apperr.ErrInvalid, "invalid menu item data", nil)
+	}
+	// the update leaves the stock as it is, so a sold out item stays off sale
+	if item.Available {
+		if err := checkNotSoldOut(existing); err != nil {
+			return err
+		}
+	}
+
+	return m.menuItemRepo.UpdateMenuItem(ctx, item)
+}
+
+// checkNotSoldOut refuses to put an item tracking its stock on sale while none
+// is left, it is available again once restocked.
+func checkNotSoldOut(item domain.MenuItem) error {
+	if !item.HasStockFor(1) {
+		return apperr.NewAppError(apperr.ErrConflict, "menu item is sold out, update its stock to sell it again", nil)
+	}
+	return nil
+}
+
+// DeleteMenuItem removes the item from the menu, orders that have it keep
+// referring to it.
+func (m *MenuItemService) DeleteMenuItem(ctx context.Context, id int) error {
+	if id <= 0 {
+		return apperr.NewAppError(apperr.ErrInvalid, "invalid menu item id", nil)
+	}
+
+	if _, err := m.authorizeMenuItem(ctx, domain.ActionDeleteMenuItem, id); err != nil {
+		return err
 	}
-	if user.Role != domain.OWNER {
-		return apperr.NewAppError(apperr.ErrForbidden, "only restaurant owners can update menu items", nil)
+
+	return m.menuItemRepo.DeleteMenuItem(ctx, id)
+}
+
+// authorizeMenuItem loads the item for an action on it, deleted items are
+// treated as not found.
+func (m *MenuItemService) authorizeMenuItem(ctx context.Context, action domain.Action, id int) (domain.MenuItem, error) {
+	if _, err := m.authorizer.Authorize(ctx, action); err != nil {
+		return domain.MenuItem{}, err
 	}
 
 	item, err := m.menuItemRepo.FindMenuItemById(ctx, id)
+	if err != nil {
+		return domain.MenuItem{}, err
+	}
+	if item.Deleted {
+		return domain.MenuItem{}, apperr.NewAppError(apperr.ErrNotFound, "menu item not found", nil)
+	}
+	if _, err := m.authorizer.AuthorizeResource(ctx, action, domain.Resource{RestaurantID: item.RestaurantID}); err != nil {
+		return domain.MenuItem{}, err
+	}
+	return item, nil
+}
+
+// GetMenusByRestaurantId returns every menu of the restaurant with its
+// schedules, whether it is offered right now or not.
+func (m *MenuItemService) GetMenusByRestaurantId(ctx context.Context, restaurantId int) ([]domain.Menu, error) {
+	if restaurantId <= 0 {
+		return nil, apperr.NewAppError(apperr.ErrInvalid, "invalid restaurant id", nil)
+	}
+
+	items, err := m.menuItemRepo.FindMenuItemsByRestaurantId(ctx, restaurantId)
+	if err != nil {
+		return nil, err
+	}
+	menus, err := m.menuRepo.FindMenusByRestaurantId(ctx, restaurantId)
+	if err != nil {
+		return nil, err
+	}
+	return withMenuItems(menus, items), nil
+}
+
+func (m *MenuItemService) CreateMenu(ctx context.Context, menu domain.Menu) (int, error) {
+	if !menu.Validate() {
+		return 0, apperr.NewAppError(apperr.ErrInvalid, "invalid menu data", nil)
+	}
+
+	if _, err := m.authorizer.AuthorizeResource(ctx, domain.ActionManageMenus, domain.Resource{RestaurantID: menu.RestaurantID}); err != nil {
+		return 0, err
+	}
+
+	if err := m.checkMenuItems(ctx, menu); err != nil {
+		return 0, err
+	}
+	return m.menuRepo.SaveMenu(ctx, menu)
+}
+
+// UpdateMenu replaces the name, schedules and categories of a menu, it stays in
+// the restaurant it was created in.
+func (m *MenuItemService) UpdateMenu(ctx context.Context, menu domain.Menu) error {
+	if menu.ID <= 0 {
+		return apperr.NewAppError(apperr.ErrInvalid, "invalid menu id", nil)
+	}
+
+	existing, err := m.authorizeMenu(ctx, menu.ID)
 	if err != nil {
 		return err
 	}
-	restaurant, err := m.restaurantRepo.FindRestaurantById(ctx, item.RestaurantID)
+
+	menu.RestaurantID = existing.RestaurantID
+	if !menu.Validate() {
+		return apperr.NewAppError(apperr.ErrInvalid, "invalid menu data", nil)
+	}
+	if err := m.checkMenuItems(ctx, menu); err != nil {
+		return err
+	}
+	return m.menuRepo.UpdateMenu(ctx, menu)
+}
+
+// DeleteMenu removes the menu, its items stay on the other menus they are on.
+func (m *MenuItemService) DeleteMenu(ctx context.Context, id int) error {
+	if id <= 0 {
+		return apperr.NewAppError(apperr.ErrInvalid, "invalid menu id", nil)
+	}
+
+	if _, err := m.authorizeMenu(ctx, id); err != nil {
+		return err
+	}
+	return m.menuRepo.DeleteMenu(ctx, id)
+}
+
+func (m *MenuItemService) authorizeMenu(ctx context.Context, id int) (domain.Menu, error) {
+	if _, err := m.authorizer.Authorize(ctx, domain.ActionManageMenus); err != nil {
+		return domain.Menu{}, err
+	}
+
+	menu, err := m.menuRepo.FindMenuById(ctx, id)
+	if err != nil {
+		return domain.Menu{}, err
+	}
+	if menu.ID == 0 {
+		return domain.Menu{}, apperr.NewAppError(apperr.ErrNotFound, "menu not found", nil)
+	}
+	if _, err := m.authorizer.AuthorizeResource(ctx, domain.ActionManageMenus, domain.Resource{RestaurantID: menu.RestaurantID}); err != nil {
+		return domain.Menu{}, err
+	}
+	return menu, nil
+}
+
+// checkMenuItems makes sure the menu only lists items of its restaurant that
+// are not deleted.
+func (m *MenuItemService) checkMenuItems(ctx context.Context, menu domain.Menu) error {
+	items, err := m.menuItemRepo.FindMenuItemsByRestaurantId(ctx, menu.RestaurantID)
 	if err != nil {
 		return err
 	}
-	if restaurant.OwnerID != user.UserID {
-		return apperr.NewAppError(apperr.ErrForbidden, "only restaurant owners can update menu items", nil)
+	restaurantItems := make(map[int]bool, len(items))
+	for _, item := range items {
+		restaurantItems[item.ID] = true
 	}
 
-	return m.menuItemRepo.UpdateMenuItemAvailability(ctx, id, available)
+	for _, category := range menu.Categories {
+		for _, id := range category.ItemIDs {
+			if !restaurantItems[id] {
+				return apperr.NewAppError(apperr.ErrInvalid, "menu item does not belong to the restaurant of the menu", nil)
+			}
+		}
+	}
+	return nil
+}
+
+func withMenuItems(menus []domain.Menu, items []domain.MenuItem) []domain.Menu {
+	itemsMap := make(map[int]domain.MenuItem, len(items))
+	for _, item := range items {
+		itemsMap[item.ID] = item
+	}
+	for i := range menus {
+		menus[i].ResolveItems(itemsMap)
+	}
+	return menus
 }
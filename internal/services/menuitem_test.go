@@ -2,6 +2,7 @@ package services
 
 import (
 	"testing"
+	"time"
 
 	"github.com/mohits-git/food-ordering-system/internal/domain"
 	"github.com/mohits-git/food-ordering-system/internal/utils/apperr"
@@ -13,35 +14,81 @@ import (
 
 func Test_services_MenuItemService_NewMenuItemsService(t *testing.T) {
 	mockMenuItemRepo := mockrepository.MenuItemRepository{}
+	mockMenuRepo := mockrepository.MenuRepository{}
 	mockRestaurantRepo := mockrepository.RestaurantRepository{}
-	service := NewMenuItemsService(&mockMenuItemRepo, &mockRestaurantRepo)
+	service := NewMenuItemsService(&mockMenuItemRepo, &mockMenuRepo, NewAuthorizer(&mockRestaurantRepo, &mockrepository.RestaurantMemberRepository{}))
 	require.NotNil(t, service)
 }
 
 func Test_services_MenuItemService_GetAllMenuItemsByRestaurantId(t *testing.T) {
 	mockMenuItemRepo := mockrepository.MenuItemRepository{}
+	mockMenuRepo := mockrepository.MenuRepository{}
 	mockRestaurantRepo := mockrepository.RestaurantRepository{}
-	service := NewMenuItemsService(&mockMenuItemRepo, &mockRestaurantRepo)
+	service := NewMenuItemsService(&mockMenuItemRepo, &mockMenuRepo, NewAuthorizer(&mockRestaurantRepo, &mockrepository.RestaurantMemberRepository{}))
 
 	restaurantId := 1
 	mockMenuItemRepo.On("FindMenuItemsByRestaurantId", mock.Anything, restaurantId).
 		Return([]domain.MenuItem{
-			{ID: 1, Name: "Item 1", Price: 10.0, Available: true, RestaurantID: restaurantId},
-			{ID: 2, Name: "Item 2", Price: 15.0, Available: false, RestaurantID: restaurantId},
+			{ID: 1, Name: "Item 1", Price: domain.NewMoney(1000, "USD"), Available: true, RestaurantID: restaurantId},
+			{ID: 2, Name: "Item 2", Price: domain.NewMoney(1500, "USD"), Available: false, RestaurantID: restaurantId},
 		}, nil)
+	mockMenuRepo.On("FindMenusByRestaurantId", mock.Anything, restaurantId).
+		Return([]domain.Menu{}, nil)
 
-	items, err := service.GetAllMenuItemsByRestaurantId(t.Context(), restaurantId)
+	// without menus every item is offered, as one menu
+	menus, err := service.GetAllMenuItemsByRestaurantId(t.Context(), restaurantId)
 	require.NoError(t, err)
+	require.Len(t, menus, 1)
+	require.Len(t, menus[0].Categories, 1)
+	items := menus[0].Categories[0].Items
 	require.Len(t, items, 2)
 	require.Equal(t, "Item 1", items[0].Name)
 	require.Equal(t, "Item 2", items[1].Name)
+	require.Equal(t, []int{1, 2}, menus[0].Categories[0].ItemIDs)
 	mockMenuItemRepo.AssertExpectations(t)
+	mockMenuRepo.AssertExpectations(t)
+}
+
+func Test_services_MenuItemService_GetAllMenuItemsByRestaurantId_with_menus(t *testing.T) {
+	mockMenuItemRepo := mockrepository.MenuItemRepository{}
+	mockMenuRepo := mockrepository.MenuRepository{}
+	service := NewMenuItemsService(&mockMenuItemRepo, &mockMenuRepo, NewAuthorizer(&mockrepository.RestaurantRepository{}, &mockrepository.RestaurantMemberRepository{}))
+	service.now = func() time.Time { return time.Date(2026, time.March, 2, 8, 30, 0, 0, time.Local) }
+
+	mockMenuItemRepo.On("FindMenuItemsByRestaurantId", mock.Anything, 1).
+		Return([]domain.MenuItem{
+			{ID: 1, Name: "Eggs", Price: domain.NewMoney(500, "USD"), Available: true, RestaurantID: 1},
+			{ID: 2, Name: "Coffee", Price: domain.NewMoney(200, "USD"), Available: true, RestaurantID: 1},
+			{ID: 3, Name: "Pizza", Price: domain.NewMoney(1000, "USD"), Available: true, RestaurantID: 1},
+		}, nil)
+	mockMenuRepo.On("FindMenusByRestaurantId", mock.Anything, 1).
+		Return([]domain.Menu{
+			{ID: 1, RestaurantID: 1, Name: "Breakfast", Schedules: []domain.MenuSchedule{{Start: 420, End: 660}}, Categories: []domain.MenuCategory{
+				{ID: 1, Name: "Drinks", ItemIDs: []int{2}},
+				// 4 was deleted, it is left out
+				{ID: 2, Name: "Mains", ItemIDs: []int{4, 1}},
+			}},
+			{ID: 2, RestaurantID: 1, Name: "Dinner", Schedules: []domain.MenuSchedule{{Start: 1080, End: 1320}}, Categories: []domain.MenuCategory{
+				{ID: 3, Name: "Pizzas", ItemIDs: []int{3}},
+			}},
+		}, nil)
+
+	menus, err := service.GetAllMenuItemsByRestaurantId(t.Context(), 1)
+	require.NoError(t, err)
+	require.Len(t, menus, 1)
+	require.Equal(t, "Breakfast", menus[0].Name)
+	require.Equal(t, "Coffee", menus[0].Categories[0].Items[0].Name)
+	require.Len(t, menus[0].Categories[1].Items, 1)
+	require.Equal(t, "Eggs", menus[0].Categories[1].Items[0].Name)
+	mockMenuItemRepo.AssertExpectations(t)
+	mockMenuRepo.AssertExpectations(t)
 }
 
 func Test_services_MenuItemService_GetAllMenuItemsByRestaurantId_when_error(t *testing.T) {
 	mockMenuItemRepo := mockrepository.MenuItemRepository{}
+	mockMenuRepo := mockrepository.MenuRepository{}
 	mockRestaurantRepo := mockrepository.RestaurantRepository{}
-	service := NewMenuItemsService(&mockMenuItemRepo, &mockRestaurantRepo)
+	service := NewMenuItemsService(&mockMenuItemRepo, &mockMenuRepo, NewAuthorizer(&mockRestaurantRepo, &mockrepository.RestaurantMemberRepository{}))
 	expectedErr := apperr.NewAppError(apperr.ErrInternal, "internal error", nil)
 
 	restaurantId := 1
@@ -56,10 +103,11 @@ func Test_services_MenuItemService_GetAllMenuItemsByRestaurantId_when_error(t *t
 
 func Test_services_MenuItemService_CreateMenuItemForRestaurant(t *testing.T) {
 	mockMenuItemRepo := mockrepository.MenuItemRepository{}
+	mockMenuRepo := mockrepository.MenuRepository{}
 	mockRestaurantRepo := mockrepository.RestaurantRepository{}
-	service := NewMenuItemsService(&mockMenuItemRepo, &mockRestaurantRepo)
+	service := NewMenuItemsService(&mockMenuItemRepo, &mockMenuRepo, NewAuthorizer(&mockRestaurantRepo, &mockrepository.RestaurantMemberRepository{}))
 
-	newItem := domain.MenuItem{Name: "New Item", Price: 20.0, Available: true, RestaurantID: 1}
+	newItem := domain.MenuItem{Name: "New Item", Price: domain.NewMoney(2000, "USD"), Available: true, RestaurantID: 1}
 	restaurant := domain.Restaurant{ID: 1, Name: "Restaurant 1", OwnerID: 1}
 
 	mockRestaurantRepo.On("FindRestaurantById", mock.Anything, newItem.RestaurantID).
@@ -79,12 +127,84 @@ func Test_services_MenuItemService_CreateMenuItemForRestaurant(t *testing.T) {
 	mockRestaurantRepo.AssertExpectations(t)
 }
 
+func Test_services_MenuItemService_CreateMenuItemForRestaurant_when_out_of_stock(t *testing.T) {
+	mockMenuItemRepo := mockrepository.MenuItemRepository{}
+	mockMenuRepo := mockrepository.MenuRepository{}
+	mockRestaurantRepo := mockrepository.RestaurantRepository{}
+	service := NewMenuItemsService(&mockMenuItemRepo, &mockMenuRepo, NewAuthorizer(&mockRestaurantRepo, &mockrepository.RestaurantMemberRepository{}))
+
+	newItem := domain.MenuItem{Name: "Cake", Price: domain.NewMoney(500, "USD"), Available: true, RestaurantID: 1, TrackStock: true}
+	want := newItem
+	want.Available = false
+
+	mockRestaurantRepo.On("FindRestaurantById", mock.Anything, 1).
+		Return(domain.Restaurant{ID: 1, Name: "Restaurant 1", OwnerID: 1}, nil)
+	mockMenuItemRepo.On("SaveMenuItem", mock.Anything, want).
+		Return(1, nil)
+
+	ctx := authctx.WithUserClaims(t.Context(), &authctx.UserClaims{UserID: 1, Role: domain.OWNER})
+
+	itemId, err := service.CreateMenuItemForRestaurant(ctx, newItem)
+	require.NoError(t, err)
+	require.Equal(t, 1, itemId)
+	mockMenuItemRepo.AssertExpectations(t)
+}
+
+func Test_services_MenuItemService_CreateMenuItemForRestaurant_when_manager_staff(t *testing.T) {
+	mockMenuItemRepo := mockrepository.MenuItemRepository{}
+	mockMenuRepo := mockrepository.MenuRepository{}
+	mockRestaurantRepo := mockrepository.RestaurantRepository{}
+	mockMemberRepo := mockrepository.RestaurantMemberRepository{}
+	service := NewMenuItemsService(&mockMenuItemRepo, &mockMenuRepo, NewAuthorizer(&mockRestaurantRepo, &mockMemberRepo))
+
+	newItem := domain.MenuItem{Name: "New Item", Price: domain.NewMoney(2000, "USD"), Available: true, RestaurantID: 1}
+
+	mockMemberRepo.On("FindRestaurantMember", mock.Anything, 1, 4).
+		Return(domain.NewRestaurantMember(1, 4, domain.StaffManager), nil)
+	mockMenuItemRepo.On("SaveMenuItem", mock.Anything, newItem).
+		Return(1, nil)
+
+	ctx := authctx.WithUserClaims(t.Context(), &authctx.UserClaims{
+		UserID: 4,
+		Role:   domain.STAFF,
+	})
+
+	itemId, err := service.CreateMenuItemForRestaurant(ctx, newItem)
+	require.NoError(t, err)
+	require.Equal(t, 1, itemId)
+	mockMenuItemRepo.AssertExpectations(t)
+	mockMemberRepo.AssertExpectations(t)
+}
+
+func Test_services_MenuItemService_CreateMenuItemForRestaurant_when_kitchen_staff(t *testing.T) {
+	mockMenuItemRepo := mockrepository.MenuItemRepository{}
+	mockMenuRepo := mockrepository.MenuRepository{}
+	mockRestaurantRepo := mockrepository.RestaurantRepository{}
+	mockMemberRepo := mockrepository.RestaurantMemberRepository{}
+	service := NewMenuItemsService(&mockMenuItemRepo, &mockMenuRepo, NewAuthorizer(&mockRestaurantRepo, &mockMemberRepo))
+
+	newItem := domain.MenuItem{Name: "New Item", Price: domain.NewMoney(2000, "USD"), Available: true, RestaurantID: 1}
+
+	mockMemberRepo.On("FindRestaurantMember", mock.Anything, 1, 4).
+		Return(domain.NewRestaurantMember(1, 4, domain.StaffKitchen), nil)
+
+	ctx := authctx.WithUserClaims(t.Context(), &authctx.UserClaims{
+		UserID: 4,
+		Role:   domain.STAFF,
+	})
+
+	_, err := service.CreateMenuItemForRestaurant(ctx, newItem)
+	require.True(t, apperr.IsForbiddenError(err), "expected forbidden error but got %v", err)
+	mockMenuItemRepo.AssertNotCalled(t, "SaveMenuItem", mock.Anything, mock.Anything)
+}
+
 func Test_services_MenuItemService_CreateMenuItemForRestaurant_when_invalid_data(t *testing.T) {
 	mockMenuItemRepo := mockrepository.MenuItemRepository{}
+	mockMenuRepo := mockrepository.MenuRepository{}
 	mockRestaurantRepo := mockrepository.RestaurantRepository{}
-	service := NewMenuItemsService(&mockMenuItemRepo, &mockRestaurantRepo)
+	service := NewMenuItemsService(&mockMenuItemRepo, &mockMenuRepo, NewAuthorizer(&mockRestaurantRepo, &mockrepository.RestaurantMemberRepository{}))
 
-	newItem := domain.MenuItem{Name: "", Price: -20.0, Available: true, RestaurantID: 1}
+	newItem := domain.MenuItem{Name: "", Price: domain.NewMoney(-2000, "USD"), Available: true, RestaurantID: 1}
 
 	ctx := authctx.WithUserClaims(t.Context(), &authctx.UserClaims{
 		UserID: 1,
@@ -100,10 +220,11 @@ func Test_services_MenuItemService_CreateMenuItemForRestaurant_when_invalid_data
 
 func Test_services_MenuItemService_CreateMenuItemForRestaurant_when_unauthenticated(t *testing.T) {
 	mockMenuItemRepo := mockrepository.MenuItemRepository{}
+	mockMenuRepo := mockrepository.MenuRepository{}
 	mockRestaurantRepo := mockrepository.RestaurantRepository{}
-	service := NewMenuItemsService(&mockMenuItemRepo, &mockRestaurantRepo)
+	service := NewMenuItemsService(&mockMenuItemRepo, &mockMenuRepo, NewAuthorizer(&mockRestaurantRepo, &mockrepository.RestaurantMemberRepository{}))
 
-	newItem := domain.MenuItem{Name: "New Item", Price: 20.0, Available: true, RestaurantID: 1}
+	newItem := domain.MenuItem{Name: "New Item", Price: domain.NewMoney(2000, "USD"), Available: true, RestaurantID: 1}
 
 	itemId, err := service.CreateMenuItemForRestaurant(t.Context(), newItem)
 	require.Error(t, err)
@@ -114,10 +235,11 @@ func Test_services_MenuItemService_CreateMenuItemForRestaurant_when_unauthentica
 
 func Test_services_MenuItemService_CreateMenuItemForRestaurant_when_forbidden(t *testing.T) {
 	mockMenuItemRepo := mockrepository.MenuItemRepository{}
+	mockMenuRepo := mockrepository.MenuRepository{}
 	mockRestaurantRepo := mockrepository.RestaurantRepository{}
-	service := NewMenuItemsService(&mockMenuItemRepo, &mockRestaurantRepo)
+	service := NewMenuItemsService(&mockMenuItemRepo, &mockMenuRepo, NewAuthorizer(&mockRestaurantRepo, &mockrepository.RestaurantMemberRepository{}))
 
-	newItem := domain.MenuItem{Name: "New Item", Price: 20.0, Available: true, RestaurantID: 1}
+	newItem := domain.MenuItem{Name: "New Item", Price: domain.NewMoney(2000, "USD"), Available: true, RestaurantID: 1}
 
 	ctx := authctx.WithUserClaims(t.Context(), &authctx.UserClaims{
 		UserID: 1,
@@ -133,10 +255,11 @@ func Test_services_MenuItemService_CreateMenuItemForRestaurant_when_forbidden(t
 
 func Test_services_MenuItemService_CreateMenuItemForRestaurant_when_not_owner(t *testing.T) {
 	mockMenuItemRepo := mockrepository.MenuItemRepository{}
+	mockMenuRepo := mockrepository.MenuRepository{}
 	mockRestaurantRepo := mockrepository.RestaurantRepository{}
-	service := NewMenuItemsService(&mockMenuItemRepo, &mockRestaurantRepo)
+	service := NewMenuItemsService(&mockMenuItemRepo, &mockMenuRepo, NewAuthorizer(&mockRestaurantRepo, &mockrepository.RestaurantMemberRepository{}))
 
-	newItem := domain.MenuItem{Name: "New Item", Price: 20.0, Available: true, RestaurantID: 1}
+	newItem := domain.MenuItem{Name: "New Item", Price: domain.NewMoney(2000, "USD"), Available: true, RestaurantID: 1}
 	restaurant := domain.Restaurant{ID: 1, Name: "Restaurant 1", OwnerID: 2}
 
 	mockRestaurantRepo.On("FindRestaurantById", mock.Anything, newItem.RestaurantID).
@@ -156,11 +279,12 @@ func Test_services_MenuItemService_CreateMenuItemForRestaurant_when_not_owner(t
 
 func Test_services_MenuItemService_CreateMenuItemForRestaurant_when_restaurant_not_found(t *testing.T) {
 	mockMenuItemRepo := mockrepository.MenuItemRepository{}
+	mockMenuRepo := mockrepository.MenuRepository{}
 	mockRestaurantRepo := mockrepository.RestaurantRepository{}
-	service := NewMenuItemsService(&mockMenuItemRepo, &mockRestaurantRepo)
+	service := NewMenuItemsService(&mockMenuItemRepo, &mockMenuRepo, NewAuthorizer(&mockRestaurantRepo, &mockrepository.RestaurantMemberRepository{}))
 	expectedErr := apperr.NewAppError(apperr.ErrNotFound, "restaurant not found", nil)
 
-	newItem := domain.MenuItem{Name: "New Item", Price: 20.0, Available: true, RestaurantID: 1}
+	newItem := domain.MenuItem{Name: "New Item", Price: domain.NewMoney(2000, "USD"), Available: true, RestaurantID: 1}
 
 	mockRestaurantRepo.On("FindRestaurantById", mock.Anything, newItem.RestaurantID).
 		Return(domain.Restaurant{}, expectedErr)
@@ -179,11 +303,12 @@ func Test_services_MenuItemService_CreateMenuItemForRestaurant_when_restaurant_n
 
 func Test_services_MenuItemService_CreateMenuItemForRestaurant_when_repo_error(t *testing.T) {
 	mockMenuItemRepo := mockrepository.MenuItemRepository{}
+	mockMenuRepo := mockrepository.MenuRepository{}
 	mockRestaurantRepo := mockrepository.RestaurantRepository{}
-	service := NewMenuItemsService(&mockMenuItemRepo, &mockRestaurantRepo)
+	service := NewMenuItemsService(&mockMenuItemRepo, &mockMenuRepo, NewAuthorizer(&mockRestaurantRepo, &mockrepository.RestaurantMemberRepository{}))
 	expectedErr := apperr.NewAppError(apperr.ErrInternal, "internal error", nil)
 
-	newItem := domain.MenuItem{Name: "New Item", Price: 20.0, Available: true, RestaurantID: 1}
+	newItem := domain.MenuItem{Name: "New Item", Price: domain.NewMoney(2000, "USD"), Available: true, RestaurantID: 1}
 	restaurant := domain.Restaurant{ID: 1, Name: "Restaurant 1", OwnerID: 1}
 
 	mockRestaurantRepo.On("FindRestaurantById", mock.Anything, newItem.RestaurantID).
@@ -205,12 +330,13 @@ func Test_services_MenuItemService_CreateMenuItemForRestaurant_when_repo_error(t
 
 func Test_services_MenuItemService_UpdateAvailability(t *testing.T) {
 	mockMenuItemRepo := mockrepository.MenuItemRepository{}
+	mockMenuRepo := mockrepository.MenuRepository{}
 	mockRestaurantRepo := mockrepository.RestaurantRepository{}
-	service := NewMenuItemsService(&mockMenuItemRepo, &mockRestaurantRepo)
+	service := NewMenuItemsService(&mockMenuItemRepo, &mockMenuRepo, NewAuthorizer(&mockRestaurantRepo, &mockrepository.RestaurantMemberRepository{}))
 
 	itemId := 1
 	available := false
-	menuItem := domain.MenuItem{ID: itemId, Name: "Item 1", Price: 10.0, Available: true, RestaurantID: 1}
+	menuItem := domain.MenuItem{ID: itemId, Name: "Item 1", Price: domain.NewMoney(1000, "USD"), Available: true, RestaurantID: 1}
 	restaurant := domain.Restaurant{ID: 1, Name: "Restaurant 1", OwnerID: 1}
 
 	mockMenuItemRepo.On("FindMenuItemById", mock.Anything, itemId).
@@ -233,8 +359,9 @@ func Test_services_MenuItemService_UpdateAvailability(t *testing.T) {
 
 func Test_services_MenuItemService_UpdateAvailability_when_invalid_id(t *testing.T) {
 	mockMenuItemRepo := mockrepository.MenuItemRepository{}
+	mockMenuRepo := mockrepository.MenuRepository{}
 	mockRestaurantRepo := mockrepository.RestaurantRepository{}
-	service := NewMenuItemsService(&mockMenuItemRepo, &mockRestaurantRepo)
+	service := NewMenuItemsService(&mockMenuItemRepo, &mockMenuRepo, NewAuthorizer(&mockRestaurantRepo, &mockrepository.RestaurantMemberRepository{}))
 
 	itemId := 0
 	available := false
@@ -252,8 +379,9 @@ func Test_services_MenuItemService_UpdateAvailability_when_invalid_id(t *testing
 
 func Test_services_MenuItemService_UpdateAvailability_when_unauthenticated(t *testing.T) {
 	mockMenuItemRepo := mockrepository.MenuItemRepository{}
+	mockMenuRepo := mockrepository.MenuRepository{}
 	mockRestaurantRepo := mockrepository.RestaurantRepository{}
-	service := NewMenuItemsService(&mockMenuItemRepo, &mockRestaurantRepo)
+	service := NewMenuItemsService(&mockMenuItemRepo, &mockMenuRepo, NewAuthorizer(&mockRestaurantRepo, &mockrepository.RestaurantMemberRepository{}))
 
 	itemId := 1
 	available := false
@@ -266,8 +394,9 @@ func Test_services_MenuItemService_UpdateAvailability_when_unauthenticated(t *te
 
 func Test_services_MenuItemService_UpdateAvailability_when_forbidden(t *testing.T) {
 	mockMenuItemRepo := mockrepository.MenuItemRepository{}
+	mockMenuRepo := mockrepository.MenuRepository{}
 	mockRestaurantRepo := mockrepository.RestaurantRepository{}
-	service := NewMenuItemsService(&mockMenuItemRepo, &mockRestaurantRepo)
+	service := NewMenuItemsService(&mockMenuItemRepo, &mockMenuRepo, NewAuthorizer(&mockRestaurantRepo, &mockrepository.RestaurantMemberRepository{}))
 
 	itemId := 1
 	available := false
@@ -285,12 +414,13 @@ func Test_services_MenuItemService_UpdateAvailability_when_forbidden(t *testing.
 
 func Test_services_MenuItemService_UpdateAvailability_when_not_owner(t *testing.T) {
 	mockMenuItemRepo := mockrepository.MenuItemRepository{}
+	mockMenuRepo := mockrepository.MenuRepository{}
 	mockRestaurantRepo := mockrepository.RestaurantRepository{}
-	service := NewMenuItemsService(&mockMenuItemRepo, &mockRestaurantRepo)
+	service := NewMenuItemsService(&mockMenuItemRepo, &mockMenuRepo, NewAuthorizer(&mockRestaurantRepo, &mockrepository.RestaurantMemberRepository{}))
 
 	itemId := 1
 	available := false
-	menuItem := domain.MenuItem{ID: itemId, Name: "Item 1", Price: 10.0, Available: true, RestaurantID: 1}
+	menuItem := domain.MenuItem{ID: itemId, Name: "Item 1", Price: domain.NewMoney(1000, "USD"), Available: true, RestaurantID: 1}
 	restaurant := domain.Restaurant{ID: 1, Name: "Restaurant 1", OwnerID: 2}
 
 	mockMenuItemRepo.On("FindMenuItemById", mock.Anything, itemId).
@@ -311,8 +441,9 @@ func Test_services_MenuItemService_UpdateAvailability_when_not_owner(t *testing.
 
 func Test_services_MenuItemService_UpdateAvailability_when_menu_item_not_found(t *testing.T) {
 	mockMenuItemRepo := mockrepository.MenuItemRepository{}
+	mockMenuRepo := mockrepository.MenuRepository{}
 	mockRestaurantRepo := mockrepository.RestaurantRepository{}
-	service := NewMenuItemsService(&mockMenuItemRepo, &mockRestaurantRepo)
+	service := NewMenuItemsService(&mockMenuItemRepo, &mockMenuRepo, NewAuthorizer(&mockRestaurantRepo, &mockrepository.RestaurantMemberRepository{}))
 	expectedErr := apperr.NewAppError(apperr.ErrNotFound, "menu item not found", nil)
 
 	itemId := 1
@@ -334,13 +465,14 @@ func Test_services_MenuItemService_UpdateAvailability_when_menu_item_not_found(t
 
 func Test_services_MenuItemService_UpdateAvailability_when_restaurant_not_found(t *testing.T) {
 	mockMenuItemRepo := mockrepository.MenuItemRepository{}
+	mockMenuRepo := mockrepository.MenuRepository{}
 	mockRestaurantRepo := mockrepository.RestaurantRepository{}
-	service := NewMenuItemsService(&mockMenuItemRepo, &mockRestaurantRepo)
+	service := NewMenuItemsService(&mockMenuItemRepo, &mockMenuRepo, NewAuthorizer(&mockRestaurantRepo, &mockrepository.RestaurantMemberRepository{}))
 	expectedErr := apperr.NewAppError(apperr.ErrNotFound, "restaurant not found", nil)
 
 	itemId := 1
 	available := false
-	menuItem := domain.MenuItem{ID: itemId, Name: "Item 1", Price: 10.0, Available: true, RestaurantID: 1}
+	menuItem := domain.MenuItem{ID: itemId, Name: "Item 1", Price: domain.NewMoney(1000, "USD"), Available: true, RestaurantID: 1}
 
 	mockMenuItemRepo.On("FindMenuItemById", mock.Anything, itemId).
 		Return(menuItem, nil)
@@ -360,13 +492,14 @@ func Test_services_MenuItemService_UpdateAvailability_when_restaurant_not_found(
 
 func Test_services_MenuItemService_UpdateAvailability_when_repo_error(t *testing.T) {
 	mockMenuItemRepo := mockrepository.MenuItemRepository{}
+	mockMenuRepo := mockrepository.MenuRepository{}
 	mockRestaurantRepo := mockrepository.RestaurantRepository{}
-	service := NewMenuItemsService(&mockMenuItemRepo, &mockRestaurantRepo)
+	service := NewMenuItemsService(&mockMenuItemRepo, &mockMenuRepo, NewAuthorizer(&mockRestaurantRepo, &mockrepository.RestaurantMemberRepository{}))
 	expectedErr := apperr.NewAppError(apperr.ErrInternal, "internal error", nil)
 
 	itemId := 1
 	available := false
-	menuItem := domain.MenuItem{ID: itemId, Name: "Item 1", Price: 10.0, Available: true, RestaurantID: 1}
+	menuItem := domain.MenuItem{ID: itemId, Name: "Item 1", Price: domain.NewMoney(1000, "USD"), Available: true, RestaurantID: 1}
 	restaurant := domain.Restaurant{ID: 1, Name: "Restaurant 1", OwnerID: 1}
 
 	mockMenuItemRepo.On("FindMenuItemById", mock.Anything, itemId).
@@ -386,3 +519,449 @@ func Test_services_MenuItemService_UpdateAvailability_when_repo_error(t *testing
 	mockMenuItemRepo.AssertExpectations(t)
 	mockRestaurantRepo.AssertExpectations(t)
 }
+
+func Test_services_MenuItemService_UpdateAvailability_when_deleted(t *testing.T) {
+	mockMenuItemRepo := mockrepository.MenuItemRepository{}
+	mockMenuRepo := mockrepository.MenuRepository{}
+	mockRestaurantRepo := mockrepository.RestaurantRepository{}
+	service := NewMenuItemsService(&mockMenuItemRepo, &mockMenuRepo, NewAuthorizer(&mockRestaurantRepo, &mockrepository.RestaurantMemberRepository{}))
+
+	menuItem := domain.MenuItem{ID: 1, Name: "Item 1", Price: domain.NewMoney(1000, "USD"), RestaurantID: 1, Deleted: true}
+	mockMenuItemRepo.On("FindMenuItemById", mock.Anything, 1).
+		Return(menuItem, nil)
+
+	ctx := authctx.WithUserClaims(t.Context(), &authctx.UserClaims{UserID: 1, Role: domain.OWNER})
+
+	err := service.UpdateAvailability(ctx, 1, true)
+	require.True(t, apperr.IsNotFoundError(err), "expected not found error but got %v", err)
+	mockMenuItemRepo.AssertNotCalled(t, "UpdateMenuItemAvailability", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func Test_services_MenuItemService_UpdateAvailability_when_sold_out(t *testing.T) {
+	mockMenuItemRepo := mockrepository.MenuItemRepository{}
+	mockMenuRepo := mockrepository.MenuRepository{}
+	mockRestaurantRepo := mockrepository.RestaurantRepository{}
+	service := NewMenuItemsService(&mockMenuItemRepo, &mockMenuRepo, NewAuthorizer(&mockRestaurantRepo, &mockrepository.RestaurantMemberRepository{}))
+
+	menuItem := domain.MenuItem{ID: 1, Name: "Cake", Price: domain.NewMoney(500, "USD"), RestaurantID: 1, TrackStock: true, Stock: 0}
+	mockMenuItemRepo.On("FindMenuItemById", mock.Anything, 1).
+		Return(menuItem, nil)
+	mockRestaurantRepo.On("FindRestaurantById", mock.Anything, 1).
+		Return(domain.Restaurant{ID: 1, Name: "Restaurant 1", OwnerID: 1}, nil)
+
+	ctx := authctx.WithUserClaims(t.Context(), &authctx.UserClaims{UserID: 1, Role: domain.OWNER})
+
+	err := service.UpdateAvailability(ctx, 1, true)
+	require.True(t, apperr.IsConflictError(err), "expected conflict error but got %v", err)
+	mockMenuItemRepo.AssertNotCalled(t, "UpdateMenuItemAvailability", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func Test_services_MenuItemService_UpdateStock(t *testing.T) {
+	mockMenuItemRepo := mockrepository.MenuItemRepository{}
+	mockMenuRepo := mockrepository.MenuRepository{}
+	mockRestaurantRepo := mockrepository.RestaurantRepository{}
+	service := NewMenuItemsService(&mockMenuItemRepo, &mockMenuRepo, NewAuthorizer(&mockRestaurantRepo, &mockrepository.RestaurantMemberRepository{}))
+
+	menuItem := domain.MenuItem{ID: 1, Name: "Cake", Price: domain.NewMoney(500, "USD"), Available: true, RestaurantID: 1}
+	mockMenuItemRepo.On("FindMenuItemById", mock.Anything, 1).
+		Return(menuItem, nil)
+	mockRestaurantRepo.On("FindRestaurantById", mock.Anything, 1).
+		Return(domain.Restaurant{ID: 1, Name: "Restaurant 1", OwnerID: 1}, nil)
+	mockMenuItemRepo.On("UpdateMenuItemStock", mock.Anything, 1, true, 12).
+		Return(nil)
+
+	ctx := authctx.WithUserClaims(t.Context(), &authctx.UserClaims{UserID: 1, Role: domain.OWNER})
+
+	err := service.UpdateStock(ctx, 1, true, 12)
+	require.NoError(t, err)
+	mockMenuItemRepo.AssertExpectations(t)
+	mockRestaurantRepo.AssertExpectations(t)
+}
+
+func Test_services_MenuItemService_UpdateStock_when_invalid(t *testing.T) {
+	mockMenuItemRepo := mockrepository.MenuItemRepository{}
+	mockMenuRepo := mockrepository.MenuRepository{}
+	service := NewMenuItemsService(&mockMenuItemRepo, &mockMenuRepo, NewAuthorizer(&mockrepository.RestaurantRepository{}, &mockrepository.RestaurantMemberRepository{}))
+
+	ctx := authctx.WithUserClaims(t.Context(), &authctx.UserClaims{UserID: 1, Role: domain.OWNER})
+
+	err := service.UpdateStock(ctx, 0, true, 1)
+	require.True(t, apperr.IsInvalidError(err), "expected invalid error but got %v", err)
+	err = service.UpdateStock(ctx, 1, true, -1)
+	require.True(t, apperr.IsInvalidError(err), "expected invalid error but got %v", err)
+	mockMenuItemRepo.AssertNotCalled(t, "UpdateMenuItemStock", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func Test_services_MenuItemService_UpdateStock_when_kitchen_staff(t *testing.T) {
+	mockMenuItemRepo := mockrepository.MenuItemRepository{}
+	mockMenuRepo := mockrepository.MenuRepository{}
+	mockMemberRepo := mockrepository.RestaurantMemberRepository{}
+	service := NewMenuItemsService(&mockMenuItemRepo, &mockMenuRepo, NewAuthorizer(&mockrepository.RestaurantRepository{}, &mockMemberRepo))
+
+	menuItem := domain.MenuItem{ID: 1, Name: "Cake", Price: domain.NewMoney(500, "USD"), Available: true, RestaurantID: 1}
+	mockMenuItemRepo.On("FindMenuItemById", mock.Anything, 1).
+		Return(menuItem, nil)
+	mockMemberRepo.On("FindRestaurantMember", mock.Anything, 1, 5).
+		Return(domain.NewRestaurantMember(1, 5, domain.StaffKitchen), nil)
+
+	ctx := authctx.WithUserClaims(t.Context(), &authctx.UserClaims{UserID: 5, Role: domain.STAFF})
+
+	err := service.UpdateStock(ctx, 1, true, 3)
+	require.True(t, apperr.IsForbiddenError(err), "expected forbidden error but got %v", err)
+	mockMenuItemRepo.AssertNotCalled(t, "UpdateMenuItemStock", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func Test_services_MenuItemService_UpdateMenuItem(t *testing.T) {
+	mockMenuItemRepo := mockrepository.MenuItemRepository{}
+	mockMenuRepo := mockrepository.MenuRepository{}
+	mockRestaurantRepo := mockrepository.RestaurantRepository{}
+	service := NewMenuItemsService(&mockMenuItemRepo, &mockMenuRepo, NewAuthorizer(&mockRestaurantRepo, &mockrepository.RestaurantMemberRepository{}))
+
+	existing := domain.MenuItem{ID: 1, Name: "Piza", Price: domain.NewMoney(1000, "USD"), Available: true, RestaurantID: 1}
+	// the restaurant of the update is ignored, items cannot move between restaurants
+	update := domain.MenuItem{ID: 1, Name: "Pizza", Price: domain.NewMoney(1200, "USD"), Available: true, RestaurantID: 2, Description: "Wood fired", SortOrder: 1}
+	want := update
+	want.RestaurantID = 1
+
+	mockMenuItemRepo.On("FindMenuItemById", mock.Anything, 1).
+		Return(existing, nil)
+	mockRestaurantRepo.On("FindRestaurantById", mock.Anything, 1).
+		Return(domain.Restaurant{ID: 1, Name: "Restaurant 1", OwnerID: 1}, nil)
+	mockMenuItemRepo.On("UpdateMenuItem", mock.Anything, want).
+		Return(nil)
+
+	ctx := authctx.WithUserClaims(t.Context(), &authctx.UserClaims{UserID: 1, Role: domain.OWNER})
+
+	err := service.UpdateMenuItem(ctx, update)
+	require.NoError(t, err)
+	mockMenuItemRepo.AssertExpectations(t)
+	mockRestaurantRepo.AssertExpectations(t)
+}
+
+func Test_services_MenuItemService_UpdateMenuItem_when_sold_out(t *testing.T) {
+	mockMenuItemRepo := mockrepository.MenuItemRepository{}
+	mockMenuRepo := mockrepository.MenuRepository{}
+	mockRestaurantRepo := mockrepository.RestaurantRepository{}
+	service := NewMenuItemsService(&mockMenuItemRepo, &mockMenuRepo, NewAuthorizer(&mockRestaurantRepo, &mockrepository.RestaurantMemberRepository{}))
+
+	mockMenuItemRepo.On("FindMenuItemById", mock.Anything, 1).
+		Return(domain.MenuItem{ID: 1, Name: "Cake", Price: domain.NewMoney(500, "USD"), RestaurantID: 1, TrackStock: true, Stock: 0}, nil)
+	mockRestaurantRepo.On("FindRestaurantById", mock.Anything, 1).
+		Return(domain.Restaurant{ID: 1, Name: "Restaurant 1", OwnerID: 1}, nil)
+
+	ctx := authctx.WithUserClaims(t.Context(), &authctx.UserClaims{UserID: 1, Role: domain.OWNER})
+
+	err := service.UpdateMenuItem(ctx, domain.MenuItem{ID: 1, Name: "Cake", Price: domain.NewMoney(600, "USD"), Available: true})
+	require.True(t, apperr.IsConflictError(err), "expected conflict error but got %v", err)
+	mockMenuItemRepo.AssertNotCalled(t, "UpdateMenuItem", mock.Anything, mock.Anything)
+
+	// leaving it unavailable updates the rest
+	mockMenuItemRepo.On("UpdateMenuItem", mock.Anything, domain.MenuItem{ID: 1, Name: "Cake", Price: domain.NewMoney(600, "USD"), RestaurantID: 1}).
+		Return(nil)
+	err = service.UpdateMenuItem(ctx, domain.MenuItem{ID: 1, Name: "Cake", Price: domain.NewMoney(600, "USD")})
+	require.NoError(t, err)
+	mockMenuItemRepo.AssertExpectations(t)
+}
+
+func Test_services_MenuItemService_UpdateMenuItem_when_invalid_data(t *testing.T) {
+	mockMenuItemRepo := mockrepository.MenuItemRepository{}
+	mockMenuRepo := mockrepository.MenuRepository{}
+	mockRestaurantRepo := mockrepository.RestaurantRepository{}
+	service := NewMenuItemsService(&mockMenuItemRepo, &mockMenuRepo, NewAuthorizer(&mockRestaurantRepo, &mockrepository.RestaurantMemberRepository{}))
+
+	mockMenuItemRepo.On("FindMenuItemById", mock.Anything, 1).
+		Return(domain.MenuItem{ID: 1, Name: "Pizza", Price: domain.NewMoney(1000, "USD"), RestaurantID: 1}, nil)
+	mockRestaurantRepo.On("FindRestaurantById", mock.Anything, 1).
+		Return(domain.Restaurant{ID: 1, Name: "Restaurant 1", OwnerID: 1}, nil)
+
+	ctx := authctx.WithUserClaims(t.Context(), &authctx.UserClaims{UserID: 1, Role: domain.OWNER})
+
+	err := service.UpdateMenuItem(ctx, domain.MenuItem{ID: 1, Name: "", Price: domain.NewMoney(1000, "USD")})
+	require.True(t, apperr.IsInvalidError(err), "expected invalid error but got %v", err)
+	mockMenuItemRepo.AssertNotCalled(t, "UpdateMenuItem", mock.Anything, mock.Anything)
+}
+
+func Test_services_MenuItemService_UpdateMenuItem_when_not_owner(t *testing.T) {
+	mockMenuItemRepo := mockrepository.MenuItemRepository{}
+	mockMenuRepo := mockrepository.MenuRepository{}
+	mockRestaurantRepo := mockrepository.RestaurantRepository{}
+	service := NewMenuItemsService(&mockMenuItemRepo, &mockMenuRepo, NewAuthorizer(&mockRestaurantRepo, &mockrepository.RestaurantMemberRepository{}))
+
+	mockMenuItemRepo.On("FindMenuItemById", mock.Anything, 1).
+		Return(domain.MenuItem{ID: 1, Name: "Pizza", Price: domain.NewMoney(1000, "USD"), RestaurantID: 1}, nil)
+	mockRestaurantRepo.On("FindRestaurantById", mock.Anything, 1).
+		Return(domain.Restaurant{ID: 1, Name: "Restaurant 1", OwnerID: 2}, nil)
+
+	ctx := authctx.WithUserClaims(t.Context(), &authctx.UserClaims{UserID: 1, Role: domain.OWNER})
+
+	err := service.UpdateMenuItem(ctx, domain.MenuItem{ID: 1, Name: "Pizza", Price: domain.NewMoney(1200, "USD")})
+	require.True(t, apperr.IsForbiddenError(err), "expected forbidden error but got %v", err)
+	mockMenuItemRepo.AssertNotCalled(t, "UpdateMenuItem", mock.Anything, mock.Anything)
+}
+
+func Test_services_MenuItemService_UpdateMenuItem_when_customer(t *testing.T) {
+	mockMenuItemRepo := mockrepository.MenuItemRepository{}
+	mockMenuRepo := mockrepository.MenuRepository{}
+	mockRestaurantRepo := mockrepository.RestaurantRepository{}
+	service := NewMenuItemsService(&mockMenuItemRepo, &mockMenuRepo, NewAuthorizer(&mockRestaurantRepo, &mockrepository.RestaurantMemberRepository{}))
+
+	ctx := authctx.WithUserClaims(t.Context(), &authctx.UserClaims{UserID: 1, Role: domain.CUSTOMER})
+
+	err := service.UpdateMenuItem(ctx, domain.MenuItem{ID: 1, Name: "Pizza", Price: domain.NewMoney(1200, "USD")})
+	require.True(t, apperr.IsForbiddenError(err), "expected forbidden error but got %v", err)
+	mockMenuItemRepo.AssertNotCalled(t, "FindMenuItemById", mock.Anything, mock.Anything)
+}
+
+func Test_services_MenuItemService_DeleteMenuItem(t *testing.T) {
+	mockMenuItemRepo := mockrepository.MenuItemRepository{}
+	mockMenuRepo := mockrepository.MenuRepository{}
+	mockRestaurantRepo := mockrepository.RestaurantRepository{}
+	service := NewMenuItemsService(&mockMenuItemRepo, &mockMenuRepo, NewAuthorizer(&mockRestaurantRepo, &mockrepository.RestaurantMemberRepository{}))
+
+	mockMenuItemRepo.On("FindMenuItemById", mock.Anything, 1).
+		Return(domain.MenuItem{ID: 1, Name: "Pizza", Price: domain.NewMoney(1000, "USD"), RestaurantID: 1}, nil)
+	mockRestaurantRepo.On("FindRestaurantById", mock.Anything, 1).
+		Return(domain.Restaurant{ID: 1, Name: "Restaurant 1", OwnerID: 1}, nil)
+	mockMenuItemRepo.On("DeleteMenuItem", mock.Anything, 1).
+		Return(nil)
+
+	ctx := authctx.WithUserClaims(t.Context(), &authctx.UserClaims{UserID: 1, Role: domain.OWNER})
+
+	err := service.DeleteMenuItem(ctx, 1)
+	require.NoError(t, err)
+	mockMenuItemRepo.AssertExpectations(t)
+	mockRestaurantRepo.AssertExpectations(t)
+}
+
+func Test_services_MenuItemService_DeleteMenuItem_when_manager_staff(t *testing.T) {
+	mockMenuItemRepo := mockrepository.MenuItemRepository{}
+	mockMenuRepo := mockrepository.MenuRepository{}
+	mockRestaurantRepo := mockrepository.RestaurantRepository{}
+	mockMemberRepo := mockrepository.RestaurantMemberRepository{}
+	service := NewMenuItemsService(&mockMenuItemRepo, &mockMenuRepo, NewAuthorizer(&mockRestaurantRepo, &mockMemberRepo))
+
+	mockMenuItemRepo.On("FindMenuItemById", mock.Anything, 1).
+		Return(domain.MenuItem{ID: 1, Name: "Pizza", Price: domain.NewMoney(1000, "USD"), RestaurantID: 1}, nil)
+	mockMemberRepo.On("FindRestaurantMember", mock.Anything, 1, 5).
+		Return(domain.NewRestaurantMember(1, 5, domain.StaffManager), nil)
+	mockMenuItemRepo.On("DeleteMenuItem", mock.Anything, 1).
+		Return(nil)
+
+	ctx := authctx.WithUserClaims(t.Context(), &authctx.UserClaims{UserID: 5, Role: domain.STAFF})
+
+	err := service.DeleteMenuItem(ctx, 1)
+	require.NoError(t, err)
+	mockMenuItemRepo.AssertExpectations(t)
+	mockMemberRepo.AssertExpectations(t)
+}
+
+func Test_services_MenuItemService_DeleteMenuItem_when_already_deleted(t *testing.T) {
+	mockMenuItemRepo := mockrepository.MenuItemRepository{}
+	mockMenuRepo := mockrepository.MenuRepository{}
+	mockRestaurantRepo := mockrepository.RestaurantRepository{}
+	service := NewMenuItemsService(&mockMenuItemRepo, &mockMenuRepo, NewAuthorizer(&mockRestaurantRepo, &mockrepository.RestaurantMemberRepository{}))
+
+	mockMenuItemRepo.On("FindMenuItemById", mock.Anything, 1).
+		Return(domain.MenuItem{ID: 1, Name: "Pizza", Price: domain.NewMoney(1000, "USD"), RestaurantID: 1, Deleted: true}, nil)
+
+	ctx := authctx.WithUserClaims(t.Context(), &authctx.UserClaims{UserID: 1, Role: domain.OWNER})
+
+	err := service.DeleteMenuItem(ctx, 1)
+	require.True(t, apperr.IsNotFoundError(err), "expected not found error but got %v", err)
+	mockMenuItemRepo.AssertNotCalled(t, "DeleteMenuItem", mock.Anything, mock.Anything)
+}
+
+func Test_services_MenuItemService_DeleteMenuItem_when_invalid_id(t *testing.T) {
+	service := NewMenuItemsService(&mockrepository.MenuItemRepository{}, &mockrepository.MenuRepository{}, NewAuthorizer(&mockrepository.RestaurantRepository{}, &mockrepository.RestaurantMemberRepository{}))
+
+	err := service.DeleteMenuItem(t.Context(), 0)
+	require.True(t, apperr.IsInvalidError(err), "expected invalid error but got %v", err)
+}
+
+func lunchMenu() domain.Menu {
+	return domain.Menu{
+		RestaurantID: 1,
+		Name:         "Lunch",
+		Schedules:    []domain.MenuSchedule{{Start: 660, End: 900}},
+		Categories:   []domain.MenuCategory{{Name: "Mains", ItemIDs: []int{1, 2}}},
+	}
+}
+
+func restaurantItems() []domain.MenuItem {
+	return []domain.MenuItem{
+		{ID: 1, Name: "Pizza", Price: domain.NewMoney(1000, "USD"), Available: true, RestaurantID: 1},
+		{ID: 2, Name: "Pasta", Price: domain.NewMoney(900, "USD"), Available: true, RestaurantID: 1},
+	}
+}
+
+func Test_services_MenuItemService_GetMenusByRestaurantId(t *testing.T) {
+	mockMenuItemRepo := mockrepository.MenuItemRepository{}
+	mockMenuRepo := mockrepository.MenuRepository{}
+	service := NewMenuItemsService(&mockMenuItemRepo, &mockMenuRepo, NewAuthorizer(&mockrepository.RestaurantRepository{}, &mockrepository.RestaurantMemberRepository{}))
+
+	lunch := lunchMenu()
+	lunch.ID = 1
+	dinner := domain.Menu{ID: 2, RestaurantID: 1, Name: "Dinner", Schedules: []domain.MenuSchedule{{Start: 1080, End: 1320}}}
+	mockMenuItemRepo.On("FindMenuItemsByRestaurantId", mock.Anything, 1).Return(restaurantItems(), nil)
+	mockMenuRepo.On("FindMenusByRestaurantId", mock.Anything, 1).Return([]domain.Menu{lunch, dinner}, nil)
+
+	// every menu is listed, whether it is offered right now or not
+	menus, err := service.GetMenusByRestaurantId(t.Context(), 1)
+	require.NoError(t, err)
+	require.Len(t, menus, 2)
+	require.Equal(t, restaurantItems(), menus[0].Categories[0].Items)
+	require.Equal(t, "Dinner", menus[1].Name)
+
+	_, err = service.GetMenusByRestaurantId(t.Context(), 0)
+	require.True(t, apperr.IsInvalidError(err), "expected invalid error but got %v", err)
+	mockMenuItemRepo.AssertExpectations(t)
+	mockMenuRepo.AssertExpectations(t)
+}
+
+func Test_services_MenuItemService_CreateMenu(t *testing.T) {
+	mockMenuItemRepo := mockrepository.MenuItemRepository{}
+	mockMenuRepo := mockrepository.MenuRepository{}
+	mockRestaurantRepo := mockrepository.RestaurantRepository{}
+	service := NewMenuItemsService(&mockMenuItemRepo, &mockMenuRepo, NewAuthorizer(&mockRestaurantRepo, &mockrepository.RestaurantMemberRepository{}))
+
+	mockRestaurantRepo.On("FindRestaurantById", mock.Anything, 1).
+		Return(domain.Restaurant{ID: 1, Name: "Restaurant 1", OwnerID: 1}, nil)
+	mockMenuItemRepo.On("FindMenuItemsByRestaurantId", mock.Anything, 1).Return(restaurantItems(), nil)
+	mockMenuRepo.On("SaveMenu", mock.Anything, lunchMenu()).Return(3, nil)
+
+	ctx := authctx.WithUserClaims(t.Context(), &authctx.UserClaims{UserID: 1, Role: domain.OWNER})
+
+	id, err := service.CreateMenu(ctx, lunchMenu())
+	require.NoError(t, err)
+	require.Equal(t, 3, id)
+	mockMenuItemRepo.AssertExpectations(t)
+	mockMenuRepo.AssertExpectations(t)
+	mockRestaurantRepo.AssertExpectations(t)
+}
+
+func Test_services_MenuItemService_CreateMenu_when_invalid(t *testing.T) {
+	mockMenuRepo := mockrepository.MenuRepository{}
+	service := NewMenuItemsService(&mockrepository.MenuItemRepository{}, &mockMenuRepo, NewAuthorizer(&mockrepository.RestaurantRepository{}, &mockrepository.RestaurantMemberRepository{}))
+	ctx := authctx.WithUserClaims(t.Context(), &authctx.UserClaims{UserID: 1, Role: domain.OWNER})
+
+	menu := lunchMenu()
+	menu.Schedules = []domain.MenuSchedule{{Start: -1, End: 900}}
+
+	_, err := service.CreateMenu(ctx, menu)
+	require.True(t, apperr.IsInvalidError(err), "expected invalid error but got %v", err)
+	mockMenuRepo.AssertNotCalled(t, "SaveMenu", mock.Anything, mock.Anything)
+}
+
+func Test_services_MenuItemService_CreateMenu_when_item_of_other_restaurant(t *testing.T) {
+	mockMenuItemRepo := mockrepository.MenuItemRepository{}
+	mockMenuRepo := mockrepository.MenuRepository{}
+	mockRestaurantRepo := mockrepository.RestaurantRepository{}
+	service := NewMenuItemsService(&mockMenuItemRepo, &mockMenuRepo, NewAuthorizer(&mockRestaurantRepo, &mockrepository.RestaurantMemberRepository{}))
+
+	mockRestaurantRepo.On("FindRestaurantById", mock.Anything, 1).
+		Return(domain.Restaurant{ID: 1, Name: "Restaurant 1", OwnerID: 1}, nil)
+	mockMenuItemRepo.On("FindMenuItemsByRestaurantId", mock.Anything, 1).Return(restaurantItems(), nil)
+
+	ctx := authctx.WithUserClaims(t.Context(), &authctx.UserClaims{UserID: 1, Role: domain.OWNER})
+	menu := lunchMenu()
+	menu.Categories[0].ItemIDs = []int{1, 7}
+
+	_, err := service.CreateMenu(ctx, menu)
+	require.True(t, apperr.IsInvalidError(err), "expected invalid error but got %v", err)
+	mockMenuRepo.AssertNotCalled(t, "SaveMenu", mock.Anything, mock.Anything)
+}
+
+func Test_services_MenuItemService_CreateMenu_when_forbidden(t *testing.T) {
+	mockMenuRepo := mockrepository.MenuRepository{}
+	mockRestaurantRepo := mockrepository.RestaurantRepository{}
+	service := NewMenuItemsService(&mockrepository.MenuItemRepository{}, &mockMenuRepo, NewAuthorizer(&mockRestaurantRepo, &mockrepository.RestaurantMemberRepository{}))
+
+	mockRestaurantRepo.On("FindRestaurantById", mock.Anything, 1).
+		Return(domain.Restaurant{ID: 1, Name: "Restaurant 1", OwnerID: 2}, nil)
+
+	ctx := authctx.WithUserClaims(t.Context(), &authctx.UserClaims{UserID: 1, Role: domain.OWNER})
+
+	_, err := service.CreateMenu(ctx, lunchMenu())
+	require.True(t, apperr.IsForbiddenError(err), "expected forbidden error but got %v", err)
+	mockMenuRepo.AssertNotCalled(t, "SaveMenu", mock.Anything, mock.Anything)
+}
+
+func Test_services_MenuItemService_UpdateMenu_when_manager_staff(t *testing.T) {
+	mockMenuItemRepo := mockrepository.MenuItemRepository{}
+	mockMenuRepo := mockrepository.MenuRepository{}
+	mockMemberRepo := mockrepository.RestaurantMemberRepository{}
+	service := NewMenuItemsService(&mockMenuItemRepo, &mockMenuRepo, NewAuthorizer(&mockrepository.RestaurantRepository{}, &mockMemberRepo))
+
+	existing := lunchMenu()
+	existing.ID = 3
+	mockMenuRepo.On("FindMenuById", mock.Anything, 3).Return(existing, nil)
+	mockMemberRepo.On("FindRestaurantMember", mock.Anything, 1, 5).
+		Return(domain.NewRestaurantMember(1, 5, domain.StaffManager), nil)
+	mockMenuItemRepo.On("FindMenuItemsByRestaurantId", mock.Anything, 1).Return(restaurantItems(), nil)
+
+	// the menu stays in its restaurant
+	updated := domain.Menu{ID: 3, Name: "Brunch", Categories: []domain.MenuCategory{{Name: "Mains", ItemIDs: []int{2}}}}
+	expected := updated
+	expected.RestaurantID = 1
+	mockMenuRepo.On("UpdateMenu", mock.Anything, expected).Return(nil)
+
+	ctx := authctx.WithUserClaims(t.Context(), &authctx.UserClaims{UserID: 5, Role: domain.STAFF})
+
+	err := service.UpdateMenu(ctx, updated)
+	require.NoError(t, err)
+	mockMenuItemRepo.AssertExpectations(t)
+	mockMenuRepo.AssertExpectations(t)
+	mockMemberRepo.AssertExpectations(t)
+}
+
+func Test_services_MenuItemService_UpdateMenu_when_not_found(t *testing.T) {
+	mockMenuRepo := mockrepository.MenuRepository{}
+	service := NewMenuItemsService(&mockrepository.MenuItemRepository{}, &mockMenuRepo, NewAuthorizer(&mockrepository.RestaurantRepository{}, &mockrepository.RestaurantMemberRepository{}))
+
+	mockMenuRepo.On("FindMenuById", mock.Anything, 3).
+		Return(domain.Menu{}, apperr.NewAppError(apperr.ErrNotFound, "menu not found", nil))
+
+	ctx := authctx.WithUserClaims(t.Context(), &authctx.UserClaims{UserID: 1, Role: domain.OWNER})
+
+	err := service.UpdateMenu(ctx, domain.Menu{ID: 3, Name: "Brunch"})
+	require.True(t, apperr.IsNotFoundError(err), "expected not found error but got %v", err)
+	mockMenuRepo.AssertNotCalled(t, "UpdateMenu", mock.Anything, mock.Anything)
+}
+
+func Test_services_MenuItemService_DeleteMenu_when_not_found(t *testing.T) {
+	mockMenuRepo := mockrepository.MenuRepository{}
+	mockRestaurantRepo := mockrepository.RestaurantRepository{}
+	service := NewMenuItemsService(&mockrepository.MenuItemRepository{}, &mockMenuRepo, NewAuthorizer(&mockRestaurantRepo, &mockrepository.RestaurantMemberRepository{}))
+
+	mockMenuRepo.On("FindMenuById", mock.Anything, 3).Return(domain.Menu{}, nil)
+
+	ctx := authctx.WithUserClaims(t.Context(), &authctx.UserClaims{UserID: 1, Role: domain.OWNER})
+
+	err := service.DeleteMenu(ctx, 3)
+	require.True(t, apperr.IsNotFoundError(err), "expected not found error but got %v", err)
+	mockRestaurantRepo.AssertNotCalled(t, "FindRestaurantById", mock.Anything, mock.Anything)
+	mockMenuRepo.AssertNotCalled(t, "DeleteMenu", mock.Anything, mock.Anything)
+}
+
+func Test_services_MenuItemService_DeleteMenu(t *testing.T) {
+	mockMenuRepo := mockrepository.MenuRepository{}
+	mockRestaurantRepo := mockrepository.RestaurantRepository{}
+	service := NewMenuItemsService(&mockrepository.MenuItemRepository{}, &mockMenuRepo, NewAuthorizer(&mockRestaurantRepo, &mockrepository.RestaurantMemberRepository{}))
+
+	existing := lunchMenu()
+	existing.ID = 3
+	mockMenuRepo.On("FindMenuById", mock.Anything, 3).Return(existing, nil)
+	mockRestaurantRepo.On("FindRestaurantById", mock.Anything, 1).
+		Return(domain.Restaurant{ID: 1, Name: "Restaurant 1", OwnerID: 1}, nil)
+	mockMenuRepo.On("DeleteMenu", mock.Anything, 3).Return(nil)
+
+	ctx := authctx.WithUserClaims(t.Context(), &authctx.UserClaims{UserID: 1, Role: domain.OWNER})
+
+	err := service.DeleteMenu(ctx, 3)
+	require.NoError(t, err)
+
+	err = service.DeleteMenu(ctx, 0)
+	require.True(t, apperr.IsInvalidError(err), "expected invalid error but got %v", err)
+	mockMenuRepo.AssertExpectations(t)
+	mockRestaurantRepo.AssertExpectations(t)
+}
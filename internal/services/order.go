@@ -2,23 +2,56 @@ package services
 
 import (
 	"context"
+	"time"
 
 	"github.com/mohits-git/food-ordering-system/internal/domain"
 	"github.com/mohits-git/food-ordering-system/internal/ports"
 	"github.com/mohits-git/food-ordering-system/internal/utils/apperr"
-	"github.com/mohits-git/food-ordering-system/internal/utils/authctx"
 )
 
 type OrderService struct {
-	orderRepo    ports.OrderRepository
-	menuItemRepo ports.MenuItemRepository
+	orderRepo      ports.OrderRepository
+	restaurantRepo ports.RestaurantRepository
+	menuItemRepo   ports.MenuItemRepository
+	menuRepo       ports.MenuRepository
+	invoiceRepo    ports.InvoiceRepository
+	authorizer     ports.Authorizer
+	// now is the time the opening hours of the restaurants and the schedules
+	// of the menus are checked against
+	now func() time.Time
 }
 
-func NewOrderService(orderRepo ports.OrderRepository, menuItemRepo ports.MenuItemRepository) *OrderService {
-	return &OrderService{orderRepo, menuItemRepo}
+func NewOrderService(
+	orderRepo ports.OrderRepository,
+	restaurantRepo ports.RestaurantRepository,
+	menuItemRepo ports.MenuItemRepository,
+	menuRepo ports.MenuRepository,
+	invoiceRepo ports.InvoiceRepository,
+	authorizer ports.Authorizer,
+) *OrderService {
+	return &OrderService{orderRepo, restaurantRepo, menuItemRepo, menuRepo, invoiceRepo, authorizer, time.Now}
 }
 
-func (s *OrderService) getRestaurantItemsMap(ctx context.Context, restaurantId int) (map[int]bool, error) {
+// checkRestaurantOpen rejects orders to a restaurant that is closed or has
+// paused its orders right now.
+func (s *OrderService) checkRestaurantOpen(ctx context.Context, restaurantId int) error {
+	restaurant, err := s.restaurantRepo.FindRestaurantById(ctx, restaurantId)
+	if err != nil {
+		return err
+	}
+	if restaurant.ID == 0 {
+		return apperr.NewAppError(apperr.ErrInvalid, "restaurant not found", nil)
+	}
+	if restaurant.OrdersPaused {
+		return apperr.NewAppError(apperr.ErrConflict, "restaurant is not taking orders right now", nil)
+	}
+	if !restaurant.IsOpenAt(s.now()) {
+		return apperr.NewAppError(apperr.ErrConflict, "restaurant is closed", nil)
+	}
+	return nil
+}
+
+func (s *OrderService) getRestaurantItemsMap(ctx context.Context, restaurantId int) (map[int]domain.MenuItem, error) {
 	restaurantItems, err := s.menuItemRepo.FindMenuItemsByRestaurantId(ctx, restaurantId)
 	if err != nil {
 		return nil, err
@@ -26,20 +59,44 @@ func (s *OrderService) getRestaurantItemsMap(ctx context.Context, restaurantId i
 	if len(restaurantItems) == 0 {
 		return nil, apperr.NewAppError(apperr.ErrInvalid, "restaurant has no menu items", nil)
 	}
-	restaurantItemMap := make(map[int]bool)
+	restaurantItemMap := make(map[int]domain.MenuItem)
 	for _, item := range restaurantItems {
-		restaurantItemMap[item.ID] = item.Available
+		restaurantItemMap[item.ID] = item
 	}
 	return restaurantItemMap, nil
 }
 
+// checkActiveMenus rejects the items that are not on a menu of the restaurant
+// offered right now.
+func (s *OrderService) checkActiveMenus(ctx context.Context, restaurantId int, menuItemIds ...int) error {
+	menus, err := s.menuRepo.FindMenusByRestaurantId(ctx, restaurantId)
+	if err != nil {
+		return err
+	}
+	now := s.now()
+	for _, id := range menuItemIds {
+		if !domain.IsOnActiveMenu(menus, id, now) {
+			return apperr.NewAppError(apperr.ErrInvalid, "menu item is not on an active menu", nil)
+		}
+	}
+	return nil
+}
+
+// checkStock rejects ordering more of a menu item than it has left, the stock
+// is only taken once the order is placed.
+func checkStock(menuItem domain.MenuItem, quantity int) error {
+	if !menuItem.HasStockFor(quantity) {
+		return apperr.NewAppError(apperr.ErrConflict, "not enough stock of "+menuItem.Name, nil)
+	}
+	return nil
+}
+
 func (s *OrderService) CreateOrder(ctx context.Context, order domain.Order) (int, error) {
-	user, ok := authctx.UserClaimsFromCtx(ctx)
-	if !ok {
-		return 0, apperr.NewAppError(apperr.ErrUnauthorized, "user not authenticated", nil)
+	if _, err := s.authorizer.AuthorizeResource(ctx, domain.ActionCreateOrder, domain.Resource{CustomerID: order.CustomerID}); err != nil {
+		return 0, err
 	}
-	if user.Role != domain.CUSTOMER || user.UserID != order.CustomerID {
-		return 0, apperr.NewAppError(apperr.ErrForbidden, "only customers can create orders", nil)
+	if err := s.checkRestaurantOpen(ctx, order.RestaurantID); err != nil {
+		return 0, err
 	}
 
 	restaurantItemsMap, err := s.getRestaurantItemsMap(ctx, order.RestaurantID)
@@ -50,7 +107,33 @@ func (s *OrderService) CreateOrder(ctx context.Context, order domain.Order) (int
 	if ok := order.Validate(restaurantItemsMap); !ok {
 		return 0, apperr.NewAppError(apperr.ErrInvalid, "invalid order data", nil)
 	}
+	menuItemIds := make([]int, 0, len(order.OrderItems))
+	for _, item := range order.OrderItems {
+		menuItemIds = append(menuItemIds, item.MenuItemID)
+	}
+	if err := s.checkActiveMenus(ctx, order.RestaurantID, menuItemIds...); err != nil {
+		return 0, err
+	}
+	quantities := order.Quantities()
+	for _, item := range order.OrderItems {
+		if err := checkStock(restaurantItemsMap[item.MenuItemID], quantities[item.MenuItemID]); err != nil {
+			return 0, err
+		}
+	}
 
+	// snapshot the menu items and options so the order is billed at the price
+	// it was made
+	orderItems := make([]domain.OrderItem, 0, len(order.OrderItems))
+	for _, item := range order.OrderItems {
+		menuItem := restaurantItemsMap[item.MenuItemID]
+		item.Options, _ = menuItem.SelectOptions(item.OptionIDs())
+		item.Name = menuItem.Name
+		item.UnitPrice = menuItem.PriceWith(item.Options)
+		orderItems = append(orderItems, item)
+	}
+	order.OrderItems = orderItems
+
+	order.Status = domain.OrderDraft
 	id, err := s.orderRepo.SaveOrder(ctx, order)
 	if err != nil {
 		return 0, err
@@ -64,48 +147,140 @@ func (s *OrderService) GetOrderById(ctx context.Context, id int) (domain.Order,
 		return domain.Order{}, apperr.NewAppError(apperr.ErrInvalid, "invalid order id", nil)
 	}
 
-	user, ok := authctx.UserClaimsFromCtx(ctx)
-	if !ok {
-		return domain.Order{}, apperr.NewAppError(apperr.ErrUnauthorized, "user not authenticated", nil)
-	}
-
-	if user.Role != domain.CUSTOMER {
-		return domain.Order{}, apperr.NewAppError(apperr.ErrForbidden, "only customers can access orders", nil)
+	if _, err := s.authorizer.Authorize(ctx, domain.ActionViewOrder); err != nil {
+		return domain.Order{}, err
 	}
 
 	order, err := s.orderRepo.FindOrderById(ctx, id)
 	if err != nil {
 		return domain.Order{}, err
 	}
-
-	if order.CustomerID != user.UserID {
-		return domain.Order{}, apperr.NewAppError(apperr.ErrForbidden, "access to the order is forbidden", nil)
+	if order.ID == 0 {
+		return domain.Order{}, apperr.NewAppError(apperr.ErrNotFound, "order not found", nil)
+	}
+	if _, err := s.authorizer.AuthorizeResource(ctx, domain.ActionViewOrder, orderResource(order)); err != nil {
+		return domain.Order{}, err
 	}
 
 	return order, nil
 }
 
-func (s *OrderService) AddOrderItem(ctx context.Context, orderId int, item domain.OrderItem) error {
-	if orderId <= 0 || !item.Validate() {
-		return apperr.NewAppError(apperr.ErrInvalid, "invalid input data", nil)
+const (
+	defaultOrdersPageSize = 20
+	maxOrdersPageSize     = 100
+)
+
+func (s *OrderService) GetCustomerOrders(ctx context.Context, filter domain.OrderFilter) ([]domain.Order, int, error) {
+	if err := validateOrderFilter(filter); err != nil {
+		return nil, 0, err
 	}
 
-	// authorize access to orders resource
-	user, ok := authctx.UserClaimsFromCtx(ctx)
-	if !ok {
-		return apperr.NewAppError(apperr.ErrUnauthorized, "user not authenticated", nil)
+	user, err := s.authorizer.Authorize(ctx, domain.ActionViewOrderHistory)
+	if err != nil {
+		return nil, 0, err
 	}
-	if user.Role != domain.CUSTOMER {
-		return apperr.NewAppError(apperr.ErrForbidden, "only customers can modify orders", nil)
+
+	return paginateOrders(filter, func(filter domain.OrderFilter) ([]domain.Order, error) {
+		return s.orderRepo.FindOrdersByCustomerId(ctx, user.UserID, filter)
+	})
+}
+
+// GetAllOrders lists the orders of every customer and restaurant, for admins.
+func (s *OrderService) GetAllOrders(ctx context.Context, filter domain.OrderFilter) ([]domain.Order, int, error) {
+	if filter.CustomerID < 0 {
+		return nil, 0, apperr.NewAppError(apperr.ErrInvalid, "invalid order filter", nil)
+	}
+	if err := validateOrderFilter(filter); err != nil {
+		return nil, 0, err
 	}
 
-	// authorize access to order
-	order, err := s.orderRepo.FindOrderById(ctx, orderId)
+	if _, err := s.authorizer.Authorize(ctx, domain.ActionViewAllOrders); err != nil {
+		return nil, 0, err
+	}
+
+	return paginateOrders(filter, func(filter domain.OrderFilter) ([]domain.Order, error) {
+		return s.orderRepo.FindOrders(ctx, filter)
+	})
+}
+
+func validateOrderFilter(filter domain.OrderFilter) error {
+	if filter.RestaurantID < 0 || filter.Cursor < 0 || filter.Limit < 0 {
+		return apperr.NewAppError(apperr.ErrInvalid, "invalid order filter", nil)
+	}
+	for _, status := range filter.Statuses {
+		if !status.IsValid() {
+			return apperr.NewAppError(apperr.ErrInvalid, "invalid order status filter", nil)
+		}
+	}
+	if !filter.From.IsZero() && !filter.To.IsZero() && !filter.From.Before(filter.To) {
+		return apperr.NewAppError(apperr.ErrInvalid, "invalid date range", nil)
+	}
+	return nil
+}
+
+// paginateOrders caps the page size of the filter and returns the page along
+// with the cursor of the next one, 0 when it is the last page.
+func paginateOrders(filter domain.OrderFilter, find func(domain.OrderFilter) ([]domain.Order, error)) ([]domain.Order, int, error) {
+	pageSize := filter.Limit
+	if pageSize == 0 {
+		pageSize = defaultOrdersPageSize
+	}
+	pageSize = min(pageSize, maxOrdersPageSize)
+
+	// fetch one extra order to know if there is a next page
+	filter.Limit = pageSize + 1
+	orders, err := find(filter)
 	if err != nil {
-		return err
+		return nil, 0, err
 	}
-	if order.CustomerID != user.UserID {
-		return apperr.NewAppError(apperr.ErrForbidden, "access to the order is forbidden", nil)
+
+	nextCursor := 0
+	if len(orders) > pageSize {
+		orders = orders[:pageSize]
+		nextCursor = orders[pageSize-1].ID
+	}
+	return orders, nextCursor, nil
+}
+
+// restaurantOrderStatuses are the statuses visible to a restaurant, draft
+// orders are still being put together by the customer.
+var restaurantOrderStatuses = []domain.OrderStatus{
+	domain.OrderPlaced,
+	domain.OrderAccepted,
+	domain.OrderPreparing,
+	domain.OrderReady,
+	domain.OrderDelivered,
+	domain.OrderCancelled,
+}
+
+func (s *OrderService) GetRestaurantOrders(ctx context.Context, restaurantId int, statuses []domain.OrderStatus) ([]domain.Order, error) {
+	if restaurantId <= 0 {
+		return nil, apperr.NewAppError(apperr.ErrInvalid, "invalid restaurant id", nil)
+	}
+	for _, status := range statuses {
+		if !status.IsValid() || status == domain.OrderDraft {
+			return nil, apperr.NewAppError(apperr.ErrInvalid, "invalid order status filter", nil)
+		}
+	}
+
+	if _, err := s.authorizer.AuthorizeResource(ctx, domain.ActionViewRestaurantOrders, domain.Resource{RestaurantID: restaurantId}); err != nil {
+		return nil, err
+	}
+
+	if len(statuses) == 0 {
+		statuses = restaurantOrderStatuses
+	}
+	return s.orderRepo.FindOrdersByRestaurantId(ctx, restaurantId, statuses)
+}
+
+func (s *OrderService) AddOrderItem(ctx context.Context, orderId int, item domain.OrderItem) error {
+	if orderId <= 0 || !item.Validate() {
+		return apperr.NewAppError(apperr.ErrInvalid, "invalid input data", nil)
+	}
+
+	order, err := s.getDraftOrderForCustomer(ctx, orderId)
+	if err != nil {
+		return err
 	}
 
 	// validation - check if item belongs to the same restaurant
@@ -116,12 +291,26 @@ func (s *OrderService) AddOrderItem(ctx context.Context, orderId int, item domai
 	if menuItem.ID == 0 || menuItem.RestaurantID != order.RestaurantID {
 		return apperr.NewAppError(apperr.ErrInvalid, "menu item does not belong to the restaurant of the order", nil)
 	}
-	if !menuItem.Available {
+	if !menuItem.IsAvailable() {
 		return apperr.NewAppError(apperr.ErrInvalid, "menu item is not available", nil)
 	}
+	if err := s.checkActiveMenus(ctx, order.RestaurantID, menuItem.ID); err != nil {
+		return err
+	}
+	if err := checkStock(menuItem, order.Quantities()[menuItem.ID]+item.Quantity); err != nil {
+		return err
+	}
+	options, ok := menuItem.SelectOptions(item.OptionIDs())
+	if !ok {
+		return apperr.NewAppError(apperr.ErrInvalid, "invalid options for menu item", nil)
+	}
+
+	if err := voidDraftInvoices(ctx, s.invoiceRepo, order.ID); err != nil {
+		return err
+	}
 
 	// save order
-	updatedOrder := s.addItemToOrder(order, item.MenuItemID, item.Quantity)
+	updatedOrder := s.addItemToOrder(order, menuItem, options, item.Quantity)
 	if err := s.orderRepo.UpdateOrder(ctx, updatedOrder); err != nil {
 		return err
 	}
@@ -129,16 +318,275 @@ func (s *OrderService) AddOrderItem(ctx context.Context, orderId int, item domai
 	return nil
 }
 
-func (o *OrderService) addItemToOrder(order domain.Order, menuItemID int, quantity int) domain.Order {
+// UpdateOrderItemQuantity sets the quantity of the line of a menu item. A menu
+// item ordered with different options is on several lines, those are changed
+// by their line id with UpdateOrderLineQuantity.
+func (s *OrderService) UpdateOrderItemQuantity(ctx context.Context, orderId int, menuItemId int, quantity int) error {
+	if orderId <= 0 || menuItemId <= 0 || quantity <= 0 {
+		return apperr.NewAppError(apperr.ErrInvalid, "invalid input data", nil)
+	}
+
+	order, err := s.getDraftOrderForCustomer(ctx, orderId)
+	if err != nil {
+		return err
+	}
+
+	lineId, err := lineOfMenuItem(order, menuItemId)
+	if err != nil {
+		return err
+	}
+	return s.setLineQuantity(ctx, order, lineId, quantity)
+}
+
+// UpdateOrderLineQuantity sets the quantity of a line of the order by its id.
+func (s *OrderService) UpdateOrderLineQuantity(ctx context.Context, orderId int, lineId int, quantity int) error {
+	if orderId <= 0 || lineId <= 0 || quantity <= 0 {
+		return apperr.NewAppError(apperr.ErrInvalid, "invalid input data", nil)
+	}
+
+	order, err := s.getDraftOrderForCustomer(ctx, orderId)
+	if err != nil {
+		return err
+	}
+	return s.setLineQuantity(ctx, order, lineId, quantity)
+}
+
+// RemoveOrderItem removes the line of a menu item, like
+// UpdateOrderItemQuantity a menu item on several lines is removed by line id.
+func (s *OrderService) RemoveOrderItem(ctx context.Context, orderId int, menuItemId int) error {
+	if orderId <= 0 || menuItemId <= 0 {
+		return apperr.NewAppError(apperr.ErrInvalid, "invalid input data", nil)
+	}
+
+	order, err := s.getDraftOrderForCustomer(ctx, orderId)
+	if err != nil {
+		return err
+	}
+
+	lineId, err := lineOfMenuItem(order, menuItemId)
+	if err != nil {
+		return err
+	}
+	return s.removeLine(ctx, order, lineId)
+}
+
+// RemoveOrderLine removes a line of the order by its id.
+func (s *OrderService) RemoveOrderLine(ctx context.Context, orderId int, lineId int) error {
+	if orderId <= 0 || lineId <= 0 {
+		return apperr.NewAppError(apperr.ErrInvalid, "invalid input data", nil)
+	}
+
+	order, err := s.getDraftOrderForCustomer(ctx, orderId)
+	if err != nil {
+		return err
+	}
+	return s.removeLine(ctx, order, lineId)
+}
+
+func (s *OrderService) setLineQuantity(ctx context.Context, order domain.Order, lineId int, quantity int) error {
+	// raising the quantity needs the stock for it, like adding the item does
+	for _, item := range order.OrderItems {
+		if item.ID == lineId && quantity > item.Quantity {
+			menuItem, err := s.menuItemRepo.FindMenuItemById(ctx, item.MenuItemID)
+			if err != nil {
+				return err
+			}
+			if err := checkStock(menuItem, order.Quantities()[item.MenuItemID]-item.Quantity+quantity); err != nil {
+				return err
+			}
+		}
+	}
+
+	updatedOrder, ok := s.setItemQuantityInOrder(order, lineId, quantity)
+	if !ok {
+		return apperr.NewAppError(apperr.ErrNotFound, "order item not found", nil)
+	}
+	if err := voidDraftInvoices(ctx, s.invoiceRepo, order.ID); err != nil {
+		return err
+	}
+	return s.orderRepo.UpdateOrder(ctx, updatedOrder)
+}
+
+func (s *OrderService) removeLine(ctx context.Context, order domain.Order, lineId int) error {
+	updatedOrder, ok := s.removeItemFromOrder(order, lineId)
+	if !ok {
+		return apperr.NewAppError(apperr.ErrNotFound, "order item not found", nil)
+	}
+	// an order without items is not valid, it has to be cancelled instead
+	if len(updatedOrder.OrderItems) == 0 {
+		return apperr.NewAppError(apperr.ErrInvalid, "cannot remove the last item of the order, cancel the order instead", nil)
+	}
+	if err := voidDraftInvoices(ctx, s.invoiceRepo, order.ID); err != nil {
+		return err
+	}
+	return s.orderRepo.UpdateOrder(ctx, updatedOrder)
+}
+
+// lineOfMenuItem finds the only line of the order with the menu item.
+func lineOfMenuItem(order domain.Order, menuItemId int) (int, error) {
+	lineId := 0
+	for _, item := range order.OrderItems {
+		if item.MenuItemID != menuItemId {
+			continue
+		}
+		if lineId != 0 {
+			return 0, apperr.NewAppError(apperr.ErrConflict, "menu item is on several lines of the order, change the line by its id", nil)
+		}
+		lineId = item.ID
+	}
+	if lineId == 0 {
+		return 0, apperr.NewAppError(apperr.ErrNotFound, "order item not found", nil)
+	}
+	return lineId, nil
+}
+
+// getDraftOrderForCustomer loads an order which the current customer can
+// still modify.
+func (s *OrderService) getDraftOrderForCustomer(ctx context.Context, orderId int) (domain.Order, error) {
+	if _, err := s.authorizer.Authorize(ctx, domain.ActionModifyOrder); err != nil {
+		return domain.Order{}, err
+	}
+
+	order, err := s.orderRepo.FindOrderById(ctx, orderId)
+	if err != nil {
+		return domain.Order{}, err
+	}
+	if order.ID == 0 {
+		return domain.Order{}, apperr.NewAppError(apperr.ErrNotFound, "order not found", nil)
+	}
+	if _, err := s.authorizer.AuthorizeResource(ctx, domain.ActionModifyOrder, orderResource(order)); err != nil {
+		return domain.Order{}, err
+	}
+	if !order.IsDraft() {
+		return domain.Order{}, apperr.NewAppError(apperr.ErrInvalid, "order can no longer be modified", nil)
+	}
+	return order, nil
+}
+
+func (s *OrderService) TransitionOrder(ctx context.Context, orderId int, status domain.OrderStatus) error {
+	if orderId <= 0 || !status.IsValid() {
+		return apperr.NewAppError(apperr.ErrInvalid, "invalid input data", nil)
+	}
+
+	if _, err := s.authorizer.Authorize(ctx, domain.ActionTransitionOrder); err != nil {
+		return err
+	}
+	if status == domain.OrderPlaced {
+		return apperr.NewAppError(apperr.ErrForbidden, "orders are placed on payment", nil)
+	}
+
+	order, err := s.orderRepo.FindOrderById(ctx, orderId)
+	if err != nil {
+		return err
+	}
+	if order.ID == 0 {
+		return apperr.NewAppError(apperr.ErrNotFound, "order not found", nil)
+	}
+	if _, err := s.authorizer.AuthorizeResource(ctx, domain.ActionTransitionOrder, orderResource(order)); err != nil {
+		return err
+	}
+
+	// rejecting an order cancels it, which the kitchen is not allowed to do
+	if status == domain.OrderCancelled {
+		if _, err := s.authorizer.AuthorizeResource(ctx, domain.ActionCancelOrder, orderResource(order)); err != nil {
+			return err
+		}
+		return s.cancelOrder(ctx, order)
+	}
+
+	if !order.TransitionTo(status) {
+		return apperr.NewAppError(apperr.ErrInvalid, "cannot move order from "+string(order.Status)+" to "+string(status), nil)
+	}
+
+	return s.orderRepo.UpdateOrderStatus(ctx, order.ID, order.Status)
+}
+
+func (s *OrderService) CancelOrder(ctx context.Context, orderId int) error {
+	if orderId <= 0 {
+		return apperr.NewAppError(apperr.ErrInvalid, "invalid order id", nil)
+	}
+
+	if _, err := s.authorizer.Authorize(ctx, domain.ActionCancelOrder); err != nil {
+		return err
+	}
+
+	order, err := s.orderRepo.FindOrderById(ctx, orderId)
+	if err != nil {
+		return err
+	}
+	if order.ID == 0 {
+		return apperr.NewAppError(apperr.ErrNotFound, "order not found", nil)
+	}
+	user, err := s.authorizer.AuthorizeResource(ctx, domain.ActionCancelOrder, orderResource(order))
+	if err != nil {
+		return err
+	}
+
+	// restaurants and admins can cancel any order which is not finished yet
+	if user.Role != domain.CUSTOMER {
+		return s.cancelOrder(ctx, order)
+	}
+	// once accepted the restaurant has started working on the order
+	if order.Status != domain.OrderDraft && order.Status != domain.OrderPlaced {
+		return apperr.NewAppError(apperr.ErrInvalid, "order has already been accepted by the restaurant", nil)
+	}
+	return s.cancelOrder(ctx, order)
+}
+
+func (s *OrderService) cancelOrder(ctx context.Context, order domain.Order) error {
+	if !order.TransitionTo(domain.OrderCancelled) {
+		return apperr.NewAppError(apperr.ErrInvalid, "cannot cancel order in "+string(order.Status)+" status", nil)
+	}
+	if err := s.orderRepo.UpdateOrderStatus(ctx, order.ID, order.Status); err != nil {
+		return err
+	}
+	return cancelInvoices(ctx, s.invoiceRepo, order.ID)
+}
+
+func orderResource(order domain.Order) domain.Resource {
+	return domain.Resource{CustomerID: order.CustomerID, RestaurantID: order.RestaurantID}
+}
+
+// addItemToOrder adds quantity to an existing line with the same options,
+// which keeps the price it was first added at, or adds a new line with the
+// current menu item and option prices.
+func (o *OrderService) addItemToOrder(order domain.Order, menuItem domain.MenuItem, options []domain.OrderItemOption, quantity int) domain.Order {
 	if quantity <= 0 {
 		return order
 	}
+	newItem := domain.OrderItem{
+		MenuItemID: menuItem.ID,
+		Quantity:   quantity,
+		Name:       menuItem.Name,
+		UnitPrice:  menuItem.PriceWith(options),
+		Options:    options,
+	}
 	for i, item := range order.OrderItems {
-		if item.MenuItemID == menuItemID {
+		if item.SameLine(newItem) {
 			order.OrderItems[i].Quantity += quantity
 			return order
 		}
 	}
-	order.OrderItems = append(order.OrderItems, domain.OrderItem{MenuItemID: menuItemID, Quantity: quantity})
+	order.OrderItems = append(order.OrderItems, newItem)
 	return order
 }
+
+func (o *OrderService) setItemQuantityInOrder(order domain.Order, itemID int, quantity int) (domain.Order, bool) {
+	for i, item := range order.OrderItems {
+		if item.ID == itemID {
+			order.OrderItems[i].Quantity = quantity
+			return order, true
+		}
+	}
+	return order, false
+}
+
+func (o *OrderService) removeItemFromOrder(order domain.Order, itemID int) (domain.Order, bool) {
+	for i, item := range order.OrderItems {
+		if item.ID == itemID {
+			order.OrderItems = append(order.OrderItems[:i], order.OrderItems[i+1:]...)
+			return order, true
+		}
+	}
+	return order, false
+}
@@ -2,8 +2,10 @@ package services
 
 import (
 	"testing"
+	"time"
 
 	"github.com/mohits-git/food-ordering-system/internal/domain"
+	"github.com/mohits-git/food-ordering-system/internal/utils/apperr"
 	"github.com/mohits-git/food-ordering-system/internal/utils/authctx"
 	mockrepository "github.com/mohits-git/food-ordering-system/tests/mock_repository"
 	"github.com/stretchr/testify/mock"
@@ -13,33 +15,42 @@ import (
 func Test_services_OrderService_NewOrderService(t *testing.T) {
 	mockOrderRepo := mockrepository.OrderRepository{}
 	mockMenuItemRepo := mockrepository.MenuItemRepository{}
-	service := NewOrderService(&mockOrderRepo, &mockMenuItemRepo)
+	mockMenuRepo := mockrepository.MenuRepository{}
+	mockRestaurantRepo := mockrepository.RestaurantRepository{}
+	mockInvoiceRepo := mockrepository.InvoiceRepository{}
+	service := NewOrderService(&mockOrderRepo, &mockRestaurantRepo, &mockMenuItemRepo, &mockMenuRepo, &mockInvoiceRepo, NewAuthorizer(&mockRestaurantRepo, &mockrepository.RestaurantMemberRepository{}))
 	require.NotNil(t, service)
 }
 
 func Test_services_OrderService_getRestaurantItemsMap(t *testing.T) {
 	mockOrderRepo := mockrepository.OrderRepository{}
 	mockMenuItemRepo := mockrepository.MenuItemRepository{}
-	service := NewOrderService(&mockOrderRepo, &mockMenuItemRepo)
+	mockMenuRepo := mockrepository.MenuRepository{}
+	mockRestaurantRepo := mockrepository.RestaurantRepository{}
+	mockInvoiceRepo := mockrepository.InvoiceRepository{}
+	service := NewOrderService(&mockOrderRepo, &mockRestaurantRepo, &mockMenuItemRepo, &mockMenuRepo, &mockInvoiceRepo, NewAuthorizer(&mockRestaurantRepo, &mockrepository.RestaurantMemberRepository{}))
 
 	mockMenuItemRepo.On("FindMenuItemsByRestaurantId", mock.Anything, 1).
 		Return([]domain.MenuItem{
-			{ID: 1, Name: "Item 1", Price: 100, Available: true, RestaurantID: 1},
-			{ID: 2, Name: "Item 2", Price: 200, Available: false, RestaurantID: 1},
+			{ID: 1, Name: "Item 1", Price: domain.NewMoney(10000, "USD"), Available: true, RestaurantID: 1},
+			{ID: 2, Name: "Item 2", Price: domain.NewMoney(20000, "USD"), Available: false, RestaurantID: 1},
 		}, nil)
 
 	itemsMap, err := service.getRestaurantItemsMap(t.Context(), 1)
 	require.NoError(t, err)
 	require.Len(t, itemsMap, 2)
-	require.True(t, itemsMap[1])
-	require.False(t, itemsMap[2])
+	require.Equal(t, "Item 1", itemsMap[1].Name)
+	require.False(t, itemsMap[2].Available)
 	mockMenuItemRepo.AssertExpectations(t)
 }
 
 func Test_services_OrderService_getRestaurantItemsMap_when_no_items(t *testing.T) {
 	mockOrderRepo := mockrepository.OrderRepository{}
 	mockMenuItemRepo := mockrepository.MenuItemRepository{}
-	service := NewOrderService(&mockOrderRepo, &mockMenuItemRepo)
+	mockMenuRepo := mockrepository.MenuRepository{}
+	mockRestaurantRepo := mockrepository.RestaurantRepository{}
+	mockInvoiceRepo := mockrepository.InvoiceRepository{}
+	service := NewOrderService(&mockOrderRepo, &mockRestaurantRepo, &mockMenuItemRepo, &mockMenuRepo, &mockInvoiceRepo, NewAuthorizer(&mockRestaurantRepo, &mockrepository.RestaurantMemberRepository{}))
 
 	mockMenuItemRepo.On("FindMenuItemsByRestaurantId", mock.Anything, 1).
 		Return([]domain.MenuItem{}, nil)
@@ -53,7 +64,12 @@ func Test_services_OrderService_getRestaurantItemsMap_when_no_items(t *testing.T
 func Test_services_OrderService_CreateOrder(t *testing.T) {
 	mockOrderRepo := mockrepository.OrderRepository{}
 	mockMenuItemRepo := mockrepository.MenuItemRepository{}
-	service := NewOrderService(&mockOrderRepo, &mockMenuItemRepo)
+	mockMenuRepo := mockrepository.MenuRepository{}
+	mockRestaurantRepo := mockrepository.RestaurantRepository{}
+	mockInvoiceRepo := mockrepository.InvoiceRepository{}
+	service := NewOrderService(&mockOrderRepo, &mockRestaurantRepo, &mockMenuItemRepo, &mockMenuRepo, &mockInvoiceRepo, NewAuthorizer(&mockRestaurantRepo, &mockrepository.RestaurantMemberRepository{}))
+	mockRestaurantRepo.On("FindRestaurantById", mock.Anything, 1).
+		Return(domain.Restaurant{ID: 1, Name: "Restaurant 1", OwnerID: 2}, nil)
 
 	order := domain.Order{
 		CustomerID:   1,
@@ -71,11 +87,22 @@ func Test_services_OrderService_CreateOrder(t *testing.T) {
 
 	mockMenuItemRepo.On("FindMenuItemsByRestaurantId", mock.Anything, 1).
 		Return([]domain.MenuItem{
-			{ID: 1, Name: "Item 1", Price: 100, Available: true, RestaurantID: 1},
-			{ID: 2, Name: "Item 2", Price: 200, Available: true, RestaurantID: 1},
+			{ID: 1, Name: "Item 1", Price: domain.NewMoney(10000, "USD"), Available: true, RestaurantID: 1},
+			{ID: 2, Name: "Item 2", Price: domain.NewMoney(20000, "USD"), Available: true, RestaurantID: 1},
 		}, nil)
+	mockMenuRepo.On("FindMenusByRestaurantId", mock.Anything, 1).
+		Return([]domain.Menu{}, nil)
 
-	mockOrderRepo.On("SaveOrder", mock.Anything, order).
+	savedOrder := domain.Order{
+		CustomerID:   1,
+		RestaurantID: 1,
+		Status:       domain.OrderDraft,
+		OrderItems: []domain.OrderItem{
+			{MenuItemID: 1, Quantity: 2, Name: "Item 1", UnitPrice: domain.NewMoney(10000, "USD")},
+			{MenuItemID: 2, Quantity: 1, Name: "Item 2", UnitPrice: domain.NewMoney(20000, "USD")},
+		},
+	}
+	mockOrderRepo.On("SaveOrder", mock.Anything, savedOrder).
 		Return(1, nil)
 
 	id, err := service.CreateOrder(authCtx, order)
@@ -85,10 +112,255 @@ func Test_services_OrderService_CreateOrder(t *testing.T) {
 	mockOrderRepo.AssertExpectations(t)
 }
 
+func Test_services_OrderService_CreateOrder_with_options(t *testing.T) {
+	mockOrderRepo := mockrepository.OrderRepository{}
+	mockMenuItemRepo := mockrepository.MenuItemRepository{}
+	mockMenuRepo := mockrepository.MenuRepository{}
+	mockRestaurantRepo := mockrepository.RestaurantRepository{}
+	mockInvoiceRepo := mockrepository.InvoiceRepository{}
+	service := NewOrderService(&mockOrderRepo, &mockRestaurantRepo, &mockMenuItemRepo, &mockMenuRepo, &mockInvoiceRepo, NewAuthorizer(&mockRestaurantRepo, &mockrepository.RestaurantMemberRepository{}))
+	mockRestaurantRepo.On("FindRestaurantById", mock.Anything, 1).
+		Return(domain.Restaurant{ID: 1, Name: "Restaurant 1", OwnerID: 2}, nil)
+
+	order := domain.Order{
+		CustomerID:   1,
+		RestaurantID: 1,
+		OrderItems: []domain.OrderItem{
+			{MenuItemID: 1, Quantity: 1, Options: []domain.OrderItemOption{{OptionID: 11}}},
+			{MenuItemID: 1, Quantity: 2, Options: []domain.OrderItemOption{{OptionID: 10}}},
+		},
+	}
+
+	authCtx := authctx.WithUserClaims(t.Context(), &authctx.UserClaims{
+		UserID: 1,
+		Role:   domain.CUSTOMER,
+	})
+
+	mockMenuItemRepo.On("FindMenuItemsByRestaurantId", mock.Anything, 1).
+		Return([]domain.MenuItem{pizzaMenuItem()}, nil)
+	mockMenuRepo.On("FindMenusByRestaurantId", mock.Anything, 1).
+		Return([]domain.Menu{}, nil)
+
+	savedOrder := domain.Order{
+		CustomerID:   1,
+		RestaurantID: 1,
+		Status:       domain.OrderDraft,
+		OrderItems: []domain.OrderItem{
+			{MenuItemID: 1, Quantity: 1, Name: "Pizza", UnitPrice: domain.NewMoney(1300, "USD"), Options: []domain.OrderItemOption{
+				{OptionID: 11, Name: "Large", PriceDelta: domain.NewMoney(300, "USD")},
+			}},
+			{MenuItemID: 1, Quantity: 2, Name: "Pizza", UnitPrice: domain.NewMoney(1000, "USD"), Options: []domain.OrderItemOption{
+				{OptionID: 10, Name: "Regular", PriceDelta: domain.NewMoney(0, "USD")},
+			}},
+		},
+	}
+	mockOrderRepo.On("SaveOrder", mock.Anything, savedOrder).
+		Return(1, nil)
+
+	id, err := service.CreateOrder(authCtx, order)
+	require.NoError(t, err)
+	require.Equal(t, 1, id)
+	mockOrderRepo.AssertExpectations(t)
+}
+
+func Test_services_OrderService_CreateOrder_when_options_invalid(t *testing.T) {
+	mockOrderRepo := mockrepository.OrderRepository{}
+	mockMenuItemRepo := mockrepository.MenuItemRepository{}
+	mockMenuRepo := mockrepository.MenuRepository{}
+	mockRestaurantRepo := mockrepository.RestaurantRepository{}
+	mockInvoiceRepo := mockrepository.InvoiceRepository{}
+	service := NewOrderService(&mockOrderRepo, &mockRestaurantRepo, &mockMenuItemRepo, &mockMenuRepo, &mockInvoiceRepo, NewAuthorizer(&mockRestaurantRepo, &mockrepository.RestaurantMemberRepository{}))
+	mockRestaurantRepo.On("FindRestaurantById", mock.Anything, 1).
+		Return(domain.Restaurant{ID: 1, Name: "Restaurant 1", OwnerID: 2}, nil)
+
+	// the size has to be chosen
+	order := domain.Order{
+		CustomerID:   1,
+		RestaurantID: 1,
+		OrderItems: []domain.OrderItem{
+			{MenuItemID: 1, Quantity: 1},
+		},
+	}
+
+	authCtx := authctx.WithUserClaims(t.Context(), &authctx.UserClaims{
+		UserID: 1,
+		Role:   domain.CUSTOMER,
+	})
+
+	mockMenuItemRepo.On("FindMenuItemsByRestaurantId", mock.Anything, 1).
+		Return([]domain.MenuItem{pizzaMenuItem()}, nil)
+
+	_, err := service.CreateOrder(authCtx, order)
+	require.True(t, apperr.IsInvalidError(err), "expected invalid error but got %v", err)
+	mockOrderRepo.AssertNotCalled(t, "SaveOrder", mock.Anything, mock.Anything)
+}
+
+// pizzaMenuItem has a required size and optional extras.
+func pizzaMenuItem() domain.MenuItem {
+	return domain.MenuItem{ID: 1, Name: "Pizza", Price: domain.NewMoney(1000, "USD"), Available: true, RestaurantID: 1, ModifierGroups: []domain.ModifierGroup{
+		{ID: 1, Name: "Size", MinSelect: 1, MaxSelect: 1, Options: []domain.ModifierOption{
+			{ID: 10, Name: "Regular", PriceDelta: domain.NewMoney(0, "USD")},
+			{ID: 11, Name: "Large", PriceDelta: domain.NewMoney(300, "USD")},
+		}},
+		{ID: 2, Name: "Extras", MinSelect: 0, MaxSelect: 2, Options: []domain.ModifierOption{
+			{ID: 20, Name: "Extra cheese", PriceDelta: domain.NewMoney(150, "USD")},
+			{ID: 21, Name: "Olives", PriceDelta: domain.NewMoney(100, "USD")},
+		}},
+	}}
+}
+
+// breakfastAndDinner are menus of restaurant 1, item 1 is served at breakfast
+// and item 2 at dinner
+func breakfastAndDinner() []domain.Menu {
+	return []domain.Menu{
+		{ID: 1, RestaurantID: 1, Name: "Breakfast", Schedules: []domain.MenuSchedule{{Start: 420, End: 660}}, Categories: []domain.MenuCategory{
+			{ID: 1, Name: "Mains", ItemIDs: []int{1}},
+		}},
+		{ID: 2, RestaurantID: 1, Name: "Dinner", Schedules: []domain.MenuSchedule{{Start: 1080, End: 1320}}, Categories: []domain.MenuCategory{
+			{ID: 2, Name: "Mains", ItemIDs: []int{2}},
+		}},
+	}
+}
+
+func Test_services_OrderService_CreateOrder_with_menus(t *testing.T) {
+	tests := []struct {
+		name    string
+		hour    int
+		itemId  int
+		wantErr bool
+	}{
+		{"breakfast item at breakfast", 8, 1, false},
+		{"dinner item at breakfast", 8, 2, true},
+		{"dinner item at dinner", 19, 2, false},
+		{"any item between menus", 15, 1, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockOrderRepo := mockrepository.OrderRepository{}
+			mockMenuItemRepo := mockrepository.MenuItemRepository{}
+			mockMenuRepo := mockrepository.MenuRepository{}
+			mockInvoiceRepo := mockrepository.InvoiceRepository{}
+			mockRestaurantRepo := mockrepository.RestaurantRepository{}
+			service := NewOrderService(&mockOrderRepo, &mockRestaurantRepo, &mockMenuItemRepo, &mockMenuRepo, &mockInvoiceRepo, NewAuthorizer(&mockRestaurantRepo, &mockrepository.RestaurantMemberRepository{}))
+			service.now = func() time.Time { return time.Date(2026, time.March, 2, tt.hour, 0, 0, 0, time.Local) }
+			mockRestaurantRepo.On("FindRestaurantById", mock.Anything, 1).
+				Return(domain.Restaurant{ID: 1, Name: "Restaurant 1", OwnerID: 2}, nil)
+
+			mockMenuItemRepo.On("FindMenuItemsByRestaurantId", mock.Anything, 1).
+				Return([]domain.MenuItem{
+					{ID: 1, Name: "Omelette", Price: domain.NewMoney(800, "USD"), Available: true, RestaurantID: 1},
+					{ID: 2, Name: "Steak", Price: domain.NewMoney(2500, "USD"), Available: true, RestaurantID: 1},
+				}, nil)
+			mockMenuRepo.On("FindMenusByRestaurantId", mock.Anything, 1).
+				Return(breakfastAndDinner(), nil)
+			mockOrderRepo.On("SaveOrder", mock.Anything, mock.Anything).
+				Return(1, nil).Maybe()
+
+			authCtx := authctx.WithUserClaims(t.Context(), &authctx.UserClaims{UserID: 1, Role: domain.CUSTOMER})
+			order := domain.Order{CustomerID: 1, RestaurantID: 1, OrderItems: []domain.OrderItem{{MenuItemID: tt.itemId, Quantity: 1}}}
+
+			id, err := service.CreateOrder(authCtx, order)
+			if tt.wantErr {
+				require.True(t, apperr.IsInvalidError(err), "expected invalid error but got %v", err)
+				mockOrderRepo.AssertNotCalled(t, "SaveOrder", mock.Anything, mock.Anything)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, 1, id)
+			}
+			mockMenuRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func Test_services_OrderService_CreateOrder_when_out_of_stock(t *testing.T) {
+	mockOrderRepo := mockrepository.OrderRepository{}
+	mockMenuItemRepo := mockrepository.MenuItemRepository{}
+	mockMenuRepo := mockrepository.MenuRepository{}
+	mockInvoiceRepo := mockrepository.InvoiceRepository{}
+	mockRestaurantRepo := mockrepository.RestaurantRepository{}
+	service := NewOrderService(&mockOrderRepo, &mockRestaurantRepo, &mockMenuItemRepo, &mockMenuRepo, &mockInvoiceRepo, NewAuthorizer(&mockRestaurantRepo, &mockrepository.RestaurantMemberRepository{}))
+	mockRestaurantRepo.On("FindRestaurantById", mock.Anything, 1).
+		Return(domain.Restaurant{ID: 1, Name: "Restaurant 1", OwnerID: 2}, nil)
+
+	mockMenuItemRepo.On("FindMenuItemsByRestaurantId", mock.Anything, 1).
+		Return([]domain.MenuItem{
+			{ID: 1, Name: "Cake", Price: domain.NewMoney(500, "USD"), Available: true, RestaurantID: 1, TrackStock: true, Stock: 3},
+			{ID: 2, Name: "Coffee", Price: domain.NewMoney(200, "USD"), Available: true, RestaurantID: 1},
+		}, nil)
+	mockMenuRepo.On("FindMenusByRestaurantId", mock.Anything, 1).
+		Return([]domain.Menu{}, nil)
+
+	authCtx := authctx.WithUserClaims(t.Context(), &authctx.UserClaims{UserID: 1, Role: domain.CUSTOMER})
+	// the lines add up to more cakes than are left
+	order := domain.Order{CustomerID: 1, RestaurantID: 1, OrderItems: []domain.OrderItem{
+		{MenuItemID: 2, Quantity: 5},
+		{MenuItemID: 1, Quantity: 2},
+		{MenuItemID: 1, Quantity: 2},
+	}}
+
+	_, err := service.CreateOrder(authCtx, order)
+	require.True(t, apperr.IsConflictError(err), "expected conflict error but got %v", err)
+	require.EqualError(t, err, "not enough stock of Cake")
+	mockOrderRepo.AssertNotCalled(t, "SaveOrder", mock.Anything, mock.Anything)
+}
+
+func Test_services_OrderService_CreateOrder_when_restaurant_closed(t *testing.T) {
+	// 2026-03-02 is a Monday
+	monday := time.Date(2026, time.March, 2, 3, 0, 0, 0, time.Local)
+	tests := []struct {
+		name       string
+		restaurant domain.Restaurant
+		wantErr    string
+	}{
+		{"open late on sunday", domain.Restaurant{ID: 1, OpeningHours: []domain.OpeningHours{{Weekday: time.Sunday, Start: 1080, End: 240}}}, ""},
+		{"outside opening hours", domain.Restaurant{ID: 1, OpeningHours: []domain.OpeningHours{{Weekday: time.Monday, Start: 660, End: 1320}}}, "restaurant is closed"},
+		{"holiday closure", domain.Restaurant{ID: 1, Closures: []string{"2026-03-02"}}, "restaurant is closed"},
+		{"orders paused", domain.Restaurant{ID: 1, OrdersPaused: true}, "restaurant is not taking orders right now"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockOrderRepo := mockrepository.OrderRepository{}
+			mockMenuItemRepo := mockrepository.MenuItemRepository{}
+			mockMenuRepo := mockrepository.MenuRepository{}
+			mockInvoiceRepo := mockrepository.InvoiceRepository{}
+			mockRestaurantRepo := mockrepository.RestaurantRepository{}
+			service := NewOrderService(&mockOrderRepo, &mockRestaurantRepo, &mockMenuItemRepo, &mockMenuRepo, &mockInvoiceRepo, NewAuthorizer(&mockRestaurantRepo, &mockrepository.RestaurantMemberRepository{}))
+			service.now = func() time.Time { return monday }
+			mockRestaurantRepo.On("FindRestaurantById", mock.Anything, 1).Return(tt.restaurant, nil)
+
+			mockMenuItemRepo.On("FindMenuItemsByRestaurantId", mock.Anything, 1).
+				Return([]domain.MenuItem{{ID: 1, Name: "Omelette", Price: domain.NewMoney(800, "USD"), Available: true, RestaurantID: 1}}, nil).Maybe()
+			mockMenuRepo.On("FindMenusByRestaurantId", mock.Anything, 1).
+				Return([]domain.Menu{}, nil).Maybe()
+			mockOrderRepo.On("SaveOrder", mock.Anything, mock.Anything).
+				Return(1, nil).Maybe()
+
+			authCtx := authctx.WithUserClaims(t.Context(), &authctx.UserClaims{UserID: 1, Role: domain.CUSTOMER})
+			order := domain.Order{CustomerID: 1, RestaurantID: 1, OrderItems: []domain.OrderItem{{MenuItemID: 1, Quantity: 1}}}
+
+			_, err := service.CreateOrder(authCtx, order)
+			if tt.wantErr == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.True(t, apperr.IsConflictError(err), "expected conflict error but got %v", err)
+			require.EqualError(t, err, tt.wantErr)
+			mockOrderRepo.AssertNotCalled(t, "SaveOrder", mock.Anything, mock.Anything)
+		})
+	}
+}
+
 func Test_services_OrderService_CreateOrder_when_invalid(t *testing.T) {
 	mockOrderRepo := mockrepository.OrderRepository{}
 	mockMenuItemRepo := mockrepository.MenuItemRepository{}
-	service := NewOrderService(&mockOrderRepo, &mockMenuItemRepo)
+	mockMenuRepo := mockrepository.MenuRepository{}
+	mockRestaurantRepo := mockrepository.RestaurantRepository{}
+	mockInvoiceRepo := mockrepository.InvoiceRepository{}
+	service := NewOrderService(&mockOrderRepo, &mockRestaurantRepo, &mockMenuItemRepo, &mockMenuRepo, &mockInvoiceRepo, NewAuthorizer(&mockRestaurantRepo, &mockrepository.RestaurantMemberRepository{}))
+	mockRestaurantRepo.On("FindRestaurantById", mock.Anything, 1).
+		Return(domain.Restaurant{ID: 1, Name: "Restaurant 1", OwnerID: 2}, nil)
 
 	order := domain.Order{
 		CustomerID:   1,
@@ -106,8 +378,8 @@ func Test_services_OrderService_CreateOrder_when_invalid(t *testing.T) {
 
 	mockMenuItemRepo.On("FindMenuItemsByRestaurantId", mock.Anything, 1).
 		Return([]domain.MenuItem{
-			{ID: 1, Name: "Item 1", Price: 100, Available: true, RestaurantID: 1},
-			{ID: 2, Name: "Item 2", Price: 200, Available: false, RestaurantID: 1},
+			{ID: 1, Name: "Item 1", Price: domain.NewMoney(10000, "USD"), Available: true, RestaurantID: 1},
+			{ID: 2, Name: "Item 2", Price: domain.NewMoney(20000, "USD"), Available: false, RestaurantID: 1},
 		}, nil)
 
 	id, err := service.CreateOrder(authCtx, order)
@@ -120,7 +392,10 @@ func Test_services_OrderService_CreateOrder_when_invalid(t *testing.T) {
 func Test_services_OrderService_CreateOrder_when_unauthorized(t *testing.T) {
 	mockOrderRepo := mockrepository.OrderRepository{}
 	mockMenuItemRepo := mockrepository.MenuItemRepository{}
-	service := NewOrderService(&mockOrderRepo, &mockMenuItemRepo)
+	mockMenuRepo := mockrepository.MenuRepository{}
+	mockRestaurantRepo := mockrepository.RestaurantRepository{}
+	mockInvoiceRepo := mockrepository.InvoiceRepository{}
+	service := NewOrderService(&mockOrderRepo, &mockRestaurantRepo, &mockMenuItemRepo, &mockMenuRepo, &mockInvoiceRepo, NewAuthorizer(&mockRestaurantRepo, &mockrepository.RestaurantMemberRepository{}))
 
 	order := domain.Order{
 		CustomerID:   1,
@@ -141,7 +416,10 @@ func Test_services_OrderService_CreateOrder_when_unauthorized(t *testing.T) {
 func Test_services_OrderService_CreateOrder_when_forbidden(t *testing.T) {
 	mockOrderRepo := mockrepository.OrderRepository{}
 	mockMenuItemRepo := mockrepository.MenuItemRepository{}
-	service := NewOrderService(&mockOrderRepo, &mockMenuItemRepo)
+	mockMenuRepo := mockrepository.MenuRepository{}
+	mockRestaurantRepo := mockrepository.RestaurantRepository{}
+	mockInvoiceRepo := mockrepository.InvoiceRepository{}
+	service := NewOrderService(&mockOrderRepo, &mockRestaurantRepo, &mockMenuItemRepo, &mockMenuRepo, &mockInvoiceRepo, NewAuthorizer(&mockRestaurantRepo, &mockrepository.RestaurantMemberRepository{}))
 
 	order := domain.Order{
 		CustomerID:   1,
@@ -167,12 +445,16 @@ func Test_services_OrderService_CreateOrder_when_forbidden(t *testing.T) {
 func Test_services_OrderService_GetOrderById(t *testing.T) {
 	mockOrderRepo := mockrepository.OrderRepository{}
 	mockMenuItemRepo := mockrepository.MenuItemRepository{}
-	service := NewOrderService(&mockOrderRepo, &mockMenuItemRepo)
+	mockMenuRepo := mockrepository.MenuRepository{}
+	mockRestaurantRepo := mockrepository.RestaurantRepository{}
+	mockInvoiceRepo := mockrepository.InvoiceRepository{}
+	service := NewOrderService(&mockOrderRepo, &mockRestaurantRepo, &mockMenuItemRepo, &mockMenuRepo, &mockInvoiceRepo, NewAuthorizer(&mockRestaurantRepo, &mockrepository.RestaurantMemberRepository{}))
 
 	order := domain.Order{
 		ID:           1,
 		CustomerID:   1,
 		RestaurantID: 1,
+		Status:       domain.OrderDraft,
 		OrderItems: []domain.OrderItem{
 			{MenuItemID: 1, Quantity: 2},
 			{MenuItemID: 2, Quantity: 1},
@@ -196,7 +478,10 @@ func Test_services_OrderService_GetOrderById(t *testing.T) {
 func Test_services_OrderService_GetOrderById_when_invalid_id(t *testing.T) {
 	mockOrderRepo := mockrepository.OrderRepository{}
 	mockMenuItemRepo := mockrepository.MenuItemRepository{}
-	service := NewOrderService(&mockOrderRepo, &mockMenuItemRepo)
+	mockMenuRepo := mockrepository.MenuRepository{}
+	mockRestaurantRepo := mockrepository.RestaurantRepository{}
+	mockInvoiceRepo := mockrepository.InvoiceRepository{}
+	service := NewOrderService(&mockOrderRepo, &mockRestaurantRepo, &mockMenuItemRepo, &mockMenuRepo, &mockInvoiceRepo, NewAuthorizer(&mockRestaurantRepo, &mockrepository.RestaurantMemberRepository{}))
 
 	authCtx := authctx.WithUserClaims(t.Context(), &authctx.UserClaims{
 		UserID: 1,
@@ -212,7 +497,10 @@ func Test_services_OrderService_GetOrderById_when_invalid_id(t *testing.T) {
 func Test_services_OrderService_GetOrderById_when_unauthorized(t *testing.T) {
 	mockOrderRepo := mockrepository.OrderRepository{}
 	mockMenuItemRepo := mockrepository.MenuItemRepository{}
-	service := NewOrderService(&mockOrderRepo, &mockMenuItemRepo)
+	mockMenuRepo := mockrepository.MenuRepository{}
+	mockRestaurantRepo := mockrepository.RestaurantRepository{}
+	mockInvoiceRepo := mockrepository.InvoiceRepository{}
+	service := NewOrderService(&mockOrderRepo, &mockRestaurantRepo, &mockMenuItemRepo, &mockMenuRepo, &mockInvoiceRepo, NewAuthorizer(&mockRestaurantRepo, &mockrepository.RestaurantMemberRepository{}))
 
 	fetchedOrder, err := service.GetOrderById(t.Context(), 1)
 	require.Error(t, err)
@@ -223,12 +511,16 @@ func Test_services_OrderService_GetOrderById_when_unauthorized(t *testing.T) {
 func Test_services_OrderService_GetOrderById_when_forbidden(t *testing.T) {
 	mockOrderRepo := mockrepository.OrderRepository{}
 	mockMenuItemRepo := mockrepository.MenuItemRepository{}
-	service := NewOrderService(&mockOrderRepo, &mockMenuItemRepo)
+	mockMenuRepo := mockrepository.MenuRepository{}
+	mockRestaurantRepo := mockrepository.RestaurantRepository{}
+	mockInvoiceRepo := mockrepository.InvoiceRepository{}
+	service := NewOrderService(&mockOrderRepo, &mockRestaurantRepo, &mockMenuItemRepo, &mockMenuRepo, &mockInvoiceRepo, NewAuthorizer(&mockRestaurantRepo, &mockrepository.RestaurantMemberRepository{}))
 
 	order := domain.Order{
 		ID:           1,
 		CustomerID:   1,
 		RestaurantID: 1,
+		Status:       domain.OrderDraft,
 		OrderItems: []domain.OrderItem{
 			{MenuItemID: 1, Quantity: 2},
 			{MenuItemID: 2, Quantity: 1},
@@ -252,12 +544,16 @@ func Test_services_OrderService_GetOrderById_when_forbidden(t *testing.T) {
 func Test_services_OrderService_AddOrderItem(t *testing.T) {
 	mockOrderRepo := mockrepository.OrderRepository{}
 	mockMenuItemRepo := mockrepository.MenuItemRepository{}
-	service := NewOrderService(&mockOrderRepo, &mockMenuItemRepo)
+	mockMenuRepo := mockrepository.MenuRepository{}
+	mockRestaurantRepo := mockrepository.RestaurantRepository{}
+	mockInvoiceRepo := mockrepository.InvoiceRepository{}
+	service := NewOrderService(&mockOrderRepo, &mockRestaurantRepo, &mockMenuItemRepo, &mockMenuRepo, &mockInvoiceRepo, NewAuthorizer(&mockRestaurantRepo, &mockrepository.RestaurantMemberRepository{}))
 
 	order := domain.Order{
 		ID:           1,
 		CustomerID:   1,
 		RestaurantID: 1,
+		Status:       domain.OrderDraft,
 		OrderItems: []domain.OrderItem{
 			{MenuItemID: 1, Quantity: 2},
 			{MenuItemID: 2, Quantity: 1},
@@ -275,16 +571,21 @@ func Test_services_OrderService_AddOrderItem(t *testing.T) {
 		Return(order, nil)
 
 	mockMenuItemRepo.On("FindMenuItemById", mock.Anything, 3).
-		Return(domain.MenuItem{ID: 3, Name: "Item 3", Price: 150, Available: true, RestaurantID: 1}, nil)
+		Return(domain.MenuItem{ID: 3, Name: "Item 3", Price: domain.NewMoney(15000, "USD"), Available: true, RestaurantID: 1}, nil)
+	mockMenuRepo.On("FindMenusByRestaurantId", mock.Anything, 1).
+		Return([]domain.Menu{}, nil)
 
+	mockInvoiceRepo.On("FindInvoicesByOrderId", mock.Anything, 1).
+		Return([]domain.Invoice{}, nil)
 	mockOrderRepo.On("UpdateOrder", mock.Anything, domain.Order{
 		ID:           1,
 		CustomerID:   1,
 		RestaurantID: 1,
+		Status:       domain.OrderDraft,
 		OrderItems: []domain.OrderItem{
 			{MenuItemID: 1, Quantity: 2},
 			{MenuItemID: 2, Quantity: 1},
-			{MenuItemID: 3, Quantity: 1},
+			{MenuItemID: 3, Quantity: 1, Name: "Item 3", UnitPrice: domain.NewMoney(15000, "USD")},
 		},
 	}).Return(nil)
 
@@ -294,10 +595,103 @@ func Test_services_OrderService_AddOrderItem(t *testing.T) {
 	mockMenuItemRepo.AssertExpectations(t)
 }
 
+func Test_services_OrderService_AddOrderItem_with_options(t *testing.T) {
+	large := domain.OrderItemOption{OptionID: 11, Name: "Large", PriceDelta: domain.NewMoney(300, "USD")}
+	cheese := domain.OrderItemOption{OptionID: 20, Name: "Extra cheese", PriceDelta: domain.NewMoney(150, "USD")}
+	// the line was added when extra cheese was cheaper, it keeps its price
+	existing := domain.OrderItem{ID: 7, MenuItemID: 1, Quantity: 1, Name: "Pizza", UnitPrice: domain.NewMoney(1400, "USD"), Options: []domain.OrderItemOption{large, cheese}}
+
+	tests := []struct {
+		name      string
+		optionIds []int
+		wantItems []domain.OrderItem
+	}{
+		{
+			name:      "same options are added to the existing line",
+			optionIds: []int{20, 11},
+			wantItems: []domain.OrderItem{
+				{ID: 7, MenuItemID: 1, Quantity: 3, Name: "Pizza", UnitPrice: domain.NewMoney(1400, "USD"), Options: []domain.OrderItemOption{large, cheese}},
+			},
+		},
+		{
+			name:      "other options are a new line",
+			optionIds: []int{11},
+			wantItems: []domain.OrderItem{
+				existing,
+				{MenuItemID: 1, Quantity: 2, Name: "Pizza", UnitPrice: domain.NewMoney(1300, "USD"), Options: []domain.OrderItemOption{large}},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockOrderRepo := mockrepository.OrderRepository{}
+			mockMenuItemRepo := mockrepository.MenuItemRepository{}
+			mockMenuRepo := mockrepository.MenuRepository{}
+			mockInvoiceRepo := mockrepository.InvoiceRepository{}
+			service := NewOrderService(&mockOrderRepo, &mockrepository.RestaurantRepository{}, &mockMenuItemRepo, &mockMenuRepo, &mockInvoiceRepo, NewAuthorizer(&mockrepository.RestaurantRepository{}, &mockrepository.RestaurantMemberRepository{}))
+
+			authCtx := authctx.WithUserClaims(t.Context(), &authctx.UserClaims{
+				UserID: 1,
+				Role:   domain.CUSTOMER,
+			})
+
+			mockOrderRepo.On("FindOrderById", mock.Anything, 1).
+				Return(domain.Order{ID: 1, CustomerID: 1, RestaurantID: 1, Status: domain.OrderDraft, OrderItems: []domain.OrderItem{existing}}, nil)
+			mockMenuItemRepo.On("FindMenuItemById", mock.Anything, 1).
+				Return(pizzaMenuItem(), nil)
+			mockMenuRepo.On("FindMenusByRestaurantId", mock.Anything, 1).
+				Return([]domain.Menu{}, nil)
+			mockInvoiceRepo.On("FindInvoicesByOrderId", mock.Anything, 1).
+				Return([]domain.Invoice{}, nil)
+			mockOrderRepo.On("UpdateOrder", mock.Anything, domain.Order{ID: 1, CustomerID: 1, RestaurantID: 1, Status: domain.OrderDraft, OrderItems: tt.wantItems}).
+				Return(nil)
+
+			options := []domain.OrderItemOption{}
+			for _, id := range tt.optionIds {
+				options = append(options, domain.OrderItemOption{OptionID: id})
+			}
+			err := service.AddOrderItem(authCtx, 1, domain.OrderItem{MenuItemID: 1, Quantity: 2, Options: options})
+			require.NoError(t, err)
+			mockOrderRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func Test_services_OrderService_AddOrderItem_when_options_invalid(t *testing.T) {
+	mockOrderRepo := mockrepository.OrderRepository{}
+	mockMenuItemRepo := mockrepository.MenuItemRepository{}
+	mockMenuRepo := mockrepository.MenuRepository{}
+	mockInvoiceRepo := mockrepository.InvoiceRepository{}
+	service := NewOrderService(&mockOrderRepo, &mockrepository.RestaurantRepository{}, &mockMenuItemRepo, &mockMenuRepo, &mockInvoiceRepo, NewAuthorizer(&mockrepository.RestaurantRepository{}, &mockrepository.RestaurantMemberRepository{}))
+
+	authCtx := authctx.WithUserClaims(t.Context(), &authctx.UserClaims{
+		UserID: 1,
+		Role:   domain.CUSTOMER,
+	})
+
+	mockOrderRepo.On("FindOrderById", mock.Anything, 1).
+		Return(domain.Order{ID: 1, CustomerID: 1, RestaurantID: 1, Status: domain.OrderDraft, OrderItems: []domain.OrderItem{
+			{ID: 7, MenuItemID: 1, Quantity: 1},
+		}}, nil)
+	mockMenuItemRepo.On("FindMenuItemById", mock.Anything, 1).
+		Return(pizzaMenuItem(), nil)
+	mockMenuRepo.On("FindMenusByRestaurantId", mock.Anything, 1).
+		Return([]domain.Menu{}, nil)
+
+	// two sizes
+	err := service.AddOrderItem(authCtx, 1, domain.OrderItem{MenuItemID: 1, Quantity: 1, Options: []domain.OrderItemOption{{OptionID: 10}, {OptionID: 11}}})
+	require.True(t, apperr.IsInvalidError(err), "expected invalid error but got %v", err)
+	mockOrderRepo.AssertNotCalled(t, "UpdateOrder", mock.Anything, mock.Anything)
+}
+
 func Test_services_OrderService_AddOrderItem_when_invalid(t *testing.T) {
 	mockOrderRepo := mockrepository.OrderRepository{}
 	mockMenuItemRepo := mockrepository.MenuItemRepository{}
-	service := NewOrderService(&mockOrderRepo, &mockMenuItemRepo)
+	mockMenuRepo := mockrepository.MenuRepository{}
+	mockRestaurantRepo := mockrepository.RestaurantRepository{}
+	mockInvoiceRepo := mockrepository.InvoiceRepository{}
+	service := NewOrderService(&mockOrderRepo, &mockRestaurantRepo, &mockMenuItemRepo, &mockMenuRepo, &mockInvoiceRepo, NewAuthorizer(&mockRestaurantRepo, &mockrepository.RestaurantMemberRepository{}))
 
 	newItem := domain.OrderItem{MenuItemID: 3, Quantity: 1}
 
@@ -316,7 +710,10 @@ func Test_services_OrderService_AddOrderItem_when_invalid(t *testing.T) {
 func Test_services_OrderService_AddOrderItem_when_unauthorized(t *testing.T) {
 	mockOrderRepo := mockrepository.OrderRepository{}
 	mockMenuItemRepo := mockrepository.MenuItemRepository{}
-	service := NewOrderService(&mockOrderRepo, &mockMenuItemRepo)
+	mockMenuRepo := mockrepository.MenuRepository{}
+	mockRestaurantRepo := mockrepository.RestaurantRepository{}
+	mockInvoiceRepo := mockrepository.InvoiceRepository{}
+	service := NewOrderService(&mockOrderRepo, &mockRestaurantRepo, &mockMenuItemRepo, &mockMenuRepo, &mockInvoiceRepo, NewAuthorizer(&mockRestaurantRepo, &mockrepository.RestaurantMemberRepository{}))
 
 	newItem := domain.OrderItem{MenuItemID: 3, Quantity: 1}
 
@@ -330,12 +727,16 @@ func Test_services_OrderService_AddOrderItem_when_unauthorized(t *testing.T) {
 func Test_services_OrderService_AddOrderItem_when_forbidden(t *testing.T) {
 	mockOrderRepo := mockrepository.OrderRepository{}
 	mockMenuItemRepo := mockrepository.MenuItemRepository{}
-	service := NewOrderService(&mockOrderRepo, &mockMenuItemRepo)
+	mockMenuRepo := mockrepository.MenuRepository{}
+	mockRestaurantRepo := mockrepository.RestaurantRepository{}
+	mockInvoiceRepo := mockrepository.InvoiceRepository{}
+	service := NewOrderService(&mockOrderRepo, &mockRestaurantRepo, &mockMenuItemRepo, &mockMenuRepo, &mockInvoiceRepo, NewAuthorizer(&mockRestaurantRepo, &mockrepository.RestaurantMemberRepository{}))
 
 	order := domain.Order{
 		ID:           1,
 		CustomerID:   1,
 		RestaurantID: 1,
+		Status:       domain.OrderDraft,
 		OrderItems: []domain.OrderItem{
 			{MenuItemID: 1, Quantity: 2},
 			{MenuItemID: 2, Quantity: 1},
@@ -359,15 +760,49 @@ func Test_services_OrderService_AddOrderItem_when_forbidden(t *testing.T) {
 	mockOrderRepo.AssertNotCalled(t, "UpdateOrder", mock.Anything, mock.Anything)
 }
 
+func Test_services_OrderService_AddOrderItem_when_order_not_found(t *testing.T) {
+	mockOrderRepo := mockrepository.OrderRepository{}
+	mockMenuItemRepo := mockrepository.MenuItemRepository{}
+	mockMenuRepo := mockrepository.MenuRepository{}
+	mockRestaurantRepo := mockrepository.RestaurantRepository{}
+	mockInvoiceRepo := mockrepository.InvoiceRepository{}
+	service := NewOrderService(&mockOrderRepo, &mockRestaurantRepo, &mockMenuItemRepo, &mockMenuRepo, &mockInvoiceRepo, NewAuthorizer(&mockRestaurantRepo, &mockrepository.RestaurantMemberRepository{}))
+
+	authCtx := authctx.WithUserClaims(t.Context(), &authctx.UserClaims{
+		UserID: 1,
+		Role:   domain.CUSTOMER,
+	})
+
+	mockOrderRepo.On("FindOrderById", mock.Anything, 99).
+		Return(domain.Order{}, nil)
+
+	err := service.AddOrderItem(authCtx, 99, domain.OrderItem{MenuItemID: 3, Quantity: 1})
+	require.True(t, apperr.IsNotFoundError(err), "expected not found error but got %v", err)
+
+	err = service.UpdateOrderItemQuantity(authCtx, 99, 3, 2)
+	require.True(t, apperr.IsNotFoundError(err), "expected not found error but got %v", err)
+
+	err = service.RemoveOrderLine(authCtx, 99, 4)
+	require.True(t, apperr.IsNotFoundError(err), "expected not found error but got %v", err)
+
+	mockOrderRepo.AssertExpectations(t)
+	mockMenuItemRepo.AssertNotCalled(t, "FindMenuItemById", mock.Anything, mock.Anything)
+	mockOrderRepo.AssertNotCalled(t, "UpdateOrder", mock.Anything, mock.Anything)
+}
+
 func Test_services_OrderService_AddOrderItem_when_item_not_belong_to_restaurant(t *testing.T) {
 	mockOrderRepo := mockrepository.OrderRepository{}
 	mockMenuItemRepo := mockrepository.MenuItemRepository{}
-	service := NewOrderService(&mockOrderRepo, &mockMenuItemRepo)
+	mockMenuRepo := mockrepository.MenuRepository{}
+	mockRestaurantRepo := mockrepository.RestaurantRepository{}
+	mockInvoiceRepo := mockrepository.InvoiceRepository{}
+	service := NewOrderService(&mockOrderRepo, &mockRestaurantRepo, &mockMenuItemRepo, &mockMenuRepo, &mockInvoiceRepo, NewAuthorizer(&mockRestaurantRepo, &mockrepository.RestaurantMemberRepository{}))
 
 	order := domain.Order{
 		ID:           1,
 		CustomerID:   1,
 		RestaurantID: 1,
+		Status:       domain.OrderDraft,
 		OrderItems: []domain.OrderItem{
 			{MenuItemID: 1, Quantity: 2},
 			{MenuItemID: 2, Quantity: 1},
@@ -385,7 +820,7 @@ func Test_services_OrderService_AddOrderItem_when_item_not_belong_to_restaurant(
 		Return(order, nil)
 
 	mockMenuItemRepo.On("FindMenuItemById", mock.Anything, 3).
-		Return(domain.MenuItem{ID: 3, Name: "Item 3", Price: 150, Available: true, RestaurantID: 2}, nil)
+		Return(domain.MenuItem{ID: 3, Name: "Item 3", Price: domain.NewMoney(15000, "USD"), Available: true, RestaurantID: 2}, nil)
 
 	err := service.AddOrderItem(authCtx, 1, newItem)
 	require.Error(t, err)
@@ -397,12 +832,16 @@ func Test_services_OrderService_AddOrderItem_when_item_not_belong_to_restaurant(
 func Test_services_OrderService_AddOrderItem_when_item_not_available(t *testing.T) {
 	mockOrderRepo := mockrepository.OrderRepository{}
 	mockMenuItemRepo := mockrepository.MenuItemRepository{}
-	service := NewOrderService(&mockOrderRepo, &mockMenuItemRepo)
+	mockMenuRepo := mockrepository.MenuRepository{}
+	mockRestaurantRepo := mockrepository.RestaurantRepository{}
+	mockInvoiceRepo := mockrepository.InvoiceRepository{}
+	service := NewOrderService(&mockOrderRepo, &mockRestaurantRepo, &mockMenuItemRepo, &mockMenuRepo, &mockInvoiceRepo, NewAuthorizer(&mockRestaurantRepo, &mockrepository.RestaurantMemberRepository{}))
 
 	order := domain.Order{
 		ID:           1,
 		CustomerID:   1,
 		RestaurantID: 1,
+		Status:       domain.OrderDraft,
 		OrderItems: []domain.OrderItem{
 			{MenuItemID: 1, Quantity: 2},
 			{MenuItemID: 2, Quantity: 1},
@@ -420,7 +859,7 @@ func Test_services_OrderService_AddOrderItem_when_item_not_available(t *testing.
 		Return(order, nil)
 
 	mockMenuItemRepo.On("FindMenuItemById", mock.Anything, 3).
-		Return(domain.MenuItem{ID: 3, Name: "Item 3", Price: 150, Available: false, RestaurantID: 1}, nil)
+		Return(domain.MenuItem{ID: 3, Name: "Item 3", Price: domain.NewMoney(15000, "USD"), Available: false, RestaurantID: 1}, nil)
 
 	err := service.AddOrderItem(authCtx, 1, newItem)
 	require.Error(t, err)
@@ -428,3 +867,1055 @@ func Test_services_OrderService_AddOrderItem_when_item_not_available(t *testing.
 	mockMenuItemRepo.AssertExpectations(t)
 	mockOrderRepo.AssertNotCalled(t, "UpdateOrder", mock.Anything, mock.Anything)
 }
+
+func Test_services_OrderService_AddOrderItem_when_item_deleted(t *testing.T) {
+	mockOrderRepo := mockrepository.OrderRepository{}
+	mockMenuItemRepo := mockrepository.MenuItemRepository{}
+	mockMenuRepo := mockrepository.MenuRepository{}
+	mockRestaurantRepo := mockrepository.RestaurantRepository{}
+	mockInvoiceRepo := mockrepository.InvoiceRepository{}
+	service := NewOrderService(&mockOrderRepo, &mockRestaurantRepo, &mockMenuItemRepo, &mockMenuRepo, &mockInvoiceRepo, NewAuthorizer(&mockRestaurantRepo, &mockrepository.RestaurantMemberRepository{}))
+
+	order := domain.Order{
+		ID:           1,
+		CustomerID:   1,
+		RestaurantID: 1,
+		Status:       domain.OrderDraft,
+		OrderItems: []domain.OrderItem{
+			{MenuItemID: 1, Quantity: 2},
+			{MenuItemID: 2, Quantity: 1},
+		},
+	}
+
+	newItem := domain.OrderItem{MenuItemID: 3, Quantity: 1}
+
+	authCtx := authctx.WithUserClaims(t.Context(), &authctx.UserClaims{
+		UserID: 1,
+		Role:   domain.CUSTOMER,
+	})
+
+	mockOrderRepo.On("FindOrderById", mock.Anything, 1).
+		Return(order, nil)
+
+	mockMenuItemRepo.On("FindMenuItemById", mock.Anything, 3).
+		Return(domain.MenuItem{ID: 3, Name: "Item 3", Price: domain.NewMoney(15000, "USD"), Available: true, RestaurantID: 1, Deleted: true}, nil)
+
+	err := service.AddOrderItem(authCtx, 1, newItem)
+	require.True(t, apperr.IsInvalidError(err), "expected invalid error but got %v", err)
+	mockOrderRepo.AssertExpectations(t)
+	mockMenuItemRepo.AssertExpectations(t)
+	mockOrderRepo.AssertNotCalled(t, "UpdateOrder", mock.Anything, mock.Anything)
+}
+
+func Test_services_OrderService_AddOrderItem_when_not_on_active_menu(t *testing.T) {
+	mockOrderRepo := mockrepository.OrderRepository{}
+	mockMenuItemRepo := mockrepository.MenuItemRepository{}
+	mockMenuRepo := mockrepository.MenuRepository{}
+	mockInvoiceRepo := mockrepository.InvoiceRepository{}
+	service := NewOrderService(&mockOrderRepo, &mockrepository.RestaurantRepository{}, &mockMenuItemRepo, &mockMenuRepo, &mockInvoiceRepo, NewAuthorizer(&mockrepository.RestaurantRepository{}, &mockrepository.RestaurantMemberRepository{}))
+	service.now = func() time.Time { return time.Date(2026, time.March, 2, 8, 0, 0, 0, time.Local) }
+
+	mockOrderRepo.On("FindOrderById", mock.Anything, 1).
+		Return(domain.Order{ID: 1, CustomerID: 1, RestaurantID: 1, Status: domain.OrderDraft, OrderItems: []domain.OrderItem{{ID: 7, MenuItemID: 1, Quantity: 1}}}, nil)
+	mockMenuItemRepo.On("FindMenuItemById", mock.Anything, 2).
+		Return(domain.MenuItem{ID: 2, Name: "Steak", Price: domain.NewMoney(2500, "USD"), Available: true, RestaurantID: 1}, nil)
+	mockMenuRepo.On("FindMenusByRestaurantId", mock.Anything, 1).
+		Return(breakfastAndDinner(), nil)
+
+	authCtx := authctx.WithUserClaims(t.Context(), &authctx.UserClaims{UserID: 1, Role: domain.CUSTOMER})
+
+	err := service.AddOrderItem(authCtx, 1, domain.OrderItem{MenuItemID: 2, Quantity: 1})
+	require.True(t, apperr.IsInvalidError(err), "expected invalid error but got %v", err)
+	require.Contains(t, err.Error(), "not on an active menu")
+	mockOrderRepo.AssertNotCalled(t, "UpdateOrder", mock.Anything, mock.Anything)
+	mockMenuRepo.AssertExpectations(t)
+}
+
+func Test_services_OrderService_AddOrderItem_when_out_of_stock(t *testing.T) {
+	mockOrderRepo := mockrepository.OrderRepository{}
+	mockMenuItemRepo := mockrepository.MenuItemRepository{}
+	mockMenuRepo := mockrepository.MenuRepository{}
+	mockInvoiceRepo := mockrepository.InvoiceRepository{}
+	service := NewOrderService(&mockOrderRepo, &mockrepository.RestaurantRepository{}, &mockMenuItemRepo, &mockMenuRepo, &mockInvoiceRepo, NewAuthorizer(&mockrepository.RestaurantRepository{}, &mockrepository.RestaurantMemberRepository{}))
+
+	mockOrderRepo.On("FindOrderById", mock.Anything, 1).
+		Return(domain.Order{ID: 1, CustomerID: 1, RestaurantID: 1, Status: domain.OrderDraft, OrderItems: []domain.OrderItem{{ID: 7, MenuItemID: 1, Quantity: 2}}}, nil)
+	mockMenuItemRepo.On("FindMenuItemById", mock.Anything, 1).
+		Return(domain.MenuItem{ID: 1, Name: "Cake", Price: domain.NewMoney(500, "USD"), Available: true, RestaurantID: 1, TrackStock: true, Stock: 3}, nil)
+	mockMenuRepo.On("FindMenusByRestaurantId", mock.Anything, 1).
+		Return([]domain.Menu{}, nil)
+
+	authCtx := authctx.WithUserClaims(t.Context(), &authctx.UserClaims{UserID: 1, Role: domain.CUSTOMER})
+
+	// two are already on the order
+	err := service.AddOrderItem(authCtx, 1, domain.OrderItem{MenuItemID: 1, Quantity: 2})
+	require.True(t, apperr.IsConflictError(err), "expected conflict error but got %v", err)
+	mockOrderRepo.AssertNotCalled(t, "UpdateOrder", mock.Anything, mock.Anything)
+}
+
+func Test_services_OrderService_AddOrderItem_when_order_not_draft(t *testing.T) {
+	mockOrderRepo := mockrepository.OrderRepository{}
+	mockMenuItemRepo := mockrepository.MenuItemRepository{}
+	mockMenuRepo := mockrepository.MenuRepository{}
+	mockRestaurantRepo := mockrepository.RestaurantRepository{}
+	mockInvoiceRepo := mockrepository.InvoiceRepository{}
+	service := NewOrderService(&mockOrderRepo, &mockRestaurantRepo, &mockMenuItemRepo, &mockMenuRepo, &mockInvoiceRepo, NewAuthorizer(&mockRestaurantRepo, &mockrepository.RestaurantMemberRepository{}))
+
+	order := domain.Order{
+		ID:           1,
+		CustomerID:   1,
+		RestaurantID: 1,
+		Status:       domain.OrderPlaced,
+		OrderItems: []domain.OrderItem{
+			{MenuItemID: 1, Quantity: 2},
+		},
+	}
+
+	authCtx := authctx.WithUserClaims(t.Context(), &authctx.UserClaims{
+		UserID: 1,
+		Role:   domain.CUSTOMER,
+	})
+
+	mockOrderRepo.On("FindOrderById", mock.Anything, 1).
+		Return(order, nil)
+
+	err := service.AddOrderItem(authCtx, 1, domain.OrderItem{MenuItemID: 3, Quantity: 1})
+	require.Error(t, err)
+	mockOrderRepo.AssertExpectations(t)
+	mockMenuItemRepo.AssertNotCalled(t, "FindMenuItemById", mock.Anything, mock.Anything)
+	mockOrderRepo.AssertNotCalled(t, "UpdateOrder", mock.Anything, mock.Anything)
+}
+
+func Test_services_OrderService_TransitionOrder(t *testing.T) {
+	mockOrderRepo := mockrepository.OrderRepository{}
+	mockMenuItemRepo := mockrepository.MenuItemRepository{}
+	mockMenuRepo := mockrepository.MenuRepository{}
+	mockRestaurantRepo := mockrepository.RestaurantRepository{}
+	mockInvoiceRepo := mockrepository.InvoiceRepository{}
+	service := NewOrderService(&mockOrderRepo, &mockRestaurantRepo, &mockMenuItemRepo, &mockMenuRepo, &mockInvoiceRepo, NewAuthorizer(&mockRestaurantRepo, &mockrepository.RestaurantMemberRepository{}))
+
+	authCtx := authctx.WithUserClaims(t.Context(), &authctx.UserClaims{
+		UserID: 5,
+		Role:   domain.OWNER,
+	})
+
+	mockOrderRepo.On("FindOrderById", mock.Anything, 1).
+		Return(domain.Order{ID: 1, CustomerID: 1, RestaurantID: 2, Status: domain.OrderPlaced}, nil)
+	mockRestaurantRepo.On("FindRestaurantById", mock.Anything, 2).
+		Return(domain.Restaurant{ID: 2, Name: "Restaurant", OwnerID: 5}, nil)
+	mockOrderRepo.On("UpdateOrderStatus", mock.Anything, 1, domain.OrderAccepted).
+		Return(nil)
+
+	err := service.TransitionOrder(authCtx, 1, domain.OrderAccepted)
+	require.NoError(t, err)
+	mockOrderRepo.AssertExpectations(t)
+	mockRestaurantRepo.AssertExpectations(t)
+}
+
+func Test_services_OrderService_TransitionOrder_when_invalid_transition(t *testing.T) {
+	mockOrderRepo := mockrepository.OrderRepository{}
+	mockMenuItemRepo := mockrepository.MenuItemRepository{}
+	mockMenuRepo := mockrepository.MenuRepository{}
+	mockRestaurantRepo := mockrepository.RestaurantRepository{}
+	mockInvoiceRepo := mockrepository.InvoiceRepository{}
+	service := NewOrderService(&mockOrderRepo, &mockRestaurantRepo, &mockMenuItemRepo, &mockMenuRepo, &mockInvoiceRepo, NewAuthorizer(&mockRestaurantRepo, &mockrepository.RestaurantMemberRepository{}))
+
+	authCtx := authctx.WithUserClaims(t.Context(), &authctx.UserClaims{
+		UserID: 5,
+		Role:   domain.OWNER,
+	})
+
+	mockOrderRepo.On("FindOrderById", mock.Anything, 1).
+		Return(domain.Order{ID: 1, CustomerID: 1, RestaurantID: 2, Status: domain.OrderDraft}, nil)
+	mockRestaurantRepo.On("FindRestaurantById", mock.Anything, 2).
+		Return(domain.Restaurant{ID: 2, Name: "Restaurant", OwnerID: 5}, nil)
+
+	err := service.TransitionOrder(authCtx, 1, domain.OrderReady)
+	require.Error(t, err)
+	mockOrderRepo.AssertExpectations(t)
+	mockOrderRepo.AssertNotCalled(t, "UpdateOrderStatus", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func Test_services_OrderService_TransitionOrder_when_not_restaurant_owner(t *testing.T) {
+	mockOrderRepo := mockrepository.OrderRepository{}
+	mockMenuItemRepo := mockrepository.MenuItemRepository{}
+	mockMenuRepo := mockrepository.MenuRepository{}
+	mockRestaurantRepo := mockrepository.RestaurantRepository{}
+	mockInvoiceRepo := mockrepository.InvoiceRepository{}
+	service := NewOrderService(&mockOrderRepo, &mockRestaurantRepo, &mockMenuItemRepo, &mockMenuRepo, &mockInvoiceRepo, NewAuthorizer(&mockRestaurantRepo, &mockrepository.RestaurantMemberRepository{}))
+
+	authCtx := authctx.WithUserClaims(t.Context(), &authctx.UserClaims{
+		UserID: 6,
+		Role:   domain.OWNER,
+	})
+
+	mockOrderRepo.On("FindOrderById", mock.Anything, 1).
+		Return(domain.Order{ID: 1, CustomerID: 1, RestaurantID: 2, Status: domain.OrderPlaced}, nil)
+	mockRestaurantRepo.On("FindRestaurantById", mock.Anything, 2).
+		Return(domain.Restaurant{ID: 2, Name: "Restaurant", OwnerID: 5}, nil)
+
+	err := service.TransitionOrder(authCtx, 1, domain.OrderAccepted)
+	require.Error(t, err)
+	mockOrderRepo.AssertNotCalled(t, "UpdateOrderStatus", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func Test_services_OrderService_TransitionOrder_when_kitchen_staff(t *testing.T) {
+	mockOrderRepo := mockrepository.OrderRepository{}
+	mockMenuItemRepo := mockrepository.MenuItemRepository{}
+	mockMenuRepo := mockrepository.MenuRepository{}
+	mockRestaurantRepo := mockrepository.RestaurantRepository{}
+	mockMemberRepo := mockrepository.RestaurantMemberRepository{}
+	mockInvoiceRepo := mockrepository.InvoiceRepository{}
+	service := NewOrderService(&mockOrderRepo, &mockRestaurantRepo, &mockMenuItemRepo, &mockMenuRepo, &mockInvoiceRepo, NewAuthorizer(&mockRestaurantRepo, &mockMemberRepo))
+
+	authCtx := authctx.WithUserClaims(t.Context(), &authctx.UserClaims{
+		UserID: 7,
+		Role:   domain.STAFF,
+	})
+
+	mockOrderRepo.On("FindOrderById", mock.Anything, 1).
+		Return(domain.Order{ID: 1, CustomerID: 1, RestaurantID: 2, Status: domain.OrderAccepted}, nil)
+	mockMemberRepo.On("FindRestaurantMember", mock.Anything, 2, 7).
+		Return(domain.NewRestaurantMember(2, 7, domain.StaffKitchen), nil)
+	mockOrderRepo.On("UpdateOrderStatus", mock.Anything, 1, domain.OrderPreparing).
+		Return(nil)
+
+	err := service.TransitionOrder(authCtx, 1, domain.OrderPreparing)
+	require.NoError(t, err)
+	mockOrderRepo.AssertExpectations(t)
+	mockMemberRepo.AssertExpectations(t)
+	mockRestaurantRepo.AssertNotCalled(t, "FindRestaurantById", mock.Anything, mock.Anything)
+}
+
+func Test_services_OrderService_TransitionOrder_when_kitchen_staff_rejects(t *testing.T) {
+	mockOrderRepo := mockrepository.OrderRepository{}
+	mockMenuItemRepo := mockrepository.MenuItemRepository{}
+	mockMenuRepo := mockrepository.MenuRepository{}
+	mockRestaurantRepo := mockrepository.RestaurantRepository{}
+	mockMemberRepo := mockrepository.RestaurantMemberRepository{}
+	mockInvoiceRepo := mockrepository.InvoiceRepository{}
+	service := NewOrderService(&mockOrderRepo, &mockRestaurantRepo, &mockMenuItemRepo, &mockMenuRepo, &mockInvoiceRepo, NewAuthorizer(&mockRestaurantRepo, &mockMemberRepo))
+
+	authCtx := authctx.WithUserClaims(t.Context(), &authctx.UserClaims{
+		UserID: 7,
+		Role:   domain.STAFF,
+	})
+
+	mockOrderRepo.On("FindOrderById", mock.Anything, 1).
+		Return(domain.Order{ID: 1, CustomerID: 1, RestaurantID: 2, Status: domain.OrderPlaced}, nil)
+	mockMemberRepo.On("FindRestaurantMember", mock.Anything, 2, 7).
+		Return(domain.NewRestaurantMember(2, 7, domain.StaffKitchen), nil)
+
+	err := service.TransitionOrder(authCtx, 1, domain.OrderCancelled)
+	require.True(t, apperr.IsForbiddenError(err), "expected forbidden error but got %v", err)
+	mockOrderRepo.AssertNotCalled(t, "UpdateOrderStatus", mock.Anything, mock.Anything, mock.Anything)
+	mockInvoiceRepo.AssertNotCalled(t, "FindInvoicesByOrderId", mock.Anything, mock.Anything)
+}
+
+func Test_services_OrderService_TransitionOrder_when_cashier_staff(t *testing.T) {
+	mockOrderRepo := mockrepository.OrderRepository{}
+	mockMenuItemRepo := mockrepository.MenuItemRepository{}
+	mockMenuRepo := mockrepository.MenuRepository{}
+	mockRestaurantRepo := mockrepository.RestaurantRepository{}
+	mockMemberRepo := mockrepository.RestaurantMemberRepository{}
+	mockInvoiceRepo := mockrepository.InvoiceRepository{}
+	service := NewOrderService(&mockOrderRepo, &mockRestaurantRepo, &mockMenuItemRepo, &mockMenuRepo, &mockInvoiceRepo, NewAuthorizer(&mockRestaurantRepo, &mockMemberRepo))
+
+	authCtx := authctx.WithUserClaims(t.Context(), &authctx.UserClaims{
+		UserID: 8,
+		Role:   domain.STAFF,
+	})
+
+	mockOrderRepo.On("FindOrderById", mock.Anything, 1).
+		Return(domain.Order{ID: 1, CustomerID: 1, RestaurantID: 2, Status: domain.OrderAccepted}, nil)
+	mockMemberRepo.On("FindRestaurantMember", mock.Anything, 2, 8).
+		Return(domain.NewRestaurantMember(2, 8, domain.StaffCashier), nil)
+
+	err := service.TransitionOrder(authCtx, 1, domain.OrderPreparing)
+	require.True(t, apperr.IsForbiddenError(err), "expected forbidden error but got %v", err)
+	mockOrderRepo.AssertNotCalled(t, "UpdateOrderStatus", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func Test_services_OrderService_TransitionOrder_when_customer(t *testing.T) {
+	mockOrderRepo := mockrepository.OrderRepository{}
+	mockMenuItemRepo := mockrepository.MenuItemRepository{}
+	mockMenuRepo := mockrepository.MenuRepository{}
+	mockRestaurantRepo := mockrepository.RestaurantRepository{}
+	mockInvoiceRepo := mockrepository.InvoiceRepository{}
+	service := NewOrderService(&mockOrderRepo, &mockRestaurantRepo, &mockMenuItemRepo, &mockMenuRepo, &mockInvoiceRepo, NewAuthorizer(&mockRestaurantRepo, &mockrepository.RestaurantMemberRepository{}))
+
+	authCtx := authctx.WithUserClaims(t.Context(), &authctx.UserClaims{
+		UserID: 1,
+		Role:   domain.CUSTOMER,
+	})
+
+	err := service.TransitionOrder(authCtx, 1, domain.OrderAccepted)
+	require.Error(t, err)
+	mockOrderRepo.AssertNotCalled(t, "FindOrderById", mock.Anything, mock.Anything)
+}
+
+func Test_services_OrderService_GetOrderById_when_restaurant_owner(t *testing.T) {
+	mockOrderRepo := mockrepository.OrderRepository{}
+	mockMenuItemRepo := mockrepository.MenuItemRepository{}
+	mockMenuRepo := mockrepository.MenuRepository{}
+	mockRestaurantRepo := mockrepository.RestaurantRepository{}
+	mockInvoiceRepo := mockrepository.InvoiceRepository{}
+	service := NewOrderService(&mockOrderRepo, &mockRestaurantRepo, &mockMenuItemRepo, &mockMenuRepo, &mockInvoiceRepo, NewAuthorizer(&mockRestaurantRepo, &mockrepository.RestaurantMemberRepository{}))
+
+	order := domain.Order{ID: 1, CustomerID: 1, RestaurantID: 2, Status: domain.OrderPlaced}
+
+	authCtx := authctx.WithUserClaims(t.Context(), &authctx.UserClaims{
+		UserID: 5,
+		Role:   domain.OWNER,
+	})
+
+	mockOrderRepo.On("FindOrderById", mock.Anything, 1).
+		Return(order, nil)
+	mockRestaurantRepo.On("FindRestaurantById", mock.Anything, 2).
+		Return(domain.Restaurant{ID: 2, Name: "Restaurant", OwnerID: 5}, nil)
+
+	fetchedOrder, err := service.GetOrderById(authCtx, 1)
+	require.NoError(t, err)
+	require.Equal(t, order, fetchedOrder)
+	mockOrderRepo.AssertExpectations(t)
+	mockRestaurantRepo.AssertExpectations(t)
+}
+
+func Test_services_OrderService_GetRestaurantOrders(t *testing.T) {
+	mockOrderRepo := mockrepository.OrderRepository{}
+	mockMenuItemRepo := mockrepository.MenuItemRepository{}
+	mockMenuRepo := mockrepository.MenuRepository{}
+	mockRestaurantRepo := mockrepository.RestaurantRepository{}
+	mockInvoiceRepo := mockrepository.InvoiceRepository{}
+	service := NewOrderService(&mockOrderRepo, &mockRestaurantRepo, &mockMenuItemRepo, &mockMenuRepo, &mockInvoiceRepo, NewAuthorizer(&mockRestaurantRepo, &mockrepository.RestaurantMemberRepository{}))
+
+	authCtx := authctx.WithUserClaims(t.Context(), &authctx.UserClaims{
+		UserID: 5,
+		Role:   domain.OWNER,
+	})
+
+	orders := []domain.Order{{ID: 1, CustomerID: 1, RestaurantID: 2, Status: domain.OrderPlaced}}
+	mockRestaurantRepo.On("FindRestaurantById", mock.Anything, 2).
+		Return(domain.Restaurant{ID: 2, Name: "Restaurant", OwnerID: 5}, nil)
+	mockOrderRepo.On("FindOrdersByRestaurantId", mock.Anything, 2, restaurantOrderStatuses).
+		Return(orders, nil)
+
+	fetchedOrders, err := service.GetRestaurantOrders(authCtx, 2, nil)
+	require.NoError(t, err)
+	require.Equal(t, orders, fetchedOrders)
+	mockOrderRepo.AssertExpectations(t)
+	mockRestaurantRepo.AssertExpectations(t)
+}
+
+func Test_services_OrderService_GetRestaurantOrders_when_draft_filter(t *testing.T) {
+	mockOrderRepo := mockrepository.OrderRepository{}
+	mockMenuItemRepo := mockrepository.MenuItemRepository{}
+	mockMenuRepo := mockrepository.MenuRepository{}
+	mockRestaurantRepo := mockrepository.RestaurantRepository{}
+	mockInvoiceRepo := mockrepository.InvoiceRepository{}
+	service := NewOrderService(&mockOrderRepo, &mockRestaurantRepo, &mockMenuItemRepo, &mockMenuRepo, &mockInvoiceRepo, NewAuthorizer(&mockRestaurantRepo, &mockrepository.RestaurantMemberRepository{}))
+
+	authCtx := authctx.WithUserClaims(t.Context(), &authctx.UserClaims{
+		UserID: 5,
+		Role:   domain.OWNER,
+	})
+
+	orders, err := service.GetRestaurantOrders(authCtx, 2, []domain.OrderStatus{domain.OrderDraft})
+	require.Error(t, err)
+	require.Nil(t, orders)
+	mockOrderRepo.AssertNotCalled(t, "FindOrdersByRestaurantId", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func Test_services_OrderService_GetRestaurantOrders_when_not_restaurant_owner(t *testing.T) {
+	mockOrderRepo := mockrepository.OrderRepository{}
+	mockMenuItemRepo := mockrepository.MenuItemRepository{}
+	mockMenuRepo := mockrepository.MenuRepository{}
+	mockRestaurantRepo := mockrepository.RestaurantRepository{}
+	mockInvoiceRepo := mockrepository.InvoiceRepository{}
+	service := NewOrderService(&mockOrderRepo, &mockRestaurantRepo, &mockMenuItemRepo, &mockMenuRepo, &mockInvoiceRepo, NewAuthorizer(&mockRestaurantRepo, &mockrepository.RestaurantMemberRepository{}))
+
+	authCtx := authctx.WithUserClaims(t.Context(), &authctx.UserClaims{
+		UserID: 6,
+		Role:   domain.OWNER,
+	})
+
+	mockRestaurantRepo.On("FindRestaurantById", mock.Anything, 2).
+		Return(domain.Restaurant{ID: 2, Name: "Restaurant", OwnerID: 5}, nil)
+
+	orders, err := service.GetRestaurantOrders(authCtx, 2, []domain.OrderStatus{domain.OrderPlaced})
+	require.Error(t, err)
+	require.Nil(t, orders)
+	mockRestaurantRepo.AssertExpectations(t)
+	mockOrderRepo.AssertNotCalled(t, "FindOrdersByRestaurantId", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func Test_services_OrderService_GetCustomerOrders(t *testing.T) {
+	mockOrderRepo := mockrepository.OrderRepository{}
+	mockMenuItemRepo := mockrepository.MenuItemRepository{}
+	mockMenuRepo := mockrepository.MenuRepository{}
+	mockRestaurantRepo := mockrepository.RestaurantRepository{}
+	mockInvoiceRepo := mockrepository.InvoiceRepository{}
+	service := NewOrderService(&mockOrderRepo, &mockRestaurantRepo, &mockMenuItemRepo, &mockMenuRepo, &mockInvoiceRepo, NewAuthorizer(&mockRestaurantRepo, &mockrepository.RestaurantMemberRepository{}))
+
+	authCtx := authctx.WithUserClaims(t.Context(), &authctx.UserClaims{
+		UserID: 1,
+		Role:   domain.CUSTOMER,
+	})
+
+	mockOrderRepo.On("FindOrdersByCustomerId", mock.Anything, 1, domain.OrderFilter{RestaurantID: 2, Limit: 3}).
+		Return([]domain.Order{
+			{ID: 9, CustomerID: 1, RestaurantID: 2},
+			{ID: 7, CustomerID: 1, RestaurantID: 2},
+			{ID: 4, CustomerID: 1, RestaurantID: 2},
+		}, nil)
+
+	orders, nextCursor, err := service.GetCustomerOrders(authCtx, domain.OrderFilter{RestaurantID: 2, Limit: 2})
+	require.NoError(t, err)
+	require.Len(t, orders, 2)
+	require.Equal(t, 7, nextCursor)
+	mockOrderRepo.AssertExpectations(t)
+}
+
+func Test_services_OrderService_GetCustomerOrders_last_page(t *testing.T) {
+	mockOrderRepo := mockrepository.OrderRepository{}
+	mockMenuItemRepo := mockrepository.MenuItemRepository{}
+	mockMenuRepo := mockrepository.MenuRepository{}
+	mockRestaurantRepo := mockrepository.RestaurantRepository{}
+	mockInvoiceRepo := mockrepository.InvoiceRepository{}
+	service := NewOrderService(&mockOrderRepo, &mockRestaurantRepo, &mockMenuItemRepo, &mockMenuRepo, &mockInvoiceRepo, NewAuthorizer(&mockRestaurantRepo, &mockrepository.RestaurantMemberRepository{}))
+
+	authCtx := authctx.WithUserClaims(t.Context(), &authctx.UserClaims{
+		UserID: 1,
+		Role:   domain.CUSTOMER,
+	})
+
+	mockOrderRepo.On("FindOrdersByCustomerId", mock.Anything, 1, domain.OrderFilter{Cursor: 7, Limit: defaultOrdersPageSize + 1}).
+		Return([]domain.Order{{ID: 4, CustomerID: 1, RestaurantID: 2}}, nil)
+
+	orders, nextCursor, err := service.GetCustomerOrders(authCtx, domain.OrderFilter{Cursor: 7})
+	require.NoError(t, err)
+	require.Len(t, orders, 1)
+	require.Equal(t, 0, nextCursor)
+	mockOrderRepo.AssertExpectations(t)
+}
+
+func Test_services_OrderService_GetCustomerOrders_when_invalid_date_range(t *testing.T) {
+	mockOrderRepo := mockrepository.OrderRepository{}
+	mockMenuItemRepo := mockrepository.MenuItemRepository{}
+	mockMenuRepo := mockrepository.MenuRepository{}
+	mockRestaurantRepo := mockrepository.RestaurantRepository{}
+	mockInvoiceRepo := mockrepository.InvoiceRepository{}
+	service := NewOrderService(&mockOrderRepo, &mockRestaurantRepo, &mockMenuItemRepo, &mockMenuRepo, &mockInvoiceRepo, NewAuthorizer(&mockRestaurantRepo, &mockrepository.RestaurantMemberRepository{}))
+
+	authCtx := authctx.WithUserClaims(t.Context(), &authctx.UserClaims{
+		UserID: 1,
+		Role:   domain.CUSTOMER,
+	})
+
+	now := time.Now()
+	orders, _, err := service.GetCustomerOrders(authCtx, domain.OrderFilter{From: now, To: now.Add(-time.Hour)})
+	require.Error(t, err)
+	require.Nil(t, orders)
+	mockOrderRepo.AssertNotCalled(t, "FindOrdersByCustomerId", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func Test_services_OrderService_GetCustomerOrders_when_owner(t *testing.T) {
+	mockOrderRepo := mockrepository.OrderRepository{}
+	mockMenuItemRepo := mockrepository.MenuItemRepository{}
+	mockMenuRepo := mockrepository.MenuRepository{}
+	mockRestaurantRepo := mockrepository.RestaurantRepository{}
+	mockInvoiceRepo := mockrepository.InvoiceRepository{}
+	service := NewOrderService(&mockOrderRepo, &mockRestaurantRepo, &mockMenuItemRepo, &mockMenuRepo, &mockInvoiceRepo, NewAuthorizer(&mockRestaurantRepo, &mockrepository.RestaurantMemberRepository{}))
+
+	authCtx := authctx.WithUserClaims(t.Context(), &authctx.UserClaims{
+		UserID: 5,
+		Role:   domain.OWNER,
+	})
+
+	orders, _, err := service.GetCustomerOrders(authCtx, domain.OrderFilter{})
+	require.Error(t, err)
+	require.Nil(t, orders)
+	mockOrderRepo.AssertNotCalled(t, "FindOrdersByCustomerId", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func Test_services_OrderService_CancelOrder_when_customer(t *testing.T) {
+	mockOrderRepo := mockrepository.OrderRepository{}
+	mockMenuItemRepo := mockrepository.MenuItemRepository{}
+	mockMenuRepo := mockrepository.MenuRepository{}
+	mockRestaurantRepo := mockrepository.RestaurantRepository{}
+	mockInvoiceRepo := mockrepository.InvoiceRepository{}
+	service := NewOrderService(&mockOrderRepo, &mockRestaurantRepo, &mockMenuItemRepo, &mockMenuRepo, &mockInvoiceRepo, NewAuthorizer(&mockRestaurantRepo, &mockrepository.RestaurantMemberRepository{}))
+
+	authCtx := authctx.WithUserClaims(t.Context(), &authctx.UserClaims{
+		UserID: 1,
+		Role:   domain.CUSTOMER,
+	})
+
+	mockOrderRepo.On("FindOrderById", mock.Anything, 1).
+		Return(domain.Order{ID: 1, CustomerID: 1, RestaurantID: 2, Status: domain.OrderPlaced}, nil)
+	mockOrderRepo.On("UpdateOrderStatus", mock.Anything, 1, domain.OrderCancelled).
+		Return(nil)
+	mockInvoiceRepo.On("FindInvoicesByOrderId", mock.Anything, 1).
+		Return([]domain.Invoice{
+			{ID: 1, OrderID: 1, PaymentStatus: domain.Cancelled},
+			{ID: 2, OrderID: 1, PaymentStatus: domain.Unpaid},
+			{ID: 3, OrderID: 1, PaymentStatus: domain.Paid},
+		}, nil)
+	mockInvoiceRepo.On("ChangeInvoiceStatus", mock.Anything, 2, domain.Cancelled).Return(nil)
+	mockInvoiceRepo.On("ChangeInvoiceStatus", mock.Anything, 3, domain.RefundPending).Return(nil)
+
+	err := service.CancelOrder(authCtx, 1)
+	require.NoError(t, err)
+	mockOrderRepo.AssertExpectations(t)
+	mockInvoiceRepo.AssertExpectations(t)
+	mockInvoiceRepo.AssertNotCalled(t, "ChangeInvoiceStatus", mock.Anything, 1, mock.Anything)
+}
+
+func Test_services_OrderService_CancelOrder_when_customer_and_order_accepted(t *testing.T) {
+	mockOrderRepo := mockrepository.OrderRepository{}
+	mockMenuItemRepo := mockrepository.MenuItemRepository{}
+	mockMenuRepo := mockrepository.MenuRepository{}
+	mockRestaurantRepo := mockrepository.RestaurantRepository{}
+	mockInvoiceRepo := mockrepository.InvoiceRepository{}
+	service := NewOrderService(&mockOrderRepo, &mockRestaurantRepo, &mockMenuItemRepo, &mockMenuRepo, &mockInvoiceRepo, NewAuthorizer(&mockRestaurantRepo, &mockrepository.RestaurantMemberRepository{}))
+
+	authCtx := authctx.WithUserClaims(t.Context(), &authctx.UserClaims{
+		UserID: 1,
+		Role:   domain.CUSTOMER,
+	})
+
+	mockOrderRepo.On("FindOrderById", mock.Anything, 1).
+		Return(domain.Order{ID: 1, CustomerID: 1, RestaurantID: 2, Status: domain.OrderAccepted}, nil)
+
+	err := service.CancelOrder(authCtx, 1)
+	require.Error(t, err)
+	mockOrderRepo.AssertNotCalled(t, "UpdateOrderStatus", mock.Anything, mock.Anything, mock.Anything)
+	mockInvoiceRepo.AssertNotCalled(t, "FindInvoicesByOrderId", mock.Anything, mock.Anything)
+}
+
+func Test_services_OrderService_CancelOrder_when_not_order_customer(t *testing.T) {
+	mockOrderRepo := mockrepository.OrderRepository{}
+	mockMenuItemRepo := mockrepository.MenuItemRepository{}
+	mockMenuRepo := mockrepository.MenuRepository{}
+	mockRestaurantRepo := mockrepository.RestaurantRepository{}
+	mockInvoiceRepo := mockrepository.InvoiceRepository{}
+	service := NewOrderService(&mockOrderRepo, &mockRestaurantRepo, &mockMenuItemRepo, &mockMenuRepo, &mockInvoiceRepo, NewAuthorizer(&mockRestaurantRepo, &mockrepository.RestaurantMemberRepository{}))
+
+	authCtx := authctx.WithUserClaims(t.Context(), &authctx.UserClaims{
+		UserID: 7,
+		Role:   domain.CUSTOMER,
+	})
+
+	mockOrderRepo.On("FindOrderById", mock.Anything, 1).
+		Return(domain.Order{ID: 1, CustomerID: 1, RestaurantID: 2, Status: domain.OrderDraft}, nil)
+
+	err := service.CancelOrder(authCtx, 1)
+	require.Error(t, err)
+	mockOrderRepo.AssertNotCalled(t, "UpdateOrderStatus", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func Test_services_OrderService_CancelOrder_when_restaurant_owner(t *testing.T) {
+	mockOrderRepo := mockrepository.OrderRepository{}
+	mockMenuItemRepo := mockrepository.MenuItemRepository{}
+	mockMenuRepo := mockrepository.MenuRepository{}
+	mockRestaurantRepo := mockrepository.RestaurantRepository{}
+	mockInvoiceRepo := mockrepository.InvoiceRepository{}
+	service := NewOrderService(&mockOrderRepo, &mockRestaurantRepo, &mockMenuItemRepo, &mockMenuRepo, &mockInvoiceRepo, NewAuthorizer(&mockRestaurantRepo, &mockrepository.RestaurantMemberRepository{}))
+
+	authCtx := authctx.WithUserClaims(t.Context(), &authctx.UserClaims{
+		UserID: 5,
+		Role:   domain.OWNER,
+	})
+
+	mockOrderRepo.On("FindOrderById", mock.Anything, 1).
+		Return(domain.Order{ID: 1, CustomerID: 1, RestaurantID: 2, Status: domain.OrderReady}, nil)
+	mockRestaurantRepo.On("FindRestaurantById", mock.Anything, 2).
+		Return(domain.Restaurant{ID: 2, Name: "Restaurant", OwnerID: 5}, nil)
+	mockOrderRepo.On("UpdateOrderStatus", mock.Anything, 1, domain.OrderCancelled).
+		Return(nil)
+	mockInvoiceRepo.On("FindInvoicesByOrderId", mock.Anything, 1).
+		Return([]domain.Invoice{{ID: 3, OrderID: 1, PaymentStatus: domain.Paid}}, nil)
+	mockInvoiceRepo.On("ChangeInvoiceStatus", mock.Anything, 3, domain.RefundPending).Return(nil)
+
+	err := service.CancelOrder(authCtx, 1)
+	require.NoError(t, err)
+	mockOrderRepo.AssertExpectations(t)
+	mockRestaurantRepo.AssertExpectations(t)
+	mockInvoiceRepo.AssertExpectations(t)
+}
+
+func Test_services_OrderService_CancelOrder_when_delivered(t *testing.T) {
+	mockOrderRepo := mockrepository.OrderRepository{}
+	mockMenuItemRepo := mockrepository.MenuItemRepository{}
+	mockMenuRepo := mockrepository.MenuRepository{}
+	mockRestaurantRepo := mockrepository.RestaurantRepository{}
+	mockInvoiceRepo := mockrepository.InvoiceRepository{}
+	service := NewOrderService(&mockOrderRepo, &mockRestaurantRepo, &mockMenuItemRepo, &mockMenuRepo, &mockInvoiceRepo, NewAuthorizer(&mockRestaurantRepo, &mockrepository.RestaurantMemberRepository{}))
+
+	authCtx := authctx.WithUserClaims(t.Context(), &authctx.UserClaims{
+		UserID: 5,
+		Role:   domain.OWNER,
+	})
+
+	mockOrderRepo.On("FindOrderById", mock.Anything, 1).
+		Return(domain.Order{ID: 1, CustomerID: 1, RestaurantID: 2, Status: domain.OrderDelivered}, nil)
+	mockRestaurantRepo.On("FindRestaurantById", mock.Anything, 2).
+		Return(domain.Restaurant{ID: 2, Name: "Restaurant", OwnerID: 5}, nil)
+
+	err := service.CancelOrder(authCtx, 1)
+	require.Error(t, err)
+	mockOrderRepo.AssertNotCalled(t, "UpdateOrderStatus", mock.Anything, mock.Anything, mock.Anything)
+	mockInvoiceRepo.AssertNotCalled(t, "FindInvoicesByOrderId", mock.Anything, mock.Anything)
+}
+
+func Test_services_OrderService_UpdateOrderItemQuantity(t *testing.T) {
+	mockOrderRepo := mockrepository.OrderRepository{}
+	mockMenuItemRepo := mockrepository.MenuItemRepository{}
+	mockMenuRepo := mockrepository.MenuRepository{}
+	mockRestaurantRepo := mockrepository.RestaurantRepository{}
+	mockInvoiceRepo := mockrepository.InvoiceRepository{}
+	service := NewOrderService(&mockOrderRepo, &mockRestaurantRepo, &mockMenuItemRepo, &mockMenuRepo, &mockInvoiceRepo, NewAuthorizer(&mockRestaurantRepo, &mockrepository.RestaurantMemberRepository{}))
+
+	authCtx := authctx.WithUserClaims(t.Context(), &authctx.UserClaims{
+		UserID: 1,
+		Role:   domain.CUSTOMER,
+	})
+
+	mockOrderRepo.On("FindOrderById", mock.Anything, 1).
+		Return(domain.Order{ID: 1, CustomerID: 1, RestaurantID: 2, Status: domain.OrderDraft, OrderItems: []domain.OrderItem{
+			{ID: 7, MenuItemID: 3, Quantity: 4},
+			{ID: 8, MenuItemID: 4, Quantity: 1},
+		}}, nil)
+	mockInvoiceRepo.On("FindInvoicesByOrderId", mock.Anything, 1).
+		Return([]domain.Invoice{{ID: 3, OrderID: 1, PaymentStatus: domain.Unpaid}, {ID: 2, OrderID: 1, PaymentStatus: domain.Cancelled}}, nil)
+	mockInvoiceRepo.On("ChangeInvoiceStatus", mock.Anything, 3, domain.Cancelled).Return(nil)
+	mockOrderRepo.On("UpdateOrder", mock.Anything, domain.Order{ID: 1, CustomerID: 1, RestaurantID: 2, Status: domain.OrderDraft, OrderItems: []domain.OrderItem{
+		{ID: 7, MenuItemID: 3, Quantity: 2},
+		{ID: 8, MenuItemID: 4, Quantity: 1},
+	}}).Return(nil)
+
+	err := service.UpdateOrderItemQuantity(authCtx, 1, 3, 2)
+	require.NoError(t, err)
+	mockOrderRepo.AssertExpectations(t)
+	// the open invoice no longer bills the order
+	mockInvoiceRepo.AssertExpectations(t)
+}
+
+func Test_services_OrderService_UpdateOrderItemQuantity_when_menu_item_on_several_lines(t *testing.T) {
+	mockOrderRepo := mockrepository.OrderRepository{}
+	mockMenuItemRepo := mockrepository.MenuItemRepository{}
+	mockMenuRepo := mockrepository.MenuRepository{}
+	mockRestaurantRepo := mockrepository.RestaurantRepository{}
+	mockInvoiceRepo := mockrepository.InvoiceRepository{}
+	service := NewOrderService(&mockOrderRepo, &mockRestaurantRepo, &mockMenuItemRepo, &mockMenuRepo, &mockInvoiceRepo, NewAuthorizer(&mockRestaurantRepo, &mockrepository.RestaurantMemberRepository{}))
+
+	authCtx := authctx.WithUserClaims(t.Context(), &authctx.UserClaims{
+		UserID: 1,
+		Role:   domain.CUSTOMER,
+	})
+
+	mockOrderRepo.On("FindOrderById", mock.Anything, 1).
+		Return(domain.Order{ID: 1, CustomerID: 1, RestaurantID: 2, Status: domain.OrderDraft, OrderItems: []domain.OrderItem{
+			{ID: 7, MenuItemID: 3, Quantity: 4},
+			{ID: 8, MenuItemID: 3, Quantity: 1, Options: []domain.OrderItemOption{{OptionID: 11}}},
+		}}, nil)
+
+	err := service.UpdateOrderItemQuantity(authCtx, 1, 3, 2)
+	require.True(t, apperr.IsConflictError(err))
+	mockOrderRepo.AssertNotCalled(t, "UpdateOrder", mock.Anything, mock.Anything)
+}
+
+func Test_services_OrderService_UpdateOrderLineQuantity(t *testing.T) {
+	mockOrderRepo := mockrepository.OrderRepository{}
+	mockMenuItemRepo := mockrepository.MenuItemRepository{}
+	mockMenuRepo := mockrepository.MenuRepository{}
+	mockRestaurantRepo := mockrepository.RestaurantRepository{}
+	mockInvoiceRepo := mockrepository.InvoiceRepository{}
+	service := NewOrderService(&mockOrderRepo, &mockRestaurantRepo, &mockMenuItemRepo, &mockMenuRepo, &mockInvoiceRepo, NewAuthorizer(&mockRestaurantRepo, &mockrepository.RestaurantMemberRepository{}))
+
+	authCtx := authctx.WithUserClaims(t.Context(), &authctx.UserClaims{
+		UserID: 1,
+		Role:   domain.CUSTOMER,
+	})
+
+	mockOrderRepo.On("FindOrderById", mock.Anything, 1).
+		Return(domain.Order{ID: 1, CustomerID: 1, RestaurantID: 2, Status: domain.OrderDraft, OrderItems: []domain.OrderItem{
+			{ID: 7, MenuItemID: 3, Quantity: 4},
+			{ID: 8, MenuItemID: 3, Quantity: 1, Options: []domain.OrderItemOption{{OptionID: 11}}},
+		}}, nil)
+	mockMenuItemRepo.On("FindMenuItemById", mock.Anything, 3).
+		Return(domain.MenuItem{ID: 3, Name: "Cake", RestaurantID: 2, Available: true}, nil)
+	mockInvoiceRepo.On("FindInvoicesByOrderId", mock.Anything, 1).
+		Return([]domain.Invoice{}, nil)
+	mockOrderRepo.On("UpdateOrder", mock.Anything, domain.Order{ID: 1, CustomerID: 1, RestaurantID: 2, Status: domain.OrderDraft, OrderItems: []domain.OrderItem{
+		{ID: 7, MenuItemID: 3, Quantity: 4},
+		{ID: 8, MenuItemID: 3, Quantity: 2, Options: []domain.OrderItemOption{{OptionID: 11}}},
+	}}).Return(nil)
+
+	err := service.UpdateOrderLineQuantity(authCtx, 1, 8, 2)
+	require.NoError(t, err)
+	mockOrderRepo.AssertExpectations(t)
+}
+
+func Test_services_OrderService_UpdateOrderLineQuantity_when_not_enough_stock(t *testing.T) {
+	mockOrderRepo := mockrepository.OrderRepository{}
+	mockMenuItemRepo := mockrepository.MenuItemRepository{}
+	mockMenuRepo := mockrepository.MenuRepository{}
+	mockRestaurantRepo := mockrepository.RestaurantRepository{}
+	mockInvoiceRepo := mockrepository.InvoiceRepository{}
+	service := NewOrderService(&mockOrderRepo, &mockRestaurantRepo, &mockMenuItemRepo, &mockMenuRepo, &mockInvoiceRepo, NewAuthorizer(&mockRestaurantRepo, &mockrepository.RestaurantMemberRepository{}))
+
+	authCtx := authctx.WithUserClaims(t.Context(), &authctx.UserClaims{
+		UserID: 1,
+		Role:   domain.CUSTOMER,
+	})
+
+	mockOrderRepo.On("FindOrderById", mock.Anything, 1).
+		Return(domain.Order{ID: 1, CustomerID: 1, RestaurantID: 2, Status: domain.OrderDraft, OrderItems: []domain.OrderItem{
+			{ID: 7, MenuItemID: 3, Quantity: 4},
+			{ID: 8, MenuItemID: 3, Quantity: 1, Options: []domain.OrderItemOption{{OptionID: 11}}},
+		}}, nil)
+	// 4 on the other line and 2 on this one, only 5 left
+	mockMenuItemRepo.On("FindMenuItemById", mock.Anything, 3).
+		Return(domain.MenuItem{ID: 3, Name: "Cake", RestaurantID: 2, Available: true, TrackStock: true, Stock: 5}, nil)
+
+	err := service.UpdateOrderLineQuantity(authCtx, 1, 8, 2)
+	require.True(t, apperr.IsConflictError(err))
+	mockOrderRepo.AssertNotCalled(t, "UpdateOrder", mock.Anything, mock.Anything)
+	mockInvoiceRepo.AssertNotCalled(t, "FindInvoicesByOrderId", mock.Anything, mock.Anything)
+}
+
+func Test_services_OrderService_UpdateOrderLineQuantity_when_line_not_in_order(t *testing.T) {
+	mockOrderRepo := mockrepository.OrderRepository{}
+	mockMenuItemRepo := mockrepository.MenuItemRepository{}
+	mockMenuRepo := mockrepository.MenuRepository{}
+	mockRestaurantRepo := mockrepository.RestaurantRepository{}
+	mockInvoiceRepo := mockrepository.InvoiceRepository{}
+	service := NewOrderService(&mockOrderRepo, &mockRestaurantRepo, &mockMenuItemRepo, &mockMenuRepo, &mockInvoiceRepo, NewAuthorizer(&mockRestaurantRepo, &mockrepository.RestaurantMemberRepository{}))
+
+	authCtx := authctx.WithUserClaims(t.Context(), &authctx.UserClaims{
+		UserID: 1,
+		Role:   domain.CUSTOMER,
+	})
+
+	mockOrderRepo.On("FindOrderById", mock.Anything, 1).
+		Return(domain.Order{ID: 1, CustomerID: 1, RestaurantID: 2, Status: domain.OrderDraft, OrderItems: []domain.OrderItem{
+			{ID: 7, MenuItemID: 3, Quantity: 4},
+		}}, nil)
+
+	err := service.UpdateOrderLineQuantity(authCtx, 1, 3, 2)
+	require.True(t, apperr.IsNotFoundError(err))
+	mockOrderRepo.AssertNotCalled(t, "UpdateOrder", mock.Anything, mock.Anything)
+}
+
+func Test_services_OrderService_UpdateOrderItemQuantity_when_item_not_in_order(t *testing.T) {
+	mockOrderRepo := mockrepository.OrderRepository{}
+	mockMenuItemRepo := mockrepository.MenuItemRepository{}
+	mockMenuRepo := mockrepository.MenuRepository{}
+	mockRestaurantRepo := mockrepository.RestaurantRepository{}
+	mockInvoiceRepo := mockrepository.InvoiceRepository{}
+	service := NewOrderService(&mockOrderRepo, &mockRestaurantRepo, &mockMenuItemRepo, &mockMenuRepo, &mockInvoiceRepo, NewAuthorizer(&mockRestaurantRepo, &mockrepository.RestaurantMemberRepository{}))
+
+	authCtx := authctx.WithUserClaims(t.Context(), &authctx.UserClaims{
+		UserID: 1,
+		Role:   domain.CUSTOMER,
+	})
+
+	mockOrderRepo.On("FindOrderById", mock.Anything, 1).
+		Return(domain.Order{ID: 1, CustomerID: 1, RestaurantID: 2, Status: domain.OrderDraft, OrderItems: []domain.OrderItem{
+			{ID: 7, MenuItemID: 3, Quantity: 4},
+		}}, nil)
+
+	err := service.UpdateOrderItemQuantity(authCtx, 1, 5, 2)
+	require.Error(t, err)
+	require.True(t, apperr.IsNotFoundError(err))
+	mockOrderRepo.AssertNotCalled(t, "UpdateOrder", mock.Anything, mock.Anything)
+}
+
+func Test_services_OrderService_UpdateOrderItemQuantity_when_invalid_quantity(t *testing.T) {
+	mockOrderRepo := mockrepository.OrderRepository{}
+	mockMenuItemRepo := mockrepository.MenuItemRepository{}
+	mockMenuRepo := mockrepository.MenuRepository{}
+	mockRestaurantRepo := mockrepository.RestaurantRepository{}
+	mockInvoiceRepo := mockrepository.InvoiceRepository{}
+	service := NewOrderService(&mockOrderRepo, &mockRestaurantRepo, &mockMenuItemRepo, &mockMenuRepo, &mockInvoiceRepo, NewAuthorizer(&mockRestaurantRepo, &mockrepository.RestaurantMemberRepository{}))
+
+	authCtx := authctx.WithUserClaims(t.Context(), &authctx.UserClaims{
+		UserID: 1,
+		Role:   domain.CUSTOMER,
+	})
+
+	err := service.UpdateOrderItemQuantity(authCtx, 1, 3, 0)
+	require.Error(t, err)
+	mockOrderRepo.AssertNotCalled(t, "FindOrderById", mock.Anything, mock.Anything)
+}
+
+func Test_services_OrderService_UpdateOrderItemQuantity_when_invoice_has_payment(t *testing.T) {
+	mockOrderRepo := mockrepository.OrderRepository{}
+	mockMenuItemRepo := mockrepository.MenuItemRepository{}
+	mockMenuRepo := mockrepository.MenuRepository{}
+	mockRestaurantRepo := mockrepository.RestaurantRepository{}
+	mockInvoiceRepo := mockrepository.InvoiceRepository{}
+	service := NewOrderService(&mockOrderRepo, &mockRestaurantRepo, &mockMenuItemRepo, &mockMenuRepo, &mockInvoiceRepo, NewAuthorizer(&mockRestaurantRepo, &mockrepository.RestaurantMemberRepository{}))
+
+	authCtx := authctx.WithUserClaims(t.Context(), &authctx.UserClaims{
+		UserID: 1,
+		Role:   domain.CUSTOMER,
+	})
+
+	mockOrderRepo.On("FindOrderById", mock.Anything, 1).
+		Return(domain.Order{ID: 1, CustomerID: 1, RestaurantID: 2, Status: domain.OrderDraft, OrderItems: []domain.OrderItem{
+			{ID: 7, MenuItemID: 3, Quantity: 4},
+		}}, nil)
+	mockInvoiceRepo.On("FindInvoicesByOrderId", mock.Anything, 1).
+		Return([]domain.Invoice{{ID: 3, OrderID: 1, PaymentStatus: domain.Processing}}, nil)
+
+	err := service.UpdateOrderItemQuantity(authCtx, 1, 3, 2)
+	require.True(t, apperr.IsConflictError(err))
+	mockOrderRepo.AssertNotCalled(t, "UpdateOrder", mock.Anything, mock.Anything)
+	mockInvoiceRepo.AssertNotCalled(t, "ChangeInvoiceStatus", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func Test_services_OrderService_RemoveOrderItem(t *testing.T) {
+	mockOrderRepo := mockrepository.OrderRepository{}
+	mockMenuItemRepo := mockrepository.MenuItemRepository{}
+	mockMenuRepo := mockrepository.MenuRepository{}
+	mockRestaurantRepo := mockrepository.RestaurantRepository{}
+	mockInvoiceRepo := mockrepository.InvoiceRepository{}
+	service := NewOrderService(&mockOrderRepo, &mockRestaurantRepo, &mockMenuItemRepo, &mockMenuRepo, &mockInvoiceRepo, NewAuthorizer(&mockRestaurantRepo, &mockrepository.RestaurantMemberRepository{}))
+
+	authCtx := authctx.WithUserClaims(t.Context(), &authctx.UserClaims{
+		UserID: 1,
+		Role:   domain.CUSTOMER,
+	})
+
+	mockOrderRepo.On("FindOrderById", mock.Anything, 1).
+		Return(domain.Order{ID: 1, CustomerID: 1, RestaurantID: 2, Status: domain.OrderDraft, OrderItems: []domain.OrderItem{
+			{ID: 7, MenuItemID: 3, Quantity: 4},
+			{ID: 8, MenuItemID: 4, Quantity: 1},
+		}}, nil)
+	mockInvoiceRepo.On("FindInvoicesByOrderId", mock.Anything, 1).
+		Return([]domain.Invoice{}, nil)
+	mockOrderRepo.On("UpdateOrder", mock.Anything, domain.Order{ID: 1, CustomerID: 1, RestaurantID: 2, Status: domain.OrderDraft, OrderItems: []domain.OrderItem{
+		{ID: 8, MenuItemID: 4, Quantity: 1},
+	}}).Return(nil)
+
+	err := service.RemoveOrderItem(authCtx, 1, 3)
+	require.NoError(t, err)
+	mockOrderRepo.AssertExpectations(t)
+}
+
+func Test_services_OrderService_RemoveOrderLine(t *testing.T) {
+	mockOrderRepo := mockrepository.OrderRepository{}
+	mockMenuItemRepo := mockrepository.MenuItemRepository{}
+	mockMenuRepo := mockrepository.MenuRepository{}
+	mockRestaurantRepo := mockrepository.RestaurantRepository{}
+	mockInvoiceRepo := mockrepository.InvoiceRepository{}
+	service := NewOrderService(&mockOrderRepo, &mockRestaurantRepo, &mockMenuItemRepo, &mockMenuRepo, &mockInvoiceRepo, NewAuthorizer(&mockRestaurantRepo, &mockrepository.RestaurantMemberRepository{}))
+
+	authCtx := authctx.WithUserClaims(t.Context(), &authctx.UserClaims{
+		UserID: 1,
+		Role:   domain.CUSTOMER,
+	})
+
+	mockOrderRepo.On("FindOrderById", mock.Anything, 1).
+		Return(domain.Order{ID: 1, CustomerID: 1, RestaurantID: 2, Status: domain.OrderDraft, OrderItems: []domain.OrderItem{
+			{ID: 7, MenuItemID: 3, Quantity: 4},
+			{ID: 8, MenuItemID: 3, Quantity: 1, Options: []domain.OrderItemOption{{OptionID: 11}}},
+		}}, nil)
+	mockInvoiceRepo.On("FindInvoicesByOrderId", mock.Anything, 1).
+		Return([]domain.Invoice{}, nil)
+	mockOrderRepo.On("UpdateOrder", mock.Anything, domain.Order{ID: 1, CustomerID: 1, RestaurantID: 2, Status: domain.OrderDraft, OrderItems: []domain.OrderItem{
+		{ID: 7, MenuItemID: 3, Quantity: 4},
+	}}).Return(nil)
+
+	err := service.RemoveOrderLine(authCtx, 1, 8)
+	require.NoError(t, err)
+	mockOrderRepo.AssertExpectations(t)
+}
+
+func Test_services_OrderService_RemoveOrderItem_when_last_item(t *testing.T) {
+	mockOrderRepo := mockrepository.OrderRepository{}
+	mockMenuItemRepo := mockrepository.MenuItemRepository{}
+	mockMenuRepo := mockrepository.MenuRepository{}
+	mockRestaurantRepo := mockrepository.RestaurantRepository{}
+	mockInvoiceRepo := mockrepository.InvoiceRepository{}
+	service := NewOrderService(&mockOrderRepo, &mockRestaurantRepo, &mockMenuItemRepo, &mockMenuRepo, &mockInvoiceRepo, NewAuthorizer(&mockRestaurantRepo, &mockrepository.RestaurantMemberRepository{}))
+
+	authCtx := authctx.WithUserClaims(t.Context(), &authctx.UserClaims{
+		UserID: 1,
+		Role:   domain.CUSTOMER,
+	})
+
+	mockOrderRepo.On("FindOrderById", mock.Anything, 1).
+		Return(domain.Order{ID: 1, CustomerID: 1, RestaurantID: 2, Status: domain.OrderDraft, OrderItems: []domain.OrderItem{
+			{ID: 7, MenuItemID: 3, Quantity: 4},
+		}}, nil)
+
+	err := service.RemoveOrderItem(authCtx, 1, 3)
+	require.Error(t, err)
+	require.True(t, apperr.IsInvalidError(err))
+	mockOrderRepo.AssertNotCalled(t, "UpdateOrder", mock.Anything, mock.Anything)
+}
+
+func Test_services_OrderService_RemoveOrderItem_when_order_placed(t *testing.T) {
+	mockOrderRepo := mockrepository.OrderRepository{}
+	mockMenuItemRepo := mockrepository.MenuItemRepository{}
+	mockMenuRepo := mockrepository.MenuRepository{}
+	mockRestaurantRepo := mockrepository.RestaurantRepository{}
+	mockInvoiceRepo := mockrepository.InvoiceRepository{}
+	service := NewOrderService(&mockOrderRepo, &mockRestaurantRepo, &mockMenuItemRepo, &mockMenuRepo, &mockInvoiceRepo, NewAuthorizer(&mockRestaurantRepo, &mockrepository.RestaurantMemberRepository{}))
+
+	authCtx := authctx.WithUserClaims(t.Context(), &authctx.UserClaims{
+		UserID: 1,
+		Role:   domain.CUSTOMER,
+	})
+
+	mockOrderRepo.On("FindOrderById", mock.Anything, 1).
+		Return(domain.Order{ID: 1, CustomerID: 1, RestaurantID: 2, Status: domain.OrderPlaced, OrderItems: []domain.OrderItem{
+			{ID: 7, MenuItemID: 3, Quantity: 4},
+			{ID: 8, MenuItemID: 4, Quantity: 1},
+		}}, nil)
+
+	err := service.RemoveOrderItem(authCtx, 1, 3)
+	require.Error(t, err)
+	mockOrderRepo.AssertNotCalled(t, "UpdateOrder", mock.Anything, mock.Anything)
+}
+
+func Test_services_OrderService_GetAllOrders(t *testing.T) {
+	mockOrderRepo := mockrepository.OrderRepository{}
+	mockMenuItemRepo := mockrepository.MenuItemRepository{}
+	mockMenuRepo := mockrepository.MenuRepository{}
+	mockRestaurantRepo := mockrepository.RestaurantRepository{}
+	mockInvoiceRepo := mockrepository.InvoiceRepository{}
+	service := NewOrderService(&mockOrderRepo, &mockRestaurantRepo, &mockMenuItemRepo, &mockMenuRepo, &mockInvoiceRepo, NewAuthorizer(&mockRestaurantRepo, &mockrepository.RestaurantMemberRepository{}))
+
+	authCtx := authctx.WithUserClaims(t.Context(), &authctx.UserClaims{
+		UserID: 99,
+		Role:   domain.ADMIN,
+	})
+
+	mockOrderRepo.On("FindOrders", mock.Anything, domain.OrderFilter{CustomerID: 1, Limit: 2}).
+		Return([]domain.Order{
+			{ID: 9, CustomerID: 1, RestaurantID: 2},
+			{ID: 7, CustomerID: 1, RestaurantID: 3},
+		}, nil)
+
+	orders, nextCursor, err := service.GetAllOrders(authCtx, domain.OrderFilter{CustomerID: 1, Limit: 1})
+	require.NoError(t, err)
+	require.Len(t, orders, 1)
+	require.Equal(t, 9, nextCursor)
+	mockOrderRepo.AssertExpectations(t)
+}
+
+func Test_services_OrderService_GetAllOrders_when_not_admin(t *testing.T) {
+	mockOrderRepo := mockrepository.OrderRepository{}
+	mockMenuItemRepo := mockrepository.MenuItemRepository{}
+	mockMenuRepo := mockrepository.MenuRepository{}
+	mockRestaurantRepo := mockrepository.RestaurantRepository{}
+	mockInvoiceRepo := mockrepository.InvoiceRepository{}
+	service := NewOrderService(&mockOrderRepo, &mockRestaurantRepo, &mockMenuItemRepo, &mockMenuRepo, &mockInvoiceRepo, NewAuthorizer(&mockRestaurantRepo, &mockrepository.RestaurantMemberRepository{}))
+
+	for _, role := range []domain.UserRole{domain.CUSTOMER, domain.OWNER} {
+		authCtx := authctx.WithUserClaims(t.Context(), &authctx.UserClaims{
+			UserID: 1,
+			Role:   role,
+		})
+
+		orders, _, err := service.GetAllOrders(authCtx, domain.OrderFilter{})
+		require.True(t, apperr.IsForbiddenError(err))
+		require.Nil(t, orders)
+	}
+	mockOrderRepo.AssertNotCalled(t, "FindOrders", mock.Anything, mock.Anything)
+}
+
+func Test_services_OrderService_GetOrderById_when_admin(t *testing.T) {
+	mockOrderRepo := mockrepository.OrderRepository{}
+	mockMenuItemRepo := mockrepository.MenuItemRepository{}
+	mockMenuRepo := mockrepository.MenuRepository{}
+	mockRestaurantRepo := mockrepository.RestaurantRepository{}
+	mockInvoiceRepo := mockrepository.InvoiceRepository{}
+	service := NewOrderService(&mockOrderRepo, &mockRestaurantRepo, &mockMenuItemRepo, &mockMenuRepo, &mockInvoiceRepo, NewAuthorizer(&mockRestaurantRepo, &mockrepository.RestaurantMemberRepository{}))
+
+	authCtx := authctx.WithUserClaims(t.Context(), &authctx.UserClaims{
+		UserID: 99,
+		Role:   domain.ADMIN,
+	})
+
+	mockOrderRepo.On("FindOrderById", mock.Anything, 1).
+		Return(domain.Order{ID: 1, CustomerID: 1, RestaurantID: 2}, nil)
+
+	order, err := service.GetOrderById(authCtx, 1)
+	require.NoError(t, err)
+	require.Equal(t, 1, order.ID)
+	mockRestaurantRepo.AssertNotCalled(t, "FindRestaurantById", mock.Anything, mock.Anything)
+}
+
+func Test_services_OrderService_GetOrderById_when_not_found(t *testing.T) {
+	mockOrderRepo := mockrepository.OrderRepository{}
+	mockMenuItemRepo := mockrepository.MenuItemRepository{}
+	mockMenuRepo := mockrepository.MenuRepository{}
+	mockRestaurantRepo := mockrepository.RestaurantRepository{}
+	mockInvoiceRepo := mockrepository.InvoiceRepository{}
+	service := NewOrderService(&mockOrderRepo, &mockRestaurantRepo, &mockMenuItemRepo, &mockMenuRepo, &mockInvoiceRepo, NewAuthorizer(&mockRestaurantRepo, &mockrepository.RestaurantMemberRepository{}))
+
+	authCtx := authctx.WithUserClaims(t.Context(), &authctx.UserClaims{
+		UserID: 99,
+		Role:   domain.ADMIN,
+	})
+
+	mockOrderRepo.On("FindOrderById", mock.Anything, 1).
+		Return(domain.Order{}, nil)
+
+	_, err := service.GetOrderById(authCtx, 1)
+	require.True(t, apperr.IsNotFoundError(err), "expected not found error but got %v", err)
+}
+
+func Test_services_OrderService_CancelOrder_when_admin(t *testing.T) {
+	mockOrderRepo := mockrepository.OrderRepository{}
+	mockMenuItemRepo := mockrepository.MenuItemRepository{}
+	mockMenuRepo := mockrepository.MenuRepository{}
+	mockRestaurantRepo := mockrepository.RestaurantRepository{}
+	mockInvoiceRepo := mockrepository.InvoiceRepository{}
+	service := NewOrderService(&mockOrderRepo, &mockRestaurantRepo, &mockMenuItemRepo, &mockMenuRepo, &mockInvoiceRepo, NewAuthorizer(&mockRestaurantRepo, &mockrepository.RestaurantMemberRepository{}))
+
+	authCtx := authctx.WithUserClaims(t.Context(), &authctx.UserClaims{
+		UserID: 99,
+		Role:   domain.ADMIN,
+	})
+
+	// an order the restaurant is already preparing
+	mockOrderRepo.On("FindOrderById", mock.Anything, 1).
+		Return(domain.Order{ID: 1, CustomerID: 1, RestaurantID: 2, Status: domain.OrderPreparing}, nil)
+	mockOrderRepo.On("UpdateOrderStatus", mock.Anything, 1, domain.OrderCancelled).Return(nil)
+	mockInvoiceRepo.On("FindInvoicesByOrderId", mock.Anything, 1).
+		Return([]domain.Invoice{{ID: 3, OrderID: 1, PaymentStatus: domain.Paid}}, nil)
+	mockInvoiceRepo.On("ChangeInvoiceStatus", mock.Anything, 3, domain.RefundPending).Return(nil)
+
+	err := service.CancelOrder(authCtx, 1)
+	require.NoError(t, err)
+	mockOrderRepo.AssertExpectations(t)
+	mockInvoiceRepo.AssertExpectations(t)
+	mockRestaurantRepo.AssertNotCalled(t, "FindRestaurantById", mock.Anything, mock.Anything)
+}
+
+func Test_services_OrderService_CancelOrder_when_admin_and_delivered(t *testing.T) {
+	mockOrderRepo := mockrepository.OrderRepository{}
+	mockMenuItemRepo := mockrepository.MenuItemRepository{}
+	mockMenuRepo := mockrepository.MenuRepository{}
+	mockRestaurantRepo := mockrepository.RestaurantRepository{}
+	mockInvoiceRepo := mockrepository.InvoiceRepository{}
+	service := NewOrderService(&mockOrderRepo, &mockRestaurantRepo, &mockMenuItemRepo, &mockMenuRepo, &mockInvoiceRepo, NewAuthorizer(&mockRestaurantRepo, &mockrepository.RestaurantMemberRepository{}))
+
+	authCtx := authctx.WithUserClaims(t.Context(), &authctx.UserClaims{
+		UserID: 99,
+		Role:   domain.ADMIN,
+	})
+
+	mockOrderRepo.On("FindOrderById", mock.Anything, 1).
+		Return(domain.Order{ID: 1, CustomerID: 1, RestaurantID: 2, Status: domain.OrderDelivered}, nil)
+
+	err := service.CancelOrder(authCtx, 1)
+	require.True(t, apperr.IsInvalidError(err))
+	mockOrderRepo.AssertNotCalled(t, "UpdateOrderStatus", mock.Anything, mock.Anything, mock.Anything)
+}
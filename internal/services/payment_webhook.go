@@ -0,0 +1,144 @@
+package services
+
+import (
+	"context"
+
+	"github.com/mohits-git/food-ordering-system/internal/domain"
+	"github.com/mohits-git/food-ordering-system/internal/ports"
+	"github.com/mohits-git/food-ordering-system/internal/utils/apperr"
+)
+
+type PaymentWebhookService struct {
+	invoiceRepo        ports.InvoiceRepository
+	orderRepo          ports.OrderRepository
+	paymentAttemptRepo ports.PaymentAttemptRepository
+	paymentRepo        ports.PaymentRepository
+	paymentEventRepo   ports.PaymentEventRepository
+	paymentGateway     ports.PaymentGateway
+}
+
+func NewPaymentWebhookService(
+	invoiceRepo ports.InvoiceRepository,
+	orderRepo ports.OrderRepository,
+	paymentAttemptRepo ports.PaymentAttemptRepository,
+	paymentRepo ports.PaymentRepository,
+	paymentEventRepo ports.PaymentEventRepository,
+	paymentGateway ports.PaymentGateway,
+) *PaymentWebhookService {
+	return &PaymentWebhookService{
+		invoiceRepo:        invoiceRepo,
+		orderRepo:          orderRepo,
+		paymentAttemptRepo: paymentAttemptRepo,
+		paymentRepo:        paymentRepo,
+		paymentEventRepo:   paymentEventRepo,
+		paymentGateway:     paymentGateway,
+	}
+}
+
+// HandlePaymentWebhook verifies and stores a provider event and applies it to
+// the invoice. An event is applied once, redeliveries are acknowledged
+// without doing anything unless applying it failed before.
+func (s *PaymentWebhookService) HandlePaymentWebhook(ctx context.Context, payload []byte, signature string) error {
+	event, err := s.paymentGateway.ParseWebhook(payload, signature)
+	if err != nil {
+		return err
+	}
+
+	stored, err := s.paymentEventRepo.FindPaymentEvent(ctx, event.Provider, event.EventID)
+	if err != nil {
+		return err
+	}
+	switch {
+	case stored.ID == 0:
+		event.Status = domain.EventReceived
+		event.ID, err = s.paymentEventRepo.SavePaymentEvent(ctx, event)
+		if apperr.IsConflictError(err) {
+			// delivered twice at the same time
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+	case stored.Status == domain.EventFailed:
+		event.ID = stored.ID
+	default:
+		return nil
+	}
+
+	err = s.applyPaymentEvent(ctx, event)
+	status, errMsg := domain.EventProcessed, ""
+	if err != nil {
+		status, errMsg = domain.EventFailed, err.Error()
+	}
+	if updateErr := s.paymentEventRepo.UpdatePaymentEventStatus(ctx, event.ID, status, errMsg); updateErr != nil && err == nil {
+		return updateErr
+	}
+	return err
+}
+
+// applyPaymentEvent settles the attempt and the invoice the event is about.
+// Events for an invoice which already moved on are ignored, as are event
+// types we do not handle.
+func (s *PaymentWebhookService) applyPaymentEvent(ctx context.Context, event domain.PaymentEvent) error {
+	attempt, err := s.paymentAttemptRepo.FindPaymentAttemptByTransactionId(ctx, event.Provider, event.TransactionID)
+	if err != nil {
+		return err
+	}
+	if attempt.ID == 0 {
+		return apperr.NewAppError(apperr.ErrNotFound, "payment attempt not found", nil)
+	}
+
+	invoice, err := s.invoiceRepo.FindInvoiceById(ctx, attempt.InvoiceID)
+	if err != nil {
+		return err
+	}
+	if invoice.ID == 0 {
+		return apperr.NewAppError(apperr.ErrNotFound, "invoice not found", nil)
+	}
+
+	switch event.Type {
+	case domain.EventPaymentCaptured:
+		if invoice.PaymentStatus != domain.Processing && !invoice.PaymentStatus.IsOpen() {
+			return nil
+		}
+		attempt.Succeed(event.TransactionID)
+		if err := s.paymentAttemptRepo.UpdatePaymentAttempt(ctx, attempt); err != nil {
+			return err
+		}
+		order, err := s.orderRepo.FindOrderById(ctx, invoice.OrderID)
+		if err != nil {
+			return err
+		}
+		payment := domain.Payment{
+			InvoiceID:     invoice.ID,
+			PayerID:       order.CustomerID,
+			TakenBy:       attempt.TakenBy,
+			Method:        domain.PaymentCard,
+			Amount:        attempt.Amount,
+			Tendered:      attempt.Amount,
+			Change:        domain.NewMoney(0, attempt.Amount.Currency),
+			TransactionID: attempt.TransactionID,
+		}
+		_, err = recordPayment(ctx, s.invoiceRepo, s.orderRepo, s.paymentRepo, invoice, payment)
+		return err
+
+	case domain.EventPaymentFailed:
+		if invoice.PaymentStatus != domain.Processing {
+			return nil
+		}
+		reason := event.Message
+		if reason == "" {
+			reason = "payment failed"
+		}
+		attempt.Fail(event.TransactionID, reason)
+		if err := s.paymentAttemptRepo.UpdatePaymentAttempt(ctx, attempt); err != nil {
+			return err
+		}
+		status, err := failedPaymentStatus(ctx, s.paymentRepo, invoice.ID)
+		if err != nil {
+			return err
+		}
+		return s.invoiceRepo.ChangeInvoiceStatus(ctx, invoice.ID, status)
+	}
+	return nil
+}
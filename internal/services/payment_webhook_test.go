@@ -0,0 +1,253 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/mohits-git/food-ordering-system/internal/domain"
+	"github.com/mohits-git/food-ordering-system/internal/utils/apperr"
+	mockpaymentgateway "github.com/mohits-git/food-ordering-system/tests/mock_payment_gateway"
+	mockrepository "github.com/mohits-git/food-ordering-system/tests/mock_repository"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_services_PaymentWebhookService_HandlePaymentWebhook_Captured(t *testing.T) {
+	mockInvoiceRepo := mockrepository.InvoiceRepository{}
+	mockOrderRepo := mockrepository.OrderRepository{}
+	mockPaymentAttemptRepo := mockrepository.PaymentAttemptRepository{}
+	mockPaymentRepo := mockrepository.PaymentRepository{}
+	mockPaymentEventRepo := mockrepository.PaymentEventRepository{}
+	mockPaymentGateway := mockpaymentgateway.PaymentGateway{}
+
+	service := NewPaymentWebhookService(&mockInvoiceRepo, &mockOrderRepo, &mockPaymentAttemptRepo, &mockPaymentRepo, &mockPaymentEventRepo, &mockPaymentGateway)
+
+	payload := []byte("payload")
+	event := domain.PaymentEvent{Provider: "fake", EventID: "evt_1", Type: domain.EventPaymentCaptured, TransactionID: "tx_1", Payload: "payload"}
+	// a cashier started the card payment
+	attempt := domain.PaymentAttempt{ID: 7, InvoiceID: 1, Amount: domain.NewMoney(1100, "USD"), Provider: "fake", TakenBy: 9, TransactionID: "tx_1", Status: domain.AttemptPending}
+
+	mockPaymentGateway.On("ParseWebhook", payload, "sig").Return(event, nil)
+	mockPaymentEventRepo.On("FindPaymentEvent", mock.Anything, "fake", "evt_1").Return(domain.PaymentEvent{}, nil)
+	mockPaymentEventRepo.On("SavePaymentEvent", mock.Anything, mock.MatchedBy(func(e domain.PaymentEvent) bool {
+		return e.EventID == "evt_1" && e.Status == domain.EventReceived && e.Payload == "payload"
+	})).Return(3, nil)
+	mockPaymentAttemptRepo.On("FindPaymentAttemptByTransactionId", mock.Anything, "fake", "tx_1").Return(attempt, nil)
+	mockInvoiceRepo.On("FindInvoiceById", mock.Anything, 1).
+		Return(domain.Invoice{ID: 1, OrderID: 2, Total: domain.NewMoney(1000, "USD"), Tax: domain.NewMoney(100, "USD"), PaymentStatus: domain.Processing}, nil)
+	mockPaymentAttemptRepo.On("UpdatePaymentAttempt", mock.Anything, mock.MatchedBy(func(a domain.PaymentAttempt) bool {
+		return a.ID == 7 && a.Status == domain.AttemptSucceeded
+	})).Return(nil)
+	mockOrderRepo.On("FindOrderById", mock.Anything, 2).
+		Return(domain.Order{ID: 2, CustomerID: 1, Status: domain.OrderDraft}, nil)
+	payment := domain.Payment{InvoiceID: 1, PayerID: 1, TakenBy: 9, Method: domain.PaymentCard, Amount: domain.NewMoney(1100, "USD"), Tendered: domain.NewMoney(1100, "USD"), Change: domain.NewMoney(0, "USD"), TransactionID: "tx_1"}
+	mockPaymentRepo.On("SavePayment", mock.Anything, payment).Return(9, nil)
+	mockPaymentRepo.On("FindPaymentsByInvoiceId", mock.Anything, 1).Return([]domain.Payment{payment}, nil)
+	mockOrderRepo.On("UpdateOrderStatus", mock.Anything, 2, domain.OrderPlaced).Return(nil)
+	mockInvoiceRepo.On("ChangeInvoiceStatus", mock.Anything, 1, domain.Paid).Return(nil)
+	mockPaymentEventRepo.On("UpdatePaymentEventStatus", mock.Anything, 3, domain.EventProcessed, "").Return(nil)
+
+	err := service.HandlePaymentWebhook(t.Context(), payload, "sig")
+	require.NoError(t, err)
+
+	mockPaymentEventRepo.AssertExpectations(t)
+	mockPaymentAttemptRepo.AssertExpectations(t)
+	mockPaymentRepo.AssertExpectations(t)
+	mockInvoiceRepo.AssertExpectations(t)
+	mockOrderRepo.AssertExpectations(t)
+}
+
+func Test_services_PaymentWebhookService_HandlePaymentWebhook_CapturedAlreadyRecorded(t *testing.T) {
+	mockInvoiceRepo := mockrepository.InvoiceRepository{}
+	mockOrderRepo := mockrepository.OrderRepository{}
+	mockPaymentAttemptRepo := mockrepository.PaymentAttemptRepository{}
+	mockPaymentRepo := mockrepository.PaymentRepository{}
+	mockPaymentEventRepo := mockrepository.PaymentEventRepository{}
+	mockPaymentGateway := mockpaymentgateway.PaymentGateway{}
+
+	service := NewPaymentWebhookService(&mockInvoiceRepo, &mockOrderRepo, &mockPaymentAttemptRepo, &mockPaymentRepo, &mockPaymentEventRepo, &mockPaymentGateway)
+
+	payload := []byte("payload")
+	event := domain.PaymentEvent{Provider: "fake", EventID: "evt_1", Type: domain.EventPaymentCaptured, TransactionID: "tx_1"}
+
+	mockPaymentGateway.On("ParseWebhook", payload, "sig").Return(event, nil)
+	mockPaymentEventRepo.On("FindPaymentEvent", mock.Anything, "fake", "evt_1").Return(domain.PaymentEvent{}, nil)
+	mockPaymentEventRepo.On("SavePaymentEvent", mock.Anything, mock.Anything).Return(3, nil)
+	mockPaymentAttemptRepo.On("FindPaymentAttemptByTransactionId", mock.Anything, "fake", "tx_1").
+		Return(domain.PaymentAttempt{ID: 7, InvoiceID: 1, Amount: domain.NewMoney(1100, "USD"), TransactionID: "tx_1", Status: domain.AttemptSucceeded}, nil)
+	// the payment flow recorded the capture while the invoice still showed processing
+	mockInvoiceRepo.On("FindInvoiceById", mock.Anything, 1).
+		Return(domain.Invoice{ID: 1, OrderID: 2, Total: domain.NewMoney(1000, "USD"), Tax: domain.NewMoney(100, "USD"), PaymentStatus: domain.Processing}, nil)
+	mockPaymentAttemptRepo.On("UpdatePaymentAttempt", mock.Anything, mock.Anything).Return(nil)
+	mockOrderRepo.On("FindOrderById", mock.Anything, 2).
+		Return(domain.Order{ID: 2, CustomerID: 1, Status: domain.OrderPlaced}, nil)
+	mockPaymentRepo.On("SavePayment", mock.Anything, mock.Anything).
+		Return(0, apperr.NewAppError(apperr.ErrConflict, "unique constraint violation", nil))
+	mockPaymentEventRepo.On("UpdatePaymentEventStatus", mock.Anything, 3, domain.EventProcessed, "").Return(nil)
+
+	err := service.HandlePaymentWebhook(t.Context(), payload, "sig")
+	require.NoError(t, err)
+
+	mockPaymentEventRepo.AssertExpectations(t)
+	mockInvoiceRepo.AssertNotCalled(t, "ChangeInvoiceStatus", mock.Anything, mock.Anything, mock.Anything)
+	mockOrderRepo.AssertNotCalled(t, "UpdateOrderStatus", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func Test_services_PaymentWebhookService_HandlePaymentWebhook_Failed(t *testing.T) {
+	mockInvoiceRepo := mockrepository.InvoiceRepository{}
+	mockOrderRepo := mockrepository.OrderRepository{}
+	mockPaymentAttemptRepo := mockrepository.PaymentAttemptRepository{}
+	mockPaymentRepo := mockrepository.PaymentRepository{}
+	mockPaymentEventRepo := mockrepository.PaymentEventRepository{}
+	mockPaymentGateway := mockpaymentgateway.PaymentGateway{}
+
+	service := NewPaymentWebhookService(&mockInvoiceRepo, &mockOrderRepo, &mockPaymentAttemptRepo, &mockPaymentRepo, &mockPaymentEventRepo, &mockPaymentGateway)
+
+	payload := []byte("payload")
+	event := domain.PaymentEvent{Provider: "fake", EventID: "evt_1", Type: domain.EventPaymentFailed, TransactionID: "tx_1", Message: "card declined"}
+
+	mockPaymentGateway.On("ParseWebhook", payload, "sig").Return(event, nil)
+	mockPaymentEventRepo.On("FindPaymentEvent", mock.Anything, "fake", "evt_1").Return(domain.PaymentEvent{}, nil)
+	mockPaymentEventRepo.On("SavePaymentEvent", mock.Anything, mock.Anything).Return(3, nil)
+	mockPaymentAttemptRepo.On("FindPaymentAttemptByTransactionId", mock.Anything, "fake", "tx_1").
+		Return(domain.PaymentAttempt{ID: 7, InvoiceID: 1, Status: domain.AttemptPending}, nil)
+	mockInvoiceRepo.On("FindInvoiceById", mock.Anything, 1).
+		Return(domain.Invoice{ID: 1, OrderID: 2, PaymentStatus: domain.Processing}, nil)
+	mockPaymentAttemptRepo.On("UpdatePaymentAttempt", mock.Anything, mock.MatchedBy(func(a domain.PaymentAttempt) bool {
+		return a.Status == domain.AttemptFailed && a.FailureReason == "card declined"
+	})).Return(nil)
+	mockPaymentRepo.On("FindPaymentsByInvoiceId", mock.Anything, 1).Return([]domain.Payment{}, nil)
+	mockInvoiceRepo.On("ChangeInvoiceStatus", mock.Anything, 1, domain.Failed).Return(nil)
+	mockPaymentEventRepo.On("UpdatePaymentEventStatus", mock.Anything, 3, domain.EventProcessed, "").Return(nil)
+
+	err := service.HandlePaymentWebhook(t.Context(), payload, "sig")
+	require.NoError(t, err)
+
+	mockInvoiceRepo.AssertExpectations(t)
+	mockPaymentAttemptRepo.AssertExpectations(t)
+}
+
+func Test_services_PaymentWebhookService_HandlePaymentWebhook_Duplicate(t *testing.T) {
+	mockInvoiceRepo := mockrepository.InvoiceRepository{}
+	mockOrderRepo := mockrepository.OrderRepository{}
+	mockPaymentAttemptRepo := mockrepository.PaymentAttemptRepository{}
+	mockPaymentRepo := mockrepository.PaymentRepository{}
+	mockPaymentEventRepo := mockrepository.PaymentEventRepository{}
+	mockPaymentGateway := mockpaymentgateway.PaymentGateway{}
+
+	service := NewPaymentWebhookService(&mockInvoiceRepo, &mockOrderRepo, &mockPaymentAttemptRepo, &mockPaymentRepo, &mockPaymentEventRepo, &mockPaymentGateway)
+
+	payload := []byte("payload")
+	event := domain.PaymentEvent{Provider: "fake", EventID: "evt_1", Type: domain.EventPaymentCaptured, TransactionID: "tx_1"}
+
+	mockPaymentGateway.On("ParseWebhook", payload, "sig").Return(event, nil)
+	mockPaymentEventRepo.On("FindPaymentEvent", mock.Anything, "fake", "evt_1").
+		Return(domain.PaymentEvent{ID: 3, EventID: "evt_1", Status: domain.EventProcessed}, nil)
+
+	err := service.HandlePaymentWebhook(t.Context(), payload, "sig")
+	require.NoError(t, err)
+
+	mockPaymentEventRepo.AssertNotCalled(t, "SavePaymentEvent", mock.Anything, mock.Anything)
+	mockPaymentAttemptRepo.AssertNotCalled(t, "FindPaymentAttemptByTransactionId", mock.Anything, mock.Anything, mock.Anything)
+	mockInvoiceRepo.AssertNotCalled(t, "ChangeInvoiceStatus", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func Test_services_PaymentWebhookService_HandlePaymentWebhook_ConcurrentDuplicate(t *testing.T) {
+	mockInvoiceRepo := mockrepository.InvoiceRepository{}
+	mockOrderRepo := mockrepository.OrderRepository{}
+	mockPaymentAttemptRepo := mockrepository.PaymentAttemptRepository{}
+	mockPaymentRepo := mockrepository.PaymentRepository{}
+	mockPaymentEventRepo := mockrepository.PaymentEventRepository{}
+	mockPaymentGateway := mockpaymentgateway.PaymentGateway{}
+
+	service := NewPaymentWebhookService(&mockInvoiceRepo, &mockOrderRepo, &mockPaymentAttemptRepo, &mockPaymentRepo, &mockPaymentEventRepo, &mockPaymentGateway)
+
+	payload := []byte("payload")
+	event := domain.PaymentEvent{Provider: "fake", EventID: "evt_1", Type: domain.EventPaymentCaptured, TransactionID: "tx_1"}
+
+	mockPaymentGateway.On("ParseWebhook", payload, "sig").Return(event, nil)
+	mockPaymentEventRepo.On("FindPaymentEvent", mock.Anything, "fake", "evt_1").Return(domain.PaymentEvent{}, nil)
+	mockPaymentEventRepo.On("SavePaymentEvent", mock.Anything, mock.Anything).
+		Return(0, apperr.NewAppError(apperr.ErrConflict, "unique constraint violation", nil))
+
+	err := service.HandlePaymentWebhook(t.Context(), payload, "sig")
+	require.NoError(t, err)
+
+	mockPaymentAttemptRepo.AssertNotCalled(t, "FindPaymentAttemptByTransactionId", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func Test_services_PaymentWebhookService_HandlePaymentWebhook_RetriesFailedEvent(t *testing.T) {
+	mockInvoiceRepo := mockrepository.InvoiceRepository{}
+	mockOrderRepo := mockrepository.OrderRepository{}
+	mockPaymentAttemptRepo := mockrepository.PaymentAttemptRepository{}
+	mockPaymentRepo := mockrepository.PaymentRepository{}
+	mockPaymentEventRepo := mockrepository.PaymentEventRepository{}
+	mockPaymentGateway := mockpaymentgateway.PaymentGateway{}
+
+	service := NewPaymentWebhookService(&mockInvoiceRepo, &mockOrderRepo, &mockPaymentAttemptRepo, &mockPaymentRepo, &mockPaymentEventRepo, &mockPaymentGateway)
+
+	payload := []byte("payload")
+	event := domain.PaymentEvent{Provider: "fake", EventID: "evt_1", Type: domain.EventPaymentCaptured, TransactionID: "tx_1"}
+
+	mockPaymentGateway.On("ParseWebhook", payload, "sig").Return(event, nil)
+	mockPaymentEventRepo.On("FindPaymentEvent", mock.Anything, "fake", "evt_1").
+		Return(domain.PaymentEvent{ID: 3, EventID: "evt_1", Status: domain.EventFailed}, nil)
+	mockPaymentAttemptRepo.On("FindPaymentAttemptByTransactionId", mock.Anything, "fake", "tx_1").
+		Return(domain.PaymentAttempt{}, nil)
+	mockPaymentEventRepo.On("UpdatePaymentEventStatus", mock.Anything, 3, domain.EventFailed, "payment attempt not found").Return(nil)
+
+	err := service.HandlePaymentWebhook(t.Context(), payload, "sig")
+	require.True(t, apperr.IsNotFoundError(err))
+
+	mockPaymentEventRepo.AssertExpectations(t)
+	mockPaymentEventRepo.AssertNotCalled(t, "SavePaymentEvent", mock.Anything, mock.Anything)
+}
+
+func Test_services_PaymentWebhookService_HandlePaymentWebhook_StaleEvent(t *testing.T) {
+	mockInvoiceRepo := mockrepository.InvoiceRepository{}
+	mockOrderRepo := mockrepository.OrderRepository{}
+	mockPaymentAttemptRepo := mockrepository.PaymentAttemptRepository{}
+	mockPaymentRepo := mockrepository.PaymentRepository{}
+	mockPaymentEventRepo := mockrepository.PaymentEventRepository{}
+	mockPaymentGateway := mockpaymentgateway.PaymentGateway{}
+
+	service := NewPaymentWebhookService(&mockInvoiceRepo, &mockOrderRepo, &mockPaymentAttemptRepo, &mockPaymentRepo, &mockPaymentEventRepo, &mockPaymentGateway)
+
+	payload := []byte("payload")
+	event := domain.PaymentEvent{Provider: "fake", EventID: "evt_2", Type: domain.EventPaymentFailed, TransactionID: "tx_1"}
+
+	mockPaymentGateway.On("ParseWebhook", payload, "sig").Return(event, nil)
+	mockPaymentEventRepo.On("FindPaymentEvent", mock.Anything, "fake", "evt_2").Return(domain.PaymentEvent{}, nil)
+	mockPaymentEventRepo.On("SavePaymentEvent", mock.Anything, mock.Anything).Return(4, nil)
+	mockPaymentAttemptRepo.On("FindPaymentAttemptByTransactionId", mock.Anything, "fake", "tx_1").
+		Return(domain.PaymentAttempt{ID: 7, InvoiceID: 1, Status: domain.AttemptSucceeded}, nil)
+	mockInvoiceRepo.On("FindInvoiceById", mock.Anything, 1).
+		Return(domain.Invoice{ID: 1, OrderID: 2, PaymentStatus: domain.Paid}, nil)
+	mockPaymentEventRepo.On("UpdatePaymentEventStatus", mock.Anything, 4, domain.EventProcessed, "").Return(nil)
+
+	err := service.HandlePaymentWebhook(t.Context(), payload, "sig")
+	require.NoError(t, err)
+
+	mockInvoiceRepo.AssertNotCalled(t, "ChangeInvoiceStatus", mock.Anything, mock.Anything, mock.Anything)
+	mockPaymentAttemptRepo.AssertNotCalled(t, "UpdatePaymentAttempt", mock.Anything, mock.Anything)
+}
+
+func Test_services_PaymentWebhookService_HandlePaymentWebhook_InvalidSignature(t *testing.T) {
+	mockInvoiceRepo := mockrepository.InvoiceRepository{}
+	mockOrderRepo := mockrepository.OrderRepository{}
+	mockPaymentAttemptRepo := mockrepository.PaymentAttemptRepository{}
+	mockPaymentRepo := mockrepository.PaymentRepository{}
+	mockPaymentEventRepo := mockrepository.PaymentEventRepository{}
+	mockPaymentGateway := mockpaymentgateway.PaymentGateway{}
+
+	service := NewPaymentWebhookService(&mockInvoiceRepo, &mockOrderRepo, &mockPaymentAttemptRepo, &mockPaymentRepo, &mockPaymentEventRepo, &mockPaymentGateway)
+
+	payload := []byte("payload")
+
+	mockPaymentGateway.On("ParseWebhook", payload, "bad").
+		Return(domain.PaymentEvent{}, apperr.NewAppError(apperr.ErrUnauthorized, "invalid webhook signature", nil))
+
+	err := service.HandlePaymentWebhook(t.Context(), payload, "bad")
+	require.True(t, apperr.IsUnauthorizedError(err))
+
+	mockPaymentEventRepo.AssertNotCalled(t, "FindPaymentEvent", mock.Anything, mock.Anything, mock.Anything)
+}
@@ -2,20 +2,34 @@ package services
 
 import (
 	"context"
+	"strings"
 
 	"github.com/mohits-git/food-ordering-system/internal/domain"
 	"github.com/mohits-git/food-ordering-system/internal/ports"
 	"github.com/mohits-git/food-ordering-system/internal/utils/apperr"
-	"github.com/mohits-git/food-ordering-system/internal/utils/authctx"
 )
 
 type RestaurantService struct {
 	restaurantRepo ports.RestaurantRepository
+	memberRepo     ports.RestaurantMemberRepository
+	userRepo       ports.UserRepository
+	taxRuleRepo    ports.TaxRuleRepository
+	authorizer     ports.Authorizer
 }
 
-func NewRestaurantService(restaurantRepo ports.RestaurantRepository) *RestaurantService {
+func NewRestaurantService(
+	restaurantRepo ports.RestaurantRepository,
+	memberRepo ports.RestaurantMemberRepository,
+	userRepo ports.UserRepository,
+	taxRuleRepo ports.TaxRuleRepository,
+	authorizer ports.Authorizer,
+) *RestaurantService {
 	return &RestaurantService{
 		restaurantRepo: restaurantRepo,
+		memberRepo:     memberRepo,
+		userRepo:       userRepo,
+		taxRuleRepo:    taxRuleRepo,
+		authorizer:     authorizer,
 	}
 }
 
@@ -24,12 +38,9 @@ func (s *RestaurantService) CreateRestaurant(ctx context.Context, restaurantName
 		return 0, apperr.NewAppError(apperr.ErrInvalid, "restaurant name cannot be empty", nil)
 	}
 
-	user, ok := authctx.UserClaimsFromCtx(ctx)
-	if !ok {
-		return 0, apperr.NewAppError(apperr.ErrUnauthorized, "unauthorized", nil)
-	}
-	if user.Role != domain.OWNER {
-		return 0, apperr.NewAppError(apperr.ErrForbidden, "forbidden", nil)
+	user, err := s.authorizer.Authorize(ctx, domain.ActionCreateRestaurant)
+	if err != nil {
+		return 0, err
 	}
 
 	id, err := s.restaurantRepo.SaveRestaurant(ctx,
@@ -41,10 +52,208 @@ func (s *RestaurantService) CreateRestaurant(ctx context.Context, restaurantName
 	return id, nil
 }
 
-func (s *RestaurantService) GetAllRestaurants(ctx context.Context) ([]domain.Restaurant, error) {
-	restaurants, err := s.restaurantRepo.FindAllRestaurants(ctx)
+const (
+	defaultRestaurantsPageSize = 20
+	maxRestaurantsPageSize     = 100
+)
+
+// SearchRestaurants lists the restaurants matching the filter, sorted by
+// relevance when there is a query and by name otherwise unless a sort is
+// given.
+func (s *RestaurantService) SearchRestaurants(ctx context.Context, filter domain.RestaurantFilter) ([]domain.Restaurant, int, error) {
+	if filter.Cursor < 0 || filter.Limit < 0 {
+		return nil, 0, apperr.NewAppError(apperr.ErrInvalid, "invalid restaurant filter", nil)
+	}
+	if filter.Sort == "" {
+		filter.Sort = domain.RestaurantSortName
+		if strings.TrimSpace(filter.Query) != "" {
+			filter.Sort = domain.RestaurantSortRelevance
+		}
+	}
+	if !filter.Sort.IsValid() {
+		return nil, 0, apperr.NewAppError(apperr.ErrInvalid, "invalid restaurant sort", nil)
+	}
+	if filter.Cuisine != "" {
+		filter.Cuisine = domain.NormalizeCuisines([]string{filter.Cuisine})[0]
+	}
+
+	pageSize := filter.Limit
+	if pageSize == 0 {
+		pageSize = defaultRestaurantsPageSize
+	}
+	pageSize = min(pageSize, maxRestaurantsPageSize)
+
+	// fetch one extra restaurant to know if there is a next page
+	filter.Limit = pageSize + 1
+	restaurants, err := s.restaurantRepo.FindRestaurants(ctx, filter)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	nextCursor := 0
+	if len(restaurants) > pageSize {
+		restaurants = restaurants[:pageSize]
+		nextCursor = restaurants[pageSize-1].ID
+	}
+	return restaurants, nextCursor, nil
+}
+
+func (s *RestaurantService) GetRestaurantById(ctx context.Context, id int) (domain.Restaurant, error) {
+	if id <= 0 {
+		return domain.Restaurant{}, apperr.NewAppError(apperr.ErrInvalid, "invalid restaurant id", nil)
+	}
+	restaurant, err := s.restaurantRepo.FindRestaurantById(ctx, id)
+	if err != nil {
+		return domain.Restaurant{}, err
+	}
+	if restaurant.ID == 0 {
+		return domain.Restaurant{}, apperr.NewAppError(apperr.ErrNotFound, "restaurant not found", nil)
+	}
+	return restaurant, nil
+}
+
+// UpdateRestaurant replaces the profile of the restaurant, for its owner and
+// managers. The owner and the pause switch of the restaurant are kept.
+func (s *RestaurantService) UpdateRestaurant(ctx context.Context, restaurant domain.Restaurant) error {
+	if restaurant.ID <= 0 {
+		return apperr.NewAppError(apperr.ErrInvalid, "invalid restaurant id", nil)
+	}
+	if _, err := s.authorizer.AuthorizeResource(ctx, domain.ActionUpdateRestaurant, domain.Resource{RestaurantID: restaurant.ID}); err != nil {
+		return err
+	}
+
+	current, err := s.restaurantRepo.FindRestaurantById(ctx, restaurant.ID)
+	if err != nil {
+		return err
+	}
+	if current.ID == 0 {
+		return apperr.NewAppError(apperr.ErrNotFound, "restaurant not found", nil)
+	}
+	restaurant.OwnerID = current.OwnerID
+	restaurant.OrdersPaused = current.OrdersPaused
+	restaurant.Cuisines = domain.NormalizeCuisines(restaurant.Cuisines)
+	if !restaurant.Validate() {
+		return apperr.NewAppError(apperr.ErrInvalid, "invalid restaurant data", nil)
+	}
+
+	return s.restaurantRepo.UpdateRestaurant(ctx, restaurant)
+}
+
+// PauseOrders stops or resumes the restaurant taking orders, whatever its
+// opening hours.
+func (s *RestaurantService) PauseOrders(ctx context.Context, restaurantId int, paused bool) error {
+	if restaurantId <= 0 {
+		return apperr.NewAppError(apperr.ErrInvalid, "invalid restaurant id", nil)
+	}
+	if _, err := s.authorizer.AuthorizeResource(ctx, domain.ActionPauseOrders, domain.Resource{RestaurantID: restaurantId}); err != nil {
+		return err
+	}
+	return s.restaurantRepo.UpdateOrdersPaused(ctx, restaurantId, paused)
+}
+
+// InviteMember attaches the staff account with the email to the restaurant,
+// for its owner.
+func (s *RestaurantService) InviteMember(ctx context.Context, restaurantId int, email string, role domain.StaffRole) (domain.RestaurantMember, error) {
+	if restaurantId <= 0 || email == "" || !role.IsValid() {
+		return domain.RestaurantMember{}, apperr.NewAppError(apperr.ErrInvalid, "invalid member data", nil)
+	}
+	if _, err := s.authorizer.AuthorizeResource(ctx, domain.ActionManageStaff, domain.Resource{RestaurantID: restaurantId}); err != nil {
+		return domain.RestaurantMember{}, err
+	}
+
+	user, err := s.userRepo.FindUserByEmail(ctx, email)
+	if err != nil {
+		return domain.RestaurantMember{}, err
+	}
+	if user.ID == 0 {
+		return domain.RestaurantMember{}, apperr.NewAppError(apperr.ErrNotFound, "user not found", nil)
+	}
+	if user.Role != domain.STAFF {
+		return domain.RestaurantMember{}, apperr.NewAppError(apperr.ErrInvalid, "only staff accounts can join a restaurant", nil)
+	}
+
+	member := domain.NewRestaurantMember(restaurantId, user.ID, role)
+	if err := s.memberRepo.SaveRestaurantMember(ctx, member); err != nil {
+		return domain.RestaurantMember{}, err
+	}
+	member.Name = user.Name
+	member.Email = user.Email
+	return member, nil
+}
+
+func (s *RestaurantService) RemoveMember(ctx context.Context, restaurantId int, userId int) error {
+	if restaurantId <= 0 || userId <= 0 {
+		return apperr.NewAppError(apperr.ErrInvalid, "invalid member data", nil)
+	}
+	if _, err := s.authorizer.AuthorizeResource(ctx, domain.ActionManageStaff, domain.Resource{RestaurantID: restaurantId}); err != nil {
+		return err
+	}
+
+	deleted, err := s.memberRepo.DeleteRestaurantMember(ctx, restaurantId, userId)
+	if err != nil {
+		return err
+	}
+	if !deleted {
+		return apperr.NewAppError(apperr.ErrNotFound, "member not found", nil)
+	}
+	return nil
+}
+
+func (s *RestaurantService) GetMembers(ctx context.Context, restaurantId int) ([]domain.RestaurantMember, error) {
+	if restaurantId <= 0 {
+		return nil, apperr.NewAppError(apperr.ErrInvalid, "invalid restaurant id", nil)
+	}
+	if _, err := s.authorizer.AuthorizeResource(ctx, domain.ActionManageStaff, domain.Resource{RestaurantID: restaurantId}); err != nil {
+		return nil, err
+	}
+	return s.memberRepo.FindRestaurantMembers(ctx, restaurantId)
+}
+
+// GetMemberships lists the restaurants the current staff user works at.
+func (s *RestaurantService) GetMemberships(ctx context.Context) ([]domain.RestaurantMember, error) {
+	user, err := s.authorizer.Authorize(ctx, domain.ActionViewMemberships)
 	if err != nil {
 		return nil, err
 	}
-	return restaurants, nil
+	return s.memberRepo.FindMembershipsByUserId(ctx, user.UserID)
+}
+
+// SetTaxRule sets the tax rate of the restaurant for a category of its menu
+// items, an empty category applies to all of them. It replaces the rule the
+// restaurant already has for the category.
+func (s *RestaurantService) SetTaxRule(ctx context.Context, rule domain.TaxRule) (domain.TaxRule, error) {
+	rule.Category = strings.TrimSpace(rule.Category)
+	if rule.RestaurantID <= 0 || !rule.Validate() {
+		return domain.TaxRule{}, apperr.NewAppError(apperr.ErrInvalid, "invalid tax rule, the rate is a fraction between 0 and 1", nil)
+	}
+	if _, err := s.authorizer.AuthorizeResource(ctx, domain.ActionManageTaxRules, domain.Resource{RestaurantID: rule.RestaurantID}); err != nil {
+		return domain.TaxRule{}, err
+	}
+
+	id, err := s.taxRuleRepo.SaveTaxRule(ctx, rule)
+	if err != nil {
+		return domain.TaxRule{}, err
+	}
+	rule.ID = id
+	return rule, nil
+}
+
+// DeleteTaxRule removes the restaurant's rule for the category, its items are
+// taxed by the next most specific rule again.
+func (s *RestaurantService) DeleteTaxRule(ctx context.Context, restaurantId int, category string) error {
+	if restaurantId <= 0 {
+		return apperr.NewAppError(apperr.ErrInvalid, "invalid restaurant id", nil)
+	}
+	if _, err := s.authorizer.AuthorizeResource(ctx, domain.ActionManageTaxRules, domain.Resource{RestaurantID: restaurantId}); err != nil {
+		return err
+	}
+
+	deleted, err := s.taxRuleRepo.DeleteTaxRule(ctx, restaurantId, strings.TrimSpace(category))
+	if err != nil {
+		return err
+	}
+	if !deleted {
+		return apperr.NewAppError(apperr.ErrNotFound, "tax rule not found", nil)
+	}
+	return nil
 }
@@ -2,6 +2,7 @@ package services
 
 import (
 	"testing"
+	"time"
 
 	"github.com/mohits-git/food-ordering-system/internal/domain"
 	"github.com/mohits-git/food-ordering-system/internal/utils/apperr"
@@ -13,37 +14,64 @@ import (
 
 func Test_services_RestaurantService_NewRestaurantService(t *testing.T) {
 	mockRepo := mockrepository.RestaurantRepository{}
-	service := NewRestaurantService(&mockRepo)
+	service := NewRestaurantService(&mockRepo, &mockrepository.RestaurantMemberRepository{}, &mockrepository.UserRepository{}, &mockrepository.TaxRuleRepository{}, NewAuthorizer(&mockRepo, &mockrepository.RestaurantMemberRepository{}))
 	require.NotNil(t, service)
 }
 
-func Test_services_RestaurantService_GetAllRestaurants(t *testing.T) {
+func Test_services_RestaurantService_SearchRestaurants(t *testing.T) {
 	mockRepo := mockrepository.RestaurantRepository{}
-	service := NewRestaurantService(&mockRepo)
+	service := NewRestaurantService(&mockRepo, &mockrepository.RestaurantMemberRepository{}, &mockrepository.UserRepository{}, &mockrepository.TaxRuleRepository{}, NewAuthorizer(&mockRepo, &mockrepository.RestaurantMemberRepository{}))
 
-	mockRepo.On("FindAllRestaurants", mock.Anything).
+	mockRepo.On("FindRestaurants", mock.Anything, domain.RestaurantFilter{Query: "pizza", Cuisine: "italian", Sort: domain.RestaurantSortRelevance, Cursor: 2, Limit: 3}).
 		Return([]domain.Restaurant{
 			{ID: 1, Name: "Restaurant 1", OwnerID: 1},
 			{ID: 2, Name: "Restaurant 2", OwnerID: 2},
+			{ID: 3, Name: "Restaurant 3", OwnerID: 3},
 		}, nil)
 
-	restaurants, err := service.GetAllRestaurants(t.Context())
+	restaurants, nextCursor, err := service.SearchRestaurants(t.Context(), domain.RestaurantFilter{Query: "pizza", Cuisine: " Italian ", Cursor: 2, Limit: 2})
 	require.NoError(t, err)
 	require.Len(t, restaurants, 2)
 	require.Equal(t, "Restaurant 1", restaurants[0].Name)
 	require.Equal(t, "Restaurant 2", restaurants[1].Name)
+	require.Equal(t, 2, nextCursor)
 	mockRepo.AssertExpectations(t)
 }
 
-func Test_services_RestaurantService_GetAllRestaurants_when_error(t *testing.T) {
+func Test_services_RestaurantService_SearchRestaurants_when_last_page(t *testing.T) {
 	mockRepo := mockrepository.RestaurantRepository{}
-	service := NewRestaurantService(&mockRepo)
+	service := NewRestaurantService(&mockRepo, &mockrepository.RestaurantMemberRepository{}, &mockrepository.UserRepository{}, &mockrepository.TaxRuleRepository{}, NewAuthorizer(&mockRepo, &mockrepository.RestaurantMemberRepository{}))
+
+	mockRepo.On("FindRestaurants", mock.Anything, domain.RestaurantFilter{Sort: domain.RestaurantSortName, Limit: defaultRestaurantsPageSize + 1}).
+		Return([]domain.Restaurant{{ID: 1, Name: "Restaurant 1", OwnerID: 1}}, nil)
+
+	restaurants, nextCursor, err := service.SearchRestaurants(t.Context(), domain.RestaurantFilter{})
+	require.NoError(t, err)
+	require.Len(t, restaurants, 1)
+	require.Equal(t, 0, nextCursor)
+	mockRepo.AssertExpectations(t)
+}
+
+func Test_services_RestaurantService_SearchRestaurants_when_invalid_filter(t *testing.T) {
+	mockRepo := mockrepository.RestaurantRepository{}
+	service := NewRestaurantService(&mockRepo, &mockrepository.RestaurantMemberRepository{}, &mockrepository.UserRepository{}, &mockrepository.TaxRuleRepository{}, NewAuthorizer(&mockRepo, &mockrepository.RestaurantMemberRepository{}))
+
+	_, _, err := service.SearchRestaurants(t.Context(), domain.RestaurantFilter{Sort: "rating"})
+	require.True(t, apperr.IsInvalidError(err))
+	_, _, err = service.SearchRestaurants(t.Context(), domain.RestaurantFilter{Cursor: -1})
+	require.True(t, apperr.IsInvalidError(err))
+	mockRepo.AssertNotCalled(t, "FindRestaurants", mock.Anything, mock.Anything)
+}
+
+func Test_services_RestaurantService_SearchRestaurants_when_error(t *testing.T) {
+	mockRepo := mockrepository.RestaurantRepository{}
+	service := NewRestaurantService(&mockRepo, &mockrepository.RestaurantMemberRepository{}, &mockrepository.UserRepository{}, &mockrepository.TaxRuleRepository{}, NewAuthorizer(&mockRepo, &mockrepository.RestaurantMemberRepository{}))
 	expectedErr := apperr.NewAppError(apperr.ErrInternal, "internal error", nil)
 
-	mockRepo.On("FindAllRestaurants", mock.Anything).
+	mockRepo.On("FindRestaurants", mock.Anything, mock.Anything).
 		Return([]domain.Restaurant{}, expectedErr)
 
-	restaurants, err := service.GetAllRestaurants(t.Context())
+	restaurants, _, err := service.SearchRestaurants(t.Context(), domain.RestaurantFilter{})
 	require.ErrorIs(t, err, expectedErr)
 	require.Nil(t, restaurants)
 	mockRepo.AssertExpectations(t)
@@ -51,7 +79,7 @@ func Test_services_RestaurantService_GetAllRestaurants_when_error(t *testing.T)
 
 func Test_services_RestaurantService_CreateRestaurant(t *testing.T) {
 	mockRepo := mockrepository.RestaurantRepository{}
-	service := NewRestaurantService(&mockRepo)
+	service := NewRestaurantService(&mockRepo, &mockrepository.RestaurantMemberRepository{}, &mockrepository.UserRepository{}, &mockrepository.TaxRuleRepository{}, NewAuthorizer(&mockRepo, &mockrepository.RestaurantMemberRepository{}))
 
 	newRestaurant := domain.Restaurant{Name: "New Restaurant", OwnerID: 1}
 
@@ -71,7 +99,7 @@ func Test_services_RestaurantService_CreateRestaurant(t *testing.T) {
 
 func Test_services_RestaurantService_CreateRestaurant_when_invalid_name(t *testing.T) {
 	mockRepo := mockrepository.RestaurantRepository{}
-	service := NewRestaurantService(&mockRepo)
+	service := NewRestaurantService(&mockRepo, &mockrepository.RestaurantMemberRepository{}, &mockrepository.UserRepository{}, &mockrepository.TaxRuleRepository{}, NewAuthorizer(&mockRepo, &mockrepository.RestaurantMemberRepository{}))
 
 	ctx := authctx.WithUserClaims(t.Context(), &authctx.UserClaims{
 		UserID: 1,
@@ -86,7 +114,7 @@ func Test_services_RestaurantService_CreateRestaurant_when_invalid_name(t *testi
 
 func Test_services_RestaurantService_CreateRestaurant_when_unauthorized(t *testing.T) {
 	mockRepo := mockrepository.RestaurantRepository{}
-	service := NewRestaurantService(&mockRepo)
+	service := NewRestaurantService(&mockRepo, &mockrepository.RestaurantMemberRepository{}, &mockrepository.UserRepository{}, &mockrepository.TaxRuleRepository{}, NewAuthorizer(&mockRepo, &mockrepository.RestaurantMemberRepository{}))
 
 	restaurantId, err := service.CreateRestaurant(t.Context(), "New Restaurant")
 	require.Error(t, err)
@@ -96,7 +124,7 @@ func Test_services_RestaurantService_CreateRestaurant_when_unauthorized(t *testi
 
 func Test_services_RestaurantService_CreateRestaurant_when_forbidden(t *testing.T) {
 	mockRepo := mockrepository.RestaurantRepository{}
-	service := NewRestaurantService(&mockRepo)
+	service := NewRestaurantService(&mockRepo, &mockrepository.RestaurantMemberRepository{}, &mockrepository.UserRepository{}, &mockrepository.TaxRuleRepository{}, NewAuthorizer(&mockRepo, &mockrepository.RestaurantMemberRepository{}))
 
 	ctx := authctx.WithUserClaims(t.Context(), &authctx.UserClaims{
 		UserID: 1,
@@ -111,7 +139,7 @@ func Test_services_RestaurantService_CreateRestaurant_when_forbidden(t *testing.
 
 func Test_services_RestaurantService_CreateRestaurant_when_repo_error(t *testing.T) {
 	mockRepo := mockrepository.RestaurantRepository{}
-	service := NewRestaurantService(&mockRepo)
+	service := NewRestaurantService(&mockRepo, &mockrepository.RestaurantMemberRepository{}, &mockrepository.UserRepository{}, &mockrepository.TaxRuleRepository{}, NewAuthorizer(&mockRepo, &mockrepository.RestaurantMemberRepository{}))
 	expectedErr := apperr.NewAppError(apperr.ErrInternal, "internal error", nil)
 
 	newRestaurant := domain.Restaurant{Name: "New Restaurant", OwnerID: 1}
@@ -129,3 +157,362 @@ func Test_services_RestaurantService_CreateRestaurant_when_repo_error(t *testing
 	require.Equal(t, 0, restaurantId)
 	mockRepo.AssertExpectations(t)
 }
+
+func Test_services_RestaurantService_InviteMember(t *testing.T) {
+	mockRepo := mockrepository.RestaurantRepository{}
+	mockMemberRepo := mockrepository.RestaurantMemberRepository{}
+	mockUserRepo := mockrepository.UserRepository{}
+
+	service := NewRestaurantService(&mockRepo, &mockMemberRepo, &mockUserRepo, &mockrepository.TaxRuleRepository{}, NewAuthorizer(&mockRepo, &mockMemberRepo))
+
+	// restaurant 1 is owned by user 1
+	mockRepo.On("FindRestaurantById", mock.Anything, 1).
+		Return(domain.Restaurant{ID: 1, Name: "Restaurant 1", OwnerID: 1}, nil).Maybe()
+
+	ctx := authctx.WithUserClaims(t.Context(), &authctx.UserClaims{UserID: 1, Role: domain.OWNER})
+
+	mockUserRepo.On("FindUserByEmail", mock.Anything, "cook@example.com").
+		Return(domain.User{ID: 5, Name: "Cook", Email: "cook@example.com", Role: domain.STAFF}, nil)
+	mockMemberRepo.On("SaveRestaurantMember", mock.Anything, domain.NewRestaurantMember(1, 5, domain.StaffKitchen)).
+		Return(nil)
+
+	member, err := service.InviteMember(ctx, 1, "cook@example.com", domain.StaffKitchen)
+	require.NoError(t, err)
+	require.Equal(t, domain.RestaurantMember{RestaurantID: 1, UserID: 5, Role: domain.StaffKitchen, Name: "Cook", Email: "cook@example.com"}, member)
+	mockUserRepo.AssertExpectations(t)
+	mockMemberRepo.AssertExpectations(t)
+}
+
+func Test_services_RestaurantService_InviteMember_when_not_owner(t *testing.T) {
+	mockRepo := mockrepository.RestaurantRepository{}
+	mockMemberRepo := mockrepository.RestaurantMemberRepository{}
+	mockUserRepo := mockrepository.UserRepository{}
+
+	service := NewRestaurantService(&mockRepo, &mockMemberRepo, &mockUserRepo, &mockrepository.TaxRuleRepository{}, NewAuthorizer(&mockRepo, &mockMemberRepo))
+
+	// restaurant 1 is owned by user 1
+	mockRepo.On("FindRestaurantById", mock.Anything, 1).
+		Return(domain.Restaurant{ID: 1, Name: "Restaurant 1", OwnerID: 1}, nil).Maybe()
+
+	ctx := authctx.WithUserClaims(t.Context(), &authctx.UserClaims{UserID: 2, Role: domain.OWNER})
+
+	_, err := service.InviteMember(ctx, 1, "cook@example.com", domain.StaffKitchen)
+	require.True(t, apperr.IsForbiddenError(err), "expected forbidden error but got %v", err)
+	mockUserRepo.AssertNotCalled(t, "FindUserByEmail", mock.Anything, mock.Anything)
+	mockMemberRepo.AssertNotCalled(t, "SaveRestaurantMember", mock.Anything, mock.Anything)
+}
+
+func Test_services_RestaurantService_InviteMember_when_not_staff_account(t *testing.T) {
+	mockRepo := mockrepository.RestaurantRepository{}
+	mockMemberRepo := mockrepository.RestaurantMemberRepository{}
+	mockUserRepo := mockrepository.UserRepository{}
+
+	service := NewRestaurantService(&mockRepo, &mockMemberRepo, &mockUserRepo, &mockrepository.TaxRuleRepository{}, NewAuthorizer(&mockRepo, &mockMemberRepo))
+
+	// restaurant 1 is owned by user 1
+	mockRepo.On("FindRestaurantById", mock.Anything, 1).
+		Return(domain.Restaurant{ID: 1, Name: "Restaurant 1", OwnerID: 1}, nil).Maybe()
+
+	ctx := authctx.WithUserClaims(t.Context(), &authctx.UserClaims{UserID: 1, Role: domain.OWNER})
+
+	mockUserRepo.On("FindUserByEmail", mock.Anything, "john@example.com").
+		Return(domain.User{ID: 6, Name: "John", Email: "john@example.com", Role: domain.CUSTOMER}, nil)
+
+	_, err := service.InviteMember(ctx, 1, "john@example.com", domain.StaffCashier)
+	require.True(t, apperr.IsInvalidError(err), "expected invalid error but got %v", err)
+	mockMemberRepo.AssertNotCalled(t, "SaveRestaurantMember", mock.Anything, mock.Anything)
+}
+
+func Test_services_RestaurantService_InviteMember_when_invalid_role(t *testing.T) {
+	mockRepo := mockrepository.RestaurantRepository{}
+	mockMemberRepo := mockrepository.RestaurantMemberRepository{}
+	mockUserRepo := mockrepository.UserRepository{}
+
+	service := NewRestaurantService(&mockRepo, &mockMemberRepo, &mockUserRepo, &mockrepository.TaxRuleRepository{}, NewAuthorizer(&mockRepo, &mockMemberRepo))
+
+	// restaurant 1 is owned by user 1
+	mockRepo.On("FindRestaurantById", mock.Anything, 1).
+		Return(domain.Restaurant{ID: 1, Name: "Restaurant 1", OwnerID: 1}, nil).Maybe()
+
+	ctx := authctx.WithUserClaims(t.Context(), &authctx.UserClaims{UserID: 1, Role: domain.OWNER})
+
+	_, err := service.InviteMember(ctx, 1, "cook@example.com", domain.StaffRole("waiter"))
+	require.True(t, apperr.IsInvalidError(err), "expected invalid error but got %v", err)
+	mockUserRepo.AssertNotCalled(t, "FindUserByEmail", mock.Anything, mock.Anything)
+}
+
+func Test_services_RestaurantService_RemoveMember(t *testing.T) {
+	mockRepo := mockrepository.RestaurantRepository{}
+	mockMemberRepo := mockrepository.RestaurantMemberRepository{}
+	mockUserRepo := mockrepository.UserRepository{}
+
+	service := NewRestaurantService(&mockRepo, &mockMemberRepo, &mockUserRepo, &mockrepository.TaxRuleRepository{}, NewAuthorizer(&mockRepo, &mockMemberRepo))
+
+	// restaurant 1 is owned by user 1
+	mockRepo.On("FindRestaurantById", mock.Anything, 1).
+		Return(domain.Restaurant{ID: 1, Name: "Restaurant 1", OwnerID: 1}, nil).Maybe()
+
+	ctx := authctx.WithUserClaims(t.Context(), &authctx.UserClaims{UserID: 1, Role: domain.OWNER})
+
+	mockMemberRepo.On("DeleteRestaurantMember", mock.Anything, 1, 5).Return(true, nil)
+	mockMemberRepo.On("DeleteRestaurantMember", mock.Anything, 1, 6).Return(false, nil)
+
+	err := service.RemoveMember(ctx, 1, 5)
+	require.NoError(t, err)
+
+	err = service.RemoveMember(ctx, 1, 6)
+	require.True(t, apperr.IsNotFoundError(err), "expected not found error but got %v", err)
+	mockMemberRepo.AssertExpectations(t)
+}
+
+func Test_services_RestaurantService_GetMembers(t *testing.T) {
+	mockRepo := mockrepository.RestaurantRepository{}
+	mockMemberRepo := mockrepository.RestaurantMemberRepository{}
+	mockUserRepo := mockrepository.UserRepository{}
+
+	service := NewRestaurantService(&mockRepo, &mockMemberRepo, &mockUserRepo, &mockrepository.TaxRuleRepository{}, NewAuthorizer(&mockRepo, &mockMemberRepo))
+
+	// restaurant 1 is owned by user 1
+	mockRepo.On("FindRestaurantById", mock.Anything, 1).
+		Return(domain.Restaurant{ID: 1, Name: "Restaurant 1", OwnerID: 1}, nil).Maybe()
+
+	ctx := authctx.WithUserClaims(t.Context(), &authctx.UserClaims{UserID: 1, Role: domain.OWNER})
+
+	members := []domain.RestaurantMember{{RestaurantID: 1, UserID: 5, Role: domain.StaffKitchen, Name: "Cook", Email: "cook@example.com"}}
+	mockMemberRepo.On("FindRestaurantMembers", mock.Anything, 1).Return(members, nil)
+
+	result, err := service.GetMembers(ctx, 1)
+	require.NoError(t, err)
+	require.Equal(t, members, result)
+
+	staffCtx := authctx.WithUserClaims(t.Context(), &authctx.UserClaims{UserID: 5, Role: domain.STAFF})
+	_, err = service.GetMembers(staffCtx, 1)
+	require.True(t, apperr.IsForbiddenError(err), "expected forbidden error but got %v", err)
+	mockMemberRepo.AssertExpectations(t)
+}
+
+func Test_services_RestaurantService_GetMemberships(t *testing.T) {
+	mockRepo := mockrepository.RestaurantRepository{}
+	mockMemberRepo := mockrepository.RestaurantMemberRepository{}
+	mockUserRepo := mockrepository.UserRepository{}
+
+	service := NewRestaurantService(&mockRepo, &mockMemberRepo, &mockUserRepo, &mockrepository.TaxRuleRepository{}, NewAuthorizer(&mockRepo, &mockMemberRepo))
+
+	// restaurant 1 is owned by user 1
+	mockRepo.On("FindRestaurantById", mock.Anything, 1).
+		Return(domain.Restaurant{ID: 1, Name: "Restaurant 1", OwnerID: 1}, nil).Maybe()
+
+	ctx := authctx.WithUserClaims(t.Context(), &authctx.UserClaims{UserID: 5, Role: domain.STAFF})
+
+	memberships := []domain.RestaurantMember{domain.NewRestaurantMember(1, 5, domain.StaffKitchen)}
+	mockMemberRepo.On("FindMembershipsByUserId", mock.Anything, 5).Return(memberships, nil)
+
+	result, err := service.GetMemberships(ctx)
+	require.NoError(t, err)
+	require.Equal(t, memberships, result)
+
+	customerCtx := authctx.WithUserClaims(t.Context(), &authctx.UserClaims{UserID: 6, Role: domain.CUSTOMER})
+	_, err = service.GetMemberships(customerCtx)
+	require.True(t, apperr.IsForbiddenError(err), "expected forbidden error but got %v", err)
+	mockMemberRepo.AssertExpectations(t)
+}
+
+func Test_services_RestaurantService_GetRestaurantById(t *testing.T) {
+	mockRepo := mockrepository.RestaurantRepository{}
+	mockMemberRepo := mockrepository.RestaurantMemberRepository{}
+	mockUserRepo := mockrepository.UserRepository{}
+
+	service := NewRestaurantService(&mockRepo, &mockMemberRepo, &mockUserRepo, &mockrepository.TaxRuleRepository{}, NewAuthorizer(&mockRepo, &mockMemberRepo))
+
+	// restaurant 1 is owned by user 1
+	mockRepo.On("FindRestaurantById", mock.Anything, 1).
+		Return(domain.Restaurant{ID: 1, Name: "Restaurant 1", OwnerID: 1}, nil).Maybe()
+
+	mockRepo.On("FindRestaurantById", mock.Anything, 2).Return(domain.Restaurant{}, nil)
+
+	restaurant, err := service.GetRestaurantById(t.Context(), 1)
+	require.NoError(t, err)
+	require.Equal(t, "Restaurant 1", restaurant.Name)
+
+	_, err = service.GetRestaurantById(t.Context(), 2)
+	require.True(t, apperr.IsNotFoundError(err), "expected not found error but got %v", err)
+
+	_, err = service.GetRestaurantById(t.Context(), 0)
+	require.True(t, apperr.IsInvalidError(err), "expected invalid error but got %v", err)
+}
+
+func Test_services_RestaurantService_UpdateRestaurant(t *testing.T) {
+	mockRepo := mockrepository.RestaurantRepository{}
+	mockMemberRepo := mockrepository.RestaurantMemberRepository{}
+	mockUserRepo := mockrepository.UserRepository{}
+
+	service := NewRestaurantService(&mockRepo, &mockMemberRepo, &mockUserRepo, &mockrepository.TaxRuleRepository{}, NewAuthorizer(&mockRepo, &mockMemberRepo))
+
+	// restaurant 1 is owned by user 1
+	mockRepo.On("FindRestaurantById", mock.Anything, 1).
+		Return(domain.Restaurant{ID: 1, Name: "Restaurant 1", OwnerID: 1}, nil).Maybe()
+
+	ctx := authctx.WithUserClaims(t.Context(), &authctx.UserClaims{UserID: 5, Role: domain.STAFF})
+	mockMemberRepo.On("FindRestaurantMember", mock.Anything, 1, 5).
+		Return(domain.NewRestaurantMember(1, 5, domain.StaffManager), nil)
+
+	hours := []domain.OpeningHours{{Weekday: time.Monday, Start: 660, End: 1320}}
+	mockRepo.On("UpdateRestaurant", mock.Anything, domain.Restaurant{
+		ID: 1, Name: "Renamed", OwnerID: 1, Address: "1 Main St", Phone: "555-0100",
+		Cuisines: []string{"indian", "vegan"}, OpeningHours: hours, Closures: []string{"2026-12-25"},
+	}).Return(nil)
+
+	err := service.UpdateRestaurant(ctx, domain.Restaurant{
+		ID: 1, Name: "Renamed", Address: "1 Main St", Phone: "555-0100",
+		Cuisines: []string{"Indian", " vegan"}, OpeningHours: hours, Closures: []string{"2026-12-25"},
+	})
+	require.NoError(t, err)
+	mockRepo.AssertExpectations(t)
+}
+
+func Test_services_RestaurantService_UpdateRestaurant_when_invalid(t *testing.T) {
+	mockRepo := mockrepository.RestaurantRepository{}
+	mockMemberRepo := mockrepository.RestaurantMemberRepository{}
+	mockUserRepo := mockrepository.UserRepository{}
+
+	service := NewRestaurantService(&mockRepo, &mockMemberRepo, &mockUserRepo, &mockrepository.TaxRuleRepository{}, NewAuthorizer(&mockRepo, &mockMemberRepo))
+
+	// restaurant 1 is owned by user 1
+	mockRepo.On("FindRestaurantById", mock.Anything, 1).
+		Return(domain.Restaurant{ID: 1, Name: "Restaurant 1", OwnerID: 1}, nil).Maybe()
+
+	ctx := authctx.WithUserClaims(t.Context(), &authctx.UserClaims{UserID: 1, Role: domain.OWNER})
+
+	err := service.UpdateRestaurant(ctx, domain.Restaurant{ID: 1, Name: "Renamed", Closures: []string{"tomorrow"}})
+	require.True(t, apperr.IsInvalidError(err), "expected invalid error but got %v", err)
+
+	err = service.UpdateRestaurant(ctx, domain.Restaurant{ID: 1, Name: ""})
+	require.True(t, apperr.IsInvalidError(err), "expected invalid error but got %v", err)
+	mockRepo.AssertNotCalled(t, "UpdateRestaurant", mock.Anything, mock.Anything)
+}
+
+func Test_services_RestaurantService_UpdateRestaurant_when_cashier(t *testing.T) {
+	mockRepo := mockrepository.RestaurantRepository{}
+	mockMemberRepo := mockrepository.RestaurantMemberRepository{}
+	mockUserRepo := mockrepository.UserRepository{}
+
+	service := NewRestaurantService(&mockRepo, &mockMemberRepo, &mockUserRepo, &mockrepository.TaxRuleRepository{}, NewAuthorizer(&mockRepo, &mockMemberRepo))
+
+	// restaurant 1 is owned by user 1
+	mockRepo.On("FindRestaurantById", mock.Anything, 1).
+		Return(domain.Restaurant{ID: 1, Name: "Restaurant 1", OwnerID: 1}, nil).Maybe()
+
+	ctx := authctx.WithUserClaims(t.Context(), &authctx.UserClaims{UserID: 5, Role: domain.STAFF})
+	mockMemberRepo.On("FindRestaurantMember", mock.Anything, 1, 5).
+		Return(domain.NewRestaurantMember(1, 5, domain.StaffCashier), nil)
+
+	err := service.UpdateRestaurant(ctx, domain.Restaurant{ID: 1, Name: "Renamed"})
+	require.True(t, apperr.IsForbiddenError(err), "expected forbidden error but got %v", err)
+	mockRepo.AssertNotCalled(t, "UpdateRestaurant", mock.Anything, mock.Anything)
+}
+
+func Test_services_RestaurantService_PauseOrders(t *testing.T) {
+	mockRepo := mockrepository.RestaurantRepository{}
+	mockMemberRepo := mockrepository.RestaurantMemberRepository{}
+	mockUserRepo := mockrepository.UserRepository{}
+
+	service := NewRestaurantService(&mockRepo, &mockMemberRepo, &mockUserRepo, &mockrepository.TaxRuleRepository{}, NewAuthorizer(&mockRepo, &mockMemberRepo))
+
+	// restaurant 1 is owned by user 1
+	mockRepo.On("FindRestaurantById", mock.Anything, 1).
+		Return(domain.Restaurant{ID: 1, Name: "Restaurant 1", OwnerID: 1}, nil).Maybe()
+
+	ctx := authctx.WithUserClaims(t.Context(), &authctx.UserClaims{UserID: 5, Role: domain.STAFF})
+	mockMemberRepo.On("FindRestaurantMember", mock.Anything, 1, 5).
+		Return(domain.NewRestaurantMember(1, 5, domain.StaffKitchen), nil)
+	mockRepo.On("UpdateOrdersPaused", mock.Anything, 1, true).Return(nil)
+
+	err := service.PauseOrders(ctx, 1, true)
+	require.NoError(t, err)
+
+	customerCtx := authctx.WithUserClaims(t.Context(), &authctx.UserClaims{UserID: 6, Role: domain.CUSTOMER})
+	err = service.PauseOrders(customerCtx, 1, false)
+	require.True(t, apperr.IsForbiddenError(err), "expected forbidden error but got %v", err)
+	mockRepo.AssertExpectations(t)
+}
+
+func Test_services_RestaurantService_SetTaxRule(t *testing.T) {
+	mockRepo := mockrepository.RestaurantRepository{}
+	mockMemberRepo := mockrepository.RestaurantMemberRepository{}
+	mockTaxRuleRepo := mockrepository.TaxRuleRepository{}
+
+	service := NewRestaurantService(&mockRepo, &mockMemberRepo, &mockrepository.UserRepository{}, &mockTaxRuleRepo, NewAuthorizer(&mockRepo, &mockMemberRepo))
+
+	// restaurant 1 is owned by user 1
+	mockRepo.On("FindRestaurantById", mock.Anything, 1).
+		Return(domain.Restaurant{ID: 1, Name: "Restaurant 1", OwnerID: 1}, nil).Maybe()
+
+	mockTaxRuleRepo.On("SaveTaxRule", mock.Anything, domain.TaxRule{RestaurantID: 1, Category: "alcohol", Rate: 0.2, Inclusive: true}).
+		Return(4, nil).Twice()
+
+	ctx := authctx.WithUserClaims(t.Context(), &authctx.UserClaims{UserID: 1, Role: domain.OWNER})
+	rule, err := service.SetTaxRule(ctx, domain.TaxRule{RestaurantID: 1, Category: " alcohol ", Rate: 0.2, Inclusive: true})
+	require.NoError(t, err)
+	require.Equal(t, domain.TaxRule{ID: 4, RestaurantID: 1, Category: "alcohol", Rate: 0.2, Inclusive: true}, rule)
+
+	ctx = authctx.WithUserClaims(t.Context(), &authctx.UserClaims{UserID: 9, Role: domain.ADMIN})
+	_, err = service.SetTaxRule(ctx, domain.TaxRule{RestaurantID: 1, Category: "alcohol", Rate: 0.2, Inclusive: true})
+	require.NoError(t, err, "expected an admin to manage any restaurant's tax rules")
+	mockTaxRuleRepo.AssertExpectations(t)
+}
+
+func Test_services_RestaurantService_SetTaxRule_when_not_owner(t *testing.T) {
+	mockRepo := mockrepository.RestaurantRepository{}
+	mockMemberRepo := mockrepository.RestaurantMemberRepository{}
+	mockTaxRuleRepo := mockrepository.TaxRuleRepository{}
+
+	service := NewRestaurantService(&mockRepo, &mockMemberRepo, &mockrepository.UserRepository{}, &mockTaxRuleRepo, NewAuthorizer(&mockRepo, &mockMemberRepo))
+
+	// restaurant 1 is owned by user 1
+	mockRepo.On("FindRestaurantById", mock.Anything, 1).
+		Return(domain.Restaurant{ID: 1, Name: "Restaurant 1", OwnerID: 1}, nil).Maybe()
+
+	ctx := authctx.WithUserClaims(t.Context(), &authctx.UserClaims{UserID: 2, Role: domain.OWNER})
+
+	_, err := service.SetTaxRule(ctx, domain.TaxRule{RestaurantID: 1, Rate: 0.1})
+	require.True(t, apperr.IsForbiddenError(err), "expected forbidden error but got %v", err)
+	mockTaxRuleRepo.AssertNotCalled(t, "SaveTaxRule", mock.Anything, mock.Anything)
+}
+
+func Test_services_RestaurantService_SetTaxRule_when_invalid_rate(t *testing.T) {
+	mockRepo := mockrepository.RestaurantRepository{}
+	mockMemberRepo := mockrepository.RestaurantMemberRepository{}
+	mockTaxRuleRepo := mockrepository.TaxRuleRepository{}
+
+	service := NewRestaurantService(&mockRepo, &mockMemberRepo, &mockrepository.UserRepository{}, &mockTaxRuleRepo, NewAuthorizer(&mockRepo, &mockMemberRepo))
+
+	ctx := authctx.WithUserClaims(t.Context(), &authctx.UserClaims{UserID: 1, Role: domain.OWNER})
+
+	_, err := service.SetTaxRule(ctx, domain.TaxRule{RestaurantID: 1, Rate: 20})
+	require.True(t, apperr.IsInvalidError(err), "expected invalid error but got %v", err)
+	mockTaxRuleRepo.AssertNotCalled(t, "SaveTaxRule", mock.Anything, mock.Anything)
+}
+
+func Test_services_RestaurantService_DeleteTaxRule(t *testing.T) {
+	mockRepo := mockrepository.RestaurantRepository{}
+	mockMemberRepo := mockrepository.RestaurantMemberRepository{}
+	mockTaxRuleRepo := mockrepository.TaxRuleRepository{}
+
+	service := NewRestaurantService(&mockRepo, &mockMemberRepo, &mockrepository.UserRepository{}, &mockTaxRuleRepo, NewAuthorizer(&mockRepo, &mockMemberRepo))
+
+	// restaurant 1 is owned by user 1
+	mockRepo.On("FindRestaurantById", mock.Anything, 1).
+		Return(domain.Restaurant{ID: 1, Name: "Restaurant 1", OwnerID: 1}, nil).Maybe()
+
+	ctx := authctx.WithUserClaims(t.Context(), &authctx.UserClaims{UserID: 1, Role: domain.OWNER})
+
+	mockTaxRuleRepo.On("DeleteTaxRule", mock.Anything, 1, "alcohol").Return(true, nil)
+	mockTaxRuleRepo.On("DeleteTaxRule", mock.Anything, 1, "food").Return(false, nil)
+
+	err := service.DeleteTaxRule(ctx, 1, "alcohol")
+	require.NoError(t, err)
+
+	err = service.DeleteTaxRule(ctx, 1, "food")
+	require.True(t, apperr.IsNotFoundError(err), "expected not found error but got %v", err)
+	mockTaxRuleRepo.AssertExpectations(t)
+}
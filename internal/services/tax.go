@@ -0,0 +1,28 @@
+package services
+
+import (
+	"context"
+
+	"github.com/mohits-git/food-ordering-system/internal/domain"
+	"github.com/mohits-git/food-ordering-system/internal/ports"
+)
+
+// RuleTaxCalculator calculates tax from the rules configured for a
+// restaurant, see domain.SelectTaxRule.
+type RuleTaxCalculator struct {
+	taxRuleRepo ports.TaxRuleRepository
+}
+
+func NewRuleTaxCalculator(taxRuleRepo ports.TaxRuleRepository) *RuleTaxCalculator {
+	return &RuleTaxCalculator{taxRuleRepo: taxRuleRepo}
+}
+
+func (c *RuleTaxCalculator) CalculateTax(ctx context.Context, restaurantId int, category string, amount domain.Money) (domain.Money, domain.Money, error) {
+	rules, err := c.taxRuleRepo.FindTaxRulesByRestaurantId(ctx, restaurantId)
+	if err != nil {
+		return domain.Money{}, domain.Money{}, err
+	}
+	rule := domain.SelectTaxRule(rules, restaurantId, category)
+	net, tax := rule.Apply(amount)
+	return net, tax, nil
+}
@@ -0,0 +1,57 @@
+package services
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/mohits-git/food-ordering-system/internal/domain"
+	mockrepository "github.com/mohits-git/food-ordering-system/tests/mock_repository"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_services_RuleTaxCalculator_CalculateTax(t *testing.T) {
+	mockTaxRuleRepo := mockrepository.TaxRuleRepository{}
+	calculator := NewRuleTaxCalculator(&mockTaxRuleRepo)
+
+	mockTaxRuleRepo.On("FindTaxRulesByRestaurantId", mock.Anything, 5).
+		Return([]domain.TaxRule{
+			{ID: 1, Rate: 0.10},
+			{ID: 2, RestaurantID: 5, Category: "alcohol", Rate: 0.20, Inclusive: true},
+		}, nil)
+
+	net, tax, err := calculator.CalculateTax(t.Context(), 5, "alcohol", domain.NewMoney(1200, "USD"))
+	require.NoError(t, err)
+	require.Equal(t, domain.NewMoney(1000, "USD"), net)
+	require.Equal(t, domain.NewMoney(200, "USD"), tax)
+
+	net, tax, err = calculator.CalculateTax(t.Context(), 5, "food", domain.NewMoney(1200, "USD"))
+	require.NoError(t, err)
+	require.Equal(t, domain.NewMoney(1200, "USD"), net)
+	require.Equal(t, domain.NewMoney(120, "USD"), tax)
+	mockTaxRuleRepo.AssertExpectations(t)
+}
+
+func Test_services_RuleTaxCalculator_CalculateTax_when_no_rules(t *testing.T) {
+	mockTaxRuleRepo := mockrepository.TaxRuleRepository{}
+	calculator := NewRuleTaxCalculator(&mockTaxRuleRepo)
+
+	mockTaxRuleRepo.On("FindTaxRulesByRestaurantId", mock.Anything, 1).
+		Return([]domain.TaxRule{}, nil)
+
+	net, tax, err := calculator.CalculateTax(t.Context(), 1, "", domain.NewMoney(10000, "USD"))
+	require.NoError(t, err)
+	require.Equal(t, domain.NewMoney(10000, "USD"), net)
+	require.Equal(t, domain.NewMoney(1000, "USD"), tax)
+}
+
+func Test_services_RuleTaxCalculator_CalculateTax_when_error(t *testing.T) {
+	mockTaxRuleRepo := mockrepository.TaxRuleRepository{}
+	calculator := NewRuleTaxCalculator(&mockTaxRuleRepo)
+
+	mockTaxRuleRepo.On("FindTaxRulesByRestaurantId", mock.Anything, 1).
+		Return([]domain.TaxRule{}, errors.New("db error"))
+
+	_, _, err := calculator.CalculateTax(t.Context(), 1, "", domain.NewMoney(10000, "USD"))
+	require.Error(t, err)
+}
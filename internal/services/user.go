@@ -9,18 +9,58 @@ import (
 )
 
 type UserSerivce struct {
-	repo           ports.UserRepository
-	passwordHasher ports.PasswordHasher
+	repo             ports.UserRepository
+	refreshTokenRepo ports.RefreshTokenRepository
+	passwordHasher   ports.PasswordHasher
+	authorizer       ports.Authorizer
 }
 
-func NewUserService(repo ports.UserRepository, passwordHasher ports.PasswordHasher) ports.UserService {
+func NewUserService(repo ports.UserRepository, refreshTokenRepo ports.RefreshTokenRepository, passwordHasher ports.PasswordHasher, authorizer ports.Authorizer) ports.UserService {
 	return &UserSerivce{
-		repo:           repo,
-		passwordHasher: passwordHasher,
+		repo:             repo,
+		refreshTokenRepo: refreshTokenRepo,
+		passwordHasher:   passwordHasher,
+		authorizer:       authorizer,
 	}
 }
 
+// RegisterUser signs up a new user from the public registration. Customers
+// can use their account straight away, owners have to be approved by an admin
+// first and admins cannot register themselves.
+func (s *UserSerivce) RegisterUser(ctx context.Context, user domain.User) (int, error) {
+	if user.Role == "" {
+		user.Role = domain.CUSTOMER
+	}
+	if user.Role == domain.ADMIN {
+		return 0, apperr.NewAppError(apperr.ErrForbidden, "admin accounts cannot be registered", nil)
+	}
+	user.Suspended = false
+	// owners and staff work with other people's orders and money, an admin
+	// approves them before they can log in
+	user.PendingApproval = user.Role == domain.OWNER || user.Role == domain.STAFF
+	return s.createUser(ctx, user)
+}
+
+// CreateUser creates a user with any role, for admins.
 func (s *UserSerivce) CreateUser(ctx context.Context, user domain.User) (int, error) {
+	if _, err := s.authorizer.Authorize(ctx, domain.ActionManageUsers); err != nil {
+		return 0, err
+	}
+	user.Suspended = false
+	user.PendingApproval = false
+	return s.createUser(ctx, user)
+}
+
+// CreateAdmin creates an admin without an authenticated caller, it is only
+// used to bootstrap the first admin from the command line.
+func (s *UserSerivce) CreateAdmin(ctx context.Context, user domain.User) (int, error) {
+	user.Role = domain.ADMIN
+	user.Suspended = false
+	user.PendingApproval = false
+	return s.createUser(ctx, user)
+}
+
+func (s *UserSerivce) createUser(ctx context.Context, user domain.User) (int, error) {
 	if ok := user.Validate(); !ok {
 		return 0, apperr.NewAppError(apperr.ErrInvalid, "invalid user data", nil)
 	}
@@ -45,3 +85,109 @@ func (s *UserSerivce) GetUserById(ctx context.Context, id int) (domain.User, err
 	}
 	return user, nil
 }
+
+const (
+	defaultUsersPageSize = 20
+	maxUsersPageSize     = 100
+)
+
+// ListUsers searches users by name or email, for admins.
+func (s *UserSerivce) ListUsers(ctx context.Context, filter domain.UserFilter) ([]domain.User, int, error) {
+	if filter.Cursor < 0 || filter.Limit < 0 {
+		return nil, 0, apperr.NewAppError(apperr.ErrInvalid, "invalid user filter", nil)
+	}
+	if filter.Role != "" && !filter.Role.IsValid() {
+		return nil, 0, apperr.NewAppError(apperr.ErrInvalid, "invalid user role", nil)
+	}
+	if _, err := s.authorizer.Authorize(ctx, domain.ActionManageUsers); err != nil {
+		return nil, 0, err
+	}
+
+	pageSize := filter.Limit
+	if pageSize == 0 {
+		pageSize = defaultUsersPageSize
+	}
+	pageSize = min(pageSize, maxUsersPageSize)
+
+	// fetch one extra user to know if there is a next page
+	filter.Limit = pageSize + 1
+	users, err := s.repo.FindUsers(ctx, filter)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	nextCursor := 0
+	if len(users) > pageSize {
+		users = users[:pageSize]
+		nextCursor = users[pageSize-1].ID
+	}
+	return users, nextCursor, nil
+}
+
+func (s *UserSerivce) ChangeUserRole(ctx context.Context, id int, role domain.UserRole) error {
+	if id <= 0 || !role.IsValid() {
+		return apperr.NewAppError(apperr.ErrInvalid, "invalid input data", nil)
+	}
+	if err := s.checkOtherUser(ctx, id); err != nil {
+		return err
+	}
+	return s.repo.UpdateUserRole(ctx, id, role)
+}
+
+// SetUserSuspended suspends or reinstates a user. Suspending also ends the
+// sessions of the user, access tokens already issued run out on their own.
+func (s *UserSerivce) SetUserSuspended(ctx context.Context, id int, suspended bool) error {
+	if id <= 0 {
+		return apperr.NewAppError(apperr.ErrInvalid, "invalid user id", nil)
+	}
+	if err := s.checkOtherUser(ctx, id); err != nil {
+		return err
+	}
+	if err := s.repo.UpdateUserSuspended(ctx, id, suspended); err != nil {
+		return err
+	}
+	if !suspended {
+		return nil
+	}
+	return s.refreshTokenRepo.RevokeUserSessions(ctx, id)
+}
+
+func (s *UserSerivce) ApproveUser(ctx context.Context, id int) error {
+	if id <= 0 {
+		return apperr.NewAppError(apperr.ErrInvalid, "invalid user id", nil)
+	}
+	if _, err := s.authorizer.Authorize(ctx, domain.ActionManageUsers); err != nil {
+		return err
+	}
+	user, err := s.repo.FindUserById(ctx, id)
+	if err != nil {
+		return err
+	}
+	if user.ID == 0 {
+		return apperr.NewAppError(apperr.ErrNotFound, "user not found", nil)
+	}
+	if !user.PendingApproval {
+		return apperr.NewAppError(apperr.ErrInvalid, "user is not pending approval", nil)
+	}
+	return s.repo.ApproveUser(ctx, id)
+}
+
+// checkOtherUser authorizes an admin to manage the user with the given id,
+// admins cannot change their own account so there is always one admin left.
+func (s *UserSerivce) checkOtherUser(ctx context.Context, id int) error {
+	admin, err := s.authorizer.Authorize(ctx, domain.ActionManageUsers)
+	if err != nil {
+		return err
+	}
+	if admin.UserID == id {
+		return apperr.NewAppError(apperr.ErrInvalid, "admins cannot change their own account", nil)
+	}
+	user, err := s.repo.FindUserById(ctx, id)
+	if err != nil {
+		return err
+	}
+	if user.ID == 0 {
+		return apperr.NewAppError(apperr.ErrNotFound, "user not found", nil)
+	}
+	return nil
+}
@@ -6,6 +6,7 @@ import (
 
 	"github.com/mohits-git/food-ordering-system/internal/domain"
 	"github.com/mohits-git/food-ordering-system/internal/utils/apperr"
+	"github.com/mohits-git/food-ordering-system/internal/utils/authctx"
 	mockpasswordhasher "github.com/mohits-git/food-ordering-system/tests/mock_password_hasher"
 	mockrepository "github.com/mohits-git/food-ordering-system/tests/mock_repository"
 	"github.com/stretchr/testify/assert"
@@ -15,18 +16,20 @@ import (
 
 func Test_sqlite_NewUserService(t *testing.T) {
 	mockRepo := mockrepository.UserRepository{}
+	mockRefreshTokenRepo := mockrepository.RefreshTokenRepository{}
 	mockPasswordHasher := mockpasswordhasher.PasswordHasher{}
-	userSerivce := NewUserService(&mockRepo, &mockPasswordHasher)
+	userSerivce := NewUserService(&mockRepo, &mockRefreshTokenRepo, &mockPasswordHasher, NewAuthorizer(&mockrepository.RestaurantRepository{}, &mockrepository.RestaurantMemberRepository{}))
 	require.NotNil(t, userSerivce, "required NewUserService() to return non-nil value but got nil")
 	_, ok := userSerivce.(*UserSerivce)
 	require.True(t, ok, "required sqlite.NewUserSerivce() to return sqlite repository but got some tother type")
 }
 
-func Test_sqlite_CreateUser_when_valid_user(t *testing.T) {
+func Test_sqlite_RegisterUser_when_valid_user(t *testing.T) {
 	// build/mock
 	mockRepo := mockrepository.UserRepository{}
+	mockRefreshTokenRepo := mockrepository.RefreshTokenRepository{}
 	mockPasswordHasher := mockpasswordhasher.PasswordHasher{}
-	userService := NewUserService(&mockRepo, &mockPasswordHasher)
+	userService := NewUserService(&mockRepo, &mockRefreshTokenRepo, &mockPasswordHasher, NewAuthorizer(&mockrepository.RestaurantRepository{}, &mockrepository.RestaurantMemberRepository{}))
 	user := domain.User{
 		Name:     "Test User",
 		Email:    "test@example.com",
@@ -44,22 +47,23 @@ func Test_sqlite_CreateUser_when_valid_user(t *testing.T) {
 	})).Return(1, nil)
 
 	// call
-	id, err := userService.CreateUser(context.TODO(), user)
+	id, err := userService.RegisterUser(context.TODO(), user)
 
 	// assertions
 	ok := mockPasswordHasher.AssertExpectations(t)
 	assert.True(t, ok, "expected all expectations to be met for mockPasswordHasher but some were not")
 	ok = mockRepo.AssertExpectations(t)
 	assert.True(t, ok, "expected all expectations to be met for mockRepo but some were not")
-	assert.NoError(t, err, "expected CreateUser() to not return error but got %v", err)
-	assert.Equal(t, 1, id, "expected CreateUser() to return id 1 but got %d", id)
+	assert.NoError(t, err, "expected RegisterUser() to not return error but got %v", err)
+	assert.Equal(t, 1, id, "expected RegisterUser() to return id 1 but got %d", id)
 }
 
-func Test_sqlite_CreateUser_when_invalid_user(t *testing.T) {
+func Test_sqlite_RegisterUser_when_invalid_user(t *testing.T) {
 	// build/mock
 	mockRepo := mockrepository.UserRepository{}
+	mockRefreshTokenRepo := mockrepository.RefreshTokenRepository{}
 	mockPasswordHasher := mockpasswordhasher.PasswordHasher{}
-	userService := NewUserService(&mockRepo, &mockPasswordHasher)
+	userService := NewUserService(&mockRepo, &mockRefreshTokenRepo, &mockPasswordHasher, NewAuthorizer(&mockrepository.RestaurantRepository{}, &mockrepository.RestaurantMemberRepository{}))
 	user := domain.User{
 		Name:     "",
 		Email:    "test@example.com",
@@ -68,21 +72,22 @@ func Test_sqlite_CreateUser_when_invalid_user(t *testing.T) {
 	}
 
 	// call
-	id, err := userService.CreateUser(context.TODO(), user)
+	id, err := userService.RegisterUser(context.TODO(), user)
 
 	// assertions
-	assert.Equal(t, 0, id, "expected CreateUser() to return id 0 but got %d", id)
-	require.Error(t, err, "expected CreateUser() to return error but got nil")
+	assert.Equal(t, 0, id, "expected RegisterUser() to return id 0 but got %d", id)
+	require.Error(t, err, "expected RegisterUser() to return error but got nil")
 	appErr, ok := err.(*apperr.AppError)
 	require.True(t, ok, "expected error to be of type *apperr.AppError but got %T", err)
 	assert.Equal(t, apperr.ErrInvalid, appErr.Code, "expected error code to be apperr.ErrInvalid but got %s", appErr.Code)
 }
 
-func Test_sqlite_CreateUser_when_password_too_long(t *testing.T) {
+func Test_sqlite_RegisterUser_when_password_too_long(t *testing.T) {
 	// build/mock
 	mockRepo := mockrepository.UserRepository{}
+	mockRefreshTokenRepo := mockrepository.RefreshTokenRepository{}
 	mockPasswordHasher := mockpasswordhasher.PasswordHasher{}
-	userService := NewUserService(&mockRepo, &mockPasswordHasher)
+	userService := NewUserService(&mockRepo, &mockRefreshTokenRepo, &mockPasswordHasher, NewAuthorizer(&mockrepository.RestaurantRepository{}, &mockrepository.RestaurantMemberRepository{}))
 	user := domain.User{
 		Name:     "Test User",
 		Email:    "test@example.com",
@@ -93,22 +98,23 @@ func Test_sqlite_CreateUser_when_password_too_long(t *testing.T) {
 	mockPasswordHasher.On("HashPassword", user.Password).Return("", apperr.NewAppError(apperr.ErrInvalid, "password too long", nil))
 
 	// call
-	id, err := userService.CreateUser(context.TODO(), user)
+	id, err := userService.RegisterUser(context.TODO(), user)
 
 	// assertions
 	ok := mockPasswordHasher.AssertExpectations(t)
-	assert.Equal(t, 0, id, "expected CreateUser() to return id 0 but got %d", id)
-	require.Error(t, err, "expected CreateUser() to return error but got nil")
+	assert.Equal(t, 0, id, "expected RegisterUser() to return id 0 but got %d", id)
+	require.Error(t, err, "expected RegisterUser() to return error but got nil")
 	appErr, ok := err.(*apperr.AppError)
 	require.True(t, ok, "expected error to be of type *apperr.AppError but got %T", err)
 	assert.Equal(t, apperr.ErrInvalid, appErr.Code, "expected error code to be apperr.ErrInvalid but got %s", appErr.Code)
 }
 
-func Test_sqlite_CreateUser_when_email_already_exists(t *testing.T) {
+func Test_sqlite_RegisterUser_when_email_already_exists(t *testing.T) {
 	// build/mock
 	mockRepo := mockrepository.UserRepository{}
+	mockRefreshTokenRepo := mockrepository.RefreshTokenRepository{}
 	mockPasswordHasher := mockpasswordhasher.PasswordHasher{}
-	userService := NewUserService(&mockRepo, &mockPasswordHasher)
+	userService := NewUserService(&mockRepo, &mockRefreshTokenRepo, &mockPasswordHasher, NewAuthorizer(&mockrepository.RestaurantRepository{}, &mockrepository.RestaurantMemberRepository{}))
 	user := domain.User{
 		Name:     "Test User",
 		Email:    "test@example.com",
@@ -125,15 +131,15 @@ func Test_sqlite_CreateUser_when_email_already_exists(t *testing.T) {
 	})).Return(0, apperr.NewAppError(apperr.ErrConflict, "email already exists", nil))
 
 	// call
-	id, err := userService.CreateUser(context.TODO(), user)
+	id, err := userService.RegisterUser(context.TODO(), user)
 
 	// assertions
 	ok := mockPasswordHasher.AssertExpectations(t)
 	assert.True(t, ok, "expected all expectations to be met for mockPasswordHasher but some were not")
 	ok = mockRepo.AssertExpectations(t)
 	assert.True(t, ok, "expected all expectations to be met for mockRepo but some were not")
-	assert.Equal(t, 0, id, "expected CreateUser() to return id 0 but got %d", id)
-	require.Error(t, err, "expected CreateUser() to return error but got nil")
+	assert.Equal(t, 0, id, "expected RegisterUser() to return id 0 but got %d", id)
+	require.Error(t, err, "expected RegisterUser() to return error but got nil")
 	appErr, ok := err.(*apperr.AppError)
 	require.True(t, ok, "expected error to be of type *apperr.AppError but got %T", err)
 	assert.Equal(t, apperr.ErrConflict, appErr.Code, "expected error code to be apperr.ErrConflict but got %s", appErr.Code)
@@ -142,8 +148,9 @@ func Test_sqlite_CreateUser_when_email_already_exists(t *testing.T) {
 func Test_sqlite_GetUserById_when_user_exists(t *testing.T) {
 	// build/mock
 	mockRepo := mockrepository.UserRepository{}
+	mockRefreshTokenRepo := mockrepository.RefreshTokenRepository{}
 	mockPasswordHasher := mockpasswordhasher.PasswordHasher{}
-	userService := NewUserService(&mockRepo, &mockPasswordHasher)
+	userService := NewUserService(&mockRepo, &mockRefreshTokenRepo, &mockPasswordHasher, NewAuthorizer(&mockrepository.RestaurantRepository{}, &mockrepository.RestaurantMemberRepository{}))
 	user := domain.User{
 		ID:       1,
 		Name:     "Test User",
@@ -165,8 +172,9 @@ func Test_sqlite_GetUserById_when_user_exists(t *testing.T) {
 func Test_sqlite_GetUserById_when_user_not_exists(t *testing.T) {
 	// build/mock
 	mockRepo := mockrepository.UserRepository{}
+	mockRefreshTokenRepo := mockrepository.RefreshTokenRepository{}
 	mockPasswordHasher := mockpasswordhasher.PasswordHasher{}
-	userService := NewUserService(&mockRepo, &mockPasswordHasher)
+	userService := NewUserService(&mockRepo, &mockRefreshTokenRepo, &mockPasswordHasher, NewAuthorizer(&mockrepository.RestaurantRepository{}, &mockrepository.RestaurantMemberRepository{}))
 
 	// passing context as mock.Anything
 	mockRepo.On("FindUserById", mock.Anything, 1).Return(domain.User{}, apperr.NewAppError(apperr.ErrNotFound, "user not found", nil))
@@ -181,3 +189,283 @@ func Test_sqlite_GetUserById_when_user_not_exists(t *testing.T) {
 	require.True(t, ok, "expected error to be of type *apperr.AppError but got %T", err)
 	assert.Equal(t, apperr.ErrNotFound, appErr.Code, "expected error code to be apperr.ErrNotFound but got %s", appErr.Code)
 }
+
+func Test_services_UserService_ListUsers(t *testing.T) {
+	mockRepo := mockrepository.UserRepository{}
+	mockRefreshTokenRepo := mockrepository.RefreshTokenRepository{}
+	mockPasswordHasher := mockpasswordhasher.PasswordHasher{}
+	userService := NewUserService(&mockRepo, &mockRefreshTokenRepo, &mockPasswordHasher, NewAuthorizer(&mockrepository.RestaurantRepository{}, &mockrepository.RestaurantMemberRepository{}))
+
+	adminCtx := authctx.WithUserClaims(t.Context(), &authctx.UserClaims{UserID: 9, Role: domain.ADMIN})
+
+	mockRepo.On("FindUsers", mock.Anything, domain.UserFilter{Query: "jo", Role: domain.OWNER, Limit: 3}).
+		Return([]domain.User{{ID: 8}, {ID: 5}, {ID: 2}}, nil)
+
+	users, nextCursor, err := userService.ListUsers(adminCtx, domain.UserFilter{Query: "jo", Role: domain.OWNER, Limit: 2})
+	require.NoError(t, err)
+	require.Len(t, users, 2)
+	require.Equal(t, 5, nextCursor)
+	mockRepo.AssertExpectations(t)
+}
+
+func Test_services_UserService_ListUsers_when_not_admin(t *testing.T) {
+	mockRepo := mockrepository.UserRepository{}
+	mockRefreshTokenRepo := mockrepository.RefreshTokenRepository{}
+	mockPasswordHasher := mockpasswordhasher.PasswordHasher{}
+	userService := NewUserService(&mockRepo, &mockRefreshTokenRepo, &mockPasswordHasher, NewAuthorizer(&mockrepository.RestaurantRepository{}, &mockrepository.RestaurantMemberRepository{}))
+
+	userCtx := authctx.WithUserClaims(t.Context(), &authctx.UserClaims{UserID: 1, Role: domain.OWNER})
+
+	users, _, err := userService.ListUsers(userCtx, domain.UserFilter{})
+	require.True(t, apperr.IsForbiddenError(err))
+	require.Nil(t, users)
+	mockRepo.AssertNotCalled(t, "FindUsers", mock.Anything, mock.Anything)
+}
+
+func Test_services_UserService_ListUsers_when_invalid_role(t *testing.T) {
+	mockRepo := mockrepository.UserRepository{}
+	mockRefreshTokenRepo := mockrepository.RefreshTokenRepository{}
+	mockPasswordHasher := mockpasswordhasher.PasswordHasher{}
+	userService := NewUserService(&mockRepo, &mockRefreshTokenRepo, &mockPasswordHasher, NewAuthorizer(&mockrepository.RestaurantRepository{}, &mockrepository.RestaurantMemberRepository{}))
+
+	adminCtx := authctx.WithUserClaims(t.Context(), &authctx.UserClaims{UserID: 9, Role: domain.ADMIN})
+
+	_, _, err := userService.ListUsers(adminCtx, domain.UserFilter{Role: "root"})
+	require.True(t, apperr.IsInvalidError(err))
+	mockRepo.AssertNotCalled(t, "FindUsers", mock.Anything, mock.Anything)
+}
+
+func Test_services_UserService_ChangeUserRole(t *testing.T) {
+	mockRepo := mockrepository.UserRepository{}
+	mockRefreshTokenRepo := mockrepository.RefreshTokenRepository{}
+	mockPasswordHasher := mockpasswordhasher.PasswordHasher{}
+	userService := NewUserService(&mockRepo, &mockRefreshTokenRepo, &mockPasswordHasher, NewAuthorizer(&mockrepository.RestaurantRepository{}, &mockrepository.RestaurantMemberRepository{}))
+
+	adminCtx := authctx.WithUserClaims(t.Context(), &authctx.UserClaims{UserID: 9, Role: domain.ADMIN})
+
+	mockRepo.On("FindUserById", mock.Anything, 1).Return(domain.User{ID: 1, Role: domain.CUSTOMER}, nil)
+	mockRepo.On("UpdateUserRole", mock.Anything, 1, domain.OWNER).Return(nil)
+
+	err := userService.ChangeUserRole(adminCtx, 1, domain.OWNER)
+	require.NoError(t, err)
+	mockRepo.AssertExpectations(t)
+}
+
+func Test_services_UserService_ChangeUserRole_when_self(t *testing.T) {
+	mockRepo := mockrepository.UserRepository{}
+	mockRefreshTokenRepo := mockrepository.RefreshTokenRepository{}
+	mockPasswordHasher := mockpasswordhasher.PasswordHasher{}
+	userService := NewUserService(&mockRepo, &mockRefreshTokenRepo, &mockPasswordHasher, NewAuthorizer(&mockrepository.RestaurantRepository{}, &mockrepository.RestaurantMemberRepository{}))
+
+	adminCtx := authctx.WithUserClaims(t.Context(), &authctx.UserClaims{UserID: 9, Role: domain.ADMIN})
+
+	err := userService.ChangeUserRole(adminCtx, 9, domain.CUSTOMER)
+	require.True(t, apperr.IsInvalidError(err))
+	mockRepo.AssertNotCalled(t, "UpdateUserRole", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func Test_services_UserService_ChangeUserRole_when_not_found(t *testing.T) {
+	mockRepo := mockrepository.UserRepository{}
+	mockRefreshTokenRepo := mockrepository.RefreshTokenRepository{}
+	mockPasswordHasher := mockpasswordhasher.PasswordHasher{}
+	userService := NewUserService(&mockRepo, &mockRefreshTokenRepo, &mockPasswordHasher, NewAuthorizer(&mockrepository.RestaurantRepository{}, &mockrepository.RestaurantMemberRepository{}))
+
+	adminCtx := authctx.WithUserClaims(t.Context(), &authctx.UserClaims{UserID: 9, Role: domain.ADMIN})
+
+	mockRepo.On("FindUserById", mock.Anything, 1).Return(domain.User{}, apperr.NewAppError(apperr.ErrNotFound, "user not found", nil))
+
+	err := userService.ChangeUserRole(adminCtx, 1, domain.OWNER)
+	require.True(t, apperr.IsNotFoundError(err))
+	mockRepo.AssertNotCalled(t, "UpdateUserRole", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func Test_services_UserService_SetUserSuspended(t *testing.T) {
+	mockRepo := mockrepository.UserRepository{}
+	mockRefreshTokenRepo := mockrepository.RefreshTokenRepository{}
+	mockPasswordHasher := mockpasswordhasher.PasswordHasher{}
+	userService := NewUserService(&mockRepo, &mockRefreshTokenRepo, &mockPasswordHasher, NewAuthorizer(&mockrepository.RestaurantRepository{}, &mockrepository.RestaurantMemberRepository{}))
+
+	adminCtx := authctx.WithUserClaims(t.Context(), &authctx.UserClaims{UserID: 9, Role: domain.ADMIN})
+
+	mockRepo.On("FindUserById", mock.Anything, 1).Return(domain.User{ID: 1, Role: domain.CUSTOMER}, nil)
+	mockRepo.On("UpdateUserSuspended", mock.Anything, 1, true).Return(nil)
+	mockRefreshTokenRepo.On("RevokeUserSessions", mock.Anything, 1).Return(nil)
+
+	err := userService.SetUserSuspended(adminCtx, 1, true)
+	require.NoError(t, err)
+	mockRepo.AssertExpectations(t)
+	mockRefreshTokenRepo.AssertExpectations(t)
+}
+
+func Test_services_UserService_SetUserSuspended_when_reinstated(t *testing.T) {
+	mockRepo := mockrepository.UserRepository{}
+	mockRefreshTokenRepo := mockrepository.RefreshTokenRepository{}
+	mockPasswordHasher := mockpasswordhasher.PasswordHasher{}
+	userService := NewUserService(&mockRepo, &mockRefreshTokenRepo, &mockPasswordHasher, NewAuthorizer(&mockrepository.RestaurantRepository{}, &mockrepository.RestaurantMemberRepository{}))
+
+	adminCtx := authctx.WithUserClaims(t.Context(), &authctx.UserClaims{UserID: 9, Role: domain.ADMIN})
+
+	mockRepo.On("FindUserById", mock.Anything, 1).Return(domain.User{ID: 1, Role: domain.CUSTOMER, Suspended: true}, nil)
+	mockRepo.On("UpdateUserSuspended", mock.Anything, 1, false).Return(nil)
+
+	err := userService.SetUserSuspended(adminCtx, 1, false)
+	require.NoError(t, err)
+	mockRepo.AssertExpectations(t)
+	mockRefreshTokenRepo.AssertNotCalled(t, "RevokeUserSessions", mock.Anything, mock.Anything)
+}
+
+func Test_services_UserService_SetUserSuspended_when_not_admin(t *testing.T) {
+	mockRepo := mockrepository.UserRepository{}
+	mockRefreshTokenRepo := mockrepository.RefreshTokenRepository{}
+	mockPasswordHasher := mockpasswordhasher.PasswordHasher{}
+	userService := NewUserService(&mockRepo, &mockRefreshTokenRepo, &mockPasswordHasher, NewAuthorizer(&mockrepository.RestaurantRepository{}, &mockrepository.RestaurantMemberRepository{}))
+
+	userCtx := authctx.WithUserClaims(t.Context(), &authctx.UserClaims{UserID: 1, Role: domain.CUSTOMER})
+
+	err := userService.SetUserSuspended(userCtx, 2, true)
+	require.True(t, apperr.IsForbiddenError(err))
+	mockRepo.AssertNotCalled(t, "UpdateUserSuspended", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func Test_services_UserService_RegisterUser_when_owner(t *testing.T) {
+	mockRepo := mockrepository.UserRepository{}
+	mockRefreshTokenRepo := mockrepository.RefreshTokenRepository{}
+	mockPasswordHasher := mockpasswordhasher.PasswordHasher{}
+	userService := NewUserService(&mockRepo, &mockRefreshTokenRepo, &mockPasswordHasher, NewAuthorizer(&mockrepository.RestaurantRepository{}, &mockrepository.RestaurantMemberRepository{}))
+
+	mockPasswordHasher.On("HashPassword", "12345678").Return("hashedPassword", nil)
+	mockRepo.On("SaveUser", mock.Anything, mock.MatchedBy(func(u domain.User) bool {
+		return u.Role == domain.OWNER && u.PendingApproval
+	})).Return(2, nil)
+
+	id, err := userService.RegisterUser(t.Context(), domain.NewUser(0, "Owner", "owner@example.com", "12345678", domain.OWNER))
+	require.NoError(t, err)
+	require.Equal(t, 2, id)
+	mockRepo.AssertExpectations(t)
+}
+
+func Test_services_UserService_RegisterUser_when_staff(t *testing.T) {
+	mockRepo := mockrepository.UserRepository{}
+	mockRefreshTokenRepo := mockrepository.RefreshTokenRepository{}
+	mockPasswordHasher := mockpasswordhasher.PasswordHasher{}
+	userService := NewUserService(&mockRepo, &mockRefreshTokenRepo, &mockPasswordHasher, NewAuthorizer(&mockrepository.RestaurantRepository{}, &mockrepository.RestaurantMemberRepository{}))
+
+	mockPasswordHasher.On("HashPassword", "12345678").Return("hashedPassword", nil)
+	mockRepo.On("SaveUser", mock.Anything, mock.MatchedBy(func(u domain.User) bool {
+		return u.Role == domain.STAFF && u.PendingApproval
+	})).Return(4, nil)
+
+	id, err := userService.RegisterUser(t.Context(), domain.NewUser(0, "Cook", "cook@example.com", "12345678", domain.STAFF))
+	require.NoError(t, err)
+	require.Equal(t, 4, id)
+	mockRepo.AssertExpectations(t)
+}
+
+func Test_services_UserService_RegisterUser_when_no_role(t *testing.T) {
+	mockRepo := mockrepository.UserRepository{}
+	mockRefreshTokenRepo := mockrepository.RefreshTokenRepository{}
+	mockPasswordHasher := mockpasswordhasher.PasswordHasher{}
+	userService := NewUserService(&mockRepo, &mockRefreshTokenRepo, &mockPasswordHasher, NewAuthorizer(&mockrepository.RestaurantRepository{}, &mockrepository.RestaurantMemberRepository{}))
+
+	mockPasswordHasher.On("HashPassword", "12345678").Return("hashedPassword", nil)
+	mockRepo.On("SaveUser", mock.Anything, mock.MatchedBy(func(u domain.User) bool {
+		return u.Role == domain.CUSTOMER && !u.PendingApproval
+	})).Return(3, nil)
+
+	id, err := userService.RegisterUser(t.Context(), domain.NewUser(0, "Customer", "customer@example.com", "12345678", ""))
+	require.NoError(t, err)
+	require.Equal(t, 3, id)
+	mockRepo.AssertExpectations(t)
+}
+
+func Test_services_UserService_RegisterUser_when_admin(t *testing.T) {
+	mockRepo := mockrepository.UserRepository{}
+	mockRefreshTokenRepo := mockrepository.RefreshTokenRepository{}
+	mockPasswordHasher := mockpasswordhasher.PasswordHasher{}
+	userService := NewUserService(&mockRepo, &mockRefreshTokenRepo, &mockPasswordHasher, NewAuthorizer(&mockrepository.RestaurantRepository{}, &mockrepository.RestaurantMemberRepository{}))
+
+	_, err := userService.RegisterUser(t.Context(), domain.NewUser(0, "Admin", "admin@example.com", "12345678", domain.ADMIN))
+	require.True(t, apperr.IsForbiddenError(err))
+	mockRepo.AssertNotCalled(t, "SaveUser", mock.Anything, mock.Anything)
+}
+
+func Test_services_UserService_CreateUser(t *testing.T) {
+	mockRepo := mockrepository.UserRepository{}
+	mockRefreshTokenRepo := mockrepository.RefreshTokenRepository{}
+	mockPasswordHasher := mockpasswordhasher.PasswordHasher{}
+	userService := NewUserService(&mockRepo, &mockRefreshTokenRepo, &mockPasswordHasher, NewAuthorizer(&mockrepository.RestaurantRepository{}, &mockrepository.RestaurantMemberRepository{}))
+	adminCtx := authctx.WithUserClaims(t.Context(), &authctx.UserClaims{UserID: 9, Role: domain.ADMIN})
+
+	mockPasswordHasher.On("HashPassword", "12345678").Return("hashedPassword", nil)
+	mockRepo.On("SaveUser", mock.Anything, mock.MatchedBy(func(u domain.User) bool {
+		return u.Role == domain.OWNER && !u.PendingApproval
+	})).Return(4, nil)
+
+	id, err := userService.CreateUser(adminCtx, domain.NewUser(0, "Owner", "owner@example.com", "12345678", domain.OWNER))
+	require.NoError(t, err)
+	require.Equal(t, 4, id)
+	mockRepo.AssertExpectations(t)
+}
+
+func Test_services_UserService_CreateUser_when_not_admin(t *testing.T) {
+	mockRepo := mockrepository.UserRepository{}
+	mockRefreshTokenRepo := mockrepository.RefreshTokenRepository{}
+	mockPasswordHasher := mockpasswordhasher.PasswordHasher{}
+	userService := NewUserService(&mockRepo, &mockRefreshTokenRepo, &mockPasswordHasher, NewAuthorizer(&mockrepository.RestaurantRepository{}, &mockrepository.RestaurantMemberRepository{}))
+
+	_, err := userService.CreateUser(t.Context(), domain.NewUser(0, "Admin", "admin@example.com", "12345678", domain.ADMIN))
+	require.True(t, apperr.IsUnauthorizedError(err))
+
+	ownerCtx := authctx.WithUserClaims(t.Context(), &authctx.UserClaims{UserID: 1, Role: domain.OWNER})
+	_, err = userService.CreateUser(ownerCtx, domain.NewUser(0, "Admin", "admin@example.com", "12345678", domain.ADMIN))
+	require.True(t, apperr.IsForbiddenError(err))
+	mockRepo.AssertNotCalled(t, "SaveUser", mock.Anything, mock.Anything)
+}
+
+func Test_services_UserService_CreateAdmin(t *testing.T) {
+	mockRepo := mockrepository.UserRepository{}
+	mockRefreshTokenRepo := mockrepository.RefreshTokenRepository{}
+	mockPasswordHasher := mockpasswordhasher.PasswordHasher{}
+	userService := NewUserService(&mockRepo, &mockRefreshTokenRepo, &mockPasswordHasher, NewAuthorizer(&mockrepository.RestaurantRepository{}, &mockrepository.RestaurantMemberRepository{}))
+
+	mockPasswordHasher.On("HashPassword", "12345678").Return("hashedPassword", nil)
+	mockRepo.On("SaveUser", mock.Anything, mock.MatchedBy(func(u domain.User) bool {
+		return u.Role == domain.ADMIN && u.Password == "hashedPassword"
+	})).Return(1, nil)
+
+	id, err := userService.CreateAdmin(t.Context(), domain.NewUser(0, "Admin", "admin@example.com", "12345678", ""))
+	require.NoError(t, err)
+	require.Equal(t, 1, id)
+	mockRepo.AssertExpectations(t)
+}
+
+func Test_services_UserService_ApproveUser(t *testing.T) {
+	mockRepo := mockrepository.UserRepository{}
+	mockRefreshTokenRepo := mockrepository.RefreshTokenRepository{}
+	mockPasswordHasher := mockpasswordhasher.PasswordHasher{}
+	userService := NewUserService(&mockRepo, &mockRefreshTokenRepo, &mockPasswordHasher, NewAuthorizer(&mockrepository.RestaurantRepository{}, &mockrepository.RestaurantMemberRepository{}))
+
+	adminCtx := authctx.WithUserClaims(t.Context(), &authctx.UserClaims{UserID: 9, Role: domain.ADMIN})
+
+	mockRepo.On("FindUserById", mock.Anything, 2).Return(domain.User{ID: 2, Role: domain.OWNER, PendingApproval: true}, nil)
+	mockRepo.On("ApproveUser", mock.Anything, 2).Return(nil)
+
+	err := userService.ApproveUser(adminCtx, 2)
+	require.NoError(t, err)
+	mockRepo.AssertExpectations(t)
+}
+
+func Test_services_UserService_ApproveUser_when_not_pending(t *testing.T) {
+	mockRepo := mockrepository.UserRepository{}
+	mockRefreshTokenRepo := mockrepository.RefreshTokenRepository{}
+	mockPasswordHasher := mockpasswordhasher.PasswordHasher{}
+	userService := NewUserService(&mockRepo, &mockRefreshTokenRepo, &mockPasswordHasher, NewAuthorizer(&mockrepository.RestaurantRepository{}, &mockrepository.RestaurantMemberRepository{}))
+
+	adminCtx := authctx.WithUserClaims(t.Context(), &authctx.UserClaims{UserID: 9, Role: domain.ADMIN})
+
+	mockRepo.On("FindUserById", mock.Anything, 2).Return(domain.User{ID: 2, Role: domain.OWNER}, nil)
+
+	err := userService.ApproveUser(adminCtx, 2)
+	require.True(t, apperr.IsInvalidError(err))
+	mockRepo.AssertNotCalled(t, "ApproveUser", mock.Anything, mock.Anything)
+}
@@ -2,6 +2,7 @@ package authctx
 
 import (
 	"context"
+	"time"
 
 	"github.com/mohits-git/food-ordering-system/internal/domain"
 )
@@ -13,6 +14,11 @@ const userKey userKeyType = "user"
 type UserClaims struct {
 	UserID int
 	Role   domain.UserRole
+
+	// set on tokens issued by the token provider, used to revoke them
+	TokenID   string
+	SessionID string
+	ExpiresAt time.Time
 }
 
 func NewUserClaims(userID int, role domain.UserRole) UserClaims {
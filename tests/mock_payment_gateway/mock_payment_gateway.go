@@ -0,0 +1,42 @@
+package mockpaymentgateway
+
+import (
+	"context"
+
+	"github.com/mohits-git/food-ordering-system/internal/domain"
+	"github.com/stretchr/testify/mock"
+)
+
+type PaymentGateway struct {
+	mock.Mock
+}
+
+func (g *PaymentGateway) Name() string {
+	args := g.Called()
+	return args.String(0)
+}
+
+func (g *PaymentGateway) Authorize(ctx context.Context, reference string, amount domain.Money) (domain.GatewayResult, error) {
+	args := g.Called(ctx, reference, amount)
+	return args.Get(0).(domain.GatewayResult), args.Error(1)
+}
+
+func (g *PaymentGateway) Capture(ctx context.Context, transactionId string) (domain.GatewayResult, error) {
+	args := g.Called(ctx, transactionId)
+	return args.Get(0).(domain.GatewayResult), args.Error(1)
+}
+
+func (g *PaymentGateway) Refund(ctx context.Context, transactionId string, amount domain.Money) (domain.GatewayResult, error) {
+	args := g.Called(ctx, transactionId, amount)
+	return args.Get(0).(domain.GatewayResult), args.Error(1)
+}
+
+func (g *PaymentGateway) Status(ctx context.Context, transactionId string) (domain.GatewayResult, error) {
+	args := g.Called(ctx, transactionId)
+	return args.Get(0).(domain.GatewayResult), args.Error(1)
+}
+
+func (g *PaymentGateway) ParseWebhook(payload []byte, signature string) (domain.PaymentEvent, error) {
+	args := g.Called(payload, signature)
+	return args.Get(0).(domain.PaymentEvent), args.Error(1)
+}
@@ -0,0 +1,38 @@
+package mockrepository
+
+import (
+	"context"
+	"time"
+
+	"github.com/mohits-git/food-ordering-system/internal/domain"
+	"github.com/stretchr/testify/mock"
+)
+
+type IdempotencyRepository struct {
+	mock.Mock
+}
+
+func (r *IdempotencyRepository) SaveIdempotencyKey(ctx context.Context, key domain.IdempotencyKey) (int, error) {
+	args := r.Called(ctx, key)
+	return args.Int(0), args.Error(1)
+}
+
+func (r *IdempotencyRepository) FindIdempotencyKey(ctx context.Context, userId int, key string) (domain.IdempotencyKey, error) {
+	args := r.Called(ctx, userId, key)
+	return args.Get(0).(domain.IdempotencyKey), args.Error(1)
+}
+
+func (r *IdempotencyRepository) SaveIdempotencyResponse(ctx context.Context, id int, statusCode int, body []byte) error {
+	args := r.Called(ctx, id, statusCode, body)
+	return args.Error(0)
+}
+
+func (r *IdempotencyRepository) DeleteIdempotencyKey(ctx context.Context, id int) error {
+	args := r.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (r *IdempotencyRepository) DeleteExpiredIdempotencyKeys(ctx context.Context, now time.Time) error {
+	args := r.Called(ctx, now)
+	return args.Error(0)
+}
@@ -30,3 +30,8 @@ func (i *InvoiceRepository) ChangeInvoiceStatus(cxt context.Context, invoiceId i
 	args := i.Called(cxt, invoiceId, status)
 	return args.Error(0)
 }
+
+func (i *InvoiceRepository) FindInvoices(ctx context.Context, filter domain.InvoiceFilter) ([]domain.Invoice, error) {
+	args := i.Called(ctx, filter)
+	return args.Get(0).([]domain.Invoice), args.Error(1)
+}
@@ -0,0 +1,37 @@
+package mockrepository
+
+import (
+	"context"
+
+	"github.com/mohits-git/food-ordering-system/internal/domain"
+	"github.com/stretchr/testify/mock"
+)
+
+type MenuRepository struct {
+	mock.Mock
+}
+
+func (m *MenuRepository) SaveMenu(ctx context.Context, menu domain.Menu) (int, error) {
+	args := m.Called(ctx, menu)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MenuRepository) UpdateMenu(ctx context.Context, menu domain.Menu) error {
+	args := m.Called(ctx, menu)
+	return args.Error(0)
+}
+
+func (m *MenuRepository) DeleteMenu(ctx context.Context, id int) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MenuRepository) FindMenuById(ctx context.Context, id int) (domain.Menu, error) {
+	args := m.Called(ctx, id)
+	return args.Get(0).(domain.Menu), args.Error(1)
+}
+
+func (m *MenuRepository) FindMenusByRestaurantId(ctx context.Context, restaurantId int) ([]domain.Menu, error) {
+	args := m.Called(ctx, restaurantId)
+	return args.Get(0).([]domain.Menu), args.Error(1)
+}
@@ -16,11 +16,26 @@ func (m *MenuItemRepository) SaveMenuItem(cxt context.Context, item domain.MenuI
 	return args.Int(0), args.Error(1)
 }
 
+func (m *MenuItemRepository) UpdateMenuItem(cxt context.Context, item domain.MenuItem) error {
+	args := m.Called(cxt, item)
+	return args.Error(0)
+}
+
+func (m *MenuItemRepository) DeleteMenuItem(cxt context.Context, id int) error {
+	args := m.Called(cxt, id)
+	return args.Error(0)
+}
+
 func (m *MenuItemRepository) UpdateMenuItemAvailability(cxt context.Context, id int, available bool) error {
 	args := m.Called(cxt, id, available)
 	return args.Error(0)
 }
 
+func (m *MenuItemRepository) UpdateMenuItemStock(cxt context.Context, id int, trackStock bool, stock int) error {
+	args := m.Called(cxt, id, trackStock, stock)
+	return args.Error(0)
+}
+
 func (m *MenuItemRepository) FindMenuItemsByRestaurantId(cxt context.Context, restaurantId int) ([]domain.MenuItem, error) {
 	args := m.Called(cxt, restaurantId)
 	return args.Get(0).([]domain.MenuItem), args.Error(1)
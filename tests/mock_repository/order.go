@@ -25,3 +25,23 @@ func (o *OrderRepository) UpdateOrder(ctx context.Context, order domain.Order) e
 	args := o.Called(ctx, order)
 	return args.Error(0)
 }
+
+func (o *OrderRepository) UpdateOrderStatus(ctx context.Context, id int, status domain.OrderStatus) error {
+	args := o.Called(ctx, id, status)
+	return args.Error(0)
+}
+
+func (o *OrderRepository) FindOrdersByRestaurantId(ctx context.Context, restaurantId int, statuses []domain.OrderStatus) ([]domain.Order, error) {
+	args := o.Called(ctx, restaurantId, statuses)
+	return args.Get(0).([]domain.Order), args.Error(1)
+}
+
+func (o *OrderRepository) FindOrdersByCustomerId(ctx context.Context, customerId int, filter domain.OrderFilter) ([]domain.Order, error) {
+	args := o.Called(ctx, customerId, filter)
+	return args.Get(0).([]domain.Order), args.Error(1)
+}
+
+func (o *OrderRepository) FindOrders(ctx context.Context, filter domain.OrderFilter) ([]domain.Order, error) {
+	args := o.Called(ctx, filter)
+	return args.Get(0).([]domain.Order), args.Error(1)
+}
@@ -0,0 +1,22 @@
+package mockrepository
+
+import (
+	"context"
+
+	"github.com/mohits-git/food-ordering-system/internal/domain"
+	"github.com/stretchr/testify/mock"
+)
+
+type PaymentRepository struct {
+	mock.Mock
+}
+
+func (r *PaymentRepository) SavePayment(ctx context.Context, payment domain.Payment) (int, error) {
+	args := r.Called(ctx, payment)
+	return args.Int(0), args.Error(1)
+}
+
+func (r *PaymentRepository) FindPaymentsByInvoiceId(ctx context.Context, invoiceId int) ([]domain.Payment, error) {
+	args := r.Called(ctx, invoiceId)
+	return args.Get(0).([]domain.Payment), args.Error(1)
+}
@@ -0,0 +1,32 @@
+package mockrepository
+
+import (
+	"context"
+
+	"github.com/mohits-git/food-ordering-system/internal/domain"
+	"github.com/stretchr/testify/mock"
+)
+
+type PaymentAttemptRepository struct {
+	mock.Mock
+}
+
+func (r *PaymentAttemptRepository) SavePaymentAttempt(ctx context.Context, attempt domain.PaymentAttempt) (int, error) {
+	args := r.Called(ctx, attempt)
+	return args.Int(0), args.Error(1)
+}
+
+func (r *PaymentAttemptRepository) UpdatePaymentAttempt(ctx context.Context, attempt domain.PaymentAttempt) error {
+	args := r.Called(ctx, attempt)
+	return args.Error(0)
+}
+
+func (r *PaymentAttemptRepository) FindPaymentAttemptsByInvoiceId(ctx context.Context, invoiceId int) ([]domain.PaymentAttempt, error) {
+	args := r.Called(ctx, invoiceId)
+	return args.Get(0).([]domain.PaymentAttempt), args.Error(1)
+}
+
+func (r *PaymentAttemptRepository) FindPaymentAttemptByTransactionId(ctx context.Context, provider string, transactionId string) (domain.PaymentAttempt, error) {
+	args := r.Called(ctx, provider, transactionId)
+	return args.Get(0).(domain.PaymentAttempt), args.Error(1)
+}
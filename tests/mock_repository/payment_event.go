@@ -0,0 +1,27 @@
+package mockrepository
+
+import (
+	"context"
+
+	"github.com/mohits-git/food-ordering-system/internal/domain"
+	"github.com/stretchr/testify/mock"
+)
+
+type PaymentEventRepository struct {
+	mock.Mock
+}
+
+func (r *PaymentEventRepository) SavePaymentEvent(ctx context.Context, event domain.PaymentEvent) (int, error) {
+	args := r.Called(ctx, event)
+	return args.Int(0), args.Error(1)
+}
+
+func (r *PaymentEventRepository) FindPaymentEvent(ctx context.Context, provider string, eventId string) (domain.PaymentEvent, error) {
+	args := r.Called(ctx, provider, eventId)
+	return args.Get(0).(domain.PaymentEvent), args.Error(1)
+}
+
+func (r *PaymentEventRepository) UpdatePaymentEventStatus(ctx context.Context, id int, status domain.PaymentEventStatus, errMsg string) error {
+	args := r.Called(ctx, id, status, errMsg)
+	return args.Error(0)
+}
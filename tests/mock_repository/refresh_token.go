@@ -0,0 +1,43 @@
+package mockrepository
+
+import (
+	"context"
+	"time"
+
+	"github.com/mohits-git/food-ordering-system/internal/domain"
+	"github.com/stretchr/testify/mock"
+)
+
+type RefreshTokenRepository struct {
+	mock.Mock
+}
+
+func (r *RefreshTokenRepository) SaveRefreshToken(ctx context.Context, token domain.RefreshToken) (int, error) {
+	args := r.Called(ctx, token)
+	return args.Int(0), args.Error(1)
+}
+
+func (r *RefreshTokenRepository) FindRefreshTokenByHash(ctx context.Context, tokenHash string) (domain.RefreshToken, error) {
+	args := r.Called(ctx, tokenHash)
+	return args.Get(0).(domain.RefreshToken), args.Error(1)
+}
+
+func (r *RefreshTokenRepository) RevokeRefreshToken(ctx context.Context, id int) (bool, error) {
+	args := r.Called(ctx, id)
+	return args.Bool(0), args.Error(1)
+}
+
+func (r *RefreshTokenRepository) RevokeSession(ctx context.Context, sessionId string) error {
+	args := r.Called(ctx, sessionId)
+	return args.Error(0)
+}
+
+func (r *RefreshTokenRepository) RevokeUserSessions(ctx context.Context, userId int) error {
+	args := r.Called(ctx, userId)
+	return args.Error(0)
+}
+
+func (r *RefreshTokenRepository) DeleteExpiredRefreshTokens(ctx context.Context, now time.Time) error {
+	args := r.Called(ctx, now)
+	return args.Error(0)
+}
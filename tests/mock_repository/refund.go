@@ -0,0 +1,22 @@
+package mockrepository
+
+import (
+	"context"
+
+	"github.com/mohits-git/food-ordering-system/internal/domain"
+	"github.com/stretchr/testify/mock"
+)
+
+type RefundRepository struct {
+	mock.Mock
+}
+
+func (r *RefundRepository) SaveRefund(ctx context.Context, refund domain.Refund) (int, error) {
+	args := r.Called(ctx, refund)
+	return args.Int(0), args.Error(1)
+}
+
+func (r *RefundRepository) FindRefundsByInvoiceId(ctx context.Context, invoiceId int) ([]domain.Refund, error) {
+	args := r.Called(ctx, invoiceId)
+	return args.Get(0).([]domain.Refund), args.Error(1)
+}
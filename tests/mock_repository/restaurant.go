@@ -16,8 +16,8 @@ func (r *RestaurantRepository) SaveRestaurant(cxt context.Context, restaurant do
 	return args.Int(0), args.Error(1)
 }
 
-func (r *RestaurantRepository) FindAllRestaurants(cxt context.Context) ([]domain.Restaurant, error) {
-	args := r.Called(cxt)
+func (r *RestaurantRepository) FindRestaurants(cxt context.Context, filter domain.RestaurantFilter) ([]domain.Restaurant, error) {
+	args := r.Called(cxt, filter)
 	return args.Get(0).([]domain.Restaurant), args.Error(1)
 }
 
@@ -25,3 +25,13 @@ func (r *RestaurantRepository) FindRestaurantById(cxt context.Context, id int) (
 	args := r.Called(cxt, id)
 	return args.Get(0).(domain.Restaurant), args.Error(1)
 }
+
+func (r *RestaurantRepository) UpdateRestaurant(cxt context.Context, restaurant domain.Restaurant) error {
+	args := r.Called(cxt, restaurant)
+	return args.Error(0)
+}
+
+func (r *RestaurantRepository) UpdateOrdersPaused(cxt context.Context, id int, paused bool) error {
+	args := r.Called(cxt, id, paused)
+	return args.Error(0)
+}
@@ -0,0 +1,37 @@
+package mockrepository
+
+import (
+	"context"
+
+	"github.com/mohits-git/food-ordering-system/internal/domain"
+	"github.com/stretchr/testify/mock"
+)
+
+type RestaurantMemberRepository struct {
+	mock.Mock
+}
+
+func (r *RestaurantMemberRepository) SaveRestaurantMember(ctx context.Context, member domain.RestaurantMember) error {
+	args := r.Called(ctx, member)
+	return args.Error(0)
+}
+
+func (r *RestaurantMemberRepository) FindRestaurantMember(ctx context.Context, restaurantId int, userId int) (domain.RestaurantMember, error) {
+	args := r.Called(ctx, restaurantId, userId)
+	return args.Get(0).(domain.RestaurantMember), args.Error(1)
+}
+
+func (r *RestaurantMemberRepository) FindRestaurantMembers(ctx context.Context, restaurantId int) ([]domain.RestaurantMember, error) {
+	args := r.Called(ctx, restaurantId)
+	return args.Get(0).([]domain.RestaurantMember), args.Error(1)
+}
+
+func (r *RestaurantMemberRepository) FindMembershipsByUserId(ctx context.Context, userId int) ([]domain.RestaurantMember, error) {
+	args := r.Called(ctx, userId)
+	return args.Get(0).([]domain.RestaurantMember), args.Error(1)
+}
+
+func (r *RestaurantMemberRepository) DeleteRestaurantMember(ctx context.Context, restaurantId int, userId int) (bool, error) {
+	args := r.Called(ctx, restaurantId, userId)
+	return args.Bool(0), args.Error(1)
+}
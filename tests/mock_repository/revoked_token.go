@@ -0,0 +1,27 @@
+package mockrepository
+
+import (
+	"context"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type RevokedTokenRepository struct {
+	mock.Mock
+}
+
+func (r *RevokedTokenRepository) RevokeToken(ctx context.Context, tokenId string, expiresAt time.Time) error {
+	args := r.Called(ctx, tokenId, expiresAt)
+	return args.Error(0)
+}
+
+func (r *RevokedTokenRepository) IsTokenRevoked(ctx context.Context, tokenId string) (bool, error) {
+	args := r.Called(ctx, tokenId)
+	return args.Bool(0), args.Error(1)
+}
+
+func (r *RevokedTokenRepository) DeleteExpiredRevokedTokens(ctx context.Context, now time.Time) error {
+	args := r.Called(ctx, now)
+	return args.Error(0)
+}
@@ -0,0 +1,27 @@
+package mockrepository
+
+import (
+	"context"
+
+	"github.com/mohits-git/food-ordering-system/internal/domain"
+	"github.com/stretchr/testify/mock"
+)
+
+type TaxRuleRepository struct {
+	mock.Mock
+}
+
+func (r *TaxRuleRepository) FindTaxRulesByRestaurantId(ctx context.Context, restaurantId int) ([]domain.TaxRule, error) {
+	args := r.Called(ctx, restaurantId)
+	return args.Get(0).([]domain.TaxRule), args.Error(1)
+}
+
+func (r *TaxRuleRepository) SaveTaxRule(ctx context.Context, rule domain.TaxRule) (int, error) {
+	args := r.Called(ctx, rule)
+	return args.Int(0), args.Error(1)
+}
+
+func (r *TaxRuleRepository) DeleteTaxRule(ctx context.Context, restaurantId int, category string) (bool, error) {
+	args := r.Called(ctx, restaurantId, category)
+	return args.Bool(0), args.Error(1)
+}
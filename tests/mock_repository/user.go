@@ -25,3 +25,23 @@ func (u *UserRepository) SaveUser(ctx context.Context, user domain.User) (int, e
 	args := u.Called(ctx, user)
 	return args.Int(0), args.Error(1)
 }
+
+func (u *UserRepository) FindUsers(ctx context.Context, filter domain.UserFilter) ([]domain.User, error) {
+	args := u.Called(ctx, filter)
+	return args.Get(0).([]domain.User), args.Error(1)
+}
+
+func (u *UserRepository) UpdateUserRole(ctx context.Context, id int, role domain.UserRole) error {
+	args := u.Called(ctx, id, role)
+	return args.Error(0)
+}
+
+func (u *UserRepository) UpdateUserSuspended(ctx context.Context, id int, suspended bool) error {
+	args := u.Called(ctx, id, suspended)
+	return args.Error(0)
+}
+
+func (u *UserRepository) ApproveUser(ctx context.Context, id int) error {
+	args := u.Called(ctx, id)
+	return args.Error(0)
+}
@@ -3,6 +3,8 @@ package mockservice
 import (
 	"context"
 
+	"github.com/mohits-git/food-ordering-system/internal/domain"
+	"github.com/mohits-git/food-ordering-system/internal/utils/authctx"
 	"github.com/stretchr/testify/mock"
 )
 
@@ -10,12 +12,22 @@ type AuthenticationService struct {
 	mock.Mock
 }
 
-func (s *AuthenticationService) Login(ctx context.Context, email, password string) (token string, err error) {
+func (s *AuthenticationService) Login(ctx context.Context, email, password string) (domain.AuthTokens, error) {
 	args := s.Called(ctx, email, password)
-	return args.String(0), args.Error(1)
+	return args.Get(0).(domain.AuthTokens), args.Error(1)
+}
+
+func (s *AuthenticationService) Refresh(ctx context.Context, refreshToken string) (domain.AuthTokens, error) {
+	args := s.Called(ctx, refreshToken)
+	return args.Get(0).(domain.AuthTokens), args.Error(1)
 }
 
 func (s *AuthenticationService) Logout(ctx context.Context, token string) error {
 	args := s.Called(ctx, token)
 	return args.Error(0)
 }
+
+func (s *AuthenticationService) IsTokenRevoked(ctx context.Context, claims authctx.UserClaims) (bool, error) {
+	args := s.Called(ctx, claims)
+	return args.Bool(0), args.Error(1)
+}
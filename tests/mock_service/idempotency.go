@@ -0,0 +1,27 @@
+package mockservice
+
+import (
+	"context"
+
+	"github.com/mohits-git/food-ordering-system/internal/domain"
+	"github.com/stretchr/testify/mock"
+)
+
+type IdempotencyService struct {
+	mock.Mock
+}
+
+func (s *IdempotencyService) StartRequest(ctx context.Context, key string, requestHash string) (domain.IdempotencyKey, error) {
+	args := s.Called(ctx, key, requestHash)
+	return args.Get(0).(domain.IdempotencyKey), args.Error(1)
+}
+
+func (s *IdempotencyService) CompleteRequest(ctx context.Context, id int, statusCode int, body []byte) error {
+	args := s.Called(ctx, id, statusCode, body)
+	return args.Error(0)
+}
+
+func (s *IdempotencyService) ReleaseRequest(ctx context.Context, id int) error {
+	args := s.Called(ctx, id)
+	return args.Error(0)
+}
@@ -21,7 +21,17 @@ func (s *InvoiceService) GetInvoiceById(ctx context.Context, id int) (domain.Inv
 	return args.Get(0).(domain.Invoice), args.Error(1)
 }
 
-func (s *InvoiceService) DoInvoicePayment(ctx context.Context, invoiceId int, payment float64) error {
-	args := s.Called(ctx, invoiceId, payment)
-	return args.Error(0)
+func (s *InvoiceService) GetInvoices(ctx context.Context, filter domain.InvoiceFilter) ([]domain.Invoice, int, error) {
+	args := s.Called(ctx, filter)
+	return args.Get(0).([]domain.Invoice), args.Int(1), args.Error(2)
+}
+
+func (s *InvoiceService) DoInvoicePayment(ctx context.Context, invoiceId int, method domain.PaymentMethod, tendered domain.Money) (domain.PaymentReceipt, error) {
+	args := s.Called(ctx, invoiceId, method, tendered)
+	return args.Get(0).(domain.PaymentReceipt), args.Error(1)
+}
+
+func (s *InvoiceService) RefundInvoice(ctx context.Context, invoiceId int, amount domain.Money, reason string) (domain.Refund, error) {
+	args := s.Called(ctx, invoiceId, amount, reason)
+	return args.Get(0).(domain.Refund), args.Error(1)
 }
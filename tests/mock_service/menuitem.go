@@ -16,12 +16,47 @@ func (s *MenuItemService) CreateMenuItemForRestaurant(ctx context.Context, item
 	return args.Int(0), args.Error(1)
 }
 
-func (s *MenuItemService) GetAllMenuItemsByRestaurantId(ctx context.Context, restaurantId int) ([]domain.MenuItem, error) {
+func (s *MenuItemService) GetAllMenuItemsByRestaurantId(ctx context.Context, restaurantId int) ([]domain.Menu, error) {
 	args := s.Called(ctx, restaurantId)
-	return args.Get(0).([]domain.MenuItem), args.Error(1)
+	return args.Get(0).([]domain.Menu), args.Error(1)
 }
 
 func (s *MenuItemService) UpdateAvailability(ctx context.Context, id int, available bool) error {
 	args := s.Called(ctx, id, available)
 	return args.Error(0)
 }
+
+func (s *MenuItemService) UpdateStock(ctx context.Context, id int, trackStock bool, stock int) error {
+	args := s.Called(ctx, id, trackStock, stock)
+	return args.Error(0)
+}
+
+func (s *MenuItemService) UpdateMenuItem(ctx context.Context, item domain.MenuItem) error {
+	args := s.Called(ctx, item)
+	return args.Error(0)
+}
+
+func (s *MenuItemService) DeleteMenuItem(ctx context.Context, id int) error {
+	args := s.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (s *MenuItemService) GetMenusByRestaurantId(ctx context.Context, restaurantId int) ([]domain.Menu, error) {
+	args := s.Called(ctx, restaurantId)
+	return args.Get(0).([]domain.Menu), args.Error(1)
+}
+
+func (s *MenuItemService) CreateMenu(ctx context.Context, menu domain.Menu) (int, error) {
+	args := s.Called(ctx, menu)
+	return args.Int(0), args.Error(1)
+}
+
+func (s *MenuItemService) UpdateMenu(ctx context.Context, menu domain.Menu) error {
+	args := s.Called(ctx, menu)
+	return args.Error(0)
+}
+
+func (s *MenuItemService) DeleteMenu(ctx context.Context, id int) error {
+	args := s.Called(ctx, id)
+	return args.Error(0)
+}
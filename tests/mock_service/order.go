@@ -25,3 +25,48 @@ func (s *OrderService) AddOrderItem(ctx context.Context, orderId int, item domai
 	args := s.Called(ctx, orderId, item)
 	return args.Error(0)
 }
+
+func (s *OrderService) TransitionOrder(ctx context.Context, orderId int, status domain.OrderStatus) error {
+	args := s.Called(ctx, orderId, status)
+	return args.Error(0)
+}
+
+func (s *OrderService) GetRestaurantOrders(ctx context.Context, restaurantId int, statuses []domain.OrderStatus) ([]domain.Order, error) {
+	args := s.Called(ctx, restaurantId, statuses)
+	return args.Get(0).([]domain.Order), args.Error(1)
+}
+
+func (s *OrderService) GetCustomerOrders(ctx context.Context, filter domain.OrderFilter) ([]domain.Order, int, error) {
+	args := s.Called(ctx, filter)
+	return args.Get(0).([]domain.Order), args.Int(1), args.Error(2)
+}
+
+func (s *OrderService) GetAllOrders(ctx context.Context, filter domain.OrderFilter) ([]domain.Order, int, error) {
+	args := s.Called(ctx, filter)
+	return args.Get(0).([]domain.Order), args.Int(1), args.Error(2)
+}
+
+func (o *OrderService) CancelOrder(ctx context.Context, orderId int) error {
+	args := o.Called(ctx, orderId)
+	return args.Error(0)
+}
+
+func (o *OrderService) UpdateOrderItemQuantity(ctx context.Context, orderId int, menuItemId int, quantity int) error {
+	args := o.Called(ctx, orderId, menuItemId, quantity)
+	return args.Error(0)
+}
+
+func (o *OrderService) UpdateOrderLineQuantity(ctx context.Context, orderId int, lineId int, quantity int) error {
+	args := o.Called(ctx, orderId, lineId, quantity)
+	return args.Error(0)
+}
+
+func (o *OrderService) RemoveOrderItem(ctx context.Context, orderId int, menuItemId int) error {
+	args := o.Called(ctx, orderId, menuItemId)
+	return args.Error(0)
+}
+
+func (o *OrderService) RemoveOrderLine(ctx context.Context, orderId int, lineId int) error {
+	args := o.Called(ctx, orderId, lineId)
+	return args.Error(0)
+}
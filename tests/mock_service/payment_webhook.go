@@ -0,0 +1,16 @@
+package mockservice
+
+import (
+	"context"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type PaymentWebhookService struct {
+	mock.Mock
+}
+
+func (s *PaymentWebhookService) HandlePaymentWebhook(ctx context.Context, payload []byte, signature string) error {
+	args := s.Called(ctx, payload, signature)
+	return args.Error(0)
+}
@@ -16,7 +16,52 @@ func (s *RestaurantService) CreateRestaurant(ctx context.Context, restaurantName
 	return args.Int(0), args.Error(1)
 }
 
-func (s *RestaurantService) GetAllRestaurants(ctx context.Context) ([]domain.Restaurant, error) {
+func (s *RestaurantService) SearchRestaurants(ctx context.Context, filter domain.RestaurantFilter) ([]domain.Restaurant, int, error) {
+	args := s.Called(ctx, filter)
+	return args.Get(0).([]domain.Restaurant), args.Int(1), args.Error(2)
+}
+
+func (s *RestaurantService) GetRestaurantById(ctx context.Context, id int) (domain.Restaurant, error) {
+	args := s.Called(ctx, id)
+	return args.Get(0).(domain.Restaurant), args.Error(1)
+}
+
+func (s *RestaurantService) UpdateRestaurant(ctx context.Context, restaurant domain.Restaurant) error {
+	args := s.Called(ctx, restaurant)
+	return args.Error(0)
+}
+
+func (s *RestaurantService) PauseOrders(ctx context.Context, restaurantId int, paused bool) error {
+	args := s.Called(ctx, restaurantId, paused)
+	return args.Error(0)
+}
+
+func (s *RestaurantService) InviteMember(ctx context.Context, restaurantId int, email string, role domain.StaffRole) (domain.RestaurantMember, error) {
+	args := s.Called(ctx, restaurantId, email, role)
+	return args.Get(0).(domain.RestaurantMember), args.Error(1)
+}
+
+func (s *RestaurantService) RemoveMember(ctx context.Context, restaurantId int, userId int) error {
+	args := s.Called(ctx, restaurantId, userId)
+	return args.Error(0)
+}
+
+func (s *RestaurantService) GetMembers(ctx context.Context, restaurantId int) ([]domain.RestaurantMember, error) {
+	args := s.Called(ctx, restaurantId)
+	return args.Get(0).([]domain.RestaurantMember), args.Error(1)
+}
+
+func (s *RestaurantService) GetMemberships(ctx context.Context) ([]domain.RestaurantMember, error) {
 	args := s.Called(ctx)
-	return args.Get(0).([]domain.Restaurant), args.Error(1)
+	return args.Get(0).([]domain.RestaurantMember), args.Error(1)
+}
+
+func (s *RestaurantService) SetTaxRule(ctx context.Context, rule domain.TaxRule) (domain.TaxRule, error) {
+	args := s.Called(ctx, rule)
+	return args.Get(0).(domain.TaxRule), args.Error(1)
+}
+
+func (s *RestaurantService) DeleteTaxRule(ctx context.Context, restaurantId int, category string) error {
+	args := s.Called(ctx, restaurantId, category)
+	return args.Error(0)
 }
@@ -20,3 +20,33 @@ func (s *UserService) CreateUser(ctx context.Context, user domain.User) (int, er
 	args := s.Called(ctx, user)
 	return args.Int(0), args.Error(1)
 }
+
+func (s *UserService) ListUsers(ctx context.Context, filter domain.UserFilter) ([]domain.User, int, error) {
+	args := s.Called(ctx, filter)
+	return args.Get(0).([]domain.User), args.Int(1), args.Error(2)
+}
+
+func (s *UserService) ChangeUserRole(ctx context.Context, id int, role domain.UserRole) error {
+	args := s.Called(ctx, id, role)
+	return args.Error(0)
+}
+
+func (s *UserService) SetUserSuspended(ctx context.Context, id int, suspended bool) error {
+	args := s.Called(ctx, id, suspended)
+	return args.Error(0)
+}
+
+func (s *UserService) RegisterUser(ctx context.Context, user domain.User) (int, error) {
+	args := s.Called(ctx, user)
+	return args.Int(0), args.Error(1)
+}
+
+func (s *UserService) CreateAdmin(ctx context.Context, user domain.User) (int, error) {
+	args := s.Called(ctx, user)
+	return args.Int(0), args.Error(1)
+}
+
+func (s *UserService) ApproveUser(ctx context.Context, id int) error {
+	args := s.Called(ctx, id)
+	return args.Error(0)
+}
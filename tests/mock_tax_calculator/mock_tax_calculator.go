@@ -0,0 +1,17 @@
+package mocktaxcalculator
+
+import (
+	"context"
+
+	"github.com/mohits-git/food-ordering-system/internal/domain"
+	"github.com/stretchr/testify/mock"
+)
+
+type TaxCalculator struct {
+	mock.Mock
+}
+
+func (c *TaxCalculator) CalculateTax(ctx context.Context, restaurantId int, category string, amount domain.Money) (domain.Money, domain.Money, error) {
+	args := c.Called(ctx, restaurantId, category, amount)
+	return args.Get(0).(domain.Money), args.Get(1).(domain.Money), args.Error(2)
+}